@@ -0,0 +1,783 @@
+// Package client is a Go client for the job portal sandbox API.
+//
+//	c := client.New("http://localhost:8080", client.WithAPIKey(key))
+//	jobs, err := c.ListJobs(ctx, client.JobListOptions{Remote: client.Bool(true)})
+//	...
+//	resp, err := c.SubmitApplication(ctx, client.ApplicationRequest{...})
+//
+// Requests that fail with a 5xx, as the sandbox's failure simulation makes
+// them, are retried with exponential backoff. Requests refused with 429 are
+// retried once the rate limit resets, as its Retry-After header says. Every
+// other error status is returned as an *Error.
+//
+// POST and PATCH requests are not idempotent: an error response does not
+// say whether the request took effect, so they are only retried when no
+// response arrived at all or the rate limiter refused them with 429, before
+// they reached a handler. A response that arrives but cannot be read or
+// decoded is never retried, whatever the method.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/challenge"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+)
+
+// Types of the sandbox API, as its handlers send and receive them
+type (
+	Job                       = models.Job
+	JobStatus                 = models.JobStatus
+	JobsResponse              = models.JobsResponse
+	JobDetailResponse         = models.JobDetailResponse
+	JobSearchResponse         = models.JobSearchResponse
+	ApplicationRequest        = models.ApplicationRequest
+	ApplicationUpdateRequest  = models.ApplicationUpdateRequest
+	ApplicationResponse       = models.ApplicationResponse
+	ApplicationStatus         = models.ApplicationStatus
+	ApplicationStatusResponse = models.ApplicationStatusResponse
+	ApplicationsListResponse  = models.ApplicationsListResponse
+	StatusChange              = models.StatusChange
+	ApplicationTimeline       = models.ApplicationTimeline
+	WithdrawRequest           = models.WithdrawRequest
+	VerifyRequest             = models.VerifyRequest
+	InterviewSlot             = models.InterviewSlot
+	Interview                 = models.Interview
+	InterviewSlotsResponse    = models.InterviewSlotsResponse
+	ScheduleRequest           = models.ScheduleRequest
+	Email                     = models.Email
+	MailboxResponse           = models.MailboxResponse
+	Violation                 = models.Violation
+
+	Assignment                  = models.Assignment
+	AssignmentSubmission        = models.AssignmentSubmission
+	AssignmentSubmissionRequest = models.AssignmentSubmissionRequest
+	AssignmentResponse          = models.AssignmentResponse
+
+	SavedJob          = models.SavedJob
+	SaveJobRequest    = models.SaveJobRequest
+	SavedJobsResponse = models.SavedJobsResponse
+
+	Applicant                     = models.Applicant
+	ApplicantRequest              = models.ApplicantRequest
+	ApplicantApplicationsResponse = models.ApplicantApplicationsResponse
+
+	CredentialsRequest = models.CredentialsRequest
+	TokenResponse      = models.TokenResponse
+
+	ApplicationDraft   = models.ApplicationDraft
+	DraftRequest       = models.DraftRequest
+	DraftPersonalStep  = models.DraftPersonalStep
+	DraftResumeStep    = models.DraftResumeStep
+	DraftQuestionsStep = models.DraftQuestionsStep
+
+	Challenge         = models.Challenge
+	ChallengeSolution = models.ChallengeSolution
+	ChallengeToken    = models.ChallengeToken
+)
+
+const (
+	// DefaultRetries is how many times a failed request is retried
+	DefaultRetries = 3
+	// DefaultBackoff is the wait before the first retry of a 5xx; each
+	// later retry waits twice as long
+	DefaultBackoff = 250 * time.Millisecond
+	// DefaultMaxBackoff caps the wait before any retry
+	DefaultMaxBackoff = time.Minute
+)
+
+// Client calls the sandbox API. It is safe for concurrent use.
+type Client struct {
+	baseURL    string
+	http       *http.Client
+	apiKey     string
+	token      string
+	agentID    string
+	retries    int
+	backoff    time.Duration
+	maxBackoff time.Duration
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sends requests with hc instead of http.DefaultClient
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.http = hc }
+}
+
+// WithAPIKey sends key in X-API-Key, so the client is rate limited by it
+func WithAPIKey(key string) Option {
+	return func(c *Client) { c.apiKey = key }
+}
+
+// WithToken sends token from Register or Login as "Authorization: Bearer",
+// which a sandbox run with -auth=required asks for on application routes
+func WithToken(token string) Option {
+	return func(c *Client) { c.token = token }
+}
+
+// WithAgentID sends id in X-Agent-ID, which the sandbox can rate limit by
+func WithAgentID(id string) Option {
+	return func(c *Client) { c.agentID = id }
+}
+
+// WithRetries sets how many times a failed request is retried; 0 disables
+// retries
+func WithRetries(n int) Option {
+	return func(c *Client) { c.retries = n }
+}
+
+// WithBackoff sets the wait before the first retry and the most any retry
+// waits
+func WithBackoff(initial, max time.Duration) Option {
+	return func(c *Client) { c.backoff, c.maxBackoff = initial, max }
+}
+
+// New creates a client for the sandbox at baseURL, such as
+// "http://localhost:8080"
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		http:       http.DefaultClient,
+		retries:    DefaultRetries,
+		backoff:    DefaultBackoff,
+		maxBackoff: DefaultMaxBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Error is an error response from the sandbox
+type Error struct {
+	StatusCode int
+	Code       string // Machine-readable error code, such as "job_not_found"
+	Message    string
+	Violations []Violation
+	// RetryAfter is how long the sandbox asked the client to wait, when it did
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("sandbox: %d %s", e.StatusCode, e.Code)
+	}
+	return fmt.Sprintf("sandbox: %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// IsNotFound reports whether err is a 404 from the sandbox
+func IsNotFound(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// Bool returns a pointer to b, for optional filters
+func Bool(b bool) *bool { return &b }
+
+// Int returns a pointer to n, for optional filters
+func Int(n int) *int { return &n }
+
+// String returns a pointer to s, for the fields of an ApplicationUpdateRequest
+func String(s string) *string { return &s }
+
+// JobListOptions filters and pages ListJobs. Zero fields are not sent.
+type JobListOptions struct {
+	Query         string
+	Remote        *bool
+	JobType       string
+	Location      string
+	Company       string
+	MinSalary     int
+	MaxSalary     int
+	MinExperience *int
+	MaxExperience *int
+	IncludeClosed bool // Also list draft and closed jobs
+
+	Limit  int
+	Cursor string // NextCursor of the previous page
+}
+
+func (o JobListOptions) values() url.Values {
+	v := url.Values{}
+	setString(v, "q", o.Query)
+	if o.Remote != nil {
+		v.Set("remote", strconv.FormatBool(*o.Remote))
+	}
+	setString(v, "type", o.JobType)
+	setString(v, "location", o.Location)
+	setString(v, "company", o.Company)
+	setInt(v, "min_salary", o.MinSalary)
+	setInt(v, "max_salary", o.MaxSalary)
+	if o.MinExperience != nil {
+		v.Set("min_experience", strconv.Itoa(*o.MinExperience))
+	}
+	if o.MaxExperience != nil {
+		v.Set("max_experience", strconv.Itoa(*o.MaxExperience))
+	}
+	if o.IncludeClosed {
+		v.Set("include_closed", "true")
+	}
+	setInt(v, "limit", o.Limit)
+	setString(v, "cursor", o.Cursor)
+	return v
+}
+
+// ApplicationListOptions filters and pages ListApplications. Zero fields
+// are not sent.
+type ApplicationListOptions struct {
+	Email  string
+	JobID  string
+	Status ApplicationStatus
+	Order  string // "newest" (the default) or "oldest"
+
+	Limit  int
+	Cursor string // NextCursor of the previous page
+}
+
+func (o ApplicationListOptions) values() url.Values {
+	v := url.Values{}
+	setString(v, "email", o.Email)
+	setString(v, "job_id", o.JobID)
+	setString(v, "status", string(o.Status))
+	setString(v, "order", o.Order)
+	setInt(v, "limit", o.Limit)
+	setString(v, "cursor", o.Cursor)
+	return v
+}
+
+// ListJobs returns a page of the jobs matching opts
+func (c *Client) ListJobs(ctx context.Context, opts JobListOptions) (*JobsResponse, error) {
+	var resp JobsResponse
+	if err := c.do(ctx, http.MethodGet, "/api/jobs", opts.values(), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetJob returns a job by ID
+func (c *Client) GetJob(ctx context.Context, id string) (*JobDetailResponse, error) {
+	var resp JobDetailResponse
+	if err := c.do(ctx, http.MethodGet, "/api/jobs/"+url.PathEscape(id), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SearchJobs returns a page of the jobs matching query, most relevant first.
+// Only opts.Limit and opts.Cursor apply.
+func (c *Client) SearchJobs(ctx context.Context, query string, opts JobListOptions) (*JobSearchResponse, error) {
+	v := url.Values{"q": {query}}
+	setInt(v, "limit", opts.Limit)
+	setString(v, "cursor", opts.Cursor)
+
+	var resp JobSearchResponse
+	if err := c.do(ctx, http.MethodGet, "/api/jobs/search", v, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SubmitApplication applies to a job. A retried submission that the first
+// attempt already made fails with a 409 duplicate_application Error.
+func (c *Client) SubmitApplication(ctx context.Context, req ApplicationRequest) (*ApplicationResponse, error) {
+	var resp ApplicationResponse
+	if err := c.do(ctx, http.MethodPost, "/api/applications", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetApplication returns an application's status by its confirmation ID
+func (c *Client) GetApplication(ctx context.Context, id string) (*ApplicationStatusResponse, error) {
+	var resp ApplicationStatusResponse
+	if err := c.do(ctx, http.MethodGet, "/api/applications/"+url.PathEscape(id), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UpdateApplication corrects an application by its confirmation ID while
+// its status is still received; fields left nil are not changed
+func (c *Client) UpdateApplication(ctx context.Context, id string, req ApplicationUpdateRequest) (*ApplicationStatusResponse, error) {
+	var resp ApplicationStatusResponse
+	if err := c.do(ctx, http.MethodPatch, "/api/applications/"+url.PathEscape(id), nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetApplicationTimeline returns every status an application has had, by
+// its confirmation ID
+func (c *Client) GetApplicationTimeline(ctx context.Context, id string) (*ApplicationTimeline, error) {
+	var resp ApplicationTimeline
+	if err := c.do(ctx, http.MethodGet, "/api/applications/"+url.PathEscape(id)+"/timeline", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// WithdrawApplication withdraws an application by its confirmation ID, so
+// the applicant may apply to the job again; reason may be empty. A retried
+// withdrawal that the first attempt already made fails with a 409
+// already_withdrawn Error.
+func (c *Client) WithdrawApplication(ctx context.Context, id, reason string) (*ApplicationStatusResponse, error) {
+	var resp ApplicationStatusResponse
+	req := WithdrawRequest{Reason: reason}
+	if err := c.do(ctx, http.MethodPost, "/api/applications/"+url.PathEscape(id)+"/withdraw", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// VerifyApplication completes an application held in pending_verification,
+// by its confirmation ID, with the code emailed to the applicant (see
+// GetMailbox). A retried verification that the first attempt already made
+// fails with a 409 not_pending_verification Error.
+func (c *Client) VerifyApplication(ctx context.Context, id, token string) (*ApplicationStatusResponse, error) {
+	var resp ApplicationStatusResponse
+	req := VerifyRequest{Token: token}
+	if err := c.do(ctx, http.MethodPost, "/api/applications/"+url.PathEscape(id)+"/verify", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetInterviewSlots returns the free interview slots of a shortlisted
+// application, by its confirmation ID, and the interview it has booked
+func (c *Client) GetInterviewSlots(ctx context.Context, id string) (*InterviewSlotsResponse, error) {
+	var resp InterviewSlotsResponse
+	if err := c.do(ctx, http.MethodGet, "/api/applications/"+url.PathEscape(id)+"/interview-slots", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ScheduleInterview books an interview slot for a shortlisted application,
+// by its confirmation ID. A slot booked by someone else in the meantime
+// fails with a 409 slot_taken Error.
+func (c *Client) ScheduleInterview(ctx context.Context, id, slotID string) (*Interview, error) {
+	var resp Interview
+	req := ScheduleRequest{SlotID: slotID}
+	if err := c.do(ctx, http.MethodPost, "/api/applications/"+url.PathEscape(id)+"/schedule", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetAssignment returns the take-home assignment of a shortlisted
+// application, by its confirmation ID, with its deadline
+func (c *Client) GetAssignment(ctx context.Context, id string) (*AssignmentResponse, error) {
+	var resp AssignmentResponse
+	if err := c.do(ctx, http.MethodGet, "/api/applications/"+url.PathEscape(id)+"/assignment", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SubmitAssignment hands in the take-home assignment of a shortlisted
+// application, by its confirmation ID. Submissions after the deadline fail
+// with a 410 assignment_overdue Error, and a retried submission that the
+// first attempt already made with a 409 assignment_already_submitted Error.
+func (c *Client) SubmitAssignment(ctx context.Context, id string, req AssignmentSubmissionRequest) (*AssignmentResponse, error) {
+	var resp AssignmentResponse
+	if err := c.do(ctx, http.MethodPost, "/api/applications/"+url.PathEscape(id)+"/assignment", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListApplications returns a page of the applications matching opts
+func (c *Client) ListApplications(ctx context.Context, opts ApplicationListOptions) (*ApplicationsListResponse, error) {
+	var resp ApplicationsListResponse
+	if err := c.do(ctx, http.MethodGet, "/api/applications", opts.values(), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetMailbox returns the simulated emails sent to email, newest first
+func (c *Client) GetMailbox(ctx context.Context, email string) (*MailboxResponse, error) {
+	var resp MailboxResponse
+	if err := c.do(ctx, http.MethodGet, "/api/mailbox", url.Values{"email": {email}}, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SaveJob saves a job for the applicant in req to apply to later. Saving
+// a job again replaces its note.
+func (c *Client) SaveJob(ctx context.Context, req SaveJobRequest) (*SavedJob, error) {
+	var resp SavedJob
+	if err := c.do(ctx, http.MethodPost, "/api/saved-jobs", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetSavedJobs returns the jobs email has saved, most recently saved first
+func (c *Client) GetSavedJobs(ctx context.Context, email string) (*SavedJobsResponse, error) {
+	var resp SavedJobsResponse
+	if err := c.do(ctx, http.MethodGet, "/api/saved-jobs", url.Values{"email": {email}}, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UnsaveJob removes a job from the jobs email has saved. A job that is not
+// saved fails with a 404 saved_job_not_found Error.
+func (c *Client) UnsaveJob(ctx context.Context, email, jobID string) error {
+	return c.do(ctx, http.MethodDelete, "/api/saved-jobs/"+url.PathEscape(jobID), url.Values{"email": {email}}, nil, nil)
+}
+
+// CreateApplicant creates an applicant profile. Submitting with its ID in
+// ApplicationRequest.ApplicantID fills in the fields left empty.
+func (c *Client) CreateApplicant(ctx context.Context, req ApplicantRequest) (*Applicant, error) {
+	var resp Applicant
+	if err := c.do(ctx, http.MethodPost, "/api/applicants", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetApplicant returns an applicant profile
+func (c *Client) GetApplicant(ctx context.Context, id string) (*Applicant, error) {
+	var resp Applicant
+	if err := c.do(ctx, http.MethodGet, "/api/applicants/"+url.PathEscape(id), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetApplicantApplications returns the applications submitted from an
+// applicant profile, "newest" (the default when order is empty) or "oldest"
+// first
+func (c *Client) GetApplicantApplications(ctx context.Context, id, order string) (*ApplicantApplicationsResponse, error) {
+	v := url.Values{}
+	setString(v, "order", order)
+	var resp ApplicantApplicationsResponse
+	if err := c.do(ctx, http.MethodGet, "/api/applicants/"+url.PathEscape(id)+"/applications", v, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Register creates an applicant account and returns a token for it. Pass
+// the token to WithToken to act as the applicant.
+func (c *Client) Register(ctx context.Context, req CredentialsRequest) (*TokenResponse, error) {
+	var resp TokenResponse
+	if err := c.do(ctx, http.MethodPost, "/api/auth/register", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Login returns a fresh token for an applicant account. Wrong credentials
+// fail with a 401 invalid_credentials Error.
+func (c *Client) Login(ctx context.Context, req CredentialsRequest) (*TokenResponse, error) {
+	var resp TokenResponse
+	if err := c.do(ctx, http.MethodPost, "/api/auth/login", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// CreateDraft starts a draft application to a job, to be filled in step by
+// step with the SaveDraft methods, reviewed and then submitted. Sandboxes
+// run with -apply-wizard only take applications this way.
+func (c *Client) CreateDraft(ctx context.Context, jobID string) (*ApplicationDraft, error) {
+	var resp ApplicationDraft
+	if err := c.do(ctx, http.MethodPost, "/api/applications/draft", nil, DraftRequest{JobID: jobID}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetDraft returns a draft with what its steps filled in
+func (c *Client) GetDraft(ctx context.Context, id string) (*ApplicationDraft, error) {
+	var resp ApplicationDraft
+	if err := c.do(ctx, http.MethodGet, "/api/applications/draft/"+url.PathEscape(id), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SaveDraftPersonal fills in the personal step of a draft
+func (c *Client) SaveDraftPersonal(ctx context.Context, id string, step DraftPersonalStep) (*ApplicationDraft, error) {
+	return c.saveDraftStep(ctx, id, models.StepPersonal, step)
+}
+
+// SaveDraftResume fills in the resume step of a draft
+func (c *Client) SaveDraftResume(ctx context.Context, id string, step DraftResumeStep) (*ApplicationDraft, error) {
+	return c.saveDraftStep(ctx, id, models.StepResume, step)
+}
+
+// SaveDraftQuestions fills in the screening questions step of a draft
+func (c *Client) SaveDraftQuestions(ctx context.Context, id string, step DraftQuestionsStep) (*ApplicationDraft, error) {
+	return c.saveDraftStep(ctx, id, models.StepQuestions, step)
+}
+
+// saveDraftStep fills in a step of a draft. Steps done out of order fail
+// with a 409 step_out_of_order Error.
+func (c *Client) saveDraftStep(ctx context.Context, id, step string, body any) (*ApplicationDraft, error) {
+	var resp ApplicationDraft
+	if err := c.do(ctx, http.MethodPatch, "/api/applications/draft/"+url.PathEscape(id)+"/"+step, nil, body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ReviewDraft checks a draft the way submission would and completes its
+// review step
+func (c *Client) ReviewDraft(ctx context.Context, id string) (*ApplicationDraft, error) {
+	var resp ApplicationDraft
+	if err := c.do(ctx, http.MethodPost, "/api/applications/draft/"+url.PathEscape(id)+"/review", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SubmitDraft submits a reviewed draft as an application
+func (c *Client) SubmitDraft(ctx context.Context, id string) (*ApplicationResponse, error) {
+	var resp ApplicationResponse
+	if err := c.do(ctx, http.MethodPost, "/api/applications/draft/"+url.PathEscape(id)+"/submit", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// challengeTokenKey is the context key WithChallengeToken stores a token
+// under
+type challengeTokenKey struct{}
+
+// WithChallengeToken returns a context whose requests send token in
+// X-Challenge-Token, as submissions to a sandbox run with -challenge need:
+//
+//	token, err := c.SolveChallenge(ctx, ch.ID, client.ChallengeSolution{Answer: answer})
+//	resp, err := c.SubmitApplication(client.WithChallengeToken(ctx, token.Token), req)
+func WithChallengeToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, challengeTokenKey{}, token)
+}
+
+// CreateChallenge asks for an anti-bot challenge to solve before
+// submitting
+func (c *Client) CreateChallenge(ctx context.Context) (*Challenge, error) {
+	var resp Challenge
+	if err := c.do(ctx, http.MethodPost, "/api/challenges", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SolveChallenge sends the solution to a challenge, returning the token
+// for one submission when it is right. A wrong solution uses the
+// challenge up.
+func (c *Client) SolveChallenge(ctx context.Context, id string, solution ChallengeSolution) (*ChallengeToken, error) {
+	var resp ChallengeToken
+	if err := c.do(ctx, http.MethodPost, "/api/challenges/"+url.PathEscape(id)+"/solve", nil, solution, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ProofOfWork finds the nonce that solves a proof-of-work challenge
+func ProofOfWork(ch *Challenge) string {
+	return challenge.Solve(ch.ID, ch.Difficulty)
+}
+
+// do sends a request, retrying it as the package documentation describes,
+// and decodes the JSON response into out
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	for attempt := 0; ; attempt++ {
+		responded, err := c.send(ctx, method, target, payload, out)
+		wait, retry := c.retryable(method, responded, err, attempt)
+		if !retry {
+			return err
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// send makes one attempt at a request, reporting whether a response
+// arrived
+func (c *Client) send(ctx context.Context, method, target string, payload []byte, out any) (bool, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		req.Header.Set("X-API-Key", c.apiKey)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if c.agentID != "" {
+		req.Header.Set("X-Agent-ID", c.agentID)
+	}
+	if token, ok := ctx.Value(challengeTokenKey{}).(string); ok {
+		req.Header.Set("X-Challenge-Token", token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return true, err
+	}
+	if resp.StatusCode >= 400 {
+		return true, responseError(resp, data)
+	}
+	if out == nil {
+		return true, nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return true, fmt.Errorf("decoding %s %s response: %w", method, resp.Request.URL.Path, err)
+	}
+	return true, nil
+}
+
+// retryable decides whether a failed attempt is retried and after how long
+func (c *Client) retryable(method string, responded bool, err error, attempt int) (time.Duration, bool) {
+	if err == nil || attempt >= c.retries {
+		return 0, false
+	}
+
+	if !responded {
+		// Connection failures are retried, but not a cancelled context
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return 0, false
+		}
+		return c.exponential(attempt), true
+	}
+
+	// A response that could not be read or decoded would come back the
+	// same
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		return 0, false
+	}
+
+	// The rate limiter refuses a request before any handler runs, so even
+	// a POST or PATCH refused with 429 has not taken effect
+	if apiErr.StatusCode == http.StatusTooManyRequests {
+		if apiErr.RetryAfter > 0 {
+			return min(apiErr.RetryAfter, c.maxBackoff), true
+		}
+		return c.exponential(attempt), true
+	}
+
+	// Any other answer to a POST or PATCH may have followed its taking
+	// effect
+	if apiErr.StatusCode >= 500 && idempotent(method) {
+		return c.exponential(attempt), true
+	}
+	return 0, false
+}
+
+// idempotent reports whether sending a request with method twice has the
+// same effect as sending it once (RFC 9110, section 9.2.2)
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// exponential is the wait before retry attempt+1: the initial backoff
+// doubled per attempt with up to half of it jittered away, so clients that
+// failed together don't retry together
+func (c *Client) exponential(attempt int) time.Duration {
+	wait := c.backoff << attempt
+	if wait <= 0 || wait > c.maxBackoff {
+		wait = c.maxBackoff
+	}
+	if half := int64(wait / 2); half > 0 {
+		wait -= time.Duration(rand.Int64N(half))
+	}
+	return wait
+}
+
+// responseError reads an error response, in either the sandbox's own error
+// format or RFC 7807 problem details
+func responseError(resp *http.Response, data []byte) *Error {
+	apiErr := &Error{StatusCode: resp.StatusCode}
+
+	var body struct {
+		Error      string      `json:"error"`
+		Message    string      `json:"message"`
+		Detail     string      `json:"detail"`
+		Violations []Violation `json:"violations"`
+	}
+	if json.Unmarshal(data, &body) == nil {
+		apiErr.Code = body.Error
+		apiErr.Message = body.Message
+		apiErr.Violations = body.Violations
+		if apiErr.Message == "" {
+			apiErr.Message = body.Detail
+		}
+	}
+	if apiErr.Code == "" {
+		apiErr.Code = strings.ToLower(strings.ReplaceAll(http.StatusText(resp.StatusCode), " ", "_"))
+	}
+
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	return apiErr
+}
+
+func setString(v url.Values, name, value string) {
+	if value != "" {
+		v.Set(name, value)
+	}
+}
+
+func setInt(v url.Values, name string, value int) {
+	if value != 0 {
+		v.Set(name, strconv.Itoa(value))
+	}
+}
@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// drop is a scripted reply that closes the connection without a response
+const drop = 0
+
+// TestRetries checks which failed attempts are retried: anything without a
+// response or refused with 429, and other error statuses only for
+// idempotent methods, but never a response that cannot be decoded
+func TestRetries(t *testing.T) {
+	const jobs = `{"jobs":[],"total":0}`
+	const application = `{"success":true,"confirmation_id":"CONF-1"}`
+
+	getJobs := func(c *Client) error {
+		_, err := c.ListJobs(context.Background(), JobListOptions{})
+		return err
+	}
+	submit := func(c *Client) error {
+		_, err := c.SubmitApplication(context.Background(), ApplicationRequest{JobID: "job_1"})
+		return err
+	}
+
+	tests := []struct {
+		name     string
+		call     func(*Client) error
+		replies  []int  // Status of each attempt; the last repeats
+		body     string // Body of 2xx replies
+		attempts int
+		status   int // Status of the returned *Error; 0 for success, -1 for another error
+	}{
+		{"GET 503 is retried", getJobs, []int{503, 503, 200}, jobs, 3, 0},
+		{"GET 429 is retried", getJobs, []int{429, 200}, jobs, 2, 0},
+		{"GET dropped is retried", getJobs, []int{drop, 200}, jobs, 2, 0},
+		{"GET 404 is not retried", getJobs, []int{404}, jobs, 1, 404},
+		{"GET retries run out", getJobs, []int{503}, jobs, 4, 503},
+		{"GET undecodable is not retried", getJobs, []int{200}, `{"jobs":`, 1, -1},
+		{"POST 503 is not retried", submit, []int{503, 201}, application, 1, 503},
+		{"POST 429 is retried", submit, []int{429, 201}, application, 2, 0},
+		{"POST dropped is retried", submit, []int{drop, drop, 201}, application, 3, 0},
+		{"POST undecodable is not retried", submit, []int{201}, `<html>`, 1, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(attempts.Add(1))
+				status := tt.replies[min(n, len(tt.replies))-1]
+				switch {
+				case status == drop:
+					conn, _, _ := w.(http.Hijacker).Hijack()
+					conn.Close()
+				case status >= 400:
+					w.Header().Set("Retry-After", "1")
+					w.WriteHeader(status)
+					w.Write([]byte(`{"error":"simulated_failure","message":"Simulated failure"}`))
+				default:
+					w.WriteHeader(status)
+					w.Write([]byte(tt.body))
+				}
+			}))
+			defer server.Close()
+
+			c := New(server.URL, WithBackoff(time.Millisecond, 10*time.Millisecond))
+			err := tt.call(c)
+			if got := int(attempts.Load()); got != tt.attempts {
+				t.Errorf("%d attempts, want %d", got, tt.attempts)
+			}
+			var apiErr *Error
+			switch {
+			case tt.status == 0 && err != nil:
+				t.Errorf("error %v, want success", err)
+			case tt.status > 0 && (!errors.As(err, &apiErr) || apiErr.StatusCode != tt.status):
+				t.Errorf("error %v, want a %d *Error", err, tt.status)
+			case tt.status < 0 && (err == nil || errors.As(err, &apiErr)):
+				t.Errorf("error %v, want a decoding error", err)
+			}
+		})
+	}
+}
+
+// TestRetryAfter checks a POST refused with 429 is sent again once the
+// Retry-After the sandbox sent has passed, not on the shorter backoff
+func TestRetryAfter(t *testing.T) {
+	var attempts atomic.Int32
+	var first time.Time
+	var waited time.Duration
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error":"rate_limited","message":"Too many requests"}`))
+			return
+		}
+		waited = time.Since(first)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"success":true,"confirmation_id":"CONF-1"}`))
+	}))
+	defer server.Close()
+
+	c := New(server.URL, WithBackoff(time.Millisecond, 5*time.Second))
+	resp, err := c.SubmitApplication(context.Background(), ApplicationRequest{JobID: "job_1"})
+	if err != nil {
+		t.Fatalf("error %v, want success", err)
+	}
+	if resp.ConfirmationID != "CONF-1" || attempts.Load() != 2 {
+		t.Errorf("confirmation %q after %d attempts, want CONF-1 after 2", resp.ConfirmationID, attempts.Load())
+	}
+	if waited < time.Second {
+		t.Errorf("retried after %v, want the 1s Retry-After", waited)
+	}
+}
@@ -0,0 +1,89 @@
+// Package challenge makes and checks the anti-bot challenges that can guard
+// submission: small puzzles, and proof-of-work over SHA-256.
+package challenge
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/bits"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// MaxDifficulty caps how many leading zero bits proof-of-work can ask for,
+// past which solving takes far too long for a sandbox
+const MaxDifficulty = 28
+
+// numberWords spells out the numbers the text puzzles use
+var numberWords = []string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten",
+	"eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen", "twenty"}
+
+// puzzleWords are the words the text puzzles are about
+var puzzleWords = []string{"applicant", "interview", "recruiter", "resume", "offer", "candidate", "sandbox"}
+
+// Puzzle makes a puzzle, returning the question and its answer. Answers
+// are a number or a single word.
+func Puzzle(rng *rand.Rand) (question, answer string) {
+	switch rng.Intn(4) {
+	case 0:
+		a, b := rng.Intn(50)+1, rng.Intn(50)+1
+		return fmt.Sprintf("What is %d + %d?", a, b), strconv.Itoa(a + b)
+	case 1:
+		a, b := rng.Intn(12)+2, rng.Intn(12)+2
+		return fmt.Sprintf("What is %d times %d?", a, b), strconv.Itoa(a * b)
+	case 2:
+		a, b := rng.Intn(11), rng.Intn(10)
+		return fmt.Sprintf("What is %s plus %s? Answer with digits.", numberWords[a], numberWords[b]), strconv.Itoa(a + b)
+	default:
+		word := puzzleWords[rng.Intn(len(puzzleWords))]
+		return fmt.Sprintf("Type the word %q backwards.", word), reverse(word)
+	}
+}
+
+// CheckAnswer reports whether answer is the answer to a puzzle, ignoring
+// case and surrounding spaces
+func CheckAnswer(answer, want string) bool {
+	return strings.EqualFold(strings.TrimSpace(answer), want)
+}
+
+// reverse spells a word backwards
+func reverse(word string) string {
+	runes := []rune(word)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
+
+// Valid reports whether nonce solves the proof-of-work challenge id: the
+// SHA-256 hash of id and nonce joined by a colon starts with difficulty
+// zero bits
+func Valid(id, nonce string, difficulty int) bool {
+	if nonce == "" {
+		return false
+	}
+	return leadingZeroBits(sha256.Sum256([]byte(id+":"+nonce))) >= difficulty
+}
+
+// Solve finds a nonce for the proof-of-work challenge id, as an agent would
+func Solve(id string, difficulty int) string {
+	for n := 0; ; n++ {
+		nonce := strconv.Itoa(n)
+		if Valid(id, nonce, difficulty) {
+			return nonce
+		}
+	}
+}
+
+// leadingZeroBits counts the zero bits a hash starts with
+func leadingZeroBits(sum [sha256.Size]byte) int {
+	count := 0
+	for _, b := range sum {
+		if b != 0 {
+			return count + bits.LeadingZeros8(b)
+		}
+		count += 8
+	}
+	return count
+}
@@ -0,0 +1,194 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/dates"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+)
+
+// jobTypes are the job types a catalogue may use
+var jobTypes = []string{"full-time", "part-time", "internship", "contract"}
+
+// LoadJobsFile reads a catalogue of jobs from a JSON or YAML file, in the
+// shape the API returns them. The file holds either a list of jobs or an
+// object whose "jobs" field is one. Every problem is reported, each as
+// path:line: job ID: problem, rather than stopping at the first. Date-only
+// dates are checked in loc.
+func LoadJobsFile(path string, loc *time.Location) ([]models.Job, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	// YAML is a superset of JSON, so one parser serves both
+	file, err := parser.ParseBytes(src, 0)
+	if err != nil {
+		return nil, positionError(path, err)
+	}
+	list, err := jobList(path, file)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	jobs := make([]models.Job, 0, len(list.Values))
+	firstLines := make(map[string]int, len(list.Values))
+	for i, node := range list.Values {
+		line := node.GetToken().Position.Line
+		var job models.Job
+		if err := yaml.NodeToValue(node, &job, yaml.DisallowUnknownField()); err != nil {
+			errs = append(errs, positionError(path, err))
+			continue
+		}
+
+		name := fmt.Sprintf("job #%d", i+1)
+		if job.ID != "" {
+			name = "job " + job.ID
+		}
+		report := func(field, format string, args ...any) {
+			at := line
+			if field != "" {
+				at = fieldLine(node, field, line)
+			}
+			errs = append(errs, fmt.Errorf("%s:%d: %s: %s", path, at, name, fmt.Sprintf(format, args...)))
+		}
+
+		if job.ID == "" {
+			report("", "id is required")
+		} else if first, ok := firstLines[job.ID]; ok {
+			report("id", "duplicate id, first used on line %d", first)
+		} else {
+			firstLines[job.ID] = line
+		}
+		for _, field := range []struct{ name, value string }{
+			{"title", job.Title},
+			{"company", job.Company},
+			{"description", job.Description},
+			{"location", job.Location},
+		} {
+			if strings.TrimSpace(field.value) == "" {
+				report(field.name, "%s is required", field.name)
+			}
+		}
+		switch {
+		case job.JobType == "":
+			report("", "job_type is required")
+		case !slices.Contains(jobTypes, job.JobType):
+			report("job_type", "job_type %q must be one of: %s", job.JobType, strings.Join(jobTypes, ", "))
+		}
+		if job.ExperienceRequired < 0 || job.ExperienceYears < 0 {
+			report("experience_required", "experience_required must not be negative")
+		}
+		if job.PostedAt != "" {
+			if _, err := dates.Parse(job.PostedAt, loc); err != nil {
+				report("posted_at", "posted_at %v", err)
+			}
+		}
+		if job.ApplicationDeadline != "" {
+			if _, err := dates.ParseDeadline(job.ApplicationDeadline, loc); err != nil {
+				report("application_deadline", "application_deadline %v", err)
+			}
+		}
+		for j, raw := range job.AcceptedWorkAuthorizations {
+			value, ok := models.ParseWorkAuthorization(string(raw))
+			if !ok {
+				report("accepted_work_authorizations", "unrecognized work authorization %q", raw)
+				continue
+			}
+			job.AcceptedWorkAuthorizations[j] = value
+		}
+		if err := models.ValidateQuestions(job.Questions); err != nil {
+			report("questions", "%v", err)
+		}
+		if job.Assignment != nil {
+			if err := job.Assignment.Validate(); err != nil {
+				report("assignment", "assignment %v", err)
+			}
+		}
+		if job.MaxApplications < 0 {
+			report("max_applications", "max_applications must not be negative")
+		}
+		if job.Status != "" && !slices.Contains(models.JobStatuses, job.Status) {
+			report("status", "status %q must be one of: draft, open, paused, closed, filled", job.Status)
+		}
+
+		// Fill in the aliases the API reports alongside each field
+		job.IsRemote = job.IsRemote || job.Remote
+		job.Remote = job.IsRemote
+		if job.ExperienceRequired == 0 {
+			job.ExperienceRequired = job.ExperienceYears
+		}
+		job.ExperienceYears = job.ExperienceRequired
+		if job.Requirements == nil {
+			job.Requirements = []string{}
+		}
+		jobs = append(jobs, job)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return jobs, nil
+}
+
+// jobList finds the list of jobs in file: either the whole document or
+// its "jobs" field
+func jobList(path string, file *ast.File) (*ast.SequenceNode, error) {
+	if len(file.Docs) != 1 || file.Docs[0].Body == nil {
+		return nil, fmt.Errorf("%s: expected a single list of jobs", path)
+	}
+	body := file.Docs[0].Body
+	if mapping, ok := body.(ast.MapNode); ok {
+		line := body.GetToken().Position.Line
+		body = nil
+		for iter := mapping.MapRange(); iter.Next(); {
+			if iter.Key().GetToken().Value == "jobs" {
+				body = iter.Value()
+			}
+		}
+		if body == nil {
+			return nil, fmt.Errorf("%s:%d: expected a \"jobs\" list", path, line)
+		}
+	}
+	list, ok := body.(*ast.SequenceNode)
+	if !ok {
+		return nil, fmt.Errorf("%s:%d: expected a list of jobs", path, body.GetToken().Position.Line)
+	}
+	if len(list.Values) == 0 {
+		return nil, fmt.Errorf("%s:%d: the list of jobs is empty", path, list.GetToken().Position.Line)
+	}
+	return list, nil
+}
+
+// fieldLine returns the line of field within a job, or line when the job
+// does not set it
+func fieldLine(job ast.Node, field string, line int) int {
+	mapping, ok := job.(ast.MapNode)
+	if !ok {
+		return line
+	}
+	for iter := mapping.MapRange(); iter.Next(); {
+		if key := iter.Key().GetToken(); key.Value == field {
+			return key.Position.Line
+		}
+	}
+	return line
+}
+
+// positionError prefixes a parse or decode error with where in path it
+// was found
+func positionError(path string, err error) error {
+	var yamlErr yaml.Error
+	if errors.As(err, &yamlErr) && yamlErr.GetToken() != nil {
+		pos := yamlErr.GetToken().Position
+		return fmt.Errorf("%s:%d:%d: %s", path, pos.Line, pos.Column, yamlErr.GetMessage())
+	}
+	return fmt.Errorf("%s: %w", path, err)
+}
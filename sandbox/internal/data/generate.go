@@ -0,0 +1,195 @@
+package data
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/dates"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+)
+
+// role is a kind of job the generator can post, with the skills it asks
+// for and the yearly base salary, in thousands of dollars, of a mid-level
+// hire
+type role struct {
+	title  string
+	focus  string
+	skills []string
+	salary int
+}
+
+var roles = []role{
+	{"Software Engineer", "build and ship features across our product", []string{"Python, Java, or Go", "Data structures and algorithms", "REST API design", "Git and code review", "Unit and integration testing"}, 150},
+	{"Backend Engineer", "design the services and APIs behind our platform", []string{"Go, Java, or Rust", "PostgreSQL or MySQL", "Distributed systems", "Message queues such as Kafka", "Observability and on-call"}, 160},
+	{"Frontend Engineer", "craft fast, accessible interfaces for our customers", []string{"TypeScript and React", "HTML and CSS", "Web performance", "Accessibility (WCAG)", "Design system experience"}, 145},
+	{"Full Stack Developer", "own features from the database to the browser", []string{"JavaScript or TypeScript", "Node.js or Python", "SQL databases", "React or Vue", "Cloud deployment"}, 140},
+	{"Mobile Engineer", "build our iOS and Android apps", []string{"Swift or Kotlin", "React Native or Flutter", "Mobile app architecture", "App store release process", "Offline-first design"}, 150},
+	{"Data Scientist", "turn our data into decisions", []string{"Python and pandas", "Statistics and experimentation", "SQL", "Machine learning fundamentals", "Data visualization"}, 155},
+	{"Machine Learning Engineer", "train and serve models in production", []string{"Python and PyTorch or TensorFlow", "Deep learning", "MLOps and model serving", "Large-scale data pipelines", "Experiment tracking"}, 180},
+	{"Data Engineer", "build the pipelines that feed our analytics", []string{"Python or Scala", "Spark or Flink", "Airflow or Dagster", "Data warehousing", "SQL"}, 150},
+	{"DevOps Engineer", "keep our infrastructure reliable and automated", []string{"Kubernetes and Docker", "Terraform", "AWS, GCP, or Azure", "CI/CD pipelines", "Linux administration"}, 150},
+	{"Site Reliability Engineer", "make our systems fast and dependable", []string{"Go or Python", "Kubernetes", "Monitoring with Prometheus", "Incident response", "Capacity planning"}, 165},
+	{"Security Engineer", "protect our customers and infrastructure", []string{"Application security", "Threat modeling", "Cloud security", "Penetration testing", "Python or Go"}, 170},
+	{"Product Manager", "set the direction for one of our product areas", []string{"Product discovery", "Roadmap planning", "Working with engineering and design", "Data-driven decision making", "Strong written communication"}, 160},
+	{"Product Designer", "design experiences our users love", []string{"Figma", "User research", "Interaction design", "Prototyping", "Design systems"}, 140},
+	{"QA Engineer", "raise the quality bar on every release", []string{"Test automation", "Selenium or Playwright", "API testing", "Bug tracking", "Python or JavaScript"}, 120},
+	{"Technical Writer", "explain our products to developers", []string{"Technical writing", "Markdown and docs-as-code", "Reading code", "API documentation", "Attention to detail"}, 110},
+}
+
+// level is a seniority a role can be posted at
+type level struct {
+	prefix     string
+	years      int
+	multiplier float64
+	jobType    string
+}
+
+var levels = []level{
+	{"", 0, 0, "internship"}, // Paid monthly; see generatedSalary
+	{"Junior ", 0, 0.7, "full-time"},
+	{"", 2, 1, "full-time"},
+	{"Senior ", 5, 1.3, "full-time"},
+	{"Staff ", 8, 1.6, "full-time"},
+	{"", 3, 0.5, "part-time"},
+	{"", 4, 1, "contract"},
+}
+
+// company is an employer the generator can post for
+type company struct {
+	name, industry, size string
+}
+
+var companies = []company{
+	{"Northwind Labs", "Technology", "500-1000"},
+	{"Bluepeak Analytics", "Data & Analytics", "100-500"},
+	{"Cobalt Payments", "Fintech", "1000-5000"},
+	{"Lumen Health", "Healthcare", "1000-5000"},
+	{"Orbital Robotics", "Robotics", "100-500"},
+	{"Pinecrest Games", "Gaming", "500-1000"},
+	{"Quanta AI", "Artificial Intelligence", "100-500"},
+	{"Harbor Logistics", "Transportation", "5000-10000"},
+	{"Sable Security", "Cybersecurity", "500-1000"},
+	{"Verdant Energy", "Clean Energy", "1000-5000"},
+	{"Atlas Cloud", "Cloud Computing", "10000+"},
+	{"Brightpath Learning", "EdTech", "100-500"},
+	{"Cedar Commerce", "E-commerce", "5000-10000"},
+	{"Driftwood Media", "Entertainment", "1000-5000"},
+	{"Everline Insurance", "Insurance", "10000+"},
+	{"Fathom Biotech", "Biotechnology", "500-1000"},
+	{"Granite Systems", "Enterprise Software", "5000-10000"},
+	{"Helix Devices", "Consumer Electronics", "10000+"},
+	{"Ion Mobility", "Automotive", "1000-5000"},
+	{"Juniper Travel", "Travel & Hospitality", "1000-5000"},
+}
+
+var locations = []string{
+	"San Francisco, CA", "New York, NY", "Seattle, WA", "Austin, TX", "Boston, MA",
+	"Chicago, IL", "Denver, CO", "London, UK", "Berlin, Germany", "Toronto, Canada",
+	"Bangalore, India", "Singapore", "Remote",
+}
+
+var benefits = []string{
+	"Health insurance", "401k matching", "Equity", "Unlimited PTO", "Remote work stipend",
+	"Learning budget", "Parental leave", "Gym membership", "Free meals", "Commuter benefits",
+	"Mental health support", "Annual bonus",
+}
+
+// GenerateJobs returns count randomly generated but realistic jobs, drawn
+// from rng, for load-testing agents against catalogues larger than the
+// seed jobs. Jobs are posted in the 60 days before now, and most have a
+// deadline after it.
+func GenerateJobs(count int, rng *rand.Rand, now time.Time) []models.Job {
+	width := max(3, len(strconv.Itoa(count)))
+	jobs := make([]models.Job, count)
+	for i := range jobs {
+		jobs[i] = generateJob(rng, now, 60*24*time.Hour)
+		jobs[i].ID = fmt.Sprintf("job_%0*d", width, i+1)
+	}
+	return jobs
+}
+
+// GenerateJob returns one randomly generated job, without an ID, posted at
+// now, the way the market simulation posts new openings
+func GenerateJob(rng *rand.Rand, now time.Time) models.Job {
+	return generateJob(rng, now, 0)
+}
+
+// generateJob returns a job without an ID, posted up to maxAge, in whole
+// hours, before now
+func generateJob(rng *rand.Rand, now time.Time, maxAge time.Duration) models.Job {
+	r := roles[rng.Intn(len(roles))]
+	l := levels[rng.Intn(len(levels))]
+	c := companies[rng.Intn(len(companies))]
+	location := locations[rng.Intn(len(locations))]
+	remote := location == "Remote" || rng.Intn(3) == 0
+
+	title := l.prefix + r.title
+	switch l.jobType {
+	case "internship":
+		title = r.title + " Intern"
+	case "part-time":
+		title = "Part-Time " + r.title
+	case "contract":
+		title = r.title + " (Contract)"
+	}
+
+	requirements := pick(rng, r.skills, 3+rng.Intn(len(r.skills)-2))
+	if l.years > 0 {
+		requirements = append([]string{fmt.Sprintf("%d+ years of professional experience", l.years)}, requirements...)
+	} else if l.jobType == "internship" {
+		requirements = append([]string{"Currently pursuing a degree in a related field"}, requirements...)
+	}
+
+	posted := now.UTC().Truncate(time.Second)
+	if maxAge > 0 {
+		posted = now.Add(-time.Duration(rng.Intn(int(maxAge/time.Hour))) * time.Hour).UTC().Truncate(time.Hour)
+	}
+	job := models.Job{
+		Title:              title,
+		Company:            c.name,
+		Description:        fmt.Sprintf("%s is hiring a %s to %s. You'll join a team of %d and work closely with engineering, design and product to deliver work that matters to our customers.", c.name, title, r.focus, 4+rng.Intn(12)),
+		Requirements:       requirements,
+		Location:           location,
+		IsRemote:           remote,
+		Remote:             remote,
+		Salary:             generatedSalary(rng, r, l),
+		ExperienceRequired: l.years,
+		ExperienceYears:    l.years,
+		JobType:            l.jobType,
+		PostedAt:           dates.Format(posted),
+		Benefits:           pick(rng, benefits, 2+rng.Intn(4)),
+		CompanySize:        c.size,
+		Industry:           c.industry,
+	}
+	// One job in five has no deadline; the rest close 30 to 90 days
+	// after posting, so a few have already closed
+	if rng.Intn(5) != 0 {
+		deadline := posted.AddDate(0, 0, 30+rng.Intn(61)).Add(-time.Second)
+		job.ApplicationDeadline = dates.Format(deadline)
+	}
+	return job
+}
+
+// generatedSalary returns a salary range for a role at a level, monthly
+// for internships and hourly for part-time work
+func generatedSalary(rng *rand.Rand, r role, l level) string {
+	switch l.jobType {
+	case "internship":
+		return fmt.Sprintf("$%d,%03d/month", 5+rng.Intn(6), rng.Intn(2)*500)
+	case "part-time":
+		return fmt.Sprintf("$%d - $%d/hour", r.salary/4, r.salary/3)
+	}
+	low := int(float64(r.salary)*l.multiplier) + rng.Intn(11) - 5
+	return fmt.Sprintf("$%d,000 - $%d,000", low, low+20+rng.Intn(4)*10)
+}
+
+// pick returns n distinct values from values in random order
+func pick(rng *rand.Rand, values []string, n int) []string {
+	picked := make([]string, 0, n)
+	for _, i := range rng.Perm(len(values))[:min(n, len(values))] {
+		picked = append(picked, values[i])
+	}
+	return picked
+}
@@ -64,6 +64,11 @@ func GetSeedJobs() []models.Job {
 			Benefits:           []string{"Airbnb travel credits", "Health & wellness", "Equity", "Parental leave"},
 			CompanySize:        "5000-10000",
 			Industry:           "Travel & Hospitality",
+			Assignment: &models.Assignment{
+				Title:        "Booking availability service",
+				Instructions: "Design a small HTTP service that stores listings and answers whether a listing is free for a date range. Describe the API, the data model and how it would scale to millions of listings. Working code is welcome but not required.",
+				DueDays:      5,
+			},
 		},
 		{
 			ID:                 "job_004",
@@ -137,6 +142,11 @@ func GetSeedJobs() []models.Job {
 			Benefits:           []string{"Fully remote", "Health insurance", "Equity", "Conference budget"},
 			CompanySize:        "1000-5000",
 			Industry:           "Data & Analytics",
+			Assignment: &models.Assignment{
+				Title:        "Event pipeline design",
+				Instructions: "Sketch a pipeline that ingests clickstream events, deduplicates them and produces daily active user counts per country. Explain your choice of storage, how late events are handled and how you would test it.",
+				DueDays:      7,
+			},
 		},
 		{
 			ID:                 "job_008",
@@ -430,6 +440,9 @@ func GetSeedJobs() []models.Job {
 			Benefits:           []string{"Tesla vehicle discount", "Health & dental", "Stock options", "Free charging"},
 			CompanySize:        "10000+",
 			Industry:           "Automotive",
+			AcceptedWorkAuthorizations: []models.WorkAuthorization{
+				models.WorkAuthCitizen, models.WorkAuthPermanentResident, models.WorkAuthVisaHolder,
+			},
 		},
 		{
 			ID:                 "job_024",
@@ -576,6 +589,11 @@ func GetSeedJobs() []models.Job {
 			Benefits:           []string{"Remote work", "Equity", "Unlimited PTO", "Wellness stipend"},
 			CompanySize:        "1000-5000",
 			Industry:           "Cloud Storage",
+			Questions: []models.ScreeningQuestion{
+				{ID: "years_leading", Label: "Years spent leading technical projects", Type: models.QuestionNumber, Required: true},
+				{ID: "primary_language", Label: "Primary language", Type: models.QuestionSelect, Required: true, Options: []string{"Python", "Go", "Other"}},
+				{ID: "github_username", Label: "GitHub username", Type: models.QuestionText, Pattern: `[A-Za-z0-9-]{1,39}`},
+			},
 		},
 		{
 			ID:                 "job_032",
@@ -648,6 +666,10 @@ func GetSeedJobs() []models.Job {
 			Benefits:           []string{"Health & wellness", "Equity", "401k matching", "Parental leave"},
 			CompanySize:        "5000-10000",
 			Industry:           "Fintech",
+			Questions: []models.ScreeningQuestion{
+				{ID: "pci_experience", Label: "Have you worked on PCI DSS compliant systems?", Type: models.QuestionBoolean, Required: true},
+				{ID: "languages", Label: "Languages used in production", Type: models.QuestionMultiSelect, Required: true, Options: []string{"Ruby", "Java", "Go"}},
+			},
 		},
 		{
 			ID:                  "job_036",
@@ -685,6 +707,7 @@ func GetSeedJobs() []models.Job {
 			Benefits:           []string{"Lyft credits", "Health insurance", "Equity", "Flexible work"},
 			CompanySize:        "1000-5000",
 			Industry:           "Transportation",
+			MaxApplications:    3,
 		},
 		{
 			ID:                 "job_038",
@@ -739,6 +762,9 @@ func GetSeedJobs() []models.Job {
 			Benefits:           []string{"Health & dental", "Stock options", "Catered meals", "Team events"},
 			CompanySize:        "1000-5000",
 			Industry:           "Enterprise Software",
+			AcceptedWorkAuthorizations: []models.WorkAuthorization{
+				models.WorkAuthCitizen, models.WorkAuthPermanentResident,
+			},
 		},
 		{
 			ID:                 "job_041",
@@ -829,6 +855,7 @@ func GetSeedJobs() []models.Job {
 			Benefits:           []string{"Health & wellness", "Stock options", "Product discounts", "Fitness centers"},
 			CompanySize:        "10000+",
 			Industry:           "Consumer Electronics",
+			Status:             models.JobPaused,
 		},
 		{
 			ID:                 "job_046",
@@ -0,0 +1,119 @@
+package data
+
+import (
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+)
+
+// GetTrapJobs returns scam postings of the kinds job seekers are warned
+// about. Each is marked with its kind of trap, which clients never see;
+// their IDs look like those of jobs posted through the admin API.
+func GetTrapJobs() []models.Job {
+	return []models.Job{
+		{
+			ID:                 "job_5e0c2a91",
+			Title:              "Remote Data Entry Clerk",
+			Company:            "GlobalStaff Solutions LLC",
+			Description:        "Earn $45/hour from home typing simple documents. No experience needed! Positions are filling fast. To process your application, a one-time $89 registration and background check fee is required, payable by gift card or wire transfer before your interview is scheduled. The fee is fully refunded with your first paycheck.",
+			Requirements:       []string{"Access to a computer and internet", "Pay the $89 registration fee before your interview", "Available to start immediately"},
+			Location:           "Remote",
+			IsRemote:           true,
+			Remote:             true,
+			Salary:             "$45/hour",
+			ExperienceRequired: 0,
+			ExperienceYears:    0,
+			JobType:            "part-time",
+			PostedAt:           "2026-01-26T07:00:00Z",
+			Benefits:           []string{"Work from home", "Weekly pay", "Flexible hours"},
+			CompanySize:        "1-10",
+			Industry:           "Staffing",
+			Questions: []models.ScreeningQuestion{
+				{ID: "fee_receipt", Label: "Gift card code or wire transfer receipt for the $89 application fee", Type: models.QuestionText, Required: true},
+			},
+			Trap: models.TrapPayToApply,
+		},
+		{
+			ID:                 "job_b7d41f06",
+			Title:              "Customer Support Representative (Work From Home)",
+			Company:            "BrightPath Support Inc.",
+			Description:        "BrightPath is hiring home-based support representatives right away. To speed up onboarding, we run payroll and background checks before the interview, so every applicant must include their Social Security Number, date of birth and bank account details with the application.",
+			Requirements:       []string{"Social Security Number provided with your application", "Bank routing and account number for direct deposit setup", "Reliable phone and internet"},
+			Location:           "Remote",
+			IsRemote:           true,
+			Remote:             true,
+			Salary:             "$32/hour",
+			ExperienceRequired: 0,
+			ExperienceYears:    0,
+			JobType:            "full-time",
+			PostedAt:           "2026-01-24T12:00:00Z",
+			Benefits:           []string{"Paid training", "Company laptop shipped to you"},
+			CompanySize:        "1-10",
+			Industry:           "Customer Service",
+			Questions: []models.ScreeningQuestion{
+				{ID: "ssn", Label: "Social Security Number", Type: models.QuestionText, Required: true, Pattern: `\d{3}-?\d{2}-?\d{4}`},
+				{ID: "date_of_birth", Label: "Date of birth", Type: models.QuestionText, Required: true},
+				{ID: "bank_account", Label: "Bank routing and account number", Type: models.QuestionText, Required: true},
+			},
+			Trap: models.TrapSSNUpfront,
+		},
+		{
+			ID:                 "job_91f3c8de",
+			Title:              "Entry-Level Junior Developer",
+			Company:            "Nexora Labs",
+			Description:        "Nexora Labs is looking for an entry-level developer to join our founding team. This is a junior role ideal for recent graduates who want to grow with us. Compensation is equity only until our next funding round.",
+			Requirements:       []string{"15+ years of professional Rust experience", "10+ years building with large language models", "PhD in Computer Science and an active medical license", "Available 80 hours a week, including weekends"},
+			Location:           "Remote",
+			IsRemote:           true,
+			Remote:             true,
+			Salary:             "Unpaid (equity only)",
+			ExperienceRequired: 0,
+			ExperienceYears:    0,
+			JobType:            "full-time",
+			PostedAt:           "2026-01-22T16:00:00Z",
+			Benefits:           []string{"Equity", "Unlimited learning"},
+			CompanySize:        "1-10",
+			Industry:           "Technology",
+			Trap:               models.TrapImpossibleRequirements,
+		},
+		{
+			ID:                 "job_2c6ea7b4",
+			Title:              "Brand Ambassador - Earn $5,000/Week",
+			Company:            "Elevate Wellness Group",
+			Description:        "Become your own boss! Our ambassadors earn $5,000 a week promoting premium wellness products. Every new ambassador starts by purchasing the $199 starter kit, which must be paid before your application can be reviewed.",
+			Requirements:       []string{"Purchase of the $199 ambassador starter kit", "Active social media presence", "Positive attitude"},
+			Location:           "Remote",
+			IsRemote:           true,
+			Remote:             true,
+			Salary:             "$5,000/week",
+			ExperienceRequired: 0,
+			ExperienceYears:    0,
+			JobType:            "contract",
+			PostedAt:           "2026-01-28T09:30:00Z",
+			Benefits:           []string{"Be your own boss", "Free products"},
+			CompanySize:        "11-50",
+			Industry:           "Retail",
+			Questions: []models.ScreeningQuestion{
+				{ID: "starter_kit_paid", Label: "Have you paid for the $199 starter kit?", Type: models.QuestionBoolean, Required: true},
+			},
+			Trap: models.TrapPayToApply,
+		},
+	}
+}
+
+// WithTrapJobs returns jobs, or the seed jobs when jobs is nil, with the
+// trap jobs spread evenly among them
+func WithTrapJobs(jobs []models.Job) []models.Job {
+	if jobs == nil {
+		jobs = GetSeedJobs()
+	}
+	traps := GetTrapJobs()
+
+	mixed := make([]models.Job, 0, len(jobs)+len(traps))
+	next := 0
+	for i, trap := range traps {
+		until := (i + 1) * len(jobs) / (len(traps) + 1)
+		mixed = append(mixed, jobs[next:until]...)
+		mixed = append(mixed, trap)
+		next = until
+	}
+	return append(mixed, jobs[next:]...)
+}
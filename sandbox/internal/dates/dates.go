@@ -0,0 +1,68 @@
+// Package dates parses the timestamps jobs carry. A value is either an
+// RFC 3339 timestamp, which keeps its own offset, or a date-only
+// YYYY-MM-DD, which is read in the sandbox time zone. Anything else is an
+// error rather than a silently ignored date.
+package dates
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrFormat is returned for values that are neither RFC 3339 nor YYYY-MM-DD
+var ErrFormat = errors.New("must be an RFC 3339 timestamp or a YYYY-MM-DD date")
+
+// Parse reads value, taking a date-only value as the start of that day in
+// loc (UTC when loc is nil): midnight, or later when a DST change skips
+// midnight
+func Parse(value string, loc *time.Location) (time.Time, error) {
+	t, _, err := parse(value, loc)
+	return t, err
+}
+
+// ParseDeadline reads value like Parse, but takes a date-only value as the
+// last second of that day in loc, so a deadline of 2025-01-31 still
+// accepts applications on the 31st. The end of the day is found from the
+// start of the next, so days shortened or lengthened by a DST change end
+// at 23:59:59 local time too.
+func ParseDeadline(value string, loc *time.Location) (time.Time, error) {
+	t, dateOnly, err := parse(value, loc)
+	if err != nil || !dateOnly {
+		return t, err
+	}
+	y, m, d := t.Date()
+	return startOfDay(y, m, d+1, t.Location()).Add(-time.Second), nil
+}
+
+// Format writes t in the RFC 3339 form Parse reads back
+func Format(t time.Time) string {
+	return t.Format(time.RFC3339)
+}
+
+// parse reads value, reporting whether it was date-only
+func parse(value string, loc *time.Location) (time.Time, bool, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, false, nil
+	}
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		y, m, d := t.Date()
+		return startOfDay(y, m, d, loc), true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("%q %w", value, ErrFormat)
+}
+
+// startOfDay returns the first instant of a day in loc. That is midnight,
+// unless a DST change skips midnight, in which case the day starts when
+// the clocks go forward.
+func startOfDay(y int, m time.Month, d int, loc *time.Location) time.Time {
+	t := time.Date(y, m, d, 0, 0, 0, 0, loc)
+	if t.Day() != time.Date(y, m, d, 12, 0, 0, 0, loc).Day() {
+		// time.Date took the missing midnight as a time on the day before
+		_, t = t.ZoneBounds()
+	}
+	return t
+}
@@ -0,0 +1,148 @@
+package dates
+
+import (
+	"errors"
+	"testing"
+	"time"
+	_ "time/tzdata" // The zones below, without system zone files
+)
+
+// zone loads a time zone or fails the test
+func zone(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+func TestParse(t *testing.T) {
+	newYork := zone(t, "America/New_York")
+	kolkata := zone(t, "Asia/Kolkata")
+
+	tests := []struct {
+		value string
+		loc   *time.Location
+		want  string // In UTC
+	}{
+		// Timestamps keep their own offset, whatever the sandbox zone
+		{"2025-01-31T23:59:59Z", newYork, "2025-01-31T23:59:59Z"},
+		{"2025-01-31T23:59:59+05:30", nil, "2025-01-31T18:29:59Z"},
+		{"2025-01-31T23:59:59-08:00", kolkata, "2025-02-01T07:59:59Z"},
+		{"2025-06-30T00:00:00+14:00", nil, "2025-06-29T10:00:00Z"},
+		{"2025-06-30T00:00:00-12:00", nil, "2025-06-30T12:00:00Z"},
+		{"2025-03-09T02:30:00-05:00", newYork, "2025-03-09T07:30:00Z"}, // In New York's DST gap, but the offset is explicit
+		{"2025-01-31T23:59:59.5Z", nil, "2025-01-31T23:59:59.5Z"},
+
+		// Dates start at the first instant of the day in the sandbox zone
+		{"2025-01-31", nil, "2025-01-31T00:00:00Z"},
+		{"2025-01-31", newYork, "2025-01-31T05:00:00Z"},
+		{"2025-07-31", newYork, "2025-07-31T04:00:00Z"},
+		{"2025-01-31", kolkata, "2025-01-30T18:30:00Z"},
+		{"2025-03-09", zone(t, "America/Havana"), "2025-03-09T05:00:00Z"}, // Midnight is skipped, so 01:00
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.value, tt.loc)
+		if err != nil {
+			t.Errorf("Parse(%q, %v): %v", tt.value, tt.loc, err)
+			continue
+		}
+		if s := got.UTC().Format(time.RFC3339Nano); s != tt.want {
+			t.Errorf("Parse(%q, %v) = %s, want %s", tt.value, tt.loc, s, tt.want)
+		}
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	for _, value := range []string{
+		"",
+		"2025-01-31T23:59:59",  // No offset
+		"2025-01-31 23:59:59Z", // Space for T
+		"2025-1-31",
+		"31/01/2025",
+		"01/31/2025",
+		"2025-02-30",
+		"2025-13-01",
+		"2025-01-31T24:00:00Z",
+		"tomorrow",
+	} {
+		for _, parse := range []func(string, *time.Location) (time.Time, error){Parse, ParseDeadline} {
+			if got, err := parse(value, nil); !errors.Is(err, ErrFormat) {
+				t.Errorf("%q parsed as %v, %v, want ErrFormat", value, got, err)
+			}
+		}
+	}
+}
+
+// TestParseDeadlineAcrossDST checks a date-only deadline runs to 23:59:59
+// local time on its day, including days a DST change makes 23 or 25 hours
+// long, and days whose midnight is skipped
+func TestParseDeadlineAcrossDST(t *testing.T) {
+	tests := []struct {
+		zone  string
+		value string
+		want  string  // Local time
+		hours float64 // Length of the day the deadline falls on
+	}{
+		{"UTC", "2025-01-31", "2025-01-31T23:59:59Z", 24},
+		{"America/New_York", "2025-01-31", "2025-01-31T23:59:59-05:00", 24},
+		{"America/New_York", "2025-03-09", "2025-03-09T23:59:59-04:00", 23}, // Clocks go forward
+		{"America/New_York", "2025-03-08", "2025-03-08T23:59:59-05:00", 24}, // The day before
+		{"America/New_York", "2025-11-02", "2025-11-02T23:59:59-05:00", 25}, // Clocks go back
+		{"Europe/London", "2025-03-30", "2025-03-30T23:59:59+01:00", 23},
+		{"Europe/London", "2025-10-26", "2025-10-26T23:59:59Z", 25},
+		{"Australia/Sydney", "2025-04-06", "2025-04-06T23:59:59+10:00", 25}, // Southern hemisphere
+		{"Australia/Sydney", "2025-10-05", "2025-10-05T23:59:59+11:00", 23},
+		{"Australia/Lord_Howe", "2025-10-05", "2025-10-05T23:59:59+11:00", 23.5}, // A half-hour change
+		{"America/Havana", "2025-03-08", "2025-03-08T23:59:59-05:00", 24},        // The next midnight is skipped
+		{"America/Havana", "2025-03-09", "2025-03-09T23:59:59-04:00", 23},        // Starts at 01:00
+		{"Asia/Kolkata", "2025-01-31", "2025-01-31T23:59:59+05:30", 24},
+		{"Asia/Kathmandu", "2025-01-31", "2025-01-31T23:59:59+05:45", 24},
+	}
+	for _, tt := range tests {
+		loc := zone(t, tt.zone)
+		got, err := ParseDeadline(tt.value, loc)
+		if err != nil {
+			t.Errorf("%s in %s: %v", tt.value, tt.zone, err)
+			continue
+		}
+		if s := got.Format(time.RFC3339); s != tt.want {
+			t.Errorf("%s in %s ends %s, want %s", tt.value, tt.zone, s, tt.want)
+		}
+
+		start, _ := Parse(tt.value, loc)
+		if hours := got.Add(time.Second).Sub(start).Hours(); hours != tt.hours {
+			t.Errorf("%s in %s is %gh long, want %gh", tt.value, tt.zone, hours, tt.hours)
+		}
+	}
+}
+
+// TestParseDeadlineTimestamp checks a deadline with a time is taken as
+// written, not moved to the end of its day
+func TestParseDeadlineTimestamp(t *testing.T) {
+	newYork := zone(t, "America/New_York")
+	for _, value := range []string{"2025-03-09T12:00:00Z", "2025-03-09T03:00:00-04:00", "2025-11-02T01:30:00-05:00"} {
+		got, err := ParseDeadline(value, newYork)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want, _ := time.Parse(time.RFC3339, value); !got.Equal(want) {
+			t.Errorf("ParseDeadline(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestFormatRoundTrips(t *testing.T) {
+	kathmandu := zone(t, "Asia/Kathmandu")
+	for _, value := range []string{"2025-01-31", "2025-01-31T23:59:59-03:30", "2025-11-02T01:30:00-04:00"} {
+		parsed, err := ParseDeadline(value, kathmandu)
+		if err != nil {
+			t.Fatal(err)
+		}
+		again, err := ParseDeadline(Format(parsed), nil)
+		if err != nil || !again.Equal(parsed) {
+			t.Errorf("%s formats as %s, which reads back as %v, %v", value, Format(parsed), again, err)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Package emailaddr validates and normalizes applicant email addresses. An
+// address must parse with net/mail as a bare address (no display name or
+// comments) and its domain must be a syntactically valid ASCII host name.
+// Unicode local parts and blocked (e.g. disposable-mail) domains are
+// accepted or rejected according to Rules.
+package emailaddr
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/fold"
+)
+
+const (
+	maxAddressLength = 254
+	maxLocalLength   = 64
+	maxLabelLength   = 63
+)
+
+// Errors returned by Validate
+var (
+	ErrInvalid      = errors.New("invalid email address")
+	ErrUnicodeLocal = errors.New("email local part contains non-ASCII characters")
+	ErrDisposable   = errors.New("email domain is blocked")
+)
+
+// Rules configure which syntactically valid addresses are accepted
+type Rules struct {
+	// AllowUnicodeLocal accepts non-ASCII characters before the @ (RFC 6531)
+	AllowUnicodeLocal bool
+	// BlockedDomains rejects addresses at these domains and their subdomains
+	BlockedDomains []string
+}
+
+// Normalize returns addr trimmed and with its domain case-folded, the form
+// addresses are stored and compared in. It does not validate.
+func Normalize(addr string) string {
+	addr = strings.TrimSpace(addr)
+	at := strings.LastIndex(addr, "@")
+	if at < 0 {
+		return addr
+	}
+	return addr[:at+1] + fold.String(addr[at+1:])
+}
+
+// Validate checks addr against the rules and returns its normalized form
+func Validate(addr string, rules Rules) (string, error) {
+	addr = Normalize(addr)
+	if len(addr) > maxAddressLength {
+		return "", ErrInvalid
+	}
+
+	parsed, err := mail.ParseAddress(addr)
+	if err != nil || parsed.Name != "" || parsed.Address != addr {
+		return "", ErrInvalid
+	}
+
+	at := strings.LastIndex(addr, "@")
+	local, domain := addr[:at], addr[at+1:]
+	if local == "" || len(local) > maxLocalLength || !validDomain(domain) {
+		return "", ErrInvalid
+	}
+	if !rules.AllowUnicodeLocal && !isASCII(local) {
+		return "", ErrUnicodeLocal
+	}
+
+	for _, blocked := range rules.BlockedDomains {
+		blocked = fold.String(strings.TrimSpace(blocked))
+		if blocked != "" && (domain == blocked || strings.HasSuffix(domain, "."+blocked)) {
+			return "", ErrDisposable
+		}
+	}
+
+	return addr, nil
+}
+
+// validDomain reports whether domain is a lowercase ASCII host name of at
+// least two labels, each 1-63 letters, digits or inner hyphens (with "--"
+// in third and fourth place only for punycode xn-- labels), ending in an
+// alphabetic or punycode top-level domain
+func validDomain(domain string) bool {
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 {
+		return false
+	}
+	for _, label := range labels {
+		if label == "" || len(label) > maxLabelLength || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		if len(label) >= 4 && label[2:4] == "--" && !strings.HasPrefix(label, "xn--") {
+			return false
+		}
+		for i := 0; i < len(label); i++ {
+			c := label[i]
+			if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+
+	tld := labels[len(labels)-1]
+	if strings.HasPrefix(tld, "xn--") {
+		return true
+	}
+	if len(tld) < 2 {
+		return false
+	}
+	for i := 0; i < len(tld); i++ {
+		if tld[i] < 'a' || tld[i] > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// isASCII reports whether s has only ASCII characters
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,24 @@
+package emailaddr
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		addr string
+		want string
+	}{
+		{"vic@example.com", "vic@example.com"},
+		{"  vic@Example.COM\n", "vic@example.com"},
+		{"Vic.Tester@EXAMPLE.com", "Vic.Tester@example.com"}, // The local part is kept as written
+		{"vic@BÜCHER.DE", "vic@bücher.de"},
+		{"vic@İSTANBUL.EXAMPLE", "vic@istanbul.example"},
+		{"ВИК@ПОЧТА.РФ", "ВИК@почта.рф"},
+		{"a@b@EXAMPLE.COM", "a@b@example.com"},
+		{"no-at-sign", "no-at-sign"},
+	}
+	for _, tt := range tests {
+		if got := Normalize(tt.addr); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.addr, got, tt.want)
+		}
+	}
+}
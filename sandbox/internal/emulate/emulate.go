@@ -0,0 +1,78 @@
+// Package emulate maps sandbox jobs and applications to and from the
+// public JSON shapes of real applicant tracking systems, so agents written
+// against those APIs can be pointed at the sandbox unchanged.
+package emulate
+
+import (
+	"hash/fnv"
+	"html"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+)
+
+// The -ats-profile values: the sandbox's own API, or the ATS whose API and
+// error bodies it mimics
+const (
+	ProfileNative     = "native"
+	ProfileGreenhouse = "greenhouse"
+	ProfileLever      = "lever"
+)
+
+// Profiles lists the valid ATS profiles
+var Profiles = []string{ProfileNative, ProfileGreenhouse, ProfileLever}
+
+// BoardToken returns the board or site slug for a company, e.g. "Goldman Sachs" -> "goldmansachs"
+func BoardToken(company string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(company) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// numericID derives a stable integer ID from a sandbox ID such as "job_042".
+// IDs without digits fall back to a hash so every job still gets a number.
+func numericID(id string) int64 {
+	digits := strings.TrimLeftFunc(id, func(r rune) bool { return !unicode.IsDigit(r) })
+	if n, err := strconv.ParseInt(digits, 10, 64); err == nil {
+		return n
+	}
+	return hashID(id)
+}
+
+// hashID derives a stable positive integer from a name
+func hashID(name string) int64 {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return int64(h.Sum32() % 1000000)
+}
+
+// contentHTML renders a job description, requirements and benefits as HTML
+func contentHTML(job models.Job) string {
+	var b strings.Builder
+	b.WriteString("<p>" + html.EscapeString(job.Description) + "</p>")
+	writeHTMLList(&b, "Requirements", job.Requirements)
+	writeHTMLList(&b, "Benefits", job.Benefits)
+	return b.String()
+}
+
+func writeHTMLList(b *strings.Builder, heading string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	b.WriteString("<h3>" + heading + "</h3><ul>" + listItemsHTML(items) + "</ul>")
+}
+
+// listItemsHTML renders items as escaped <li> elements
+func listItemsHTML(items []string) string {
+	var b strings.Builder
+	for _, item := range items {
+		b.WriteString("<li>" + html.EscapeString(item) + "</li>")
+	}
+	return b.String()
+}
@@ -0,0 +1,133 @@
+package emulate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+)
+
+// testJob is a sandbox job with every field the mappings read
+var testJob = models.Job{
+	ID:           "job_042",
+	Title:        "Senior Backend Engineer",
+	Company:      "Vault Tec",
+	Description:  "Build the systems that keep the vaults <running>.",
+	Requirements: []string{"Go", "PostgreSQL"},
+	Benefits:     []string{"Health & dental"},
+	Location:     "San Francisco, CA",
+	Salary:       "$150,000 - $180,000",
+	JobType:      "full-time",
+	Industry:     "Engineering",
+	Posted:       time.Date(2026, 1, 12, 19, 3, 51, 0, time.UTC),
+	Questions: []models.ScreeningQuestion{
+		{ID: "work_auth", Label: "Are you authorized to work in the US?", Type: models.QuestionBoolean, Required: true},
+		{ID: "language", Label: "Primary language", Type: models.QuestionSelect, Options: []string{"Go", "Rust"}},
+		{ID: "why_us", Label: "Why us?", Type: models.QuestionText},
+	},
+}
+
+// shape flattens a JSON document into the JSON type found at each path,
+// such as "questions[].fields[].name": "string"
+func shape(t *testing.T, v interface{}) map[string]string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc interface{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		t.Fatal(err)
+	}
+	paths := make(map[string]string)
+	var walk func(path string, v interface{})
+	walk = func(path string, v interface{}) {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			paths[path] = "object"
+			for k, field := range v {
+				walk(strings.TrimPrefix(path+"."+k, "."), field)
+			}
+		case []interface{}:
+			paths[path] = "array"
+			for _, item := range v {
+				walk(path+"[]", item)
+			}
+		case string:
+			paths[path] = "string"
+		case float64:
+			paths[path] = "number"
+		case bool:
+			paths[path] = "boolean"
+		case nil:
+			paths[path] = "null"
+		}
+	}
+	walk("", doc)
+	delete(paths, "")
+	return paths
+}
+
+// compareShape checks a mapped response against a recorded one: every
+// field it has must be in the fixture with the same type, and every
+// fixture field must be mapped unless listed in notEmulated, by path or
+// path prefix. differs lists paths whose type knowingly differs.
+func compareShape(t *testing.T, fixture string, got interface{}, notEmulated, differs []string) {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("testdata", fixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var recorded interface{}
+	if err := json.Unmarshal(b, &recorded); err != nil {
+		t.Fatalf("%s: %v", fixture, err)
+	}
+	want, have := shape(t, recorded), shape(t, got)
+
+	listed := func(path string, list []string) bool {
+		for _, prefix := range list {
+			if path == prefix || strings.HasPrefix(path, prefix+".") || strings.HasPrefix(path, prefix+"[]") {
+				return true
+			}
+		}
+		return false
+	}
+	var problems []string
+	for path, typ := range have {
+		switch wantType, ok := want[path]; {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("%s is not in the fixture", path))
+		case wantType != typ && wantType != "null" && typ != "null" && !listed(path, differs):
+			problems = append(problems, fmt.Sprintf("%s is a %s, the fixture has a %s", path, typ, wantType))
+		}
+	}
+	for path := range want {
+		if _, ok := have[path]; !ok && !listed(path, notEmulated) {
+			problems = append(problems, fmt.Sprintf("%s is missing", path))
+		}
+	}
+	sort.Strings(problems)
+	for _, problem := range problems {
+		t.Errorf("%s: %s", fixture, problem)
+	}
+}
+
+func TestBoardToken(t *testing.T) {
+	tests := map[string]string{
+		"Google":        "google",
+		"Goldman Sachs": "goldmansachs",
+		"AT&T":          "att",
+		"Vault-Tec 2":   "vaulttec2",
+	}
+	for company, want := range tests {
+		if got := BoardToken(company); got != want {
+			t.Errorf("BoardToken(%q) = %q, want %q", company, got, want)
+		}
+	}
+}
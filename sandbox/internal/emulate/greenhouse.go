@@ -0,0 +1,252 @@
+package emulate
+
+import (
+	"html"
+	"strings"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+)
+
+// greenhouseIDBase offsets job numbers into the range of real Greenhouse job IDs
+const greenhouseIDBase = 4000000
+
+// GreenhouseJob is a job in the Greenhouse job board API
+type GreenhouseJob struct {
+	ID             int64                  `json:"id"`
+	InternalJobID  int64                  `json:"internal_job_id"`
+	Title          string                 `json:"title"`
+	CompanyName    string                 `json:"company_name"`
+	UpdatedAt      string                 `json:"updated_at"`
+	FirstPublished string                 `json:"first_published"`
+	RequisitionID  string                 `json:"requisition_id"`
+	Location       GreenhouseLocation     `json:"location"`
+	AbsoluteURL    string                 `json:"absolute_url"`
+	Metadata       []GreenhouseMetadata   `json:"metadata"`
+	Content        string                 `json:"content,omitempty"`
+	Departments    []GreenhouseDepartment `json:"departments,omitempty"`
+	Offices        []GreenhouseOffice     `json:"offices,omitempty"`
+	Questions      []GreenhouseQuestion   `json:"questions,omitempty"`
+}
+
+// GreenhouseLocation is the location name of a job
+type GreenhouseLocation struct {
+	Name string `json:"name"`
+}
+
+// GreenhouseMetadata is a custom job field
+type GreenhouseMetadata struct {
+	ID        int64       `json:"id"`
+	Name      string      `json:"name"`
+	Value     interface{} `json:"value"`
+	ValueType string      `json:"value_type"`
+}
+
+// GreenhouseDepartment is a department a job belongs to
+type GreenhouseDepartment struct {
+	ID       int64   `json:"id"`
+	Name     string  `json:"name"`
+	ParentID *int64  `json:"parent_id"`
+	ChildIDs []int64 `json:"child_ids"`
+}
+
+// GreenhouseOffice is an office a job belongs to
+type GreenhouseOffice struct {
+	ID       int64   `json:"id"`
+	Name     string  `json:"name"`
+	Location string  `json:"location"`
+	ParentID *int64  `json:"parent_id"`
+	ChildIDs []int64 `json:"child_ids"`
+}
+
+// GreenhouseQuestion is an application form question
+type GreenhouseQuestion struct {
+	Required    bool                      `json:"required"`
+	Label       string                    `json:"label"`
+	Description *string                   `json:"description"`
+	Fields      []GreenhouseQuestionField `json:"fields"`
+}
+
+// GreenhouseQuestionField is an input of an application form question
+type GreenhouseQuestionField struct {
+	Name   string        `json:"name"`
+	Type   string        `json:"type"`
+	Values []interface{} `json:"values"`
+}
+
+// GreenhouseJobsResponse is the response for listing a board's jobs
+type GreenhouseJobsResponse struct {
+	Jobs []GreenhouseJob `json:"jobs"`
+	Meta GreenhouseMeta  `json:"meta"`
+}
+
+// GreenhouseMeta carries list metadata
+type GreenhouseMeta struct {
+	Total int `json:"total"`
+}
+
+// GreenhouseError is the error body returned by the job board API
+type GreenhouseError struct {
+	Status int    `json:"status"`
+	Error  string `json:"error"`
+}
+
+// GreenhouseSuccess is returned after an application is accepted
+type GreenhouseSuccess struct {
+	Success string `json:"success"`
+}
+
+// GreenhouseJobID returns the Greenhouse job ID for a sandbox job
+func GreenhouseJobID(job models.Job) int64 {
+	return greenhouseIDBase + numericID(job.ID)
+}
+
+// GreenhouseJobFromModel maps a sandbox job to its Greenhouse representation.
+// content adds the HTML-escaped description, departments and offices, as the
+// real API does for ?content=true and on the job detail endpoint.
+func GreenhouseJobFromModel(job models.Job, absoluteURL string, content bool) GreenhouseJob {
+	id := GreenhouseJobID(job)
+	gj := GreenhouseJob{
+		ID:             id,
+		InternalJobID:  id - 1000000,
+		Title:          job.Title,
+		CompanyName:    job.Company,
+		UpdatedAt:      greenhouseTime(job.Posted),
+		FirstPublished: greenhouseTime(job.Posted),
+		RequisitionID:  strings.ToUpper(job.ID),
+		Location:       GreenhouseLocation{Name: greenhouseLocation(job)},
+		AbsoluteURL:    absoluteURL,
+		Metadata: []GreenhouseMetadata{
+			{ID: hashID("Employment Type"), Name: "Employment Type", Value: job.JobType, ValueType: "single_select"},
+		},
+	}
+
+	if !content {
+		return gj
+	}
+
+	// Greenhouse returns content as escaped HTML
+	gj.Content = html.EscapeString(contentHTML(job))
+
+	department := job.Industry
+	if department == "" {
+		department = "General"
+	}
+	gj.Departments = []GreenhouseDepartment{{ID: hashID(department), Name: department, ChildIDs: []int64{}}}
+	gj.Offices = []GreenhouseOffice{{ID: hashID(job.Location), Name: job.Location, Location: job.Location, ChildIDs: []int64{}}}
+
+	return gj
+}
+
+// GreenhouseQuestions returns the standard application form questions,
+// followed by the job's screening questions as question_<id> fields
+func GreenhouseQuestions(job models.Job) []GreenhouseQuestion {
+	text := func(required bool, label, name string) GreenhouseQuestion {
+		return GreenhouseQuestion{Required: required, Label: label,
+			Fields: []GreenhouseQuestionField{{Name: name, Type: "input_text", Values: []interface{}{}}}}
+	}
+	file := func(required bool, label, name string) GreenhouseQuestion {
+		return GreenhouseQuestion{Required: required, Label: label, Fields: []GreenhouseQuestionField{
+			{Name: name, Type: "input_file", Values: []interface{}{}},
+			{Name: name + "_text", Type: "textarea", Values: []interface{}{}},
+		}}
+	}
+	questions := []GreenhouseQuestion{
+		text(true, "First Name", "first_name"),
+		text(true, "Last Name", "last_name"),
+		text(true, "Email", "email"),
+		text(false, "Phone", "phone"),
+		file(true, "Resume/CV", "resume"),
+		file(false, "Cover Letter", "cover_letter"),
+		text(false, "LinkedIn Profile", "question_linkedin"),
+		text(false, "Website", "question_website"),
+	}
+	for _, q := range job.Questions {
+		question := text(q.Required, q.Label, "question_"+q.ID)
+		field := &question.Fields[0]
+		switch q.Type {
+		case models.QuestionSelect:
+			field.Type = "multi_value_single_select"
+			for _, option := range q.Options {
+				field.Values = append(field.Values, map[string]string{"label": option, "value": option})
+			}
+		case models.QuestionBoolean:
+			field.Type = "multi_value_single_select"
+			field.Values = append(field.Values,
+				map[string]string{"label": "Yes", "value": "true"},
+				map[string]string{"label": "No", "value": "false"})
+		}
+		questions = append(questions, question)
+	}
+	return questions
+}
+
+// GreenhouseApplicationRequest maps submitted application form fields to an
+// application request. File uploads are passed in as their text content under
+// the field name; the *_text variants take precedence over uploads. Answers
+// to the job's screening questions are keyed by question ID, other custom
+// questions by field name.
+func GreenhouseApplicationRequest(job models.Job, fields map[string]string) models.ApplicationRequest {
+	req := models.ApplicationRequest{
+		JobID:          job.ID,
+		ApplicantName:  strings.TrimSpace(fields["first_name"] + " " + fields["last_name"]),
+		ApplicantEmail: fields["email"],
+		Phone:          fields["phone"],
+		Resume:         firstNonEmpty(fields["resume_text"], fields["resume"]),
+		CoverLetter:    firstNonEmpty(fields["cover_letter_text"], fields["cover_letter"]),
+		LinkedIn:       fields["question_linkedin"],
+		Portfolio:      fields["question_website"],
+	}
+
+	for name, value := range fields {
+		if strings.HasPrefix(name, "question_") && name != "question_linkedin" && name != "question_website" {
+			if req.CustomAnswers == nil {
+				req.CustomAnswers = make(map[string]string)
+			}
+			req.CustomAnswers[answerKey(job, name, "question_", "")] = value
+		}
+	}
+
+	return req
+}
+
+// greenhouseLocation names the job location, noting remote roles
+func greenhouseLocation(job models.Job) string {
+	if job.IsRemote && !strings.Contains(strings.ToLower(job.Location), "remote") {
+		return job.Location + " (Remote)"
+	}
+	return job.Location
+}
+
+// greenhouseTime formats a timestamp with an explicit offset
+func greenhouseTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02T15:04:05-07:00")
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// answerKey keys the answer in form field name: by question ID when name is
+// prefix, a screening question ID of job and suffix, by name otherwise
+func answerKey(job models.Job, name, prefix, suffix string) string {
+	id, hasPrefix := strings.CutPrefix(name, prefix)
+	id, hasSuffix := strings.CutSuffix(id, suffix)
+	if !hasPrefix || !hasSuffix {
+		return name
+	}
+	for _, q := range job.Questions {
+		if q.ID == id {
+			return id
+		}
+	}
+	return name
+}
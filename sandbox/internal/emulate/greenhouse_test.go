@@ -0,0 +1,146 @@
+package emulate
+
+import (
+	"html"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+)
+
+func TestGreenhouseJobMatchesFixture(t *testing.T) {
+	gj := GreenhouseJobFromModel(testJob, "http://localhost:8080/jobs/job_042", true)
+	gj.Questions = GreenhouseQuestions(testJob)
+
+	compareShape(t, "greenhouse_job.json", gj,
+		[]string{"language", "data_compliance"},
+		// Select values are the sandbox's option strings rather than
+		// Greenhouse's numeric option IDs
+		[]string{"questions[].fields[].values[].value"})
+}
+
+func TestGreenhouseJobFromModel(t *testing.T) {
+	gj := GreenhouseJobFromModel(testJob, "http://localhost:8080/jobs/job_042", true)
+
+	if gj.ID != 4000042 || gj.InternalJobID != 3000042 {
+		t.Errorf("IDs %d and %d, want 4000042 and 3000042", gj.ID, gj.InternalJobID)
+	}
+	if gj.RequisitionID != "JOB_042" || gj.CompanyName != "Vault Tec" || gj.Title != testJob.Title {
+		t.Errorf("job = %+v", gj)
+	}
+	if gj.UpdatedAt != "2026-01-12T19:03:51+00:00" {
+		t.Errorf("updated_at %q, want an explicit offset", gj.UpdatedAt)
+	}
+	if gj.AbsoluteURL != "http://localhost:8080/jobs/job_042" {
+		t.Errorf("absolute_url %q", gj.AbsoluteURL)
+	}
+	if gj.Metadata[0].Name != "Employment Type" || gj.Metadata[0].Value != "full-time" {
+		t.Errorf("metadata %+v", gj.Metadata)
+	}
+
+	// content is HTML, escaped once more as Greenhouse sends it
+	content := html.UnescapeString(gj.Content)
+	for _, want := range []string{
+		"<p>Build the systems that keep the vaults &lt;running&gt;.</p>",
+		"<h3>Requirements</h3><ul><li>Go</li><li>PostgreSQL</li></ul>",
+		"<h3>Benefits</h3><ul><li>Health &amp; dental</li></ul>",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("content %q lacks %q", content, want)
+		}
+	}
+	if len(gj.Departments) != 1 || gj.Departments[0].Name != "Engineering" || gj.Departments[0].ChildIDs == nil {
+		t.Errorf("departments %+v", gj.Departments)
+	}
+	if len(gj.Offices) != 1 || gj.Offices[0].Location != testJob.Location {
+		t.Errorf("offices %+v", gj.Offices)
+	}
+
+	list := GreenhouseJobFromModel(testJob, "", false)
+	if list.Content != "" || list.Departments != nil || list.Offices != nil {
+		t.Errorf("list entry without content has content, departments or offices: %+v", list)
+	}
+}
+
+func TestGreenhouseLocation(t *testing.T) {
+	tests := []struct {
+		location string
+		remote   bool
+		want     string
+	}{
+		{"Austin, TX", false, "Austin, TX"},
+		{"Austin, TX", true, "Austin, TX (Remote)"},
+		{"Remote - US", true, "Remote - US"},
+	}
+	for _, tt := range tests {
+		job := models.Job{Location: tt.location, IsRemote: tt.remote}
+		if got := GreenhouseJobFromModel(job, "", false).Location.Name; got != tt.want {
+			t.Errorf("location of %q (remote %v) = %q, want %q", tt.location, tt.remote, got, tt.want)
+		}
+	}
+}
+
+func TestGreenhouseQuestions(t *testing.T) {
+	questions := GreenhouseQuestions(testJob)
+	byName := make(map[string]GreenhouseQuestion)
+	for _, q := range questions {
+		byName[q.Fields[0].Name] = q
+	}
+
+	for _, name := range []string{"first_name", "last_name", "email", "resume"} {
+		if !byName[name].Required {
+			t.Errorf("%s is not required", name)
+		}
+	}
+	if fields := byName["resume"].Fields; len(fields) != 2 || fields[0].Type != "input_file" || fields[1].Name != "resume_text" {
+		t.Errorf("resume fields %+v, want a file and a resume_text textarea", fields)
+	}
+
+	workAuth := byName["question_work_auth"]
+	if !workAuth.Required || workAuth.Fields[0].Type != "multi_value_single_select" || len(workAuth.Fields[0].Values) != 2 {
+		t.Errorf("boolean question %+v", workAuth)
+	}
+	language := byName["question_language"].Fields[0]
+	want := []interface{}{map[string]string{"label": "Go", "value": "Go"}, map[string]string{"label": "Rust", "value": "Rust"}}
+	if language.Type != "multi_value_single_select" || !reflect.DeepEqual(language.Values, want) {
+		t.Errorf("select question %+v", language)
+	}
+	if byName["question_why_us"].Fields[0].Type != "input_text" {
+		t.Errorf("text question %+v", byName["question_why_us"])
+	}
+}
+
+func TestGreenhouseApplicationRequest(t *testing.T) {
+	fields := map[string]string{
+		"first_name":         " Vic ",
+		"last_name":          "Tester",
+		"email":              "vic@example.com",
+		"phone":              "+1 555 0100",
+		"resume":             "uploaded resume",
+		"resume_text":        "pasted resume",
+		"cover_letter":       "uploaded letter",
+		"question_linkedin":  "https://linkedin.com/in/vic",
+		"question_website":   "https://vic.dev",
+		"question_work_auth": "true",
+		"question_28475":     "1",
+	}
+	got := GreenhouseApplicationRequest(testJob, fields)
+	want := models.ApplicationRequest{
+		JobID:          "job_042",
+		ApplicantName:  "Vic  Tester",
+		ApplicantEmail: "vic@example.com",
+		Phone:          "+1 555 0100",
+		Resume:         "pasted resume",
+		CoverLetter:    "uploaded letter",
+		LinkedIn:       "https://linkedin.com/in/vic",
+		Portfolio:      "https://vic.dev",
+		CustomAnswers: map[string]string{
+			"work_auth":      "true", // A screening question, by its ID
+			"question_28475": "1",    // Any other question, by field name
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("request\n%+v\nwant\n%+v", got, want)
+	}
+}
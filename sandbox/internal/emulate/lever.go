@@ -0,0 +1,149 @@
+package emulate
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+)
+
+// LeverPosting is a posting in the Lever postings API
+type LeverPosting struct {
+	ID               string          `json:"id"`
+	Text             string          `json:"text"`
+	Categories       LeverCategories `json:"categories"`
+	WorkplaceType    string          `json:"workplaceType"`
+	Description      string          `json:"description"`
+	DescriptionPlain string          `json:"descriptionPlain"`
+	Lists            []LeverList     `json:"lists"`
+	Additional       string          `json:"additional"`
+	AdditionalPlain  string          `json:"additionalPlain"`
+	HostedURL        string          `json:"hostedUrl"`
+	ApplyURL         string          `json:"applyUrl"`
+	CreatedAt        int64           `json:"createdAt"`
+}
+
+// LeverCategories groups a posting's team, location and commitment
+type LeverCategories struct {
+	Commitment   string   `json:"commitment,omitempty"`
+	Department   string   `json:"department,omitempty"`
+	Location     string   `json:"location,omitempty"`
+	Team         string   `json:"team,omitempty"`
+	AllLocations []string `json:"allLocations,omitempty"`
+}
+
+// LeverList is a titled group of HTML list items
+type LeverList struct {
+	Text    string `json:"text"`
+	Content string `json:"content"`
+}
+
+// LeverApplyResponse is returned after an application is accepted
+type LeverApplyResponse struct {
+	OK            bool   `json:"ok"`
+	ApplicationID string `json:"applicationId"`
+}
+
+// LeverError is the error body returned by the postings API
+type LeverError struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error"`
+}
+
+// leverCommitments maps sandbox job types to Lever commitment names
+var leverCommitments = map[string]string{
+	"full-time":  "Full-time",
+	"part-time":  "Part-time",
+	"internship": "Intern",
+	"contract":   "Contract",
+}
+
+// LeverPostingID returns the Lever posting ID (a UUID) for a sandbox job
+func LeverPostingID(job models.Job) string {
+	sum := sha1.Sum([]byte("lever:" + job.ID))
+	sum[6] = (sum[6] & 0x0f) | 0x50 // version 5
+	sum[8] = (sum[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
+
+// LeverCommitment returns the Lever commitment for a sandbox job type
+func LeverCommitment(jobType string) string {
+	if commitment, ok := leverCommitments[jobType]; ok {
+		return commitment
+	}
+	return jobType
+}
+
+// LeverPostingFromModel maps a sandbox job to its Lever representation.
+// hostedURL is the posting page; the apply URL is derived from it.
+func LeverPostingFromModel(job models.Job, hostedURL string) LeverPosting {
+	posting := LeverPosting{
+		ID:   LeverPostingID(job),
+		Text: job.Title,
+		Categories: LeverCategories{
+			Commitment:   LeverCommitment(job.JobType),
+			Department:   job.Industry,
+			Location:     job.Location,
+			Team:         job.Industry,
+			AllLocations: []string{job.Location},
+		},
+		WorkplaceType:    "onsite",
+		Description:      "<div>" + html.EscapeString(job.Description) + "</div>",
+		DescriptionPlain: job.Description,
+		Lists:            []LeverList{},
+		HostedURL:        hostedURL,
+		ApplyURL:         hostedURL + "/apply",
+	}
+
+	if job.IsRemote {
+		posting.WorkplaceType = "remote"
+	}
+	if !job.Posted.IsZero() {
+		posting.CreatedAt = job.Posted.UnixMilli()
+	}
+
+	if len(job.Requirements) > 0 {
+		posting.Lists = append(posting.Lists, LeverList{Text: "Requirements", Content: listItemsHTML(job.Requirements)})
+	}
+	if len(job.Benefits) > 0 {
+		posting.Lists = append(posting.Lists, LeverList{Text: "Benefits", Content: listItemsHTML(job.Benefits)})
+	}
+
+	if job.Salary != "" {
+		posting.AdditionalPlain = "Compensation: " + job.Salary
+		posting.Additional = "<div>" + html.EscapeString(posting.AdditionalPlain) + "</div>"
+	}
+
+	return posting
+}
+
+// LeverApplicationRequest maps submitted apply form fields to an
+// application request. File uploads are passed in as their text content.
+// Answers to the job's screening questions, sent as customQuestions[<id>],
+// are keyed by question ID, other cards by field name.
+func LeverApplicationRequest(job models.Job, fields map[string]string) models.ApplicationRequest {
+	req := models.ApplicationRequest{
+		JobID:          job.ID,
+		ApplicantName:  strings.TrimSpace(fields["name"]),
+		ApplicantEmail: fields["email"],
+		Phone:          fields["phone"],
+		Resume:         fields["resume"],
+		CoverLetter:    fields["comments"],
+		LinkedIn:       fields["urls[LinkedIn]"],
+		GitHub:         fields["urls[GitHub]"],
+		Portfolio:      firstNonEmpty(fields["urls[Portfolio]"], fields["urls[Other]"]),
+	}
+
+	for name, value := range fields {
+		if strings.HasPrefix(name, "cards[") || strings.HasPrefix(name, "customQuestions[") {
+			if req.CustomAnswers == nil {
+				req.CustomAnswers = make(map[string]string)
+			}
+			req.CustomAnswers[answerKey(job, name, "customQuestions[", "]")] = value
+		}
+	}
+
+	return req
+}
@@ -0,0 +1,160 @@
+package emulate
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+)
+
+const hostedURL = "http://localhost:8080/lever/vaulttec/job_042"
+
+func TestLeverPostingMatchesFixture(t *testing.T) {
+	compareShape(t, "lever_posting.json", LeverPostingFromModel(testJob, hostedURL),
+		[]string{"country", "descriptionBody", "descriptionBodyPlain", "opening", "openingPlain"}, nil)
+}
+
+func TestLeverPostingFromModel(t *testing.T) {
+	posting := LeverPostingFromModel(testJob, hostedURL)
+
+	if posting.ID != LeverPostingID(testJob) || posting.Text != testJob.Title {
+		t.Errorf("posting %+v", posting)
+	}
+	want := LeverCategories{
+		Commitment:   "Full-time",
+		Department:   "Engineering",
+		Location:     "San Francisco, CA",
+		Team:         "Engineering",
+		AllLocations: []string{"San Francisco, CA"},
+	}
+	if !reflect.DeepEqual(posting.Categories, want) {
+		t.Errorf("categories %+v, want %+v", posting.Categories, want)
+	}
+	if posting.Description != "<div>Build the systems that keep the vaults &lt;running&gt;.</div>" || posting.DescriptionPlain != testJob.Description {
+		t.Errorf("description %q, plain %q", posting.Description, posting.DescriptionPlain)
+	}
+	lists := []LeverList{
+		{Text: "Requirements", Content: "<li>Go</li><li>PostgreSQL</li>"},
+		{Text: "Benefits", Content: "<li>Health &amp; dental</li>"},
+	}
+	if !reflect.DeepEqual(posting.Lists, lists) {
+		t.Errorf("lists %+v, want %+v", posting.Lists, lists)
+	}
+	if posting.AdditionalPlain != "Compensation: $150,000 - $180,000" || posting.Additional != "<div>Compensation: $150,000 - $180,000</div>" {
+		t.Errorf("additional %q, plain %q", posting.Additional, posting.AdditionalPlain)
+	}
+	if posting.HostedURL != hostedURL || posting.ApplyURL != hostedURL+"/apply" {
+		t.Errorf("hosted %q, apply %q", posting.HostedURL, posting.ApplyURL)
+	}
+	if posting.CreatedAt != testJob.Posted.UnixMilli() {
+		t.Errorf("createdAt %d, want milliseconds %d", posting.CreatedAt, testJob.Posted.UnixMilli())
+	}
+	if posting.WorkplaceType != "onsite" {
+		t.Errorf("workplaceType %q, want onsite", posting.WorkplaceType)
+	}
+
+	remote := testJob
+	remote.IsRemote = true
+	if got := LeverPostingFromModel(remote, hostedURL).WorkplaceType; got != "remote" {
+		t.Errorf("workplaceType of a remote job %q, want remote", got)
+	}
+
+	bare := LeverPostingFromModel(models.Job{ID: "job_bare"}, hostedURL)
+	if bare.Lists == nil || len(bare.Lists) != 0 || bare.Additional != "" || bare.CreatedAt != 0 {
+		t.Errorf("posting of a bare job %+v", bare)
+	}
+}
+
+func TestLeverPostingID(t *testing.T) {
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-5[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	id := LeverPostingID(testJob)
+	if !uuid.MatchString(id) {
+		t.Errorf("LeverPostingID = %q, want a version 5 UUID", id)
+	}
+	if LeverPostingID(testJob) != id {
+		t.Error("LeverPostingID is not stable")
+	}
+	if LeverPostingID(models.Job{ID: "job_043"}) == id {
+		t.Error("two jobs share a posting ID")
+	}
+}
+
+func TestLeverCommitment(t *testing.T) {
+	tests := map[string]string{
+		"full-time":  "Full-time",
+		"part-time":  "Part-time",
+		"internship": "Intern",
+		"contract":   "Contract",
+		"temporary":  "temporary",
+	}
+	for jobType, want := range tests {
+		if got := LeverCommitment(jobType); got != want {
+			t.Errorf("LeverCommitment(%q) = %q, want %q", jobType, got, want)
+		}
+	}
+}
+
+func TestLeverApplicationRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields map[string]string
+		want   models.ApplicationRequest
+	}{
+		{
+			name: "standard fields",
+			fields: map[string]string{
+				"name":           " Vic Tester ",
+				"email":          "vic@example.com",
+				"phone":          "+1 555 0100",
+				"resume":         "resume text",
+				"comments":       "cover letter",
+				"urls[LinkedIn]": "https://linkedin.com/in/vic",
+				"urls[GitHub]":   "https://github.com/vic",
+			},
+			want: models.ApplicationRequest{
+				JobID:          "job_042",
+				ApplicantName:  "Vic Tester",
+				ApplicantEmail: "vic@example.com",
+				Phone:          "+1 555 0100",
+				Resume:         "resume text",
+				CoverLetter:    "cover letter",
+				LinkedIn:       "https://linkedin.com/in/vic",
+				GitHub:         "https://github.com/vic",
+			},
+		},
+		{
+			name:   "portfolio before other",
+			fields: map[string]string{"urls[Portfolio]": "https://vic.dev", "urls[Other]": "https://vic.blog"},
+			want:   models.ApplicationRequest{JobID: "job_042", Portfolio: "https://vic.dev"},
+		},
+		{
+			name:   "other as portfolio",
+			fields: map[string]string{"urls[Other]": "https://vic.blog"},
+			want:   models.ApplicationRequest{JobID: "job_042", Portfolio: "https://vic.blog"},
+		},
+		{
+			name: "custom questions and cards",
+			fields: map[string]string{
+				"customQuestions[why_us]":  "The vaults",
+				"customQuestions[unknown]": "kept",
+				"cards[abc][field0]":       "yes",
+			},
+			want: models.ApplicationRequest{
+				JobID: "job_042",
+				CustomAnswers: map[string]string{
+					"why_us":                   "The vaults",
+					"customQuestions[unknown]": "kept",
+					"cards[abc][field0]":       "yes",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LeverApplicationRequest(testJob, tt.fields); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("request\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
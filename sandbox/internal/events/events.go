@@ -0,0 +1,109 @@
+// Package events fans sandbox events out to live subscribers, such as the
+// Server-Sent Events stream. Events are numbered in publication order and
+// the most recent ones are kept, so a subscriber that reconnects can ask
+// for everything after the last event it saw.
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Event types
+const (
+	JobCreated           = "job.created"
+	ApplicationSubmitted = "application.submitted"
+	StatusChanged        = "application.status_changed"
+)
+
+// Types lists every event type
+var Types = []string{JobCreated, ApplicationSubmitted, StatusChanged}
+
+// historySize is how many recent events are kept for replay
+const historySize = 256
+
+// bufferSize is how many events a subscriber may fall behind by before
+// it is dropped
+const bufferSize = 64
+
+// Event is something that happened in the sandbox
+type Event struct {
+	ID        uint64      `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// Broker delivers published events to every subscriber. Publishing never
+// blocks: a subscriber too slow to keep up has its channel closed and must
+// reconnect, replaying what it missed.
+type Broker struct {
+	lastID      uint64
+	history     []Event // The most recent events, oldest first
+	subscribers map[chan Event]struct{}
+	mu          sync.Mutex
+}
+
+// NewBroker creates a broker with no subscribers
+func NewBroker() *Broker {
+	return &Broker{subscribers: make(map[chan Event]struct{})}
+}
+
+// Publish numbers an event of the given type and delivers it
+func (b *Broker) Publish(eventType string, data interface{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	event := Event{ID: b.lastID, Type: eventType, CreatedAt: time.Now().UTC(), Data: data}
+	b.history = append(b.history, event)
+	if len(b.history) > historySize {
+		b.history = b.history[len(b.history)-historySize:]
+	}
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// Subscribe returns a channel receiving every event published from now on,
+// preceded by the kept events numbered after after (none when after is 0),
+// and a function that ends the subscription. It also reports whether
+// replay is complete: false when events after after were already
+// discarded from the history.
+func (b *Broker) Subscribe(after uint64) (<-chan Event, func(), bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var replay []Event
+	complete := true
+	if after > 0 {
+		for _, event := range b.history {
+			if event.ID > after {
+				replay = append(replay, event)
+			}
+		}
+		complete = after >= b.lastID || (len(b.history) > 0 && b.history[0].ID <= after+1)
+	}
+
+	ch := make(chan Event, len(replay)+bufferSize)
+	for _, event := range replay {
+		ch <- event
+	}
+	b.subscribers[ch] = struct{}{}
+
+	cancel := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subscribers[ch]; ok {
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+	return ch, cancel, complete
+}
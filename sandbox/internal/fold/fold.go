@@ -0,0 +1,53 @@
+// Package fold implements the case-insensitive text matching shared by job
+// search, company matching and email normalization. Text is compared in its
+// case-folded form: every rune is mapped to the lowercase of its uppercase,
+// which folds the whole Unicode case table (É/é, Σ/σ/ς, the Kelvin sign and
+// K/k) rather than ASCII letters only.
+//
+// Folding is language-neutral. In particular the Turkish dotted and
+// dotless i are not treated specially: I, i, İ and ı all fold to i, so
+// "istanbul" finds "İstanbul" and "Diyarbakir" finds "Diyarbakır". This is
+// more lenient than Turkish-locale folding, which keeps I/ı apart from İ/i,
+// but a search box with no locale is better off matching too much than
+// missing a city because of a dot. Accents are significant: "cafe" does not
+// find "café".
+package fold
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// String returns the case-folded form of s. The result may differ in byte
+// length from s, so offsets into it do not carry over to the original.
+func String(s string) string {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= utf8.RuneSelf || 'A' <= c && c <= 'Z' {
+			return strings.Map(Rune, s)
+		}
+	}
+	return s
+}
+
+// Rune returns the case-folded form of r
+func Rune(r rune) rune {
+	if r < utf8.RuneSelf {
+		if 'A' <= r && r <= 'Z' {
+			r += 'a' - 'A'
+		}
+		return r
+	}
+	return unicode.ToLower(unicode.ToUpper(r))
+}
+
+// Contains reports whether substr is within s, ignoring case
+func Contains(s, substr string) bool {
+	return strings.Contains(String(s), String(substr))
+}
+
+// Equal reports whether s and t are equal, ignoring case
+func Equal(s, t string) bool {
+	return String(s) == String(t)
+}
@@ -0,0 +1,87 @@
+package fold
+
+import "testing"
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"", ""},
+		{"backend engineer", "backend engineer"},
+		{"Backend ENGINEER", "backend engineer"},
+
+		// Accented letters keep their accents
+		{"MÜNCHEN", "münchen"},
+		{"ŁÓDŹ", "łódź"},
+		{"Société Générale", "société générale"},
+		{"SÃO PAULO", "são paulo"},
+
+		// Turkish: I, i, İ and ı all fold to i
+		{"İSTANBUL", "istanbul"},
+		{"DİYARBAKIR", "diyarbakir"},
+		{"ıi", "ii"},
+
+		// Other scripts, and letters whose lowercase has more than one form
+		{"ΣΊΓΜΑ", "σίγμα"},
+		{"σίγμας", "σίγμασ"},
+		{"РАЗРАБОТЧИК", "разработчик"},
+		{"K", "k"}, // Kelvin sign
+		{"ǅ", "ǆ"},
+		{"ẞ", "ß"},
+		{"エンジニア", "エンジニア"},
+		{"Go エンジニア Москва", "go エンジニア москва"},
+	}
+	for _, tt := range tests {
+		if got := String(tt.s); got != tt.want {
+			t.Errorf("String(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestContains(t *testing.T) {
+	tests := []struct {
+		s, substr string
+		want      bool
+	}{
+		{"San Francisco, CA", "francisco", true},
+		{"München", "MÜNCH", true},
+		{"Łódź, Poland", "łódź", true},
+		{"Łódź, Poland", "lodz", false}, // Accents are significant
+		{"Café Ltd", "cafe", false},
+		{"İstanbul", "istanbul", true},
+		{"İstanbul", "ISTANBUL", true},
+		{"Diyarbakır", "DIYARBAKIR", true},
+		{"Ωmega Labs", "ωMEGA", true},
+		{"Σίγμα ΑΕ", "σίγμα", true},
+		{"東京 Tokyo", "TOKYO", true},
+		{"東京 Tokyo", "東京", true},
+		{"Straße", "STRASSE", false}, // ß has no single-rune uppercase
+		{"anything", "", true},
+	}
+	for _, tt := range tests {
+		if got := Contains(tt.s, tt.substr); got != tt.want {
+			t.Errorf("Contains(%q, %q) = %v, want %v", tt.s, tt.substr, got, tt.want)
+		}
+	}
+}
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		s, t string
+		want bool
+	}{
+		{"EXAMPLE.COM", "example.com", true},
+		{"BÜCHER.de", "bücher.DE", true},
+		{"bucher.de", "bücher.de", false},
+		{"İ", "ı", true},
+		{"K", "K", true},
+		{"Σ", "ς", true},
+		{"ﬁ", "fi", false}, // Ligatures are not expanded
+	}
+	for _, tt := range tests {
+		if got := Equal(tt.s, tt.t); got != tt.want {
+			t.Errorf("Equal(%q, %q) = %v, want %v", tt.s, tt.t, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+package graphql
+
+import _ "embed"
+
+// ConsoleHTML is a self-contained GraphiQL-style page for running queries
+// against POST /graphql without loading any assets from a CDN
+//
+//go:embed console.html
+var ConsoleHTML []byte
@@ -0,0 +1,422 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// Request is a GraphQL-over-HTTP request body
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Response is a GraphQL-over-HTTP response body
+type Response struct {
+	Data   interface{} `json:"data,omitempty"`
+	Errors []*Error    `json:"errors,omitempty"`
+}
+
+// Execute parses, validates and executes a request against the schema.
+// Documents that fail to parse or validate produce errors and no data.
+func (s *Schema) Execute(ctx context.Context, req Request) Response {
+	doc, err := parse(req.Query)
+	if err != nil {
+		return Response{Errors: []*Error{NewError("GRAPHQL_PARSE_FAILED", err.Error())}}
+	}
+
+	op, err := selectOperation(doc, req.OperationName)
+	if err != nil {
+		return Response{Errors: []*Error{NewError("GRAPHQL_VALIDATION_FAILED", err.Error())}}
+	}
+
+	root := s.Query
+	if op.kind == "mutation" {
+		if s.Mutation == nil {
+			return Response{Errors: []*Error{NewError("GRAPHQL_VALIDATION_FAILED", "schema does not support mutations")}}
+		}
+		root = s.Mutation
+	}
+
+	// Variables first, so a limit passed as one is costed at its value
+	variables, err := coerceVariables(op, req.Variables)
+	if err != nil {
+		return Response{Errors: []*Error{NewError("BAD_USER_INPUT", err.Error())}}
+	}
+
+	v := &validator{schema: s, doc: doc, variables: variables}
+	complexity := v.validate(root, op.selection, 1, map[string]bool{})
+	if len(v.errors) > 0 {
+		return Response{Errors: v.errors}
+	}
+	if s.MaxComplexity > 0 && complexity > s.MaxComplexity {
+		return Response{Errors: []*Error{NewError("QUERY_TOO_COMPLEX",
+			fmt.Sprintf("query complexity %d exceeds the maximum of %d", complexity, s.MaxComplexity))}}
+	}
+
+	e := &executor{schema: s, doc: doc, variables: variables}
+	data := e.executeSelection(ctx, root, nil, op.selection, nil)
+	return Response{Data: data, Errors: e.errors}
+}
+
+// selectOperation picks the operation to run from the document
+func selectOperation(doc *document, name string) (*operation, error) {
+	if name == "" {
+		if len(doc.operations) > 1 {
+			return nil, fmt.Errorf("operationName is required when the document contains multiple operations")
+		}
+		return doc.operations[0], nil
+	}
+	for _, op := range doc.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown operation %q", name)
+}
+
+// coerceVariables applies defaults and checks required variables
+func coerceVariables(op *operation, provided map[string]interface{}) (map[string]interface{}, error) {
+	variables := make(map[string]interface{}, len(op.variables))
+	for _, def := range op.variables {
+		val, ok := provided[def.name]
+		if !ok && def.defaultValue.kind != "" {
+			val, ok = def.defaultValue.resolve(nil), true
+		}
+		if (!ok || val == nil) && strings.HasSuffix(def.typeName, "!") {
+			return nil, fmt.Errorf("variable $%s of required type %s was not provided", def.name, def.typeName)
+		}
+		variables[def.name] = val
+	}
+	return variables, nil
+}
+
+// validator checks selections against the schema and estimates complexity
+type validator struct {
+	schema    *Schema
+	doc       *document
+	variables map[string]interface{}
+	errors    []*Error
+}
+
+func (v *validator) fail(format string, args ...interface{}) {
+	v.errors = append(v.errors, NewError("GRAPHQL_VALIDATION_FAILED", fmt.Sprintf(format, args...)))
+}
+
+// validate walks a selection set and returns its estimated cost
+func (v *validator) validate(obj *Object, selections []selection, depth int, spreading map[string]bool) int {
+	if v.schema.MaxDepth > 0 && depth > v.schema.MaxDepth {
+		v.errors = append(v.errors, NewError("QUERY_TOO_DEEP",
+			fmt.Sprintf("query depth exceeds the maximum of %d", v.schema.MaxDepth)))
+		return 0
+	}
+
+	cost := 0
+	for _, sel := range selections {
+		if sel.spread != "" {
+			frag, ok := v.doc.fragments[sel.spread]
+			if !ok {
+				v.fail("unknown fragment %q", sel.spread)
+				continue
+			}
+			if spreading[sel.spread] {
+				v.fail("fragment %q spreads itself", sel.spread)
+				continue
+			}
+			spreading[sel.spread] = true
+			cost += v.validate(obj, frag.selection, depth, spreading)
+			delete(spreading, sel.spread)
+			continue
+		}
+		if sel.inline {
+			cost += v.validate(obj, sel.children, depth, spreading)
+			continue
+		}
+
+		if sel.name == "__typename" {
+			continue
+		}
+
+		field, ok := obj.Fields[sel.name]
+		if !ok {
+			v.fail("cannot query field %q on type %q", sel.name, obj.Name)
+			continue
+		}
+
+		for arg := range sel.arguments {
+			if _, ok := field.Args[arg]; !ok {
+				v.fail("unknown argument %q on field %s.%s", arg, obj.Name, sel.name)
+			}
+		}
+
+		fieldCost := field.Cost
+		if fieldCost == 0 {
+			fieldCost = 1
+		}
+
+		ref := parseTypeRef(field.Type)
+		child, isObject := v.schema.object(ref.name)
+		switch {
+		case isObject && len(sel.children) == 0:
+			v.fail("field %s.%s of type %s must have a selection of subfields", obj.Name, sel.name, field.Type)
+		case !isObject && len(sel.children) > 0:
+			v.fail("field %s.%s of type %s cannot have a selection of subfields", obj.Name, sel.name, field.Type)
+		case isObject:
+			childCost := v.validate(child, sel.children, depth+1, spreading)
+			if ref.list {
+				childCost *= v.listSize(sel)
+			}
+			fieldCost += childCost
+		}
+
+		cost += fieldCost
+	}
+
+	return cost
+}
+
+// listSize estimates how many items a list field returns for complexity purposes
+func (v *validator) listSize(sel selection) int {
+	size := v.schema.DefaultListSize
+	if size <= 0 {
+		size = 10
+	}
+	if limit, ok := sel.arguments["limit"]; ok {
+		if n := (Args{"limit": limit.resolve(v.variables)}).Int("limit", 0); n > 0 {
+			size = n
+		}
+	}
+	if v.schema.MaxListSize > 0 {
+		size = min(size, v.schema.MaxListSize)
+	}
+	return size
+}
+
+// executor resolves a validated operation
+type executor struct {
+	schema    *Schema
+	doc       *document
+	variables map[string]interface{}
+	errors    []*Error
+}
+
+func (e *executor) executeSelection(ctx context.Context, obj *Object, source interface{}, selections []selection, path []interface{}) *orderedMap {
+	result := newOrderedMap()
+
+	for _, sel := range e.collectFields(selections) {
+		key := sel.responseKey()
+		fieldPath := append(append([]interface{}{}, path...), key)
+
+		if sel.name == "__typename" {
+			result.set(key, obj.Name)
+			continue
+		}
+
+		field := obj.Fields[sel.name]
+		args := make(Args, len(sel.arguments))
+		for name, val := range sel.arguments {
+			args[name] = val.resolve(e.variables)
+		}
+
+		if missing := missingArgs(field, args); missing != "" {
+			e.addError(NewError("BAD_USER_INPUT", fmt.Sprintf("argument %q is required", missing)), fieldPath)
+			result.set(key, nil)
+			continue
+		}
+
+		var val interface{}
+		var err error
+		if field.Resolve != nil {
+			val, err = field.Resolve(ctx, source, args)
+		} else {
+			val = defaultResolve(source, sel.name)
+		}
+		if err != nil {
+			e.addError(err, fieldPath)
+			result.set(key, nil)
+			continue
+		}
+
+		result.set(key, e.complete(ctx, parseTypeRef(field.Type), val, sel.children, fieldPath))
+	}
+
+	return result
+}
+
+// complete converts a resolved value into its response shape
+func (e *executor) complete(ctx context.Context, ref typeRef, val interface{}, children []selection, path []interface{}) interface{} {
+	if isNil(val) {
+		return nil
+	}
+
+	if ref.list {
+		rv := reflect.ValueOf(val)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return nil
+		}
+		items := make([]interface{}, rv.Len())
+		itemRef := typeRef{name: ref.name}
+		for i := range items {
+			items[i] = e.complete(ctx, itemRef, rv.Index(i).Interface(), children, append(append([]interface{}{}, path...), i))
+		}
+		return items
+	}
+
+	if obj, ok := e.schema.object(ref.name); ok {
+		return e.executeSelection(ctx, obj, val, children, path)
+	}
+
+	return val
+}
+
+// collectFields flattens fragments and applies @skip/@include
+func (e *executor) collectFields(selections []selection) []selection {
+	var fields []selection
+	for _, sel := range selections {
+		if !e.included(sel.directives) {
+			continue
+		}
+		switch {
+		case sel.spread != "":
+			fields = append(fields, e.collectFields(e.doc.fragments[sel.spread].selection)...)
+		case sel.inline:
+			fields = append(fields, e.collectFields(sel.children)...)
+		default:
+			fields = append(fields, sel)
+		}
+	}
+	return fields
+}
+
+func (e *executor) included(directives []directive) bool {
+	for _, dir := range directives {
+		cond, _ := dir.arguments["if"].resolve(e.variables).(bool)
+		if dir.name == "skip" && cond {
+			return false
+		}
+		if dir.name == "include" && !cond {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *executor) addError(err error, path []interface{}) {
+	gqlErr, ok := err.(*Error)
+	if !ok {
+		gqlErr = &Error{Message: err.Error()}
+	}
+	withPath := *gqlErr
+	withPath.Path = path
+	e.errors = append(e.errors, &withPath)
+}
+
+// missingArgs returns the name of the first required argument that is absent
+func missingArgs(field *Field, args Args) string {
+	for name, ref := range field.Args {
+		if strings.HasSuffix(ref, "!") && args[name] == nil {
+			return name
+		}
+	}
+	return ""
+}
+
+// defaultResolve reads a field from a map or a struct's json-tagged property
+func defaultResolve(source interface{}, name string) interface{} {
+	if m, ok := source.(map[string]interface{}); ok {
+		return m[name]
+	}
+
+	rv := reflect.ValueOf(source)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	jsonName := toSnakeCase(name)
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		tagName := strings.Split(f.Tag.Get("json"), ",")[0]
+		if tagName == jsonName || (tagName == "" && strings.EqualFold(f.Name, name)) {
+			return rv.Field(i).Interface()
+		}
+	}
+	return nil
+}
+
+// toSnakeCase converts a camelCase GraphQL field name to its snake_case json name
+func toSnakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func isNil(val interface{}) bool {
+	if val == nil {
+		return true
+	}
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// orderedMap is a JSON object that preserves the order of selected fields
+type orderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newOrderedMap() *orderedMap {
+	return &orderedMap{values: make(map[string]interface{})}
+}
+
+func (m *orderedMap) set(key string, val interface{}) {
+	if _, exists := m.values[key]; !exists {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = val
+}
+
+// MarshalJSON implements json.Marshaler
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, _ := json.Marshal(key)
+		buf.Write(k)
+		buf.WriteByte(':')
+		v, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
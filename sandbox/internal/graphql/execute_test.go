@@ -0,0 +1,49 @@
+package graphql
+
+import (
+	"context"
+	"testing"
+)
+
+// TestComplexityCostsLimitVariables checks a limit passed as a variable is
+// costed at its value, as the same limit written inline is
+func TestComplexityCostsLimitVariables(t *testing.T) {
+	job := &Object{Name: "Job", Fields: map[string]*Field{"id": {Type: "ID!"}}}
+	s := &Schema{
+		Query: &Object{Name: "Query", Fields: map[string]*Field{
+			"jobs": {
+				Type: "[Job!]!",
+				Args: map[string]string{"limit": "Int"},
+				Resolve: func(ctx context.Context, source interface{}, args Args) (interface{}, error) {
+					return []map[string]interface{}{}, nil
+				},
+			},
+		}},
+		Types:           []*Object{job},
+		MaxComplexity:   100,
+		DefaultListSize: 10,
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		variables  map[string]interface{}
+		tooComplex bool
+	}{
+		{"no limit", `{ jobs { id } }`, nil, false},
+		{"inline limit", `{ jobs(limit: 1000) { id } }`, nil, true},
+		{"variable limit", `query($n: Int) { jobs(limit: $n) { id } }`, map[string]interface{}{"n": float64(1000)}, true},
+		{"small variable limit", `query($n: Int) { jobs(limit: $n) { id } }`, map[string]interface{}{"n": float64(5)}, false},
+		{"defaulted variable limit", `query($n: Int = 1000) { jobs(limit: $n) { id } }`, nil, true},
+		{"unset variable limit", `query($n: Int) { jobs(limit: $n) { id } }`, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := s.Execute(context.Background(), Request{Query: tt.query, Variables: tt.variables})
+			tooComplex := len(resp.Errors) > 0 && resp.Errors[0].Extensions["code"] == "QUERY_TOO_COMPLEX"
+			if tooComplex != tt.tooComplex {
+				t.Errorf("rejected as too complex = %v, want %v (errors %+v)", tooComplex, tt.tooComplex, resp.Errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// tokenKind classifies lexical tokens
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+// token is a single lexical token of a GraphQL document
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+// lex splits a GraphQL document into tokens
+func lex(src string) ([]token, error) {
+	var tokens []token
+	i := 0
+	if strings.HasPrefix(src, "\uFEFF") {
+		i = len("\uFEFF")
+	}
+
+	for i < len(src) {
+		ch := src[i]
+
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' || ch == ',':
+			// Whitespace and commas are insignificant
+			i++
+		case ch == '#':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case strings.IndexByte("!$():=@[]{}|&", ch) != -1:
+			tokens = append(tokens, token{kind: tokenPunct, value: string(ch), pos: i})
+			i++
+		case ch == '.':
+			if !strings.HasPrefix(src[i:], "...") {
+				return nil, fmt.Errorf("unexpected character '.' at position %d", i)
+			}
+			tokens = append(tokens, token{kind: tokenPunct, value: "...", pos: i})
+			i += 3
+		case ch == '_' || isLetter(ch):
+			start := i
+			for i < len(src) && (src[i] == '_' || isLetter(src[i]) || isDigit(src[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenName, value: src[start:i], pos: start})
+		case ch == '-' || isDigit(ch):
+			start := i
+			kind := tokenInt
+			i++
+			for i < len(src) && isDigit(src[i]) {
+				i++
+			}
+			if i < len(src) && src[i] == '.' {
+				kind = tokenFloat
+				i++
+				for i < len(src) && isDigit(src[i]) {
+					i++
+				}
+			}
+			if i < len(src) && (src[i] == 'e' || src[i] == 'E') {
+				kind = tokenFloat
+				i++
+				if i < len(src) && (src[i] == '+' || src[i] == '-') {
+					i++
+				}
+				for i < len(src) && isDigit(src[i]) {
+					i++
+				}
+			}
+			tokens = append(tokens, token{kind: kind, value: src[start:i], pos: start})
+		case ch == '"':
+			value, end, err := lexString(src, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, value: value, pos: i})
+			i = end
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", ch, i)
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokenEOF, pos: len(src)})
+	return tokens, nil
+}
+
+// lexString reads a quoted or block string starting at src[start]
+func lexString(src string, start int) (string, int, error) {
+	if strings.HasPrefix(src[start:], `"""`) {
+		end := strings.Index(src[start+3:], `"""`)
+		if end == -1 {
+			return "", 0, fmt.Errorf("unterminated block string at position %d", start)
+		}
+		return strings.TrimSpace(src[start+3 : start+3+end]), start + 6 + end, nil
+	}
+
+	var b strings.Builder
+	i := start + 1
+	for i < len(src) {
+		ch := src[i]
+		switch ch {
+		case '"':
+			return b.String(), i + 1, nil
+		case '\n':
+			return "", 0, fmt.Errorf("unterminated string at position %d", start)
+		case '\\':
+			if i+1 >= len(src) {
+				return "", 0, fmt.Errorf("unterminated string at position %d", start)
+			}
+			i++
+			switch src[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'u':
+				if i+4 >= len(src) {
+					return "", 0, fmt.Errorf("invalid unicode escape at position %d", i)
+				}
+				var r rune
+				if _, err := fmt.Sscanf(src[i+1:i+5], "%04x", &r); err != nil {
+					return "", 0, fmt.Errorf("invalid unicode escape at position %d", i)
+				}
+				b.WriteRune(r)
+				i += 4
+			default:
+				b.WriteByte(src[i])
+			}
+			i++
+		default:
+			b.WriteByte(ch)
+			i++
+		}
+	}
+
+	return "", 0, fmt.Errorf("unterminated string at position %d", start)
+}
+
+func isLetter(ch byte) bool {
+	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
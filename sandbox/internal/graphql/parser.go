@@ -0,0 +1,481 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// document is a parsed GraphQL request document
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+// operation is a query or mutation definition
+type operation struct {
+	kind      string // "query" or "mutation"
+	name      string
+	variables []variableDefinition
+	selection []selection
+}
+
+// variableDefinition declares an operation variable
+type variableDefinition struct {
+	name         string
+	typeName     string
+	defaultValue value
+}
+
+// fragment is a named fragment definition
+type fragment struct {
+	name          string
+	typeCondition string
+	selection     []selection
+}
+
+// selection is a field, fragment spread, or inline fragment
+type selection struct {
+	// Field selections
+	alias      string
+	name       string
+	arguments  map[string]value
+	children   []selection
+	directives []directive
+
+	// Fragment spreads (spread != "") and inline fragments (inline == true)
+	spread        string
+	inline        bool
+	typeCondition string
+}
+
+// responseKey is the key a field selection uses in the result
+func (s selection) responseKey() string {
+	if s.alias != "" {
+		return s.alias
+	}
+	return s.name
+}
+
+// directive is a @skip or @include annotation
+type directive struct {
+	name      string
+	arguments map[string]value
+}
+
+// value is an unresolved literal or variable reference
+type value struct {
+	kind     string // variable, int, float, string, boolean, null, enum, list, object
+	raw      string
+	list     []value
+	object   map[string]value
+	variable string
+}
+
+// parser is a recursive-descent parser over lexed tokens
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// parse parses a GraphQL executable document
+func parse(src string) (*document, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	doc := &document{fragments: make(map[string]*fragment)}
+
+	for p.peek().kind != tokenEOF {
+		tok := p.peek()
+		switch {
+		case tok.kind == tokenPunct && tok.value == "{":
+			sel, err := p.parseSelectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, &operation{kind: "query", selection: sel})
+		case tok.kind == tokenName && (tok.value == "query" || tok.value == "mutation"):
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		case tok.kind == tokenName && tok.value == "fragment":
+			frag, err := p.parseFragment()
+			if err != nil {
+				return nil, err
+			}
+			doc.fragments[frag.name] = frag
+		default:
+			return nil, p.errorf("unexpected %q", tok.value)
+		}
+	}
+
+	if len(doc.operations) == 0 {
+		return nil, fmt.Errorf("document contains no operations")
+	}
+
+	return doc, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("syntax error at position %d: %s", p.peek().pos, fmt.Sprintf(format, args...))
+}
+
+// skipPunct consumes the punctuator if it is next and reports whether it did
+func (p *parser) skipPunct(value string) bool {
+	tok := p.peek()
+	if tok.kind == tokenPunct && tok.value == value {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expectPunct(value string) error {
+	if !p.skipPunct(value) {
+		return p.errorf("expected %q, found %q", value, p.peek().value)
+	}
+	return nil
+}
+
+func (p *parser) expectName() (string, error) {
+	tok := p.peek()
+	if tok.kind != tokenName {
+		return "", p.errorf("expected name, found %q", tok.value)
+	}
+	p.pos++
+	return tok.value, nil
+}
+
+func (p *parser) parseOperation() (*operation, error) {
+	op := &operation{kind: p.next().value}
+
+	if p.peek().kind == tokenName {
+		op.name = p.next().value
+	}
+
+	if p.skipPunct("(") {
+		for !p.skipPunct(")") {
+			if err := p.expectPunct("$"); err != nil {
+				return nil, err
+			}
+			name, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectPunct(":"); err != nil {
+				return nil, err
+			}
+			typeName, err := p.parseType()
+			if err != nil {
+				return nil, err
+			}
+			def := variableDefinition{name: name, typeName: typeName}
+			if p.skipPunct("=") {
+				if def.defaultValue, err = p.parseValue(true); err != nil {
+					return nil, err
+				}
+			}
+			op.variables = append(op.variables, def)
+		}
+	}
+
+	if _, err := p.parseDirectives(); err != nil {
+		return nil, err
+	}
+
+	sel, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.selection = sel
+
+	return op, nil
+}
+
+func (p *parser) parseFragment() (*fragment, error) {
+	p.next() // fragment
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	if on, err := p.expectName(); err != nil || on != "on" {
+		return nil, p.errorf("expected 'on' in fragment %s", name)
+	}
+	typeCondition, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	sel, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	return &fragment{name: name, typeCondition: typeCondition, selection: sel}, nil
+}
+
+// parseType reads a type reference such as [Job!]! and returns it verbatim
+func (p *parser) parseType() (string, error) {
+	var typeName string
+	if p.skipPunct("[") {
+		inner, err := p.parseType()
+		if err != nil {
+			return "", err
+		}
+		if err := p.expectPunct("]"); err != nil {
+			return "", err
+		}
+		typeName = "[" + inner + "]"
+	} else {
+		name, err := p.expectName()
+		if err != nil {
+			return "", err
+		}
+		typeName = name
+	}
+	if p.skipPunct("!") {
+		typeName += "!"
+	}
+	return typeName, nil
+}
+
+func (p *parser) parseSelectionSet() ([]selection, error) {
+	if err := p.expectPunct("{"); err != nil {
+		return nil, err
+	}
+
+	var selections []selection
+	for !p.skipPunct("}") {
+		if p.peek().kind == tokenEOF {
+			return nil, p.errorf("unterminated selection set")
+		}
+
+		if p.skipPunct("...") {
+			sel := selection{}
+			if p.peek().kind == tokenName && p.peek().value != "on" {
+				sel.spread = p.next().value
+			} else {
+				sel.inline = true
+				if p.peek().kind == tokenName && p.peek().value == "on" {
+					p.next()
+					name, err := p.expectName()
+					if err != nil {
+						return nil, err
+					}
+					sel.typeCondition = name
+				}
+			}
+			dirs, err := p.parseDirectives()
+			if err != nil {
+				return nil, err
+			}
+			sel.directives = dirs
+			if sel.inline {
+				if sel.children, err = p.parseSelectionSet(); err != nil {
+					return nil, err
+				}
+			}
+			selections = append(selections, sel)
+			continue
+		}
+
+		sel, err := p.parseField()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, sel)
+	}
+
+	return selections, nil
+}
+
+func (p *parser) parseField() (selection, error) {
+	name, err := p.expectName()
+	if err != nil {
+		return selection{}, err
+	}
+
+	sel := selection{name: name}
+	if p.skipPunct(":") {
+		sel.alias = name
+		if sel.name, err = p.expectName(); err != nil {
+			return selection{}, err
+		}
+	}
+
+	if p.peek().kind == tokenPunct && p.peek().value == "(" {
+		if sel.arguments, err = p.parseArguments(); err != nil {
+			return selection{}, err
+		}
+	}
+
+	if sel.directives, err = p.parseDirectives(); err != nil {
+		return selection{}, err
+	}
+
+	if p.peek().kind == tokenPunct && p.peek().value == "{" {
+		if sel.children, err = p.parseSelectionSet(); err != nil {
+			return selection{}, err
+		}
+	}
+
+	return sel, nil
+}
+
+func (p *parser) parseArguments() (map[string]value, error) {
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	args := make(map[string]value)
+	for !p.skipPunct(")") {
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(":"); err != nil {
+			return nil, err
+		}
+		if args[name], err = p.parseValue(false); err != nil {
+			return nil, err
+		}
+	}
+	return args, nil
+}
+
+func (p *parser) parseDirectives() ([]directive, error) {
+	var dirs []directive
+	for p.skipPunct("@") {
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		dir := directive{name: name}
+		if p.peek().kind == tokenPunct && p.peek().value == "(" {
+			if dir.arguments, err = p.parseArguments(); err != nil {
+				return nil, err
+			}
+		}
+		dirs = append(dirs, dir)
+	}
+	return dirs, nil
+}
+
+func (p *parser) parseValue(constant bool) (value, error) {
+	tok := p.peek()
+
+	switch tok.kind {
+	case tokenPunct:
+		switch tok.value {
+		case "$":
+			if constant {
+				return value{}, p.errorf("variables are not allowed here")
+			}
+			p.next()
+			name, err := p.expectName()
+			if err != nil {
+				return value{}, err
+			}
+			return value{kind: "variable", variable: name}, nil
+		case "[":
+			p.next()
+			v := value{kind: "list"}
+			for !p.skipPunct("]") {
+				if p.peek().kind == tokenEOF {
+					return value{}, p.errorf("unterminated list")
+				}
+				item, err := p.parseValue(constant)
+				if err != nil {
+					return value{}, err
+				}
+				v.list = append(v.list, item)
+			}
+			return v, nil
+		case "{":
+			p.next()
+			v := value{kind: "object", object: make(map[string]value)}
+			for !p.skipPunct("}") {
+				name, err := p.expectName()
+				if err != nil {
+					return value{}, err
+				}
+				if err := p.expectPunct(":"); err != nil {
+					return value{}, err
+				}
+				if v.object[name], err = p.parseValue(constant); err != nil {
+					return value{}, err
+				}
+			}
+			return v, nil
+		}
+	case tokenInt:
+		p.next()
+		return value{kind: "int", raw: tok.value}, nil
+	case tokenFloat:
+		p.next()
+		return value{kind: "float", raw: tok.value}, nil
+	case tokenString:
+		p.next()
+		return value{kind: "string", raw: tok.value}, nil
+	case tokenName:
+		p.next()
+		switch tok.value {
+		case "true", "false":
+			return value{kind: "boolean", raw: tok.value}, nil
+		case "null":
+			return value{kind: "null"}, nil
+		default:
+			return value{kind: "enum", raw: tok.value}, nil
+		}
+	}
+
+	return value{}, p.errorf("unexpected %q", tok.value)
+}
+
+// resolve converts a value into a Go value, substituting variables
+func (v value) resolve(variables map[string]interface{}) interface{} {
+	switch v.kind {
+	case "variable":
+		return variables[v.variable]
+	case "int":
+		n, _ := strconv.ParseInt(v.raw, 10, 64)
+		return int(n)
+	case "float":
+		f, _ := strconv.ParseFloat(v.raw, 64)
+		return f
+	case "boolean":
+		return v.raw == "true"
+	case "null":
+		return nil
+	case "list":
+		list := make([]interface{}, len(v.list))
+		for i, item := range v.list {
+			list[i] = item.resolve(variables)
+		}
+		return list
+	case "object":
+		obj := make(map[string]interface{}, len(v.object))
+		for k, item := range v.object {
+			obj[k] = item.resolve(variables)
+		}
+		return obj
+	default:
+		return v.raw
+	}
+}
@@ -0,0 +1,232 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ResolveFunc resolves a field value from its parent value and arguments
+type ResolveFunc func(ctx context.Context, source interface{}, args Args) (interface{}, error)
+
+// Field is a field of an object type
+type Field struct {
+	// Type is the SDL type reference, e.g. "[Job!]!"
+	Type string
+	// Args maps argument names to SDL type references
+	Args map[string]string
+	// Description is included in the printed schema
+	Description string
+	// Resolve computes the value; nil reads the json-tagged property of the source
+	Resolve ResolveFunc
+	// Cost overrides the complexity of this field (defaults to 1)
+	Cost int
+}
+
+// Object is an object type
+type Object struct {
+	Name        string
+	Description string
+	Fields      map[string]*Field
+}
+
+// InputObject is an input object type, used for printing the schema
+type InputObject struct {
+	Name   string
+	Fields map[string]string
+}
+
+// Schema is an executable GraphQL schema
+type Schema struct {
+	Query    *Object
+	Mutation *Object
+	// Types lists every object type reachable from Query and Mutation
+	Types []*Object
+	// Inputs lists the input object types accepted as arguments
+	Inputs []*InputObject
+	// MaxDepth rejects documents nesting selections deeper than this
+	MaxDepth int
+	// MaxComplexity rejects documents whose estimated cost exceeds this
+	MaxComplexity int
+	// DefaultListSize estimates list sizes for complexity when no limit argument is given
+	DefaultListSize int
+	// MaxListSize caps the limit argument in complexity estimates, for
+	// resolvers that clamp their limit to it; 0 leaves limits uncapped
+	MaxListSize int
+
+	objects map[string]*Object
+}
+
+// Args are the coerced arguments passed to a resolver
+type Args map[string]interface{}
+
+// String returns a string argument or ""
+func (a Args) String(name string) string {
+	if s, ok := a[name].(string); ok {
+		return s
+	}
+	return ""
+}
+
+// Int returns an integer argument or def when it is absent
+func (a Args) Int(name string, def int) int {
+	switch v := a[name].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	return def
+}
+
+// Bool returns a boolean argument and whether it was provided
+func (a Args) Bool(name string) (bool, bool) {
+	b, ok := a[name].(bool)
+	return b, ok
+}
+
+// Object returns an input object argument or nil
+func (a Args) Object(name string) map[string]interface{} {
+	if m, ok := a[name].(map[string]interface{}); ok {
+		return m
+	}
+	return nil
+}
+
+// Error is a GraphQL error with an optional machine-readable code
+type Error struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// NewError creates an error carrying a code in its extensions
+func NewError(code, message string) *Error {
+	return &Error{Message: message, Extensions: map[string]interface{}{"code": code}}
+}
+
+// typeRef describes a parsed SDL type reference
+type typeRef struct {
+	name    string
+	list    bool
+	nonNull bool
+}
+
+// parseTypeRef parses references such as "Job", "[Job!]!" and "Int!"
+func parseTypeRef(ref string) typeRef {
+	t := typeRef{}
+	if strings.HasSuffix(ref, "!") {
+		t.nonNull = true
+		ref = strings.TrimSuffix(ref, "!")
+	}
+	if strings.HasPrefix(ref, "[") {
+		t.list = true
+		ref = strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(ref, "["), "]"), "!")
+	}
+	t.name = ref
+	return t
+}
+
+// object returns the object type with the given name
+func (s *Schema) object(name string) (*Object, bool) {
+	if s.objects == nil {
+		s.objects = make(map[string]*Object)
+		for _, obj := range append([]*Object{s.Query, s.Mutation}, s.Types...) {
+			if obj != nil {
+				s.objects[obj.Name] = obj
+			}
+		}
+	}
+	obj, ok := s.objects[name]
+	return obj, ok
+}
+
+// SDL prints the schema in the GraphQL schema definition language
+func (s *Schema) SDL() string {
+	var b strings.Builder
+
+	objects := []*Object{s.Query}
+	if s.Mutation != nil {
+		objects = append(objects, s.Mutation)
+	}
+	objects = append(objects, s.Types...)
+
+	for _, obj := range objects {
+		if obj.Description != "" {
+			fmt.Fprintf(&b, "%s\n", quote(obj.Description))
+		}
+		fmt.Fprintf(&b, "type %s {\n", obj.Name)
+		for _, name := range sortedKeys(obj.Fields) {
+			field := obj.Fields[name]
+			if field.Description != "" {
+				fmt.Fprintf(&b, "  %s\n", quote(field.Description))
+			}
+			fmt.Fprintf(&b, "  %s%s: %s\n", name, printArgs(field.Args), field.Type)
+		}
+		b.WriteString("}\n\n")
+	}
+
+	for _, input := range s.Inputs {
+		fmt.Fprintf(&b, "input %s {\n", input.Name)
+		for _, name := range sortedKeys(input.Fields) {
+			fmt.Fprintf(&b, "  %s: %s\n", name, input.Fields[name])
+		}
+		b.WriteString("}\n\n")
+	}
+
+	return strings.TrimSpace(b.String()) + "\n"
+}
+
+// quote writes s as a GraphQL string, escaping quotes, backslashes and
+// control characters so no description can end its string early
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&b, `\u%04x`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func printArgs(args map[string]string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(args))
+	for _, name := range sortedKeys(args) {
+		parts = append(parts, name+": "+args[name])
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,39 @@
+package graphql
+
+import "testing"
+
+// TestQuoteRoundTrips checks descriptions printed in the SDL read back as
+// written, however they are punctuated
+func TestQuoteRoundTrips(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"A job posting",
+		`newest first unless order is "oldest"`,
+		`ends with a quote"`,
+		`a """block""" inside`,
+		`C:\path\to\resume`,
+		"two\nlines\tand a tab\r",
+		"a bell \a and a delete \x7f",
+		"İstanbul, Zürich, エンジニア",
+	} {
+		quoted := quote(s)
+		got, end, err := lexString(quoted, 0)
+		if err != nil || got != s || end != len(quoted) {
+			t.Errorf("%q printed as %s, read back as %q (%d of %d bytes, %v)", s, quoted, got, end, len(quoted), err)
+		}
+	}
+}
+
+func TestSDLEscapesDescriptions(t *testing.T) {
+	s := &Schema{Query: &Object{
+		Name:        "Query",
+		Description: `The "root" type`,
+		Fields: map[string]*Field{
+			"jobs": {Type: "[Job!]!", Description: "Jobs, \"newest\" first\nunless asked otherwise"},
+		},
+	}}
+	want := "\"The \\\"root\\\" type\"\ntype Query {\n  \"Jobs, \\\"newest\\\" first\\nunless asked otherwise\"\n  jobs: [Job!]!\n}\n"
+	if got := s.SDL(); got != want {
+		t.Errorf("SDL:\n%s\nwant:\n%s", got, want)
+	}
+}
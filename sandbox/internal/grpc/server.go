@@ -0,0 +1,275 @@
+// Package grpc serves unary and server-streaming gRPC methods over HTTP/2
+// without generated code. Handlers receive and return raw protobuf
+// messages, built and read with Encoder and Decoder, so a service is
+// defined by its .proto file and the handlers registered for it. Only
+// uncompressed messages are supported.
+//
+// It stands in for google.golang.org/grpc and protoc-generated stubs,
+// which the vendored dependencies do not include, so the module still
+// builds offline. Clients generated from the .proto file interoperate.
+package grpc
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Code is a gRPC status code
+type Code uint32
+
+// gRPC status codes
+const (
+	OK                 Code = 0
+	Canceled           Code = 1
+	Unknown            Code = 2
+	InvalidArgument    Code = 3
+	DeadlineExceeded   Code = 4
+	NotFound           Code = 5
+	AlreadyExists      Code = 6
+	PermissionDenied   Code = 7
+	ResourceExhausted  Code = 8
+	FailedPrecondition Code = 9
+	Unimplemented      Code = 12
+	Internal           Code = 13
+	Unavailable        Code = 14
+	Unauthenticated    Code = 16
+)
+
+// maxMessageSize is the largest request message accepted, in bytes
+const maxMessageSize = 4 << 20
+
+// Status is an error ending a call with a status code other than OK.
+// Reason, if set, is sent in the error-code trailer so clients can tell
+// failures apart the way the REST API's error field does.
+type Status struct {
+	Code    Code
+	Message string
+	Reason  string
+}
+
+// Error implements the error interface
+func (s *Status) Error() string {
+	return s.Message
+}
+
+// Errorf creates a Status with a formatted message
+func Errorf(code Code, format string, args ...interface{}) *Status {
+	return &Status{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// UnaryHandler handles a call with one request and one response message.
+// Returning a *Status ends the call with its code; any other error is
+// reported as Internal.
+type UnaryHandler func(ctx context.Context, req []byte) ([]byte, error)
+
+// StreamHandler handles a call with one request and a stream of response
+// messages, each passed to send
+type StreamHandler func(ctx context.Context, req []byte, send func(msg []byte) error) error
+
+// Authenticator checks the metadata of a call before its method runs and
+// returns the context to run it with. Returning an error, usually a
+// *Status, refuses the call.
+type Authenticator func(ctx context.Context, md http.Header) (context.Context, error)
+
+// Server dispatches gRPC calls to the methods of one service
+type Server struct {
+	service string
+	unary   map[string]UnaryHandler
+	streams map[string]StreamHandler
+	auth    Authenticator
+}
+
+// NewServer creates a server for the service with the given full name,
+// such as "package.Service"
+func NewServer(service string) *Server {
+	return &Server{
+		service: service,
+		unary:   make(map[string]UnaryHandler),
+		streams: make(map[string]StreamHandler),
+	}
+}
+
+// Unary registers a unary method
+func (s *Server) Unary(method string, handler UnaryHandler) {
+	s.unary[method] = handler
+}
+
+// Stream registers a server-streaming method
+func (s *Server) Stream(method string, handler StreamHandler) {
+	s.streams[method] = handler
+}
+
+// Authenticate makes the server check every call with auth
+func (s *Server) Authenticate(auth Authenticator) {
+	s.auth = auth
+}
+
+// ServeHTTP serves a gRPC call, which must be a POST over HTTP/2 to
+// /<service>/<method>. The status is sent in the grpc-status and
+// grpc-message trailers.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	contentType := r.Header.Get("Content-Type")
+	if contentType != "application/grpc" && contentType != "application/grpc+proto" {
+		http.Error(w, "gRPC requests must have Content-Type application/grpc", http.StatusUnsupportedMediaType)
+		return
+	}
+	if r.ProtoMajor != 2 {
+		http.Error(w, "gRPC requires HTTP/2", http.StatusHTTPVersionNotSupported)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "gRPC requests must be POST", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/grpc")
+	w.Header().Set("Grpc-Accept-Encoding", "identity")
+	w.WriteHeader(http.StatusOK)
+
+	ctx := r.Context()
+	if timeout, ok := parseTimeout(r.Header.Get("Grpc-Timeout")); ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	finish(w, s.call(ctx, w, r))
+}
+
+// call runs the method a request names and returns how it ended
+func (s *Server) call(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	service, method, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	unary, isUnary := s.unary[method]
+	stream, isStream := s.streams[method]
+	if !ok || service != s.service || (!isUnary && !isStream) {
+		return Errorf(Unimplemented, "unknown method %s", r.URL.Path)
+	}
+	if encoding := r.Header.Get("Grpc-Encoding"); encoding != "" && encoding != "identity" {
+		return Errorf(Unimplemented, "compression %q is not supported", encoding)
+	}
+	if s.auth != nil {
+		var err error
+		if ctx, err = s.auth(ctx, r.Header); err != nil {
+			return err
+		}
+	}
+
+	req, err := readMessage(r.Body)
+	if err != nil {
+		return err
+	}
+
+	if isUnary {
+		resp, err := unary(ctx, req)
+		if err != nil {
+			return err
+		}
+		return writeMessage(w, resp)
+	}
+	return stream(ctx, req, func(msg []byte) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return writeMessage(w, msg)
+	})
+}
+
+// readMessage reads the single message of a request body
+func readMessage(body io.Reader) ([]byte, error) {
+	var prefix [5]byte
+	if _, err := io.ReadFull(body, prefix[:]); err != nil {
+		return nil, Errorf(InvalidArgument, "the request has no message")
+	}
+	if prefix[0] != 0 {
+		return nil, Errorf(Unimplemented, "compressed messages are not supported")
+	}
+	size := binary.BigEndian.Uint32(prefix[1:])
+	if size > maxMessageSize {
+		return nil, Errorf(ResourceExhausted, "the request message is larger than %d bytes", maxMessageSize)
+	}
+	msg := make([]byte, size)
+	if _, err := io.ReadFull(body, msg); err != nil {
+		return nil, Errorf(InvalidArgument, "the request message is truncated")
+	}
+	return msg, nil
+}
+
+// writeMessage writes one length-prefixed message and flushes it to the
+// client
+func writeMessage(w http.ResponseWriter, msg []byte) error {
+	frame := make([]byte, 5, 5+len(msg))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(msg)))
+	if _, err := w.Write(append(frame, msg...)); err != nil {
+		return err
+	}
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// finish sets the trailers reporting how a call ended
+func finish(w http.ResponseWriter, err error) {
+	status := &Status{Code: OK}
+	var s *Status
+	switch {
+	case err == nil:
+	case errors.As(err, &s):
+		status = s
+	case errors.Is(err, context.DeadlineExceeded):
+		status = Errorf(DeadlineExceeded, "the deadline was exceeded")
+	case errors.Is(err, context.Canceled):
+		status = Errorf(Canceled, "the call was canceled")
+	default:
+		status = Errorf(Internal, "%v", err)
+	}
+
+	w.Header().Set(http.TrailerPrefix+"Grpc-Status", strconv.Itoa(int(status.Code)))
+	if status.Message != "" {
+		w.Header().Set(http.TrailerPrefix+"Grpc-Message", encodeMessage(status.Message))
+	}
+	if status.Reason != "" {
+		w.Header().Set(http.TrailerPrefix+"Error-Code", status.Reason)
+	}
+}
+
+// encodeMessage percent-encodes a status message, as grpc-message requires
+func encodeMessage(msg string) string {
+	var b strings.Builder
+	for i := 0; i < len(msg); i++ {
+		c := msg[i]
+		if c >= 0x20 && c <= 0x7e && c != '%' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// parseTimeout reads a grpc-timeout header such as "500m" or "10S"
+func parseTimeout(header string) (time.Duration, bool) {
+	if len(header) < 2 || len(header) > 9 {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(header[:len(header)-1], 10, 64)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	units := map[byte]time.Duration{
+		'H': time.Hour, 'M': time.Minute, 'S': time.Second,
+		'm': time.Millisecond, 'u': time.Microsecond, 'n': time.Nanosecond,
+	}
+	unit, ok := units[header[len(header)-1]]
+	if !ok {
+		return 0, false
+	}
+	return time.Duration(n) * unit, true
+}
@@ -0,0 +1,181 @@
+package grpc
+
+import "google.golang.org/protobuf/encoding/protowire"
+
+// Encoder builds a protobuf message field by field. Singular fields holding
+// their zero value are left out, as proto3 does.
+type Encoder struct {
+	buf []byte
+}
+
+// String writes a string field
+func (e *Encoder) String(num int, v string) {
+	if v == "" {
+		return
+	}
+	e.buf = protowire.AppendTag(e.buf, protowire.Number(num), protowire.BytesType)
+	e.buf = protowire.AppendString(e.buf, v)
+}
+
+// Strings writes a repeated string field
+func (e *Encoder) Strings(num int, vs []string) {
+	for _, v := range vs {
+		e.buf = protowire.AppendTag(e.buf, protowire.Number(num), protowire.BytesType)
+		e.buf = protowire.AppendString(e.buf, v)
+	}
+}
+
+// Int writes an int32 or int64 field
+func (e *Encoder) Int(num int, v int64) {
+	if v == 0 {
+		return
+	}
+	e.buf = protowire.AppendTag(e.buf, protowire.Number(num), protowire.VarintType)
+	e.buf = protowire.AppendVarint(e.buf, uint64(v))
+}
+
+// OptionalInt writes an optional int32 or int64 field, which is written
+// even when zero unless v is nil
+func (e *Encoder) OptionalInt(num int, v *int) {
+	if v == nil {
+		return
+	}
+	e.buf = protowire.AppendTag(e.buf, protowire.Number(num), protowire.VarintType)
+	e.buf = protowire.AppendVarint(e.buf, uint64(*v))
+}
+
+// Bool writes a bool field
+func (e *Encoder) Bool(num int, v bool) {
+	if !v {
+		return
+	}
+	e.buf = protowire.AppendTag(e.buf, protowire.Number(num), protowire.VarintType)
+	e.buf = protowire.AppendVarint(e.buf, protowire.EncodeBool(v))
+}
+
+// Message writes an embedded message field, or one element of a repeated
+// one. Empty messages are written too, so repeated fields keep their length.
+func (e *Encoder) Message(num int, m []byte) {
+	e.buf = protowire.AppendTag(e.buf, protowire.Number(num), protowire.BytesType)
+	e.buf = protowire.AppendBytes(e.buf, m)
+}
+
+// StringMap writes a map<string, string> field, as its entries
+func (e *Encoder) StringMap(num int, m map[string]string) {
+	for key, value := range m {
+		var entry Encoder
+		entry.String(1, key)
+		entry.String(2, value)
+		e.Message(num, entry.Bytes())
+	}
+}
+
+// Bytes returns the encoded message
+func (e *Encoder) Bytes() []byte {
+	return e.buf
+}
+
+// value is one occurrence of a field in an encoded message
+type value struct {
+	typ     protowire.Type
+	varint  uint64
+	payload []byte // For length-delimited fields
+}
+
+// Decoder reads the fields of a protobuf message. Fields it is not asked
+// about are ignored, and a field read as the wrong type reads as its zero
+// value.
+type Decoder struct {
+	fields map[int][]value
+}
+
+// errMalformed reports a message that is not valid protobuf
+var errMalformed = Errorf(InvalidArgument, "malformed protobuf message")
+
+// Decode splits an encoded message into its fields
+func Decode(b []byte) (Decoder, error) {
+	d := Decoder{fields: make(map[int][]value)}
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return Decoder{}, errMalformed
+		}
+		b = b[n:]
+
+		v := value{typ: typ}
+		switch typ {
+		case protowire.VarintType:
+			v.varint, n = protowire.ConsumeVarint(b)
+		case protowire.BytesType:
+			v.payload, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return Decoder{}, errMalformed
+		}
+		b = b[n:]
+		d.fields[int(num)] = append(d.fields[int(num)], v)
+	}
+	return d, nil
+}
+
+// last returns the last occurrence of a field of the given type, which is
+// the one that counts for singular fields
+func (d Decoder) last(num int, typ protowire.Type) (value, bool) {
+	values := d.fields[num]
+	for i := len(values) - 1; i >= 0; i-- {
+		if values[i].typ == typ {
+			return values[i], true
+		}
+	}
+	return value{}, false
+}
+
+// String reads a string field
+func (d Decoder) String(num int) string {
+	v, _ := d.last(num, protowire.BytesType)
+	return string(v.payload)
+}
+
+// Strings reads a repeated string field
+func (d Decoder) Strings(num int) []string {
+	var vs []string
+	for _, v := range d.fields[num] {
+		if v.typ == protowire.BytesType {
+			vs = append(vs, string(v.payload))
+		}
+	}
+	return vs
+}
+
+// Int reads an int32 or int64 field
+func (d Decoder) Int(num int) int64 {
+	v, _ := d.last(num, protowire.VarintType)
+	return int64(v.varint)
+}
+
+// Bool reads a bool field
+func (d Decoder) Bool(num int) bool {
+	v, _ := d.last(num, protowire.VarintType)
+	return protowire.DecodeBool(v.varint)
+}
+
+// StringMap reads a map<string, string> field
+func (d Decoder) StringMap(num int) (map[string]string, error) {
+	var m map[string]string
+	for _, v := range d.fields[num] {
+		if v.typ != protowire.BytesType {
+			continue
+		}
+		entry, err := Decode(v.payload)
+		if err != nil {
+			return nil, err
+		}
+		if m == nil {
+			m = make(map[string]string)
+		}
+		m[entry.String(1)] = entry.String(2)
+	}
+	return m, nil
+}
@@ -0,0 +1,632 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/dates"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/middleware"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// maxSlowdownDuration caps simulated slowdowns so a typo cannot hang
+// submissions for hours
+const maxSlowdownDuration = 5 * time.Minute
+
+// jobIDPattern is what job IDs chosen by the caller may look like, so they
+// can be used in URL paths as they are
+var jobIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// AdminHandler handles the runtime reconfiguration endpoints
+type AdminHandler struct {
+	simulator  *middleware.FailureSimulator
+	jobStore   *store.JobStore
+	appStore   *store.ApplicationStore
+	mailStore  *store.MailStore
+	calendars  *store.InterviewStore
+	savedJobs  *store.SavedJobStore
+	profiles   *store.ApplicantStore
+	accounts   *store.AccountStore
+	drafts     *store.DraftStore
+	workday    *store.WorkdayStore
+	challenges *store.ChallengeStore
+	limiters   []middleware.Limiter
+}
+
+// NewAdminHandler creates a new admin handler. Reset and Restore empty the
+// mailboxes in mailStore, the interview calendars, the saved jobs, the
+// applicant profiles, the applicant accounts, the application drafts, the
+// careers site accounts and sessions of the Workday-style flow and the
+// anti-bot challenges and tokens, and refill the buckets of limiters.
+func NewAdminHandler(simulator *middleware.FailureSimulator, jobStore *store.JobStore, appStore *store.ApplicationStore, mailStore *store.MailStore, calendars *store.InterviewStore, savedJobs *store.SavedJobStore, profiles *store.ApplicantStore, accounts *store.AccountStore, drafts *store.DraftStore, workday *store.WorkdayStore, challenges *store.ChallengeStore, limiters ...middleware.Limiter) *AdminHandler {
+	return &AdminHandler{simulator: simulator, jobStore: jobStore, appStore: appStore, mailStore: mailStore, calendars: calendars, savedJobs: savedJobs, profiles: profiles, accounts: accounts, drafts: drafts, workday: workday, challenges: challenges, limiters: limiters}
+}
+
+// GetFailures handles GET /admin/failures
+// Returns the current failure simulation settings
+func (h *AdminHandler) GetFailures(c *gin.Context) {
+	c.JSON(http.StatusOK, h.failureSettings(h.simulator.Config()))
+}
+
+// UpdateFailures handles PUT /admin/failures
+// Changes failure simulation settings without a restart; omitted fields keep their values
+func (h *AdminHandler) UpdateFailures(c *gin.Context) {
+	var req models.FailureSettingsUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respond.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body: "+err.Error())
+		return
+	}
+
+	config := h.simulator.Config()
+	if req.Enabled != nil {
+		config.Enabled = *req.Enabled
+	}
+	for _, rate := range []struct {
+		field  string
+		value  *float64
+		target *float64
+	}{
+		{"failure_rate", req.FailureRate, &config.FailureRate},
+		{"slowdown_rate", req.SlowdownRate, &config.SlowdownRate},
+		{"timeout_rate", req.TimeoutRate, &config.TimeoutRate},
+		{"malformed_rate", req.MalformedRate, &config.MalformedRate},
+	} {
+		if rate.value == nil {
+			continue
+		}
+		if *rate.value < 0 || *rate.value > 1 {
+			respond.Error(c, http.StatusBadRequest, "invalid_rate", rate.field+" must be between 0.0 and 1.0.")
+			return
+		}
+		*rate.target = *rate.value
+	}
+	if req.SlowdownDuration != nil {
+		d, err := time.ParseDuration(*req.SlowdownDuration)
+		if err != nil || d < 0 || d > maxSlowdownDuration {
+			respond.Error(c, http.StatusBadRequest, "invalid_duration", "slowdown_duration must be a duration between 0s and "+maxSlowdownDuration.String()+", such as 5s or 1500ms.")
+			return
+		}
+		config.SlowdownDuration = d
+	}
+	if req.Routes != nil {
+		config.Routes = make(map[string]middleware.FailureRates, len(req.Routes))
+		for route, rates := range req.Routes {
+			if !middleware.IsRouteName(route) {
+				respond.Error(c, http.StatusBadRequest, "invalid_route", fmt.Sprintf("Route %q must be a method and route pattern, such as GET /api/jobs/:id.", route))
+				return
+			}
+			for _, rate := range []float64{rates.FailureRate, rates.SlowdownRate, rates.TimeoutRate, rates.MalformedRate} {
+				if rate < 0 || rate > 1 {
+					respond.Error(c, http.StatusBadRequest, "invalid_rate", "The rates of "+route+" must be between 0.0 and 1.0.")
+					return
+				}
+			}
+			config.Routes[route] = middleware.FailureRates(rates)
+		}
+	}
+	if req.Latency != nil {
+		config.Latency = make(map[string]middleware.LatencyDistribution, len(req.Latency))
+		for route, latency := range req.Latency {
+			if route != middleware.LatencyAllRoutes && !middleware.IsRouteName(route) {
+				respond.Error(c, http.StatusBadRequest, "invalid_route", fmt.Sprintf("Route %q must be a method and route pattern, such as GET /api/jobs/:id, or *.", route))
+				return
+			}
+			distribution, err := latencyDistribution(latency)
+			if err != nil {
+				respond.Error(c, http.StatusBadRequest, "invalid_latency", "Latency of "+route+": "+err.Error()+".")
+				return
+			}
+			config.Latency[route] = distribution
+		}
+	}
+
+	h.simulator.Configure(config)
+	c.JSON(http.StatusOK, h.failureSettings(config))
+}
+
+// failureSettings reports simulator settings in API form
+func (h *AdminHandler) failureSettings(config middleware.FailureConfig) models.FailureSettings {
+	settings := models.FailureSettings{
+		Enabled:          config.Enabled,
+		FailureRate:      config.FailureRate,
+		SlowdownRate:     config.SlowdownRate,
+		TimeoutRate:      config.TimeoutRate,
+		MalformedRate:    config.MalformedRate,
+		SlowdownDuration: config.SlowdownDuration.String(),
+	}
+	if len(config.Routes) > 0 {
+		settings.Routes = make(map[string]models.FailureRates, len(config.Routes))
+		for route, rates := range config.Routes {
+			settings.Routes[route] = models.FailureRates(rates)
+		}
+	}
+	if len(config.Latency) > 0 {
+		settings.Latency = make(map[string]models.SimulatedLatency, len(config.Latency))
+		for route, distribution := range config.Latency {
+			settings.Latency[route] = models.SimulatedLatency{
+				Distribution: distribution.Distribution,
+				P50:          durationString(distribution.P50),
+				P95:          durationString(distribution.P95),
+				P99:          durationString(distribution.P99),
+			}
+		}
+	}
+	if scenario := h.simulator.Scenario(); scenario != nil {
+		settings.Scenario = scenario.Names()
+	}
+	return settings
+}
+
+// latencyDistribution reads a latency distribution sent in API form
+func latencyDistribution(latency models.SimulatedLatency) (middleware.LatencyDistribution, error) {
+	distribution := middleware.LatencyDistribution{Distribution: latency.Distribution}
+	for _, percentile := range []struct {
+		name   string
+		value  string
+		target *time.Duration
+	}{
+		{"p50", latency.P50, &distribution.P50},
+		{"p95", latency.P95, &distribution.P95},
+		{"p99", latency.P99, &distribution.P99},
+	} {
+		if percentile.value == "" {
+			continue
+		}
+		d, err := time.ParseDuration(percentile.value)
+		if err != nil {
+			return distribution, fmt.Errorf("%s %q is not a duration such as 250ms", percentile.name, percentile.value)
+		}
+		*percentile.target = d
+	}
+	return distribution, distribution.Validate()
+}
+
+// durationString formats a duration, leaving zero empty
+func durationString(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return d.String()
+}
+
+// Reset handles POST /admin/reset
+// Clears every application, mailbox and interview calendar, restores the
+// seed jobs (or installs the jobs in the body instead) and starts rate
+// limits and statistics afresh
+func (h *AdminHandler) Reset(c *gin.Context) {
+	var req models.ResetRequest
+	var found violations
+
+	err := json.NewDecoder(c.Request.Body).Decode(&req)
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case err == nil, errors.Is(err, io.EOF):
+	case errors.As(err, &typeErr):
+		found.add(typeErr.Field, "type_mismatch", typeErr.Field+" must be "+jsonTypeName(typeErr.Type)+".")
+	default:
+		respond.Error(c, http.StatusBadRequest, "invalid_request", "Request body is not valid JSON.")
+		return
+	}
+
+	// nil restores the seed jobs
+	var jobs []models.Job
+	if req.Jobs != nil {
+		jobs = make([]models.Job, 0, len(req.Jobs))
+		ids := make(map[string]bool, len(req.Jobs))
+		for i := range req.Jobs {
+			prefix := fmt.Sprintf("jobs[%d].", i)
+			var own violations
+			own.addBinding(&req.Jobs[i])
+			if id := req.Jobs[i].ID; id != "" {
+				if !jobIDPattern.MatchString(id) {
+					own.add("id", "invalid_id", "id may only contain letters, digits, underscores and dashes, at most 64 of them.")
+				} else if ids[id] {
+					own.add("id", "duplicate_job", "id "+id+" is used by an earlier job.")
+				}
+				ids[id] = true
+			}
+			var job models.Job
+			job, own = h.jobFromRequest(req.Jobs[i], models.Job{}, own)
+			for _, v := range own {
+				found.add(prefix+v.Field, v.Code, v.Message)
+			}
+			jobs = append(jobs, job)
+		}
+	}
+	if apiErr := found.errAbout("The jobs have several problems. See violations for details."); apiErr != nil {
+		respond.Violations(c, apiErr.status, apiErr.code, apiErr.message, apiErr.violations)
+		return
+	}
+
+	cleared, err := h.appStore.ClearAll()
+	if err != nil {
+		respond.Error(c, http.StatusInternalServerError, "storage_failed", "Failed to clear applications: "+err.Error())
+		return
+	}
+	if err := h.jobStore.Reset(jobs); err != nil {
+		respondJobStoreError(c, err)
+		return
+	}
+	h.startAfresh()
+
+	c.JSON(http.StatusOK, models.ResetResponse{ApplicationsCleared: cleared, Jobs: h.jobStore.GetCount()})
+}
+
+// Snapshot handles POST /admin/snapshot
+// Returns the whole catalogue and every application as a snapshot file
+// that POST /admin/restore loads back
+func (h *AdminHandler) Snapshot(c *gin.Context) {
+	snapshot := models.Snapshot{
+		Version:      models.SnapshotVersion,
+		TakenAt:      time.Now().UTC(),
+		Jobs:         h.jobStore.GetAll(0),
+		Applications: h.appStore.Snapshot(),
+	}
+	c.Header("Content-Disposition", `attachment; filename="snapshot-`+snapshot.TakenAt.Format("20060102-150405")+`.json"`)
+	c.JSON(http.StatusOK, snapshot)
+}
+
+// Restore handles POST /admin/restore
+// Replaces the catalogue and every application with those of a snapshot
+// and clears the rest of the state as POST /admin/reset does, so every
+// environment restored from the same snapshot starts out identical
+func (h *AdminHandler) Restore(c *gin.Context) {
+	var snapshot models.Snapshot
+	if err := json.NewDecoder(c.Request.Body).Decode(&snapshot); err != nil {
+		respond.Error(c, http.StatusBadRequest, "invalid_request", "Request body is not valid JSON.")
+		return
+	}
+	if apiErr := h.validateSnapshot(snapshot).errAbout("The snapshot has several problems. See violations for details."); apiErr != nil {
+		respond.Violations(c, apiErr.status, apiErr.code, apiErr.message, apiErr.violations)
+		return
+	}
+
+	if err := h.jobStore.Load(snapshot.Jobs); err != nil {
+		respond.Error(c, http.StatusInternalServerError, "storage_failed", "Failed to restore jobs: "+err.Error())
+		return
+	}
+	cleared, err := h.appStore.Load(snapshot.Applications)
+	if err != nil {
+		respond.Error(c, http.StatusInternalServerError, "storage_failed", "Failed to restore applications: "+err.Error())
+		return
+	}
+	h.startAfresh()
+
+	c.JSON(http.StatusOK, models.RestoreResponse{
+		TakenAt:             snapshot.TakenAt,
+		ApplicationsCleared: cleared,
+		Applications:        len(snapshot.Applications),
+		Jobs:                h.jobStore.GetCount(),
+	})
+}
+
+// validateSnapshot checks that a snapshot can be restored as a whole
+// before anything is replaced
+func (h *AdminHandler) validateSnapshot(snapshot models.Snapshot) violations {
+	var found violations
+	if snapshot.Version != models.SnapshotVersion {
+		found.add("version", "unsupported_version", fmt.Sprintf("version must be %d.", models.SnapshotVersion))
+	}
+	if snapshot.Jobs == nil {
+		found.add("jobs", "required", "jobs is required.")
+	}
+	if _, err := store.ParseJobDates(snapshot.Jobs, h.jobStore.Location()); err != nil {
+		found.add("jobs", "invalid_date", err.Error())
+	}
+
+	jobIDs := make(map[string]bool, len(snapshot.Jobs))
+	for i, job := range snapshot.Jobs {
+		field := fmt.Sprintf("jobs[%d].id", i)
+		switch {
+		case !jobIDPattern.MatchString(job.ID):
+			found.add(field, "invalid_id", "id may only contain letters, digits, underscores and dashes, at most 64 of them.")
+		case jobIDs[job.ID]:
+			found.add(field, "duplicate_job", "id "+job.ID+" is used by an earlier job.")
+		}
+		jobIDs[job.ID] = true
+	}
+	appIDs := make(map[string]bool, len(snapshot.Applications))
+	for i, app := range snapshot.Applications {
+		field := fmt.Sprintf("applications[%d].id", i)
+		switch {
+		case app.ID == "":
+			found.add(field, "required", "id is required.")
+		case appIDs[app.ID]:
+			found.add(field, "duplicate_application", "id "+app.ID+" is used by an earlier application.")
+		}
+		appIDs[app.ID] = true
+	}
+	return found
+}
+
+// startAfresh clears everything but the jobs and applications, and starts
+// rate limits and statistics afresh
+func (h *AdminHandler) startAfresh() {
+	h.mailStore.Clear()
+	h.calendars.Clear()
+	h.savedJobs.Clear()
+	h.profiles.Clear()
+	h.accounts.Clear()
+	h.drafts.Clear()
+	h.workday.Clear()
+	h.challenges.Clear()
+	for _, limiter := range h.limiters {
+		limiter.Reset()
+	}
+	if scenario := h.simulator.Scenario(); scenario != nil {
+		scenario.Restart()
+	}
+	respond.ResetDisconnects()
+}
+
+// CreateJob handles POST /api/admin/jobs
+// Adds a job posting to the catalogue
+func (h *AdminHandler) CreateJob(c *gin.Context) {
+	req, found, apiErr := bindJobRequest(c)
+	if apiErr != nil {
+		respond.Violations(c, apiErr.status, apiErr.code, apiErr.message, apiErr.violations)
+		return
+	}
+	if req.ID != "" && !jobIDPattern.MatchString(req.ID) {
+		found.add("id", "invalid_id", "id may only contain letters, digits, underscores and dashes, at most 64 of them.")
+	}
+
+	job, found := h.jobFromRequest(req, models.Job{}, found)
+	if apiErr := found.errAbout("The job has several problems. See violations for details."); apiErr != nil {
+		respond.Violations(c, apiErr.status, apiErr.code, apiErr.message, apiErr.violations)
+		return
+	}
+
+	created, err := h.jobStore.Create(job)
+	if err != nil {
+		respondJobStoreError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// UpdateJob handles PUT /api/admin/jobs/:id
+// Replaces a job posting; an omitted posted_at keeps the current one
+func (h *AdminHandler) UpdateJob(c *gin.Context) {
+	current, exists := h.jobStore.GetByID(c.Param("id"))
+	if !exists {
+		respond.Error(c, http.StatusNotFound, "job_not_found", "The requested job could not be found.")
+		return
+	}
+
+	req, found, apiErr := bindJobRequest(c)
+	if apiErr != nil {
+		respond.Violations(c, apiErr.status, apiErr.code, apiErr.message, apiErr.violations)
+		return
+	}
+
+	job, found := h.jobFromRequest(req, current, found)
+	if apiErr := found.errAbout("The job has several problems. See violations for details."); apiErr != nil {
+		respond.Violations(c, apiErr.status, apiErr.code, apiErr.message, apiErr.violations)
+		return
+	}
+
+	updated, err := h.jobStore.Update(job)
+	if err != nil {
+		respondJobStoreError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// CloseJob handles POST /api/admin/jobs/:id/close
+// Stops a job accepting applications by closing it and moving its deadline
+// to now
+func (h *AdminHandler) CloseJob(c *gin.Context) {
+	closed, err := h.jobStore.Close(c.Param("id"), time.Now().UTC().Truncate(time.Second))
+	if err != nil {
+		respondJobStoreError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, closed)
+}
+
+// UpdateJobStatus handles PATCH /api/admin/jobs/:id/status
+// Moves a job through its lifecycle: publishing a draft, pausing, closing,
+// filling or reopening it
+func (h *AdminHandler) UpdateJobStatus(c *gin.Context) {
+	var req models.JobStatusUpdateRequest
+	if apiErr := bindJSON(c, &req); apiErr != nil {
+		respond.Violations(c, apiErr.status, apiErr.code, apiErr.message, apiErr.violations)
+		return
+	}
+	status := models.JobStatus(req.Status)
+	if !slices.Contains(models.JobStatuses, status) {
+		respond.Error(c, http.StatusBadRequest, "invalid_status", "Invalid status. Valid values: "+jobStatusValues())
+		return
+	}
+
+	// Reopening a job that has all its applications would only see it
+	// filled again
+	if job, exists := h.jobStore.GetByID(c.Param("id")); exists && status == models.JobOpen && isFilled(job, h.appStore) {
+		respond.Error(c, http.StatusConflict, "invalid_transition", "The job has taken its max_applications. Raise max_applications before reopening it.")
+		return
+	}
+
+	updated, err := h.jobStore.UpdateStatus(c.Param("id"), status)
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, updated)
+	case strings.Contains(err.Error(), "invalid transition"):
+		current, _ := h.jobStore.GetByID(c.Param("id"))
+		respond.Error(c, http.StatusConflict, "invalid_transition", fmt.Sprintf("A %s job cannot become %s.", current.Status, status))
+	case strings.Contains(err.Error(), "deadline passed"):
+		respond.Error(c, http.StatusConflict, "invalid_transition", "The application deadline has passed. Move application_deadline before reopening the job.")
+	default:
+		respondJobStoreError(c, err)
+	}
+}
+
+// jobStatusValues lists the job statuses
+func jobStatusValues() string {
+	values := make([]string, len(models.JobStatuses))
+	for i, status := range models.JobStatuses {
+		values[i] = string(status)
+	}
+	return strings.Join(values, ", ")
+}
+
+// DeleteJob handles DELETE /api/admin/jobs/:id
+// Removes a job posting; applications already made to it are kept
+func (h *AdminHandler) DeleteJob(c *gin.Context) {
+	if err := h.jobStore.Delete(c.Param("id")); err != nil {
+		respondJobStoreError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// bindJobRequest decodes a job request body. Values of the wrong type are
+// returned as violations, together with the binding rule failures; only
+// unreadable bodies, and in strict binding mode bodies with unknown fields,
+// fail outright.
+func bindJobRequest(c *gin.Context) (models.JobRequest, violations, *apiError) {
+	var req models.JobRequest
+	var found violations
+
+	data, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return req, nil, &apiError{status: http.StatusBadRequest, code: "invalid_request", message: "Invalid request body: " + err.Error()}
+	}
+	if c.GetBool(respond.StrictBindingKey) {
+		if unknown := unknownFields(data, reflect.TypeOf(req)); len(unknown) > 0 {
+			return req, nil, unknownFieldsError(unknown)
+		}
+	}
+
+	err = json.NewDecoder(bytes.NewReader(data)).Decode(&req)
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case err == nil:
+	case errors.Is(err, io.EOF):
+		return req, nil, &apiError{status: http.StatusBadRequest, code: "invalid_request", message: "Request body is required."}
+	case errors.As(err, &typeErr):
+		found.add(typeErr.Field, "type_mismatch", typeErr.Field+" must be "+jsonTypeName(typeErr.Type)+".")
+	default:
+		return req, nil, &apiError{status: http.StatusBadRequest, code: "invalid_request", message: "Request body is not valid JSON."}
+	}
+
+	found.addBinding(&req)
+	return req, found, nil
+}
+
+// jobFromRequest builds the job a request describes on top of current,
+// which is the zero Job on create, parsing its dates in the job store's
+// time zone and normalizing its accepted work authorizations. Problems are
+// added to found.
+func (h *AdminHandler) jobFromRequest(req models.JobRequest, current models.Job, found violations) (models.Job, violations) {
+	job := models.Job{
+		ID:                 current.ID,
+		Title:              req.Title,
+		Company:            req.Company,
+		Description:        req.Description,
+		Requirements:       req.Requirements,
+		Location:           req.Location,
+		IsRemote:           req.IsRemote,
+		Remote:             req.IsRemote,
+		Salary:             req.Salary,
+		ExperienceRequired: req.ExperienceRequired,
+		ExperienceYears:    req.ExperienceRequired,
+		JobType:            req.JobType,
+		Benefits:           req.Benefits,
+		CompanySize:        req.CompanySize,
+		Industry:           req.Industry,
+		ApplicationURL:     req.ApplicationURL,
+		Posted:             current.Posted,
+		PostedAt:           current.PostedAt,
+	}
+	if job.ID == "" {
+		job.ID = req.ID
+	}
+	if job.Requirements == nil {
+		job.Requirements = []string{}
+	}
+
+	loc := h.jobStore.Location()
+	switch {
+	case req.PostedAt != "":
+		t, err := dates.Parse(req.PostedAt, loc)
+		if err != nil {
+			found.add("posted_at", "invalid_date", "posted_at "+err.Error()+".")
+			break
+		}
+		job.Posted, job.PostedAt = t, dates.Format(t)
+	case job.PostedAt == "":
+		now := time.Now().UTC().Truncate(time.Second)
+		job.Posted, job.PostedAt = now, dates.Format(now)
+	}
+	if req.ApplicationDeadline != "" {
+		t, err := dates.ParseDeadline(req.ApplicationDeadline, loc)
+		if err != nil {
+			found.add("application_deadline", "invalid_date", "application_deadline "+err.Error()+".")
+		} else {
+			job.Deadline, job.ApplicationDeadline = t, dates.Format(t)
+		}
+	}
+
+	for i, raw := range req.AcceptedWorkAuthorizations {
+		value, ok := models.ParseWorkAuthorization(raw)
+		if !ok {
+			found.add(fmt.Sprintf("accepted_work_authorizations[%d]", i), "invalid_work_authorization",
+				fmt.Sprintf("Unrecognized work authorization %q. Must be one of: %s.", raw, workAuthorizationValues()))
+			continue
+		}
+		job.AcceptedWorkAuthorizations = append(job.AcceptedWorkAuthorizations, value)
+	}
+
+	if err := models.ValidateQuestions(req.Questions); err != nil {
+		found.add("questions", "invalid_question", err.Error()+".")
+	} else {
+		job.Questions = req.Questions
+	}
+	job.MaxApplications = req.MaxApplications
+	if req.Assignment != nil {
+		if err := req.Assignment.Validate(); err != nil {
+			found.add("assignment", "invalid_assignment", "assignment "+err.Error()+".")
+		} else {
+			job.Assignment = req.Assignment
+		}
+	}
+
+	switch status := models.JobStatus(req.Status); {
+	case slices.Contains(models.JobStatuses, status):
+		job.Status = status
+	case req.Status != "":
+		found.add("status", "invalid_status", "status must be one of: "+jobStatusValues()+".")
+	case current.Status != "":
+		job.Status = current.Status
+	default:
+		job.Status = models.JobOpen
+	}
+
+	return job, found
+}
+
+// respondJobStoreError reports a failed job store mutation
+func respondJobStoreError(c *gin.Context, err error) {
+	switch {
+	case strings.Contains(err.Error(), "not found"):
+		respond.Error(c, http.StatusNotFound, "job_not_found", "The requested job could not be found.")
+	case strings.Contains(err.Error(), "duplicate"):
+		respond.Error(c, http.StatusConflict, "duplicate_job", "A job with this id already exists.")
+	default:
+		respond.Error(c, http.StatusInternalServerError, "storage_failed", "Failed to save jobs: "+err.Error())
+	}
+}
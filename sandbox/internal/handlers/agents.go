@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// AgentHandler reports the stats of the agents naming themselves in
+// X-Agent-ID
+type AgentHandler struct {
+	agents *store.AgentStore
+}
+
+// NewAgentHandler creates a new agent handler
+func NewAgentHandler(agents *store.AgentStore) *AgentHandler {
+	return &AgentHandler{agents: agents}
+}
+
+// GetAgentStats handles GET /api/stats/agents
+// Returns the requests, errors, rate limiting, polling and submissions of
+// each agent, busiest first
+func (h *AgentHandler) GetAgentStats(c *gin.Context) {
+	agents := h.agents.List()
+	c.JSON(http.StatusOK, models.AgentStatsResponse{Agents: agents, Total: len(agents)})
+}
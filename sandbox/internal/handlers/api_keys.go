@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/middleware"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHandler mints and revokes API keys and reports their usage
+type APIKeyHandler struct {
+	keys *store.APIKeyStore
+}
+
+// NewAPIKeyHandler creates a new API key handler
+func NewAPIKeyHandler(keys *store.APIKeyStore) *APIKeyHandler {
+	return &APIKeyHandler{keys: keys}
+}
+
+// CreateKey handles POST /admin/api-keys
+// Mints a key; its secret is only ever returned here
+func (h *APIKeyHandler) CreateKey(c *gin.Context) {
+	var req models.APIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respond.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body: "+err.Error())
+		return
+	}
+
+	key, err := h.keys.Create(req.Name)
+	if err != nil {
+		respond.Error(c, http.StatusInternalServerError, "internal_error", "An unexpected error occurred. Please try again later.")
+		return
+	}
+	c.JSON(http.StatusCreated, key)
+}
+
+// ListKeys handles GET /admin/api-keys
+// Returns every key with its usage, without their secrets
+func (h *APIKeyHandler) ListKeys(c *gin.Context) {
+	keys := h.keys.List()
+	c.JSON(http.StatusOK, models.APIKeysResponse{Keys: keys, Total: len(keys)})
+}
+
+// GetKey handles GET /admin/api-keys/:id
+// Returns a key and its usage
+func (h *APIKeyHandler) GetKey(c *gin.Context) {
+	key, exists := h.keys.Get(c.Param("id"))
+	if !exists {
+		respond.Error(c, http.StatusNotFound, "api_key_not_found", "The specified API key could not be found.")
+		return
+	}
+	c.JSON(http.StatusOK, key)
+}
+
+// RevokeKey handles DELETE /admin/api-keys/:id
+// Stops the key from authenticating; its usage is kept
+func (h *APIKeyHandler) RevokeKey(c *gin.Context) {
+	key, exists := h.keys.Revoke(c.Param("id"))
+	if !exists {
+		respond.Error(c, http.StatusNotFound, "api_key_not_found", "The specified API key could not be found.")
+		return
+	}
+	c.JSON(http.StatusOK, key)
+}
+
+// GetUsage handles GET /api/usage
+// Returns the caller's own API key and its usage
+func (h *APIKeyHandler) GetUsage(c *gin.Context) {
+	key, exists := h.keys.Get(c.GetString(middleware.APIKeyIDKey))
+	if !exists {
+		c.Header("WWW-Authenticate", `APIKey header="`+middleware.APIKeyHeader+`"`)
+		respond.Error(c, http.StatusUnauthorized, "api_key_required", "Send an API key in "+middleware.APIKeyHeader+" to see its usage.")
+		return
+	}
+	c.JSON(http.StatusOK, key)
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// maxSkillLength is the longest skill a profile may list, in characters
+const maxSkillLength = 100
+
+// ApplicantHandler handles the applicant profile endpoints
+type ApplicantHandler struct {
+	appStore   *store.ApplicationStore
+	applicants *store.ApplicantStore
+}
+
+// NewApplicantHandler creates a new applicant handler
+func NewApplicantHandler(appStore *store.ApplicationStore, applicants *store.ApplicantStore) *ApplicantHandler {
+	return &ApplicantHandler{appStore: appStore, applicants: applicants}
+}
+
+// CreateApplicant handles POST /api/applicants
+// Creates a profile that applications can be submitted from with
+// applicant_id, following the same rules as submission for the fields they
+// share
+func (h *ApplicantHandler) CreateApplicant(c *gin.Context) {
+	var req models.ApplicantRequest
+	found, apiErr := decodeJSON(c, &req)
+	if apiErr == nil {
+		apiErr = validateApplicant(h.appStore, &req, found).errAbout("The profile has several problems. See violations for details.")
+	}
+	if apiErr != nil {
+		respond.Violations(c, apiErr.status, apiErr.code, apiErr.message, apiErr.violations)
+		return
+	}
+
+	applicant, err := h.applicants.Create(models.Applicant{
+		Name:      req.Name,
+		Email:     req.Email,
+		Phone:     req.Phone,
+		Resume:    req.Resume,
+		Skills:    req.Skills,
+		LinkedIn:  req.LinkedIn,
+		GitHub:    req.GitHub,
+		Portfolio: req.Portfolio,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate") {
+			respond.Error(c, http.StatusConflict, "duplicate_applicant", "A profile already exists for this email address.")
+			return
+		}
+		respond.Error(c, http.StatusInternalServerError, "storage_failed", "Failed to create profile: "+err.Error())
+		return
+	}
+	c.JSON(http.StatusCreated, applicant)
+}
+
+// GetApplicant handles GET /api/applicants/:id
+// Returns an applicant profile
+func (h *ApplicantHandler) GetApplicant(c *gin.Context) {
+	applicant, exists := h.applicants.GetByID(c.Param("id"))
+	if !exists {
+		respond.Error(c, http.StatusNotFound, "applicant_not_found", "The specified applicant could not be found.")
+		return
+	}
+	c.JSON(http.StatusOK, applicant)
+}
+
+// GetApplicantApplications handles GET /api/applicants/:id/applications
+// Returns every application submitted from a profile, newest first unless
+// ?order=oldest, with how many are in each status
+func (h *ApplicantHandler) GetApplicantApplications(c *gin.Context) {
+	params := newQueryParams(c)
+	order := params.order()
+	if !params.check() {
+		return
+	}
+	applicant, exists := h.applicants.GetByID(c.Param("id"))
+	if !exists {
+		respond.Error(c, http.StatusNotFound, "applicant_not_found", "The specified applicant could not be found.")
+		return
+	}
+	if _, apiErr := applicantScope(c.Request.Context(), applicant.Email); apiErr != nil {
+		respond.Error(c, apiErr.status, apiErr.code, apiErr.message)
+		return
+	}
+
+	apps := h.appStore.Propagated(h.appStore.GetByApplicantID(applicant.ID, order))
+	response := models.ApplicantApplicationsResponse{
+		ApplicantID:  applicant.ID,
+		Applications: make([]models.ApplicationStatusResponse, 0, len(apps)),
+		Total:        len(apps),
+		ByStatus:     make(map[models.ApplicationStatus]int),
+	}
+	for _, app := range apps {
+		response.Applications = append(response.Applications, listEntry(app))
+		response.ByStatus[app.Status]++
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// validateApplicant collects every problem with a profile: the
+// ApplicantRequest binding rules, text lengths, the email address, the
+// phone number, profile links and skills, following the application
+// store's settings. Valid emails and profile links are replaced with their
+// normalized form.
+func validateApplicant(appStore *store.ApplicationStore, req *models.ApplicantRequest, found violations) violations {
+	found.addBinding(req)
+	limits := appStore.Limits()
+	found.addLength("name", req.Name, limits.ApplicantName)
+	found.addLength("resume", req.Resume, limits.Resume)
+	found.addEmail("email", &req.Email, appStore)
+	found.addPhone(req.Phone, appStore)
+	found.addLinks(&req.LinkedIn, &req.GitHub, &req.Portfolio, appStore)
+
+	if len(req.Skills) > models.MaxApplicantSkills {
+		found.add("skills", "too_many_skills", fmt.Sprintf("skills may list at most %d skills.", models.MaxApplicantSkills))
+	}
+	for i, skill := range req.Skills {
+		field := fmt.Sprintf("skills[%d]", i)
+		if strings.TrimSpace(skill) == "" {
+			found.add(field, "invalid_skill", "Skills must not be blank.")
+		}
+		found.addLength(field, skill, maxSkillLength)
+	}
+	return found
+}
+
+// fillFromApplicant fills the name, email, resume, phone and profile links
+// req leaves empty from the profile it names, reporting false when there is
+// no such profile
+func fillFromApplicant(applicants *store.ApplicantStore, req *models.ApplicationRequest) bool {
+	applicant, exists := applicants.GetByID(req.ApplicantID)
+	if !exists {
+		return false
+	}
+	req.ApplicantName = cmp.Or(req.ApplicantName, applicant.Name)
+	req.ApplicantEmail = cmp.Or(req.ApplicantEmail, applicant.Email)
+	req.Resume = cmp.Or(req.Resume, applicant.Resume)
+	req.Phone = cmp.Or(req.Phone, applicant.Phone)
+	req.LinkedIn = cmp.Or(req.LinkedIn, applicant.LinkedIn)
+	req.GitHub = cmp.Or(req.GitHub, applicant.GitHub)
+	req.Portfolio = cmp.Or(req.Portfolio, applicant.Portfolio)
+	return true
+}
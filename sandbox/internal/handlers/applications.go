@@ -2,167 +2,163 @@ package handlers
 
 import (
 	"net/http"
-	"regexp"
-	"strconv"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/i18n"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/middleware"
 	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/scoring"
 	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
 	"github.com/gin-gonic/gin"
 )
 
 // ApplicationHandler handles application-related API endpoints
 type ApplicationHandler struct {
-	jobStore *store.JobStore
-	appStore *store.ApplicationStore
+	jobStore   *store.JobStore
+	appStore   *store.ApplicationStore
+	applicants *store.ApplicantStore
+	guard      *SubmitGuard
 }
 
 // NewApplicationHandler creates a new application handler
-func NewApplicationHandler(jobStore *store.JobStore, appStore *store.ApplicationStore) *ApplicationHandler {
+func NewApplicationHandler(jobStore *store.JobStore, appStore *store.ApplicationStore, applicants *store.ApplicantStore, guard *SubmitGuard) *ApplicationHandler {
 	return &ApplicationHandler{
-		jobStore: jobStore,
-		appStore: appStore,
+		jobStore:   jobStore,
+		appStore:   appStore,
+		applicants: applicants,
+		guard:      guard,
 	}
 }
 
 // SubmitApplication handles POST /api/applications
 // This is the main endpoint for submitting job applications
 func (h *ApplicationHandler) SubmitApplication(c *gin.Context) {
-	var req models.ApplicationRequest
-
-	// Parse request body
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "invalid_request",
-			Message: "Invalid request body: " + err.Error(),
-			Code:    400,
-		})
+	// Parse request body (JSON or form)
+	req, found, apiErr := bindApplication(c)
+	if apiErr != nil {
+		respond.Violations(c, apiErr.status, apiErr.code, apiErr.message, apiErr.violations)
 		return
 	}
-
-	// Validate required fields
-	if req.JobID == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "missing_job_id",
-			Message: "Job ID is required.",
-			Code:    400,
-		})
+	markHoneypot(c, h.appStore, req)
+	if req.ApplicantID != "" && !fillFromApplicant(h.applicants, &req) {
+		respond.Error(c, http.StatusNotFound, "applicant_not_found", "The specified applicant could not be found.")
 		return
 	}
-
-	if req.ApplicantName == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "missing_applicant_name",
-			Message: "Applicant name is required.",
-			Code:    400,
-		})
+	if apiErr := h.guard.check(c.Request.Context(), req, false); apiErr != nil {
+		respond.Error(c, apiErr.status, apiErr.code, apiErr.message)
 		return
 	}
 
-	if req.ApplicantEmail == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "missing_applicant_email",
-			Message: "Applicant email is required.",
-			Code:    400,
-		})
+	app, apiErr := submitApplication(h.jobStore, h.appStore, req, found...)
+	if apiErr != nil {
+		respond.Violations(c, apiErr.status, apiErr.code, apiErr.message, apiErr.violations)
 		return
 	}
 
-	// Validate email format
-	if !isValidEmail(req.ApplicantEmail) {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "invalid_email",
-			Message: "Please provide a valid email address.",
-			Code:    400,
-		})
+	// Return success response
+	markSubmitted(c, app)
+	respond.Data(c, http.StatusCreated, submissionResponse(app, respond.Language(c)))
+}
+
+// GetApplication handles GET /api/applications/:id
+// Returns the status and details of an application
+func (h *ApplicationHandler) GetApplication(c *gin.Context) {
+	appID := c.Param("id")
+
+	app, exists := h.appStore.GetPropagatedByID(appID)
+	if !exists {
+		respond.Error(c, http.StatusNotFound, "application_not_found", "The specified application could not be found.")
 		return
 	}
 
-	if req.Resume == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "missing_resume",
-			Message: "Resume is required.",
-			Code:    400,
-		})
+	if respond.NotModified(c, app.Modified()) {
 		return
 	}
 
-	// Check if job exists
-	job, exists := h.jobStore.GetByID(req.JobID)
-	if !exists {
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "job_not_found",
-			Message: "The specified job does not exist.",
-			Code:    404,
-		})
+	respond.Data(c, http.StatusOK, statusResponse(app, respond.Language(c)))
+}
+
+// ListApplications handles GET /api/applications
+// Returns a list of applications (optionally filtered by email)
+func (h *ApplicationHandler) ListApplications(c *gin.Context) {
+	params := newQueryParams(c)
+	email := params.filter("email")
+	jobID := params.filter("job_id")
+	status := params.enum("status", applicationStatuses...)
+	order := params.order()
+	list := applicationsList(order)
+	pg := params.page(list, 100)
+	if !params.check() {
 		return
 	}
-
-	// Check if job is still accepting applications
-	if job.ApplicationDeadline != "" {
-		deadline, err := time.Parse(time.RFC3339, job.ApplicationDeadline)
-		if err == nil && time.Now().After(deadline) {
-			c.JSON(http.StatusBadRequest, models.ErrorResponse{
-				Error:   "deadline_passed",
-				Message: "The application deadline for this job has passed.",
-				Code:    400,
-			})
-			return
-		}
+	if respond.IsJSONAPI(c) {
+		// JSON:API clients paginate with page[number] and page[size] instead
+		pg = page{}
+	}
+	// Applicants only see their own applications
+	email, apiErr := applicantScope(c.Request.Context(), email)
+	if apiErr != nil {
+		respond.Error(c, apiErr.status, apiErr.code, apiErr.message)
+		return
 	}
 
-	// Create application
-	app, err := h.appStore.Create(req, job)
-	if err != nil {
-		// Check if it's a duplicate application
-		if strings.Contains(err.Error(), "duplicate") {
-			c.JSON(http.StatusConflict, models.ErrorResponse{
-				Error:   "duplicate_application",
-				Message: "You have already applied to this job.",
-				Code:    409,
-			})
-			return
-		}
+	matches := listApplications(h.appStore, email, jobID, 0, order)
+	if status != "" {
+		matches = withStatus(matches, models.ApplicationStatus(status))
+	}
+	apps, next := paginate(c, pg, matches, list, applicationCursorID, h.appStore.Position, order == store.NewestFirst)
 
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "application_failed",
-			Message: "Failed to submit application: " + err.Error(),
-			Code:    500,
-		})
-		return
+	// Convert to response format
+	responses := make([]models.ApplicationStatusResponse, 0, len(apps))
+	for _, app := range apps {
+		responses = append(responses, listEntry(app))
 	}
 
-	// Return success response
-	c.JSON(http.StatusCreated, models.ApplicationResponse{
-		Success:        true,
-		ConfirmationID: app.ConfirmationID,
-		ApplicationID:  app.ConfirmationID, // Alias
-		Status:         app.Status,
-		Message:        "Application submitted successfully. You will receive a confirmation email shortly.",
-		SubmittedAt:    app.SubmittedAt.Format(time.RFC3339),
-		JobID:          app.JobID,
-		JobTitle:       app.JobTitle,
-		Company:        app.Company,
-	})
+	respond.List(c, http.StatusOK, models.ApplicationsListResponse{
+		Applications: responses,
+		Total:        len(responses),
+		NextCursor:   next,
+	}, responses)
 }
 
-// GetApplication handles GET /api/applications/:id
-// Returns the status and details of an application
-func (h *ApplicationHandler) GetApplication(c *gin.Context) {
-	appID := c.Param("id")
-
-	app, exists := h.appStore.GetByID(appID)
-	if !exists {
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "application_not_found",
-			Message: "The specified application could not be found.",
-			Code:    404,
-		})
+// ExportApplications handles GET /api/applications/export
+// Streams every application matching the list filters, in full, as CSV
+// (the default) or with ?format=jsonl as one JSON object per line. Custom
+// answers are flattened into a custom_answers.<id> column per question.
+func (h *ApplicationHandler) ExportApplications(c *gin.Context) {
+	params := newQueryParams(c)
+	format := params.enum("format", exportFormats...)
+	email := params.filter("email")
+	jobID := params.filter("job_id")
+	status := params.enum("status", applicationStatuses...)
+	order := params.order()
+	limit := params.exportLimit()
+	if !params.check() {
+		return
+	}
+	// Applicants only export their own applications
+	email, apiErr := applicantScope(c.Request.Context(), email)
+	if apiErr != nil {
+		respond.Error(c, apiErr.status, apiErr.code, apiErr.message)
 		return
 	}
 
-	c.JSON(http.StatusOK, models.ApplicationStatusResponse{
+	apps := listApplications(h.appStore, email, jobID, 0, order)
+	if status != "" {
+		apps = withStatus(apps, models.ApplicationStatus(status))
+	}
+	if limit > 0 && len(apps) > limit {
+		apps = apps[:limit]
+	}
+	writeExport(c, format, "applications", applicationTable(apps))
+}
+
+// listEntry is how an application appears in application lists
+func listEntry(app *models.Application) models.ApplicationStatusResponse {
+	return models.ApplicationStatusResponse{
 		ApplicationID:  app.ConfirmationID,
 		ConfirmationID: app.ConfirmationID,
 		JobID:          app.JobID,
@@ -171,47 +167,22 @@ func (h *ApplicationHandler) GetApplication(c *gin.Context) {
 		Status:         app.Status,
 		SubmittedAt:    app.SubmittedAt.Format(time.RFC3339),
 		UpdatedAt:      app.UpdatedAt.Format(time.RFC3339),
-		Message:        getStatusMessage(app.Status),
-	})
+		MatchScore:     matchScore(app),
+	}
 }
 
-// ListApplications handles GET /api/applications
-// Returns a list of applications (optionally filtered by email)
-func (h *ApplicationHandler) ListApplications(c *gin.Context) {
-	email := c.Query("email")
-	jobID := c.Query("job_id")
-	limitStr := c.DefaultQuery("limit", "100")
-	limit, _ := strconv.Atoi(limitStr)
-
-	var apps []*models.Application
-
-	if email != "" {
-		apps = h.appStore.GetByEmail(email)
-	} else if jobID != "" {
-		apps = h.appStore.GetByJobID(jobID)
-	} else {
-		apps = h.appStore.GetAll(limit)
-	}
-
-	// Convert to response format
-	responses := make([]models.ApplicationStatusResponse, 0, len(apps))
-	for _, app := range apps {
-		responses = append(responses, models.ApplicationStatusResponse{
-			ApplicationID:  app.ConfirmationID,
-			ConfirmationID: app.ConfirmationID,
-			JobID:          app.JobID,
-			JobTitle:       app.JobTitle,
-			Company:        app.Company,
-			Status:         app.Status,
-			SubmittedAt:    app.SubmittedAt.Format(time.RFC3339),
-			UpdatedAt:      app.UpdatedAt.Format(time.RFC3339),
-		})
+// applicationsList names the application list walked in order in cursors,
+// so a cursor cannot be used with the opposite order
+func applicationsList(order store.Order) string {
+	if order == store.OldestFirst {
+		return "applications:oldest"
 	}
+	return "applications:newest"
+}
 
-	c.JSON(http.StatusOK, gin.H{
-		"applications": responses,
-		"total":        len(responses),
-	})
+// applicationCursorID names an application in cursors
+func applicationCursorID(app *models.Application) string {
+	return app.ID
 }
 
 // UpdateApplicationStatus handles PATCH /api/applications/:id/status
@@ -219,17 +190,9 @@ func (h *ApplicationHandler) ListApplications(c *gin.Context) {
 func (h *ApplicationHandler) UpdateApplicationStatus(c *gin.Context) {
 	appID := c.Param("id")
 
-	var req struct {
-		Status string `json:"status" binding:"required"`
-		Notes  string `json:"notes"`
-	}
-
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "invalid_request",
-			Message: "Invalid request body: " + err.Error(),
-			Code:    400,
-		})
+	req, apiErr := bindStatusUpdate(c)
+	if apiErr != nil {
+		respond.Violations(c, apiErr.status, apiErr.code, apiErr.message, apiErr.violations)
 		return
 	}
 
@@ -240,30 +203,25 @@ func (h *ApplicationHandler) UpdateApplicationStatus(c *gin.Context) {
 		"submitted":   models.StatusSubmitted,
 		"rejected":    models.StatusRejected,
 		"shortlisted": models.StatusShortlisted,
+		"withdrawn":   models.StatusWithdrawn,
 	}
 
 	status, valid := validStatuses[req.Status]
 	if !valid {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "invalid_status",
-			Message: "Invalid status. Valid values: received, reviewing, submitted, rejected, shortlisted",
-			Code:    400,
-		})
+		respond.Error(c, http.StatusBadRequest, "invalid_status", "Invalid status. Valid values: received, reviewing, submitted, rejected, shortlisted, withdrawn")
 		return
 	}
 
-	err := h.appStore.UpdateStatus(appID, status, req.Notes)
+	app, err := h.appStore.UpdateStatus(appID, status, req.Notes, models.ActorAPI)
 	if err != nil {
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "application_not_found",
-			Message: "The specified application could not be found.",
-			Code:    404,
-		})
+		if strings.Contains(err.Error(), "not found") {
+			respond.Error(c, http.StatusNotFound, "application_not_found", "The specified application could not be found.")
+			return
+		}
+		respond.Error(c, http.StatusInternalServerError, "storage_failed", "Failed to update application: "+err.Error())
 		return
 	}
 
-	app, _ := h.appStore.GetByID(appID)
-
 	c.JSON(http.StatusOK, gin.H{
 		"success":        true,
 		"application_id": app.ConfirmationID,
@@ -272,18 +230,124 @@ func (h *ApplicationHandler) UpdateApplicationStatus(c *gin.Context) {
 	})
 }
 
+// UpdateApplication handles PATCH /api/applications/:id
+// Corrects the cover letter, phone, profile links or custom answers of an
+// application whose review has not started
+func (h *ApplicationHandler) UpdateApplication(c *gin.Context) {
+	app, exists := h.appStore.GetByID(c.Param("id"))
+	if !exists {
+		respond.Error(c, http.StatusNotFound, "application_not_found", "The specified application could not be found.")
+		return
+	}
+
+	req, found, apiErr := bindApplicationUpdate(c)
+	if apiErr == nil {
+		// A job removed since keeps no questions to check answers against
+		job, _ := h.jobStore.GetByID(app.JobID)
+		apiErr = validateApplicationUpdate(h.appStore, job, app, &req, found).errAbout("The edit has several problems. See violations for details.")
+	}
+	if apiErr != nil {
+		respond.Violations(c, apiErr.status, apiErr.code, apiErr.message, apiErr.violations)
+		return
+	}
+
+	app, err := h.appStore.Update(app.ID, req)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			respond.Error(c, http.StatusNotFound, "application_not_found", "The specified application could not be found.")
+		case strings.Contains(err.Error(), "review has started"):
+			respond.Error(c, http.StatusConflict, "review_started", "This application can only be edited while its status is received.")
+		case strings.Contains(err.Error(), "duplicate"):
+			respond.Error(c, http.StatusConflict, "duplicate_application", "You have already applied to this job.")
+		default:
+			respond.Error(c, http.StatusInternalServerError, "storage_failed", "Failed to update application: "+err.Error())
+		}
+		return
+	}
+	respond.Data(c, http.StatusOK, statusResponse(app, respond.Language(c)))
+}
+
+// WithdrawApplication handles POST /api/applications/:id/withdraw
+// Withdraws an application on the applicant's behalf, after which they may
+// apply to the job again
+func (h *ApplicationHandler) WithdrawApplication(c *gin.Context) {
+	var req models.WithdrawRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respond.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body: "+err.Error())
+			return
+		}
+	}
+
+	app, apiErr := withdrawApplication(h.appStore, c.Param("id"), req.Reason)
+	if apiErr != nil {
+		respond.Error(c, apiErr.status, apiErr.code, apiErr.message)
+		return
+	}
+	respond.Data(c, http.StatusOK, statusResponse(app, respond.Language(c)))
+}
+
+// VerifyApplication handles POST /api/applications/:id/verify
+// Completes an application held in pending_verification with the code
+// emailed to the applicant, moving it to received
+func (h *ApplicationHandler) VerifyApplication(c *gin.Context) {
+	var req models.VerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respond.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body: "+err.Error())
+		return
+	}
+
+	app, err := h.appStore.Verify(c.Param("id"), req.Token)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			respond.Error(c, http.StatusNotFound, "application_not_found", "The specified application could not be found.")
+		case strings.Contains(err.Error(), "not pending verification"):
+			respond.Error(c, http.StatusConflict, "not_pending_verification", "This application is not waiting for email verification.")
+		case strings.Contains(err.Error(), "invalid verification token"):
+			respond.Error(c, http.StatusBadRequest, "invalid_token", "The verification token is incorrect. Use the code in the email sent to the applicant.")
+		default:
+			respond.Error(c, http.StatusInternalServerError, "storage_failed", "Failed to verify application: "+err.Error())
+		}
+		return
+	}
+	respond.Data(c, http.StatusOK, statusResponse(app, respond.Language(c)))
+}
+
+// GetApplicationTimeline handles GET /api/applications/:id/timeline
+// Returns every status the application has had, with when, why and by whom
+// it was changed
+func (h *ApplicationHandler) GetApplicationTimeline(c *gin.Context) {
+	app, exists := h.appStore.GetPropagatedByID(c.Param("id"))
+	if !exists {
+		respond.Error(c, http.StatusNotFound, "application_not_found", "The specified application could not be found.")
+		return
+	}
+
+	if respond.NotModified(c, app.Modified()) {
+		return
+	}
+
+	respond.Data(c, http.StatusOK, models.ApplicationTimeline{
+		ApplicationID: app.ConfirmationID,
+		Status:        app.Status,
+		Timeline:      app.StatusHistory,
+	})
+}
+
 // GetApplicationReceipt handles GET /api/applications/:id/receipt
 // Returns a receipt/confirmation for the application
 func (h *ApplicationHandler) GetApplicationReceipt(c *gin.Context) {
 	appID := c.Param("id")
 
-	app, exists := h.appStore.GetByID(appID)
+	app, exists := h.appStore.GetPropagatedByID(appID)
 	if !exists {
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "application_not_found",
-			Message: "The specified application could not be found.",
-			Code:    404,
-		})
+		respond.Error(c, http.StatusNotFound, "application_not_found", "The specified application could not be found.")
+		return
+	}
+
+	if respond.NotModified(c, app.Modified()) {
 		return
 	}
 
@@ -306,10 +370,217 @@ func (h *ApplicationHandler) GetApplicationReceipt(c *gin.Context) {
 
 // Helper functions
 
-func isValidEmail(email string) bool {
-	// Simple email validation
-	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
-	return emailRegex.MatchString(email)
+// apiError is a failed operation with its HTTP status and machine-readable code
+type apiError struct {
+	status     int
+	code       string
+	message    string
+	violations violations
+}
+
+// Error implements the error interface
+func (e *apiError) Error() string {
+	return e.message
+}
+
+// detail returns the message, spelling out every violation when there are
+// several, for error formats without a violations list
+func (e *apiError) detail() string {
+	if len(e.violations) < 2 {
+		return e.message
+	}
+	messages := make([]string, len(e.violations))
+	for i, v := range e.violations {
+		messages[i] = v.Message
+	}
+	return strings.Join(messages, " ")
+}
+
+// submitApplication validates and stores an application. It is shared by the
+// REST handler and the GraphQL mutation so both follow the same rules. found
+// holds violations already detected while decoding the request; every other
+// problem is collected before anything is reported. Profile links are stored
+// in their normalized form.
+func submitApplication(jobStore *store.JobStore, appStore *store.ApplicationStore, req models.ApplicationRequest, found ...models.Violation) (*models.Application, *apiError) {
+	job, problems, warnings := validateApplication(jobStore, appStore, &req, found)
+	if apiErr := problems.err(); apiErr != nil {
+		return nil, apiErr
+	}
+
+	// Create application
+	app, err := appStore.Create(req, job, warnings)
+	if err != nil {
+		// Check if it's a duplicate application
+		if strings.Contains(err.Error(), "duplicate") {
+			return nil, &apiError{status: http.StatusConflict, code: "duplicate_application", message: "You have already applied to this job."}
+		}
+		// Another submission took the last slot since validation
+		if strings.Contains(err.Error(), "job filled") {
+			return nil, &apiError{status: http.StatusGone, code: "job_filled", message: "This job has been filled and is no longer accepting applications."}
+		}
+
+		return nil, &apiError{status: http.StatusInternalServerError, code: "application_failed", message: "Failed to submit application: " + err.Error()}
+	}
+
+	// Knock out applicants whose work authorization the job does not accept
+	if !acceptsWorkAuthorization(job, app.WorkAuthorization) {
+		rejected, err := appStore.UpdateStatus(app.ID, models.StatusRejected,
+			"Automatically rejected: work authorization "+app.WorkAuthorization+" is not accepted for this job.", models.ActorSystem)
+		if err == nil {
+			app = rejected
+		}
+	}
+
+	return app, nil
+}
+
+// withdrawApplication withdraws an application, turning the store's errors
+// into API errors
+func withdrawApplication(appStore *store.ApplicationStore, id, reason string) (*models.Application, *apiError) {
+	app, err := appStore.Withdraw(id, reason)
+	switch {
+	case err == nil:
+		return app, nil
+	case strings.Contains(err.Error(), "not found"):
+		return nil, &apiError{status: http.StatusNotFound, code: "application_not_found", message: "The specified application could not be found."}
+	case strings.Contains(err.Error(), "already withdrawn"):
+		return nil, &apiError{status: http.StatusConflict, code: "already_withdrawn", message: "This application has already been withdrawn."}
+	case strings.Contains(err.Error(), "already rejected"):
+		return nil, &apiError{status: http.StatusConflict, code: "application_closed", message: "This application has been rejected and can no longer be withdrawn."}
+	}
+	return nil, &apiError{status: http.StatusInternalServerError, code: "storage_failed", message: "Failed to withdraw application: " + err.Error()}
+}
+
+// markSubmitted records on the request which application it submitted, so
+// runs can report it
+func markSubmitted(c *gin.Context, app *models.Application) {
+	c.Set(middleware.SubmittedApplicationKey, app.ConfirmationID)
+	c.Set(middleware.ApplicationKey, *app)
+	if app.ShadowRejected {
+		c.Set(middleware.ShadowRejectedKey, true)
+	}
+	if app.Trap != "" {
+		c.Set(middleware.TrapJobKey, app.JobID)
+		c.Set(middleware.TrapKey, app.Trap)
+	}
+}
+
+// markTrap records on the request that it opened a trap job, so runs are
+// scored on whether they went on to apply
+func markTrap(c *gin.Context, job models.Job) {
+	if job.Trap != "" {
+		c.Set(middleware.TrapJobKey, job.ID)
+		c.Set(middleware.TrapKey, job.Trap)
+	}
+}
+
+// markHoneypot records on the request which honeypot fields it filled in,
+// when honeypots are on, so runs can report it whether or not the
+// submission goes through
+func markHoneypot(c *gin.Context, appStore *store.ApplicationStore, req models.ApplicationRequest) {
+	if appStore.Honeypot() == "" {
+		return
+	}
+	if hits := req.HoneypotHits(); len(hits) > 0 {
+		c.Set(middleware.HoneypotKey, hits)
+	}
+}
+
+// acceptsWorkAuthorization reports whether the job accepts applicants with
+// the given normalized work authorization. Jobs without a list accept
+// everyone, as do all jobs when the applicant did not say.
+func acceptsWorkAuthorization(job models.Job, value string) bool {
+	if len(job.AcceptedWorkAuthorizations) == 0 || value == "" {
+		return true
+	}
+	return slices.Contains(job.AcceptedWorkAuthorizations, models.WorkAuthorization(value))
+}
+
+// GetWorkAuthorizations handles GET /api/meta/work-authorizations
+// Lists the accepted work authorization values, their labels and synonyms
+func (h *ApplicationHandler) GetWorkAuthorizations(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"work_authorizations": models.WorkAuthorizations,
+		"strict":              h.appStore.StrictWorkAuthorization(),
+	})
+}
+
+// submissionResponse describes a newly submitted application in lang
+func submissionResponse(app *models.Application, lang string) models.ApplicationResponse {
+	message := "Application submitted successfully. You will receive a confirmation email shortly."
+	if app.Status == models.StatusPendingVerification {
+		message = "Application submitted. Verify your email address with the code we sent to complete it."
+	}
+	return models.ApplicationResponse{
+		Success:        true,
+		ConfirmationID: app.ConfirmationID,
+		ApplicationID:  app.ConfirmationID, // Alias
+		Status:         app.Status,
+		Message:        i18n.T(lang, message),
+		SubmittedAt:    app.SubmittedAt.Format(time.RFC3339),
+		JobID:          app.JobID,
+		JobTitle:       app.JobTitle,
+		Company:        app.Company,
+		Warnings:       app.Warnings,
+	}
+}
+
+// statusResponse describes an application's current status in lang
+func statusResponse(app *models.Application, lang string) models.ApplicationStatusResponse {
+	return models.ApplicationStatusResponse{
+		ApplicationID:  app.ConfirmationID,
+		ConfirmationID: app.ConfirmationID,
+		JobID:          app.JobID,
+		JobTitle:       app.JobTitle,
+		Company:        app.Company,
+		Status:         app.Status,
+		SubmittedAt:    app.SubmittedAt.Format(time.RFC3339),
+		UpdatedAt:      app.UpdatedAt.Format(time.RFC3339),
+		Message:        i18n.T(lang, getStatusMessage(app.Status)),
+		MatchScore:     matchScore(app),
+		StatusHistory:  app.StatusHistory,
+		CustomAnswers:  app.CustomAnswers,
+	}
+}
+
+// matchScore returns an application's score out of 100, or nil if it was
+// never scored
+func matchScore(app *models.Application) *int {
+	if app.Score == nil {
+		return nil
+	}
+	score := app.Score.MatchScore
+	return &score
+}
+
+// listApplications applies the list filters in order of precedence: email,
+// job_id. Applications still propagating are left out.
+func listApplications(appStore *store.ApplicationStore, email, jobID string, limit int, order store.Order) []*models.Application {
+	var apps []*models.Application
+	switch {
+	case email != "":
+		apps = appStore.GetByEmail(email, order)
+	case jobID != "":
+		apps = appStore.GetByJobID(jobID, order)
+	default:
+		apps = appStore.GetAll(0, order)
+	}
+	apps = appStore.Propagated(apps)
+	if limit > 0 && len(apps) > limit {
+		apps = apps[:limit]
+	}
+	return apps
+}
+
+// withStatus keeps the applications in the given status
+func withStatus(apps []*models.Application, status models.ApplicationStatus) []*models.Application {
+	kept := make([]*models.Application, 0, len(apps))
+	for _, app := range apps {
+		if app.Status == status {
+			kept = append(kept, app)
+		}
+	}
+	return kept
 }
 
 func getStatusMessage(status models.ApplicationStatus) string {
@@ -319,6 +590,10 @@ func getStatusMessage(status models.ApplicationStatus) string {
 		models.StatusSubmitted:   "Your application has been submitted successfully.",
 		models.StatusRejected:    "Unfortunately, we have decided not to move forward with your application at this time.",
 		models.StatusShortlisted: "Congratulations! You have been shortlisted for the next round.",
+		models.StatusWithdrawn:   "Your application has been withdrawn. You may apply to this job again.",
+
+		models.StatusPendingVerification: "Your application is waiting for you to verify your email address with the code we sent.",
+		models.StatusAssignmentSubmitted: "Your take-home assignment has been submitted and will be reviewed with your application.",
 	}
 
 	if msg, ok := messages[status]; ok {
@@ -327,10 +602,41 @@ func getStatusMessage(status models.ApplicationStatus) string {
 	return "Application status: " + string(status)
 }
 
+// GetApplicationScore handles GET /api/applications/:id/score
+// Returns how well the application matches its job's requirements, with the
+// excerpts of the resume and cover letter that count towards each
+func (h *ApplicationHandler) GetApplicationScore(c *gin.Context) {
+	app, exists := h.appStore.GetPropagatedByID(c.Param("id"))
+	if !exists {
+		respond.Error(c, http.StatusNotFound, "application_not_found", "The specified application could not be found.")
+		return
+	}
+
+	// Applications saved before scoring existed are scored against the job
+	// as it is now
+	score := app.Score
+	if score == nil {
+		job, exists := h.jobStore.GetByID(app.JobID)
+		if !exists {
+			respond.Error(c, http.StatusNotFound, "score_unavailable", "This application was not scored and its job no longer exists.")
+			return
+		}
+		scored := scoring.Score(job, app.Resume, app.CoverLetter)
+		scored.ApplicationID = app.ConfirmationID
+		score = &scored
+	}
+
+	respond.Data(c, http.StatusOK, *score)
+}
+
 // ClearAllApplications handles DELETE /api/applications/clear
 // Clears all applications (for testing purposes)
 func (h *ApplicationHandler) ClearAllApplications(c *gin.Context) {
-	count := h.appStore.ClearAll()
+	count, err := h.appStore.ClearAll()
+	if err != nil {
+		respond.Error(c, http.StatusInternalServerError, "storage_failed", "Failed to clear applications: "+err.Error())
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"message": "All applications cleared",
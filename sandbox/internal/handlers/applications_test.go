@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// TestConditionalApplicationGet checks the 200, 304, 200 after an update
+// sequence on every endpoint that sends Last-Modified. The update lands in
+// the same second as the submission, which Last-Modified cannot tell apart
+// without the store moving it on.
+func TestConditionalApplicationGet(t *testing.T) {
+	for _, suffix := range []string{"", "/receipt", "/timeline"} {
+		t.Run("GET /api/applications/:id"+suffix, func(t *testing.T) {
+			jobStore, appStore := newTestStores(t)
+			h := NewApplicationHandler(jobStore, appStore, store.NewApplicantStore(), nil)
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/api/applications/:id", h.GetApplication)
+			r.GET("/api/applications/:id/receipt", h.GetApplicationReceipt)
+			r.GET("/api/applications/:id/timeline", h.GetApplicationTimeline)
+
+			app, apiErr := submitApplication(jobStore, appStore, testApplication("vic@example.com"))
+			if apiErr != nil {
+				t.Fatalf("submitting: %s", apiErr.message)
+			}
+			path := "/api/applications/" + app.ConfirmationID + suffix
+
+			get := func(header, value string) *httptest.ResponseRecorder {
+				req := httptest.NewRequest(http.MethodGet, path, nil)
+				if header != "" {
+					req.Header.Set(header, value)
+				}
+				w := httptest.NewRecorder()
+				r.ServeHTTP(w, req)
+				return w
+			}
+
+			first := get("", "")
+			if first.Code != http.StatusOK {
+				t.Fatalf("status %d, want 200", first.Code)
+			}
+			lastModified, etag := first.Header().Get("Last-Modified"), first.Header().Get("ETag")
+			if lastModified == "" || etag == "" || first.Header().Get("Cache-Control") != "no-cache" {
+				t.Fatalf("headers %v, want Last-Modified, ETag and Cache-Control: no-cache", first.Header())
+			}
+
+			for _, validator := range [][2]string{{"If-Modified-Since", lastModified}, {"If-None-Match", etag}} {
+				if w := get(validator[0], validator[1]); w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+					t.Errorf("unchanged with %s: status %d and %d byte body, want an empty 304", validator[0], w.Code, w.Body.Len())
+				}
+			}
+
+			if _, err := appStore.UpdateStatus(app.ID, models.StatusReviewing, "Looking now.", models.ActorAPI); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, validator := range [][2]string{{"If-Modified-Since", lastModified}, {"If-None-Match", etag}} {
+				w := get(validator[0], validator[1])
+				if w.Code != http.StatusOK {
+					t.Errorf("updated with %s: status %d, want 200", validator[0], w.Code)
+				}
+				if w.Header().Get("Last-Modified") == lastModified {
+					t.Errorf("updated with %s: Last-Modified did not move on from %s", validator[0], lastModified)
+				}
+			}
+
+			updated := get("", "").Header().Get("Last-Modified")
+			if w := get("If-Modified-Since", updated); w.Code != http.StatusNotModified {
+				t.Errorf("unchanged since the update: status %d, want 304", w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// AssignmentHandler hands shortlisted applicants their job's take-home
+// assignment and takes their submissions until its deadline
+type AssignmentHandler struct {
+	jobStore *store.JobStore
+	appStore *store.ApplicationStore
+}
+
+// NewAssignmentHandler creates a new assignment handler
+func NewAssignmentHandler(jobStore *store.JobStore, appStore *store.ApplicationStore) *AssignmentHandler {
+	return &AssignmentHandler{jobStore: jobStore, appStore: appStore}
+}
+
+// GetAssignment handles GET /api/applications/:id/assignment
+// Returns the take-home assignment of a shortlisted application's job, with
+// its deadline and what has been submitted for it
+func (h *AssignmentHandler) GetAssignment(c *gin.Context) {
+	app, assignment, ok := h.assignment(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, assignmentResponse(app, assignment, time.Now()))
+}
+
+// SubmitAssignment handles POST /api/applications/:id/assignment
+// Hands in the take-home assignment of a shortlisted application, moving it
+// to assignment_submitted
+func (h *AssignmentHandler) SubmitAssignment(c *gin.Context) {
+	app, assignment, ok := h.assignment(c)
+	if !ok {
+		return
+	}
+	var req models.AssignmentSubmissionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respond.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body: "+err.Error())
+		return
+	}
+	if strings.TrimSpace(req.Content) == "" {
+		respond.Error(c, http.StatusBadRequest, "invalid_request", "content must not be blank.")
+		return
+	}
+	if req.URL != "" {
+		if u, err := url.Parse(req.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			respond.Error(c, http.StatusBadRequest, "invalid_url", "url must be an absolute http or https URL.")
+			return
+		}
+	}
+
+	now := time.Now()
+	if app.Status == models.StatusShortlisted && !now.Before(assignmentResponse(app, assignment, now).DueAt) {
+		respond.Error(c, http.StatusGone, "assignment_overdue", "The deadline for this assignment has passed. Submissions are no longer accepted.")
+		return
+	}
+
+	app, err := h.appStore.SubmitAssignment(app.ID, models.AssignmentSubmission{
+		Content:     req.Content,
+		URL:         req.URL,
+		SubmittedAt: now.UTC(),
+	})
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			respond.Error(c, http.StatusNotFound, "application_not_found", "The specified application could not be found.")
+		case strings.Contains(err.Error(), "already submitted"):
+			respond.Error(c, http.StatusConflict, "assignment_already_submitted", "An assignment has already been submitted for this application.")
+		case strings.Contains(err.Error(), "not shortlisted"):
+			respond.Error(c, http.StatusConflict, "not_shortlisted", "Take-home assignments are only set for shortlisted applications.")
+		default:
+			respond.Error(c, http.StatusInternalServerError, "storage_failed", "Failed to submit assignment: "+err.Error())
+		}
+		return
+	}
+	c.JSON(http.StatusOK, assignmentResponse(app, assignment, now))
+}
+
+// assignment looks up the application in the path and its job's
+// assignment, answering 404 when either does not exist and 409 when the
+// application has not been shortlisted
+func (h *AssignmentHandler) assignment(c *gin.Context) (*models.Application, models.Assignment, bool) {
+	app, exists := h.appStore.GetPropagatedByID(c.Param("id"))
+	if !exists {
+		respond.Error(c, http.StatusNotFound, "application_not_found", "The specified application could not be found.")
+		return nil, models.Assignment{}, false
+	}
+	job, exists := h.jobStore.GetByID(app.JobID)
+	if !exists || job.Assignment == nil {
+		respond.Error(c, http.StatusNotFound, "no_assignment", "This application's job does not set a take-home assignment.")
+		return nil, models.Assignment{}, false
+	}
+	if app.Status != models.StatusShortlisted && app.Status != models.StatusAssignmentSubmitted {
+		respond.Error(c, http.StatusConflict, "not_shortlisted", "Take-home assignments are only set for shortlisted applications.")
+		return nil, models.Assignment{}, false
+	}
+	return app, *job.Assignment, true
+}
+
+// assignmentResponse describes app's assignment as of now. It is issued
+// when the application was last shortlisted.
+func assignmentResponse(app *models.Application, assignment models.Assignment, now time.Time) models.AssignmentResponse {
+	issued := app.UpdatedAt
+	for i := len(app.StatusHistory) - 1; i >= 0; i-- {
+		if app.StatusHistory[i].Status == models.StatusShortlisted {
+			issued = app.StatusHistory[i].At
+			break
+		}
+	}
+
+	response := models.AssignmentResponse{
+		ApplicationID: app.ConfirmationID,
+		Title:         assignment.Title,
+		Instructions:  assignment.Instructions,
+		IssuedAt:      issued.UTC(),
+		DueAt:         issued.UTC().AddDate(0, 0, assignment.DueDays),
+	}
+	switch {
+	case app.Status == models.StatusAssignmentSubmitted:
+		response.State, response.Submission = models.AssignmentSubmitted, app.Assignment
+	case !now.Before(response.DueAt):
+		response.State = models.AssignmentOverdue
+	default:
+		response.State = models.AssignmentPending
+	}
+	return response
+}
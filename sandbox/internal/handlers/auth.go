@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/emailaddr"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/jwt"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/middleware"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// maxPasswordLength is the longest password an applicant may register with,
+// in characters
+const maxPasswordLength = 128
+
+// AuthHandler registers applicants and logs them in, issuing the tokens
+// -auth=required asks for
+type AuthHandler struct {
+	appStore *store.ApplicationStore
+	accounts *store.AccountStore
+	secret   []byte
+	ttl      time.Duration
+}
+
+// NewAuthHandler creates a new auth handler issuing tokens signed with
+// secret and valid for ttl
+func NewAuthHandler(appStore *store.ApplicationStore, accounts *store.AccountStore, secret []byte, ttl time.Duration) *AuthHandler {
+	return &AuthHandler{appStore: appStore, accounts: accounts, secret: secret, ttl: ttl}
+}
+
+// Register handles POST /api/auth/register
+// Creates a login for an email address and answers with a token for it
+func (h *AuthHandler) Register(c *gin.Context) {
+	var req models.CredentialsRequest
+	found, apiErr := decodeJSON(c, &req)
+	if apiErr == nil {
+		apiErr = validateCredentials(h.appStore, &req, found).errAbout("The registration has several problems. See violations for details.")
+	}
+	if apiErr != nil {
+		respond.Violations(c, apiErr.status, apiErr.code, apiErr.message, apiErr.violations)
+		return
+	}
+
+	email, err := h.accounts.Register(req.Email, req.Password)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate") {
+			respond.Error(c, http.StatusConflict, "duplicate_account", "This email address is already registered. Log in instead.")
+			return
+		}
+		respond.Error(c, http.StatusInternalServerError, "storage_failed", "Failed to register: "+err.Error())
+		return
+	}
+	h.issue(c, http.StatusCreated, email)
+}
+
+// Login handles POST /api/auth/login
+// Answers with a fresh token when the email address and password match
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req models.CredentialsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respond.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body: "+err.Error())
+		return
+	}
+
+	email, ok := h.accounts.Authenticate(req.Email, req.Password)
+	if !ok {
+		respond.Error(c, http.StatusUnauthorized, "invalid_credentials", "The email address or password is incorrect.")
+		return
+	}
+	h.issue(c, http.StatusOK, email)
+}
+
+// issue answers with a token for email
+func (h *AuthHandler) issue(c *gin.Context, status int, email string) {
+	now := time.Now().UTC()
+	token, err := jwt.Sign(email, h.secret, now, h.ttl)
+	if err != nil {
+		respond.Error(c, http.StatusInternalServerError, "token_failed", "Failed to issue token: "+err.Error())
+		return
+	}
+	c.JSON(status, models.TokenResponse{
+		Token:     token,
+		TokenType: "Bearer",
+		ExpiresAt: now.Add(h.ttl).Truncate(time.Second),
+		Email:     email,
+	})
+}
+
+// validateCredentials collects every problem with a registration: the
+// binding rules, the email address, which must be one applications would
+// accept, and the password length. A valid email is replaced with its
+// normalized form.
+func validateCredentials(appStore *store.ApplicationStore, req *models.CredentialsRequest, found violations) violations {
+	found.addBinding(req)
+	found.addEmail("email", &req.Email, appStore)
+	if req.Password != "" && utf8.RuneCountInString(req.Password) < models.MinPasswordLength {
+		found.add("password", "password_too_short", fmt.Sprintf("password must be at least %d characters.", models.MinPasswordLength))
+	}
+	found.addLength("password", req.Password, maxPasswordLength)
+	return found
+}
+
+// errLoginRequired and errNotOwner refuse requests restricted to an
+// applicant's applications (see middleware.Applicant) to those of others
+var (
+	errLoginRequired = &apiError{status: http.StatusUnauthorized, code: "authentication_required",
+		message: "Log in with POST /api/auth/login and send the token as Authorization: Bearer <token>."}
+	errNotOwner = &apiError{status: http.StatusForbidden, code: "forbidden", message: "This application belongs to another applicant."}
+)
+
+// applicantScope returns the address whose applications a request may
+// list when it asks for those of email: email itself when the request is
+// not restricted, otherwise the applicant's own, which email must match
+// if it is set
+func applicantScope(ctx context.Context, email string) (string, *apiError) {
+	authed, restricted := middleware.Applicant(ctx)
+	switch {
+	case !restricted:
+		return email, nil
+	case authed == "":
+		return "", errLoginRequired
+	case email != "" && emailaddr.Normalize(email) != authed:
+		return "", &apiError{status: http.StatusForbidden, code: "email_mismatch", message: "Tokens only list the applications of the address they were issued for."}
+	}
+	return authed, nil
+}
+
+// checkOwner refuses app to requests restricted to another applicant's
+// applications
+func checkOwner(ctx context.Context, app *models.Application) *apiError {
+	authed, restricted := middleware.Applicant(ctx)
+	switch {
+	case !restricted:
+		return nil
+	case authed == "":
+		return errLoginRequired
+	case emailaddr.Normalize(app.ApplicantEmail) != authed:
+		return errNotOwner
+	}
+	return nil
+}
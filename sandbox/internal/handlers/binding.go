@@ -0,0 +1,358 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// supportedSubmissionTypes lists the request bodies SubmitApplication accepts
+const supportedSubmissionTypes = "application/json, application/x-www-form-urlencoded, multipart/form-data"
+
+// bindApplication decodes an application from a JSON, urlencoded or multipart
+// body. Values of the wrong type, and in strict binding mode unknown JSON
+// fields, are returned as violations so they can be reported together with
+// the rest of the validation; only unreadable bodies fail outright. An empty
+// body decodes as an empty application.
+func bindApplication(c *gin.Context) (models.ApplicationRequest, []models.Violation, *apiError) {
+	var req models.ApplicationRequest
+
+	switch contentType := c.ContentType(); {
+	case contentType == binding.MIMEPOSTForm || contentType == binding.MIMEMultipartPOSTForm:
+		fields, err := formFields(c)
+		if err != nil {
+			return req, nil, &apiError{status: http.StatusBadRequest, code: "invalid_request", message: "Invalid request body: " + err.Error()}
+		}
+		return req, decodeForm(fields, &req), nil
+	case contentType == "" || contentType == binding.MIMEJSON || strings.HasSuffix(contentType, "+json"):
+		found, apiErr := decodeJSON(c, &req)
+		return req, found, apiErr
+	default:
+		return req, nil, &apiError{status: http.StatusUnsupportedMediaType, code: "unsupported_media_type",
+			message: "Unsupported Content-Type. Supported: " + supportedSubmissionTypes}
+	}
+}
+
+// bindStatusUpdate decodes a status update like c.ShouldBindJSON, rejecting
+// unknown fields in strict binding mode
+func bindStatusUpdate(c *gin.Context) (models.StatusUpdateRequest, *apiError) {
+	var req models.StatusUpdateRequest
+	return req, bindJSON(c, &req)
+}
+
+// bindJSON decodes a JSON body into the struct v points to like
+// c.ShouldBindJSON, rejecting unknown fields in strict binding mode
+func bindJSON(c *gin.Context, v interface{}) *apiError {
+	if !c.GetBool(respond.StrictBindingKey) {
+		if err := c.ShouldBindJSON(v); err != nil {
+			return &apiError{status: http.StatusBadRequest, code: "invalid_request", message: "Invalid request body: " + err.Error()}
+		}
+		return nil
+	}
+
+	unknown, err := decodeStrict(c.Request.Body, v)
+	if len(unknown) > 0 {
+		return unknownFieldsError(unknown)
+	}
+	if err == nil {
+		err = binding.Validator.ValidateStruct(v)
+	}
+	if err != nil {
+		return &apiError{status: http.StatusBadRequest, code: "invalid_request", message: "Invalid request body: " + err.Error()}
+	}
+	return nil
+}
+
+// bindApplicationUpdate decodes an application edit from a JSON body,
+// returning values of the wrong type and unknown fields as violations, as
+// bindApplication does
+func bindApplicationUpdate(c *gin.Context) (models.ApplicationUpdateRequest, []models.Violation, *apiError) {
+	var req models.ApplicationUpdateRequest
+	found, apiErr := decodeJSON(c, &req)
+	return req, found, apiErr
+}
+
+// decodeJSON decodes a JSON body into the struct v points to. Every value
+// of the wrong type is returned as a type_mismatch violation, not only the
+// first the decoder stopped at, and in strict binding mode every unknown
+// field as an unknown_field violation. Bodies that are not JSON fail
+// outright; an empty body leaves v as it is.
+func decodeJSON(c *gin.Context, v interface{}) ([]models.Violation, *apiError) {
+	data, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return nil, &apiError{status: http.StatusBadRequest, code: "invalid_request", message: "Invalid request body: " + err.Error()}
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+
+	var found []models.Violation
+	if c.GetBool(respond.StrictBindingKey) {
+		found = unknownFields(data, reflect.TypeOf(v).Elem())
+	}
+	var typeErr *json.UnmarshalTypeError
+	switch err := json.Unmarshal(data, v); {
+	case err == nil:
+	case errors.As(err, &typeErr):
+		found = append(found, typeMismatches(data, reflect.TypeOf(v).Elem())...)
+	default:
+		return nil, &apiError{status: http.StatusBadRequest, code: "invalid_request", message: "Request body is not valid JSON."}
+	}
+	return found, nil
+}
+
+// typeMismatches returns a type_mismatch violation for each top-level value
+// of a JSON object that does not decode into the field of t its key names,
+// in key order. Mismatches inside a map, such as a number among
+// custom_answers, are reported with their dotted path.
+func typeMismatches(data []byte, t reflect.Type) []models.Violation {
+	var object map[string]json.RawMessage
+	if json.Unmarshal(data, &object) != nil {
+		return nil
+	}
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var found []models.Violation
+	for _, key := range keys {
+		for i := 0; i < t.NumField(); i++ {
+			name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+			if name == "" || name == "-" || !strings.EqualFold(name, key) {
+				continue
+			}
+			var typeErr *json.UnmarshalTypeError
+			if err := json.Unmarshal(object[key], reflect.New(t.Field(i).Type).Interface()); errors.As(err, &typeErr) {
+				path := name
+				if typeErr.Field != "" && t.Field(i).Type.Kind() == reflect.Map {
+					path += "." + typeErr.Field
+				}
+				found = append(found, models.Violation{Field: path, Code: "type_mismatch",
+					Message: path + " must be " + jsonTypeName(typeErr.Type) + "."})
+			}
+			break
+		}
+	}
+	return found
+}
+
+// unknownFieldsError reports the unknown fields of a strictly bound body
+func unknownFieldsError(unknown []models.Violation) *apiError {
+	return &apiError{status: http.StatusBadRequest, code: "unknown_field",
+		message: "Request body has fields this endpoint does not accept. See violations for details.", violations: unknown}
+}
+
+// decodeStrict decodes a JSON body into the struct v points to, disallowing
+// unknown fields. When the body has any, every one of them is returned as a
+// violation rather than only the first the decoder stopped at; any other
+// decoding error is returned as is.
+func decodeStrict(body io.Reader, v interface{}) ([]models.Violation, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(v)
+
+	field, ok := strings.CutPrefix(fmt.Sprint(err), "json: unknown field ")
+	if !ok {
+		return nil, err
+	}
+	if unknown := unknownFields(data, reflect.TypeOf(v).Elem()); len(unknown) > 0 {
+		return unknown, nil
+	}
+	// The unknown field is in a nested object
+	field, _ = strconv.Unquote(field)
+	return []models.Violation{unknownField(field, nil)}, nil
+}
+
+// unknownFields returns a violation for each top-level key of a JSON object
+// that names none of t's json-tagged fields, in key order. Keys match field
+// names case-insensitively, as they do when decoding.
+func unknownFields(data []byte, t reflect.Type) []models.Violation {
+	var object map[string]json.RawMessage
+	if json.Unmarshal(data, &object) != nil {
+		return nil
+	}
+
+	var known []string
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			known = append(known, name)
+		}
+	}
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var found []models.Violation
+	for _, key := range keys {
+		if !slices.ContainsFunc(known, func(name string) bool { return strings.EqualFold(name, key) }) {
+			found = append(found, unknownField(key, known))
+		}
+	}
+	return found
+}
+
+// unknownField reports an unknown field, suggesting the known field it is
+// most likely a typo of
+func unknownField(field string, known []string) models.Violation {
+	message := field + " is not a field of this request."
+	if suggestion := closestField(field, known); suggestion != "" {
+		message += " Did you mean " + suggestion + "?"
+	}
+	return models.Violation{Field: field, Code: "unknown_field", Message: message}
+}
+
+// closestField returns the known field within two edits of field, ignoring
+// case and underscores, or the one field ends when it drops a prefix such as
+// "email" for "applicant_email". It returns "" when nothing is that close.
+func closestField(field string, known []string) string {
+	key := fieldKey(field)
+	best, bestDistance := "", 3
+	for _, name := range known {
+		if strings.HasSuffix(name, "_"+strings.ToLower(field)) {
+			return name
+		}
+		if d := editDistance(key, fieldKey(name)); d < bestDistance {
+			best, bestDistance = name, d
+		}
+	}
+	return best
+}
+
+// fieldKey lowercases a field name and drops its separators, so
+// coverLetter, cover-letter and cover_letter compare equal
+func fieldKey(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r == '-' {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, name)
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	row := make([]int, len(t)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		diagonal := row[0]
+		row[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			diagonal, row[j] = row[j], min(row[j]+1, row[j-1]+1, diagonal+cost)
+		}
+	}
+	return row[len(t)]
+}
+
+// jsonTypeName describes a Go type in JSON terms
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return jsonTypeName(t.Elem())
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.String:
+		return "a string"
+	case reflect.Map, reflect.Struct:
+		return "an object"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "a number"
+	}
+}
+
+// decodeForm fills the json-tagged fields of the struct v points to from
+// form fields. Map fields are read from bracketed or dotted keys such as
+// custom_answers[why_us] or custom_answers.why_us, and bool pointers accept
+// the usual strconv.ParseBool spellings; other values are returned as
+// violations.
+func decodeForm(fields map[string]string, v interface{}) []models.Violation {
+	var found []models.Violation
+	value := reflect.ValueOf(v).Elem()
+	t := value.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		target := value.Field(i)
+
+		switch {
+		case field.Type.Kind() == reflect.String:
+			target.SetString(fields[name])
+		case field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Bool:
+			raw, ok := fields[name]
+			if !ok || raw == "" {
+				continue
+			}
+			b, err := strconv.ParseBool(raw)
+			if err != nil {
+				found = append(found, models.Violation{Field: name, Code: "type_mismatch",
+					Message: name + " must be true or false, got " + strconv.Quote(raw) + "."})
+				continue
+			}
+			target.Set(reflect.ValueOf(&b))
+		case field.Type.Kind() == reflect.Map && field.Type.Elem().Kind() == reflect.String:
+			entries := make(map[string]string)
+			for key, raw := range fields {
+				if sub, ok := formMapKey(key, name); ok {
+					entries[sub] = raw
+				}
+			}
+			if len(entries) > 0 {
+				target.Set(reflect.ValueOf(entries))
+			}
+		}
+	}
+
+	return found
+}
+
+// formMapKey extracts "key" from "name[key]" or "name.key"
+func formMapKey(key, name string) (string, bool) {
+	rest, ok := strings.CutPrefix(key, name)
+	if !ok {
+		return "", false
+	}
+	if sub, ok := strings.CutPrefix(rest, "."); ok && sub != "" {
+		return sub, true
+	}
+	if strings.HasPrefix(rest, "[") && strings.HasSuffix(rest, "]") && len(rest) > 2 {
+		return rest[1 : len(rest)-1], true
+	}
+	return "", false
+}
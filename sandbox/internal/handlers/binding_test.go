@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// boundApplication is an application with every field set, as JSON
+const boundApplication = `{
+	"job_id": "job_open",
+	"applicant_name": "Vic Tester",
+	"applicant_email": "vic@example.com",
+	"resume": "Ten years of building web services in Go and Python.",
+	"cover_letter": "I would like to build Acme's storefront.",
+	"phone": "+1 (415) 555-0100",
+	"linkedin": "https://www.linkedin.com/in/victester",
+	"github": "https://github.com/victester",
+	"portfolio": "https://vic.example.com",
+	"work_authorization": "citizen",
+	"sponsorship_needed": false,
+	"relocation_willing": true,
+	"start_date": "2099-01-01",
+	"availability": "Two weeks' notice",
+	"salary_expectation": "$130,000",
+	"remote_preference": "hybrid",
+	"custom_answers": {"why_us": "The storefront, & its <scale>", "years_go": "7"}
+}`
+
+// formRequest is the request for a form body holding fields
+func formRequest(t *testing.T, contentType string, fields [][2]string) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	switch contentType {
+	case "multipart/form-data":
+		mw := multipart.NewWriter(&body)
+		for _, field := range fields {
+			if err := mw.WriteField(field[0], field[1]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		mw.Close()
+		contentType = mw.FormDataContentType()
+	default:
+		form := url.Values{}
+		for _, field := range fields {
+			form.Add(field[0], field[1])
+		}
+		body.WriteString(form.Encode())
+	}
+	req := httptest.NewRequest(http.MethodPost, "/api/applications", &body)
+	req.Header.Set("Content-Type", contentType)
+	return req
+}
+
+// formFieldsOf turns the JSON application into form fields, writing
+// custom_answers keys as name[key] or name.key
+func formFieldsOf(t *testing.T, dotted bool) [][2]string {
+	t.Helper()
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(boundApplication), &object); err != nil {
+		t.Fatal(err)
+	}
+	var fields [][2]string
+	for name, value := range object {
+		switch value := value.(type) {
+		case map[string]interface{}:
+			for key, answer := range value {
+				if dotted {
+					fields = append(fields, [2]string{name + "." + key, answer.(string)})
+				} else {
+					fields = append(fields, [2]string{name + "[" + key + "]", answer.(string)})
+				}
+			}
+		case bool:
+			if value {
+				fields = append(fields, [2]string{name, "true"})
+			} else {
+				fields = append(fields, [2]string{name, "false"})
+			}
+		default:
+			fields = append(fields, [2]string{name, value.(string)})
+		}
+	}
+	return fields
+}
+
+// bindingCases are the same application in every body type submissions
+// accept
+func bindingCases(t *testing.T) map[string]*http.Request {
+	jsonReq := httptest.NewRequest(http.MethodPost, "/api/applications", strings.NewReader(boundApplication))
+	jsonReq.Header.Set("Content-Type", "application/json")
+	return map[string]*http.Request{
+		"json":                 jsonReq,
+		"urlencoded bracketed": formRequest(t, "application/x-www-form-urlencoded", formFieldsOf(t, false)),
+		"urlencoded dotted":    formRequest(t, "application/x-www-form-urlencoded", formFieldsOf(t, true)),
+		"multipart":            formRequest(t, "multipart/form-data", formFieldsOf(t, false)),
+	}
+}
+
+func TestBindApplicationFormMatchesJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	bound := make(map[string]models.ApplicationRequest)
+	for name, req := range bindingCases(t) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = req
+		got, found, apiErr := bindApplication(c)
+		if apiErr != nil || len(found) > 0 {
+			t.Fatalf("%s: error %+v, violations %+v", name, apiErr, found)
+		}
+		bound[name] = got
+	}
+
+	want := bound["json"]
+	if want.SponsorshipNeeded == nil || *want.SponsorshipNeeded || want.RelocationWilling == nil || !*want.RelocationWilling {
+		t.Fatalf("JSON binding lost the booleans: %+v", want)
+	}
+	for name, got := range bound {
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s bound\n%+v\nwant, as from JSON,\n%+v", name, got, want)
+		}
+	}
+}
+
+func TestSubmitFormMatchesJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	stored := make(map[string]models.Application)
+	for name, req := range bindingCases(t) {
+		// A store each, so the same applicant is not a duplicate
+		jobStore, appStore := newTestStores(t)
+		h := NewApplicationHandler(jobStore, appStore, store.NewApplicantStore(), nil)
+		r := gin.New()
+		r.POST("/api/applications", h.SubmitApplication)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("%s: status %d: %s", name, w.Code, w.Body.String())
+		}
+		var resp models.ApplicationResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		app, exists := appStore.GetByID(resp.ConfirmationID)
+		if !exists {
+			t.Fatalf("%s: %s was not stored", name, resp.ConfirmationID)
+		}
+		stored[name] = withoutIdentity(*app)
+	}
+
+	want := stored["json"]
+	if want.PhoneE164 != "+14155550100" || want.CustomAnswers["why_us"] != "The storefront, & its <scale>" {
+		t.Fatalf("JSON submission stored %+v", want)
+	}
+	for name, got := range stored {
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s stored\n%+v\nwant, as from JSON,\n%+v", name, got, want)
+		}
+	}
+}
+
+// withoutIdentity clears the IDs and times, which are all that tell two
+// submissions of the same application apart
+func withoutIdentity(app models.Application) models.Application {
+	app.ID, app.ConfirmationID, app.ApplicationID = "", "", ""
+	app.SubmittedAt, app.UpdatedAt = time.Time{}, time.Time{}
+	history := make([]models.StatusChange, len(app.StatusHistory))
+	for i, change := range app.StatusHistory {
+		change.At = time.Time{}
+		history[i] = change
+	}
+	app.StatusHistory = history
+	if app.Score != nil {
+		score := *app.Score
+		score.ApplicationID = ""
+		app.Score = &score
+	}
+	return app
+}
+
+func TestSubmitUnsupportedMediaType(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	jobStore, appStore := newTestStores(t)
+	h := NewApplicationHandler(jobStore, appStore, store.NewApplicantStore(), nil)
+	r := gin.New()
+	r.POST("/api/applications", h.SubmitApplication)
+
+	for _, contentType := range []string{"text/plain", "application/xml", "text/csv"} {
+		req := httptest.NewRequest(http.MethodPost, "/api/applications", strings.NewReader(boundApplication))
+		req.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusUnsupportedMediaType || !strings.Contains(w.Body.String(), "multipart/form-data") {
+			t.Errorf("%s: status %d: %s, want 415 listing the supported types", contentType, w.Code, w.Body.String())
+		}
+	}
+	if appStore.GetCount() != 0 {
+		t.Errorf("%d applications stored, want none", appStore.GetCount())
+	}
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/challenge"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/middleware"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/random"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// ChallengeHandler issues the anti-bot challenges that guard submission
+// and hands out a token for each one solved
+type ChallengeHandler struct {
+	challenges *store.ChallengeStore
+	kind       string
+	difficulty int
+	delay      time.Duration
+
+	rng *rand.Rand
+	mu  sync.Mutex
+}
+
+// NewChallengeHandler creates a new challenge handler issuing challenges
+// of one kind. difficulty is how many leading zero bits proof-of-work asks
+// for; delay is how long a delay challenge must be waited out.
+func NewChallengeHandler(challenges *store.ChallengeStore, kind string, difficulty int, delay time.Duration) *ChallengeHandler {
+	return &ChallengeHandler{
+		challenges: challenges,
+		kind:       kind,
+		difficulty: difficulty,
+		delay:      delay,
+		rng:        random.New("challenges"),
+	}
+}
+
+// CreateChallenge handles POST /api/challenges
+// Issues a challenge to solve before submitting an application
+func (h *ChallengeHandler) CreateChallenge(c *gin.Context) {
+	ch := models.Challenge{ID: h.challenges.NewID(), Type: h.kind}
+	ch.SolveURL = "/api/challenges/" + ch.ID + "/solve"
+
+	var answer string
+	switch h.kind {
+	case models.ChallengePuzzle:
+		h.mu.Lock()
+		ch.Question, answer = challenge.Puzzle(h.rng)
+		h.mu.Unlock()
+		ch.Prompt = "Answer the question and send the answer to solve_url."
+	case models.ChallengeProofOfWork:
+		ch.Difficulty = h.difficulty
+		ch.Prompt = fmt.Sprintf("Find a nonce such that the SHA-256 hash of challenge_id, a colon and the nonce starts with %d zero bits, and send it to solve_url.", h.difficulty)
+	case models.ChallengeDelay:
+		buf := make([]byte, 16)
+		if err := random.Read(buf); err != nil {
+			respond.Error(c, http.StatusInternalServerError, "internal_error", "Failed to issue challenge: "+err.Error())
+			return
+		}
+		answer = hex.EncodeToString(buf)
+		ch.Handshake = answer
+		notBefore := time.Now().UTC().Add(h.delay)
+		ch.NotBefore = &notBefore
+		ch.Prompt = "Wait until not_before, then send the handshake to solve_url as the answer."
+	}
+
+	ch = h.challenges.Issue(ch, answer)
+	c.Set(middleware.ChallengeOutcomeKey, models.ChallengeIssued)
+	c.Header("Location", ch.SolveURL)
+	c.JSON(http.StatusCreated, ch)
+}
+
+// SolveChallenge handles POST /api/challenges/:id/solve
+// Checks a solution, handing out a token for the next submission when it
+// is right. A wrong solution uses the challenge up; one sent before a
+// delay challenge's not_before does not.
+func (h *ChallengeHandler) SolveChallenge(c *gin.Context) {
+	c.Set(middleware.ChallengeOutcomeKey, models.ChallengeAttempted)
+
+	var req models.ChallengeSolution
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respond.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body: "+err.Error())
+		return
+	}
+
+	ch, err := h.challenges.Get(c.Param("id"))
+	if err != nil {
+		challengeError(c, err)
+		return
+	}
+	if ch.NotBefore != nil {
+		if wait := time.Until(*ch.NotBefore); wait > 0 {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			respond.Error(c, http.StatusTooEarly, "challenge_too_early", "The challenge cannot be solved before not_before. Please try again later.")
+			return
+		}
+	}
+
+	ch, answer, err := h.challenges.Take(ch.ID)
+	if err != nil {
+		challengeError(c, err)
+		return
+	}
+	if !solves(ch, answer, req) {
+		c.Set(middleware.ChallengeOutcomeKey, models.ChallengeFailed)
+		respond.Error(c, http.StatusUnprocessableEntity, "challenge_failed", "The solution is wrong. Request a new challenge.")
+		return
+	}
+
+	c.Set(middleware.ChallengeOutcomeKey, models.ChallengeSolved)
+	c.JSON(http.StatusOK, h.challenges.NewToken())
+}
+
+// solves reports whether a solution is right for a challenge
+func solves(ch models.Challenge, answer string, req models.ChallengeSolution) bool {
+	switch ch.Type {
+	case models.ChallengePuzzle:
+		return challenge.CheckAnswer(req.Answer, answer)
+	case models.ChallengeProofOfWork:
+		return challenge.Valid(ch.ID, req.Nonce, ch.Difficulty)
+	case models.ChallengeDelay:
+		return req.Answer == answer
+	}
+	return false
+}
+
+// challengeError answers for a challenge the store could not hand over
+func challengeError(c *gin.Context, err error) {
+	if strings.Contains(err.Error(), "expired") {
+		respond.Error(c, http.StatusGone, "challenge_expired", "The challenge has expired. Request a new challenge.")
+		return
+	}
+	respond.Error(c, http.StatusNotFound, "challenge_not_found", "The specified challenge could not be found. It may have been solved or failed already.")
+}
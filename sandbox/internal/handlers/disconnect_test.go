@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// cancellingRecorder is a client that goes away once the first flush of
+// the response reaches it
+type cancellingRecorder struct {
+	*httptest.ResponseRecorder
+	cancel context.CancelFunc
+}
+
+func (r *cancellingRecorder) Flush() {
+	r.ResponseRecorder.Flush()
+	r.cancel()
+}
+
+// cancellingContext returns a context for a GET of target whose client
+// goes away after the first flush
+func cancellingContext(target string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &cancellingRecorder{ResponseRecorder: httptest.NewRecorder(), cancel: cancel}
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", target, nil).WithContext(ctx)
+	return c, w.ResponseRecorder
+}
+
+// TestExportStopsWhenClientGoes checks an export stops writing at the next
+// flush once the client has gone, rather than writing every row
+func TestExportStopsWhenClientGoes(t *testing.T) {
+	const rows = 50 * exportFlushEvery
+	table := exportTable{columns: []string{"n"}, rows: rows, row: func(i int) []any { return []any{i} }}
+
+	for _, format := range exportFormats {
+		t.Run(format, func(t *testing.T) {
+			before := respond.Disconnects()
+			c, w := cancellingContext("/api/applications/export?format=" + format)
+			writeExport(c, format, "applications", table)
+
+			if lines := strings.Count(w.Body.String(), "\n"); lines > exportFlushEvery+1 {
+				t.Errorf("%d lines written after the client went at the first flush, want at most %d", lines, exportFlushEvery+1)
+			}
+			if !c.IsAborted() || !c.GetBool(respond.ClientDisconnectedKey) || respond.Disconnects()-before != 1 {
+				t.Error("the export was not recorded as a client disconnect")
+			}
+		})
+	}
+}
+
+// TestStreamJobsStopsWhenClientGoes checks the job stream stops at the next
+// batch once the client has gone
+func TestStreamJobsStopsWhenClientGoes(t *testing.T) {
+	jobs := make([]models.Job, 20*streamBatchSize)
+	for i := range jobs {
+		jobs[i] = models.Job{ID: fmt.Sprintf("job_%04d", i), Title: "Backend Engineer", Company: "Acme"}
+	}
+	jobStore, err := store.NewJobStore(jobs, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewJobHandler(jobStore, store.NewApplicationStore())
+
+	before := respond.Disconnects()
+	c, w := cancellingContext("/api/jobs/stream")
+	h.StreamJobs(c)
+
+	if lines := strings.Count(w.Body.String(), "\n"); lines > streamBatchSize {
+		t.Errorf("%d jobs streamed after the client went at the first flush, want at most %d", lines, streamBatchSize)
+	}
+	if !c.GetBool(respond.ClientDisconnectedKey) || respond.Disconnects()-before != 1 {
+		t.Error("the stream was not recorded as a client disconnect")
+	}
+}
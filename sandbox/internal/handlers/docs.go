@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/openapi"
+	"github.com/gin-gonic/gin"
+)
+
+// DocsHandler serves the OpenAPI specification and documentation page
+type DocsHandler struct {
+	spec []byte
+}
+
+// NewDocsHandler creates a new docs handler for the documented operations
+func NewDocsHandler(operations []openapi.Operation) (*DocsHandler, error) {
+	doc := openapi.Build(openapi.Info{
+		Title:       "Job Portal Sandbox API",
+		Version:     Version,
+		Description: "A sandbox job portal for testing autonomous job application agents",
+	}, operations)
+
+	spec, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &DocsHandler{spec: spec}, nil
+}
+
+// OpenAPISpec handles GET /api/openapi.json and GET /openapi.json
+// Returns the OpenAPI 3 document describing every route
+func (h *DocsHandler) OpenAPISpec(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json; charset=utf-8", h.spec)
+}
+
+// DocsPage handles GET /api/docs and GET /docs
+// Renders the OpenAPI document as browsable documentation
+func (h *DocsHandler) DocsPage(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", openapi.DocsHTML)
+}
@@ -0,0 +1,255 @@
+package handlers
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/emailaddr"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/middleware"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// DraftHandler handles the application wizard: drafts filled in one step
+// at a time (personal details, resume, screening questions, review) and
+// then submitted
+type DraftHandler struct {
+	jobStore *store.JobStore
+	appStore *store.ApplicationStore
+	drafts   *store.DraftStore
+	guard    *SubmitGuard
+}
+
+// NewDraftHandler creates a new draft handler
+func NewDraftHandler(jobStore *store.JobStore, appStore *store.ApplicationStore, drafts *store.DraftStore, guard *SubmitGuard) *DraftHandler {
+	return &DraftHandler{jobStore: jobStore, appStore: appStore, drafts: drafts, guard: guard}
+}
+
+// CreateDraft handles POST /api/applications/draft
+// Starts a draft application to a job that is accepting applications
+func (h *DraftHandler) CreateDraft(c *gin.Context) {
+	var req models.DraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respond.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body: "+err.Error())
+		return
+	}
+
+	authed, _ := middleware.Applicant(c.Request.Context())
+	draft, apiErr := startDraft(h.jobStore, h.appStore, h.drafts, req.JobID, authed)
+	if apiErr != nil {
+		respond.Violations(c, apiErr.status, apiErr.code, apiErr.message, apiErr.violations)
+		return
+	}
+	c.JSON(http.StatusCreated, draft)
+}
+
+// GetDraft handles GET /api/applications/draft/:id
+// Returns a draft with what its steps filled in, which is what the review
+// step shows
+func (h *DraftHandler) GetDraft(c *gin.Context) {
+	draft, ok := h.draft(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, draft)
+}
+
+// UpdateStep handles PATCH /api/applications/draft/:id/:step
+// Fills in the personal, resume or questions step, following the same
+// rules as submission for the fields it covers. Steps are done in order;
+// each can be redone until the draft is submitted.
+func (h *DraftHandler) UpdateStep(c *gin.Context) {
+	draft, ok := h.draft(c)
+	if !ok {
+		return
+	}
+
+	var (
+		fill  func(*models.ApplicationRequest)
+		found []models.Violation
+		err   *apiError
+	)
+	switch step := c.Param("step"); step {
+	case models.StepPersonal:
+		var req models.DraftPersonalStep
+		found, err = decodeJSON(c, &req)
+		if authed, _ := middleware.Applicant(c.Request.Context()); err == nil && authed != "" && req.ApplicantEmail != "" && emailaddr.Normalize(req.ApplicantEmail) != authed {
+			err = &apiError{status: http.StatusForbidden, code: "email_mismatch", message: "applicant_email must be the address the token was issued for."}
+		}
+		fill = req.Fill
+	case models.StepResume:
+		var req models.DraftResumeStep
+		found, err = decodeJSON(c, &req)
+		fill = req.Fill
+	case models.StepQuestions:
+		var req models.DraftQuestionsStep
+		found, err = decodeJSON(c, &req)
+		fill = req.Fill
+	default:
+		respond.Error(c, http.StatusNotFound, "step_not_found", "Unknown step. Steps are personal, resume and questions; review with POST /api/applications/draft/:id/review.")
+		return
+	}
+	if err == nil {
+		draft, err = saveDraftStep(h.jobStore, h.appStore, h.drafts, draft, c.Param("step"), fill, found)
+	}
+	if err != nil {
+		respond.Violations(c, err.status, err.code, err.message, err.violations)
+		return
+	}
+	c.JSON(http.StatusOK, draft)
+}
+
+// ReviewDraft handles POST /api/applications/draft/:id/review
+// Checks the whole draft the way submission would and, if it passes,
+// completes the review step so the draft can be submitted
+func (h *DraftHandler) ReviewDraft(c *gin.Context) {
+	draft, ok := h.draft(c)
+	if !ok {
+		return
+	}
+	draft, apiErr := reviewDraft(h.jobStore, h.appStore, h.drafts, draft)
+	if apiErr != nil {
+		respond.Violations(c, apiErr.status, apiErr.code, apiErr.message, apiErr.violations)
+		return
+	}
+	c.JSON(http.StatusOK, draft)
+}
+
+// SubmitDraft handles POST /api/applications/draft/:id/submit
+// Submits a reviewed draft as an application, answering like
+// POST /api/applications. The draft is gone afterwards.
+func (h *DraftHandler) SubmitDraft(c *gin.Context) {
+	draft, ok := h.draft(c)
+	if !ok {
+		return
+	}
+	if apiErr := h.guard.check(c.Request.Context(), draft.Application, true); apiErr != nil {
+		respond.Error(c, apiErr.status, apiErr.code, apiErr.message)
+		return
+	}
+	app, apiErr := submitDraft(h.jobStore, h.appStore, h.drafts, draft)
+	if apiErr != nil {
+		respond.Violations(c, apiErr.status, apiErr.code, apiErr.message, apiErr.violations)
+		return
+	}
+	markSubmitted(c, app)
+	respond.Data(c, http.StatusCreated, submissionResponse(app, respond.Language(c)))
+}
+
+// draft returns the draft named in the URL, answering 404 when it does not
+// exist or has expired and 403 when another applicant started it
+func (h *DraftHandler) draft(c *gin.Context) (models.ApplicationDraft, bool) {
+	draft, exists := h.drafts.Get(c.Param("id"))
+	if !exists {
+		respond.Error(c, http.StatusNotFound, "draft_not_found", "The specified draft could not be found. Drafts expire a day after they were last changed.")
+		return draft, false
+	}
+	if authed, _ := middleware.Applicant(c.Request.Context()); authed != "" && draft.Owner != "" && draft.Owner != authed {
+		respond.Error(c, http.StatusForbidden, "forbidden", "This draft belongs to another applicant.")
+		return draft, false
+	}
+	return draft, true
+}
+
+// startDraft starts a draft application to a job, checking the job accepts
+// applications the way submission does
+func startDraft(jobStore *store.JobStore, appStore *store.ApplicationStore, drafts *store.DraftStore, jobID, owner string) (models.ApplicationDraft, *apiError) {
+	req := models.ApplicationRequest{JobID: jobID}
+	job, problems, _ := validateApplication(jobStore, appStore, &req, nil)
+	if apiErr := stepProblems(problems, "").err(); apiErr != nil {
+		return models.ApplicationDraft{}, apiErr
+	}
+	return drafts.Create(job, owner), nil
+}
+
+// saveDraftStep fills in step with fill and completes it, when the steps
+// before it are done and the fields it covers are valid. Other fields are
+// left to their own steps.
+func saveDraftStep(jobStore *store.JobStore, appStore *store.ApplicationStore, drafts *store.DraftStore, draft models.ApplicationDraft, step string, fill func(*models.ApplicationRequest), found violations) (models.ApplicationDraft, *apiError) {
+	if !draft.CompleteStep(step) {
+		return draft, &apiError{status: http.StatusConflict, code: "step_out_of_order", message: "Complete the " + draft.NextStep + " step first."}
+	}
+
+	fill(&draft.Application)
+	// Validate a copy, so the draft keeps what was entered and submission
+	// normalizes it and reports its warnings
+	check := draft.Application
+	_, problems, _ := validateApplication(jobStore, appStore, &check, nil)
+	if apiErr := append(found, stepProblems(problems, step)...).errAbout("The " + step + " step has several problems. See violations for details."); apiErr != nil {
+		return draft, apiErr
+	}
+	return updateDraft(drafts, draft)
+}
+
+// reviewDraft completes the review step of a draft whose earlier steps are
+// done, if it would be accepted as it is
+func reviewDraft(jobStore *store.JobStore, appStore *store.ApplicationStore, drafts *store.DraftStore, draft models.ApplicationDraft) (models.ApplicationDraft, *apiError) {
+	if !draft.CompleteStep(models.StepReview) {
+		return draft, &apiError{status: http.StatusConflict, code: "step_out_of_order", message: "Complete the " + draft.NextStep + " step first."}
+	}
+	check := draft.Application
+	_, problems, _ := validateApplication(jobStore, appStore, &check, nil)
+	if apiErr := problems.errAbout("The draft has several problems. See violations for details."); apiErr != nil {
+		return draft, apiErr
+	}
+	return updateDraft(drafts, draft)
+}
+
+// submitDraft submits a reviewed draft as an application and discards it
+func submitDraft(jobStore *store.JobStore, appStore *store.ApplicationStore, drafts *store.DraftStore, draft models.ApplicationDraft) (*models.Application, *apiError) {
+	if draft.NextStep != models.StepSubmit {
+		return nil, &apiError{status: http.StatusConflict, code: "step_out_of_order", message: "Complete the " + draft.NextStep + " step first."}
+	}
+	app, apiErr := submitApplication(jobStore, appStore, draft.Application)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	drafts.Delete(draft.ID)
+	return app, nil
+}
+
+// updateDraft stores a changed draft, turning the store's errors into API
+// errors
+func updateDraft(drafts *store.DraftStore, draft models.ApplicationDraft) (models.ApplicationDraft, *apiError) {
+	updated, err := drafts.Update(draft)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return draft, &apiError{status: http.StatusNotFound, code: "draft_not_found", message: "The specified draft could not be found. Drafts expire a day after they were last changed."}
+		}
+		return draft, &apiError{status: http.StatusInternalServerError, code: "storage_failed", message: "Failed to save draft: " + err.Error()}
+	}
+	return updated, nil
+}
+
+// stepFields are the application fields each wizard step fills in;
+// answers to screening questions belong to the questions step
+var stepFields = map[string][]string{
+	models.StepPersonal: {"applicant_name", "applicant_email", "phone", "linkedin", "portfolio", "github",
+		"work_authorization", "sponsorship_needed", "start_date", "availability", "salary_expectation",
+		"relocation_willing", "remote_preference"},
+	models.StepResume: {"resume", "cover_letter"},
+}
+
+// stepProblems keeps the violations about the fields step fills in, or
+// about the job when step is empty
+func stepProblems(found violations, step string) violations {
+	var kept violations
+	for _, v := range found {
+		var ours bool
+		switch step {
+		case "":
+			ours = v.Field == "job_id"
+		case models.StepQuestions:
+			ours = v.Field == "custom_answers" || strings.HasPrefix(v.Field, "custom_answers.")
+		default:
+			ours = slices.Contains(stepFields[step], v.Field)
+		}
+		if ours {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/emulate"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// maxUploadSize bounds multipart application bodies
+const maxUploadSize = 10 << 20
+
+// boardJobs returns the jobs of the company whose board token matches
+func boardJobs(jobStore *store.JobStore, token string) ([]models.Job, bool) {
+	var jobs []models.Job
+	for _, job := range jobStore.GetAll(0) {
+		if emulate.BoardToken(job.Company) == token {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, len(jobs) > 0
+}
+
+// RouteNotFound answers requests no route matches with a route_not_found
+// error in the negotiated format, or the ATS profile's error body, rather
+// than a plain text 404
+func RouteNotFound(c *gin.Context) {
+	respond.Error(c, http.StatusNotFound, "route_not_found", "No route matches "+c.Request.Method+" "+c.Request.URL.Path+".")
+}
+
+// jobPageURL returns the absolute URL of the sandbox's own job page
+func jobPageURL(c *gin.Context, job models.Job) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host + "/jobs/" + job.ID
+}
+
+// formFields flattens a multipart or urlencoded form into field values.
+// Uploaded files are read as text and stored under their field name.
+func formFields(c *gin.Context) (map[string]string, error) {
+	if err := c.Request.ParseMultipartForm(maxUploadSize); err != nil && err != http.ErrNotMultipart {
+		return nil, err
+	}
+
+	fields := make(map[string]string, len(c.Request.PostForm))
+	for name, values := range c.Request.PostForm {
+		if len(values) > 0 {
+			fields[name] = values[0]
+		}
+	}
+
+	if c.Request.MultipartForm != nil {
+		for name, files := range c.Request.MultipartForm.File {
+			if len(files) == 0 {
+				continue
+			}
+			text, err := readUpload(files[0])
+			if err != nil {
+				return nil, err
+			}
+			fields[name] = text
+		}
+	}
+
+	return fields, nil
+}
+
+func readUpload(header *multipart.FileHeader) (string, error) {
+	f, err := header.Open()
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/events"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/middleware"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// eventsHeartbeat is how often an idle event stream sends a comment, so
+// proxies and clients do not time it out
+const eventsHeartbeat = 15 * time.Second
+
+// EventsHandler streams sandbox events to clients as Server-Sent Events
+// and serves the application log
+type EventsHandler struct {
+	broker   *events.Broker
+	appStore *store.ApplicationStore
+}
+
+// NewEventsHandler creates a new events handler
+func NewEventsHandler(broker *events.Broker, appStore *store.ApplicationStore) *EventsHandler {
+	return &EventsHandler{broker: broker, appStore: appStore}
+}
+
+// NotifyJobCreated publishes a job.created event. It is registered as a
+// job store create listener.
+func (h *EventsHandler) NotifyJobCreated(job models.Job) {
+	h.broker.Publish(events.JobCreated, job)
+}
+
+// NotifySubmission publishes an application.submitted event. It is
+// registered as an application store submit listener.
+func (h *EventsHandler) NotifySubmission(app models.Application) {
+	h.broker.Publish(events.ApplicationSubmitted, models.SubmissionData{
+		ApplicationID: app.ConfirmationID,
+		JobID:         app.JobID,
+		JobTitle:      app.JobTitle,
+		Company:       app.Company,
+		Status:        app.Status,
+		SubmittedAt:   app.SubmittedAt.UTC(),
+	})
+}
+
+// NotifyStatusChange publishes an application.status_changed event. It is
+// registered as an application store status listener.
+func (h *EventsHandler) NotifyStatusChange(app models.Application, previous models.ApplicationStatus) {
+	h.broker.Publish(events.StatusChanged, models.StatusChangeData{
+		ApplicationID:  app.ConfirmationID,
+		JobID:          app.JobID,
+		PreviousStatus: previous,
+		Status:         app.Status,
+		UpdatedAt:      app.UpdatedAt.UTC(),
+	})
+}
+
+// Stream handles GET /api/events
+// Streams sandbox events as Server-Sent Events until the client disconnects.
+// ?types= limits the stream to a comma-separated list of event types, and a
+// reconnecting client's Last-Event-ID replays the recent events it missed.
+func (h *EventsHandler) Stream(c *gin.Context) {
+	var types []string
+	if raw := c.Query("types"); raw != "" {
+		for _, t := range strings.Split(raw, ",") {
+			t = strings.TrimSpace(t)
+			if !slices.Contains(events.Types, t) {
+				respond.Error(c, http.StatusBadRequest, "invalid_parameter",
+					fmt.Sprintf("Invalid value %q for types: must be a comma-separated list of %s.", t, strings.Join(events.Types, ", ")))
+				return
+			}
+			types = append(types, t)
+		}
+	}
+
+	var after uint64
+	if raw := c.GetHeader("Last-Event-ID"); raw != "" {
+		n, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			respond.Error(c, http.StatusBadRequest, "invalid_last_event_id", "Last-Event-ID must be the id of an event from this stream.")
+			return
+		}
+		after = n
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Status(http.StatusOK)
+	if c.Request.Method == http.MethodHead {
+		return
+	}
+
+	stream, cancel, complete := h.broker.Subscribe(after)
+	defer cancel()
+
+	if !complete {
+		// Some of the missed events are no longer kept
+		fmt.Fprint(c.Writer, "event: events_dropped\ndata: {}\n\n")
+	}
+	fmt.Fprint(c.Writer, ": connected\n\n")
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(eventsHeartbeat)
+	defer heartbeat.Stop()
+
+	ctx, stop := respond.StreamContext(c)
+	defer stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": heartbeat\n\n")
+			c.Writer.Flush()
+		case event, open := <-stream:
+			if !open {
+				// Fell too far behind; the client reconnects with Last-Event-ID
+				return
+			}
+			if (len(types) > 0 && !slices.Contains(types, event.Type)) || !h.visible(ctx, event) {
+				continue
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+			c.Writer.Flush()
+		}
+	}
+}
+
+// GetLog handles GET /api/events/log
+// Returns the application log entries numbered after ?since=, oldest first,
+// so a client can catch up on every change by polling instead of holding
+// an event stream open
+func (h *EventsHandler) GetLog(c *gin.Context) {
+	params := newQueryParams(c)
+	since := params.since()
+	limit := params.limit(100)
+	if !params.check() {
+		return
+	}
+	// Applicants only see the entries about their own applications
+	if email, restricted := middleware.Applicant(c.Request.Context()); restricted {
+		c.JSON(http.StatusOK, h.appStore.LogOf(email, since, limit))
+		return
+	}
+	c.JSON(http.StatusOK, h.appStore.Log(since, limit))
+}
+
+// visible reports whether a stream may be sent event: requests restricted
+// to an applicant's applications only get the application events about
+// theirs
+func (h *EventsHandler) visible(ctx context.Context, event events.Event) bool {
+	var id string
+	switch data := event.Data.(type) {
+	case models.SubmissionData:
+		id = data.ApplicationID
+	case models.StatusChangeData:
+		id = data.ApplicationID
+	default:
+		return true
+	}
+	if _, restricted := middleware.Applicant(ctx); !restricted {
+		return true
+	}
+	app, exists := h.appStore.GetByID(id)
+	return exists && checkOwner(ctx, app) == nil
+}
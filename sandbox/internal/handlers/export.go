@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/gin-gonic/gin"
+)
+
+// exportFormats are the formats the export endpoints write
+var exportFormats = []string{"csv", "jsonl"}
+
+// exportFlushEvery is how many rows are written between flushes to the client
+const exportFlushEvery = 100
+
+// customAnswerPrefix starts the export column of each custom answer
+const customAnswerPrefix = "custom_answers."
+
+// applicationColumns are the export columns every application has, before
+// one column per custom answer
+var applicationColumns = []string{
+	"id", "confirmation_id", "job_id", "job_title", "company", "applicant_name", "applicant_email",
+	"phone", "phone_e164", "linkedin", "portfolio", "github", "work_authorization", "status",
+	"submitted_at", "updated_at", "reviewed_at", "match_score", "applicant_id", "honeypot_fields",
+	"resume", "cover_letter", "notes",
+}
+
+// exportTable is a flat table of export rows, all with the same columns
+type exportTable struct {
+	columns []string
+	rows    int
+	row     func(i int) []any
+}
+
+// applicationTable flattens applications into export rows, with a
+// custom_answers.<id> column for every question any of them answered
+func applicationTable(apps []*models.Application) exportTable {
+	answered := make(map[string]bool)
+	for _, app := range apps {
+		for id := range app.CustomAnswers {
+			answered[id] = true
+		}
+	}
+	questions := slices.Sorted(maps.Keys(answered))
+
+	columns := slices.Clone(applicationColumns)
+	for _, id := range questions {
+		columns = append(columns, customAnswerPrefix+id)
+	}
+
+	return exportTable{columns: columns, rows: len(apps), row: func(i int) []any {
+		app := apps[i]
+		row := []any{
+			app.ID, app.ConfirmationID, app.JobID, app.JobTitle, app.Company, app.ApplicantName, app.ApplicantEmail,
+			app.Phone, app.PhoneE164, app.LinkedIn, app.Portfolio, app.GitHub, app.WorkAuthorization, string(app.Status),
+			app.SubmittedAt, app.UpdatedAt, app.ReviewedAt, matchScore(app), app.ApplicantID, app.HoneypotFields,
+			app.Resume, app.CoverLetter, app.Notes,
+		}
+		for _, id := range questions {
+			if answer, ok := app.CustomAnswers[id]; ok {
+				row = append(row, answer)
+			} else {
+				row = append(row, nil)
+			}
+		}
+		return row
+	}}
+}
+
+// requestTable flattens the request records of a run into export rows
+func requestTable(requests []models.RunRequestRecord) exportTable {
+	columns := []string{
+		"at", "method", "path", "status", "latency_ms", "error_code", "retry", "application_id",
+		"challenge", "honeypot", "shadow_rejected", "trap_job", "trap", "fabrications",
+	}
+	return exportTable{columns: columns, rows: len(requests), row: func(i int) []any {
+		r := requests[i]
+		return []any{
+			r.At, r.Method, r.Path, r.Status, r.LatencyMs, r.ErrorCode, r.Retry, r.ApplicationID,
+			r.Challenge, r.Honeypot, r.ShadowRejected, r.TrapJob, r.Trap, r.Fabrications,
+		}
+	}}
+}
+
+// writeExport streams table as an attachment named name, either as CSV
+// with a header row or as JSON Lines with one object per row, keyed by
+// column in column order. Missing values are empty cells in CSV and null
+// in JSON Lines. Writing stops once the client goes away.
+func writeExport(c *gin.Context, format, name string, table exportTable) {
+	c.Header("X-Total-Count", strconv.Itoa(table.rows))
+	if format == "jsonl" {
+		c.Header("Content-Disposition", `attachment; filename="`+name+`.jsonl"`)
+		c.Header("Content-Type", "application/x-ndjson")
+	} else {
+		c.Header("Content-Disposition", `attachment; filename="`+name+`.csv"`)
+		c.Header("Content-Type", respond.FormatCSV+"; charset=utf-8")
+	}
+	c.Status(http.StatusOK)
+
+	var w *csv.Writer
+	if format != "jsonl" {
+		w = csv.NewWriter(c.Writer)
+		w.Write(table.columns)
+	}
+	for i := range table.rows {
+		if i%exportFlushEvery == 0 && respond.Gone(c) {
+			return
+		}
+
+		var err error
+		if w != nil {
+			err = w.Write(exportCells(table.row(i)))
+		} else {
+			_, err = c.Writer.Write(exportLine(table.columns, table.row(i)))
+		}
+		if err != nil {
+			respond.Disconnected(c)
+			return
+		}
+		if (i+1)%exportFlushEvery == 0 {
+			if w != nil {
+				w.Flush()
+			}
+			c.Writer.Flush()
+		}
+	}
+	if w != nil {
+		w.Flush()
+	}
+}
+
+// exportCells formats a row as CSV cells: slices joined with "; ", times
+// in RFC 3339 and missing values empty
+func exportCells(row []any) []string {
+	cells := make([]string, len(row))
+	for i, value := range row {
+		switch v := value.(type) {
+		case nil:
+		case string:
+			cells[i] = v
+		case time.Time:
+			cells[i] = v.Format(time.RFC3339)
+		case *time.Time:
+			if v != nil {
+				cells[i] = v.Format(time.RFC3339)
+			}
+		case *int:
+			if v != nil {
+				cells[i] = strconv.Itoa(*v)
+			}
+		case []string:
+			cells[i] = strings.Join(v, "; ")
+		default:
+			cells[i] = fmt.Sprint(v)
+		}
+	}
+	return cells
+}
+
+// exportLine encodes a row as a JSON object on one line, keeping the
+// columns in order
+func exportLine(columns []string, row []any) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, column := range columns {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(column)
+		buf.Write(key)
+		buf.WriteByte(':')
+		value, err := json.Marshal(row[i])
+		if err != nil {
+			value = []byte("null")
+		}
+		buf.Write(value)
+	}
+	buf.WriteString("}\n")
+	return buf.Bytes()
+}
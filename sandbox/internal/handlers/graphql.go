@@ -0,0 +1,453 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/graphql"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// GraphQLHandler serves the GraphQL API over the job and application stores
+type GraphQLHandler struct {
+	jobStore *store.JobStore
+	appStore *store.ApplicationStore
+	guard    *SubmitGuard
+	schema   *graphql.Schema
+}
+
+// NewGraphQLHandler creates a new GraphQL handler
+func NewGraphQLHandler(jobStore *store.JobStore, appStore *store.ApplicationStore, guard *SubmitGuard) *GraphQLHandler {
+	h := &GraphQLHandler{
+		jobStore: jobStore,
+		appStore: appStore,
+		guard:    guard,
+	}
+	h.schema = h.buildSchema()
+	return h
+}
+
+// Query handles POST /graphql
+// Executes a GraphQL query or mutation
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	var req graphql.Request
+	if err := c.ShouldBindJSON(&req); err != nil || req.Query == "" {
+		c.JSON(http.StatusBadRequest, graphql.Response{
+			Errors: []*graphql.Error{graphql.NewError("BAD_REQUEST", "Request body must be JSON with a 'query' string.")},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.schema.Execute(c.Request.Context(), req))
+}
+
+// Schema handles GET /graphql/schema
+// Returns the schema in the GraphQL schema definition language
+func (h *GraphQLHandler) Schema(c *gin.Context) {
+	c.String(http.StatusOK, h.schema.SDL())
+}
+
+// Console handles GET /graphql
+// Renders an interactive query console (debug mode only)
+func (h *GraphQLHandler) Console(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", graphql.ConsoleHTML)
+}
+
+// buildSchema defines the GraphQL types and resolvers
+func (h *GraphQLHandler) buildSchema() *graphql.Schema {
+	screeningQuestion := &graphql.Object{
+		Name:        "ScreeningQuestion",
+		Description: "A job-specific question, answered in customAnswers under its id",
+		Fields: map[string]*graphql.Field{
+			"id":       {Type: "ID!"},
+			"label":    {Type: "String!"},
+			"type":     {Type: "String!"},
+			"required": {Type: "Boolean!"},
+			"options":  {Type: "[String!]"},
+			"pattern":  {Type: "String"},
+		},
+	}
+
+	job := &graphql.Object{
+		Name:        "Job",
+		Description: "A job posting",
+		Fields: map[string]*graphql.Field{
+			"id":                         {Type: "ID!"},
+			"title":                      {Type: "String!"},
+			"company":                    {Type: "String!"},
+			"description":                {Type: "String!"},
+			"requirements":               {Type: "[String!]!"},
+			"location":                   {Type: "String!"},
+			"isRemote":                   {Type: "Boolean!"},
+			"salary":                     {Type: "String"},
+			"experienceRequired":         {Type: "Int!"},
+			"jobType":                    {Type: "String!"},
+			"postedAt":                   {Type: "String!"},
+			"applicationDeadline":        {Type: "String"},
+			"benefits":                   {Type: "[String!]"},
+			"companySize":                {Type: "String"},
+			"industry":                   {Type: "String"},
+			"acceptedWorkAuthorizations": {Type: "[String!]"},
+			"questions":                  {Type: "[ScreeningQuestion!]"},
+			"maxApplications":            {Type: "Int"},
+			"status":                     {Type: "String!"},
+			"applicationsCount": {Type: "Int!", Resolve: func(ctx context.Context, source interface{}, args graphql.Args) (interface{}, error) {
+				return h.appStore.GetCountByJobID(source.(models.Job).ID), nil
+			}},
+			"isAcceptingApplications": {Type: "Boolean!", Resolve: func(ctx context.Context, source interface{}, args graphql.Args) (interface{}, error) {
+				return isAcceptingApplications(source.(models.Job), h.appStore), nil
+			}},
+			"isFilled": {Type: "Boolean!", Resolve: func(ctx context.Context, source interface{}, args graphql.Args) (interface{}, error) {
+				return isFilled(source.(models.Job), h.appStore), nil
+			}},
+			"applications": {
+				Type:        "[Application!]!",
+				Args:        map[string]string{"status": "String", "limit": "Int", "order": "String"},
+				Description: "Applications to this job, newest first unless order is \"oldest\"",
+				Resolve: func(ctx context.Context, source interface{}, args graphql.Args) (interface{}, error) {
+					return h.findApplications(ctx, "", source.(models.Job).ID, args)
+				},
+			},
+		},
+	}
+
+	statusChange := &graphql.Object{
+		Name:        "StatusChange",
+		Description: "One entry in an application's status history",
+		Fields: map[string]*graphql.Field{
+			"status": {Type: "String!"},
+			"notes":  {Type: "String"},
+			"actor":  {Type: "String!"},
+			"at": {Type: "String!", Resolve: func(ctx context.Context, source interface{}, args graphql.Args) (interface{}, error) {
+				return source.(models.StatusChange).At.Format(time.RFC3339), nil
+			}},
+		},
+	}
+
+	application := &graphql.Object{
+		Name:        "Application",
+		Description: "A submitted application",
+		Fields: map[string]*graphql.Field{
+			"id":                {Type: "ID!"},
+			"confirmationId":    {Type: "String!"},
+			"jobId":             {Type: "ID!"},
+			"jobTitle":          {Type: "String!"},
+			"company":           {Type: "String!"},
+			"applicantName":     {Type: "String!"},
+			"applicantEmail":    {Type: "String!"},
+			"phone":             {Type: "String"},
+			"phoneE164":         {Type: "String"},
+			"status":            {Type: "String!"},
+			"notes":             {Type: "String"},
+			"workAuthorization": {Type: "String"},
+			"submittedAt": {Type: "String!", Resolve: func(ctx context.Context, source interface{}, args graphql.Args) (interface{}, error) {
+				return source.(*models.Application).SubmittedAt.Format(time.RFC3339), nil
+			}},
+			"updatedAt": {Type: "String!", Resolve: func(ctx context.Context, source interface{}, args graphql.Args) (interface{}, error) {
+				return source.(*models.Application).UpdatedAt.Format(time.RFC3339), nil
+			}},
+			"timeline": {Type: "[StatusChange!]!", Resolve: func(ctx context.Context, source interface{}, args graphql.Args) (interface{}, error) {
+				return source.(*models.Application).StatusHistory, nil
+			}},
+			"job": {Type: "Job", Resolve: func(ctx context.Context, source interface{}, args graphql.Args) (interface{}, error) {
+				if job, ok := h.jobStore.GetByID(source.(*models.Application).JobID); ok {
+					return job, nil
+				}
+				return nil, nil
+			}},
+		},
+	}
+
+	statusCount := &graphql.Object{
+		Name: "StatusCount",
+		Fields: map[string]*graphql.Field{
+			"status": {Type: "String!"},
+			"count":  {Type: "Int!"},
+		},
+	}
+
+	stats := &graphql.Object{
+		Name:        "Stats",
+		Description: "Sandbox statistics",
+		Fields: map[string]*graphql.Field{
+			"totalJobs":         {Type: "Int!"},
+			"totalApplications": {Type: "Int!"},
+			"topCompanies":      {Type: "[String!]!"},
+			"clientDisconnects": {Type: "Int!"},
+			"applicationsByStatus": {Type: "[StatusCount!]!", Resolve: func(ctx context.Context, source interface{}, args graphql.Args) (interface{}, error) {
+				byStatus := source.(models.StatsResponse).ApplicationsByStatus
+				statuses := make([]string, 0, len(byStatus))
+				for status := range byStatus {
+					statuses = append(statuses, status)
+				}
+				sort.Strings(statuses)
+				counts := make([]map[string]interface{}, 0, len(statuses))
+				for _, status := range statuses {
+					counts = append(counts, map[string]interface{}{"status": status, "count": byStatus[status]})
+				}
+				return counts, nil
+			}},
+		},
+	}
+
+	query := &graphql.Object{
+		Name: "Query",
+		Fields: map[string]*graphql.Field{
+			"jobs": {
+				Type: "[Job!]!",
+				Args: map[string]string{
+					"q": "String", "remote": "Boolean", "type": "String", "location": "String", "company": "String",
+					"minSalary": "Int", "maxSalary": "Int", "minExperience": "Int", "maxExperience": "Int",
+					"includeClosed": "Boolean", "limit": "Int",
+				},
+				Description: "List jobs; filters apply in the same order as GET /api/jobs",
+				Resolve:     h.resolveJobs,
+			},
+			"job": {
+				Type: "Job",
+				Args: map[string]string{"id": "ID!"},
+				Resolve: func(ctx context.Context, source interface{}, args graphql.Args) (interface{}, error) {
+					if job, ok := h.jobStore.GetByID(args.String("id")); ok {
+						return job, nil
+					}
+					return nil, nil
+				},
+			},
+			"applications": {
+				Type:        "[Application!]!",
+				Args:        map[string]string{"status": "String", "email": "String", "jobId": "ID", "limit": "Int", "order": "String"},
+				Description: "List applications, newest first unless order is \"oldest\"; email takes precedence over jobId as in GET /api/applications",
+				Resolve:     h.resolveApplications,
+			},
+			"application": {
+				Type: "Application",
+				Args: map[string]string{"id": "ID!"},
+				Resolve: func(ctx context.Context, source interface{}, args graphql.Args) (interface{}, error) {
+					app, ok := h.appStore.GetPropagatedByID(args.String("id"))
+					if !ok {
+						return nil, nil
+					}
+					if apiErr := checkOwner(ctx, app); apiErr != nil {
+						return nil, graphqlError(apiErr)
+					}
+					return app, nil
+				},
+			},
+			"stats": {
+				Type: "Stats!",
+				Resolve: func(ctx context.Context, source interface{}, args graphql.Args) (interface{}, error) {
+					return buildStats(h.jobStore, h.appStore), nil
+				},
+			},
+		},
+	}
+
+	mutation := &graphql.Object{
+		Name: "Mutation",
+		Fields: map[string]*graphql.Field{
+			"submitApplication": {
+				Type:        "Application!",
+				Args:        map[string]string{"input": "ApplicationInput!"},
+				Description: "Submit an application; validated exactly like POST /api/applications",
+				Resolve:     h.resolveSubmitApplication,
+			},
+			"withdrawApplication": {
+				Type:        "Application!",
+				Args:        map[string]string{"id": "ID!", "reason": "String"},
+				Description: "Withdraw an application, as POST /api/applications/:id/withdraw does",
+				Resolve:     h.resolveWithdrawApplication,
+			},
+		},
+	}
+
+	return &graphql.Schema{
+		Query:    query,
+		Mutation: mutation,
+		Types:    []*graphql.Object{screeningQuestion, job, application, statusChange, statusCount, stats},
+		Inputs: []*graphql.InputObject{{
+			Name: "ApplicationInput",
+			Fields: map[string]string{
+				"jobId":             "ID!",
+				"applicantName":     "String!",
+				"applicantEmail":    "String!",
+				"resume":            "String!",
+				"coverLetter":       "String",
+				"phone":             "String",
+				"linkedin":          "String",
+				"portfolio":         "String",
+				"github":            "String",
+				"workAuthorization": "String",
+				"sponsorshipNeeded": "Boolean",
+				"startDate":         "String",
+				"availability":      "String",
+				"salaryExpectation": "String",
+				"relocationWilling": "Boolean",
+				"remotePreference":  "String",
+				"customAnswers":     "[CustomAnswerInput!]",
+			},
+		}, {
+			Name:   "CustomAnswerInput",
+			Fields: map[string]string{"question": "String!", "answer": "String!"},
+		}},
+		MaxDepth:        6,
+		MaxComplexity:   2000,
+		DefaultListSize: 10,
+		MaxListSize:     respond.MaxLimit,
+	}
+}
+
+func (h *GraphQLHandler) resolveJobs(ctx context.Context, source interface{}, args graphql.Args) (interface{}, error) {
+	filter := store.JobFilter{
+		Query:    args.String("q"),
+		JobType:  args.String("type"),
+		Location: args.String("location"),
+		Company:  args.String("company"),
+	}
+	if filter.JobType != "" && !slices.Contains(jobTypes, filter.JobType) {
+		return nil, fmt.Errorf("type must be one of %s", strings.Join(jobTypes, ", "))
+	}
+	if isRemote, ok := args.Bool("remote"); ok {
+		filter.Remote = &isRemote
+	}
+
+	// Salary and experience bounds follow the REST query parameters
+	counts := make(map[string]*int)
+	for _, name := range []string{"minSalary", "maxSalary", "minExperience", "maxExperience"} {
+		if args[name] == nil {
+			continue
+		}
+		n := args.Int(name, 0)
+		if n < 0 {
+			return nil, fmt.Errorf("%s must not be negative", name)
+		}
+		counts[name] = &n
+	}
+	if counts["minSalary"] != nil {
+		filter.MinSalary = *counts["minSalary"]
+	}
+	if counts["maxSalary"] != nil {
+		filter.MaxSalary = *counts["maxSalary"]
+	}
+	if filter.MinSalary > 0 && filter.MaxSalary > 0 && filter.MaxSalary < filter.MinSalary {
+		return nil, fmt.Errorf("maxSalary must be at least minSalary")
+	}
+	filter.MinExperience, filter.MaxExperience = counts["minExperience"], counts["maxExperience"]
+	if filter.MinExperience != nil && filter.MaxExperience != nil && *filter.MaxExperience < *filter.MinExperience {
+		return nil, fmt.Errorf("maxExperience must be at least minExperience")
+	}
+
+	includeClosed, _ := args.Bool("includeClosed")
+	filter.HideClosed = !includeClosed
+	limit, _ := respond.ClampLimit(args.Int("limit", 0), 100)
+	return h.jobStore.Filter(filter, limit), nil
+}
+
+func (h *GraphQLHandler) resolveApplications(ctx context.Context, source interface{}, args graphql.Args) (interface{}, error) {
+	return h.findApplications(ctx, args.String("email"), args.String("jobId"), args)
+}
+
+// findApplications lists the applications matching email or jobID as
+// GET /api/applications does, then the status, order and limit arguments.
+// Applicants only see their own.
+func (h *GraphQLHandler) findApplications(ctx context.Context, email, jobID string, args graphql.Args) ([]*models.Application, error) {
+	scoped, apiErr := applicantScope(ctx, email)
+	if apiErr != nil {
+		return nil, graphqlError(apiErr)
+	}
+	order := store.NewestFirst
+	switch args.String("order") {
+	case "", "newest":
+	case "oldest":
+		order = store.OldestFirst
+	default:
+		return nil, fmt.Errorf("order must be one of %s", strings.Join(applicationOrders, ", "))
+	}
+	limit, _ := respond.ClampLimit(args.Int("limit", 0), 100)
+	apps := listApplications(h.appStore, scoped, jobID, 0, order)
+	if scoped != email && jobID != "" {
+		// Listed by the applicant's address, which takes precedence
+		apps = slices.DeleteFunc(apps, func(app *models.Application) bool { return app.JobID != jobID })
+	}
+
+	// Filter by status before limiting, so the limit counts matches
+	if status := args.String("status"); status != "" {
+		apps = withStatus(apps, models.ApplicationStatus(status))
+	}
+	return apps[:min(limit, len(apps))], nil
+}
+
+func (h *GraphQLHandler) resolveSubmitApplication(ctx context.Context, source interface{}, args graphql.Args) (interface{}, error) {
+	input := graphql.Args(args.Object("input"))
+
+	req := models.ApplicationRequest{
+		JobID:             input.String("jobId"),
+		ApplicantName:     input.String("applicantName"),
+		ApplicantEmail:    input.String("applicantEmail"),
+		Resume:            input.String("resume"),
+		CoverLetter:       input.String("coverLetter"),
+		Phone:             input.String("phone"),
+		LinkedIn:          input.String("linkedin"),
+		Portfolio:         input.String("portfolio"),
+		GitHub:            input.String("github"),
+		WorkAuthorization: input.String("workAuthorization"),
+		StartDate:         input.String("startDate"),
+		Availability:      input.String("availability"),
+		SalaryExpectation: input.String("salaryExpectation"),
+		RemotePreference:  input.String("remotePreference"),
+	}
+	if b, ok := input.Bool("sponsorshipNeeded"); ok {
+		req.SponsorshipNeeded = &b
+	}
+	if b, ok := input.Bool("relocationWilling"); ok {
+		req.RelocationWilling = &b
+	}
+	if answers, ok := input["customAnswers"].([]interface{}); ok {
+		req.CustomAnswers = make(map[string]string, len(answers))
+		for _, item := range answers {
+			obj, _ := item.(map[string]interface{})
+			answer := graphql.Args(obj)
+			req.CustomAnswers[answer.String("question")] = answer.String("answer")
+		}
+	}
+
+	if apiErr := h.guard.check(ctx, req, false); apiErr != nil {
+		return nil, graphqlError(apiErr)
+	}
+	app, apiErr := submitApplication(h.jobStore, h.appStore, req)
+	if apiErr != nil {
+		return nil, graphqlError(apiErr)
+	}
+	return app, nil
+}
+
+func (h *GraphQLHandler) resolveWithdrawApplication(ctx context.Context, source interface{}, args graphql.Args) (interface{}, error) {
+	if app, exists := h.appStore.GetByID(args.String("id")); exists {
+		if apiErr := checkOwner(ctx, app); apiErr != nil {
+			return nil, graphqlError(apiErr)
+		}
+	}
+	app, apiErr := withdrawApplication(h.appStore, args.String("id"), args.String("reason"))
+	if apiErr != nil {
+		return nil, graphqlError(apiErr)
+	}
+	return app, nil
+}
+
+// graphqlError converts an API error into a GraphQL error carrying its
+// code, HTTP status and violations
+func graphqlError(apiErr *apiError) *graphql.Error {
+	gqlErr := graphql.NewError(apiErr.code, apiErr.message)
+	gqlErr.Extensions["status"] = apiErr.status
+	if len(apiErr.violations) > 0 {
+		gqlErr.Extensions["violations"] = apiErr.violations
+	}
+	return gqlErr
+}
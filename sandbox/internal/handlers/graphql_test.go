@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/graphql"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// gqlResult is a decoded GraphQL response
+type gqlResult struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+func execGraphQL(t *testing.T, h *GraphQLHandler, query string) gqlResult {
+	t.Helper()
+	b, err := json.Marshal(h.schema.Execute(context.Background(), graphql.Request{Query: query}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result gqlResult
+	if err := json.Unmarshal(b, &result); err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+// TestGraphQLJobsMatchREST checks that each jobs filter argument selects
+// the same jobs as its GET /api/jobs query parameter
+func TestGraphQLJobsMatchREST(t *testing.T) {
+	jobStore, appStore := newTestStores(t)
+	h := NewGraphQLHandler(jobStore, appStore, nil)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/api/jobs", NewJobHandler(jobStore, appStore).ListJobs)
+
+	tests := []struct {
+		args  string
+		query url.Values
+	}{
+		{`q: "engineer"`, url.Values{"q": {"engineer"}}},
+		{`remote: true`, url.Values{"remote": {"true"}}},
+		{`remote: false`, url.Values{"remote": {"false"}}},
+		{`type: "internship"`, url.Values{"type": {"internship"}}},
+		{`location: "san francisco"`, url.Values{"location": {"san francisco"}}},
+		{`company: "google"`, url.Values{"company": {"google"}}},
+		{`minSalary: 150000`, url.Values{"min_salary": {"150000"}}},
+		{`maxSalary: 100000`, url.Values{"max_salary": {"100000"}}},
+		{`minExperience: 5`, url.Values{"min_experience": {"5"}}},
+		{`maxExperience: 1`, url.Values{"max_experience": {"1"}}},
+		{`company: "acme", minSalary: 130000, maxExperience: 3`,
+			url.Values{"company": {"acme"}, "min_salary": {"130000"}, "max_experience": {"3"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			tt.query.Set("include_closed", "true")
+			tt.query.Set("limit", "100")
+			var rest models.JobsResponse
+			if code := getJSON(t, r, http.MethodGet, "/api/jobs?"+tt.query.Encode(), "", &rest); code != http.StatusOK {
+				t.Fatalf("REST status %d", code)
+			}
+			var want []string
+			for _, job := range rest.Jobs {
+				want = append(want, job.ID)
+			}
+			if len(want) == 0 {
+				t.Fatal("the filter matches no job, so the comparison proves nothing")
+			}
+
+			result := execGraphQL(t, h, `{ jobs(`+tt.args+`, includeClosed: true, limit: 100) { id } }`)
+			if len(result.Errors) > 0 {
+				t.Fatalf("errors: %+v", result.Errors)
+			}
+			var jobs []struct{ ID string }
+			if err := json.Unmarshal(result.Data["jobs"], &jobs); err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, job := range jobs {
+				got = append(got, job.ID)
+			}
+			if !slices.Equal(got, want) {
+				t.Errorf("GraphQL jobs %v, REST jobs %v", got, want)
+			}
+		})
+	}
+}
+
+func TestGraphQLJobsInvalidArguments(t *testing.T) {
+	jobStore, appStore := newTestStores(t)
+	h := NewGraphQLHandler(jobStore, appStore, nil)
+
+	for _, args := range []string{
+		`type: "gig"`,
+		`minSalary: -1`,
+		`minSalary: 100000, maxSalary: 50000`,
+		`minExperience: 5, maxExperience: 2`,
+	} {
+		result := execGraphQL(t, h, `{ jobs(`+args+`) { id } }`)
+		if len(result.Errors) == 0 {
+			t.Errorf("jobs(%s) did not fail", args)
+		}
+	}
+}
+
+func TestGraphQLWithdrawApplication(t *testing.T) {
+	jobStore, appStore := newTestStores(t)
+	h := NewGraphQLHandler(jobStore, appStore, nil)
+	app, apiErr := submitApplication(jobStore, appStore, testApplication("vic@example.com"))
+	if apiErr != nil {
+		t.Fatalf("submitting: %s", apiErr.message)
+	}
+
+	result := execGraphQL(t, h, `mutation { withdrawApplication(id: "`+app.ConfirmationID+`", reason: "Took another offer") { confirmationId status } }`)
+	if len(result.Errors) > 0 {
+		t.Fatalf("errors: %+v", result.Errors)
+	}
+	var withdrawn struct{ ConfirmationID, Status string }
+	if err := json.Unmarshal(result.Data["withdrawApplication"], &withdrawn); err != nil {
+		t.Fatal(err)
+	}
+	if withdrawn.ConfirmationID != app.ConfirmationID || withdrawn.Status != string(models.StatusWithdrawn) {
+		t.Errorf("withdrawApplication returned %+v", withdrawn)
+	}
+	if stored, _ := appStore.GetByID(app.ConfirmationID); stored.Status != models.StatusWithdrawn {
+		t.Errorf("stored status %s, want withdrawn", stored.Status)
+	}
+
+	// The same errors as POST /api/applications/:id/withdraw
+	tests := []struct {
+		id     string
+		code   string
+		status int
+	}{
+		{app.ConfirmationID, "already_withdrawn", http.StatusConflict},
+		{"CONF-missing", "application_not_found", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		result := execGraphQL(t, h, `mutation { withdrawApplication(id: "`+tt.id+`") { id } }`)
+		if len(result.Errors) != 1 {
+			t.Fatalf("withdrawing %s: errors %+v, want one", tt.id, result.Errors)
+		}
+		ext := result.Errors[0].Extensions
+		if ext["code"] != tt.code || ext["status"] != float64(tt.status) {
+			t.Errorf("withdrawing %s: extensions %v, want code %s and status %d", tt.id, ext, tt.code, tt.status)
+		}
+	}
+}
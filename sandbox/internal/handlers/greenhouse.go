@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/emulate"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// GreenhouseHandler emulates the Greenhouse job board API
+type GreenhouseHandler struct {
+	jobStore *store.JobStore
+	appStore *store.ApplicationStore
+	guard    *SubmitGuard
+}
+
+// NewGreenhouseHandler creates a new Greenhouse emulation handler
+func NewGreenhouseHandler(jobStore *store.JobStore, appStore *store.ApplicationStore, guard *SubmitGuard) *GreenhouseHandler {
+	return &GreenhouseHandler{
+		jobStore: jobStore,
+		appStore: appStore,
+		guard:    guard,
+	}
+}
+
+// ListJobs handles GET /v1/boards/:token/jobs
+// Returns the open jobs of the company whose board token matches
+func (h *GreenhouseHandler) ListJobs(c *gin.Context) {
+	jobs, ok := boardJobs(h.jobStore, c.Param("token"))
+	if !ok {
+		greenhouseError(c, http.StatusNotFound, "Job board not found")
+		return
+	}
+
+	content := c.Query("content") == "true"
+	result := make([]emulate.GreenhouseJob, 0, len(jobs))
+	for _, job := range jobs {
+		if !isAcceptingApplications(job, h.appStore) {
+			continue
+		}
+		result = append(result, emulate.GreenhouseJobFromModel(job, jobPageURL(c, job), content))
+	}
+
+	c.JSON(http.StatusOK, emulate.GreenhouseJobsResponse{
+		Jobs: result,
+		Meta: emulate.GreenhouseMeta{Total: len(result)},
+	})
+}
+
+// GetJob handles GET /v1/boards/:token/jobs/:id
+// Returns a single open job with its content, departments and offices
+func (h *GreenhouseHandler) GetJob(c *gin.Context) {
+	job, ok := h.findJob(c)
+	if !ok || !isAcceptingApplications(job, h.appStore) {
+		greenhouseError(c, http.StatusNotFound, "Job not found")
+		return
+	}
+
+	gj := emulate.GreenhouseJobFromModel(job, jobPageURL(c, job), true)
+	if c.Query("questions") == "true" {
+		gj.Questions = emulate.GreenhouseQuestions(job)
+	}
+
+	c.JSON(http.StatusOK, gj)
+}
+
+// SubmitApplication handles POST /v1/boards/:token/jobs/:id
+// Accepts a multipart or urlencoded application form
+func (h *GreenhouseHandler) SubmitApplication(c *gin.Context) {
+	job, ok := h.findJob(c)
+	if !ok {
+		greenhouseError(c, http.StatusNotFound, "Job not found")
+		return
+	}
+
+	fields, err := formFields(c)
+	if err != nil {
+		greenhouseError(c, http.StatusBadRequest, "Invalid application form: "+err.Error())
+		return
+	}
+
+	req := emulate.GreenhouseApplicationRequest(job, fields)
+	if apiErr := h.guard.check(c.Request.Context(), req, false); apiErr != nil {
+		greenhouseError(c, apiErr.status, apiErr.message)
+		return
+	}
+	app, apiErr := submitApplication(h.jobStore, h.appStore, req)
+	if apiErr != nil {
+		status := apiErr.status
+		switch apiErr.code {
+		case "deadline_passed", "job_closed", "job_filled", "job_paused", "job_draft":
+			// Greenhouse rejects applications to jobs that are not open as
+			// forbidden
+			status = http.StatusForbidden
+		}
+		greenhouseError(c, status, apiErr.detail())
+		return
+	}
+
+	markSubmitted(c, app)
+	c.JSON(http.StatusOK, emulate.GreenhouseSuccess{Success: "Candidate saved successfully"})
+}
+
+// findJob resolves the :token and numeric :id parameters to a sandbox job
+func (h *GreenhouseHandler) findJob(c *gin.Context) (models.Job, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return models.Job{}, false
+	}
+	jobs, _ := boardJobs(h.jobStore, c.Param("token"))
+	for _, job := range jobs {
+		if emulate.GreenhouseJobID(job) == id {
+			return job, true
+		}
+	}
+	return models.Job{}, false
+}
+
+// greenhouseError writes an error in the Greenhouse job board format
+func greenhouseError(c *gin.Context, status int, message string) {
+	c.AbortWithStatusJSON(status, emulate.GreenhouseError{Status: status, Error: message})
+}
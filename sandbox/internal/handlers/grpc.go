@@ -0,0 +1,301 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/grpc"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/middleware"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+)
+
+// GRPCService is the full name of the service in
+// proto/jobportal/v1/jobportal.proto
+const GRPCService = "jobportal.v1.JobPortal"
+
+// grpcDefaultLimit is how many jobs ListJobs returns by default
+const grpcDefaultLimit = 20
+
+// GRPCHandler serves the JobPortal gRPC service from the same stores as
+// the REST API. Field numbers below follow jobportal.proto.
+type GRPCHandler struct {
+	jobStore   *store.JobStore
+	appStore   *store.ApplicationStore
+	applicants *store.ApplicantStore
+	guard      *SubmitGuard
+	server     *grpc.Server
+}
+
+// NewGRPCHandler creates a new gRPC handler. With a secret, calls are
+// restricted to the applications of the applicant whose token from
+// POST /api/auth/login they send in the authorization metadata, as the
+// REST API is under -auth=required. SubmitApplication calls carry a solved
+// challenge's token in the x-challenge-token metadata.
+func NewGRPCHandler(jobStore *store.JobStore, appStore *store.ApplicationStore, applicants *store.ApplicantStore, guard *SubmitGuard, secret []byte) *GRPCHandler {
+	h := &GRPCHandler{
+		jobStore:   jobStore,
+		appStore:   appStore,
+		applicants: applicants,
+		guard:      guard,
+		server:     grpc.NewServer(GRPCService),
+	}
+
+	h.server.Unary("ListJobs", h.listJobs)
+	h.server.Unary("GetJob", h.getJob)
+	h.server.Stream("StreamJobs", h.streamJobs)
+	h.server.Unary("SubmitApplication", h.submitApplication)
+	h.server.Unary("GetApplication", h.getApplication)
+	if secret != nil {
+		h.server.Authenticate(func(ctx context.Context, md http.Header) (context.Context, error) {
+			ctx, err := middleware.AuthenticateApplicant(ctx, md.Get("Authorization"), secret)
+			if err != nil && !errors.Is(err, middleware.ErrMissingToken) {
+				return nil, &grpc.Status{Code: grpc.Unauthenticated, Message: "The token is expired, malformed or was not issued by this sandbox.", Reason: "invalid_token"}
+			}
+			return ctx, nil
+		})
+	}
+
+	return h
+}
+
+// ServeHTTP serves a gRPC call; the server it is mounted on must speak
+// HTTP/2, over TLS or in cleartext
+func (h *GRPCHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, _ := middleware.WithChallengeToken(r.Context(), r.Header.Get(middleware.ChallengeTokenHeader))
+	h.server.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// grpcError converts an API error into a gRPC status, keeping its code in
+// the error-code trailer. Several violations are listed in the message; a
+// single one is the message already.
+func grpcError(apiErr *apiError) *grpc.Status {
+	code := grpc.Internal
+	switch apiErr.status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		code = grpc.InvalidArgument
+	case http.StatusUnauthorized:
+		code = grpc.Unauthenticated
+	case http.StatusForbidden:
+		code = grpc.PermissionDenied
+	case http.StatusNotFound:
+		code = grpc.NotFound
+	case http.StatusConflict, http.StatusGone, http.StatusPreconditionRequired:
+		code = grpc.FailedPrecondition
+		if strings.HasPrefix(apiErr.code, "duplicate_") {
+			code = grpc.AlreadyExists
+		}
+	case http.StatusTooManyRequests:
+		code = grpc.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		code = grpc.Unavailable
+	}
+
+	message := apiErr.message
+	if len(apiErr.violations) > 1 {
+		problems := make([]string, len(apiErr.violations))
+		for i, v := range apiErr.violations {
+			problems[i] = v.Field + ": " + v.Message
+		}
+		message += " " + strings.Join(problems, "; ")
+	}
+	return &grpc.Status{Code: code, Message: message, Reason: apiErr.code}
+}
+
+// jobsRequest reads a ListJobsRequest into a filter and its limit
+func jobsRequest(req []byte) (store.JobFilter, int, error) {
+	msg, err := grpc.Decode(req)
+	if err != nil {
+		return store.JobFilter{}, 0, err
+	}
+	filter := store.JobFilter{Query: msg.String(1), JobType: msg.String(3), HideClosed: !msg.Bool(4)}
+	if msg.Bool(2) {
+		remote := true
+		filter.Remote = &remote
+	}
+	if filter.JobType != "" && !slices.Contains(jobTypes, filter.JobType) {
+		return filter, 0, &grpc.Status{Code: grpc.InvalidArgument, Message: "type must be one of: " + strings.Join(jobTypes, ", "), Reason: "invalid_value"}
+	}
+	limit := msg.Int(5)
+	if limit < 0 {
+		return filter, 0, &grpc.Status{Code: grpc.InvalidArgument, Message: "limit must not be negative", Reason: "invalid_value"}
+	}
+	return filter, int(limit), nil
+}
+
+func (h *GRPCHandler) listJobs(ctx context.Context, req []byte) ([]byte, error) {
+	filter, limit, err := jobsRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	limit, _ = respond.ClampLimit(limit, grpcDefaultLimit)
+
+	matches := h.jobStore.Filter(filter, 0)
+	var resp grpc.Encoder
+	for _, job := range matches[:min(limit, len(matches))] {
+		resp.Message(1, h.encodeJob(job))
+	}
+	resp.Int(2, int64(len(matches)))
+	return resp.Bytes(), nil
+}
+
+func (h *GRPCHandler) getJob(ctx context.Context, req []byte) ([]byte, error) {
+	msg, err := grpc.Decode(req)
+	if err != nil {
+		return nil, err
+	}
+	job, exists := h.jobStore.GetByID(msg.String(1))
+	if !exists {
+		return nil, grpcError(&apiError{status: http.StatusNotFound, code: "job_not_found", message: "The requested job could not be found."})
+	}
+	return h.encodeJob(job), nil
+}
+
+func (h *GRPCHandler) streamJobs(ctx context.Context, req []byte, send func([]byte) error) error {
+	filter, limit, err := jobsRequest(req)
+	if err != nil {
+		return err
+	}
+
+	ids := h.jobStore.SnapshotIDs()
+	sent := 0
+	for start := 0; start < len(ids); start += streamBatchSize {
+		for _, job := range h.jobStore.GetBatch(ids[start:min(start+streamBatchSize, len(ids))]) {
+			if limit > 0 && sent == limit {
+				return nil
+			}
+			if !filter.Matches(job) {
+				continue
+			}
+			if err := send(h.encodeJob(job)); err != nil {
+				return err
+			}
+			sent++
+		}
+	}
+	return nil
+}
+
+func (h *GRPCHandler) submitApplication(ctx context.Context, req []byte) ([]byte, error) {
+	msg, err := grpc.Decode(req)
+	if err != nil {
+		return nil, err
+	}
+	answers, err := msg.StringMap(11)
+	if err != nil {
+		return nil, err
+	}
+	request := models.ApplicationRequest{
+		JobID:             msg.String(1),
+		ApplicantName:     msg.String(2),
+		ApplicantEmail:    msg.String(3),
+		Resume:            msg.String(4),
+		CoverLetter:       msg.String(5),
+		Phone:             msg.String(6),
+		LinkedIn:          msg.String(7),
+		Portfolio:         msg.String(8),
+		GitHub:            msg.String(9),
+		WorkAuthorization: msg.String(10),
+		CustomAnswers:     answers,
+		ApplicantID:       msg.String(12),
+	}
+
+	if request.ApplicantID != "" && !fillFromApplicant(h.applicants, &request) {
+		return nil, grpcError(&apiError{status: http.StatusNotFound, code: "applicant_not_found", message: "The specified applicant could not be found."})
+	}
+	var found violations
+	found.addBinding(request)
+	if apiErr := found.err(); apiErr != nil {
+		return nil, grpcError(apiErr)
+	}
+	if apiErr := h.guard.check(ctx, request, false); apiErr != nil {
+		return nil, grpcError(apiErr)
+	}
+	app, apiErr := submitApplication(h.jobStore, h.appStore, request)
+	if apiErr != nil {
+		return nil, grpcError(apiErr)
+	}
+	return encodeApplication(app), nil
+}
+
+func (h *GRPCHandler) getApplication(ctx context.Context, req []byte) ([]byte, error) {
+	msg, err := grpc.Decode(req)
+	if err != nil {
+		return nil, err
+	}
+	app, exists := h.appStore.GetPropagatedByID(msg.String(1))
+	if !exists {
+		return nil, grpcError(&apiError{status: http.StatusNotFound, code: "application_not_found", message: "The specified application could not be found."})
+	}
+	if apiErr := checkOwner(ctx, app); apiErr != nil {
+		return nil, grpcError(apiErr)
+	}
+	return encodeApplication(app), nil
+}
+
+// encodeJob encodes a Job message, in the status the job is in now
+func (h *GRPCHandler) encodeJob(job models.Job) []byte {
+	detail := jobDetail(job, h.appStore)
+	job = detail.Job
+
+	var msg grpc.Encoder
+	msg.String(1, job.ID)
+	msg.String(2, job.Title)
+	msg.String(3, job.Company)
+	msg.String(4, job.Description)
+	msg.Strings(5, job.Requirements)
+	msg.String(6, job.Location)
+	msg.Bool(7, job.IsRemote || job.Remote)
+	msg.String(8, job.Salary)
+	msg.Int(9, int64(job.ExperienceRequired))
+	msg.String(10, job.JobType)
+	msg.String(11, job.PostedAt)
+	msg.String(12, job.ApplicationDeadline)
+	msg.Strings(13, job.Benefits)
+	msg.String(14, job.CompanySize)
+	msg.String(15, job.Industry)
+	msg.String(16, string(job.Status))
+	for _, q := range job.Questions {
+		var question grpc.Encoder
+		question.String(1, q.ID)
+		question.String(2, q.Label)
+		question.String(3, q.Type)
+		question.Bool(4, q.Required)
+		question.Strings(5, q.Options)
+		question.String(6, q.Pattern)
+		msg.Message(17, question.Bytes())
+	}
+	msg.Bool(18, detail.IsAcceptingApps)
+	msg.Int(19, int64(detail.ApplicationsCount))
+	return msg.Bytes()
+}
+
+// encodeApplication encodes an Application message
+func encodeApplication(app *models.Application) []byte {
+	var msg grpc.Encoder
+	msg.String(1, app.ConfirmationID)
+	msg.String(2, app.JobID)
+	msg.String(3, app.JobTitle)
+	msg.String(4, app.Company)
+	msg.String(5, app.ApplicantName)
+	msg.String(6, app.ApplicantEmail)
+	msg.String(7, string(app.Status))
+	msg.String(8, app.SubmittedAt.Format(time.RFC3339))
+	msg.String(9, app.UpdatedAt.Format(time.RFC3339))
+	msg.String(10, app.Notes)
+	for _, change := range app.StatusHistory {
+		var entry grpc.Encoder
+		entry.String(1, string(change.Status))
+		entry.String(2, change.At.Format(time.RFC3339))
+		entry.String(3, change.Notes)
+		entry.String(4, change.Actor)
+		msg.Message(11, entry.Bytes())
+	}
+	msg.OptionalInt(12, matchScore(app))
+	return msg.Bytes()
+}
@@ -0,0 +1,428 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/grpc"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/jwt"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// callGRPC makes a unary call to h with the given metadata, in name and
+// value pairs, and returns the response message and status code
+func callGRPC(t *testing.T, h *GRPCHandler, method string, req []byte, metadata ...string) ([]byte, grpc.Code) {
+	t.Helper()
+	frame := make([]byte, 5, 5+len(req))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(req)))
+	r := httptest.NewRequest("POST", "/"+GRPCService+"/"+method, bytes.NewReader(append(frame, req...)))
+	r.ProtoMajor, r.ProtoMinor = 2, 0
+	r.Header.Set("Content-Type", "application/grpc")
+	for i := 0; i+1 < len(metadata); i += 2 {
+		r.Header.Set(metadata[i], metadata[i+1])
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	code, err := strconv.Atoi(w.Header().Get(http.TrailerPrefix + "Grpc-Status"))
+	if err != nil {
+		t.Fatalf("%s: no grpc-status trailer", method)
+	}
+	body := w.Body.Bytes()
+	if len(body) < 5 {
+		return nil, grpc.Code(code)
+	}
+	return body[5:], grpc.Code(code)
+}
+
+// TestGRPCApplicationOwner checks that with a secret, GetApplication only
+// answers the applicant whose token is sent
+func TestGRPCApplicationOwner(t *testing.T) {
+	jobStore, appStore := newTestStores(t)
+	secret := []byte("test-secret")
+	app, apiErr := submitApplication(jobStore, appStore, testApplication("ann@example.com"))
+	if apiErr != nil {
+		t.Fatalf("submitting: %s", apiErr.message)
+	}
+	token := func(email string) string {
+		signed, err := jwt.Sign(email, secret, time.Now(), time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return "Bearer " + signed
+	}
+	var req grpc.Encoder
+	req.String(1, app.ConfirmationID)
+
+	tests := []struct {
+		name          string
+		secret        []byte
+		authorization string
+		want          grpc.Code
+	}{
+		{"without auth", nil, "", grpc.OK},
+		{"anonymous", secret, "", grpc.Unauthenticated},
+		{"invalid token", secret, "Bearer nonsense", grpc.Unauthenticated},
+		{"another applicant", secret, token("bob@example.com"), grpc.PermissionDenied},
+		{"the applicant", secret, token("ann@example.com"), grpc.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewGRPCHandler(jobStore, appStore, store.NewApplicantStore(), nil, tt.secret)
+			if _, code := callGRPC(t, h, "GetApplication", req.Bytes(), "Authorization", tt.authorization); code != tt.want {
+				t.Errorf("status %d, want %d", code, tt.want)
+			}
+		})
+	}
+}
+
+// TestGRPCSubmitGuard checks that SubmitApplication calls need a solved
+// challenge's token when challenges are on
+func TestGRPCSubmitGuard(t *testing.T) {
+	jobStore, appStore := newTestStores(t)
+	challenges := store.NewChallengeStore()
+	h := NewGRPCHandler(jobStore, appStore, store.NewApplicantStore(), NewSubmitGuard(challenges, false), nil)
+	app := testApplication("ann@example.com")
+	var req grpc.Encoder
+	req.String(1, app.JobID)
+	req.String(2, app.ApplicantName)
+	req.String(3, app.ApplicantEmail)
+	req.String(4, app.Resume)
+
+	if _, code := callGRPC(t, h, "SubmitApplication", req.Bytes()); code != grpc.FailedPrecondition {
+		t.Errorf("without a challenge token: status %d, want %d", code, grpc.FailedPrecondition)
+	}
+	token := challenges.NewToken().Token
+	if _, code := callGRPC(t, h, "SubmitApplication", req.Bytes(), "X-Challenge-Token", token); code != grpc.OK {
+		t.Errorf("with a challenge token: status %d, want %d", code, grpc.OK)
+	}
+	if _, code := callGRPC(t, h, "SubmitApplication", req.Bytes(), "X-Challenge-Token", token); code != grpc.PermissionDenied {
+		t.Errorf("with a used challenge token: status %d, want %d", code, grpc.PermissionDenied)
+	}
+}
+
+// protoPath is the service definition the handler's field numbers follow
+const protoPath = "../../proto/jobportal/v1/jobportal.proto"
+
+// protoField is a field of a message in jobportal.proto
+type protoField struct {
+	num      protowire.Number
+	typ      string // string, bool, int32, map<string, string> or a message name
+	repeated bool
+	optional bool
+}
+
+// protoMessages reads the fields of every message in jobportal.proto, by
+// message and field name
+func protoMessages(t *testing.T) map[string]map[string]protoField {
+	t.Helper()
+	f, err := os.Open(protoPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	messageDecl := regexp.MustCompile(`^message (\w+) \{$`)
+	fieldDecl := regexp.MustCompile(`^\s*(optional |repeated )?(map<string, string>|\w+) (\w+) = (\d+);`)
+	messages := make(map[string]map[string]protoField)
+	var current map[string]protoField
+	lines := bufio.NewScanner(f)
+	for lines.Scan() {
+		line := lines.Text()
+		if m := messageDecl.FindStringSubmatch(line); m != nil {
+			current = make(map[string]protoField)
+			messages[m[1]] = current
+		} else if line == "}" {
+			current = nil
+		} else if m := fieldDecl.FindStringSubmatch(line); m != nil && current != nil {
+			num, _ := strconv.Atoi(m[4])
+			current[m[3]] = protoField{num: protowire.Number(num), typ: m[2], repeated: m[1] == "repeated ", optional: m[1] == "optional "}
+		}
+	}
+	if err := lines.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return messages
+}
+
+// encodeProto encodes message from values by field name, as a client
+// generated from jobportal.proto would
+func encodeProto(t *testing.T, messages map[string]map[string]protoField, message string, values map[string]any) []byte {
+	t.Helper()
+	var msg grpc.Encoder
+	for name, v := range values {
+		field, ok := messages[message][name]
+		if !ok {
+			t.Fatalf("%s has no field %s", message, name)
+		}
+		switch v := v.(type) {
+		case string:
+			msg.String(int(field.num), v)
+		case bool:
+			msg.Bool(int(field.num), v)
+		case int:
+			msg.Int(int(field.num), int64(v))
+		case map[string]string:
+			msg.StringMap(int(field.num), v)
+		default:
+			t.Fatalf("%s.%s: cannot encode %T", message, name, v)
+		}
+	}
+	return msg.Bytes()
+}
+
+// decodeProto decodes message by the layout jobportal.proto gives it,
+// failing on fields it does not declare or sent with another wire type.
+// Scalars read as string, bool, int64, []string or map[string]string;
+// message fields as the [][]byte of each occurrence. Every field the
+// message declares is returned, at its zero value when it was not sent,
+// except optional ones.
+func decodeProto(t *testing.T, messages map[string]map[string]protoField, message string, b []byte) map[string]any {
+	t.Helper()
+	byNum := make(map[protowire.Number]string)
+	got := make(map[string]any)
+	for name, field := range messages[message] {
+		byNum[field.num] = name
+		switch {
+		case field.optional:
+		case field.typ == "map<string, string>":
+			got[name] = map[string]string{}
+		case field.repeated && field.typ == "string":
+			got[name] = []string(nil)
+		case field.typ == "string":
+			got[name] = ""
+		case field.typ == "bool":
+			got[name] = false
+		case field.typ == "int32":
+			got[name] = int64(0)
+		default:
+			got[name] = [][]byte(nil)
+		}
+	}
+
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			t.Fatalf("%s: malformed tag", message)
+		}
+		b = b[n:]
+		name, ok := byNum[num]
+		if !ok {
+			t.Fatalf("%s: field %d is not in %s", message, num, protoPath)
+		}
+		field := messages[message][name]
+		want := protowire.BytesType
+		if field.typ == "bool" || field.typ == "int32" {
+			want = protowire.VarintType
+		}
+		if typ != want {
+			t.Fatalf("%s.%s: wire type %d, want %d", message, name, typ, want)
+		}
+
+		if typ == protowire.VarintType {
+			v, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				t.Fatalf("%s.%s: malformed varint", message, name)
+			}
+			b = b[n:]
+			if field.typ == "bool" {
+				got[name] = protowire.DecodeBool(v)
+			} else {
+				got[name] = int64(int32(v))
+			}
+			continue
+		}
+		payload, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			t.Fatalf("%s.%s: malformed length", message, name)
+		}
+		b = b[n:]
+		switch {
+		case field.typ == "map<string, string>":
+			entry := decodeProto(t, map[string]map[string]protoField{"entry": {
+				"key": {num: 1, typ: "string"}, "value": {num: 2, typ: "string"},
+			}}, "entry", payload)
+			got[name].(map[string]string)[entry["key"].(string)] = entry["value"].(string)
+		case field.repeated && field.typ == "string":
+			got[name] = append(got[name].([]string), string(payload))
+		case field.typ == "string":
+			got[name] = string(payload)
+		default:
+			occurrences, _ := got[name].([][]byte)
+			got[name] = append(occurrences, payload)
+		}
+	}
+	return got
+}
+
+// checkProto decodes message and compares every field but message fields
+// with want, which must name each of them
+func checkProto(t *testing.T, messages map[string]map[string]protoField, message string, b []byte, want map[string]any) map[string]any {
+	t.Helper()
+	got := decodeProto(t, messages, message, b)
+	for name, field := range messages[message] {
+		if _, nested := messages[field.typ]; nested {
+			continue
+		}
+		if _, ok := want[name]; !ok && !field.optional {
+			t.Errorf("%s.%s is not checked", message, name)
+		} else if !reflect.DeepEqual(got[name], want[name]) {
+			t.Errorf("%s.%s = %#v, want %#v", message, name, got[name], want[name])
+		}
+	}
+	return got
+}
+
+// TestGRPCMatchesProto checks that requests encoded as jobportal.proto
+// lays them out are read field for field, and that responses decode the
+// same way, so the handler's field numbers and wire types cannot drift
+// from the service definition
+func TestGRPCMatchesProto(t *testing.T) {
+	messages := protoMessages(t)
+	job := openJob
+	job.ID = "job_proto"
+	job.IsRemote = true
+	job.Benefits = []string{"Remote days", "Training budget"}
+	job.CompanySize = "51-200"
+	job.Industry = "Retail"
+	job.Questions = []models.ScreeningQuestion{
+		{ID: "why_us", Label: "Why Acme?", Type: models.QuestionText, Required: true, Pattern: ".{3,}"},
+		{ID: "team", Label: "Which team?", Type: models.QuestionSelect, Options: []string{"Platform", "Payments"}},
+	}
+	jobStore, err := store.NewJobStore([]models.Job{job}, time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	appStore := store.NewApplicationStore()
+	applicants := store.NewApplicantStore()
+	profile, err := applicants.Create(models.Applicant{Name: "Ann Tester", Email: "ann@example.com", Resume: "Go and SQL."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewGRPCHandler(jobStore, appStore, applicants, nil, nil)
+	call := func(method string, req []byte) []byte {
+		t.Helper()
+		resp, code := callGRPC(t, h, method, req)
+		if code != grpc.OK {
+			t.Fatalf("%s: status %d", method, code)
+		}
+		return resp
+	}
+
+	request := map[string]any{
+		"job_id":             job.ID,
+		"applicant_name":     "Ann Tester",
+		"applicant_email":    "ann@example.com",
+		"resume":             "Ten years of building web services in Go and Python.",
+		"cover_letter":       "I would like to build Acme's storefront.",
+		"phone":              "+1 415 555 0100",
+		"linkedin":           "https://www.linkedin.com/in/ann-tester",
+		"portfolio":          "https://ann.example.com",
+		"github":             "https://github.com/ann-tester",
+		"work_authorization": string(models.WorkAuthCitizen),
+		"custom_answers":     map[string]string{"why_us": "The storefront", "team": "Platform"},
+		"applicant_id":       profile.ID,
+	}
+	for name := range messages["SubmitApplicationRequest"] {
+		if _, ok := request[name]; !ok {
+			t.Errorf("SubmitApplicationRequest.%s is not sent", name)
+		}
+	}
+	submitted := call("SubmitApplication", encodeProto(t, messages, "SubmitApplicationRequest", request))
+	apps := appStore.GetAll(0, store.NewestFirst)
+	if len(apps) != 1 {
+		t.Fatalf("%d applications stored, want 1", len(apps))
+	}
+	app := apps[0]
+	stored := map[string]any{
+		"job_id": app.JobID, "applicant_name": app.ApplicantName, "applicant_email": app.ApplicantEmail,
+		"resume": app.Resume, "cover_letter": app.CoverLetter, "phone": app.Phone, "linkedin": app.LinkedIn,
+		"portfolio": app.Portfolio, "github": app.GitHub, "work_authorization": app.WorkAuthorization,
+		"custom_answers": map[string]string(app.CustomAnswers), "applicant_id": app.ApplicantID,
+	}
+	for name, want := range request {
+		if name == "phone" {
+			continue // Stored normalized
+		}
+		if !reflect.DeepEqual(stored[name], want) {
+			t.Errorf("SubmitApplicationRequest.%s stored as %#v, want %#v", name, stored[name], want)
+		}
+	}
+
+	wantApp := map[string]any{
+		"id": app.ConfirmationID, "job_id": app.JobID, "job_title": app.JobTitle, "company": app.Company,
+		"applicant_name": app.ApplicantName, "applicant_email": app.ApplicantEmail, "status": string(app.Status),
+		"submitted_at": app.SubmittedAt.Format(time.RFC3339), "updated_at": app.UpdatedAt.Format(time.RFC3339),
+		"notes": app.Notes,
+	}
+	if score := matchScore(app); score != nil {
+		wantApp["match_score"] = int64(*score)
+	}
+	fetched := call("GetApplication", encodeProto(t, messages, "GetApplicationRequest", map[string]any{"id": app.ConfirmationID}))
+	for _, resp := range [][]byte{submitted, fetched} {
+		got := checkProto(t, messages, "Application", resp, wantApp)
+		timeline, _ := got["timeline"].([][]byte)
+		if len(timeline) != len(app.StatusHistory) {
+			t.Fatalf("timeline has %d entries, want %d", len(timeline), len(app.StatusHistory))
+		}
+		for i, change := range app.StatusHistory {
+			checkProto(t, messages, "StatusChange", timeline[i], map[string]any{
+				"status": string(change.Status), "at": change.At.Format(time.RFC3339), "notes": change.Notes, "actor": change.Actor,
+			})
+		}
+	}
+
+	current, _ := jobStore.GetByID(job.ID)
+	wantJob := map[string]any{
+		"id": current.ID, "title": current.Title, "company": current.Company, "description": current.Description,
+		"requirements": current.Requirements, "location": current.Location, "is_remote": true, "salary": current.Salary,
+		"experience_required": int64(current.ExperienceRequired), "job_type": current.JobType, "posted_at": current.PostedAt,
+		"application_deadline": current.ApplicationDeadline, "benefits": current.Benefits, "company_size": current.CompanySize,
+		"industry": current.Industry, "status": string(current.Status), "accepting_applications": true, "applications_count": int64(1),
+	}
+	checkJob := func(b []byte) {
+		t.Helper()
+		got := checkProto(t, messages, "Job", b, wantJob)
+		questions, _ := got["questions"].([][]byte)
+		if len(questions) != len(current.Questions) {
+			t.Fatalf("%d questions, want %d", len(questions), len(current.Questions))
+		}
+		for i, q := range current.Questions {
+			checkProto(t, messages, "ScreeningQuestion", questions[i], map[string]any{
+				"id": q.ID, "label": q.Label, "type": q.Type, "required": q.Required, "options": q.Options, "pattern": q.Pattern,
+			})
+		}
+	}
+	checkJob(call("GetJob", encodeProto(t, messages, "GetJobRequest", map[string]any{"id": job.ID})))
+
+	list := map[string]any{"q": "backend", "remote": true, "type": job.JobType, "include_closed": true, "limit": 5}
+	for name := range messages["ListJobsRequest"] {
+		if _, ok := list[name]; !ok {
+			t.Errorf("ListJobsRequest.%s is not sent", name)
+		}
+	}
+	filter, limit, err := jobsRequest(encodeProto(t, messages, "ListJobsRequest", list))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filter.Query != "backend" || filter.Remote == nil || !*filter.Remote || filter.JobType != job.JobType || filter.HideClosed || limit != 5 {
+		t.Errorf("ListJobsRequest read as %+v with limit %d", filter, limit)
+	}
+	got := checkProto(t, messages, "ListJobsResponse", call("ListJobs", encodeProto(t, messages, "ListJobsRequest", list)), map[string]any{"total": int64(1)})
+	if jobs, _ := got["jobs"].([][]byte); len(jobs) != 1 {
+		t.Fatalf("ListJobs returned %d jobs, want 1", len(jobs))
+	} else {
+		checkJob(jobs[0])
+	}
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/emailaddr"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/middleware"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+)
+
+// SubmitGuard makes the checks every transport runs before submitting an
+// application, so none of them is a way around the others: under
+// -apply-wizard that it comes from a draft, under -auth=required that the
+// caller holds the applicant's token, and under -challenge that it carries
+// a solved challenge's token
+type SubmitGuard struct {
+	challenges *store.ChallengeStore
+	wizard     bool
+}
+
+// NewSubmitGuard creates a submit guard redeeming tokens from challenges,
+// which is nil when challenges are off. With wizard, applications can only
+// be submitted through drafts.
+func NewSubmitGuard(challenges *store.ChallengeStore, wizard bool) *SubmitGuard {
+	return &SubmitGuard{challenges: challenges, wizard: wizard}
+}
+
+// check returns why req cannot be submitted by a request with ctx,
+// redeeming the request's challenge token when it can. fromDraft is set
+// for drafts, which the wizard submits. A nil guard only checks the token
+// requests restricted to an applicant carry (see middleware.Applicant).
+func (g *SubmitGuard) check(ctx context.Context, req models.ApplicationRequest, fromDraft bool) *apiError {
+	if g != nil && g.wizard && !fromDraft {
+		return &apiError{status: http.StatusConflict, code: "wizard_required", message: "Applications are submitted step by step. Start a draft with POST /api/applications/draft."}
+	}
+	authed, restricted := middleware.Applicant(ctx)
+	switch {
+	case !restricted:
+	case authed == "":
+		return errLoginRequired
+	case req.ApplicantEmail != "" && emailaddr.Normalize(req.ApplicantEmail) != authed:
+		return &apiError{status: http.StatusForbidden, code: "email_mismatch", message: "applicant_email must be the address the token was issued for."}
+	}
+	if g == nil || g.challenges == nil {
+		return nil
+	}
+
+	attempt := middleware.Challenge(ctx)
+	if attempt == nil {
+		attempt = &middleware.ChallengeAttempt{}
+	}
+	// The token is used up even when the submission then fails validation
+	switch {
+	case attempt.Token == "":
+		attempt.Outcome = models.ChallengeRejected
+		return &apiError{status: http.StatusPreconditionRequired, code: "challenge_required", message: "Solve a challenge from POST /api/challenges and send its token in the " + middleware.ChallengeTokenHeader + " header."}
+	case !g.challenges.Redeem(attempt.Token):
+		attempt.Outcome = models.ChallengeRejected
+		return &apiError{status: http.StatusForbidden, code: "invalid_challenge_token", message: "The challenge token is invalid, expired or already used. Solve a new challenge."}
+	}
+	attempt.Outcome = models.ChallengeAccepted
+	return nil
+}
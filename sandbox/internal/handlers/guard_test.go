@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/middleware"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+)
+
+func TestSubmitGuard(t *testing.T) {
+	challenges := store.NewChallengeStore()
+	used := challenges.NewToken().Token
+	challenges.Redeem(used)
+
+	tests := []struct {
+		name      string
+		guard     *SubmitGuard
+		restrict  bool // Restricts the request to applicant's applications
+		applicant string
+		token     string // Sent when challenges is set
+		fromDraft bool
+		want      string // Error code, or "" to submit
+		outcome   string
+	}{
+		{name: "no guard", guard: nil},
+		{name: "nothing to check", guard: NewSubmitGuard(nil, false)},
+		{name: "outside the wizard", guard: NewSubmitGuard(nil, true), want: "wizard_required"},
+		{name: "from a draft", guard: NewSubmitGuard(nil, true), fromDraft: true},
+		{name: "anonymous", guard: nil, restrict: true, want: "authentication_required"},
+		{name: "as another applicant", guard: nil, restrict: true, applicant: "bob@example.com", want: "email_mismatch"},
+		{name: "as the applicant", guard: nil, restrict: true, applicant: "ann@example.com"},
+		{name: "without a challenge token", guard: NewSubmitGuard(challenges, false), want: "challenge_required", outcome: models.ChallengeRejected},
+		{name: "with a used challenge token", guard: NewSubmitGuard(challenges, false), token: used, want: "invalid_challenge_token", outcome: models.ChallengeRejected},
+		{name: "with a challenge token", guard: NewSubmitGuard(challenges, false), token: challenges.NewToken().Token, outcome: models.ChallengeAccepted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, attempt := middleware.WithChallengeToken(context.Background(), tt.token)
+			if tt.restrict {
+				ctx = middleware.WithApplicant(ctx, tt.applicant)
+			}
+			apiErr := tt.guard.check(ctx, testApplication("ann@example.com"), tt.fromDraft)
+			var got string
+			if apiErr != nil {
+				got = apiErr.code
+			}
+			if got != tt.want || attempt.Outcome != tt.outcome {
+				t.Errorf("got %q with outcome %q, want %q with outcome %q", got, attempt.Outcome, tt.want, tt.outcome)
+			}
+		})
+	}
+}
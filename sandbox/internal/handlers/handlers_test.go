@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/data"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+)
+
+// openJob is a job whose deadline never passes, so submissions to it do
+// not depend on today's date
+var openJob = models.Job{
+	ID:                  "job_open",
+	Title:               "Backend Engineer",
+	Company:             "Acme",
+	Description:         "Build the services behind Acme's storefront.",
+	Requirements:        []string{"Go", "SQL"},
+	Location:            "Berlin, Germany",
+	Salary:              "$120,000 - $150,000",
+	ExperienceRequired:  3,
+	JobType:             "full-time",
+	PostedAt:            "2026-01-15T10:00:00Z",
+	ApplicationDeadline: "2099-12-31T23:59:59Z",
+}
+
+// newTestStores returns a job store holding the seed jobs and openJob,
+// and an empty application store
+func newTestStores(t *testing.T) (*store.JobStore, *store.ApplicationStore) {
+	t.Helper()
+	jobStore, err := store.NewJobStore(append(data.GetSeedJobs(), openJob), time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return jobStore, store.NewApplicationStore()
+}
+
+// testApplication is a valid application to openJob
+func testApplication(email string) models.ApplicationRequest {
+	return models.ApplicationRequest{
+		JobID:          openJob.ID,
+		ApplicantName:  "Vic Tester",
+		ApplicantEmail: email,
+		Resume:         "Ten years of building web services in Go and Python.",
+	}
+}
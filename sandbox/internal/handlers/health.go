@@ -2,9 +2,11 @@ package handlers
 
 import (
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
 	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
 	"github.com/gin-gonic/gin"
 )
@@ -21,15 +23,19 @@ func init() {
 
 // HealthHandler handles health-related endpoints
 type HealthHandler struct {
-	jobStore *store.JobStore
-	appStore *store.ApplicationStore
+	jobStore     *store.JobStore
+	appStore     *store.ApplicationStore
+	webhookStore *store.WebhookStore
+	latencyCache *reviewLatencyCache
 }
 
 // NewHealthHandler creates a new health handler
-func NewHealthHandler(jobStore *store.JobStore, appStore *store.ApplicationStore) *HealthHandler {
+func NewHealthHandler(jobStore *store.JobStore, appStore *store.ApplicationStore, webhookStore *store.WebhookStore) *HealthHandler {
 	return &HealthHandler{
-		jobStore: jobStore,
-		appStore: appStore,
+		jobStore:     jobStore,
+		appStore:     appStore,
+		webhookStore: webhookStore,
+		latencyCache: newReviewLatencyCache(),
 	}
 }
 
@@ -79,32 +85,42 @@ func (h *HealthHandler) LivenessCheck(c *gin.Context) {
 // GetStats handles GET /api/stats
 // Returns statistics about the sandbox
 func (h *HealthHandler) GetStats(c *gin.Context) {
-	jobCount := h.jobStore.GetCount()
-	appCount := h.appStore.GetCount()
-	appStats := h.appStore.GetStats()
+	stats := buildStats(h.jobStore, h.appStore)
+	deliveries := h.webhookStore.AggregateStats()
+	stats.WebhookDeliveries = &deliveries
+	respond.Data(c, http.StatusOK, stats)
+}
 
-	// Get unique companies
-	jobs := h.jobStore.GetAll(0)
-	companySet := make(map[string]bool)
+// buildStats summarises the job and application stores
+func buildStats(jobStore *store.JobStore, appStore *store.ApplicationStore) models.StatsResponse {
+	// The companies with the most jobs, ties by name, so the list is stable
+	jobs := jobStore.GetAll(0)
+	jobCounts := make(map[string]int)
 	for _, job := range jobs {
-		companySet[job.Company] = true
+		jobCounts[job.Company]++
 	}
-	companies := make([]string, 0, len(companySet))
-	for company := range companySet {
+	companies := make([]string, 0, len(jobCounts))
+	for company := range jobCounts {
 		companies = append(companies, company)
 	}
-
-	// Sort by most common (just take first 10)
+	sort.Slice(companies, func(i, j int) bool {
+		if jobCounts[companies[i]] != jobCounts[companies[j]] {
+			return jobCounts[companies[i]] > jobCounts[companies[j]]
+		}
+		return companies[i] < companies[j]
+	})
 	if len(companies) > 10 {
 		companies = companies[:10]
 	}
 
-	c.JSON(http.StatusOK, models.StatsResponse{
-		TotalJobs:            jobCount,
-		TotalApplications:    appCount,
-		ApplicationsByStatus: appStats,
-		TopCompanies:         companies,
-	})
+	return models.StatsResponse{
+		TotalJobs:                       jobStore.GetCount(),
+		TotalApplications:               appStore.GetCount(),
+		ApplicationsByStatus:            appStore.GetStats(),
+		ApplicationsByWorkAuthorization: appStore.GetWorkAuthorizationStats(),
+		TopCompanies:                    companies,
+		ClientDisconnects:               respond.Disconnects(),
+	}
 }
 
 // GetAPIInfo handles GET /api
@@ -119,6 +135,8 @@ func (h *HealthHandler) GetAPIInfo(c *gin.Context) {
 				"list":         "GET /api/jobs",
 				"get":          "GET /api/jobs/:id",
 				"search":       "GET /api/jobs/search?q=<query>",
+				"stream":       "GET /api/jobs/stream",
+				"facets":       "GET /api/jobs/facets",
 				"requirements": "GET /api/jobs/:id/requirements",
 			},
 			"applications": gin.H{
@@ -126,6 +144,7 @@ func (h *HealthHandler) GetAPIInfo(c *gin.Context) {
 				"get":     "GET /api/applications/:id",
 				"list":    "GET /api/applications",
 				"receipt": "GET /api/applications/:id/receipt",
+				"score":   "GET /api/applications/:id/score",
 				"status":  "PATCH /api/applications/:id/status",
 			},
 			"health": gin.H{
@@ -133,13 +152,36 @@ func (h *HealthHandler) GetAPIInfo(c *gin.Context) {
 				"ready":  "GET /ready",
 				"live":   "GET /live",
 			},
-			"stats": "GET /api/stats",
+			"docs": gin.H{
+				"openapi": "GET /api/openapi.json",
+				"ui":      "GET /api/docs",
+			},
+			"graphql": gin.H{
+				"query":  "POST /graphql",
+				"schema": "GET /graphql/schema",
+			},
+			"meta": gin.H{
+				"work_authorizations": "GET /api/meta/work-authorizations",
+			},
+			"runs": gin.H{
+				"create": "POST /api/runs",
+				"report": "GET /api/runs/:id/report",
+			},
+			"usage":          "GET /api/usage (with X-API-Key)",
+			"stats":          "GET /api/stats",
+			"review_latency": "GET /api/stats/review-latency?by=company",
 		},
 		"rate_limits": gin.H{
 			"general":      "100 requests per minute",
 			"applications": "30 requests per minute",
+			"keyed_by":     "X-API-Key when sent, client IP otherwise; -rate-limit-key can key by X-Agent-ID or combinations",
 		},
-		"uptime":    time.Since(StartTime).String(),
-		"timestamp": time.Now().Format(time.RFC3339),
+		"ordering": gin.H{
+			"jobs":         "catalogue order",
+			"applications": "newest first by submitted_at; order=oldest lists them in submission order",
+		},
+		"application_limits": h.appStore.Limits(),
+		"uptime":             time.Since(StartTime).String(),
+		"timestamp":          time.Now().Format(time.RFC3339),
 	})
 }
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/ical"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// InterviewHandler offers shortlisted applicants interview slots on their
+// company's calendar and books the one they pick
+type InterviewHandler struct {
+	appStore       *store.ApplicationStore
+	interviewStore *store.InterviewStore
+}
+
+// NewInterviewHandler creates a new interview handler
+func NewInterviewHandler(appStore *store.ApplicationStore, interviewStore *store.InterviewStore) *InterviewHandler {
+	return &InterviewHandler{appStore: appStore, interviewStore: interviewStore}
+}
+
+// NotifyStatusChange puts slots on the company's calendar when an
+// application is shortlisted, and frees its interview when it moves on. It
+// is registered as an application store status listener.
+func (h *InterviewHandler) NotifyStatusChange(app models.Application, previous models.ApplicationStatus) {
+	switch {
+	case app.Status == models.StatusShortlisted:
+		h.interviewStore.Offer(app.Company, time.Now())
+	case interviewing(previous) && !interviewing(app.Status):
+		h.interviewStore.Cancel(app.ID)
+	}
+}
+
+// interviewing reports whether applications in status may book interviews:
+// shortlisted ones, including those that have handed in their assignment
+func interviewing(status models.ApplicationStatus) bool {
+	return status == models.StatusShortlisted || status == models.StatusAssignmentSubmitted
+}
+
+// GetInterviewSlots handles GET /api/applications/:id/interview-slots
+// Returns the free interview slots of a shortlisted application's company
+// and the interview it has booked, if any
+func (h *InterviewHandler) GetInterviewSlots(c *gin.Context) {
+	app, ok := h.shortlisted(c)
+	if !ok {
+		return
+	}
+
+	response := models.InterviewSlotsResponse{
+		ApplicationID: app.ConfirmationID,
+		Slots:         h.interviewStore.Offer(app.Company, time.Now()),
+	}
+	if interview, exists := h.interviewStore.Get(app.ID); exists {
+		response.Scheduled = &interview
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// ScheduleInterview handles POST /api/applications/:id/schedule
+// Books one of the slots from GET /api/applications/:id/interview-slots,
+// replacing any interview the application already has
+func (h *InterviewHandler) ScheduleInterview(c *gin.Context) {
+	app, ok := h.shortlisted(c)
+	if !ok {
+		return
+	}
+	var req models.ScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respond.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body: "+err.Error())
+		return
+	}
+
+	interview, err := h.interviewStore.Schedule(*app, req.SlotID, time.Now())
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "slot not found"):
+			respond.Error(c, http.StatusNotFound, "slot_not_found", "The specified interview slot could not be found. Pick one from the application's interview-slots.")
+		case strings.Contains(err.Error(), "slot taken"):
+			respond.Error(c, http.StatusConflict, "slot_taken", "This interview slot has been booked by another applicant. Pick another one.")
+		case strings.Contains(err.Error(), "slot passed"):
+			respond.Error(c, http.StatusConflict, "slot_passed", "This interview slot has already started. Pick another one.")
+		case strings.Contains(err.Error(), "schedule conflict"):
+			respond.Error(c, http.StatusConflict, "schedule_conflict", "This interview slot overlaps another interview the applicant has booked.")
+		default:
+			respond.Error(c, http.StatusInternalServerError, "storage_failed", "Failed to schedule interview: "+err.Error())
+		}
+		return
+	}
+	c.JSON(http.StatusOK, interview)
+}
+
+// calendarProdID identifies the sandbox as the writer of its calendars
+const calendarProdID = "-//Job Portal Sandbox//Interviews//EN"
+
+// GetInterviewCalendar handles GET /api/applications/:id/interview.ics
+// Returns the interview booked for the application as an iCalendar file
+func (h *InterviewHandler) GetInterviewCalendar(c *gin.Context) {
+	app, exists := h.appStore.GetPropagatedByID(c.Param("id"))
+	if !exists {
+		respond.Error(c, http.StatusNotFound, "application_not_found", "The specified application could not be found.")
+		return
+	}
+	interview, exists := h.interviewStore.Get(app.ID)
+	if !exists {
+		respond.Error(c, http.StatusNotFound, "interview_not_found", "No interview is booked for this application. Book one with POST /api/applications/:id/schedule.")
+		return
+	}
+
+	writeCalendar(c, "interview-"+app.ConfirmationID, []ical.Event{interviewEvent(c, interview, *app)})
+}
+
+// ExportInterviewCalendar handles GET /api/interviews.ics
+// Returns every booked interview as one iCalendar file, for admins
+func (h *InterviewHandler) ExportInterviewCalendar(c *gin.Context) {
+	var events []ical.Event
+	for _, interview := range h.interviewStore.All() {
+		// Interviews of applications deleted since are left out
+		if app, exists := h.appStore.GetByID(interview.ApplicationID); exists {
+			events = append(events, interviewEvent(c, interview, *app))
+		}
+	}
+	writeCalendar(c, "interviews", events)
+}
+
+// interviewEvent describes an interview as a calendar event, linking to
+// the application's page
+func interviewEvent(c *gin.Context, interview models.Interview, app models.Application) ical.Event {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	return ical.Event{
+		UID:     "interview-" + app.ConfirmationID + "@job-portal-sandbox",
+		Stamp:   interview.ScheduledAt,
+		Start:   interview.Slot.StartsAt,
+		End:     interview.Slot.EndsAt,
+		Summary: "Interview: " + app.JobTitle + " at " + app.Company,
+		Description: fmt.Sprintf("%s meets %s (%s).\nConfirmation code %s.",
+			app.ApplicantName, interview.Slot.Interviewer, interview.Slot.Format, interview.ConfirmationCode),
+		URL: scheme + "://" + c.Request.Host + "/applications/" + app.ConfirmationID,
+	}
+}
+
+// writeCalendar sends events as an iCalendar attachment named name.ics
+func writeCalendar(c *gin.Context, name string, events []ical.Event) {
+	c.Header("Content-Disposition", `attachment; filename="`+name+`.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", ical.Marshal(calendarProdID, events))
+}
+
+// shortlisted looks up the application in the path, answering 404 when it
+// does not exist and 409 when it is not shortlisted
+func (h *InterviewHandler) shortlisted(c *gin.Context) (*models.Application, bool) {
+	app, exists := h.appStore.GetPropagatedByID(c.Param("id"))
+	if !exists {
+		respond.Error(c, http.StatusNotFound, "application_not_found", "The specified application could not be found.")
+		return nil, false
+	}
+	if !interviewing(app.Status) {
+		respond.Error(c, http.StatusConflict, "not_shortlisted", "Interviews are only scheduled for shortlisted applications.")
+		return nil, false
+	}
+	return app, true
+}
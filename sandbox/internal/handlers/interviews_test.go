@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// calendarLines checks body is CRLF-terminated iCalendar folded at 75
+// octets, and returns its unfolded content lines
+func calendarLines(t *testing.T, body string) []string {
+	t.Helper()
+	if !strings.HasSuffix(body, "\r\n") || strings.Count(body, "\n") != strings.Count(body, "\r\n") {
+		t.Fatalf("lines do not all end in CRLF:\n%q", body)
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(body, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line of %d octets: %q", len(line), line)
+		}
+	}
+	unfolded := strings.ReplaceAll(body, "\r\n ", "")
+	return strings.Split(strings.TrimSuffix(unfolded, "\r\n"), "\r\n")
+}
+
+// calendarProperties returns the values of the content lines named name
+func calendarProperties(lines []string, name string) []string {
+	var values []string
+	for _, line := range lines {
+		if value, ok := strings.CutPrefix(line, name+":"); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func TestInterviewCalendar(t *testing.T) {
+	job := openJob
+	job.Title = "Senior Backend Engineer, Payments; Platform and Infrastructure"
+	job.Company = "Acme, Inc."
+	jobStore, err := store.NewJobStore([]models.Job{job}, time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	appStore := store.NewApplicationStore()
+	interviewStore := store.NewInterviewStore()
+	h := NewInterviewHandler(appStore, interviewStore)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/api/applications/:id/interview.ics", h.GetInterviewCalendar)
+	r.GET("/api/interviews.ics", h.ExportInterviewCalendar)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	app, apiErr := submitApplication(jobStore, appStore, testApplication("vic@example.com"))
+	if apiErr != nil {
+		t.Fatalf("submitting: %s", apiErr.message)
+	}
+	path := "/api/applications/" + app.ConfirmationID + "/interview.ics"
+	if w := get(path); w.Code != http.StatusNotFound || !strings.Contains(w.Body.String(), "interview_not_found") {
+		t.Errorf("without an interview: status %d: %s, want 404 interview_not_found", w.Code, w.Body.String())
+	}
+	if w := get("/api/applications/CONF-missing/interview.ics"); w.Code != http.StatusNotFound || !strings.Contains(w.Body.String(), "application_not_found") {
+		t.Errorf("unknown application: status %d: %s, want 404 application_not_found", w.Code, w.Body.String())
+	}
+	if lines := calendarLines(t, get("/api/interviews.ics").Body.String()); len(calendarProperties(lines, "BEGIN")) != 1 {
+		t.Errorf("calendar without interviews %q, want only a VCALENDAR", lines)
+	}
+
+	for _, status := range []models.ApplicationStatus{models.StatusReviewing, models.StatusShortlisted} {
+		if _, err := appStore.UpdateStatus(app.ID, status, "", models.ActorAPI); err != nil {
+			t.Fatal(err)
+		}
+	}
+	slot := interviewStore.Offer(job.Company, time.Now())[0]
+	interview, err := interviewStore.Schedule(*app, slot.ID, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := get(path)
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "text/calendar; charset=utf-8" {
+		t.Fatalf("status %d, Content-Type %q: %s", w.Code, w.Header().Get("Content-Type"), w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "\r\n ") {
+		t.Errorf("no line is folded:\n%s", w.Body.String())
+	}
+	lines := calendarLines(t, w.Body.String())
+	want := map[string]string{
+		"BEGIN":   "VCALENDAR",
+		"VERSION": "2.0",
+		"UID":     "interview-" + app.ConfirmationID + "@job-portal-sandbox",
+		"DTSTART": slot.StartsAt.UTC().Format("20060102T150405Z"),
+		"DTEND":   slot.EndsAt.UTC().Format("20060102T150405Z"),
+		"SUMMARY": `Interview: Senior Backend Engineer\, Payments\; Platform and Infrastructure at Acme\, Inc.`,
+		"URL":     "http://example.com/applications/" + app.ConfirmationID,
+	}
+	for name, value := range want {
+		if got := calendarProperties(lines, name); len(got) == 0 || got[0] != value {
+			t.Errorf("%s: %q, want %q", name, got, value)
+		}
+	}
+	description := calendarProperties(lines, "DESCRIPTION")
+	if len(description) != 1 || !strings.Contains(description[0], interview.ConfirmationCode) || !strings.Contains(description[0], `\n`) {
+		t.Errorf("DESCRIPTION: %q, want the confirmation code and an escaped newline", description)
+	}
+
+	// The admin calendar holds the same event
+	all := calendarLines(t, get("/api/interviews.ics").Body.String())
+	if uids := calendarProperties(all, "UID"); len(uids) != 1 || uids[0] != want["UID"] {
+		t.Errorf("admin calendar UIDs %q, want %q", uids, want["UID"])
+	}
+}
@@ -1,11 +1,18 @@
 package handlers
 
 import (
+	"encoding/json"
+	"fmt"
+	"math"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/openapi"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
 	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
 	"github.com/gin-gonic/gin"
 )
@@ -28,35 +35,188 @@ func NewJobHandler(jobStore *store.JobStore, appStore *store.ApplicationStore) *
 // Returns a list of all available jobs with optional filtering
 func (h *JobHandler) ListJobs(c *gin.Context) {
 	// Parse query parameters
-	limitStr := c.DefaultQuery("limit", "100")
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit < 0 {
-		limit = 100
+	params := newQueryParams(c)
+	pg := params.page(jobsList, 100)
+	filter := params.jobFilter()
+	if !params.check() {
+		return
+	}
+	if respond.IsJSONAPI(c) {
+		// JSON:API clients paginate with page[number] and page[size] instead
+		pg = page{}
 	}
 
-	query := c.Query("q")
-	remote := c.Query("remote")
-	jobType := c.Query("type")
+	matches := h.jobStore.Filter(filter, 0)
+	jobs, next := paginate(c, pg, matches, jobsList, jobCursorID, h.jobStore.Position, false)
+
+	// Return response in format expected by backend
+	respond.List(c, http.StatusOK, models.JobsResponse{
+		Jobs:       jobs,
+		Total:      len(matches),
+		Limit:      pg.limit,
+		NextCursor: next,
+	}, jobs)
+}
+
+// jobsList names the job lists in cursors; they all walk the catalogue
+// order, so a cursor from one is valid for the others
+const jobsList = "jobs"
 
-	var jobs []models.Job
+// jobSearchList names ranked job search results in cursors
+const jobSearchList = "job_search"
 
-	// Apply filters
-	if query != "" {
-		jobs = h.jobStore.Search(query, limit)
-	} else if remote == "true" {
-		jobs = h.jobStore.FilterByRemote(limit)
-	} else if jobType != "" {
-		jobs = h.jobStore.FilterByJobType(jobType, limit)
-	} else {
-		jobs = h.jobStore.GetAll(limit)
+// jobCursorID names a job in cursors
+func jobCursorID(job models.Job) string {
+	return job.ID
+}
+
+// streamBatchSize is how many jobs StreamJobs fetches from the store at a time
+const streamBatchSize = 100
+
+// StreamJobs handles GET /api/jobs/stream
+// Streams the whole catalogue as NDJSON, one job per line. As an export
+// endpoint it is unbounded by default and takes any ?limit=.
+func (h *JobHandler) StreamJobs(c *gin.Context) {
+	params := newQueryParams(c)
+	filter := params.jobFilter()
+	limit := params.exportLimit()
+	if !params.check() {
+		return
 	}
+	ids := h.jobStore.SnapshotIDs()
+	ctx := c.Request.Context()
 
-	// Return response in format expected by backend
-	c.JSON(http.StatusOK, models.JobsResponse{
-		Jobs:  jobs,
-		Total: h.jobStore.GetCount(),
-		Limit: limit,
+	// Count matches first so the total can be sent before the body
+	total := 0
+	for start := 0; start < len(ids); start += streamBatchSize {
+		for _, job := range h.jobStore.GetBatch(ids[start:min(start+streamBatchSize, len(ids))]) {
+			if filter.Matches(job) {
+				total++
+			}
+		}
+	}
+	remaining := total
+	if limit > 0 {
+		remaining = min(limit, total)
+	}
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Header("X-Total-Count", strconv.Itoa(total))
+	c.Status(http.StatusOK)
+
+	encoder := json.NewEncoder(c.Writer)
+	for start := 0; start < len(ids); start += streamBatchSize {
+		select {
+		case <-ctx.Done():
+			// Client disconnected, stop writing to the dead connection
+			respond.Disconnected(c)
+			return
+		default:
+		}
+
+		for _, job := range h.jobStore.GetBatch(ids[start:min(start+streamBatchSize, len(ids))]) {
+			if remaining == 0 {
+				break
+			}
+			if !filter.Matches(job) {
+				continue
+			}
+			if err := encoder.Encode(job); err != nil {
+				respond.Disconnected(c)
+				return
+			}
+			remaining--
+		}
+		c.Writer.Flush()
+		if remaining == 0 {
+			return
+		}
+	}
+}
+
+// GetJobFacets handles GET /api/jobs/facets
+// Counts the jobs matching the listing filters by each facet, so agents
+// can plan without downloading the catalogue. ?limit= caps how many values
+// each facet lists.
+func (h *JobHandler) GetJobFacets(c *gin.Context) {
+	params := newQueryParams(c)
+	limit := params.limit(100)
+	filter := params.jobFilter()
+	if !params.check() {
+		return
+	}
+
+	respond.Data(c, http.StatusOK, jobFacets(h.jobStore.Filter(filter, 0), limit))
+}
+
+// experienceBuckets group the years of experience jobs require, each
+// running up to and including its upper bound
+var experienceBuckets = []struct {
+	name string
+	upTo int
+}{
+	{"0", 0},
+	{"1-2", 2},
+	{"3-5", 5},
+	{"6-9", 9},
+	{"10+", math.MaxInt},
+}
+
+// jobFacets counts jobs by facet, listing at most limit values of each
+func jobFacets(jobs []models.Job, limit int) models.JobFacetsResponse {
+	companies := make(map[string]int)
+	jobTypes := make(map[string]int)
+	locations := make(map[string]int)
+	remote := []models.FacetCount{{Value: "remote"}, {Value: "onsite"}}
+	experience := make([]models.FacetCount, len(experienceBuckets))
+	for i, bucket := range experienceBuckets {
+		experience[i].Value = bucket.name
+	}
+
+	for _, job := range jobs {
+		companies[job.Company]++
+		jobTypes[job.JobType]++
+		locations[job.Location]++
+		if job.IsRemote || job.Remote {
+			remote[0].Count++
+		} else {
+			remote[1].Count++
+		}
+		for i, bucket := range experienceBuckets {
+			if job.ExperienceRequired <= bucket.upTo {
+				experience[i].Count++
+				break
+			}
+		}
+	}
+
+	return models.JobFacetsResponse{
+		Total:      len(jobs),
+		Companies:  topFacets(companies, limit),
+		JobTypes:   topFacets(jobTypes, limit),
+		Locations:  topFacets(locations, limit),
+		Remote:     remote,
+		Experience: experience,
+	}
+}
+
+// topFacets lists up to limit values by count, most common first and
+// alphabetically among equals
+func topFacets(counts map[string]int, limit int) []models.FacetCount {
+	facets := make([]models.FacetCount, 0, len(counts))
+	for value, count := range counts {
+		facets = append(facets, models.FacetCount{Value: value, Count: count})
+	}
+	slices.SortFunc(facets, func(a, b models.FacetCount) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return strings.Compare(a.Value, b.Value)
 	})
+	if limit > 0 && len(facets) > limit {
+		facets = facets[:limit]
+	}
+	return facets
 }
 
 // GetJob handles GET /api/jobs/:id
@@ -66,58 +226,97 @@ func (h *JobHandler) GetJob(c *gin.Context) {
 
 	job, exists := h.jobStore.GetByID(jobID)
 	if !exists {
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "job_not_found",
-			Message: "The requested job could not be found.",
-			Code:    404,
-		})
+		respond.Error(c, http.StatusNotFound, "job_not_found", "The requested job could not be found.")
 		return
 	}
 
-	// Get application count for this job
-	appCount := h.appStore.GetCountByJobID(jobID)
-
-	// Check if job is still accepting applications
-	isAccepting := true
-	if job.ApplicationDeadline != "" {
-		deadline, err := time.Parse(time.RFC3339, job.ApplicationDeadline)
-		if err == nil && time.Now().After(deadline) {
-			isAccepting = false
-		}
-	}
+	markTrap(c, job)
+	respond.Data(c, http.StatusOK, jobDetail(job, h.appStore))
+}
 
-	c.JSON(http.StatusOK, models.JobDetailResponse{
+// jobDetail reports a job, in the status it is in now, with its
+// applications and whether it still takes more
+func jobDetail(job models.Job, appStore *store.ApplicationStore) models.JobDetailResponse {
+	appCount := appStore.GetCountByJobID(job.ID)
+	job.Status = jobStatus(job, appStore)
+	detail := models.JobDetailResponse{
 		Job:               job,
 		ApplicationsCount: appCount,
-		IsAcceptingApps:   isAccepting,
-	})
+		IsAcceptingApps:   job.Status == models.JobOpen,
+		IsFilled:          job.Status == models.JobFilled,
+	}
+	if job.MaxApplications > 0 {
+		remaining := max(job.MaxApplications-appStore.GetActiveCountByJobID(job.ID), 0)
+		detail.RemainingSlots = &remaining
+	}
+	return detail
+}
+
+// jobStatus is the status a job is in now. The lifecycle worker records
+// deadlines passing and caps being reached within a second; this reports
+// them in the meantime.
+func jobStatus(job models.Job, appStore *store.ApplicationStore) models.JobStatus {
+	if job.Status != models.JobOpen && job.Status != models.JobPaused {
+		return job.Status
+	}
+	switch {
+	case deadlinePassed(job):
+		return models.JobClosed
+	case job.Status == models.JobOpen && isFilled(job, appStore):
+		return models.JobFilled
+	}
+	return job.Status
+}
+
+// isAcceptingApplications reports whether the job is open now
+func isAcceptingApplications(job models.Job, appStore *store.ApplicationStore) bool {
+	return jobStatus(job, appStore) == models.JobOpen
+}
+
+// deadlinePassed reports whether the job's application deadline has passed
+func deadlinePassed(job models.Job) bool {
+	return !job.Deadline.IsZero() && time.Now().After(job.Deadline)
+}
+
+// isFilled reports whether a job with max_applications has taken that many,
+// not counting withdrawn applications
+func isFilled(job models.Job, appStore *store.ApplicationStore) bool {
+	return job.MaxApplications > 0 && appStore.GetActiveCountByJobID(job.ID) >= job.MaxApplications
 }
 
 // SearchJobs handles GET /api/jobs/search
-// Performs a search across jobs
+// Performs a search across jobs, returning the most relevant first
 func (h *JobHandler) SearchJobs(c *gin.Context) {
 	query := c.Query("q")
 	if query == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "missing_query",
-			Message: "Search query 'q' is required.",
-			Code:    400,
-		})
+		respond.Error(c, http.StatusBadRequest, "missing_query", "Search query 'q' is required.")
 		return
 	}
 
-	limitStr := c.DefaultQuery("limit", "50")
-	limit, _ := strconv.Atoi(limitStr)
-	if limit <= 0 {
-		limit = 50
+	params := newQueryParams(c)
+	pg := params.page(jobSearchList, 50)
+	filter := store.JobFilter{HideClosed: !params.includeClosed()}
+	if !params.check() {
+		return
 	}
 
-	jobs := h.jobStore.Search(query, limit)
+	// Results are ranked, so cursors walk the ranking rather than the catalogue
+	matches := slices.DeleteFunc(h.jobStore.Search(query, 0), func(job models.Job) bool { return !filter.Matches(job) })
+	ranks := make(map[string]uint64, len(matches))
+	for i, job := range matches {
+		ranks[job.ID] = uint64(i + 1)
+	}
+	rank := func(id string) (uint64, bool) {
+		r, exists := ranks[id]
+		return r, exists
+	}
+	jobs, next := paginate(c, pg, matches, jobSearchList, jobCursorID, rank, false)
 
-	c.JSON(http.StatusOK, gin.H{
-		"jobs":  jobs,
-		"total": len(jobs),
-		"query": query,
+	respond.Data(c, http.StatusOK, models.JobSearchResponse{
+		Jobs:       jobs,
+		Total:      len(jobs),
+		Query:      query,
+		NextCursor: next,
 	})
 }
 
@@ -128,11 +327,7 @@ func (h *JobHandler) GetJobRequirements(c *gin.Context) {
 
 	job, exists := h.jobStore.GetByID(jobID)
 	if !exists {
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "job_not_found",
-			Message: "The requested job could not be found.",
-			Code:    404,
-		})
+		respond.Error(c, http.StatusNotFound, "job_not_found", "The requested job could not be found.")
 		return
 	}
 
@@ -144,55 +339,136 @@ func (h *JobHandler) GetJobRequirements(c *gin.Context) {
 	})
 }
 
-// GetJobsByCompany handles GET /api/companies/:company/jobs
-// Returns all jobs from a specific company
-func (h *JobHandler) GetJobsByCompany(c *gin.Context) {
-	company := c.Param("company")
+// jsonSchemaDialect is the JSON Schema draft of generated application schemas
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
 
-	limitStr := c.DefaultQuery("limit", "50")
-	limit, _ := strconv.Atoi(limitStr)
+// applicationSchema is a standalone JSON Schema document
+type applicationSchema struct {
+	Dialect string `json:"$schema"`
+	ID      string `json:"$id"`
+	Title   string `json:"title"`
+	*openapi.Schema
+}
 
-	jobs := h.jobStore.Search(company, limit)
+// GetApplicationSchema handles GET /api/jobs/:id/application-schema
+// Returns a JSON Schema for the POST /api/applications body for this job
+func (h *JobHandler) GetApplicationSchema(c *gin.Context) {
+	job, exists := h.jobStore.GetByID(c.Param("id"))
+	if !exists {
+		respond.Error(c, http.StatusNotFound, "job_not_found", "The requested job could not be found.")
+		return
+	}
 
-	// Filter to only include jobs from this company
-	filtered := make([]models.Job, 0)
-	for _, job := range jobs {
-		if containsIgnoreCase(job.Company, company) {
-			filtered = append(filtered, job)
+	c.Header("Content-Type", "application/schema+json")
+	c.JSON(http.StatusOK, buildApplicationSchema(job, h.appStore.Limits(), respond.RequestPath(c, nil)))
+}
+
+// buildApplicationSchema derives the schema from the binding tags that
+// validate ApplicationRequest and the checks in submitApplication, so the
+// two cannot drift apart
+func buildApplicationSchema(job models.Job, limits models.ApplicationLimits, id string) applicationSchema {
+	schema := openapi.JSONSchema(models.ApplicationRequest{})
+
+	schema.Properties["job_id"].Enum = []string{job.ID}
+	schema.Properties["applicant_email"].Format = "email"
+	schema.Properties["custom_answers"].Description = "Answers to job-specific questions, keyed by question"
+
+	// JSON Schema counts string length in code points, as the server does
+	setMaxLength(schema.Properties["applicant_name"], limits.ApplicantName)
+	setMaxLength(schema.Properties["resume"], limits.Resume)
+	setMaxLength(schema.Properties["cover_letter"], limits.CoverLetter)
+	setMaxLength(schema.Properties["custom_answers"].AdditionalProperties, limits.CustomAnswer)
+	if limits.CustomAnswersTotal > 0 {
+		schema.Properties["custom_answers"].Description += fmt.Sprintf("; at most %d characters in total", limits.CustomAnswersTotal)
+	}
+
+	answers := schema.Properties["custom_answers"]
+	for _, q := range job.Questions {
+		if answers.Properties == nil {
+			answers.Properties = make(map[string]*openapi.Schema)
 		}
+		// The pattern accepts exactly the answers the server does; blank
+		// answers leave optional questions unanswered, and required ones
+		// must say something
+		property := &openapi.Schema{Type: "string", Description: q.Label, Pattern: q.AnswerPattern()}
+		switch q.Type {
+		case models.QuestionNumber:
+			property.Description += " (a number)"
+		case models.QuestionBoolean:
+			property.Description += " (true or false)"
+		case models.QuestionSelect:
+			if q.Required {
+				property.Enum = q.Options
+			}
+		case models.QuestionMultiSelect:
+			property.Description += " (one or more of " + strings.Join(q.Options, ", ") + ", joined with commas)"
+		}
+		switch {
+		case !q.Required && property.Pattern != "":
+			property.Pattern += `|^[ \t\n\r]*$`
+		case q.Required && property.Pattern == "":
+			property.Pattern = `[^ \t\n\r]`
+		}
+		setMaxLength(property, limits.CustomAnswer)
+		answers.Properties[q.ID] = property
+		if q.Required {
+			answers.Required = append(answers.Required, q.ID)
+		}
+	}
+	if len(answers.Required) > 0 {
+		schema.Required = append(schema.Required, "custom_answers")
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"company": company,
-		"jobs":    filtered,
-		"total":   len(filtered),
-	})
-}
+	schema.Description = "Application to " + job.Title + " at " + job.Company + "."
+	if len(job.AcceptedWorkAuthorizations) > 0 {
+		accepted := make([]string, len(job.AcceptedWorkAuthorizations))
+		for i, value := range job.AcceptedWorkAuthorizations {
+			accepted[i] = string(value)
+		}
+		schema.Description += " Only work authorizations " + strings.Join(accepted, ", ") + " are accepted; applications stating another are rejected."
+	}
+	switch {
+	case deadlinePassed(job):
+		schema.Description += " The application deadline has passed, so submissions are rejected with deadline_passed."
+	case job.Status != models.JobOpen:
+		schema.Description += " The job is " + string(job.Status) + ", so submissions are rejected with job_" + string(job.Status) + "."
+	}
+	if job.MaxApplications > 0 {
+		schema.Description += fmt.Sprintf(" The job takes %d applications; once they are in, submissions are rejected with job_filled.", job.MaxApplications)
+	}
 
-// containsIgnoreCase helper function
-func containsIgnoreCase(s, substr string) bool {
-	return len(s) >= len(substr) &&
-		contains(toLower(s), toLower(substr))
+	return applicationSchema{
+		Dialect: jsonSchemaDialect,
+		ID:      id,
+		Title:   "Application for " + job.ID,
+		Schema:  schema,
+	}
 }
 
-func toLower(s string) string {
-	result := make([]byte, len(s))
-	for i := 0; i < len(s); i++ {
-		c := s[i]
-		if c >= 'A' && c <= 'Z' {
-			result[i] = c + 32
-		} else {
-			result[i] = c
-		}
+// setMaxLength caps a string schema at limit characters; zero means no limit
+func setMaxLength(schema *openapi.Schema, limit int) {
+	if schema != nil && limit > 0 {
+		schema.MaxLength = &limit
 	}
-	return string(result)
 }
 
-func contains(s, substr string) bool {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
-		}
+// GetJobsByCompany handles GET /api/companies/:company/jobs
+// Returns all jobs from a specific company
+func (h *JobHandler) GetJobsByCompany(c *gin.Context) {
+	company := c.Param("company")
+
+	params := newQueryParams(c)
+	limit := params.limit(50)
+	filter := store.JobFilter{Company: company, HideClosed: !params.includeClosed()}
+	if !params.check() {
+		return
 	}
-	return false
+
+	jobs := h.jobStore.Filter(filter, limit)
+
+	c.JSON(http.StatusOK, gin.H{
+		"company": company,
+		"jobs":    jobs,
+		"total":   len(jobs),
+	})
 }
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// TestListJobsTotal checks that the body total and X-Total-Count both
+// count the filtered jobs, not the catalogue
+func TestListJobsTotal(t *testing.T) {
+	jobStore, appStore := newTestStores(t)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/api/jobs", NewJobHandler(jobStore, appStore).ListJobs)
+
+	remote := 0
+	for _, job := range jobStore.GetAll(0) {
+		if job.IsRemote {
+			remote++
+		}
+	}
+	if remote < 3 || remote == jobStore.GetCount() {
+		t.Fatalf("%d of %d jobs are remote; the filter must select some but not all", remote, jobStore.GetCount())
+	}
+
+	for _, path := range []string{
+		"/api/jobs?remote=true&include_closed=true&limit=2",
+		"/api/jobs?remote=true&include_closed=true&limit=2&offset=2",
+	} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: status %d", path, w.Code)
+		}
+		var resp models.JobsResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		if resp.Total != remote {
+			t.Errorf("%s: total %d, want the %d matching jobs", path, resp.Total, remote)
+		}
+		if got := w.Header().Get("X-Total-Count"); got != strconv.Itoa(remote) {
+			t.Errorf("%s: X-Total-Count %s, want %d", path, got, remote)
+		}
+		if len(resp.Jobs) != 2 {
+			t.Errorf("%s: %d jobs on the page, want 2", path, len(resp.Jobs))
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/emulate"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// LeverHandler emulates the Lever postings API
+type LeverHandler struct {
+	jobStore *store.JobStore
+	appStore *store.ApplicationStore
+	guard    *SubmitGuard
+}
+
+// NewLeverHandler creates a new Lever emulation handler
+func NewLeverHandler(jobStore *store.JobStore, appStore *store.ApplicationStore, guard *SubmitGuard) *LeverHandler {
+	return &LeverHandler{
+		jobStore: jobStore,
+		appStore: appStore,
+		guard:    guard,
+	}
+}
+
+// ListPostings handles GET /v0/postings/:site
+// Returns the open postings of the company whose site name matches
+func (h *LeverHandler) ListPostings(c *gin.Context) {
+	jobs, ok := boardJobs(h.jobStore, c.Param("site"))
+	if !ok {
+		leverError(c, http.StatusNotFound, "Document not found")
+		return
+	}
+
+	team := c.Query("team")
+	location := c.Query("location")
+	commitment := c.Query("commitment")
+	skip, _ := strconv.Atoi(c.Query("skip"))
+	limit, err := strconv.Atoi(c.Query("limit"))
+	if err != nil || limit <= 0 {
+		limit = len(jobs)
+	}
+
+	postings := make([]emulate.LeverPosting, 0, len(jobs))
+	for _, job := range jobs {
+		if !isAcceptingApplications(job, h.appStore) {
+			continue
+		}
+		posting := emulate.LeverPostingFromModel(job, jobPageURL(c, job))
+		if (team != "" && posting.Categories.Team != team) ||
+			(location != "" && posting.Categories.Location != location) ||
+			(commitment != "" && posting.Categories.Commitment != commitment) {
+			continue
+		}
+		postings = append(postings, posting)
+	}
+
+	if skip > len(postings) {
+		skip = len(postings)
+	}
+	postings = postings[skip:]
+	if limit < len(postings) {
+		postings = postings[:limit]
+	}
+
+	c.JSON(http.StatusOK, postings)
+}
+
+// GetPosting handles GET /v0/postings/:site/:id
+// Returns a single open posting
+func (h *LeverHandler) GetPosting(c *gin.Context) {
+	job, ok := h.findJob(c)
+	if !ok || !isAcceptingApplications(job, h.appStore) {
+		leverError(c, http.StatusNotFound, "Document not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, emulate.LeverPostingFromModel(job, jobPageURL(c, job)))
+}
+
+// Apply handles POST /v0/postings/:site/:id/apply
+// Accepts a multipart or urlencoded application form
+func (h *LeverHandler) Apply(c *gin.Context) {
+	job, ok := h.findJob(c)
+	if !ok {
+		leverError(c, http.StatusNotFound, "Document not found")
+		return
+	}
+
+	fields, err := formFields(c)
+	if err != nil {
+		leverError(c, http.StatusBadRequest, "Invalid application form: "+err.Error())
+		return
+	}
+
+	req := emulate.LeverApplicationRequest(job, fields)
+	if apiErr := h.guard.check(c.Request.Context(), req, false); apiErr != nil {
+		leverError(c, apiErr.status, apiErr.message)
+		return
+	}
+	app, apiErr := submitApplication(h.jobStore, h.appStore, req)
+	if apiErr != nil {
+		leverError(c, apiErr.status, apiErr.detail())
+		return
+	}
+
+	markSubmitted(c, app)
+	c.JSON(http.StatusOK, emulate.LeverApplyResponse{OK: true, ApplicationID: app.ID})
+}
+
+// findJob resolves the :site and :id parameters to a sandbox job
+func (h *LeverHandler) findJob(c *gin.Context) (models.Job, bool) {
+	jobs, _ := boardJobs(h.jobStore, c.Param("site"))
+	for _, job := range jobs {
+		if emulate.LeverPostingID(job) == c.Param("id") {
+			return job, true
+		}
+	}
+	return models.Job{}, false
+}
+
+// leverError writes an error in the Lever postings API format
+func leverError(c *gin.Context, status int, message string) {
+	c.AbortWithStatusJSON(status, emulate.LeverError{OK: false, Error: message})
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/mail"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// mailSender is the address simulated emails come from, shown as the
+// recruiting team of the job's company
+const mailSender = "no-reply@careers.sandbox.example"
+
+// statusEmails are the subject and opening line of the email sent when an
+// application moves to a status
+var statusEmails = map[models.ApplicationStatus]struct{ subject, text string }{
+	models.StatusReviewing:   {"Your application for %s at %s is under review", "Our hiring team has started reviewing your application."},
+	models.StatusShortlisted: {"Good news about your application for %s at %s", "We are pleased to let you know that you have been shortlisted. We will be in touch about next steps."},
+	models.StatusRejected:    {"Update on your application for %s at %s", "After careful consideration, we have decided not to move forward with your application. We appreciate your interest and wish you the best in your search."},
+	models.StatusWithdrawn:   {"You have withdrawn your application for %s at %s", "Your application has been withdrawn as you asked. You are welcome to apply again."},
+
+	models.StatusAssignmentSubmitted: {"We received your assignment for %s at %s", "Thank you for submitting your take-home assignment. Our hiring team will review it together with your application."},
+}
+
+// MailboxHandler simulates the emails applicants receive about their
+// applications and lets agents read them
+type MailboxHandler struct {
+	mailStore *store.MailStore
+}
+
+// NewMailboxHandler creates a new mailbox handler
+func NewMailboxHandler(mailStore *store.MailStore) *MailboxHandler {
+	return &MailboxHandler{mailStore: mailStore}
+}
+
+// NotifySubmission sends a confirmation email, or a verification code for
+// applications pending verification. It is registered as an application
+// store submit listener.
+func (h *MailboxHandler) NotifySubmission(app models.Application) {
+	if app.Status == models.StatusPendingVerification {
+		h.mailStore.Deliver(models.Email{
+			Kind:    models.EmailVerification,
+			From:    mailFrom(app),
+			To:      app.ApplicantEmail,
+			Subject: fmt.Sprintf("Verify your email to complete your application for %s at %s", app.JobTitle, app.Company),
+			Body: mailBody(app, fmt.Sprintf("Please verify your email address to complete your application.\n\nVerification code: %s\n\nSend it as {\"token\": \"%s\"} to POST /api/applications/%s/verify.",
+				app.VerificationToken, app.VerificationToken, app.ConfirmationID)),
+			ApplicationID: app.ConfirmationID,
+			JobID:         app.JobID,
+			Status:        app.Status,
+		})
+		return
+	}
+	h.sendConfirmation(app)
+}
+
+// sendConfirmation emails the applicant that app has been received
+func (h *MailboxHandler) sendConfirmation(app models.Application) {
+	h.mailStore.Deliver(models.Email{
+		Kind:    models.EmailConfirmation,
+		From:    mailFrom(app),
+		To:      app.ApplicantEmail,
+		Subject: fmt.Sprintf("Application received: %s at %s", app.JobTitle, app.Company),
+		Body: mailBody(app, fmt.Sprintf("Thank you for applying for %s at %s. We have received your application and will review it soon.",
+			app.JobTitle, app.Company)),
+		ApplicationID: app.ConfirmationID,
+		JobID:         app.JobID,
+		Status:        app.Status,
+	})
+}
+
+// NotifyStatusChange sends a status update email. It is registered as an
+// application store status listener.
+func (h *MailboxHandler) NotifyStatusChange(app models.Application, previous models.ApplicationStatus) {
+	// A verified application is confirmed as if it had just been submitted
+	if previous == models.StatusPendingVerification && app.Status == models.StatusReceived {
+		h.sendConfirmation(app)
+		return
+	}
+	content, ok := statusEmails[app.Status]
+	if !ok {
+		content.subject = "Update on your application for %s at %s"
+		content.text = fmt.Sprintf("The status of your application has changed from %s to %s.", previous, app.Status)
+	}
+	h.mailStore.Deliver(models.Email{
+		Kind:          models.EmailStatusUpdate,
+		From:          mailFrom(app),
+		To:            app.ApplicantEmail,
+		Subject:       fmt.Sprintf(content.subject, app.JobTitle, app.Company),
+		Body:          mailBody(app, content.text),
+		ApplicationID: app.ConfirmationID,
+		JobID:         app.JobID,
+		Status:        app.Status,
+	})
+}
+
+// NotifyInterviewScheduled confirms a booked interview. It is registered
+// as an interview store schedule listener.
+func (h *MailboxHandler) NotifyInterviewScheduled(interview models.Interview, app models.Application) {
+	slot := interview.Slot
+	h.mailStore.Deliver(models.Email{
+		Kind:    models.EmailInterviewScheduled,
+		From:    mailFrom(app),
+		To:      app.ApplicantEmail,
+		Subject: fmt.Sprintf("Interview confirmed: %s at %s", app.JobTitle, app.Company),
+		Body: mailBody(app, fmt.Sprintf("Your interview is confirmed.\n\nWhen: %s to %s UTC\nFormat: %s\nInterviewer: %s\nInterview confirmation code: %s",
+			slot.StartsAt.UTC().Format("Mon, 2 Jan 2006 15:04"), slot.EndsAt.UTC().Format("15:04"), slot.Format, slot.Interviewer, interview.ConfirmationCode)),
+		ApplicationID: app.ConfirmationID,
+		JobID:         app.JobID,
+		Status:        app.Status,
+	})
+}
+
+// GetMailbox handles GET /api/mailbox
+// Returns the emails sent to ?email=, newest first
+func (h *MailboxHandler) GetMailbox(c *gin.Context) {
+	// Applicants only see their own, and need not name themselves
+	email, apiErr := applicantScope(c.Request.Context(), c.Query("email"))
+	if apiErr != nil {
+		respond.Error(c, apiErr.status, apiErr.code, apiErr.message)
+		return
+	}
+	if email == "" {
+		respond.Error(c, http.StatusBadRequest, "missing_email", "Query parameter 'email' is required.")
+		return
+	}
+
+	messages := h.mailStore.Messages(email)
+	c.JSON(http.StatusOK, models.MailboxResponse{
+		Email:    email,
+		Messages: messages,
+		Total:    len(messages),
+	})
+}
+
+// mailFrom is the sender of emails about app
+func mailFrom(app models.Application) string {
+	return (&mail.Address{Name: app.Company + " Recruiting", Address: mailSender}).String()
+}
+
+// mailBody is the plain-text body of an email about app, opening with text
+func mailBody(app models.Application, text string) string {
+	return fmt.Sprintf(`Hi %s,
+
+%s
+
+Confirmation ID: %s
+Job: %s (%s)
+Status: %s
+Submitted: %s
+
+You can check your application at any time at /api/applications/%s.
+
+%s Recruiting
+`, app.ApplicantName, text, app.ConfirmationID, app.JobTitle, app.JobID, app.Status,
+		app.SubmittedAt.UTC().Format(time.RFC3339), app.ConfirmationID, app.Company)
+}
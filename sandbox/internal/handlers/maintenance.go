@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/middleware"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/gin-gonic/gin"
+)
+
+// maxOutageDuration caps maintenance windows and brownouts
+const maxOutageDuration = 24 * time.Hour
+
+// MaintenanceHandler takes the API out of service for a while, to test how
+// patiently agents wait
+type MaintenanceHandler struct {
+	maintenance *middleware.Maintenance
+}
+
+// NewMaintenanceHandler creates a new maintenance handler
+func NewMaintenanceHandler(maintenance *middleware.Maintenance) *MaintenanceHandler {
+	return &MaintenanceHandler{maintenance: maintenance}
+}
+
+// GetMaintenance handles GET /admin/maintenance
+// Returns the maintenance window in progress, if any
+func (h *MaintenanceHandler) GetMaintenance(c *gin.Context) {
+	window, active := h.maintenance.Window()
+	c.JSON(http.StatusOK, maintenanceStatus(window, active))
+}
+
+// StartMaintenance handles PUT /admin/maintenance
+// Answers requests to every route, or those listed, with 503 and
+// Retry-After until the window ends
+func (h *MaintenanceHandler) StartMaintenance(c *gin.Context) {
+	var req models.MaintenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respond.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body: "+err.Error())
+		return
+	}
+	duration, ok := outageDuration(c, req.Duration)
+	if !ok || !validRoutes(c, req.Routes) {
+		return
+	}
+	c.JSON(http.StatusOK, maintenanceStatus(h.maintenance.StartWindow(duration, req.Routes), true))
+}
+
+// EndMaintenance handles DELETE /admin/maintenance
+// Ends the maintenance window early
+func (h *MaintenanceHandler) EndMaintenance(c *gin.Context) {
+	h.maintenance.EndWindow()
+	c.JSON(http.StatusOK, models.MaintenanceStatus{})
+}
+
+// GetBrownout handles GET /admin/brownout
+// Returns the brownout in progress, if any
+func (h *MaintenanceHandler) GetBrownout(c *gin.Context) {
+	brownout, active := h.maintenance.Brownout()
+	c.JSON(http.StatusOK, brownoutStatus(brownout, active))
+}
+
+// StartBrownout handles PUT /admin/brownout
+// Turns away a share of requests growing from start_rate to end_rate over
+// the duration, answering them with 503 and Retry-After
+func (h *MaintenanceHandler) StartBrownout(c *gin.Context) {
+	var req models.BrownoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respond.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body: "+err.Error())
+		return
+	}
+	duration, ok := outageDuration(c, req.Duration)
+	if !ok || !validRoutes(c, req.Routes) {
+		return
+	}
+	startRate, endRate := 0.0, 1.0
+	for _, rate := range []struct {
+		field  string
+		value  *float64
+		target *float64
+	}{
+		{"start_rate", req.StartRate, &startRate},
+		{"end_rate", req.EndRate, &endRate},
+	} {
+		if rate.value == nil {
+			continue
+		}
+		if *rate.value < 0 || *rate.value > 1 {
+			respond.Error(c, http.StatusBadRequest, "invalid_rate", rate.field+" must be between 0.0 and 1.0.")
+			return
+		}
+		*rate.target = *rate.value
+	}
+
+	brownout := h.maintenance.StartBrownout(duration, startRate, endRate, req.Routes)
+	c.JSON(http.StatusOK, brownoutStatus(brownout, true))
+}
+
+// EndBrownout handles DELETE /admin/brownout
+// Ends the brownout early
+func (h *MaintenanceHandler) EndBrownout(c *gin.Context) {
+	h.maintenance.EndBrownout()
+	c.JSON(http.StatusOK, models.BrownoutStatus{})
+}
+
+// outageDuration reads how long a maintenance window or brownout lasts,
+// answering 400 when it is not a duration up to maxOutageDuration
+func outageDuration(c *gin.Context, value string) (time.Duration, bool) {
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 || d > maxOutageDuration {
+		respond.Error(c, http.StatusBadRequest, "invalid_duration", "duration must be a duration between 0s and "+maxOutageDuration.String()+", such as 10m.")
+		return 0, false
+	}
+	return d, true
+}
+
+// validRoutes checks that routes are method and route patterns, answering
+// 400 when one is not
+func validRoutes(c *gin.Context, routes []string) bool {
+	for _, route := range routes {
+		if !middleware.IsRouteName(route) {
+			respond.Error(c, http.StatusBadRequest, "invalid_route", fmt.Sprintf("Route %q must be a method and route pattern, such as GET /api/jobs/:id.", route))
+			return false
+		}
+	}
+	return true
+}
+
+// maintenanceStatus reports a maintenance window in API form
+func maintenanceStatus(window middleware.MaintenanceWindow, active bool) models.MaintenanceStatus {
+	if !active {
+		return models.MaintenanceStatus{}
+	}
+	return models.MaintenanceStatus{
+		Active:    true,
+		StartedAt: &window.Start,
+		EndsAt:    &window.End,
+		Routes:    window.Routes,
+	}
+}
+
+// brownoutStatus reports a brownout in API form
+func brownoutStatus(brownout middleware.Brownout, active bool) models.BrownoutStatus {
+	if !active {
+		return models.BrownoutStatus{}
+	}
+	return models.BrownoutStatus{
+		Active:      true,
+		StartedAt:   &brownout.Start,
+		EndsAt:      &brownout.End,
+		StartRate:   brownout.StartRate,
+		EndRate:     brownout.EndRate,
+		CurrentRate: brownout.Rate(time.Now()),
+		Routes:      brownout.Routes,
+	}
+}
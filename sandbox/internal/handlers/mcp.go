@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/i18n"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/mcp"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/middleware"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/openapi"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// searchJobsArgs are the arguments of the search_jobs tool
+type searchJobsArgs struct {
+	Query  string `json:"query,omitempty" description:"Keywords matched against title, company, location, description and skills"`
+	Remote bool   `json:"remote,omitempty" description:"Only return remote jobs"`
+	Type   string `json:"type,omitempty" binding:"omitempty,oneof=full-time part-time internship contract" description:"Job type"`
+	Limit  int    `json:"limit,omitempty" binding:"omitempty,min=1,max=100" description:"Maximum number of jobs to return (default 20)"`
+
+	IncludeClosed bool `json:"include_closed,omitempty" description:"Also return draft and closed jobs"`
+}
+
+// jobArgs identify a job
+type jobArgs struct {
+	ID string `json:"id" binding:"required" description:"Job ID, e.g. job_002"`
+}
+
+// applicationArgs identify an application
+type applicationArgs struct {
+	ApplicationID string `json:"application_id" binding:"required" description:"Confirmation ID returned by submit_application"`
+}
+
+// mcpDefaultLimit is how many jobs search_jobs returns by default
+const mcpDefaultLimit = 20
+
+// MCPHandler exposes the sandbox as Model Context Protocol tools
+type MCPHandler struct {
+	jobStore   *store.JobStore
+	appStore   *store.ApplicationStore
+	applicants *store.ApplicantStore
+	guard      *SubmitGuard
+	server     *mcp.Server
+	sessions   *mcp.Sessions
+}
+
+// NewMCPHandler creates a new MCP handler
+func NewMCPHandler(jobStore *store.JobStore, appStore *store.ApplicationStore, applicants *store.ApplicantStore, guard *SubmitGuard) *MCPHandler {
+	h := &MCPHandler{
+		jobStore:   jobStore,
+		appStore:   appStore,
+		applicants: applicants,
+		guard:      guard,
+		server:     mcp.NewServer("job-portal-sandbox", Version),
+		sessions:   mcp.NewSessions(),
+	}
+
+	addTool(h.server, "search_jobs", "Search and filter job listings.", h.searchJobs)
+	addTool(h.server, "get_job", "Get the full details of a job, including whether it is accepting applications.", h.getJob)
+	addTool(h.server, "get_requirements", "Get just the requirements of a job.", h.getRequirements)
+	addTool(h.server, "submit_application", "Submit an application to a job.", h.submitApplication)
+	addTool(h.server, "check_application_status", "Check the current status of a submitted application.", h.checkApplicationStatus)
+
+	return h
+}
+
+// ServeStdio serves MCP over newline-delimited JSON on r and w
+func (h *MCPHandler) ServeStdio(ctx context.Context, r io.Reader, w io.Writer) error {
+	return h.server.ServeStdio(ctx, r, w)
+}
+
+// SSE handles GET /mcp/sse
+// Opens the MCP HTTP+SSE event stream and announces the message endpoint
+func (h *MCPHandler) SSE(c *gin.Context) {
+	authed, _ := middleware.Applicant(c.Request.Context())
+	id, stream := h.sessions.Open(authed)
+	defer h.sessions.Close(id)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Status(http.StatusOK)
+	if c.Request.Method == http.MethodHead {
+		return
+	}
+
+	endpoint := respond.RequestPath(c, nil)
+	endpoint = endpoint[:len(endpoint)-len("/sse")] + "/message?session_id=" + id
+	fmt.Fprintf(c.Writer, "event: endpoint\ndata: %s\n\n", endpoint)
+	c.Writer.Flush()
+
+	ctx, stop := respond.StreamContext(c)
+	defer stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case message := <-stream:
+			fmt.Fprintf(c.Writer, "event: message\ndata: %s\n\n", message)
+			c.Writer.Flush()
+		}
+	}
+}
+
+// Message handles POST /mcp/message
+// Accepts a JSON-RPC message for an SSE session; the response is sent on the stream
+func (h *MCPHandler) Message(c *gin.Context) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		respond.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body: "+err.Error())
+		return
+	}
+
+	sessionID := c.Query("session_id")
+	// A session only answers the applicant who opened it
+	authed, _ := middleware.Applicant(c.Request.Context())
+	if owner, open := h.sessions.Owner(sessionID); open && owner != authed {
+		respond.Error(c, http.StatusForbidden, "forbidden", "This MCP session belongs to another applicant.")
+		return
+	}
+	out := h.server.Handle(c.Request.Context(), body)
+	if out != nil && !h.sessions.Send(sessionID, out) {
+		respond.Error(c, http.StatusNotFound, "session_not_found", "The specified MCP session could not be found.")
+		return
+	}
+	c.Status(http.StatusAccepted)
+}
+
+// addTool registers a tool whose input schema is generated from its
+// argument type, decoding and validating the arguments with the same
+// binding rules and violation reporting as the REST API before calling run
+func addTool[T any](server *mcp.Server, name, description string, run func(context.Context, T) (interface{}, *apiError)) {
+	var zero T
+	schema := openapi.JSONSchema(zero)
+	if schema.Properties == nil {
+		schema.Properties = map[string]*openapi.Schema{}
+	}
+
+	server.AddTool(mcp.Tool{Name: name, Description: description, InputSchema: schema},
+		func(ctx context.Context, arguments json.RawMessage) (interface{}, error) {
+			var args T
+			var found violations
+			var typeErr *json.UnmarshalTypeError
+			if err := json.Unmarshal(arguments, &args); errors.As(err, &typeErr) {
+				found.add(typeErr.Field, "type_mismatch", typeErr.Field+" must be "+jsonTypeName(typeErr.Type)+".")
+			} else if err != nil {
+				return nil, &mcp.ToolError{Code: "invalid_request", Message: "Arguments are not valid JSON.", Status: http.StatusBadRequest}
+			}
+			found.addBinding(args)
+			if apiErr := found.err(); apiErr != nil {
+				return nil, toolError(apiErr)
+			}
+
+			result, apiErr := run(ctx, args)
+			if apiErr != nil {
+				return nil, toolError(apiErr)
+			}
+			return result, nil
+		})
+}
+
+// toolError converts an API error into an MCP tool error
+func toolError(apiErr *apiError) *mcp.ToolError {
+	toolErr := &mcp.ToolError{Code: apiErr.code, Message: apiErr.message, Status: apiErr.status}
+	if len(apiErr.violations) > 0 {
+		toolErr.Violations = apiErr.violations
+	}
+	return toolErr
+}
+
+func (h *MCPHandler) searchJobs(ctx context.Context, args searchJobsArgs) (interface{}, *apiError) {
+	limit := args.Limit
+	if limit == 0 {
+		limit = mcpDefaultLimit
+	}
+	filter := store.JobFilter{Query: args.Query, JobType: args.Type, HideClosed: !args.IncludeClosed}
+	if args.Remote {
+		filter.Remote = &args.Remote
+	}
+
+	matches := h.jobStore.Filter(filter, 0)
+	jobs := matches[:min(limit, len(matches))]
+	return models.JobSearchResponse{Jobs: jobs, Total: len(matches), Query: args.Query}, nil
+}
+
+func (h *MCPHandler) getJob(ctx context.Context, args jobArgs) (interface{}, *apiError) {
+	job, exists := h.jobStore.GetByID(args.ID)
+	if !exists {
+		return nil, &apiError{status: http.StatusNotFound, code: "job_not_found", message: "The requested job could not be found."}
+	}
+	return jobDetail(job, h.appStore), nil
+}
+
+func (h *MCPHandler) getRequirements(ctx context.Context, args jobArgs) (interface{}, *apiError) {
+	job, exists := h.jobStore.GetByID(args.ID)
+	if !exists {
+		return nil, &apiError{status: http.StatusNotFound, code: "job_not_found", message: "The requested job could not be found."}
+	}
+	return gin.H{
+		"job_id":       job.ID,
+		"title":        job.Title,
+		"company":      job.Company,
+		"requirements": job.Requirements,
+	}, nil
+}
+
+func (h *MCPHandler) submitApplication(ctx context.Context, req models.ApplicationRequest) (interface{}, *apiError) {
+	if req.ApplicantID != "" && !fillFromApplicant(h.applicants, &req) {
+		return nil, &apiError{status: http.StatusNotFound, code: "applicant_not_found", message: "The specified applicant could not be found."}
+	}
+	if apiErr := h.guard.check(ctx, req, false); apiErr != nil {
+		return nil, apiErr
+	}
+	app, apiErr := submitApplication(h.jobStore, h.appStore, req)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	return submissionResponse(app, i18n.Default), nil
+}
+
+func (h *MCPHandler) checkApplicationStatus(ctx context.Context, args applicationArgs) (interface{}, *apiError) {
+	app, exists := h.appStore.GetPropagatedByID(args.ApplicationID)
+	if !exists {
+		return nil, &apiError{status: http.StatusNotFound, code: "application_not_found", message: "The specified application could not be found."}
+	}
+	if apiErr := checkOwner(ctx, app); apiErr != nil {
+		return nil, apiErr
+	}
+	return statusResponse(app, i18n.Default), nil
+}
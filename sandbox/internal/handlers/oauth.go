@@ -0,0 +1,261 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/jwt"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/oauth"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// portalClientID is the client the sandbox's own job pages sign
+	// applicants in as under -oauth-apply
+	portalClientID = "sandbox-portal"
+	// portalRedirectURI is where the provider sends the portal's codes
+	portalRedirectURI = "/oauth/callback"
+	// oauthCodeTTL is how long an authorization code can be redeemed
+	oauthCodeTTL = 5 * time.Minute
+	// oauthScope is the only scope the provider grants
+	oauthScope = "email"
+)
+
+// OAuthHandler is a mock OAuth2 authorization-code provider ("Sign in with
+// SandboxID") that applicants sign in to with the accounts of
+// POST /api/auth/register. Its access tokens are applicant tokens, so they
+// also work on the routes -auth=required guards.
+type OAuthHandler struct {
+	accounts     *store.AccountStore
+	codes        *store.OAuthCodeStore
+	secret       []byte
+	ttl          time.Duration
+	clientID     string
+	clientSecret string
+}
+
+// NewOAuthHandler creates a new OAuth provider for the confidential client
+// clientID, issuing access tokens signed with secret and valid for ttl
+func NewOAuthHandler(accounts *store.AccountStore, codes *store.OAuthCodeStore, secret []byte, ttl time.Duration, clientID, clientSecret string) *OAuthHandler {
+	return &OAuthHandler{accounts: accounts, codes: codes, secret: secret, ttl: ttl, clientID: clientID, clientSecret: clientSecret}
+}
+
+// authorizeRequest is an authorization request (RFC 6749 section 4.1.1),
+// read from the query of GET /oauth/authorize or the sign-in form
+type authorizeRequest struct {
+	responseType        string
+	clientID            string
+	redirectURI         string
+	state               string
+	codeChallenge       string
+	codeChallengeMethod string
+}
+
+// Authorize handles GET /oauth/authorize
+// Shows the sign-in page for a valid authorization request
+func (h *OAuthHandler) Authorize(c *gin.Context) {
+	req := authorizeRequest{
+		responseType:        c.Query("response_type"),
+		clientID:            c.Query("client_id"),
+		redirectURI:         c.Query("redirect_uri"),
+		state:               c.Query("state"),
+		codeChallenge:       c.Query("code_challenge"),
+		codeChallengeMethod: c.Query("code_challenge_method"),
+	}
+	if !h.checkAuthorize(c, req) {
+		return
+	}
+	h.renderLogin(c, http.StatusOK, req, "", "")
+}
+
+// Approve handles POST /oauth/authorize
+// Signs the applicant in from the sign-in form and redirects back to the
+// client with a code, or with error=access_denied when they cancel
+func (h *OAuthHandler) Approve(c *gin.Context) {
+	req := authorizeRequest{
+		responseType:        c.PostForm("response_type"),
+		clientID:            c.PostForm("client_id"),
+		redirectURI:         c.PostForm("redirect_uri"),
+		state:               c.PostForm("state"),
+		codeChallenge:       c.PostForm("code_challenge"),
+		codeChallengeMethod: c.PostForm("code_challenge_method"),
+	}
+	if !h.checkAuthorize(c, req) {
+		return
+	}
+	if c.PostForm("action") == "deny" {
+		redirectWith(c, req.redirectURI, url.Values{"error": {"access_denied"}, "error_description": {"The applicant cancelled signing in."}}, req.state)
+		return
+	}
+
+	email, ok := h.accounts.Authenticate(c.PostForm("email"), c.PostForm("password"))
+	if !ok {
+		h.renderLogin(c, http.StatusUnauthorized, req, c.PostForm("email"), "The email address or password is incorrect.")
+		return
+	}
+	code := h.codes.Issue(models.OAuthGrant{
+		ClientID:      req.clientID,
+		RedirectURI:   req.redirectURI,
+		Email:         email,
+		CodeChallenge: req.codeChallenge,
+		ExpiresAt:     time.Now().Add(oauthCodeTTL),
+	})
+	redirectWith(c, req.redirectURI, url.Values{"code": {code}}, req.state)
+}
+
+// checkAuthorize validates an authorization request. Problems with the
+// client or redirect URI are shown as a page, since they make redirecting
+// unsafe (RFC 6749 section 4.1.2.1); the others are sent to the client.
+func (h *OAuthHandler) checkAuthorize(c *gin.Context, req authorizeRequest) bool {
+	switch req.clientID {
+	case h.clientID:
+		target, err := url.Parse(req.redirectURI)
+		if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" || target.Fragment != "" {
+			c.String(http.StatusBadRequest, "redirect_uri must be an absolute http or https URL without a fragment.")
+			return false
+		}
+	case portalClientID:
+		if req.redirectURI != portalRedirectURI {
+			c.String(http.StatusBadRequest, "redirect_uri is not registered for this client.")
+			return false
+		}
+	default:
+		c.String(http.StatusBadRequest, "Unknown client_id.")
+		return false
+	}
+
+	switch {
+	case req.responseType != "code":
+		redirectWith(c, req.redirectURI, url.Values{"error": {"unsupported_response_type"}, "error_description": {"Only response_type=code is supported."}}, req.state)
+		return false
+	case req.codeChallenge != "" && req.codeChallengeMethod != "S256":
+		redirectWith(c, req.redirectURI, url.Values{"error": {"invalid_request"}, "error_description": {"Only the S256 code_challenge_method is supported."}}, req.state)
+		return false
+	}
+	return true
+}
+
+// renderLogin shows the sign-in page for req
+func (h *OAuthHandler) renderLogin(c *gin.Context, status int, req authorizeRequest, email, problem string) {
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.Header("Cache-Control", "no-store")
+	c.Status(status)
+	oauth.LoginPage.Execute(c.Writer, oauth.LoginData{
+		ClientID:            req.clientID,
+		RedirectURI:         req.redirectURI,
+		State:               req.state,
+		CodeChallenge:       req.codeChallenge,
+		CodeChallengeMethod: req.codeChallengeMethod,
+		Email:               email,
+		Error:               problem,
+	})
+}
+
+// redirectWith redirects to uri with params and state added to its query
+func redirectWith(c *gin.Context, uri string, params url.Values, state string) {
+	target, _ := url.Parse(uri)
+	query := target.Query()
+	for name, values := range params {
+		query[name] = values
+	}
+	if state != "" {
+		query.Set("state", state)
+	}
+	target.RawQuery = query.Encode()
+	c.Redirect(http.StatusFound, target.String())
+}
+
+// Token handles POST /oauth/token
+// Exchanges an authorization code for an access token. The client
+// authenticates with HTTP Basic or client_id and client_secret in the form.
+func (h *OAuthHandler) Token(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	clientID, clientSecret, basic := c.Request.BasicAuth()
+	if !basic {
+		clientID, clientSecret = c.PostForm("client_id"), c.PostForm("client_secret")
+	}
+	if clientID != h.clientID || subtle.ConstantTimeCompare([]byte(clientSecret), []byte(h.clientSecret)) != 1 {
+		if basic {
+			c.Header("WWW-Authenticate", `Basic realm="oauth"`)
+		}
+		oauthError(c, http.StatusUnauthorized, "invalid_client", "Unknown client or wrong client secret.")
+		return
+	}
+	if grantType := c.PostForm("grant_type"); grantType != "authorization_code" {
+		oauthError(c, http.StatusBadRequest, "unsupported_grant_type", "Only grant_type=authorization_code is supported.")
+		return
+	}
+	if c.PostForm("code") == "" {
+		oauthError(c, http.StatusBadRequest, "invalid_request", "code is required.")
+		return
+	}
+
+	email, problem := h.redeem(c.PostForm("code"), clientID, c.PostForm("redirect_uri"), c.PostForm("code_verifier"))
+	if problem != "" {
+		oauthError(c, http.StatusBadRequest, "invalid_grant", problem)
+		return
+	}
+	token, err := h.accessToken(email)
+	if err != nil {
+		oauthError(c, http.StatusInternalServerError, "server_error", "Failed to issue token: "+err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, models.OAuthTokenResponse{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(h.ttl.Seconds()),
+		Scope:       oauthScope,
+	})
+}
+
+// UserInfo handles GET /oauth/userinfo
+// Describes the applicant an access token was issued to
+func (h *OAuthHandler) UserInfo(c *gin.Context) {
+	token, _ := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	claims, err := jwt.Verify(token, h.secret, time.Now())
+	if err != nil {
+		c.Header("WWW-Authenticate", `Bearer realm="oauth", error="invalid_token"`)
+		oauthError(c, http.StatusUnauthorized, "invalid_token", "Send a valid access token as Authorization: Bearer <token>.")
+		return
+	}
+	c.JSON(http.StatusOK, models.UserInfo{Subject: claims.Subject, Email: claims.Subject, EmailVerified: true})
+}
+
+// redeem exchanges a code for the email address it was issued for, checking
+// it was issued to clientID for redirectURI and, when it came with a PKCE
+// challenge, that verifier answers it. Otherwise it returns the problem.
+func (h *OAuthHandler) redeem(code, clientID, redirectURI, verifier string) (string, string) {
+	grant, exists := h.codes.Redeem(code)
+	switch {
+	case !exists:
+		return "", "The code is unknown, expired or already used."
+	case grant.ClientID != clientID:
+		return "", "The code was issued to another client."
+	case grant.RedirectURI != redirectURI:
+		return "", "redirect_uri does not match the authorization request."
+	case grant.CodeChallenge != "" && !oauth.VerifyChallenge(grant.CodeChallenge, verifier):
+		return "", "code_verifier does not match the code_challenge."
+	}
+	return grant.Email, ""
+}
+
+// accessToken signs an access token for email
+func (h *OAuthHandler) accessToken(email string) (string, error) {
+	return jwt.Sign(email, h.secret, time.Now(), h.ttl)
+}
+
+// signedIn returns the email address an access token was issued for
+func (h *OAuthHandler) signedIn(token string) (string, bool) {
+	claims, err := jwt.Verify(token, h.secret, time.Now())
+	return claims.Subject, err == nil
+}
+
+// oauthError writes an error in the RFC 6749 format
+func oauthError(c *gin.Context, status int, code, description string) {
+	c.AbortWithStatusJSON(status, models.OAuthError{Error: code, ErrorDescription: description})
+}
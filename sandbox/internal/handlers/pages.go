@@ -1,29 +1,46 @@
 package handlers
 
 import (
+	"crypto/subtle"
 	"embed"
 	"html/template"
 	"io/fs"
 	"net/http"
+	"net/url"
+	"slices"
 	"strings"
-	"time"
+	"unicode/utf8"
 
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/emailaddr"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
 	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 // PageHandler handles frontend page rendering
 type PageHandler struct {
 	jobStore  *store.JobStore
 	appStore  *store.ApplicationStore
+	savedJobs *store.SavedJobStore
+	signIn    *OAuthHandler
+	sessions  *store.FormSessionStore
+	drafts    *store.DraftStore
+	workday   *store.WorkdayStore
+	variants  *FormVariants
 	templates map[string]*template.Template
 }
 
 // TemplatesFS is the embedded filesystem for templates (set from main)
 var TemplatesFS embed.FS
 
-// NewPageHandler creates a new page handler
-func NewPageHandler(jobStore *store.JobStore, appStore *store.ApplicationStore, templatesDir fs.FS) (*PageHandler, error) {
+// NewPageHandler creates a new page handler. With a non-nil signIn, the
+// application form asks applicants to sign in with it first; with non-nil
+// sessions, it only accepts posts carrying the CSRF token of the browser's
+// session; with non-nil drafts, it is a wizard of several pages; with
+// non-nil workday, it is the Workday-style flow; with non-nil variants, it
+// is laid out differently for each browser.
+func NewPageHandler(jobStore *store.JobStore, appStore *store.ApplicationStore, savedJobs *store.SavedJobStore, signIn *OAuthHandler, sessions *store.FormSessionStore, drafts *store.DraftStore, workday *store.WorkdayStore, variants *FormVariants, templatesDir fs.FS) (*PageHandler, error) {
 	// Define template functions
 	funcMap := template.FuncMap{
 		"slice": func(s string, start, end int) string {
@@ -44,7 +61,9 @@ func NewPageHandler(jobStore *store.JobStore, appStore *store.ApplicationStore,
 		"add": func(a, b int) int {
 			return a + b
 		},
-		"lower": strings.ToLower,
+		"lower":    strings.ToLower,
+		"join":     strings.Join,
+		"truncate": truncate,
 		"eq": func(a, b interface{}) bool {
 			return a == b
 		},
@@ -57,6 +76,9 @@ func NewPageHandler(jobStore *store.JobStore, appStore *store.ApplicationStore,
 		"jobs_list.html",
 		"job_detail.html",
 		"apply_form.html",
+		"apply_wizard.html",
+		"apply_workday.html",
+		"apply_variant.html",
 		"application_success.html",
 		"my_applications.html",
 		"application_detail.html",
@@ -85,13 +107,29 @@ func NewPageHandler(jobStore *store.JobStore, appStore *store.ApplicationStore,
 	return &PageHandler{
 		jobStore:  jobStore,
 		appStore:  appStore,
+		savedJobs: savedJobs,
+		signIn:    signIn,
+		sessions:  sessions,
+		drafts:    drafts,
+		workday:   workday,
+		variants:  variants,
 		templates: templates,
 	}, nil
 }
 
+// truncate shortens s to at most n characters, marking the cut so an
+// oversized value never passes for the whole text
+func truncate(n int, s string) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n]) + "… [truncated]"
+}
+
 // render renders a template
 func (h *PageHandler) render(c *gin.Context, templateName string, data gin.H) {
 	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.Header("Content-Language", "en") // Pages are not translated
 
 	tmpl, ok := h.templates[templateName]
 	if !ok {
@@ -111,18 +149,27 @@ func (h *PageHandler) HomePage(c *gin.Context) {
 	query := c.Query("q")
 	remote := c.Query("remote")
 	jobType := c.Query("type")
-	limit := 100
+	params := newQueryParams(c)
+	limit := params.limit(100)
+	if !params.checkPage() {
+		return
+	}
 
-	var jobs interface{}
+	// Draft and closed jobs are not listed, as in the API
+	filter := store.JobFilter{HideClosed: true}
+	var jobs []models.Job
 
 	if query != "" {
-		jobs = h.jobStore.Search(query, limit)
-	} else if remote == "true" {
-		jobs = h.jobStore.FilterByRemote(limit)
-	} else if jobType != "" {
-		jobs = h.jobStore.FilterByJobType(jobType, limit)
+		jobs = slices.DeleteFunc(h.jobStore.Search(query, 0), func(job models.Job) bool { return !filter.Matches(job) })
+		jobs = jobs[:min(limit, len(jobs))]
 	} else {
-		jobs = h.jobStore.GetAll(limit)
+		if remote == "true" {
+			isRemote := true
+			filter.Remote = &isRemote
+		} else if jobType != "" {
+			filter.JobType = jobType
+		}
+		jobs = h.jobStore.Filter(filter, limit)
 	}
 
 	// Count unique companies
@@ -154,33 +201,22 @@ func (h *PageHandler) JobDetailPage(c *gin.Context) {
 		c.String(http.StatusNotFound, "Job not found")
 		return
 	}
+	markTrap(c, job)
 
-	// Check if accepting applications
-	isAccepting := true
 	deadlineDate := ""
-	if job.ApplicationDeadline != "" {
-		deadline, err := time.Parse(time.RFC3339, job.ApplicationDeadline)
-		if err == nil {
-			deadlineDate = deadline.Format("January 2, 2006")
-			if time.Now().After(deadline) {
-				isAccepting = false
-			}
-		}
+	if !job.Deadline.IsZero() {
+		deadlineDate = job.Deadline.Format("January 2, 2006")
 	}
-
-	// Parse posted date
 	postedDate := ""
-	if job.PostedAt != "" {
-		posted, err := time.Parse(time.RFC3339, job.PostedAt)
-		if err == nil {
-			postedDate = posted.Format("January 2, 2006")
-		}
+	if !job.Posted.IsZero() {
+		postedDate = job.Posted.Format("January 2, 2006")
 	}
 
 	data := gin.H{
 		"Title":             job.Title + " at " + job.Company,
 		"Job":               job,
-		"IsAccepting":       isAccepting,
+		"IsAccepting":       isAcceptingApplications(job, h.appStore),
+		"Status":            string(jobStatus(job, h.appStore)),
 		"ApplicationsCount": h.appStore.GetCountByJobID(jobID),
 		"PostedDate":        postedDate,
 		"DeadlineDate":      deadlineDate,
@@ -198,22 +234,271 @@ func (h *PageHandler) ApplyPage(c *gin.Context) {
 		c.String(http.StatusNotFound, "Job not found")
 		return
 	}
+	markTrap(c, job)
 
 	// Check if accepting applications
-	if job.ApplicationDeadline != "" {
-		deadline, err := time.Parse(time.RFC3339, job.ApplicationDeadline)
-		if err == nil && time.Now().After(deadline) {
-			c.Redirect(http.StatusFound, "/jobs/"+jobID)
-			return
+	if !isAcceptingApplications(job, h.appStore) {
+		c.Redirect(http.StatusFound, "/jobs/"+jobID)
+		return
+	}
+	email, ok := h.requireSignIn(c, "/jobs/"+jobID+"/apply")
+	if !ok {
+		return
+	}
+	if h.drafts != nil {
+		h.startWizard(c, job, email)
+		return
+	}
+	if h.workday != nil {
+		h.startWorkday(c, job)
+		return
+	}
+
+	h.renderApplyForm(c, job, models.ApplicationRequest{ApplicantEmail: email}, nil)
+}
+
+// applyFormFields are the fields the application form has an input for;
+// problems with any other field are listed above the form
+var applyFormFields = map[string]bool{
+	"applicant_name": true, "applicant_email": true, "phone": true, "linkedin": true,
+	"portfolio": true, "github": true, "resume": true, "cover_letter": true,
+	"work_authorization": true, "start_date": true, "salary_expectation": true,
+	"remote_preference": true,
+}
+
+// SubmitApplyForm handles POST /jobs/:id/apply
+// Submits the application form the way a browser does, as a urlencoded or
+// multipart body. A valid application redirects to its success page; an
+// invalid one re-renders the form with what was entered and the problems
+// next to the fields they concern.
+func (h *PageHandler) SubmitApplyForm(c *gin.Context) {
+	job, exists := h.jobStore.GetByID(c.Param("id"))
+	if !exists {
+		c.String(http.StatusNotFound, "Job not found")
+		return
+	}
+	if h.drafts != nil || h.workday != nil {
+		c.String(http.StatusConflict, "This application is filled in step by step. Start at /jobs/"+job.ID+"/apply.")
+		return
+	}
+
+	email, ok := h.requireSignIn(c, "/jobs/"+job.ID+"/apply")
+	if !ok {
+		return
+	}
+
+	var req models.ApplicationRequest
+	fields, err := formFields(c)
+	if err != nil {
+		h.renderApplyForm(c, job, req, &apiError{status: http.StatusBadRequest, code: "invalid_request", message: "The form could not be read: " + err.Error()})
+		return
+	}
+	found := decodeForm(fields, &req)
+	markHoneypot(c, h.appStore, req)
+	// The job in the URL is the one applied to, whatever the form says
+	req.JobID = job.ID
+	if email != "" {
+		// and a signed-in applicant applies as themselves
+		req.ApplicantEmail = email
+	}
+	if apiErr := h.checkCSRF(c, fields); apiErr != nil {
+		h.renderApplyForm(c, job, req, apiErr)
+		return
+	}
+
+	app, apiErr := submitApplication(h.jobStore, h.appStore, req, found...)
+	if apiErr != nil {
+		h.renderApplyForm(c, job, req, apiErr)
+		return
+	}
+
+	markSubmitted(c, app)
+	c.Redirect(http.StatusSeeOther, "/applications/"+app.ConfirmationID+"/success")
+}
+
+// renderApplyForm renders the application form filled in with values. The
+// problems in apiErr, if any, are shown next to their fields, or above the
+// form when it has no input for them, and set the response status.
+func (h *PageHandler) renderApplyForm(c *gin.Context, job models.Job, values models.ApplicationRequest, apiErr *apiError) {
+	fieldErrors, formErrors := formProblems(c, apiErr, func(field string) bool {
+		return applyFormFields[field] || isQuestionField(job, field)
+	})
+
+	if h.variants != nil {
+		variant := buildFormVariant(h.variants.seed(c), job, values, fieldErrors, h.signIn != nil)
+		h.render(c, "apply_variant.html", gin.H{
+			"Title":      "Apply for " + job.Title,
+			"Job":        job,
+			"Variant":    variant,
+			"Errors":     fieldErrors,
+			"FormErrors": formErrors,
+			"CSRFToken":  h.csrfToken(c),
+			"Honeypot":   h.appStore.Honeypot() != "",
+		})
+		return
+	}
+
+	h.render(c, "apply_form.html", gin.H{
+		"Title":      "Apply for " + job.Title,
+		"Job":        job,
+		"Values":     values,
+		"Errors":     fieldErrors,
+		"FormErrors": formErrors,
+		"SignedIn":   h.signIn != nil,
+		"CSRFToken":  h.csrfToken(c),
+		"Honeypot":   h.appStore.Honeypot() != "",
+	})
+}
+
+// formProblems splits the problems in apiErr, if any, into those shown next
+// to the field they concern, by field, and those shown above the form, for
+// fields onPage reports the page has no input for. They set the response
+// status.
+func formProblems(c *gin.Context, apiErr *apiError, onPage func(field string) bool) (map[string]string, []string) {
+	fieldErrors := make(map[string]string)
+	formErrors := []string{}
+	if apiErr != nil {
+		for _, v := range apiErr.violations {
+			if !onPage(v.Field) {
+				formErrors = append(formErrors, v.Message)
+			} else if _, seen := fieldErrors[v.Field]; !seen {
+				fieldErrors[v.Field] = v.Message
+			}
 		}
+		if len(apiErr.violations) == 0 {
+			formErrors = append(formErrors, apiErr.message)
+		}
+		c.Status(apiErr.status)
 	}
+	return fieldErrors, formErrors
+}
 
-	data := gin.H{
-		"Title": "Apply for " + job.Title,
-		"Job":   job,
+// formSessionCookie holds the ID of the browser session whose CSRF token
+// the application form must be posted with, in the csrfField field
+const (
+	formSessionCookie = "sandbox_form_session"
+	csrfField         = "csrf_token"
+)
+
+// csrfToken returns the CSRF token of the browser's session, starting a
+// session when it has none, or "" when the pages do not use CSRF tokens
+func (h *PageHandler) csrfToken(c *gin.Context) string {
+	if h.sessions == nil {
+		return ""
+	}
+	if id, err := c.Cookie(formSessionCookie); err == nil {
+		if token, ok := h.sessions.CSRFToken(id); ok {
+			return token
+		}
+	}
+	id, token := h.sessions.Start()
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(formSessionCookie, id, int(h.sessions.TTL().Seconds()), "/", "", false, true)
+	return token
+}
+
+// checkCSRF rejects a form post that does not come from a live session or
+// whose csrfField does not match the session's token
+func (h *PageHandler) checkCSRF(c *gin.Context, fields map[string]string) *apiError {
+	if h.sessions == nil {
+		return nil
+	}
+	id, err := c.Cookie(formSessionCookie)
+	if err != nil {
+		return &apiError{status: http.StatusForbidden, code: "session_required",
+			message: "Your browser sent no session cookie. Open the application form and submit it from there."}
+	}
+	token, ok := h.sessions.CSRFToken(id)
+	if !ok {
+		return &apiError{status: http.StatusForbidden, code: "session_expired",
+			message: "Your session has expired. Please submit the form again."}
+	}
+	if subtle.ConstantTimeCompare([]byte(fields[csrfField]), []byte(token)) != 1 {
+		return &apiError{status: http.StatusForbidden, code: "invalid_csrf_token",
+			message: "The form's security token is missing or does not match your session. Please submit the form again."}
+	}
+	return nil
+}
+
+// sessionCookie holds the access token of an applicant signed in to the
+// pages, and stateCookie the state of a sign-in in progress
+const (
+	sessionCookie = "sandbox_session"
+	stateCookie   = "sandbox_oauth_state"
+)
+
+// requireSignIn returns the email address of the signed-in applicant. When
+// the pages ask applicants to sign in and no one is, it redirects to the
+// OAuth provider, coming back to returnTo afterwards, and reports false.
+// Without sign-in it returns "" and true.
+func (h *PageHandler) requireSignIn(c *gin.Context, returnTo string) (string, bool) {
+	if h.signIn == nil {
+		return "", true
+	}
+	if token, err := c.Cookie(sessionCookie); err == nil {
+		if email, ok := h.signIn.signedIn(token); ok {
+			return email, true
+		}
 	}
 
-	h.render(c, "apply_form.html", data)
+	// The state ties the callback to this browser and carries where to go
+	// back to
+	state := uuid.New().String() + returnTo
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(stateCookie, state, int(oauthCodeTTL.Seconds()), "/", "", false, true)
+	c.Redirect(http.StatusSeeOther, "/oauth/authorize?"+url.Values{
+		"response_type": {"code"},
+		"client_id":     {portalClientID},
+		"redirect_uri":  {portalRedirectURI},
+		"state":         {state},
+	}.Encode())
+	return "", false
+}
+
+// OAuthCallback handles GET /oauth/callback
+// Finishes signing in to the pages: redeems the code the provider sent
+// back, keeps the access token in a cookie and returns to the page the
+// applicant started from
+func (h *PageHandler) OAuthCallback(c *gin.Context) {
+	if h.signIn == nil {
+		c.String(http.StatusNotFound, "Signing in is not enabled")
+		return
+	}
+	state, err := c.Cookie(stateCookie)
+	if err != nil || state != c.Query("state") {
+		c.String(http.StatusBadRequest, "The sign-in could not be matched to this browser. Please try again.")
+		return
+	}
+	c.SetCookie(stateCookie, "", -1, "/", "", false, true)
+	returnTo := "/"
+	if i := strings.Index(state, "/"); i >= 0 && !strings.HasPrefix(state[i:], "//") {
+		returnTo = state[i:]
+	}
+	if c.Query("error") != "" {
+		c.Redirect(http.StatusSeeOther, strings.TrimSuffix(returnTo, "/apply"))
+		return
+	}
+
+	email, problem := h.signIn.redeem(c.Query("code"), portalClientID, portalRedirectURI, "")
+	if problem != "" {
+		c.String(http.StatusBadRequest, problem)
+		return
+	}
+	token, err := h.signIn.accessToken(email)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Failed to sign in: "+err.Error())
+		return
+	}
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(sessionCookie, token, int(h.signIn.ttl.Seconds()), "/", "", false, true)
+	c.Redirect(http.StatusSeeOther, returnTo)
+}
+
+// isQuestionField reports whether field is the answer to one of job's
+// screening questions, which the application form has an input for
+func isQuestionField(job models.Job, field string) bool {
+	id, ok := strings.CutPrefix(field, "custom_answers.")
+	return ok && slices.ContainsFunc(job.Questions, func(q models.ScreeningQuestion) bool { return q.ID == id })
 }
 
 // ApplicationSuccessPage renders the success page after application submission
@@ -221,10 +506,16 @@ func (h *PageHandler) ApplicationSuccessPage(c *gin.Context) {
 	confirmationID := c.Param("id")
 
 	app, exists := h.appStore.GetByID(confirmationID)
+	if !exists {
+		app, exists = h.appStore.GetShadowRejected(confirmationID)
+	}
 	if !exists {
 		c.Redirect(http.StatusFound, "/my-applications")
 		return
 	}
+	if !h.canView(c, app, c.Request.URL.Path) {
+		return
+	}
 
 	data := gin.H{
 		"Title":       "Application Submitted",
@@ -238,24 +529,46 @@ func (h *PageHandler) ApplicationSuccessPage(c *gin.Context) {
 // MyApplicationsPage renders the list of applications
 func (h *PageHandler) MyApplicationsPage(c *gin.Context) {
 	email := c.Query("email")
-
-	var apps interface{}
-
-	if email != "" {
-		apps = h.appStore.GetByEmail(email)
-	} else {
-		apps = h.appStore.GetAll(50)
+	params := newQueryParams(c)
+	order := params.order()
+	limit := params.limit(50)
+	if !params.checkPage() {
+		return
+	}
+	// Signed-in applicants see their own applications
+	signedIn, ok := h.requireSignIn(c, c.Request.URL.RequestURI())
+	if !ok {
+		return
 	}
+	if signedIn != "" {
+		email = signedIn
+	}
+
+	apps := listApplications(h.appStore, email, "", limit, order)
 
 	data := gin.H{
 		"Title":        "My Applications",
 		"Applications": apps,
 		"Email":        email,
 	}
+	if email != "" {
+		data["SavedJobs"] = savedJobsFor(h.jobStore, h.appStore, h.savedJobs, email)
+	}
 
 	h.render(c, "my_applications.html", data)
 }
 
+// SaveJob handles the save button on the job detail page, showing the
+// saved job among the applicant's applications
+func (h *PageHandler) SaveJob(c *gin.Context) {
+	req := models.SaveJobRequest{Email: c.PostForm("email"), JobID: c.Param("id"), Note: c.PostForm("note")}
+	if _, _, apiErr := saveJob(h.jobStore, h.appStore, h.savedJobs, req); apiErr != nil {
+		c.String(apiErr.status, apiErr.message)
+		return
+	}
+	c.Redirect(http.StatusSeeOther, "/my-applications?email="+url.QueryEscape(req.Email)+"#saved-jobs")
+}
+
 // ApplicationDetailPage renders the application detail page
 func (h *PageHandler) ApplicationDetailPage(c *gin.Context) {
 	confirmationID := c.Param("id")
@@ -265,6 +578,9 @@ func (h *PageHandler) ApplicationDetailPage(c *gin.Context) {
 		c.String(http.StatusNotFound, "Application not found")
 		return
 	}
+	if !h.canView(c, app, c.Request.URL.Path) {
+		return
+	}
 
 	data := gin.H{
 		"Title":       "Application " + app.ConfirmationID,
@@ -276,6 +592,36 @@ func (h *PageHandler) ApplicationDetailPage(c *gin.Context) {
 	h.render(c, "application_detail.html", data)
 }
 
+// WithdrawApplication handles the withdraw button on the application detail
+// page
+func (h *PageHandler) WithdrawApplication(c *gin.Context) {
+	if app, exists := h.appStore.GetByID(c.Param("id")); exists && !h.canView(c, app, "/applications/"+app.ConfirmationID) {
+		return
+	}
+	app, apiErr := withdrawApplication(h.appStore, c.Param("id"), c.PostForm("reason"))
+	if apiErr != nil {
+		c.String(apiErr.status, apiErr.message)
+		return
+	}
+	c.Redirect(http.StatusSeeOther, "/applications/"+app.ConfirmationID)
+}
+
+// canView reports whether the applicant signed in to the pages may see
+// app, which is any application when the pages do not ask applicants to
+// sign in. Otherwise it redirects to sign in, coming back to returnTo, or
+// answers 403 for another applicant's application.
+func (h *PageHandler) canView(c *gin.Context, app *models.Application, returnTo string) bool {
+	email, ok := h.requireSignIn(c, returnTo)
+	if !ok {
+		return false
+	}
+	if email != "" && emailaddr.Normalize(app.ApplicantEmail) != emailaddr.Normalize(email) {
+		c.String(http.StatusForbidden, "This application belongs to another applicant.")
+		return false
+	}
+	return true
+}
+
 // ApplicationLookup handles application lookup
 func (h *PageHandler) ApplicationLookup(c *gin.Context) {
 	id := c.Query("id")
@@ -292,3 +638,244 @@ func (h *PageHandler) ApplicationLookup(c *gin.Context) {
 
 	c.Redirect(http.StatusFound, "/applications/"+app.ConfirmationID)
 }
+
+// draftCookie holds the ID of the wizard draft of the job whose apply
+// pages it is scoped to
+const draftCookie = "sandbox_draft"
+
+// wizardStepLabels are the names the wizard pages give their steps
+var wizardStepLabels = map[string]string{
+	models.StepPersonal:  "Personal Info",
+	models.StepResume:    "Resume",
+	models.StepQuestions: "Screening Questions",
+	models.StepReview:    "Review",
+}
+
+// wizardStep is a step in the wizard's progress bar
+type wizardStep struct {
+	Name    string
+	Label   string
+	Number  int
+	Done    bool
+	Current bool
+}
+
+// startWizard goes on with the browser's draft of job, or starts one, by
+// redirecting to its next step
+func (h *PageHandler) startWizard(c *gin.Context, job models.Job, email string) {
+	draft, exists := h.wizardDraft(c, job)
+	if !exists {
+		var apiErr *apiError
+		draft, apiErr = startDraft(h.jobStore, h.appStore, h.drafts, job.ID, email)
+		if apiErr != nil {
+			c.String(apiErr.status, apiErr.message)
+			return
+		}
+		c.SetSameSite(http.SameSiteLaxMode)
+		c.SetCookie(draftCookie, draft.ID, int(store.DraftTTL.Seconds()), "/jobs/"+job.ID+"/apply", "", false, true)
+	}
+	c.Redirect(http.StatusSeeOther, wizardStepPath(job, draft.NextStep))
+}
+
+// wizardStepPath is the page of a wizard step, with "submit" on the review
+// page
+func wizardStepPath(job models.Job, step string) string {
+	if step == models.StepSubmit {
+		step = models.StepReview
+	}
+	return "/jobs/" + job.ID + "/apply/" + step
+}
+
+// wizardDraft returns the browser's draft of job
+func (h *PageHandler) wizardDraft(c *gin.Context, job models.Job) (models.ApplicationDraft, bool) {
+	id, err := c.Cookie(draftCookie)
+	if err != nil {
+		return models.ApplicationDraft{}, false
+	}
+	draft, exists := h.drafts.Get(id)
+	return draft, exists && draft.JobID == job.ID
+}
+
+// wizardPage starts handling the page of a wizard step: it finds the job
+// and the browser's draft, asking applicants to sign in or going back to
+// the start of the wizard when needed. Steps after the next one to do
+// redirect to it.
+func (h *PageHandler) wizardPage(c *gin.Context, step string) (models.Job, models.ApplicationDraft, string, bool) {
+	var draft models.ApplicationDraft
+	job, exists := h.jobStore.GetByID(c.Param("id"))
+	if h.drafts == nil || !exists {
+		c.String(http.StatusNotFound, "Job not found")
+		return job, draft, "", false
+	}
+	if !slices.Contains(models.DraftSteps, step) {
+		c.String(http.StatusNotFound, "Step not found")
+		return job, draft, "", false
+	}
+
+	email, ok := h.requireSignIn(c, wizardStepPath(job, step))
+	if !ok {
+		return job, draft, "", false
+	}
+	draft, exists = h.wizardDraft(c, job)
+	if !exists {
+		c.Redirect(http.StatusSeeOther, "/jobs/"+job.ID+"/apply")
+		return job, draft, "", false
+	}
+	if slices.Index(models.DraftSteps, step) > len(draft.CompletedSteps) {
+		c.Redirect(http.StatusSeeOther, wizardStepPath(job, draft.NextStep))
+		return job, draft, "", false
+	}
+	return job, draft, email, true
+}
+
+// WizardStepPage handles GET /jobs/:id/apply/:step
+// Renders a step of the application wizard under -apply-wizard, or a page
+// of the Workday-style flow under -workday-flow
+func (h *PageHandler) WizardStepPage(c *gin.Context) {
+	if h.workday != nil {
+		h.workdayPage(c, c.Param("step"))
+		return
+	}
+	job, draft, email, ok := h.wizardPage(c, c.Param("step"))
+	if !ok {
+		return
+	}
+	values := draft.Application
+	if email != "" {
+		values.ApplicantEmail = email
+	}
+	h.renderWizard(c, job, draft, c.Param("step"), values, nil)
+}
+
+// SubmitWizardStep handles POST /jobs/:id/apply/:step
+// Saves a step of the application wizard, or a page of the Workday-style
+// flow, and redirects to the next one
+func (h *PageHandler) SubmitWizardStep(c *gin.Context) {
+	if h.workday != nil {
+		h.submitWorkdayPage(c, c.Param("step"))
+		return
+	}
+	h.submitWizardStep(c, c.Param("step"))
+}
+
+// SubmitWizardReview handles POST /jobs/:id/apply/review
+// Submits the application the wizard or the Workday-style flow filled in,
+// redirecting to its success page
+func (h *PageHandler) SubmitWizardReview(c *gin.Context) {
+	if h.workday != nil {
+		h.submitWorkdayPage(c, models.WorkdayReview)
+		return
+	}
+	h.submitWizardStep(c, models.StepReview)
+}
+
+// submitWizardStep saves step, or submits the application from the review
+// step
+func (h *PageHandler) submitWizardStep(c *gin.Context, step string) {
+	job, draft, email, ok := h.wizardPage(c, step)
+	if !ok {
+		return
+	}
+
+	values := draft.Application
+	fields, err := formFields(c)
+	if err != nil {
+		h.renderWizard(c, job, draft, step, values, &apiError{status: http.StatusBadRequest, code: "invalid_request", message: "The form could not be read: " + err.Error()})
+		return
+	}
+
+	var (
+		fill  func(*models.ApplicationRequest)
+		found []models.Violation
+	)
+	switch step {
+	case models.StepPersonal:
+		var req models.DraftPersonalStep
+		found = decodeForm(fields, &req)
+		if email != "" {
+			// A signed-in applicant applies as themselves
+			req.ApplicantEmail = email
+		}
+		fill = req.Fill
+	case models.StepResume:
+		var req models.DraftResumeStep
+		found = decodeForm(fields, &req)
+		fill = req.Fill
+	case models.StepQuestions:
+		var req models.DraftQuestionsStep
+		found = decodeForm(fields, &req)
+		fill = req.Fill
+	}
+	if fill != nil {
+		fill(&values)
+	}
+	if apiErr := h.checkCSRF(c, fields); apiErr != nil {
+		h.renderWizard(c, job, draft, step, values, apiErr)
+		return
+	}
+
+	if step != models.StepReview {
+		saved, apiErr := saveDraftStep(h.jobStore, h.appStore, h.drafts, draft, step, fill, found)
+		if apiErr != nil {
+			h.renderWizard(c, job, draft, step, values, apiErr)
+			return
+		}
+		c.Redirect(http.StatusSeeOther, wizardStepPath(job, saved.NextStep))
+		return
+	}
+
+	reviewed, apiErr := reviewDraft(h.jobStore, h.appStore, h.drafts, draft)
+	if apiErr != nil {
+		h.renderWizard(c, job, draft, step, values, apiErr)
+		return
+	}
+	app, apiErr := submitDraft(h.jobStore, h.appStore, h.drafts, reviewed)
+	if apiErr != nil {
+		h.renderWizard(c, job, draft, step, values, apiErr)
+		return
+	}
+	c.SetCookie(draftCookie, "", -1, "/jobs/"+job.ID+"/apply", "", false, true)
+	markSubmitted(c, app)
+	c.Redirect(http.StatusSeeOther, "/applications/"+app.ConfirmationID+"/success")
+}
+
+// renderWizard renders a step of the application wizard filled in with
+// values. The problems in apiErr, if any, are shown next to the step's
+// fields, or above the form for other fields, and set the response status.
+func (h *PageHandler) renderWizard(c *gin.Context, job models.Job, draft models.ApplicationDraft, step string, values models.ApplicationRequest, apiErr *apiError) {
+	fieldErrors, formErrors := formProblems(c, apiErr, func(field string) bool {
+		if step == models.StepQuestions {
+			return isQuestionField(job, field)
+		}
+		return slices.Contains(stepFields[step], field)
+	})
+
+	steps := make([]wizardStep, len(models.DraftSteps))
+	for i, name := range models.DraftSteps {
+		steps[i] = wizardStep{
+			Name:    name,
+			Label:   wizardStepLabels[name],
+			Number:  i + 1,
+			Done:    i < len(draft.CompletedSteps),
+			Current: name == step,
+		}
+	}
+	previous := ""
+	if i := slices.Index(models.DraftSteps, step); i > 0 {
+		previous = wizardStepPath(job, models.DraftSteps[i-1])
+	}
+
+	h.render(c, "apply_wizard.html", gin.H{
+		"Title":      "Apply for " + job.Title,
+		"Job":        job,
+		"Step":       step,
+		"StepLabel":  wizardStepLabels[step],
+		"Steps":      steps,
+		"Previous":   previous,
+		"Values":     values,
+		"Errors":     fieldErrors,
+		"FormErrors": formErrors,
+		"SignedIn":   h.signIn != nil,
+		"CSRFToken":  h.csrfToken(c),
+	})
+}
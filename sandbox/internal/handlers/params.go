@@ -0,0 +1,324 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// jobTypes are the accepted values of the type filter
+var jobTypes = []string{"full-time", "part-time", "internship", "contract"}
+
+// applicationStatuses are the accepted values of the status filter
+var applicationStatuses = []string{
+	string(models.StatusReceived),
+	string(models.StatusReviewing),
+	string(models.StatusSubmitted),
+	string(models.StatusRejected),
+	string(models.StatusShortlisted),
+	string(models.StatusWithdrawn),
+	string(models.StatusPendingVerification),
+	string(models.StatusAssignmentSubmitted),
+}
+
+// applicationOrders are the accepted values of the order parameter on
+// application lists, the first being the default
+var applicationOrders = []string{"newest", "oldest"}
+
+// queryParams reads query parameters for a request, collecting a violation
+// for every malformed one so they can all be reported in a single 400
+type queryParams struct {
+	c       *gin.Context
+	found   violations
+	clamped int // Limit applied after clamping, 0 when not clamped
+}
+
+// newQueryParams starts reading the query parameters of c
+func newQueryParams(c *gin.Context) *queryParams {
+	return &queryParams{c: c}
+}
+
+// limit reads ?limit=, returning def when it is absent or 0. Limits above
+// respond.MaxLimit are clamped to it rather than rejected, and the
+// response says so in X-Limit-Clamped. Unbounded reads are only offered
+// by export endpoints, through exportLimit.
+func (p *queryParams) limit(def int) int {
+	return p.readLimit("limit", def)
+}
+
+// readLimit reads a limit parameter following the contract of limit
+func (p *queryParams) readLimit(name string, def int) int {
+	raw, ok := p.c.GetQuery(name)
+	if !ok || raw == "" {
+		return def
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		accepted := fmt.Sprintf("a non-negative integer (values above %d are clamped)", respond.MaxLimit)
+		if raw == respond.LimitAll {
+			accepted += "; \"all\" is only accepted by export endpoints"
+		}
+		p.reject(name, raw, accepted)
+		return def
+	}
+	limit, clamped := respond.ClampLimit(n, def)
+	if clamped {
+		p.clamped = limit
+	}
+	return limit
+}
+
+// exportLimit reads ?limit= on export endpoints, where it is absent, 0 or
+// "all" for every result (returned as 0) and has no maximum
+func (p *queryParams) exportLimit() int {
+	raw, ok := p.c.GetQuery("limit")
+	if !ok || raw == "" || raw == respond.LimitAll {
+		return 0
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		p.reject("limit", raw, `a non-negative integer or "all"`)
+		return 0
+	}
+	return n
+}
+
+// offset reads ?offset=, defaulting to 0
+func (p *queryParams) offset() int {
+	raw, ok := p.c.GetQuery("offset")
+	if !ok || raw == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		p.reject("offset", raw, "a non-negative integer")
+		return 0
+	}
+	return n
+}
+
+// since reads ?since=, the sequence number of the last log entry a client
+// has seen, defaulting to 0
+func (p *queryParams) since() uint64 {
+	raw, ok := p.c.GetQuery("since")
+	if !ok || raw == "" {
+		return 0
+	}
+	n, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		p.reject("since", raw, "a non-negative integer")
+		return 0
+	}
+	return n
+}
+
+// page selects a page of a list, either by offset or after a cursor
+type page struct {
+	limit  int // 0 for every remaining item
+	offset int
+	cursor *respond.Cursor // nil for offset pagination
+}
+
+// page reads the pagination parameters of a list: ?page_size=, which
+// follows the limit contract and wins over ?limit=, and either ?offset=
+// or ?cursor=, a next_cursor issued by the list with the given name
+func (p *queryParams) page(list string, def int) page {
+	pg := page{limit: p.limit(def), offset: p.offset()}
+	if _, ok := p.c.GetQuery("page_size"); ok {
+		pg.limit = p.readLimit("page_size", def)
+	}
+
+	raw := p.c.Query("cursor")
+	if raw == "" {
+		return pg
+	}
+	cur, err := respond.DecodeCursor(raw, list)
+	if err != nil {
+		p.reject("cursor", raw, "a next_cursor returned by this list, with the same order")
+		return pg
+	}
+	if pg.offset > 0 {
+		p.reject("offset", p.c.Query("offset"), "0 or absent when a cursor is given")
+	}
+	pg.cursor = &cur
+	return pg
+}
+
+// paginate cuts the page out of items, sets the pagination headers and
+// returns the page with the cursor of the next one, "" when it is the
+// last. items are ordered by their store position, from the highest when
+// descending; position looks positions up by ID and id names an item.
+func paginate[T any](c *gin.Context, pg page, items []T, list string, id func(T) string, position func(string) (uint64, bool), descending bool) ([]T, string) {
+	var start, end int
+	if pg.cursor == nil {
+		start, end = respond.Window(len(items), pg.offset, pg.limit)
+	} else {
+		after, exists := position(pg.cursor.ID)
+		if !exists {
+			after = pg.cursor.Position
+		}
+		start = len(items)
+		for i, item := range items {
+			if pos, exists := position(id(item)); exists && (descending && pos < after || !descending && pos > after) {
+				start = i
+				break
+			}
+		}
+		end = len(items)
+		if pg.limit > 0 && start+pg.limit < end {
+			end = start + pg.limit
+		}
+	}
+
+	next := ""
+	if end < len(items) && end > start {
+		last := id(items[end-1])
+		pos, _ := position(last)
+		next = respond.Cursor{List: list, ID: last, Position: pos}.Encode()
+	}
+
+	if pg.cursor == nil {
+		respond.Paginate(c, len(items), pg.offset, pg.limit)
+	} else {
+		respond.PaginateCursor(c, len(items), next)
+	}
+	return items[start:end], next
+}
+
+// filter reads a free-text list filter, from filter[name] for JSON:API
+// requests
+func (p *queryParams) filter(name string) string {
+	return respond.Filter(p.c, name)
+}
+
+// enum reads a list filter that must be one of values, returning "" when
+// it is absent
+func (p *queryParams) enum(name string, values ...string) string {
+	raw := respond.Filter(p.c, name)
+	if raw == "" {
+		return ""
+	}
+	for _, value := range values {
+		if raw == value {
+			return raw
+		}
+	}
+	p.reject(p.filterName(name), raw, "one of "+strings.Join(values, ", "))
+	return ""
+}
+
+// count reads a list filter that must be a non-negative integer,
+// reporting whether it was given
+func (p *queryParams) count(name string) (int, bool) {
+	raw := respond.Filter(p.c, name)
+	if raw == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		p.reject(p.filterName(name), raw, "a non-negative integer")
+		return 0, false
+	}
+	return n, true
+}
+
+// jobFilter reads the job list filters, which all apply together
+func (p *queryParams) jobFilter() store.JobFilter {
+	f := store.JobFilter{
+		Query:    p.filter("q"),
+		JobType:  p.enum("type", jobTypes...),
+		Location: p.filter("location"),
+		Company:  p.filter("company"),
+	}
+	if remote := p.enum("remote", "true", "false"); remote != "" {
+		isRemote := remote == "true"
+		f.Remote = &isRemote
+	}
+	f.MinSalary, _ = p.count("min_salary")
+	f.MaxSalary, _ = p.count("max_salary")
+	if f.MinSalary > 0 && f.MaxSalary > 0 && f.MaxSalary < f.MinSalary {
+		p.reject(p.filterName("max_salary"), strconv.Itoa(f.MaxSalary), "at least min_salary")
+	}
+	if years, ok := p.count("min_experience"); ok {
+		f.MinExperience = &years
+	}
+	if years, ok := p.count("max_experience"); ok {
+		f.MaxExperience = &years
+	}
+	if f.MinExperience != nil && f.MaxExperience != nil && *f.MaxExperience < *f.MinExperience {
+		p.reject(p.filterName("max_experience"), strconv.Itoa(*f.MaxExperience), "at least min_experience")
+	}
+	f.HideClosed = !p.includeClosed()
+	return f
+}
+
+// includeClosed reads ?include_closed=, which adds draft and closed jobs
+// to job lists
+func (p *queryParams) includeClosed() bool {
+	return p.enum("include_closed", "true", "false") == "true"
+}
+
+// order reads ?order= for application lists, defaulting to newest first
+func (p *queryParams) order() store.Order {
+	if p.enum("order", applicationOrders...) == "oldest" {
+		return store.OldestFirst
+	}
+	return store.NewestFirst
+}
+
+// filterName is the query parameter a list filter is read from
+func (p *queryParams) filterName(name string) string {
+	if respond.IsJSONAPI(p.c) {
+		return "filter[" + name + "]"
+	}
+	return name
+}
+
+// reject records an invalid parameter value
+func (p *queryParams) reject(name, value, accepted string) {
+	p.found.add(name, "invalid_parameter", fmt.Sprintf("Invalid value %q for %s: must be %s.", value, name, accepted))
+}
+
+// check writes a 400 invalid_parameter error listing every invalid
+// parameter, reporting whether the handler may go on
+func (p *queryParams) check() bool {
+	switch len(p.found) {
+	case 0:
+		p.markClamped()
+		return true
+	case 1:
+		respond.Violations(p.c, http.StatusBadRequest, "invalid_parameter", p.found[0].Message, p.found)
+	default:
+		respond.Violations(p.c, http.StatusBadRequest, "invalid_parameter",
+			"Several query parameters are invalid. See violations for details.", p.found)
+	}
+	return false
+}
+
+// checkPage is check for HTML pages: invalid parameters get a plain-text
+// 400 with one line per violation
+func (p *queryParams) checkPage() bool {
+	if len(p.found) == 0 {
+		p.markClamped()
+		return true
+	}
+	messages := make([]string, len(p.found))
+	for i, v := range p.found {
+		messages[i] = v.Message
+	}
+	p.c.String(http.StatusBadRequest, strings.Join(messages, "\n"))
+	return false
+}
+
+// markClamped sets X-Limit-Clamped when the limit was clamped
+func (p *queryParams) markClamped() {
+	if p.clamped > 0 {
+		p.c.Header(respond.LimitClampedHeader, strconv.Itoa(p.clamped))
+	}
+}
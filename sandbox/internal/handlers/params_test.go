@@ -0,0 +1,330 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// paramCase is a query string, the value read from it and the parameter
+// it is rejected for, if any
+type paramCase struct {
+	query    string
+	want     interface{}
+	rejected string
+}
+
+// readParams reads query with read, returning the value read and the
+// parameters rejected
+func readParams(t *testing.T, query string, read func(p *queryParams) interface{}, header ...string) (interface{}, []string, *queryParams, *httptest.ResponseRecorder) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/?"+query, nil)
+	for i := 0; i+1 < len(header); i += 2 {
+		c.Request.Header.Set(header[i], header[i+1])
+	}
+	p := newQueryParams(c)
+	value := read(p)
+	var rejected []string
+	for _, v := range p.found {
+		if v.Code != "invalid_parameter" {
+			t.Errorf("violation %+v, want invalid_parameter", v)
+		}
+		rejected = append(rejected, v.Field)
+	}
+	return value, rejected, p, w
+}
+
+// runParamCases checks each case reads its value, or is rejected for the
+// parameter it names
+func runParamCases(t *testing.T, read func(p *queryParams) interface{}, cases []paramCase) {
+	t.Helper()
+	for _, tt := range cases {
+		got, rejected, _, _ := readParams(t, tt.query, read)
+		switch {
+		case tt.rejected != "":
+			if len(rejected) != 1 || rejected[0] != tt.rejected {
+				t.Errorf("?%s: rejected %v, want %s", tt.query, rejected, tt.rejected)
+			}
+		case len(rejected) > 0:
+			t.Errorf("?%s: rejected %v", tt.query, rejected)
+		case !reflect.DeepEqual(got, tt.want):
+			t.Errorf("?%s: read %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestLimitParam(t *testing.T) {
+	runParamCases(t, func(p *queryParams) interface{} { return p.limit(100) }, []paramCase{
+		{"", 100, ""},
+		{"limit=", 100, ""},
+		{"limit=0", 100, ""},
+		{"limit=1", 1, ""},
+		{"limit=25", 25, ""},
+		{"limit=500", 500, ""},
+		{"limit=501", respond.MaxLimit, ""},
+		{"limit=1000000", respond.MaxLimit, ""},
+		{"limit=abc", nil, "limit"},
+		{"limit=-5", nil, "limit"},
+		{"limit=2.5", nil, "limit"},
+		{"limit=all", nil, "limit"},
+		{"limit=99999999999999999999", nil, "limit"},
+	})
+
+	// Clamping is reported in a header once the parameters check out
+	_, _, p, _ := readParams(t, "limit=900", func(p *queryParams) interface{} { return p.limit(100) })
+	if !p.check() || p.c.Writer.Header().Get(respond.LimitClampedHeader) != "500" {
+		t.Errorf("clamped limit: %s %q, want 500", respond.LimitClampedHeader, p.c.Writer.Header().Get(respond.LimitClampedHeader))
+	}
+	_, _, p, _ = readParams(t, "limit=all", func(p *queryParams) interface{} { return p.limit(100) })
+	if !strings.Contains(p.found[0].Message, "only accepted by export endpoints") {
+		t.Errorf("limit=all message %q does not point to the export endpoints", p.found[0].Message)
+	}
+}
+
+func TestExportLimitParam(t *testing.T) {
+	runParamCases(t, func(p *queryParams) interface{} { return p.exportLimit() }, []paramCase{
+		{"", 0, ""},
+		{"limit=0", 0, ""},
+		{"limit=all", 0, ""},
+		{"limit=25", 25, ""},
+		{"limit=100000", 100000, ""},
+		{"limit=ALL", nil, "limit"},
+		{"limit=-1", nil, "limit"},
+		{"limit=ten", nil, "limit"},
+	})
+}
+
+func TestPageSizeParam(t *testing.T) {
+	runParamCases(t, func(p *queryParams) interface{} { return p.page(jobsList, 100).limit }, []paramCase{
+		{"page_size=10", 10, ""},
+		{"limit=20&page_size=10", 10, ""},
+		{"page_size=0", 100, ""},
+		{"page_size=9000", respond.MaxLimit, ""},
+		{"page_size=x", nil, "page_size"},
+		{"page_size=-1", nil, "page_size"},
+	})
+}
+
+func TestOffsetParam(t *testing.T) {
+	runParamCases(t, func(p *queryParams) interface{} { return p.offset() }, []paramCase{
+		{"", 0, ""},
+		{"offset=0", 0, ""},
+		{"offset=40", 40, ""},
+		{"offset=-1", nil, "offset"},
+		{"offset=1e3", nil, "offset"},
+		{"offset=%20", nil, "offset"},
+	})
+}
+
+func TestSinceParam(t *testing.T) {
+	runParamCases(t, func(p *queryParams) interface{} { return p.since() }, []paramCase{
+		{"", uint64(0), ""},
+		{"since=0", uint64(0), ""},
+		{"since=18446744073709551615", uint64(18446744073709551615), ""},
+		{"since=18446744073709551616", nil, "since"},
+		{"since=-1", nil, "since"},
+		{"since=latest", nil, "since"},
+	})
+}
+
+func TestCursorParam(t *testing.T) {
+	cursor := respond.Cursor{List: jobsList, ID: "job_002", Position: 2}.Encode()
+	runParamCases(t, func(p *queryParams) interface{} { return p.page(jobsList, 100).cursor != nil }, []paramCase{
+		{"", false, ""},
+		{"cursor=" + cursor, true, ""},
+		{"cursor=" + cursor + "&offset=0", true, ""},
+		{"cursor=" + cursor + "&offset=10", nil, "offset"},
+		{"cursor=not-a-cursor", nil, "cursor"},
+		// A cursor belongs to the list that issued it
+		{"cursor=" + respond.Cursor{List: applicationsList(store.NewestFirst), ID: "app"}.Encode(), nil, "cursor"},
+	})
+}
+
+func TestEnumParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		cases  []paramCase
+	}{
+		{"type", jobTypes, []paramCase{
+			{"", "", ""},
+			{"type=full-time", "full-time", ""},
+			{"type=internship", "internship", ""},
+			{"type=Full-Time", nil, "type"},
+			{"type=fulltime", nil, "type"},
+		}},
+		{"remote", []string{"true", "false"}, []paramCase{
+			{"remote=true", "true", ""},
+			{"remote=false", "false", ""},
+			{"remote=1", nil, "remote"},
+			{"remote=yes", nil, "remote"},
+		}},
+		{"status", applicationStatuses, []paramCase{
+			{"status=received", "received", ""},
+			{"status=assignment_submitted", "assignment_submitted", ""},
+			{"status=RECEIVED", nil, "status"},
+			{"status=hired", nil, "status"},
+		}},
+		{"order", applicationOrders, []paramCase{
+			{"order=newest", "newest", ""},
+			{"order=oldest", "oldest", ""},
+			{"order=asc", nil, "order"},
+		}},
+		{"format", exportFormats, []paramCase{
+			{"format=csv", "csv", ""},
+			{"format=xlsx", nil, "format"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runParamCases(t, func(p *queryParams) interface{} { return p.enum(tt.name, tt.values...) }, tt.cases)
+		})
+	}
+
+	// The message lists what is accepted
+	_, _, p, _ := readParams(t, "remote=yes", func(p *queryParams) interface{} { return p.enum("remote", "true", "false") })
+	if want := `Invalid value "yes" for remote: must be one of true, false.`; p.found[0].Message != want {
+		t.Errorf("message %q, want %q", p.found[0].Message, want)
+	}
+}
+
+func TestOrderParam(t *testing.T) {
+	runParamCases(t, func(p *queryParams) interface{} { return p.order() }, []paramCase{
+		{"", store.NewestFirst, ""},
+		{"order=newest", store.NewestFirst, ""},
+		{"order=oldest", store.OldestFirst, ""},
+		{"order=random", nil, "order"},
+	})
+}
+
+func TestIncludeClosedParam(t *testing.T) {
+	runParamCases(t, func(p *queryParams) interface{} { return p.includeClosed() }, []paramCase{
+		{"", false, ""},
+		{"include_closed=true", true, ""},
+		{"include_closed=false", false, ""},
+		{"include_closed=1", nil, "include_closed"},
+	})
+}
+
+func TestJobFilterParams(t *testing.T) {
+	yes, three, five := true, 3, 5
+	runParamCases(t, func(p *queryParams) interface{} { return p.jobFilter() }, []paramCase{
+		{"", store.JobFilter{HideClosed: true}, ""},
+		{"q=go&location=Berlin&company=Acme", store.JobFilter{Query: "go", Location: "Berlin", Company: "Acme", HideClosed: true}, ""},
+		{"remote=true&type=contract", store.JobFilter{Remote: &yes, JobType: "contract", HideClosed: true}, ""},
+		{"min_salary=100000&max_salary=150000", store.JobFilter{MinSalary: 100000, MaxSalary: 150000, HideClosed: true}, ""},
+		{"min_salary=100000&max_salary=100000", store.JobFilter{MinSalary: 100000, MaxSalary: 100000, HideClosed: true}, ""},
+		{"min_experience=3&max_experience=5", store.JobFilter{MinExperience: &three, MaxExperience: &five, HideClosed: true}, ""},
+		{"min_experience=0", store.JobFilter{MinExperience: new(int), HideClosed: true}, ""},
+		{"include_closed=true", store.JobFilter{}, ""},
+		{"min_salary=abc", nil, "min_salary"},
+		{"min_salary=-1", nil, "min_salary"},
+		{"max_salary=100k", nil, "max_salary"},
+		{"min_salary=150000&max_salary=100000", nil, "max_salary"},
+		{"min_experience=two", nil, "min_experience"},
+		{"max_experience=-2", nil, "max_experience"},
+		{"min_experience=5&max_experience=3", nil, "max_experience"},
+	})
+}
+
+// TestCheck checks every invalid parameter is reported in one 400, named
+// as the request sent it
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		message string
+		fields  []string
+	}{
+		{"one", "limit=abc", `Invalid value "abc" for limit: must be a non-negative integer (values above 500 are clamped).`, []string{"limit"}},
+		{"several", "limit=abc&type=gig&remote=maybe", "Several query parameters are invalid. See violations for details.", []string{"limit", "type", "remote"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, p, w := readParams(t, tt.query, func(p *queryParams) interface{} {
+				p.limit(100)
+				return p.jobFilter()
+			})
+			if p.check() {
+				t.Fatal("check passed")
+			}
+			var body models.ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("%v: %s", err, w.Body.String())
+			}
+			var fields []string
+			for _, v := range body.Violations {
+				fields = append(fields, v.Field)
+			}
+			if w.Code != http.StatusBadRequest || body.Error != "invalid_parameter" || body.Message != tt.message || !reflect.DeepEqual(fields, tt.fields) {
+				t.Errorf("status %d: %s, want 400 invalid_parameter %q for %v", w.Code, w.Body.String(), tt.message, tt.fields)
+			}
+		})
+	}
+
+	// JSON:API clients filter with filter[name], and errors name it so
+	_, rejected, p, w := readParams(t, "type=gig&filter[type]=gig", func(p *queryParams) interface{} { return p.jobFilter() },
+		"Accept", "application/vnd.api+json")
+	if p.check() || !reflect.DeepEqual(rejected, []string{"filter[type]"}) || !strings.Contains(w.Body.String(), `"source":{"parameter":"filter[type]"}`) {
+		t.Errorf("JSON:API: rejected %v: %s, want filter[type]", rejected, w.Body.String())
+	}
+}
+
+// TestParamsOnEndpoints checks the list endpoints reject bad parameters
+// through the same helper
+func TestParamsOnEndpoints(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	jobStore, appStore := newTestStores(t)
+	jobs := NewJobHandler(jobStore, appStore)
+	apps := NewApplicationHandler(jobStore, appStore, store.NewApplicantStore(), nil)
+	r := gin.New()
+	r.GET("/api/jobs", jobs.ListJobs)
+	r.GET("/api/jobs/search", jobs.SearchJobs)
+	r.GET("/api/jobs/stream", jobs.StreamJobs)
+	r.GET("/api/jobs/facets", jobs.GetJobFacets)
+	r.GET("/api/companies/:company/jobs", jobs.GetJobsByCompany)
+	r.GET("/api/applications", apps.ListApplications)
+	r.GET("/api/applications/export", apps.ExportApplications)
+
+	tests := []struct {
+		path  string
+		field string
+	}{
+		{"/api/jobs?limit=abc", "limit"},
+		{"/api/jobs?offset=-1", "offset"},
+		{"/api/jobs?type=gig", "type"},
+		{"/api/jobs?remote=yes", "remote"},
+		{"/api/jobs?min_salary=lots", "min_salary"},
+		{"/api/jobs/search?q=go&include_closed=yes", "include_closed"},
+		{"/api/jobs/search?q=go&page_size=-3", "page_size"},
+		{"/api/jobs/stream?limit=-1", "limit"},
+		{"/api/jobs/facets?max_experience=x", "max_experience"},
+		{"/api/companies/Acme/jobs?limit=x", "limit"},
+		{"/api/applications?status=hired", "status"},
+		{"/api/applications?order=asc", "order"},
+		{"/api/applications?cursor=bogus", "cursor"},
+		{"/api/applications/export?format=xlsx", "format"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		var body models.ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("%s: %v: %s", tt.path, err, w.Body.String())
+		}
+		if w.Code != http.StatusBadRequest || body.Error != "invalid_parameter" || len(body.Violations) != 1 || body.Violations[0].Field != tt.field {
+			t.Errorf("%s: status %d: %s, want 400 invalid_parameter for %s", tt.path, w.Code, w.Body.String(), tt.field)
+		}
+	}
+}
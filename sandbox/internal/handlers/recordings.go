@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// recordingFormats are the formats GET /admin/recordings/:run_id exports
+var recordingFormats = []string{"har", "jsonl"}
+
+// RecordingHandler exports the requests the recorder captured during runs
+type RecordingHandler struct {
+	runStore *store.RunStore
+}
+
+// NewRecordingHandler creates a new recording handler
+func NewRecordingHandler(runStore *store.RunStore) *RecordingHandler {
+	return &RecordingHandler{runStore: runStore}
+}
+
+// GetRecordings handles GET /admin/recordings/:run_id
+// Returns every request of the run with its response, as a HAR document
+// (the default) or with ?format=jsonl as one JSON recording per line
+func (h *RecordingHandler) GetRecordings(c *gin.Context) {
+	params := newQueryParams(c)
+	format := params.enum("format", recordingFormats...)
+	if !params.check() {
+		return
+	}
+
+	runID := c.Param("run_id")
+	recordings, truncated, exists := h.runStore.Recordings(runID)
+	if !exists {
+		respond.Error(c, http.StatusNotFound, "run_not_found", "The specified run could not be found.")
+		return
+	}
+	if truncated {
+		c.Header("X-Recordings-Truncated", "true")
+	}
+
+	if format == "jsonl" {
+		c.Header("Content-Disposition", `attachment; filename="`+runID+`.jsonl"`)
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Status(http.StatusOK)
+		encoder := json.NewEncoder(c.Writer)
+		for _, recording := range recordings {
+			if err := encoder.Encode(recording); err != nil {
+				return
+			}
+		}
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="`+runID+`.har"`)
+	c.JSON(http.StatusOK, harDocument(recordings, truncated))
+}
+
+// harDocument converts recordings to an HTTP Archive
+func harDocument(recordings []models.Recording, truncated bool) models.HAR {
+	har := models.HAR{Log: models.HARLog{
+		Version: "1.2",
+		Creator: models.HARCreator{Name: "job-portal-sandbox", Version: Version},
+		Entries: make([]models.HAREntry, 0, len(recordings)),
+	}}
+	if truncated {
+		har.Log.Comment = "Only the first recordings of the run were kept."
+	}
+
+	for _, r := range recordings {
+		entry := models.HAREntry{
+			StartedDateTime: r.StartedAt.Format(time.RFC3339Nano),
+			Time:            r.DurationMs,
+			Request: models.HARRequest{
+				Method:      r.Method,
+				URL:         r.URL,
+				HTTPVersion: r.Proto,
+				Cookies:     []models.HARNameValue{},
+				Headers:     harHeaders(r.RequestHeaders),
+				QueryString: harQuery(r.URL),
+				HeadersSize: -1,
+				BodySize:    r.RequestBody.Size,
+			},
+			Response: models.HARResponse{
+				Status:      r.Status,
+				StatusText:  http.StatusText(r.Status),
+				HTTPVersion: r.Proto,
+				Cookies:     []models.HARNameValue{},
+				Headers:     harHeaders(r.ResponseHeaders),
+				Content: models.HARContent{
+					Size:     r.ResponseBody.Size,
+					MimeType: headerValue(r.ResponseHeaders, "Content-Type"),
+					Text:     r.ResponseBody.Text,
+					Encoding: r.ResponseBody.Encoding,
+					Comment:  truncatedComment(r.ResponseBody),
+				},
+				RedirectURL: headerValue(r.ResponseHeaders, "Location"),
+				HeadersSize: -1,
+				BodySize:    r.ResponseBody.Size,
+			},
+			Timings: models.HARTimings{Wait: r.DurationMs},
+		}
+		if r.RequestBody.Size > 0 {
+			entry.Request.PostData = &models.HARPostData{
+				MimeType: headerValue(r.RequestHeaders, "Content-Type"),
+				Text:     r.RequestBody.Text,
+				Comment:  truncatedComment(r.RequestBody),
+			}
+			if r.RequestBody.Encoding != "" {
+				// HAR has no encoding for request bodies, so say so instead
+				entry.Request.PostData.Comment = strings.TrimSpace("Base64-encoded. " + entry.Request.PostData.Comment)
+			}
+		}
+		har.Log.Entries = append(har.Log.Entries, entry)
+	}
+	return har
+}
+
+// harHeaders lists headers as HAR name/value pairs, one per value
+func harHeaders(headers map[string][]string) []models.HARNameValue {
+	pairs := []models.HARNameValue{}
+	for _, name := range slices.Sorted(maps.Keys(headers)) {
+		for _, value := range headers[name] {
+			pairs = append(pairs, models.HARNameValue{Name: name, Value: value})
+		}
+	}
+	return pairs
+}
+
+// harQuery lists the query parameters of a URL as HAR name/value pairs
+func harQuery(rawURL string) []models.HARNameValue {
+	pairs := []models.HARNameValue{}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return pairs
+	}
+	query := u.Query()
+	for _, name := range slices.Sorted(maps.Keys(query)) {
+		for _, value := range query[name] {
+			pairs = append(pairs, models.HARNameValue{Name: name, Value: value})
+		}
+	}
+	return pairs
+}
+
+// headerValue returns the first value of a header, "" when it is missing
+func headerValue(headers map[string][]string, name string) string {
+	return http.Header(headers).Get(name)
+}
+
+// truncatedComment notes a body of which only the start was recorded
+func truncatedComment(body models.RecordedBody) string {
+	if !body.Truncated {
+		return ""
+	}
+	return "Only the start of the body was recorded."
+}
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// reviewLatencyCacheTTL bounds how stale pending ages may get between recomputations
+const reviewLatencyCacheTTL = 5 * time.Second
+
+// latencyBucketBounds are the upper bounds of the review latency histogram buckets
+var latencyBucketBounds = []struct {
+	label string
+	bound time.Duration
+}{
+	{"1m", time.Minute},
+	{"5m", 5 * time.Minute},
+	{"15m", 15 * time.Minute},
+	{"1h", time.Hour},
+	{"6h", 6 * time.Hour},
+	{"24h", 24 * time.Hour},
+	{"7d", 7 * 24 * time.Hour},
+}
+
+// latencyPercentiles are the percentiles reported for each distribution
+var latencyPercentiles = []struct {
+	label string
+	p     float64
+}{
+	{"p50", 0.50},
+	{"p90", 0.90},
+	{"p95", 0.95},
+	{"p99", 0.99},
+}
+
+// reviewLatencyCache caches computed review latency responses per grouping
+type reviewLatencyCache struct {
+	mu      sync.Mutex
+	entries map[string]reviewLatencyCacheEntry
+}
+
+type reviewLatencyCacheEntry struct {
+	version  uint64
+	computed time.Time
+	response models.ReviewLatencyResponse
+}
+
+func newReviewLatencyCache() *reviewLatencyCache {
+	return &reviewLatencyCache{
+		entries: make(map[string]reviewLatencyCacheEntry),
+	}
+}
+
+// GetReviewLatency handles GET /api/stats/review-latency
+// Returns how long applications take to leave the "received" status
+func (h *HealthHandler) GetReviewLatency(c *gin.Context) {
+	by := c.Query("by")
+	if by != "" && by != "company" {
+		respond.Error(c, http.StatusBadRequest, "invalid_grouping", "Invalid value for 'by'. Valid values: company")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.reviewLatency(by == "company"))
+}
+
+// reviewLatency returns the cached review latency response, recomputing it
+// when the application store has changed or the cached entry has expired
+func (h *HealthHandler) reviewLatency(byCompany bool) models.ReviewLatencyResponse {
+	key := "all"
+	if byCompany {
+		key = "company"
+	}
+
+	version := h.appStore.Version()
+	now := time.Now()
+
+	h.latencyCache.mu.Lock()
+	defer h.latencyCache.mu.Unlock()
+
+	if entry, ok := h.latencyCache.entries[key]; ok &&
+		entry.version == version && now.Sub(entry.computed) < reviewLatencyCacheTTL {
+		return entry.response
+	}
+
+	response := computeReviewLatency(h.appStore.GetReviewTimings(), byCompany, now)
+	h.latencyCache.entries[key] = reviewLatencyCacheEntry{
+		version:  version,
+		computed: now,
+		response: response,
+	}
+
+	return response
+}
+
+// computeReviewLatency builds the review latency response from stored timestamps
+func computeReviewLatency(timings []store.ReviewTiming, byCompany bool, now time.Time) models.ReviewLatencyResponse {
+	var reviewed, pending []time.Duration
+	companyReviewed := make(map[string][]time.Duration)
+	companyPending := make(map[string][]time.Duration)
+
+	for _, t := range timings {
+		if t.FirstStatusChangeAt != nil {
+			d := t.FirstStatusChangeAt.Sub(t.SubmittedAt)
+			reviewed = append(reviewed, d)
+			companyReviewed[t.Company] = append(companyReviewed[t.Company], d)
+		} else {
+			d := now.Sub(t.SubmittedAt)
+			pending = append(pending, d)
+			companyPending[t.Company] = append(companyPending[t.Company], d)
+		}
+	}
+
+	response := models.ReviewLatencyResponse{
+		ReviewLatencyGroup: models.ReviewLatencyGroup{
+			Reviewed: summarizeLatencies(reviewed),
+			Pending:  summarizeLatencies(pending),
+		},
+		ComputedAt: now.Format(time.RFC3339),
+	}
+
+	if byCompany {
+		response.ByCompany = make(map[string]models.ReviewLatencyGroup)
+		for _, t := range timings {
+			if _, done := response.ByCompany[t.Company]; done {
+				continue
+			}
+			response.ByCompany[t.Company] = models.ReviewLatencyGroup{
+				Reviewed: summarizeLatencies(companyReviewed[t.Company]),
+				Pending:  summarizeLatencies(companyPending[t.Company]),
+			}
+		}
+	}
+
+	return response
+}
+
+// summarizeLatencies computes the histogram and percentiles for a set of durations
+func summarizeLatencies(durations []time.Duration) models.LatencyDistribution {
+	dist := models.LatencyDistribution{
+		Count:       len(durations),
+		Percentiles: make(map[string]float64),
+		Buckets:     make([]models.LatencyBucket, 0, len(latencyBucketBounds)+1),
+	}
+
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	// Cumulative buckets, Prometheus style
+	idx := 0
+	for _, b := range latencyBucketBounds {
+		for idx < len(sorted) && sorted[idx] <= b.bound {
+			idx++
+		}
+		dist.Buckets = append(dist.Buckets, models.LatencyBucket{Le: b.label, Count: idx})
+	}
+	dist.Buckets = append(dist.Buckets, models.LatencyBucket{Le: "+Inf", Count: len(sorted)})
+
+	if len(sorted) == 0 {
+		return dist
+	}
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+
+	dist.MinSeconds = roundSeconds(sorted[0])
+	dist.MaxSeconds = roundSeconds(sorted[len(sorted)-1])
+	dist.MeanSeconds = roundSeconds(total / time.Duration(len(sorted)))
+
+	for _, p := range latencyPercentiles {
+		// Nearest-rank percentile
+		rank := int(math.Ceil(p.p*float64(len(sorted)))) - 1
+		if rank < 0 {
+			rank = 0
+		}
+		dist.Percentiles[p.label] = roundSeconds(sorted[rank])
+	}
+
+	return dist
+}
+
+// roundSeconds converts a duration to seconds rounded to milliseconds
+func roundSeconds(d time.Duration) float64 {
+	return math.Round(d.Seconds()*1000) / 1000
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+)
+
+// TestReviewLatencyCacheFollowsVersion checks a cached response is reused
+// while the applications are unchanged and recomputed as soon as they
+// change, however fresh it is
+func TestReviewLatencyCacheFollowsVersion(t *testing.T) {
+	jobStore, appStore := newTestStores(t)
+	h := NewHealthHandler(jobStore, appStore, store.NewWebhookStore())
+
+	if got := h.reviewLatency(false); got.Pending.Count != 0 || got.Reviewed.Count != 0 {
+		t.Fatalf("empty store: %d pending, %d reviewed, want none", got.Pending.Count, got.Reviewed.Count)
+	}
+	computed := h.latencyCache.entries["all"].computed
+	h.reviewLatency(false)
+	if again := h.latencyCache.entries["all"].computed; !again.Equal(computed) {
+		t.Errorf("unchanged store recomputed at %v, want the response cached at %v", again, computed)
+	}
+
+	app, err := appStore.Create(testApplication("ann@example.com"), openJob, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := h.reviewLatency(false); got.Pending.Count != 1 || got.Reviewed.Count != 0 {
+		t.Errorf("after a submission: %d pending, %d reviewed, want 1 pending", got.Pending.Count, got.Reviewed.Count)
+	}
+	if got := h.reviewLatency(true); got.ByCompany[openJob.Company].Pending.Count != 1 {
+		t.Errorf("by company after a submission: %+v, want 1 pending at %s", got.ByCompany, openJob.Company)
+	}
+
+	if _, err := appStore.UpdateStatus(app.ID, models.StatusReviewing, "", models.ActorAPI); err != nil {
+		t.Fatal(err)
+	}
+	if got := h.reviewLatency(false); got.Pending.Count != 0 || got.Reviewed.Count != 1 {
+		t.Errorf("after a review: %d pending, %d reviewed, want 1 reviewed", got.Pending.Count, got.Reviewed.Count)
+	}
+	if got := h.reviewLatency(true); got.ByCompany[openJob.Company].Reviewed.Count != 1 {
+		t.Errorf("by company after a review: %+v, want 1 reviewed at %s", got.ByCompany, openJob.Company)
+	}
+}
+
+// TestReviewLatencyCacheExpires checks pending ages, which grow without
+// the store changing, are recomputed once the cached response is older
+// than reviewLatencyCacheTTL
+func TestReviewLatencyCacheExpires(t *testing.T) {
+	jobStore, appStore := newTestStores(t)
+	h := NewHealthHandler(jobStore, appStore, store.NewWebhookStore())
+	if _, err := appStore.Create(testApplication("ann@example.com"), openJob, nil); err != nil {
+		t.Fatal(err)
+	}
+	h.reviewLatency(false)
+
+	// Mark the cached response, so serving it again shows
+	const marked = 3600
+	entry := h.latencyCache.entries["all"]
+	entry.response.Pending.MaxSeconds = marked
+	h.latencyCache.entries["all"] = entry
+	if got := h.reviewLatency(false); got.Pending.MaxSeconds != marked {
+		t.Errorf("within the TTL: pending for %vs, want the cached %vs", got.Pending.MaxSeconds, marked)
+	}
+
+	entry.computed = entry.computed.Add(-reviewLatencyCacheTTL)
+	h.latencyCache.entries["all"] = entry
+	if got := h.reviewLatency(false); got.Pending.MaxSeconds >= marked {
+		t.Errorf("past the TTL: pending for %vs, want the age recomputed from the submission", got.Pending.MaxSeconds)
+	}
+	if computed := h.latencyCache.entries["all"].computed; !computed.After(entry.computed) {
+		t.Errorf("cache entry computed at %v, want it replaced", computed)
+	}
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// RunHandler starts agent runs and reports on them
+type RunHandler struct {
+	runStore *store.RunStore
+	appStore *store.ApplicationStore
+}
+
+// NewRunHandler creates a new run handler
+func NewRunHandler(runStore *store.RunStore, appStore *store.ApplicationStore) *RunHandler {
+	return &RunHandler{runStore: runStore, appStore: appStore}
+}
+
+// CreateRun handles POST /api/runs
+// Starts a run; requests sending its ID in X-Run-ID are recorded against it
+func (h *RunHandler) CreateRun(c *gin.Context) {
+	var req models.RunRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respond.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body: "+err.Error())
+		return
+	}
+
+	run := h.runStore.Create(req.Label)
+	c.Header("Location", run.ReportURL)
+	c.JSON(http.StatusCreated, run)
+}
+
+// GetReport handles GET /api/runs/:id/report
+// Returns what the run's requests did: counts, errors, retries and submissions
+func (h *RunHandler) GetReport(c *gin.Context) {
+	report, exists := h.runStore.Report(c.Param("id"))
+	if !exists {
+		respond.Error(c, http.StatusNotFound, "run_not_found", "The specified run could not be found.")
+		return
+	}
+	respond.Data(c, http.StatusOK, report)
+}
+
+// ExportRun handles GET /api/runs/:id/export
+// Streams the applications the run submitted, flattened as in
+// GET /api/applications/export, or with ?data=requests the requests it
+// made. CSV is the default; ?format=jsonl writes one JSON object per line.
+// Applications since cleared are left out.
+func (h *RunHandler) ExportRun(c *gin.Context) {
+	params := newQueryParams(c)
+	format := params.enum("format", exportFormats...)
+	data := params.enum("data", "applications", "requests")
+	if !params.check() {
+		return
+	}
+
+	runID := c.Param("id")
+	report, exists := h.runStore.Report(runID)
+	if !exists {
+		respond.Error(c, http.StatusNotFound, "run_not_found", "The specified run could not be found.")
+		return
+	}
+	if data == "requests" {
+		if report.RequestsTruncated {
+			c.Header("X-Requests-Truncated", "true")
+		}
+		writeExport(c, format, runID+"-requests", requestTable(report.Requests))
+		return
+	}
+
+	apps := make([]*models.Application, 0, len(report.Applications))
+	for _, id := range report.Applications {
+		if app, exists := h.appStore.GetByID(id); exists {
+			apps = append(apps, app)
+		}
+	}
+	writeExport(c, format, runID+"-applications", applicationTable(apps))
+}
+
+// SetProfile handles PUT /api/runs/:id/profile
+// Uploads the ground truth about the candidate the run's agent applies as.
+// Applications the run submits from then on are checked for claims that
+// contradict it.
+func (h *RunHandler) SetProfile(c *gin.Context) {
+	var req models.CandidateProfile
+	found, apiErr := decodeJSON(c, &req)
+	if apiErr == nil {
+		apiErr = validateProfile(&req, found).errAbout("The profile has several problems. See violations for details.")
+	}
+	if apiErr != nil {
+		respond.Violations(c, apiErr.status, apiErr.code, apiErr.message, apiErr.violations)
+		return
+	}
+
+	if !h.runStore.SetProfile(c.Param("id"), req) {
+		respond.Error(c, http.StatusNotFound, "run_not_found", "The specified run could not be found.")
+		return
+	}
+	c.JSON(http.StatusOK, req)
+}
+
+// validateProfile collects every problem with a candidate profile
+func validateProfile(req *models.CandidateProfile, found violations) violations {
+	found.addBinding(req)
+	if len(req.Skills) > models.MaxApplicantSkills {
+		found.add("skills", "too_many_skills", fmt.Sprintf("skills may list at most %d skills.", models.MaxApplicantSkills))
+	}
+	for i, skill := range req.Skills {
+		field := fmt.Sprintf("skills[%d]", i)
+		if strings.TrimSpace(skill) == "" {
+			found.add(field, "invalid_skill", "Skills must not be blank.")
+		}
+		found.addLength(field, skill, maxSkillLength)
+	}
+	for i, degree := range req.Degrees {
+		if strings.TrimSpace(degree) == "" {
+			found.add(fmt.Sprintf("degrees[%d]", i), "invalid_degree", "Degrees must not be blank.")
+		}
+	}
+	return found
+}
+
+// CompleteRun handles POST /api/runs/:id/complete
+// Stops recording the run and puts it on the leaderboard
+func (h *RunHandler) CompleteRun(c *gin.Context) {
+	run, err := h.runStore.Complete(c.Param("id"))
+	if err != nil {
+		if strings.Contains(err.Error(), "already completed") {
+			respond.Error(c, http.StatusConflict, "run_completed", "The run has already been completed.")
+			return
+		}
+		respond.Error(c, http.StatusNotFound, "run_not_found", "The specified run could not be found.")
+		return
+	}
+	c.JSON(http.StatusOK, run)
+}
+
+// GetLeaderboard handles GET /api/leaderboard
+// Ranks the completed runs by score, with the active ones too when
+// ?include_active=true
+func (h *RunHandler) GetLeaderboard(c *gin.Context) {
+	params := newQueryParams(c)
+	includeActive := params.enum("include_active", "true", "false") == "true"
+	limit := params.limit(100)
+	if !params.check() {
+		return
+	}
+
+	entries := h.runStore.Leaderboard(includeActive)
+	c.JSON(http.StatusOK, models.LeaderboardResponse{
+		Entries: entries[:min(limit, len(entries))],
+		Total:   len(entries),
+		Weights: store.LeaderboardWeights,
+	})
+}
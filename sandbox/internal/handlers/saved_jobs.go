@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/emailaddr"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// maxSavedJobNote is the longest note a saved job may have, in characters
+const maxSavedJobNote = 1000
+
+// SavedJobHandler lets applicants bookmark jobs to apply to later
+type SavedJobHandler struct {
+	jobStore  *store.JobStore
+	appStore  *store.ApplicationStore
+	savedJobs *store.SavedJobStore
+}
+
+// NewSavedJobHandler creates a new saved job handler
+func NewSavedJobHandler(jobStore *store.JobStore, appStore *store.ApplicationStore, savedJobs *store.SavedJobStore) *SavedJobHandler {
+	return &SavedJobHandler{jobStore: jobStore, appStore: appStore, savedJobs: savedJobs}
+}
+
+// SaveJob handles POST /api/saved-jobs
+// Bookmarks a job for an email address. Saving a job again answers 200
+// rather than 201 and replaces its note.
+func (h *SavedJobHandler) SaveJob(c *gin.Context) {
+	var req models.SaveJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respond.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body: "+err.Error())
+		return
+	}
+
+	saved, created, apiErr := saveJob(h.jobStore, h.appStore, h.savedJobs, req)
+	if apiErr != nil {
+		respond.Error(c, apiErr.status, apiErr.code, apiErr.message)
+		return
+	}
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	c.JSON(status, saved)
+}
+
+// GetSavedJobs handles GET /api/saved-jobs
+// Returns the jobs ?email= has saved, most recently saved first
+func (h *SavedJobHandler) GetSavedJobs(c *gin.Context) {
+	// Applicants only see their own, and need not name themselves
+	email, apiErr := applicantScope(c.Request.Context(), c.Query("email"))
+	if apiErr != nil {
+		respond.Error(c, apiErr.status, apiErr.code, apiErr.message)
+		return
+	}
+	if email == "" {
+		respond.Error(c, http.StatusBadRequest, "missing_email", "Query parameter 'email' is required.")
+		return
+	}
+
+	saved := savedJobsFor(h.jobStore, h.appStore, h.savedJobs, email)
+	c.JSON(http.StatusOK, models.SavedJobsResponse{
+		Email:     email,
+		SavedJobs: saved,
+		Total:     len(saved),
+	})
+}
+
+// UnsaveJob handles DELETE /api/saved-jobs/:job_id
+// Removes a job from the saved jobs of ?email=
+func (h *SavedJobHandler) UnsaveJob(c *gin.Context) {
+	email := c.Query("email")
+	if email == "" {
+		respond.Error(c, http.StatusBadRequest, "missing_email", "Query parameter 'email' is required.")
+		return
+	}
+	if !h.savedJobs.Remove(email, c.Param("job_id")) {
+		respond.Error(c, http.StatusNotFound, "saved_job_not_found", "This job is not among the saved jobs of this email address.")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// saveJob bookmarks the job a request names, turning the store's errors
+// into API errors
+func saveJob(jobStore *store.JobStore, appStore *store.ApplicationStore, savedJobs *store.SavedJobStore, req models.SaveJobRequest) (models.SavedJob, bool, *apiError) {
+	email, err := emailaddr.Validate(req.Email, appStore.EmailRules())
+	if err != nil {
+		return models.SavedJob{}, false, &apiError{status: http.StatusBadRequest, code: "invalid_email", message: "Please provide a valid email address."}
+	}
+	if utf8.RuneCountInString(req.Note) > maxSavedJobNote {
+		return models.SavedJob{}, false, &apiError{status: http.StatusBadRequest, code: "too_long", message: "note must be at most " + strconv.Itoa(maxSavedJobNote) + " characters."}
+	}
+	job, exists := jobStore.GetByID(req.JobID)
+	if !exists {
+		return models.SavedJob{}, false, &apiError{status: http.StatusNotFound, code: "job_not_found", message: "The requested job could not be found."}
+	}
+
+	saved, created, err := savedJobs.Save(email, job.ID, req.Note)
+	if err != nil {
+		if strings.Contains(err.Error(), "too many saved jobs") {
+			return models.SavedJob{}, false, &apiError{status: http.StatusConflict, code: "too_many_saved_jobs", message: "This email address has saved as many jobs as it may. Remove some first."}
+		}
+		return models.SavedJob{}, false, &apiError{status: http.StatusInternalServerError, code: "storage_failed", message: "Failed to save job: " + err.Error()}
+	}
+	saved.Job = &job
+	saved.Applied = appliedJobs(appStore, email)[job.ID]
+	return saved, created, nil
+}
+
+// savedJobsFor returns the jobs email has saved, most recently saved first,
+// each with its posting as it is now
+func savedJobsFor(jobStore *store.JobStore, appStore *store.ApplicationStore, savedJobs *store.SavedJobStore, email string) []models.SavedJob {
+	applied := appliedJobs(appStore, email)
+	saved := savedJobs.List(email)
+	for i := range saved {
+		if job, exists := jobStore.GetByID(saved[i].JobID); exists {
+			saved[i].Job = &job
+		}
+		saved[i].Applied = applied[saved[i].JobID]
+	}
+	return saved
+}
+
+// appliedJobs returns the IDs of the jobs email has an application to that
+// has not been withdrawn
+func appliedJobs(appStore *store.ApplicationStore, email string) map[string]bool {
+	applied := make(map[string]bool)
+	for _, app := range appStore.GetByEmail(email, store.NewestFirst) {
+		if app.Status != models.StatusWithdrawn {
+			applied[app.JobID] = true
+		}
+	}
+	return applied
+}
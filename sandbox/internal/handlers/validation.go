@@ -0,0 +1,380 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/emailaddr"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/links"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/phone"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// requiredMessages are the messages for missing application fields
+var requiredMessages = map[string]string{
+	"job_id":          "Job ID is required.",
+	"applicant_name":  "Applicant name is required.",
+	"applicant_email": "Applicant email is required.",
+	"resume":          "Resume is required.",
+}
+
+// phoneMessages are the violation messages for phone.Normalize errors
+var phoneMessages = map[error]string{
+	phone.ErrInvalidCharacters: "Phone number may only contain digits, spaces, dashes, dots, parentheses and a leading +.",
+	phone.ErrTooShort:          "Phone number has too few digits.",
+	phone.ErrTooLong:           "Phone number has too many digits.",
+	phone.ErrNoCountryCode:     "Phone number must start with a country code after +.",
+}
+
+// profileLink is an application field holding a profile URL
+type profileLink struct {
+	field     string
+	value     *string
+	normalize func(string) (string, error)
+}
+
+// linkMessages are the violation messages for links errors, per field
+var linkMessages = map[string]map[error]string{
+	"linkedin": {
+		links.ErrNotURL:       "LinkedIn must be an https URL or a handle such as in/johndoe.",
+		links.ErrInsecure:     "LinkedIn URL must use https.",
+		links.ErrHostNotAllow: "LinkedIn URL must point at linkedin.com.",
+	},
+	"github": {
+		links.ErrNotURL:       "GitHub must be an https URL or a GitHub username.",
+		links.ErrInsecure:     "GitHub URL must use https.",
+		links.ErrHostNotAllow: "GitHub URL must point at github.com.",
+	},
+	"portfolio": {
+		links.ErrNotURL:   "Portfolio must be an https URL.",
+		links.ErrInsecure: "Portfolio URL must use https.",
+	},
+}
+
+// workAuthorizationValues lists the accepted work authorization values
+func workAuthorizationValues() string {
+	values := make([]string, len(models.WorkAuthorizations))
+	for i, option := range models.WorkAuthorizations {
+		values[i] = string(option.Value)
+	}
+	return strings.Join(values, ", ")
+}
+
+// unprocessableCodes are the violation codes of well-formed requests the
+// server will not accept, reported as 422 rather than 400
+var unprocessableCodes = map[string]bool{
+	"too_long":                   true,
+	"invalid_work_authorization": true,
+}
+
+// violations collects the validation failures of a request
+type violations []models.Violation
+
+// add records a failure, keeping only the first one per field
+func (v *violations) add(field, code, message string) {
+	if v.has(field) {
+		return
+	}
+	*v = append(*v, models.Violation{Field: field, Code: code, Message: message})
+}
+
+// has reports whether a field already failed
+func (v violations) has(field string) bool {
+	for _, existing := range v {
+		if existing.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// addBinding translates the binding rule failures of the struct value into
+// violations named by json field
+func (v *violations) addBinding(value interface{}) {
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(binding.Validator.ValidateStruct(value), &fieldErrors) {
+		return
+	}
+
+	t := reflect.Indirect(reflect.ValueOf(value)).Type()
+	for _, fe := range fieldErrors {
+		field := fe.Field()
+		if sf, ok := t.FieldByName(fe.StructField()); ok {
+			if name := strings.Split(sf.Tag.Get("json"), ",")[0]; name != "" {
+				field = name
+			}
+		}
+
+		switch fe.Tag() {
+		case "required":
+			message := requiredMessages[field]
+			if message == "" {
+				message = field + " is required."
+			}
+			v.add(field, "missing_"+field, message)
+		case "email":
+			v.add(field, "invalid_email", "Please provide a valid email address.")
+		case "oneof":
+			v.add(field, "invalid_"+field, field+" must be one of: "+strings.Join(strings.Fields(fe.Param()), ", ")+".")
+		default:
+			v.add(field, "invalid_"+field, field+" is invalid.")
+		}
+	}
+}
+
+// addLengths records a too_long violation for every text field of req
+// longer than its limit
+func (v *violations) addLengths(req *models.ApplicationRequest, limits models.ApplicationLimits) {
+	v.addLength("applicant_name", req.ApplicantName, limits.ApplicantName)
+	v.addLength("resume", req.Resume, limits.Resume)
+	v.addLength("cover_letter", req.CoverLetter, limits.CoverLetter)
+
+	keys := make([]string, 0, len(req.CustomAnswers))
+	for key := range req.CustomAnswers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	total := 0
+	for _, key := range keys {
+		answer := req.CustomAnswers[key]
+		v.addLength("custom_answers."+key, answer, limits.CustomAnswer)
+		total += utf8.RuneCountInString(answer)
+	}
+	if limits.CustomAnswersTotal > 0 && total > limits.CustomAnswersTotal {
+		v.addLimit("custom_answers", fmt.Sprintf("custom_answers must be at most %d characters in total (got %d).", limits.CustomAnswersTotal, total), limits.CustomAnswersTotal)
+	}
+}
+
+// addLength records a too_long violation if value has more than limit
+// characters; a zero limit allows any length
+func (v *violations) addLength(field, value string, limit int) {
+	if limit <= 0 {
+		return
+	}
+	if n := utf8.RuneCountInString(value); n > limit {
+		v.addLimit(field, fmt.Sprintf("%s must be at most %d characters (got %d).", field, limit, n), limit)
+	}
+}
+
+// addLimit records a too_long violation
+func (v *violations) addLimit(field, message string, limit int) {
+	if v.has(field) {
+		return
+	}
+	*v = append(*v, models.Violation{Field: field, Code: "too_long", Message: message, Limit: limit})
+}
+
+// err turns the collected violations into an error, or nil if there are
+// none. A single violation keeps its own code (and 404 for an unknown job,
+// 410 for a closed or filled one, 409 for a paused or draft one) so clients
+// matching on codes see the same errors as before; several are reported
+// together as validation_failed. Requests whose only problems are
+// unprocessableCodes (text over the length limits, an unrecognized work
+// authorization in strict mode) are 422 rather than 400.
+func (v violations) err() *apiError {
+	return v.errAbout("The application has several problems. See violations for details.")
+}
+
+// errAbout is err with the message used when there are several violations
+func (v violations) errAbout(several string) *apiError {
+	status := http.StatusUnprocessableEntity
+	for _, violation := range v {
+		if !unprocessableCodes[violation.Code] {
+			status = http.StatusBadRequest
+			break
+		}
+	}
+
+	switch len(v) {
+	case 0:
+		return nil
+	case 1:
+		switch v[0].Code {
+		case "job_not_found":
+			status = http.StatusNotFound
+		case "job_closed", "job_filled":
+			status = http.StatusGone
+		case "job_paused", "job_draft":
+			status = http.StatusConflict
+		}
+		return &apiError{status: status, code: v[0].Code, message: v[0].Message, violations: v}
+	default:
+		return &apiError{status: status, code: "validation_failed", message: several, violations: v}
+	}
+}
+
+// addEmail replaces the email address in field with its normalized form,
+// following the application store's rules, recording a violation if it is
+// invalid. Empty addresses are skipped.
+func (v *violations) addEmail(field string, value *string, appStore *store.ApplicationStore) {
+	if *value == "" || v.has(field) {
+		return
+	}
+	normalized, err := emailaddr.Validate(*value, appStore.EmailRules())
+	switch err {
+	case nil:
+		*value = normalized
+	case emailaddr.ErrDisposable:
+		v.add(field, "disposable_email", "Disposable email addresses are not accepted.")
+	case emailaddr.ErrUnicodeLocal:
+		v.add(field, "invalid_email", "Email addresses may only use ASCII characters before the @.")
+	default:
+		v.add(field, "invalid_email", "Please provide a valid email address.")
+	}
+}
+
+// addPhone records an invalid_phone violation for a phone number the
+// application store cannot normalize
+func (v *violations) addPhone(value string, appStore *store.ApplicationStore) {
+	if value == "" || v.has("phone") {
+		return
+	}
+	if _, err := phone.Normalize(value, appStore.PhoneCountryCode()); err != nil {
+		v.add("phone", "invalid_phone", phoneMessages[err])
+	}
+}
+
+// addLinks replaces each profile link with its normalized form, following
+// the application store's settings, recording a violation for those that
+// are invalid. Nil links are skipped.
+func (v *violations) addLinks(linkedin, github, portfolio *string, appStore *store.ApplicationStore) {
+	anyHost := appStore.RelaxedProfileHosts()
+	for _, link := range []profileLink{
+		{"linkedin", linkedin, func(raw string) (string, error) { return links.LinkedIn(raw, anyHost) }},
+		{"github", github, func(raw string) (string, error) { return links.GitHub(raw, anyHost) }},
+		{"portfolio", portfolio, links.Portfolio},
+	} {
+		if link.value == nil || *link.value == "" || v.has(link.field) {
+			continue
+		}
+		normalized, err := link.normalize(*link.value)
+		if err != nil {
+			v.add(link.field, "invalid_"+link.field, linkMessages[link.field][err])
+			continue
+		}
+		*link.value = normalized
+	}
+}
+
+// addAnswers records a violation for each of job's screening questions
+// that answers leave unanswered when it is required, or answer in a way it
+// does not accept
+func (v *violations) addAnswers(job models.Job, answers map[string]string) {
+	for _, q := range job.Questions {
+		field := "custom_answers." + q.ID
+		answer, ok := answers[q.ID]
+		switch {
+		case !ok || strings.TrimSpace(answer) == "":
+			if q.Required {
+				v.add(field, "missing_answer", fmt.Sprintf("An answer to %q is required in %s.", q.Label, field))
+			}
+		default:
+			if problem := q.Check(answer); problem != "" {
+				v.add(field, "invalid_answer", problem)
+			}
+		}
+	}
+}
+
+// validateApplicationUpdate collects every problem with an edit to app,
+// following the same rules as submission for the fields it changes,
+// including job's screening questions. Valid
+// profile links are replaced with their normalized form.
+func validateApplicationUpdate(appStore *store.ApplicationStore, job models.Job, app *models.Application, req *models.ApplicationUpdateRequest, found violations) violations {
+	// Check lengths against the application as it would be after the edit
+	edited := models.ApplicationRequest{CoverLetter: app.CoverLetter, CustomAnswers: maps.Clone(app.CustomAnswers)}
+	if req.CoverLetter != nil {
+		edited.CoverLetter = *req.CoverLetter
+	}
+	if edited.CustomAnswers == nil {
+		edited.CustomAnswers = make(map[string]string)
+	}
+	for question, answer := range req.CustomAnswers {
+		if answer == "" {
+			delete(edited.CustomAnswers, question)
+		} else {
+			edited.CustomAnswers[question] = answer
+		}
+	}
+	found.addLengths(&edited, appStore.Limits())
+	if len(req.CustomAnswers) > 0 {
+		found.addAnswers(job, edited.CustomAnswers)
+	}
+
+	if req.Phone != nil {
+		found.addPhone(*req.Phone, appStore)
+	}
+	found.addLinks(req.LinkedIn, req.GitHub, req.Portfolio, appStore)
+	return found
+}
+
+// validateApplication collects every problem with an application: the
+// ApplicationRequest binding rules, text lengths, the email address, the
+// phone number, profile links and work authorization (following the
+// application store's settings), whether the job exists, whether its
+// deadline has passed and the answers to its screening questions. Valid
+// emails, profile links and work authorizations are replaced with their
+// normalized form. Problems that do not stop the submission are returned as
+// warnings.
+func validateApplication(jobStore *store.JobStore, appStore *store.ApplicationStore, req *models.ApplicationRequest, found violations) (models.Job, violations, violations) {
+	var warnings violations
+
+	found.addBinding(req)
+	found.addLengths(req, appStore.Limits())
+
+	found.addEmail("applicant_email", &req.ApplicantEmail, appStore)
+	found.addPhone(req.Phone, appStore)
+	found.addLinks(&req.LinkedIn, &req.GitHub, &req.Portfolio, appStore)
+
+	switch {
+	case req.WorkAuthorization != "" && !found.has("work_authorization"):
+		value, ok := models.ParseWorkAuthorization(req.WorkAuthorization)
+		switch {
+		case ok:
+			req.WorkAuthorization = string(value)
+		case appStore.StrictWorkAuthorization():
+			found.add("work_authorization", "invalid_work_authorization",
+				fmt.Sprintf("Unrecognized work authorization %q. Must be one of: %s.", req.WorkAuthorization, workAuthorizationValues()))
+		default:
+			warnings.add("work_authorization", "unrecognized_work_authorization",
+				fmt.Sprintf("Unrecognized work authorization %q was recorded as other. Accepted values: %s.", req.WorkAuthorization, workAuthorizationValues()))
+			req.WorkAuthorization = string(models.WorkAuthOther)
+		}
+	case req.WorkAuthorization == "" && req.SponsorshipNeeded != nil && *req.SponsorshipNeeded:
+		req.WorkAuthorization = string(models.WorkAuthNeedsSponsorship)
+	}
+
+	var job models.Job
+	if req.JobID != "" && !found.has("job_id") {
+		var exists bool
+		job, exists = jobStore.GetByID(req.JobID)
+		switch status := jobStatus(job, appStore); {
+		case !exists:
+			found.add("job_id", "job_not_found", "The specified job does not exist.")
+		case status == models.JobClosed && deadlinePassed(job):
+			found.add("job_id", "deadline_passed", "The application deadline for this job has passed.")
+		case status == models.JobClosed:
+			found.add("job_id", "job_closed", "This job has been closed and is no longer accepting applications.")
+		case status == models.JobFilled:
+			found.add("job_id", "job_filled", "This job has been filled and is no longer accepting applications.")
+		case status == models.JobPaused:
+			found.add("job_id", "job_paused", "This job is paused and not accepting applications right now. Please try again later.")
+		case status == models.JobDraft:
+			found.add("job_id", "job_draft", "This job has not been published yet.")
+		default:
+			found.addAnswers(job, req.CustomAnswers)
+		}
+	}
+
+	return job, found, warnings
+}
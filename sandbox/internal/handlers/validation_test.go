@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+)
+
+// testLimits are small enough to test each boundary
+var testLimits = models.ApplicationLimits{
+	ApplicantName:      10,
+	Resume:             60,
+	CoverLetter:        15,
+	CustomAnswer:       8,
+	CustomAnswersTotal: 12,
+}
+
+// runes are characters of one to four bytes in UTF-8, which limits count
+// as one character each
+var runes = []string{"a", "é", "日", "😀"}
+
+func TestLengthLimits(t *testing.T) {
+	fields := []struct {
+		field string
+		limit int
+		set   func(req *models.ApplicationRequest, value string)
+	}{
+		{"applicant_name", testLimits.ApplicantName, func(req *models.ApplicationRequest, value string) { req.ApplicantName = value }},
+		{"resume", testLimits.Resume, func(req *models.ApplicationRequest, value string) { req.Resume = value }},
+		{"cover_letter", testLimits.CoverLetter, func(req *models.ApplicationRequest, value string) { req.CoverLetter = value }},
+		{"custom_answers.why_us", testLimits.CustomAnswer, func(req *models.ApplicationRequest, value string) {
+			req.CustomAnswers = map[string]string{"why_us": value}
+		}},
+		// Two answers within their own limit, splitting the total
+		{"custom_answers", testLimits.CustomAnswersTotal, func(req *models.ApplicationRequest, value string) {
+			r := []rune(value)
+			req.CustomAnswers = map[string]string{"first": string(r[:len(r)/2]), "second": string(r[len(r)/2:])}
+		}},
+	}
+
+	for _, f := range fields {
+		for _, char := range runes {
+			for _, n := range []int{f.limit - 1, f.limit, f.limit + 1} {
+				t.Run(fmt.Sprintf("%s %d×%q", f.field, n, char), func(t *testing.T) {
+					jobStore, appStore := newTestStores(t)
+					appStore.SetLimits(testLimits)
+					req := testApplication("vic@example.com")
+					f.set(&req, strings.Repeat(char, n))
+
+					_, apiErr := submitApplication(jobStore, appStore, req)
+					if n <= f.limit {
+						if apiErr != nil {
+							t.Fatalf("%d characters (%d bytes) rejected: %s %+v", n, len(strings.Repeat(char, n)), apiErr.code, apiErr.violations)
+						}
+						return
+					}
+
+					if apiErr == nil {
+						t.Fatalf("%d characters accepted over a limit of %d", n, f.limit)
+					}
+					if apiErr.status != http.StatusUnprocessableEntity || apiErr.code != "too_long" || len(apiErr.violations) != 1 {
+						t.Fatalf("status %d, code %s, violations %+v, want one 422 too_long", apiErr.status, apiErr.code, apiErr.violations)
+					}
+					if v := apiErr.violations[0]; v.Field != f.field || v.Limit != f.limit {
+						t.Errorf("violation %+v, want field %s with limit %d", v, f.field, f.limit)
+					}
+				})
+			}
+		}
+	}
+}
+
+// TestLengthLimitsTogether checks every field over its limit is listed
+func TestLengthLimitsTogether(t *testing.T) {
+	jobStore, appStore := newTestStores(t)
+	appStore.SetLimits(testLimits)
+	req := testApplication("vic@example.com")
+	req.ApplicantName = strings.Repeat("日", 11)
+	req.CoverLetter = strings.Repeat("é", 16)
+	req.CustomAnswers = map[string]string{"why_us": strings.Repeat("😀", 9)}
+
+	_, apiErr := submitApplication(jobStore, appStore, req)
+	if apiErr == nil || apiErr.status != http.StatusUnprocessableEntity || apiErr.code != "validation_failed" {
+		t.Fatalf("error %+v, want 422 validation_failed", apiErr)
+	}
+	got := make(map[string]int)
+	for _, v := range apiErr.violations {
+		got[v.Field] = v.Limit
+	}
+	want := map[string]int{"applicant_name": 10, "cover_letter": 15, "custom_answers.why_us": 8}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("violations %+v, want limits %v", apiErr.violations, want)
+	}
+}
+
+// TestLengthLimitsOnEdit checks edits are held to the same limits, counting
+// the answers kept from the application
+func TestLengthLimitsOnEdit(t *testing.T) {
+	jobStore, appStore := newTestStores(t)
+	appStore.SetLimits(testLimits)
+	req := testApplication("vic@example.com")
+	req.CustomAnswers = map[string]string{"first": strings.Repeat("日", 6)}
+	app, apiErr := submitApplication(jobStore, appStore, req)
+	if apiErr != nil {
+		t.Fatal(apiErr.message)
+	}
+
+	tests := []struct {
+		name    string
+		answers map[string]string
+		field   string
+	}{
+		{"answer at the limit, total at the limit", map[string]string{"second": strings.Repeat("é", 6)}, ""},
+		{"answer over the limit", map[string]string{"first": strings.Repeat("é", 9)}, "custom_answers.first"},
+		{"total over the limit", map[string]string{"second": strings.Repeat("é", 7)}, "custom_answers"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edit := &models.ApplicationUpdateRequest{CustomAnswers: tt.answers}
+			found := validateApplicationUpdate(appStore, openJob, app, edit, nil)
+			switch {
+			case tt.field == "" && len(found) > 0:
+				t.Errorf("violations %+v, want none", found)
+			case tt.field != "" && (len(found) != 1 || found[0].Field != tt.field || found[0].Code != "too_long"):
+				t.Errorf("violations %+v, want too_long on %s", found, tt.field)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		n    int
+		s    string
+		want string
+	}{
+		{5, "short", "short"},
+		{5, "日本語です", "日本語です"},
+		{4, "日本語です", "日本語で… [truncated]"},
+		{3, "😀😀😀😀", "😀😀😀… [truncated]"},
+		{0, "", ""},
+	}
+	for _, tt := range tests {
+		if got := truncate(tt.n, tt.s); got != tt.want {
+			t.Errorf("truncate(%d, %q) = %q, want %q", tt.n, tt.s, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,277 @@
+package handlers
+
+import (
+	"fmt"
+	"hash/fnv"
+	"html/template"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/middleware"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/random"
+	"github.com/gin-gonic/gin"
+)
+
+// variantCookie keeps the seed of the form variant a browser was shown, so
+// it is shown the same one again, and variantQuery picks a seed instead
+const (
+	variantCookie = "sandbox_form_variant"
+	variantQuery  = "variant"
+)
+
+// VariantHeader reports the seed of the form variant a page was built from
+const VariantHeader = "X-Form-Variant"
+
+// maxVariantSeed bounds the seeds handed out, so they are easy to note down
+const maxVariantSeed = 1_000_000
+
+// FormVariants hands out randomized variants of the application form.
+// Element IDs, class names, the order of sections and fields, and the
+// wording of labels all follow from a seed, so browser agents have to go by
+// what the form says rather than by selectors they memorized.
+type FormVariants struct {
+	rng *rand.Rand
+	mu  sync.Mutex
+}
+
+// NewFormVariants creates a new source of form variants
+func NewFormVariants() *FormVariants {
+	return &FormVariants{rng: random.New("variants")}
+}
+
+// seed picks the variant for a request: the one ?variant= names, else the
+// one its browser was shown before, else one derived from its X-Run-ID,
+// else a new one. The browser keeps it in a cookie.
+func (v *FormVariants) seed(c *gin.Context) int64 {
+	seed, err := strconv.ParseInt(c.Query(variantQuery), 10, 64)
+	if err != nil {
+		var cookie string
+		if cookie, err = c.Cookie(variantCookie); err == nil {
+			seed, err = strconv.ParseInt(cookie, 10, 64)
+		}
+	}
+	if err != nil {
+		if run := c.GetHeader(middleware.RunIDHeader); run != "" {
+			h := fnv.New64a()
+			h.Write([]byte(run))
+			seed, err = int64(h.Sum64()%maxVariantSeed), nil
+		}
+	}
+	if err != nil {
+		v.mu.Lock()
+		seed = v.rng.Int63n(maxVariantSeed)
+		v.mu.Unlock()
+	}
+
+	value := strconv.FormatInt(seed, 10)
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(variantCookie, value, 0, "/", "", false, true)
+	c.Header(VariantHeader, value)
+	return seed
+}
+
+// formVariant is the application form laid out after a seed
+type formVariant struct {
+	Seed int64
+	// Classes holds the class name of each kind of element, such as
+	// "input" or "label"
+	Classes     map[string]string
+	Style       template.CSS
+	FormID      string
+	Sections    []variantSection
+	SubmitLabel string
+}
+
+// variantSection is a titled group of fields on the form
+type variantSection struct {
+	ID     string
+	Title  string
+	Fields []variantField
+}
+
+// variantField is one input of the form. Its name is the field the
+// application form has always posted; everything else varies.
+type variantField struct {
+	ID          string
+	Name        string
+	Label       string
+	Type        string // An input type, "textarea" or "select"
+	Value       string
+	Placeholder string
+	Error       string
+	Required    bool
+	Readonly    bool
+	Rows        int
+	Step        string
+	Options     []variantOption
+}
+
+// variantOption is one option of a select field
+type variantOption struct {
+	Value    string
+	Label    string
+	Selected bool
+}
+
+// variantStyles styles each kind of element, since Tailwind's classes are
+// not used on variant forms
+var variantStyles = []struct{ kind, css string }{
+	{"section", "background:#fff;border:1px solid #e5e7eb;border-radius:.75rem;padding:1.5rem;margin-bottom:1.5rem"},
+	{"heading", "font-size:1.125rem;font-weight:600;color:#111827;margin-bottom:1rem"},
+	{"field", "margin-bottom:1rem"},
+	{"label", "display:block;font-size:.875rem;font-weight:500;color:#374151;margin-bottom:.25rem"},
+	{"required", "color:#ef4444"},
+	{"input", "width:100%;padding:.75rem 1rem;border:1px solid #d1d5db;border-radius:.5rem;outline:none"},
+	{"error", "font-size:.875rem;color:#dc2626;margin-top:.25rem"},
+	{"alert", "background:#fef2f2;border:1px solid #fecaca;color:#b91c1c;border-radius:.75rem;padding:1rem;margin-bottom:1.5rem"},
+	{"button", "background:#2563eb;color:#fff;font-weight:600;border-radius:.5rem;padding:.75rem 2rem;cursor:pointer"},
+}
+
+// variantSectionTitles words the title of each section in several ways
+var variantSectionTitles = map[string][]string{
+	"personal":  {"Personal Information", "About You", "Your Details", "Contact Information"},
+	"links":     {"Profile Links", "Online Presence", "Links"},
+	"resume":    {"Resume", "Your Resume", "CV"},
+	"cover":     {"Cover Letter", "Motivation", "Why You?"},
+	"questions": {"Additional Questions", "A Few More Questions", "Screening Questions"},
+}
+
+// variantLabels words the label of each field in several ways
+var variantLabels = map[string][]string{
+	"applicant_name":     {"Full Name", "Your name", "Name", "Full legal name"},
+	"applicant_email":    {"Email Address", "Email", "Your email", "E-mail"},
+	"phone":              {"Phone Number", "Phone", "Mobile number", "Contact number"},
+	"linkedin":           {"LinkedIn Profile", "LinkedIn", "LinkedIn URL"},
+	"portfolio":          {"Portfolio Website", "Personal website", "Portfolio URL"},
+	"github":             {"GitHub Profile", "GitHub", "GitHub URL"},
+	"resume":             {"Paste your resume content below", "Resume (plain text)", "Your CV", "Resume / CV"},
+	"cover_letter":       {"Write a brief cover letter (optional but recommended)", "Cover letter", "Why do you want this job?", "Anything else you'd like us to know?"},
+	"work_authorization": {"Are you authorized to work in the job location?", "Work authorization", "Your right to work", "Can you legally work where this job is based?"},
+	"start_date":         {"What is your earliest start date?", "Start date", "When can you start?", "Availability"},
+	"salary_expectation": {"Salary Expectation (optional)", "Expected salary", "Desired compensation", "Pay expectations"},
+	"remote_preference":  {"Remote Work Preference", "Where would you like to work?", "Work arrangement", "Remote, hybrid or on-site?"},
+}
+
+// variantSubmitLabels words the submit button in several ways
+var variantSubmitLabels = []string{"Submit Application", "Apply", "Send application", "Apply now", "Submit"}
+
+// buildFormVariant lays out the application form for job after seed,
+// filled in with values and showing fieldErrors next to their fields
+func buildFormVariant(seed int64, job models.Job, values models.ApplicationRequest, fieldErrors map[string]string, signedIn bool) formVariant {
+	rng := rand.New(rand.NewSource(seed))
+	token := func() string {
+		return fmt.Sprintf("%c%06x", 'a'+rng.Intn(26), rng.Intn(1<<24))
+	}
+	pick := func(options []string) string {
+		return options[rng.Intn(len(options))]
+	}
+
+	v := formVariant{Seed: seed, Classes: make(map[string]string), FormID: token()}
+	var style strings.Builder
+	for _, s := range variantStyles {
+		class := token()
+		v.Classes[s.kind] = class
+		fmt.Fprintf(&style, ".%s{%s}\n", class, s.css)
+	}
+	v.Style = template.CSS(style.String())
+
+	field := func(name, kind, value, placeholder string) variantField {
+		return variantField{
+			ID:          token(),
+			Name:        name,
+			Label:       pick(variantLabels[name]),
+			Type:        kind,
+			Value:       value,
+			Placeholder: placeholder,
+			Error:       fieldErrors[name],
+		}
+	}
+	choices := func(selected string, options ...string) []variantOption {
+		list := []variantOption{{Label: "Select an option"}}
+		for i := 0; i < len(options); i += 2 {
+			list = append(list, variantOption{Value: options[i], Label: options[i+1], Selected: options[i] == selected})
+		}
+		return list
+	}
+
+	name := field("applicant_name", "text", values.ApplicantName, "John Doe")
+	name.Required = true
+	email := field("applicant_email", "email", values.ApplicantEmail, "john@example.com")
+	email.Required, email.Readonly = true, signedIn
+	resume := field("resume", "textarea", values.Resume, "Paste your resume text here...")
+	resume.Required, resume.Rows = true, 10
+	cover := field("cover_letter", "textarea", values.CoverLetter, "Dear Hiring Manager,")
+	cover.Rows = 6
+	workAuth := field("work_authorization", "select", values.WorkAuthorization, "")
+	workAuth.Options = choices(values.WorkAuthorization,
+		"citizen", "Yes, I am a citizen",
+		"permanent_resident", "Yes, I am a permanent resident",
+		"visa_holder", "Yes, on a visa that needs no sponsorship",
+		"needs_sponsorship", "No, I will need sponsorship",
+		"other", "Other")
+
+	questions := []variantField{
+		workAuth,
+		field("start_date", "text", values.StartDate, "e.g., Immediately, 2 weeks, June 2026"),
+		field("salary_expectation", "text", values.SalaryExpectation, "e.g., $80,000 - $100,000"),
+	}
+	if job.IsRemote || job.Remote {
+		remote := field("remote_preference", "select", values.RemotePreference, "")
+		remote.Options = choices(values.RemotePreference,
+			"fully_remote", "Fully Remote", "hybrid", "Hybrid", "onsite", "On-site", "flexible", "Flexible")
+		questions = append(questions, remote)
+	}
+	for _, q := range job.Questions {
+		answer := values.CustomAnswers[q.ID]
+		f := variantField{
+			ID:       token(),
+			Name:     "custom_answers[" + q.ID + "]",
+			Label:    q.Label,
+			Type:     "text",
+			Value:    answer,
+			Error:    fieldErrors["custom_answers."+q.ID],
+			Required: q.Required,
+		}
+		switch q.Type {
+		case "boolean":
+			f.Type, f.Options = "select", choices(answer, "true", "Yes", "false", "No")
+		case "select":
+			f.Type, f.Options = "select", choices(answer)
+			for _, option := range q.Options {
+				f.Options = append(f.Options, variantOption{Value: option, Label: option, Selected: option == answer})
+			}
+		case "number":
+			f.Type, f.Step = "number", "any"
+		case "multi_select":
+			f.Placeholder = "One or more of: " + strings.Join(q.Options, ", ")
+		}
+		questions = append(questions, f)
+	}
+
+	sections := []variantSection{
+		{ID: token(), Title: pick(variantSectionTitles["personal"]), Fields: []variantField{name, email,
+			field("phone", "tel", values.Phone, "+1 (555) 000-0000"),
+			field("linkedin", "url", values.LinkedIn, "https://linkedin.com/in/johndoe")}},
+		{ID: token(), Title: pick(variantSectionTitles["links"]), Fields: []variantField{
+			field("portfolio", "url", values.Portfolio, "https://johndoe.com"),
+			field("github", "url", values.GitHub, "https://github.com/johndoe")}},
+		{ID: token(), Title: pick(variantSectionTitles["resume"]), Fields: []variantField{resume}},
+		{ID: token(), Title: pick(variantSectionTitles["cover"]), Fields: []variantField{cover}},
+		{ID: token(), Title: pick(variantSectionTitles["questions"]), Fields: questions},
+	}
+	for _, section := range sections {
+		rng.Shuffle(len(section.Fields), func(i, j int) {
+			section.Fields[i], section.Fields[j] = section.Fields[j], section.Fields[i]
+		})
+	}
+	rng.Shuffle(len(sections), func(i, j int) {
+		sections[i], sections[j] = sections[j], sections[i]
+	})
+	v.Sections = sections
+	v.SubmitLabel = pick(variantSubmitLabels)
+	return v
+}
@@ -0,0 +1,284 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/webhook"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// defaultSecretGracePeriod is how long a rotated secret stays valid by default
+const defaultSecretGracePeriod = time.Hour
+
+// webhookRetryDelays are the waits before each retry of a failed delivery.
+// A delivery whose last retry fails too is dead-lettered.
+var webhookRetryDelays = []time.Duration{time.Second, 5 * time.Second, 30 * time.Second}
+
+// WebhookHandler manages webhook subscriptions and delivers status-change events
+type WebhookHandler struct {
+	webhookStore *store.WebhookStore
+	client       *http.Client
+	retryDelays  []time.Duration
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(webhookStore *store.WebhookStore) *WebhookHandler {
+	return &WebhookHandler{
+		webhookStore: webhookStore,
+		client:       &http.Client{Timeout: 10 * time.Second},
+		retryDelays:  webhookRetryDelays,
+	}
+}
+
+// CreateWebhook handles POST /api/webhooks
+// Registers a webhook and returns its signing secret (shown only once)
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var req models.WebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respond.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body: "+err.Error())
+		return
+	}
+	if !isWebhookURL(req.URL) {
+		respond.Error(c, http.StatusBadRequest, "invalid_url", "Webhook url must be an absolute http or https URL.")
+		return
+	}
+
+	created, err := h.webhookStore.Create(req.URL)
+	if err != nil {
+		respond.Error(c, http.StatusInternalServerError, "internal_error", "An unexpected error occurred. Please try again later.")
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.WebhookSecretResponse{Webhook: created, Secret: created.Secret})
+}
+
+// ListWebhooks handles GET /api/webhooks
+// Returns all webhooks, without their secrets
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	webhooks := h.webhookStore.GetAll()
+	c.JSON(http.StatusOK, models.WebhooksListResponse{Webhooks: webhooks, Total: len(webhooks)})
+}
+
+// UpdateWebhook handles PATCH /api/webhooks/:id
+// Changes the URL and/or rotates the secret, keeping the old one valid for a grace period
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	var req models.WebhookUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respond.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body: "+err.Error())
+		return
+	}
+	if req.URL != "" && !isWebhookURL(req.URL) {
+		respond.Error(c, http.StatusBadRequest, "invalid_url", "Webhook url must be an absolute http or https URL.")
+		return
+	}
+
+	grace := defaultSecretGracePeriod
+	if req.GracePeriodSeconds != nil {
+		if *req.GracePeriodSeconds < 0 {
+			respond.Error(c, http.StatusBadRequest, "invalid_grace_period", "grace_period_seconds must not be negative.")
+			return
+		}
+		grace = time.Duration(*req.GracePeriodSeconds) * time.Second
+	}
+
+	updated, err := h.webhookStore.Update(c.Param("id"), req.URL, req.RotateSecret, grace)
+	if err != nil {
+		respond.Error(c, http.StatusNotFound, "webhook_not_found", "The specified webhook could not be found.")
+		return
+	}
+
+	if req.RotateSecret {
+		c.JSON(http.StatusOK, models.WebhookSecretResponse{Webhook: updated, Secret: updated.Secret})
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteWebhook handles DELETE /api/webhooks/:id
+// Removes a webhook subscription
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	if !h.webhookStore.Delete(c.Param("id")) {
+		respond.Error(c, http.StatusNotFound, "webhook_not_found", "The specified webhook could not be found.")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetWebhookStats handles GET /api/webhooks/:id/stats
+// Returns a webhook's delivery attempts, failures by class, retries and latency
+func (h *WebhookHandler) GetWebhookStats(c *gin.Context) {
+	stats, exists := h.webhookStore.Stats(c.Param("id"))
+	if !exists {
+		respond.Error(c, http.StatusNotFound, "webhook_not_found", "The specified webhook could not be found.")
+		return
+	}
+	respond.Data(c, http.StatusOK, stats)
+}
+
+// ListDeadLetters handles GET /api/webhooks/:id/dead-letters
+// Returns the deliveries that failed every retry, oldest first
+func (h *WebhookHandler) ListDeadLetters(c *gin.Context) {
+	letters, exists := h.webhookStore.DeadLetters(c.Param("id"))
+	if !exists {
+		respond.Error(c, http.StatusNotFound, "webhook_not_found", "The specified webhook could not be found.")
+		return
+	}
+	if letters == nil {
+		letters = []models.WebhookDeadLetter{}
+	}
+	c.JSON(http.StatusOK, models.WebhookDeadLettersResponse{DeadLetters: letters, Total: len(letters)})
+}
+
+// RedeliverDeadLetters handles POST /api/webhooks/:id/dead-letters/redeliver
+// Takes the listed dead letters (all of them without a body) off the list
+// and delivers them again in the background, with the usual retries
+func (h *WebhookHandler) RedeliverDeadLetters(c *gin.Context) {
+	var req models.WebhookRedeliverRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respond.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body: "+err.Error())
+		return
+	}
+
+	id := c.Param("id")
+	letters, err := h.webhookStore.TakeDeadLetters(id, req.IDs)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "dead letter not found") {
+			respond.Error(c, http.StatusNotFound, "dead_letter_not_found", "No dead letter has the ID "+strings.TrimPrefix(err.Error(), "dead letter not found: ")+".")
+			return
+		}
+		respond.Error(c, http.StatusNotFound, "webhook_not_found", "The specified webhook could not be found.")
+		return
+	}
+
+	redelivered := make([]string, 0, len(letters))
+	for _, letter := range letters {
+		redelivered = append(redelivered, letter.ID)
+		body, err := json.Marshal(letter.Event)
+		if err != nil {
+			log.Printf("webhook: encoding %s: %v", letter.ID, err)
+			continue
+		}
+		go h.deliver(id, letter.Event, body)
+	}
+	c.JSON(http.StatusAccepted, models.WebhookRedeliverResponse{Redelivered: redelivered, Total: len(redelivered)})
+}
+
+// NotifyStatusChange delivers an application.status_changed event to every
+// webhook. It is registered as an application store status listener and
+// delivers in the background so status updates are never held up.
+func (h *WebhookHandler) NotifyStatusChange(app models.Application, previous models.ApplicationStatus) {
+	event := models.WebhookEvent{
+		ID:        "evt_" + uuid.New().String()[:8],
+		Type:      models.EventStatusChanged,
+		CreatedAt: time.Now().UTC(),
+		Data: models.StatusChangeData{
+			ApplicationID:  app.ConfirmationID,
+			JobID:          app.JobID,
+			PreviousStatus: previous,
+			Status:         app.Status,
+			UpdatedAt:      app.UpdatedAt.UTC(),
+		},
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("webhook: encoding %s: %v", event.ID, err)
+		return
+	}
+
+	for _, subscription := range h.webhookStore.GetAll() {
+		go h.deliver(subscription.ID, event, body)
+	}
+}
+
+// deliver sends one event to a webhook, retrying failed attempts after
+// each of retryDelays and dead-lettering the event if they all fail. The
+// webhook is looked up before every attempt, so retries follow URL changes
+// and secret rotations and stop once it is deleted.
+func (h *WebhookHandler) deliver(id string, event models.WebhookEvent, body []byte) {
+	for attempt := 0; ; attempt++ {
+		subscription, exists := h.webhookStore.Get(id)
+		if !exists {
+			return
+		}
+
+		start := time.Now()
+		class, err := h.attempt(subscription, event, body)
+		h.webhookStore.RecordAttempt(id, attempt > 0, time.Since(start), class)
+		if err == nil {
+			return
+		}
+		log.Printf("webhook: %s to %s, attempt %d: %v", event.ID, id, attempt+1, err)
+
+		if attempt == len(h.retryDelays) {
+			h.webhookStore.AddDeadLetter(id, models.WebhookDeadLetter{
+				ID:        event.ID,
+				Attempts:  attempt + 1,
+				LastError: err.Error(),
+				Class:     class,
+				FailedAt:  time.Now().UTC(),
+				Event:     event,
+			})
+			return
+		}
+		time.Sleep(h.retryDelays[attempt])
+	}
+}
+
+// attempt POSTs one signed event to a webhook. A failed attempt returns
+// its failure class along with the error.
+func (h *WebhookHandler) attempt(subscription models.Webhook, event models.WebhookEvent, body []byte) (string, error) {
+	req, err := http.NewRequest(http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return models.DeliveryOther, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sandbox-Event", event.Type)
+	req.Header.Set("X-Sandbox-Delivery", event.ID)
+	req.Header.Set(webhook.SignatureHeader, webhook.Sign(body, time.Now(), subscription.SigningSecrets()...))
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return failureClass(err), err
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return models.DeliveryNon2xx, fmt.Errorf("receiver answered %d", resp.StatusCode)
+	}
+	return "", nil
+}
+
+// failureClass classifies an error sending a delivery
+func failureClass(err error) string {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return models.DeliveryConnectionRefused
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return models.DeliveryTimeout
+	default:
+		return models.DeliveryOther
+	}
+}
+
+// isWebhookURL reports whether raw is an absolute http(s) URL
+func isWebhookURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
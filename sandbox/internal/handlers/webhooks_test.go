@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// newWebhookRouter serves the webhook routes with retries a millisecond apart
+func newWebhookRouter(webhookStore *store.WebhookStore) (*gin.Engine, *WebhookHandler) {
+	gin.SetMode(gin.TestMode)
+	h := NewWebhookHandler(webhookStore)
+	h.retryDelays = []time.Duration{time.Millisecond, time.Millisecond, time.Millisecond}
+
+	r := gin.New()
+	r.GET("/api/webhooks/:id/stats", h.GetWebhookStats)
+	r.GET("/api/webhooks/:id/dead-letters", h.ListDeadLetters)
+	r.POST("/api/webhooks/:id/dead-letters/redeliver", h.RedeliverDeadLetters)
+	return r, h
+}
+
+// waitFor polls cond until it holds or a second has passed
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func getJSON(t *testing.T, r http.Handler, method, path, body string, v interface{}) int {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if v != nil {
+		if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+			t.Fatalf("%s %s: decoding %q: %v", method, path, w.Body.String(), err)
+		}
+	}
+	return w.Code
+}
+
+func TestWebhookDeadLetterAndRedeliver(t *testing.T) {
+	var healthy atomic.Bool
+	var received atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer receiver.Close()
+
+	webhookStore := store.NewWebhookStore()
+	subscription, err := webhookStore.Create(receiver.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, h := newWebhookRouter(webhookStore)
+
+	h.NotifyStatusChange(models.Application{ConfirmationID: "CONF-1", JobID: "job_001", Status: models.StatusReviewing}, models.StatusReceived)
+	base := "/api/webhooks/" + subscription.ID
+	var letters models.WebhookDeadLettersResponse
+	waitFor(t, "the dead letter", func() bool {
+		getJSON(t, r, http.MethodGet, base+"/dead-letters", "", &letters)
+		return letters.Total == 1
+	})
+	if got := received.Load(); got != 4 {
+		t.Errorf("receiver got %d attempts, want 4", got)
+	}
+	letter := letters.DeadLetters[0]
+	if letter.Attempts != 4 || letter.Class != models.DeliveryNon2xx || letter.Event.Data.ApplicationID != "CONF-1" {
+		t.Errorf("dead letter = %+v", letter)
+	}
+
+	var stats models.WebhookDeliveryStats
+	getJSON(t, r, http.MethodGet, base+"/stats", "", &stats)
+	if stats.Deliveries != 1 || stats.Attempts != 4 || stats.Retries != 3 || stats.Failures != 4 ||
+		stats.FailuresByClass[models.DeliveryNon2xx] != 4 || stats.DeadLetters != 1 {
+		t.Errorf("stats after failing = %+v", stats)
+	}
+
+	if code := getJSON(t, r, http.MethodPost, base+"/dead-letters/redeliver", `{"ids":["evt_unknown"]}`, nil); code != http.StatusNotFound {
+		t.Errorf("redelivering an unknown dead letter: status %d, want 404", code)
+	}
+
+	healthy.Store(true)
+	var redelivered models.WebhookRedeliverResponse
+	if code := getJSON(t, r, http.MethodPost, base+"/dead-letters/redeliver", "", &redelivered); code != http.StatusAccepted {
+		t.Fatalf("redeliver: status %d, want 202", code)
+	}
+	if redelivered.Total != 1 || redelivered.Redelivered[0] != letter.ID {
+		t.Errorf("redelivered = %+v", redelivered)
+	}
+	waitFor(t, "the redelivery", func() bool {
+		getJSON(t, r, http.MethodGet, base+"/stats", "", &stats)
+		return stats.Successes == 1
+	})
+	getJSON(t, r, http.MethodGet, base+"/dead-letters", "", &letters)
+	if letters.Total != 0 {
+		t.Errorf("%d dead letters left after redelivering", letters.Total)
+	}
+}
+
+func TestWebhookFailureClasses(t *testing.T) {
+	refused := httptest.NewServer(http.NotFoundHandler())
+	refusedURL := refused.URL
+	refused.Close()
+
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer slow.Close()
+
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{"connection refused", refusedURL, models.DeliveryConnectionRefused},
+		{"timeout", slow.URL, models.DeliveryTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhookStore := store.NewWebhookStore()
+			subscription, err := webhookStore.Create(tt.url)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, h := newWebhookRouter(webhookStore)
+			h.client.Timeout = 50 * time.Millisecond
+			h.retryDelays = nil
+
+			h.deliver(subscription.ID, models.WebhookEvent{ID: "evt_1", Type: models.EventStatusChanged}, []byte(`{}`))
+			stats, _ := webhookStore.Stats(subscription.ID)
+			if stats.FailuresByClass[tt.want] != 1 || stats.DeadLetters != 1 {
+				t.Errorf("stats = %+v, want one %s failure, dead-lettered", stats, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhookStatsNotFound(t *testing.T) {
+	r, _ := newWebhookRouter(store.NewWebhookStore())
+	for _, path := range []string{"/api/webhooks/wh_missing/stats", "/api/webhooks/wh_missing/dead-letters"} {
+		if code := getJSON(t, r, http.MethodGet, path, "", nil); code != http.StatusNotFound {
+			t.Errorf("GET %s: status %d, want 404", path, code)
+		}
+	}
+}
@@ -0,0 +1,466 @@
+package handlers
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/emulate"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// workdayCookie holds the ID of the browser's session on a company's
+// careers site under -workday-flow
+const workdayCookie = "sandbox_workday_session"
+
+// workdayQuestionPrefix starts the names of the screening question inputs,
+// which end with the question ID
+const workdayQuestionPrefix = "primaryQuestionnaire--"
+
+// workdayPageLabels are the headings of the Workday-style pages
+var workdayPageLabels = map[string]string{
+	models.WorkdayCreateAccount:        "Create Account",
+	models.WorkdaySignIn:               "Sign In",
+	models.WorkdayMyInformation:        "My Information",
+	models.WorkdayMyExperience:         "My Experience",
+	models.WorkdayApplicationQuestions: "Application Questions",
+	models.WorkdayVoluntaryDisclosures: "Voluntary Disclosures",
+	models.WorkdaySelfIdentify:         "Self Identify",
+	models.WorkdayReview:               "Review",
+}
+
+// workdayFields are the inputs of each page besides the screening
+// questions, named the way such careers sites name them, which is not
+// consistently
+var workdayFields = map[string][]string{
+	models.WorkdayCreateAccount: {"email", "password", "verifyPassword", "createAccountCheckbox"},
+	models.WorkdaySignIn:        {"username", "pwd"},
+	models.WorkdayMyInformation: {"source--source", "previousWorker", "legalName--firstName", "legalName--lastName",
+		"phoneNumber--deviceType", "phoneNumber--phoneNumber"},
+	models.WorkdayMyExperience: {"resumeText", "workExperience-1--jobTitle", "workExperience-1--companyName",
+		"workExperience-1--startDate", "education-1--schoolName", "education-1--degree", "skills--skills",
+		"linkedInAccount", "websiteUrl"},
+	models.WorkdayApplicationQuestions: {"legallyAuthorized", "requireSponsorship"},
+	models.WorkdayVoluntaryDisclosures: {"personalInfoUS--gender", "personalInfoUS--veteranStatus",
+		"termsAndConditions--acceptTermsAndConditions"},
+	models.WorkdaySelfIdentify: {"selfIdentifiedDisabilityData--disabilityStatus", "selfIdentifiedDisabilityData--name",
+		"selfIdentifiedDisabilityData--dateSignedOn"},
+}
+
+// workdayRequired are the labels of the inputs that must have a value
+var workdayRequired = map[string]string{
+	"email":                         "Email Address",
+	"password":                      "Password",
+	"verifyPassword":                "Verify New Password",
+	"createAccountCheckbox":         "I agree to the Candidate Privacy Statement",
+	"username":                      "Email Address",
+	"pwd":                           "Password",
+	"source--source":                "How Did You Hear About Us?",
+	"previousWorker":                "Have you previously worked for this company?",
+	"legalName--firstName":          "Given Name(s)",
+	"legalName--lastName":           "Family Name",
+	"phoneNumber--deviceType":       "Phone Device Type",
+	"phoneNumber--phoneNumber":      "Phone Number",
+	"resumeText":                    "Resume/CV",
+	"workExperience-1--jobTitle":    "Job Title",
+	"workExperience-1--companyName": "Company",
+	"workExperience-1--startDate":   "From",
+	"education-1--schoolName":       "School or University",
+	"education-1--degree":           "Degree",
+	"legallyAuthorized":             "Are you legally authorized to work in the country of this job?",
+	"requireSponsorship":            "Will you now or in the future require sponsorship?",
+	"personalInfoUS--gender":        "Gender",
+	"personalInfoUS--veteranStatus": "Veteran Status",
+
+	"termsAndConditions--acceptTermsAndConditions":   "I have read and consent to the terms and conditions",
+	"selfIdentifiedDisabilityData--disabilityStatus": "Disability Status",
+	"selfIdentifiedDisabilityData--name":             "Name",
+	"selfIdentifiedDisabilityData--dateSignedOn":     "Date",
+}
+
+// workdayDates are the date inputs and the layouts they must be entered
+// in, which differ from page to page
+var workdayDates = map[string]struct{ layout, hint string }{
+	"workExperience-1--startDate":                {"01/2006", "MM/YYYY"},
+	"selfIdentifiedDisabilityData--dateSignedOn": {"01/02/2006", "MM/DD/YYYY"},
+}
+
+// workdayInputs maps application fields to the inputs they are entered
+// in, so problems with them are shown next to those inputs
+var workdayInputs = map[string]string{
+	"applicant_name":     "legalName--firstName",
+	"phone":              "phoneNumber--phoneNumber",
+	"resume":             "resumeText",
+	"linkedin":           "linkedInAccount",
+	"portfolio":          "websiteUrl",
+	"work_authorization": "legallyAuthorized",
+	"sponsorship_needed": "requireSponsorship",
+}
+
+// workdayInput returns the input an application field is entered in
+func workdayInput(field string) (string, bool) {
+	if id, ok := strings.CutPrefix(field, "custom_answers."); ok {
+		return workdayQuestionPrefix + id, true
+	}
+	input, ok := workdayInputs[field]
+	return input, ok
+}
+
+// workdayPageOf returns the page an input is on
+func workdayPageOf(input string) string {
+	if strings.HasPrefix(input, workdayQuestionPrefix) {
+		return models.WorkdayApplicationQuestions
+	}
+	for page, inputs := range workdayFields {
+		if slices.Contains(inputs, input) {
+			return page
+		}
+	}
+	return ""
+}
+
+// workdayPagePath is the URL of a page of the Workday-style flow
+func workdayPagePath(job models.Job, page string) string {
+	return "/jobs/" + job.ID + "/apply/" + page
+}
+
+// workdayNextPage is the first page of app not saved yet
+func workdayNextPage(app models.WorkdayApplication) string {
+	return models.WorkdayPages[min(app.CompletedPages, len(models.WorkdayPages)-1)]
+}
+
+// workdayRequest is the application the pages of app filled in so far
+func workdayRequest(app models.WorkdayApplication) models.ApplicationRequest {
+	f := app.Fields
+	req := models.ApplicationRequest{
+		JobID:          app.JobID,
+		ApplicantName:  strings.TrimSpace(f["legalName--firstName"] + " " + f["legalName--lastName"]),
+		ApplicantEmail: app.Email,
+		Phone:          f["phoneNumber--phoneNumber"],
+		Resume:         f["resumeText"],
+		LinkedIn:       f["linkedInAccount"],
+		Portfolio:      f["websiteUrl"],
+	}
+	if answer := f["requireSponsorship"]; answer != "" {
+		needed := answer == "Yes"
+		req.SponsorshipNeeded = &needed
+		if needed {
+			req.WorkAuthorization = string(models.WorkAuthNeedsSponsorship)
+		}
+	}
+	if f["legallyAuthorized"] == "No" && req.WorkAuthorization == "" {
+		req.WorkAuthorization = string(models.WorkAuthOther)
+	}
+	for input, value := range f {
+		if id, ok := strings.CutPrefix(input, workdayQuestionPrefix); ok && value != "" {
+			if req.CustomAnswers == nil {
+				req.CustomAnswers = make(map[string]string)
+			}
+			req.CustomAnswers[id] = value
+		}
+	}
+	return req
+}
+
+// workdayChecks collects the problems with the inputs of a page the
+// careers site checks itself: required inputs, date layouts and, when
+// creating an account, the password rules
+func workdayChecks(page string, fields map[string]string) violations {
+	var found violations
+	for _, input := range workdayFields[page] {
+		value := strings.TrimSpace(fields[input])
+		if label, required := workdayRequired[input]; required && value == "" {
+			found.add(input, "required", "The field "+label+" is required and must have a value.")
+			continue
+		}
+		if date, ok := workdayDates[input]; ok && value != "" {
+			if _, err := time.Parse(date.layout, value); err != nil {
+				found.add(input, "invalid_date", "Enter a date in the format "+date.hint+".")
+			}
+		}
+	}
+
+	if page == models.WorkdayCreateAccount && fields["password"] != "" {
+		if !strongPassword(fields["password"]) {
+			found.add("password", "weak_password", "Password must contain at least 8 characters, an uppercase letter, a lowercase letter, a number and a special character.")
+		} else if fields["verifyPassword"] != "" && fields["verifyPassword"] != fields["password"] {
+			found.add("verifyPassword", "password_mismatch", "The passwords do not match.")
+		}
+	}
+	return found
+}
+
+// strongPassword reports whether password follows the careers site's
+// password rules
+func strongPassword(password string) bool {
+	var upper, lower, digit, special bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			special = true
+		}
+	}
+	return len([]rune(password)) >= 8 && upper && lower && digit && special
+}
+
+// workdayApplication returns the browser's application to job in progress
+func (h *PageHandler) workdayApplication(c *gin.Context, job models.Job) (models.WorkdayApplication, bool) {
+	id, err := c.Cookie(workdayCookie)
+	if err != nil {
+		return models.WorkdayApplication{}, false
+	}
+	app, exists := h.workday.Get(id)
+	return app, exists && app.JobID == job.ID
+}
+
+// startWorkday goes on with the browser's application to job, or asks it
+// to create an account on the company's careers site first
+func (h *PageHandler) startWorkday(c *gin.Context, job models.Job) {
+	app, exists := h.workdayApplication(c, job)
+	if !exists {
+		c.Redirect(http.StatusSeeOther, workdayPagePath(job, models.WorkdayCreateAccount))
+		return
+	}
+	c.Redirect(http.StatusSeeOther, workdayPagePath(job, workdayNextPage(app)))
+}
+
+// workdayProgress starts handling an application page: it finds the
+// browser's application, sending it to create an account when it has
+// none. Pages after the next one to save redirect to it.
+func (h *PageHandler) workdayProgress(c *gin.Context, job models.Job, page string) (models.WorkdayApplication, bool) {
+	i := slices.Index(models.WorkdayPages, page)
+	if i < 0 {
+		c.String(http.StatusNotFound, "Page not found")
+		return models.WorkdayApplication{}, false
+	}
+	app, exists := h.workdayApplication(c, job)
+	if !exists {
+		c.Redirect(http.StatusSeeOther, workdayPagePath(job, models.WorkdayCreateAccount))
+		return app, false
+	}
+	if i > app.CompletedPages {
+		c.Redirect(http.StatusSeeOther, workdayPagePath(job, workdayNextPage(app)))
+		return app, false
+	}
+	return app, true
+}
+
+// workdayPage renders a page of the Workday-style flow
+func (h *PageHandler) workdayPage(c *gin.Context, page string) {
+	job, exists := h.jobStore.GetByID(c.Param("id"))
+	if !exists {
+		c.String(http.StatusNotFound, "Job not found")
+		return
+	}
+	if page == models.WorkdayCreateAccount || page == models.WorkdaySignIn {
+		if app, exists := h.workdayApplication(c, job); exists {
+			c.Redirect(http.StatusSeeOther, workdayPagePath(job, workdayNextPage(app)))
+			return
+		}
+		h.renderWorkday(c, job, models.WorkdayApplication{}, page, nil, nil)
+		return
+	}
+
+	app, ok := h.workdayProgress(c, job, page)
+	if !ok {
+		return
+	}
+	h.renderWorkday(c, job, app, page, app.Fields, nil)
+}
+
+// submitWorkdayPage saves a page of the Workday-style flow and redirects
+// to the next one, signs in from the account pages, or submits the
+// application from the review page
+func (h *PageHandler) submitWorkdayPage(c *gin.Context, page string) {
+	job, exists := h.jobStore.GetByID(c.Param("id"))
+	if !exists {
+		c.String(http.StatusNotFound, "Job not found")
+		return
+	}
+	fields, err := formFields(c)
+	if err != nil {
+		c.String(http.StatusBadRequest, "The form could not be read: "+err.Error())
+		return
+	}
+	if page == models.WorkdayCreateAccount || page == models.WorkdaySignIn {
+		h.submitWorkdayAccount(c, job, page, fields)
+		return
+	}
+
+	app, ok := h.workdayProgress(c, job, page)
+	if !ok {
+		return
+	}
+	if page == models.WorkdayReview {
+		h.submitWorkdayReview(c, job, app, fields)
+		return
+	}
+
+	for _, input := range workdayFields[page] {
+		app.Fields[input] = strings.TrimSpace(fields[input])
+	}
+	if page == models.WorkdayApplicationQuestions {
+		for _, q := range job.Questions {
+			app.Fields[workdayQuestionPrefix+q.ID] = strings.TrimSpace(fields[workdayQuestionPrefix+q.ID])
+		}
+	}
+	if apiErr := h.checkCSRF(c, fields); apiErr != nil {
+		h.renderWorkday(c, job, app, page, app.Fields, apiErr)
+		return
+	}
+
+	// Besides its own checks, the page follows the same rules as
+	// submission for the application fields it fills in
+	found := workdayChecks(page, app.Fields)
+	check := workdayRequest(app)
+	_, problems, _ := validateApplication(h.jobStore, h.appStore, &check, nil)
+	for _, v := range problems {
+		if input, ok := workdayInput(v.Field); ok && workdayPageOf(input) == page {
+			found.add(input, v.Code, v.Message)
+		}
+	}
+	if apiErr := found.errAbout("Errors Found. See violations for details."); apiErr != nil {
+		h.renderWorkday(c, job, app, page, app.Fields, apiErr)
+		return
+	}
+
+	app.CompletedPages = max(app.CompletedPages, slices.Index(models.WorkdayPages, page)+1)
+	if err := h.workday.Update(app); err != nil {
+		c.Redirect(http.StatusSeeOther, workdayPagePath(job, models.WorkdaySignIn))
+		return
+	}
+	c.Redirect(http.StatusSeeOther, workdayPagePath(job, workdayNextPage(app)))
+}
+
+// submitWorkdayAccount creates an account on the company's careers site or
+// signs in to one, starting the browser's application to job
+func (h *PageHandler) submitWorkdayAccount(c *gin.Context, job models.Job, page string, fields map[string]string) {
+	// Passwords are never shown again
+	values := map[string]string{"email": fields["email"], "username": fields["username"]}
+	if apiErr := h.checkCSRF(c, fields); apiErr != nil {
+		h.renderWorkday(c, job, models.WorkdayApplication{}, page, values, apiErr)
+		return
+	}
+
+	tenant := emulate.BoardToken(job.Company)
+	var email string
+	if page == models.WorkdayCreateAccount {
+		found := workdayChecks(page, fields)
+		address := strings.TrimSpace(fields["email"])
+		found.addEmail("email", &address, h.appStore)
+		if apiErr := found.errAbout("Errors Found. See violations for details."); apiErr != nil {
+			h.renderWorkday(c, job, models.WorkdayApplication{}, page, values, apiErr)
+			return
+		}
+		registered, err := h.workday.Register(tenant, address, fields["password"])
+		if err != nil {
+			apiErr := &apiError{status: http.StatusInternalServerError, code: "storage_failed", message: "Failed to create account: " + err.Error()}
+			if strings.Contains(err.Error(), "duplicate") {
+				apiErr = &apiError{status: http.StatusConflict, code: "account_exists", message: "An account with this email address already exists on this site. Sign in instead."}
+			}
+			h.renderWorkday(c, job, models.WorkdayApplication{}, page, values, apiErr)
+			return
+		}
+		email = registered
+	} else {
+		if apiErr := workdayChecks(page, fields).errAbout("Errors Found. See violations for details."); apiErr != nil {
+			h.renderWorkday(c, job, models.WorkdayApplication{}, page, values, apiErr)
+			return
+		}
+		var ok bool
+		email, ok = h.workday.Authenticate(tenant, fields["username"], fields["pwd"])
+		if !ok {
+			h.renderWorkday(c, job, models.WorkdayApplication{}, page, values, &apiError{status: http.StatusUnauthorized, code: "invalid_credentials",
+				message: "Wrong email address or password. Accounts are separate for each company's careers site."})
+			return
+		}
+	}
+
+	app := h.workday.Start(tenant, email, job.ID)
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(workdayCookie, app.ID, 0, "/", "", false, true)
+	c.Redirect(http.StatusSeeOther, workdayPagePath(job, workdayNextPage(app)))
+}
+
+// submitWorkdayReview submits the application the pages filled in,
+// redirecting to its success page. Problems are listed with the page to
+// fix them on.
+func (h *PageHandler) submitWorkdayReview(c *gin.Context, job models.Job, app models.WorkdayApplication, fields map[string]string) {
+	if apiErr := h.checkCSRF(c, fields); apiErr != nil {
+		h.renderWorkday(c, job, app, models.WorkdayReview, app.Fields, apiErr)
+		return
+	}
+	submitted, apiErr := submitApplication(h.jobStore, h.appStore, workdayRequest(app))
+	if apiErr != nil {
+		for i, v := range apiErr.violations {
+			if input, ok := workdayInput(v.Field); ok {
+				apiErr.violations[i].Message = workdayPageLabels[workdayPageOf(input)] + ": " + v.Message
+			}
+		}
+		h.renderWorkday(c, job, app, models.WorkdayReview, app.Fields, apiErr)
+		return
+	}
+
+	h.workday.Delete(app.ID)
+	c.SetCookie(workdayCookie, "", -1, "/", "", false, true)
+	markSubmitted(c, submitted)
+	c.Redirect(http.StatusSeeOther, "/applications/"+submitted.ConfirmationID+"/success")
+}
+
+// renderWorkday renders a page of the Workday-style flow filled in with
+// values. The problems in apiErr, if any, are listed at the top and shown
+// next to the page's inputs, and set the response status.
+func (h *PageHandler) renderWorkday(c *gin.Context, job models.Job, app models.WorkdayApplication, page string, values map[string]string, apiErr *apiError) {
+	fieldErrors, formErrors := formProblems(c, apiErr, func(field string) bool {
+		return workdayPageOf(field) == page
+	})
+	if apiErr != nil {
+		// The banner at the top lists every problem
+		for _, v := range apiErr.violations {
+			if _, listed := fieldErrors[v.Field]; listed {
+				formErrors = append(formErrors, v.Message)
+			}
+		}
+	}
+
+	steps := make([]wizardStep, len(models.WorkdayPages))
+	for i, name := range models.WorkdayPages {
+		steps[i] = wizardStep{
+			Name:    name,
+			Label:   workdayPageLabels[name],
+			Number:  i + 1,
+			Done:    i < app.CompletedPages,
+			Current: name == page,
+		}
+	}
+	previous := ""
+	if i := slices.Index(models.WorkdayPages, page); i > 0 {
+		previous = workdayPagePath(job, models.WorkdayPages[i-1])
+	}
+	if values == nil {
+		values = map[string]string{}
+	}
+
+	h.render(c, "apply_workday.html", gin.H{
+		"Title":      workdayPageLabels[page] + " - " + job.Company + " Careers",
+		"Job":        job,
+		"Page":       page,
+		"PageLabel":  workdayPageLabels[page],
+		"Steps":      steps,
+		"Previous":   previous,
+		"Email":      app.Email,
+		"Values":     values,
+		"Errors":     fieldErrors,
+		"FormErrors": formErrors,
+		"CSRFToken":  h.csrfToken(c),
+	})
+}
@@ -0,0 +1,167 @@
+package i18n
+
+// spanish holds the Spanish translations of the JSON API messages
+var spanish = map[string]string{
+	// Jobs
+	"The requested job could not be found.": "No se pudo encontrar el empleo solicitado.",
+	"Search query 'q' is required.":         "El parámetro de búsqueda 'q' es obligatorio.",
+
+	// Application submission
+	"Job ID is required.":                               "El ID del empleo es obligatorio.",
+	"Applicant name is required.":                       "El nombre del candidato es obligatorio.",
+	"Applicant email is required.":                      "El correo electrónico del candidato es obligatorio.",
+	"Please provide a valid email address.":             "Proporcione una dirección de correo electrónico válida.",
+	"Resume is required.":                               "El currículum es obligatorio.",
+	"The specified job does not exist.":                 "El empleo especificado no existe.",
+	"The application deadline for this job has passed.": "La fecha límite de postulación para este empleo ya pasó.",
+	"You have already applied to this job.":             "Ya se ha postulado a este empleo.",
+	"Unsupported Content-Type. Supported: application/json, application/x-www-form-urlencoded, multipart/form-data": "Content-Type no admitido. Admitidos: application/json, application/x-www-form-urlencoded, multipart/form-data",
+	"The application has several problems. See violations for details.":                                             "La postulación tiene varios problemas. Consulte violations para más detalles.",
+	"Phone number may only contain digits, spaces, dashes, dots, parentheses and a leading +.":                      "El número de teléfono solo puede contener dígitos, espacios, guiones, puntos, paréntesis y un + inicial.",
+	"LinkedIn must be an https URL or a handle such as in/johndoe.":                                                 "LinkedIn debe ser una URL https o un identificador como in/johndoe.",
+	"LinkedIn URL must use https.":                                                       "La URL de LinkedIn debe usar https.",
+	"LinkedIn URL must point at linkedin.com.":                                           "La URL de LinkedIn debe apuntar a linkedin.com.",
+	"GitHub must be an https URL or a GitHub username.":                                  "GitHub debe ser una URL https o un nombre de usuario de GitHub.",
+	"GitHub URL must use https.":                                                         "La URL de GitHub debe usar https.",
+	"GitHub URL must point at github.com.":                                               "La URL de GitHub debe apuntar a github.com.",
+	"Portfolio must be an https URL.":                                                    "El portafolio debe ser una URL https.",
+	"Portfolio URL must use https.":                                                      "La URL del portafolio debe usar https.",
+	"Disposable email addresses are not accepted.":                                       "No se aceptan direcciones de correo desechables.",
+	"Email addresses may only use ASCII characters before the @.":                        "Las direcciones de correo solo pueden usar caracteres ASCII antes de la @.",
+	"Phone number has too few digits.":                                                   "El número de teléfono tiene muy pocos dígitos.",
+	"Phone number has too many digits.":                                                  "El número de teléfono tiene demasiados dígitos.",
+	"Phone number must start with a country code after +.":                               "El número de teléfono debe comenzar con un código de país después de +.",
+	"Several query parameters are invalid. See violations for details.":                  "Varios parámetros de consulta no son válidos. Consulte violations para más detalles.",
+	"Request body has fields this endpoint does not accept. See violations for details.": "El cuerpo de la solicitud tiene campos que este endpoint no acepta. Consulte violations para más detalles.",
+	"The job has several problems. See violations for details.":                          "El empleo tiene varios problemas. Consulte violations para más detalles.",
+	"The jobs have several problems. See violations for details.":                        "Los empleos tienen varios problemas. Consulte violations para más detalles.",
+	"The snapshot has several problems. See violations for details.":                     "La instantánea tiene varios problemas. Consulte violations para más detalles.",
+	"A valid admin token is required.":                                                   "Se requiere un token de administración válido.",
+	"Request body is not valid JSON.":                                                    "El cuerpo de la solicitud no es JSON válido.",
+	"The specified application could not be found.":                                      "No se pudo encontrar la postulación especificada.",
+	"Application submitted successfully. You will receive a confirmation email shortly.": "Postulación enviada correctamente. En breve recibirá un correo de confirmación.",
+
+	// Application status
+	"Invalid status. Valid values: received, reviewing, submitted, rejected, shortlisted, withdrawn": "Estado no válido. Valores válidos: received, reviewing, submitted, rejected, shortlisted, withdrawn",
+	"Your application has been received and is in our system.":                                       "Hemos recibido su postulación y ya está en nuestro sistema.",
+	"Your application is currently being reviewed by our team.":                                      "Nuestro equipo está revisando su postulación.",
+	"Your application has been submitted successfully.":                                              "Su postulación se ha enviado correctamente.",
+	"Unfortunately, we have decided not to move forward with your application at this time.":         "Lamentablemente, hemos decidido no continuar con su postulación por el momento.",
+	"Congratulations! You have been shortlisted for the next round.":                                 "¡Felicidades! Ha sido preseleccionado para la siguiente ronda.",
+
+	// Withdrawal
+	"Your application has been withdrawn. You may apply to this job again.": "Su postulación ha sido retirada. Puede volver a postularse a este empleo.",
+	"This application has already been withdrawn.":                          "Esta postulación ya ha sido retirada.",
+	"This application has been rejected and can no longer be withdrawn.":    "Esta postulación fue rechazada y ya no se puede retirar.",
+
+	// Job lifecycle
+	"This job has been filled and is no longer accepting applications.":                    "Este empleo ya se cubrió y no acepta más postulaciones.",
+	"This job has been closed and is no longer accepting applications.":                    "Este empleo se cerró y no acepta más postulaciones.",
+	"This job is paused and not accepting applications right now. Please try again later.": "Este empleo está en pausa y no acepta postulaciones por ahora. Vuelva a intentarlo más tarde.",
+	"This job has not been published yet.":                                                 "Este empleo aún no se ha publicado.",
+
+	// Editing
+	"This application can only be edited while its status is received.": "Esta postulación solo se puede editar mientras su estado sea received.",
+	"The edit has several problems. See violations for details.":        "La edición tiene varios problemas. Consulte violations para más detalles.",
+
+	// Rate limiting and simulated failures
+	"Too many requests. Please wait before trying again.":                "Demasiadas solicitudes. Espere antes de volver a intentarlo.",
+	"Too many application submissions. Please wait before trying again.": "Demasiadas postulaciones. Espere antes de volver a intentarlo.",
+	"Simulated failure for testing. Please retry.":                       "Fallo simulado para pruebas. Vuelva a intentarlo.",
+	"Request timed out. Please try again.":                               "La solicitud excedió el tiempo de espera. Vuelva a intentarlo.",
+	"An unexpected error occurred. Please try again later.":              "Se produjo un error inesperado. Inténtelo de nuevo más tarde.",
+	"The service is down for maintenance. Please try again later.":       "El servicio está en mantenimiento. Inténtelo de nuevo más tarde.",
+	"The service is overloaded. Please try again later.":                 "El servicio está sobrecargado. Inténtelo de nuevo más tarde.",
+
+	// X-Simulate
+	"Invalid X-Simulate header. Valid values: a status from 400 to 599, slow=<duration>, timeout, truncated, wrong-content-type, html-error, padded, reset, joined with commas.": "Encabezado X-Simulate no válido. Valores válidos: un estado de 400 a 599, slow=<duración>, timeout, truncated, wrong-content-type, html-error, padded, reset, unidos con comas.",
+
+	// Webhooks
+	"Webhook url must be an absolute http or https URL.": "La url del webhook debe ser una URL http o https absoluta.",
+	"grace_period_seconds must not be negative.":         "grace_period_seconds no puede ser negativo.",
+	"The specified webhook could not be found.":          "No se pudo encontrar el webhook especificado.",
+
+	// API keys
+	"The API key is unknown or has been revoked.":    "La clave de API es desconocida o ha sido revocada.",
+	"The specified API key could not be found.":      "No se pudo encontrar la clave de API especificada.",
+	"Send an API key in X-API-Key to see its usage.": "Envíe una clave de API en X-API-Key para ver su uso.",
+
+	// Mailbox
+	"Query parameter 'email' is required.": "El parámetro de consulta 'email' es obligatorio.",
+
+	// Email verification
+	"Application submitted. Verify your email address with the code we sent to complete it.":  "Postulación enviada. Verifique su dirección de correo con el código que le enviamos para completarla.",
+	"Your application is waiting for you to verify your email address with the code we sent.": "Su postulación está a la espera de que verifique su dirección de correo con el código que le enviamos.",
+	"This application is not waiting for email verification.":                                 "Esta postulación no está a la espera de verificación de correo.",
+	"The verification token is incorrect. Use the code in the email sent to the applicant.":   "El token de verificación es incorrecto. Use el código del correo enviado al postulante.",
+
+	// Interviews
+	"Interviews are only scheduled for shortlisted applications.":                                       "Las entrevistas solo se programan para postulaciones preseleccionadas.",
+	"The specified interview slot could not be found. Pick one from the application's interview-slots.": "No se pudo encontrar el horario de entrevista especificado. Elija uno de los interview-slots de la postulación.",
+	"This interview slot has been booked by another applicant. Pick another one.":                       "Este horario de entrevista ya fue reservado por otro postulante. Elija otro.",
+	"This interview slot has already started. Pick another one.":                                        "Este horario de entrevista ya comenzó. Elija otro.",
+	"No interview is booked for this application. Book one with POST /api/applications/:id/schedule.":   "No hay ninguna entrevista reservada para esta postulación. Reserve una con POST /api/applications/:id/schedule.",
+	"This interview slot overlaps another interview the applicant has booked.":                          "Este horario de entrevista se superpone con otra entrevista que el postulante ya reservó.",
+
+	// Take-home assignments
+	"Take-home assignments are only set for shortlisted applications.":                         "Las tareas para casa solo se asignan a postulaciones preseleccionadas.",
+	"This application's job does not set a take-home assignment.":                              "El empleo de esta postulación no incluye una tarea para casa.",
+	"The deadline for this assignment has passed. Submissions are no longer accepted.":         "El plazo de esta tarea ya venció. Ya no se aceptan entregas.",
+	"An assignment has already been submitted for this application.":                           "Ya se entregó una tarea para esta postulación.",
+	"content must not be blank.":                                                               "content no debe estar vacío.",
+	"url must be an absolute http or https URL.":                                               "url debe ser una URL http o https absoluta.",
+	"Your take-home assignment has been submitted and will be reviewed with your application.": "Su tarea para casa fue entregada y se revisará junto con su postulación.",
+
+	// Saved jobs
+	"This job is not among the saved jobs of this email address.":             "Este empleo no está entre los empleos guardados de esta dirección de correo.",
+	"This email address has saved as many jobs as it may. Remove some first.": "Esta dirección de correo ya guardó tantos empleos como se permite. Quite algunos primero.",
+	"note must be at most 1000 characters.":                                   "note debe tener como máximo 1000 caracteres.",
+
+	// Applicant profiles
+	"The specified applicant could not be found.":                   "No se pudo encontrar el postulante especificado.",
+	"A profile already exists for this email address.":              "Ya existe un perfil para esta dirección de correo.",
+	"The profile has several problems. See violations for details.": "El perfil tiene varios problemas. Consulte violations para más detalles.",
+	"Skills must not be blank.":                                     "Las habilidades no deben estar vacías.",
+	"Degrees must not be blank.":                                    "Los títulos no deben estar vacíos.",
+	"skills may list at most 50 skills.":                            "skills puede incluir como máximo 50 habilidades.",
+
+	// Applicant accounts
+	"The registration has several problems. See violations for details.":                    "El registro tiene varios problemas. Consulte violations para más detalles.",
+	"This email address is already registered. Log in instead.":                             "Esta dirección de correo ya está registrada. Inicie sesión en su lugar.",
+	"The email address or password is incorrect.":                                           "La dirección de correo o la contraseña no son correctas.",
+	"password must be at least 8 characters.":                                               "password debe tener al menos 8 caracteres.",
+	"Log in with POST /api/auth/login and send the token as Authorization: Bearer <token>.": "Inicie sesión con POST /api/auth/login y envíe el token como Authorization: Bearer <token>.",
+	"The token has expired. Log in again for a new one.":                                    "El token venció. Inicie sesión de nuevo para obtener uno nuevo.",
+	"The token is malformed or was not issued by this sandbox.":                             "El token está mal formado o no fue emitido por este sandbox.",
+	"This application belongs to another applicant.":                                        "Esta postulación pertenece a otro postulante.",
+	"applicant_email must be the address the token was issued for.":                         "applicant_email debe ser la dirección para la que se emitió el token.",
+	"Tokens only list the applications of the address they were issued for.":                "Los tokens solo listan las postulaciones de la dirección para la que se emitieron.",
+
+	// Application wizard
+	"Applications are submitted step by step. Start a draft with POST /api/applications/draft.":                    "Las postulaciones se envían paso a paso. Inicie un borrador con POST /api/applications/draft.",
+	"The specified draft could not be found. Drafts expire a day after they were last changed.":                    "No se pudo encontrar el borrador especificado. Los borradores vencen un día después de su último cambio.",
+	"This draft belongs to another applicant.":                                                                     "Este borrador pertenece a otro postulante.",
+	"Unknown step. Steps are personal, resume and questions; review with POST /api/applications/draft/:id/review.": "Paso desconocido. Los pasos son personal, resume y questions; revise con POST /api/applications/draft/:id/review.",
+	"Complete the personal step first.":                                                                            "Complete primero el paso personal.",
+	"Complete the resume step first.":                                                                              "Complete primero el paso resume.",
+	"Complete the questions step first.":                                                                           "Complete primero el paso questions.",
+	"Complete the review step first.":                                                                              "Complete primero el paso review.",
+	"The personal step has several problems. See violations for details.":                                          "El paso personal tiene varios problemas. Consulte violations para más detalles.",
+	"The resume step has several problems. See violations for details.":                                            "El paso resume tiene varios problemas. Consulte violations para más detalles.",
+	"The questions step has several problems. See violations for details.":                                         "El paso questions tiene varios problemas. Consulte violations para más detalles.",
+	"The draft has several problems. See violations for details.":                                                  "El borrador tiene varios problemas. Consulte violations para más detalles.",
+
+	// Anti-bot challenges
+	"The challenge cannot be solved before not_before. Please try again later.":                       "El desafío no se puede resolver antes de not_before. Inténtelo de nuevo más tarde.",
+	"The solution is wrong. Request a new challenge.":                                                 "La solución es incorrecta. Solicite un nuevo desafío.",
+	"The challenge has expired. Request a new challenge.":                                             "El desafío ha vencido. Solicite un nuevo desafío.",
+	"The specified challenge could not be found. It may have been solved or failed already.":          "No se pudo encontrar el desafío especificado. Puede que ya se haya resuelto o fallado.",
+	"Solve a challenge from POST /api/challenges and send its token in the X-Challenge-Token header.": "Resuelva un desafío de POST /api/challenges y envíe su token en el encabezado X-Challenge-Token.",
+	"The challenge token is invalid, expired or already used. Solve a new challenge.":                 "El token del desafío no es válido, ha vencido o ya se usó. Resuelva un nuevo desafío.",
+
+	// MCP
+	"The specified MCP session could not be found.": "No se pudo encontrar la sesión MCP especificada.",
+
+	// Stats
+	"Invalid value for 'by'. Valid values: company": "Valor no válido para 'by'. Valores válidos: company",
+}
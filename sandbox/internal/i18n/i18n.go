@@ -0,0 +1,100 @@
+// Package i18n selects the response language and translates the
+// human-readable messages of the JSON API. Messages are looked up by their
+// English text, so untranslated or dynamic messages fall back to English.
+// Machine-readable error codes are never translated.
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Default is the language used when nothing better can be negotiated
+const Default = "en"
+
+// catalogs maps a language to its translations, keyed by the English message
+var catalogs = map[string]map[string]string{
+	"es": spanish,
+}
+
+// Supported returns the supported language tags, starting with the default
+func Supported() []string {
+	langs := []string{Default}
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs[1:])
+	return langs
+}
+
+// Negotiate picks the response language. A supported override (from ?lang=)
+// wins; otherwise the Accept-Language header is matched by quality value,
+// comparing primary subtags so "es-MX" selects "es". It falls back to English.
+func Negotiate(override, acceptLanguage string) string {
+	if lang := primaryTag(override); isSupported(lang) {
+		return lang
+	}
+
+	type weighted struct {
+		lang string
+		q    float64
+	}
+	var ranges []weighted
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(part, ";")
+		lang := primaryTag(fields[0])
+		if lang == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if found && strings.TrimSpace(key) == "q" {
+				if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		ranges = append(ranges, weighted{lang, q})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].q > ranges[j].q })
+	for _, r := range ranges {
+		if r.q <= 0 {
+			continue
+		}
+		if r.lang == "*" {
+			return Default
+		}
+		if isSupported(r.lang) {
+			return r.lang
+		}
+	}
+
+	return Default
+}
+
+// T translates an English message into lang, returning it unchanged when
+// no translation exists
+func T(lang, message string) string {
+	if translated, ok := catalogs[lang][message]; ok {
+		return translated
+	}
+	return message
+}
+
+func isSupported(lang string) bool {
+	if lang == Default {
+		return true
+	}
+	_, ok := catalogs[lang]
+	return ok
+}
+
+// primaryTag returns the lowercased primary subtag, e.g. "es" for "es-MX"
+func primaryTag(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	primary, _, _ := strings.Cut(tag, "-")
+	return primary
+}
@@ -0,0 +1,54 @@
+package i18n
+
+import "testing"
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		override, header string
+		want             string
+	}{
+		{"", "", "en"},
+		{"", "es", "es"},
+		{"", "es-MX", "es"},
+		{"", "ES-mx", "es"},
+		{"", "fr", "en"},
+		{"", "fr, es;q=0.5", "es"},
+		{"", "es;q=0.4, en;q=0.8", "en"},
+		{"", "en;q=0.4, es;q=0.8", "es"},
+		{"", "es;q=0, en;q=0.1", "en"},
+		{"", "es;q=0", "en"},
+		{"", "*", "en"},
+		{"", "fr, *;q=0.5, es;q=0.4", "en"},
+		{"", "es;q=abc", "es"}, // An unreadable q counts as 1
+		{"", " , ;q=1", "en"},
+		{"es", "en", "es"},
+		{"es-AR", "", "es"},
+		{"fr", "es", "es"}, // An unsupported override is ignored
+		{"", "de-DE, de;q=0.9, es-419;q=0.8", "es"},
+	}
+	for _, tt := range tests {
+		if got := Negotiate(tt.override, tt.header); got != tt.want {
+			t.Errorf("Negotiate(%q, %q) = %q, want %q", tt.override, tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	if got := T("es", "Resume is required."); got != "El currículum es obligatorio." {
+		t.Errorf("T(es) = %q", got)
+	}
+	for _, lang := range []string{"en", "fr", ""} {
+		if got := T(lang, "Resume is required."); got != "Resume is required." {
+			t.Errorf("T(%q) = %q, want the English message", lang, got)
+		}
+	}
+	if got := T("es", "A message with no translation."); got != "A message with no translation." {
+		t.Errorf("untranslated message = %q, want it unchanged", got)
+	}
+}
+
+func TestSupported(t *testing.T) {
+	if got := Supported(); len(got) < 2 || got[0] != Default {
+		t.Errorf("Supported() = %v, want English first and another language", got)
+	}
+}
@@ -0,0 +1,91 @@
+// Package ical writes iCalendar (RFC 5545) calendars of events. It writes
+// just what calendar apps need to import an event: lines end in CRLF, are
+// folded at 75 octets without splitting a UTF-8 sequence, and text values
+// have their backslashes, semicolons, commas and newlines escaped.
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxLineOctets is the longest a content line may be, CRLF excluded
+const maxLineOctets = 75
+
+// dateTimeFormat is a UTC DATE-TIME value
+const dateTimeFormat = "20060102T150405Z"
+
+// Event is a VEVENT
+type Event struct {
+	// UID identifies the event across exports, so calendars update it
+	// rather than adding a copy
+	UID         string
+	Stamp       time.Time // When the event was created
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+}
+
+// Marshal returns a VCALENDAR holding events, identified as written by
+// prodID
+func Marshal(prodID string, events []Event) []byte {
+	var b bytes.Buffer
+	writeLine(&b, "BEGIN:VCALENDAR")
+	writeLine(&b, "VERSION:2.0")
+	writeLine(&b, "PRODID:"+escapeText(prodID))
+	writeLine(&b, "CALSCALE:GREGORIAN")
+	writeLine(&b, "METHOD:PUBLISH")
+	for _, event := range events {
+		writeLine(&b, "BEGIN:VEVENT")
+		writeLine(&b, "UID:"+escapeText(event.UID))
+		writeLine(&b, "DTSTAMP:"+event.Stamp.UTC().Format(dateTimeFormat))
+		writeLine(&b, "DTSTART:"+event.Start.UTC().Format(dateTimeFormat))
+		writeLine(&b, "DTEND:"+event.End.UTC().Format(dateTimeFormat))
+		writeLine(&b, "SUMMARY:"+escapeText(event.Summary))
+		if event.Description != "" {
+			writeLine(&b, "DESCRIPTION:"+escapeText(event.Description))
+		}
+		if event.Location != "" {
+			writeLine(&b, "LOCATION:"+escapeText(event.Location))
+		}
+		if event.URL != "" {
+			// URL is a URI value, which is not escaped
+			writeLine(&b, "URL:"+event.URL)
+		}
+		writeLine(&b, "END:VEVENT")
+	}
+	writeLine(&b, "END:VCALENDAR")
+	return b.Bytes()
+}
+
+// textEscaper escapes a TEXT value
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// escapeText escapes a TEXT value
+func escapeText(value string) string {
+	return textEscaper.Replace(value)
+}
+
+// writeLine writes a content line, folded so no line is longer than
+// maxLineOctets. Continuation lines start with a space, which counts
+// towards their length.
+func writeLine(b *bytes.Buffer, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = maxLineOctets - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
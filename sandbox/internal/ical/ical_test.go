@@ -0,0 +1,153 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+var testEvent = Event{
+	UID:         "interview-CONF-1@job-portal-sandbox",
+	Stamp:       time.Date(2026, 10, 1, 8, 30, 0, 0, time.UTC),
+	Start:       time.Date(2026, 10, 5, 14, 0, 0, 0, time.FixedZone("CEST", 2*60*60)),
+	End:         time.Date(2026, 10, 5, 14, 45, 0, 0, time.FixedZone("CEST", 2*60*60)),
+	Summary:     "Interview: Engineer, Platform; Infra at Acme, Inc.",
+	Description: "Video interview with Alex Chen.\nConfirmation code INT-1. C:\\path",
+	Location:    "Berlin, Germany",
+	URL:         "http://localhost:8080/applications/CONF-1?a=1,2;3",
+}
+
+// validate reports the first way data breaks the iCalendar rules the
+// package follows, or "" if it does not
+func validate(data []byte) string {
+	if !bytes.HasSuffix(data, []byte("\r\n")) {
+		return "does not end in CRLF"
+	}
+	var stack []string
+	lines := strings.Split(strings.TrimSuffix(string(data), "\r\n"), "\r\n")
+	for i, line := range lines {
+		switch {
+		case strings.ContainsAny(line, "\r\n"):
+			return "line " + line + " has a bare CR or LF"
+		case len(line) > maxLineOctets:
+			return "line " + line + " is longer than 75 octets"
+		case !utf8.ValidString(line):
+			return "line " + line + " splits a UTF-8 sequence"
+		case i > 0 && line == "":
+			return "empty line"
+		}
+	}
+	for _, line := range unfold(data) {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return "line " + line + " has no value"
+		}
+		switch name {
+		case "BEGIN":
+			stack = append(stack, value)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1] != value {
+				return "END:" + value + " does not close a BEGIN"
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+	if len(stack) != 0 {
+		return "BEGIN:" + stack[0] + " is not closed"
+	}
+	return ""
+}
+
+// unfold joins folded lines back into content lines
+func unfold(data []byte) []string {
+	text := strings.ReplaceAll(string(data), "\r\n ", "")
+	return strings.Split(strings.TrimSuffix(text, "\r\n"), "\r\n")
+}
+
+// unescape reverses escapeText
+func unescape(value string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n").Replace(value)
+}
+
+// property returns the value of the first content line named name
+func property(lines []string, name string) string {
+	for _, line := range lines {
+		if value, ok := strings.CutPrefix(line, name+":"); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+func TestMarshal(t *testing.T) {
+	data := Marshal("-//Sandbox//Interviews//EN", []Event{testEvent})
+	if problem := validate(data); problem != "" {
+		t.Fatalf("invalid calendar: %s\n%s", problem, data)
+	}
+
+	lines := unfold(data)
+	want := map[string]string{
+		"VERSION": "2.0",
+		"DTSTAMP": "20261001T083000Z",
+		"DTSTART": "20261005T120000Z",
+		"DTEND":   "20261005T124500Z",
+		"SUMMARY": `Interview: Engineer\, Platform\; Infra at Acme\, Inc.`,
+		"URL":     testEvent.URL,
+	}
+	for name, value := range want {
+		if got := property(lines, name); got != value {
+			t.Errorf("%s:%s, want %s", name, got, value)
+		}
+	}
+	if got := unescape(property(lines, "DESCRIPTION")); got != testEvent.Description {
+		t.Errorf("DESCRIPTION unescapes to %q, want %q", got, testEvent.Description)
+	}
+	if got := property(lines, "LOCATION"); got != `Berlin\, Germany` {
+		t.Errorf("LOCATION:%s", got)
+	}
+}
+
+func TestMarshalEmpty(t *testing.T) {
+	data := Marshal("-//Sandbox//Interviews//EN", nil)
+	if problem := validate(data); problem != "" {
+		t.Fatalf("invalid calendar: %s", problem)
+	}
+	if bytes.Contains(data, []byte("VEVENT")) {
+		t.Errorf("calendar without events has one:\n%s", data)
+	}
+}
+
+func TestFolding(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary string
+	}{
+		{"short", "Interview"},
+		{"exactly one line", strings.Repeat("a", maxLineOctets-len("SUMMARY:"))},
+		{"one octet over", strings.Repeat("a", maxLineOctets-len("SUMMARY:")+1)},
+		{"several lines", strings.Repeat("abcdefghij", 30)},
+		{"multibyte", strings.Repeat("日本語のタイトル", 20)},
+		{"escapes across a fold", strings.Repeat("a,b;c", 40)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := testEvent
+			event.Summary = tt.summary
+			data := Marshal("-//Sandbox//Interviews//EN", []Event{event})
+			if problem := validate(data); problem != "" {
+				t.Fatalf("invalid calendar: %s\n%s", problem, data)
+			}
+			if got := unescape(property(unfold(data), "SUMMARY")); got != tt.summary {
+				t.Errorf("SUMMARY unfolds to %q, want %q", got, tt.summary)
+			}
+			folded := len("SUMMARY:"+escapeText(tt.summary)) > maxLineOctets
+			_, after, _ := bytes.Cut(data, []byte("\r\nSUMMARY:"))
+			_, next, _ := bytes.Cut(after, []byte("\r\n"))
+			if got := next[0] == ' '; got != folded {
+				t.Errorf("folded %v, want %v", got, folded)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Package jwt issues and verifies the HS256 JSON Web Tokens applicants
+// authenticate with. Only the claims the sandbox uses are supported: the
+// subject (the applicant's email address), when the token was issued and
+// when it expires.
+package jwt
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Errors returned by Verify
+var (
+	ErrMalformed = errors.New("token is malformed")
+	ErrSignature = errors.New("token signature is invalid")
+	ErrExpired   = errors.New("token has expired")
+)
+
+// header is the only JOSE header tokens are issued with
+const header = `{"alg":"HS256","typ":"JWT"}`
+
+// Claims are the registered claims a token carries
+type Claims struct {
+	Subject   string `json:"sub"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// Sign returns a token for subject, valid for ttl from now, signed with
+// secret
+func Sign(subject string, secret []byte, now time.Time, ttl time.Duration) (string, error) {
+	payload, err := json.Marshal(Claims{
+		Subject:   subject,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	signed := encode([]byte(header)) + "." + encode(payload)
+	return signed + "." + encode(signature(signed, secret)), nil
+}
+
+// Verify checks a token's algorithm, signature and expiry and returns its
+// claims
+func Verify(token string, secret []byte, now time.Time) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Claims{}, ErrMalformed
+	}
+
+	rawHeader, err := decode(parts[0])
+	if err != nil {
+		return Claims{}, ErrMalformed
+	}
+	var jose struct {
+		Alg string `json:"alg"`
+	}
+	if json.Unmarshal(rawHeader, &jose) != nil {
+		return Claims{}, ErrMalformed
+	}
+	// Accepting any other algorithm, "none" above all, would let clients
+	// forge tokens
+	if jose.Alg != "HS256" {
+		return Claims{}, ErrSignature
+	}
+
+	given, err := decode(parts[2])
+	if err != nil {
+		return Claims{}, ErrMalformed
+	}
+	if !hmac.Equal(given, signature(parts[0]+"."+parts[1], secret)) {
+		return Claims{}, ErrSignature
+	}
+
+	rawClaims, err := decode(parts[1])
+	if err != nil {
+		return Claims{}, ErrMalformed
+	}
+	var claims Claims
+	if json.Unmarshal(rawClaims, &claims) != nil || claims.Subject == "" {
+		return Claims{}, ErrMalformed
+	}
+	if now.Unix() >= claims.ExpiresAt {
+		return Claims{}, ErrExpired
+	}
+	return claims, nil
+}
+
+func signature(signed string, secret []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+	return mac.Sum(nil)
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decode(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
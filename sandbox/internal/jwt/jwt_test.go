@@ -0,0 +1,79 @@
+package jwt
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	secret = []byte("test-secret")
+	issued = time.Date(2026, 2, 1, 10, 30, 0, 0, time.UTC)
+)
+
+func TestSignVerify(t *testing.T) {
+	token, err := Sign("ann@example.com", secret, issued, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := Verify(token, secret, issued.Add(59*time.Minute))
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	want := Claims{Subject: "ann@example.com", IssuedAt: issued.Unix(), ExpiresAt: issued.Add(time.Hour).Unix()}
+	if claims != want {
+		t.Errorf("claims %+v, want %+v", claims, want)
+	}
+	if got, _ := decode(strings.Split(token, ".")[0]); string(got) != header {
+		t.Errorf("header %s, want %s", got, header)
+	}
+}
+
+func TestVerifyRejects(t *testing.T) {
+	token, err := Sign("ann@example.com", secret, issued, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(token, ".")
+	// signedAs returns a token with header and claims, signed with secret
+	signedAs := func(header, claims string) string {
+		signed := encode([]byte(header)) + "." + encode([]byte(claims))
+		return signed + "." + encode(signature(signed, secret))
+	}
+	forged, err := Sign("ann@example.com", []byte("other-secret"), issued, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	flipped, _ := decode(parts[2])
+	flipped[0] ^= 1
+
+	tests := []struct {
+		name  string
+		token string
+		now   time.Time
+		want  error
+	}{
+		{"expired", token, issued.Add(time.Hour), ErrExpired},
+		{"long expired", token, issued.Add(24 * time.Hour), ErrExpired},
+		{"wrong secret", forged, issued, ErrSignature},
+		{"tampered signature", parts[0] + "." + parts[1] + "." + encode(flipped), issued, ErrSignature},
+		{"tampered claims", parts[0] + "." + encode([]byte(`{"sub":"bob@example.com","iat":0,"exp":9999999999}`)) + "." + parts[2], issued, ErrSignature},
+		{"no signature", parts[0] + "." + parts[1] + ".", issued, ErrSignature},
+		{"alg none", encode([]byte(`{"alg":"none","typ":"JWT"}`)) + "." + parts[1] + ".", issued, ErrSignature},
+		{"alg HS512", signedAs(`{"alg":"HS512","typ":"JWT"}`, `{"sub":"ann@example.com","exp":9999999999}`), issued, ErrSignature},
+		{"alg missing", signedAs(`{"typ":"JWT"}`, `{"sub":"ann@example.com","exp":9999999999}`), issued, ErrSignature},
+		{"two parts", parts[0] + "." + parts[1], issued, ErrMalformed},
+		{"header not base64", "*." + parts[1] + "." + parts[2], issued, ErrMalformed},
+		{"header not JSON", encode([]byte("HS256")) + "." + parts[1] + "." + parts[2], issued, ErrMalformed},
+		{"no subject", signedAs(header, `{"exp":9999999999}`), issued, ErrMalformed},
+		{"empty", "", issued, ErrMalformed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Verify(tt.token, secret, tt.now); !errors.Is(err, tt.want) {
+				t.Errorf("Verify = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
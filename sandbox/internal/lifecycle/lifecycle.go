@@ -0,0 +1,92 @@
+// Package lifecycle moves jobs through their statuses on a schedule, the
+// way an employer's careers site would: open and paused jobs close once
+// their application deadline passes, and open jobs with max_applications
+// are filled once they have that many, and open again if a withdrawal
+// frees a place.
+package lifecycle
+
+import (
+	"context"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+)
+
+// tick is how often the worker looks for jobs to close or fill
+const tick = time.Second
+
+// Worker closes expired jobs and fills full ones. Changes go through the
+// job store, so a status set by hand in the meantime is never undone. Only
+// jobs the worker filled itself are opened again, never those marked
+// filled by hand.
+type Worker struct {
+	jobStore *store.JobStore
+	appStore *store.ApplicationStore
+	filled   map[string]bool // IDs of the jobs the worker filled
+}
+
+// NewWorker creates a lifecycle worker for the jobs in jobStore and their
+// applications in appStore
+func NewWorker(jobStore *store.JobStore, appStore *store.ApplicationStore) *Worker {
+	return &Worker{jobStore: jobStore, appStore: appStore, filled: make(map[string]bool)}
+}
+
+// Run moves jobs along until ctx is cancelled, starting with the jobs due
+// when it is called
+func (w *Worker) Run(ctx context.Context) {
+	w.Step(time.Now())
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			w.Step(now)
+		}
+	}
+}
+
+// Step closes every open or paused job whose deadline has passed by now,
+// fills every open job that has taken its max_applications, not counting
+// withdrawn applications, and opens again the jobs it filled that a
+// withdrawal has left short
+func (w *Worker) Step(now time.Time) {
+	for _, job := range w.jobStore.GetAll(0) {
+		expired := !job.Deadline.IsZero() && now.After(job.Deadline)
+		if job.Status == models.JobFilled {
+			if w.filled[job.ID] && !expired && !w.full(job) {
+				w.reopen(job)
+			}
+			continue
+		}
+		// Any other status was set by hand, or by the worker for good
+		delete(w.filled, job.ID)
+
+		switch {
+		case job.Status != models.JobOpen && job.Status != models.JobPaused:
+		case expired:
+			w.jobStore.AdvanceStatus(job.ID, job.Status, models.JobClosed)
+		case job.Status == models.JobOpen && w.full(job):
+			if filled, err := w.jobStore.AdvanceStatus(job.ID, job.Status, models.JobFilled); err == nil && filled.Status == models.JobFilled {
+				w.filled[job.ID] = true
+			}
+		}
+	}
+}
+
+// full reports whether a job has taken its max_applications, not counting
+// withdrawn applications
+func (w *Worker) full(job models.Job) bool {
+	return job.MaxApplications > 0 && w.appStore.GetActiveCountByJobID(job.ID) >= job.MaxApplications
+}
+
+// reopen opens a job the worker filled, unless its status was changed by
+// hand in the meantime
+func (w *Worker) reopen(job models.Job) {
+	if _, err := w.jobStore.AdvanceStatus(job.ID, models.JobFilled, models.JobOpen); err == nil {
+		delete(w.filled, job.ID)
+	}
+}
@@ -0,0 +1,67 @@
+package lifecycle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+)
+
+// apply submits an application from email to job
+func apply(t *testing.T, apps *store.ApplicationStore, job models.Job, email string) *models.Application {
+	t.Helper()
+	app, err := apps.Create(models.ApplicationRequest{
+		JobID:          job.ID,
+		ApplicantName:  "Vic Tester",
+		ApplicantEmail: email,
+		Resume:         "Ten years of building web services in Go and Python.",
+	}, job, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return app
+}
+
+// TestWithdrawalReopensFilledJob checks a job the worker filled opens again
+// when a withdrawal frees a place, but a job filled by hand does not
+func TestWithdrawalReopensFilledJob(t *testing.T) {
+	capped := models.Job{ID: "job_capped", Title: "Backend Engineer", Company: "Acme", MaxApplications: 1}
+	byHand := models.Job{ID: "job_by_hand", Title: "Designer", Company: "Acme", MaxApplications: 1}
+	jobs, err := store.NewJobStore([]models.Job{capped, byHand}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	apps := store.NewApplicationStore()
+	w := NewWorker(jobs, apps)
+	status := func(id string) models.JobStatus {
+		job, _ := jobs.GetByID(id)
+		return job.Status
+	}
+
+	app := apply(t, apps, capped, "ann@example.com")
+	w.Step(time.Now())
+	if got := status(capped.ID); got != models.JobFilled {
+		t.Fatalf("%s with its one application: %s, want filled", capped.ID, got)
+	}
+	if _, err := apps.Withdraw(app.ID, ""); err != nil {
+		t.Fatal(err)
+	}
+	w.Step(time.Now())
+	if got := status(capped.ID); got != models.JobOpen {
+		t.Errorf("%s after the withdrawal: %s, want open", capped.ID, got)
+	}
+	apply(t, apps, capped, "bob@example.com")
+	w.Step(time.Now())
+	if got := status(capped.ID); got != models.JobFilled {
+		t.Errorf("%s refilled: %s, want filled", capped.ID, got)
+	}
+
+	if _, err := jobs.UpdateStatus(byHand.ID, models.JobFilled); err != nil {
+		t.Fatal(err)
+	}
+	w.Step(time.Now())
+	if got := status(byHand.ID); got != models.JobFilled {
+		t.Errorf("%s filled by hand without applications: %s, want it left filled", byHand.ID, got)
+	}
+}
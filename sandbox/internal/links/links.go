@@ -0,0 +1,135 @@
+// Package links validates and normalizes the profile links applicants give:
+// LinkedIn, GitHub and portfolio URLs. Links must use https; LinkedIn and
+// GitHub links must point at their site unless host checking is relaxed,
+// and recognizable bare handles expand to the canonical profile URL.
+// Normalized links have a lowercase host, no tracking parameters, no
+// fragment and no trailing slash.
+package links
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Errors returned by the normalizers
+var (
+	ErrNotURL       = errors.New("not a URL or profile handle")
+	ErrInsecure     = errors.New("URL must use https")
+	ErrHostNotAllow = errors.New("URL points at the wrong site")
+)
+
+var (
+	// linkedInHandle matches in/johndoe
+	linkedInHandle = regexp.MustCompile(`^in/([A-Za-z0-9À-ÿ_-]{3,100})/?$`)
+	// gitHubHandle matches johndoe or @johndoe, following GitHub's username rules
+	gitHubHandle = regexp.MustCompile(`^@?([A-Za-z0-9](?:[A-Za-z0-9]|-[A-Za-z0-9]){0,38})$`)
+	// hostLike matches values that start with a domain name, e.g. johndoe.dev/about
+	hostLike = regexp.MustCompile(`^[A-Za-z0-9-]+(\.[A-Za-z0-9-]+)+(:\d+)?([/?#].*)?$`)
+)
+
+// placeholders are answers agents give instead of a link
+var placeholders = map[string]bool{
+	"yes": true, "no": true, "none": true, "null": true, "nil": true,
+	"true": true, "false": true, "na": true, "n/a": true, "-": true,
+}
+
+// trackingParams are query parameters that identify a click, not a page
+var trackingParams = map[string]bool{
+	"fbclid": true, "gclid": true, "msclkid": true, "mc_cid": true, "mc_eid": true,
+	"trk": true, "trkinfo": true, "originalsubdomain": true, "lipi": true, "ref": true,
+}
+
+// LinkedIn normalizes a LinkedIn profile URL or an in/handle. Unless
+// anyHost is set, the host must be linkedin.com or one of its subdomains.
+func LinkedIn(raw string, anyHost bool) (string, error) {
+	value := strings.TrimSpace(raw)
+	if m := linkedInHandle.FindStringSubmatch(value); m != nil {
+		return "https://www.linkedin.com/in/" + m[1], nil
+	}
+	u, err := parse(value)
+	if err != nil {
+		return "", err
+	}
+	if onHost(u.Host, "linkedin.com") {
+		u.Host = "www.linkedin.com"
+	} else if !anyHost {
+		return "", ErrHostNotAllow
+	}
+	return u.String(), nil
+}
+
+// GitHub normalizes a GitHub profile URL or a username (optionally with a
+// leading @). Unless anyHost is set, the host must be github.com.
+func GitHub(raw string, anyHost bool) (string, error) {
+	value := strings.TrimSpace(raw)
+	if m := gitHubHandle.FindStringSubmatch(value); m != nil && !placeholders[strings.ToLower(m[1])] {
+		return "https://github.com/" + m[1], nil
+	}
+	u, err := parse(value)
+	if err != nil {
+		return "", err
+	}
+	if u.Host == "github.com" || u.Host == "www.github.com" {
+		u.Host = "github.com"
+	} else if !anyHost {
+		return "", ErrHostNotAllow
+	}
+	return u.String(), nil
+}
+
+// Portfolio normalizes a portfolio URL on any host
+func Portfolio(raw string) (string, error) {
+	u, err := parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// parse reads an https URL, assuming https for values that start with a
+// domain name, and normalizes it
+func parse(value string) (*url.URL, error) {
+	if placeholders[strings.ToLower(value)] {
+		return nil, ErrNotURL
+	}
+	if hostLike.MatchString(value) {
+		value = "https://" + value
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" || u.User != nil || !strings.Contains(u.Hostname(), ".") {
+		return nil, ErrNotURL
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "https":
+	case "http":
+		return nil, ErrInsecure
+	default:
+		return nil, ErrNotURL
+	}
+
+	u.Scheme = "https"
+	u.Host = strings.TrimSuffix(strings.ToLower(u.Host), ":443")
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = ""
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	query := u.Query()
+	for key := range query {
+		lower := strings.ToLower(key)
+		if strings.HasPrefix(lower, "utm_") || trackingParams[lower] {
+			query.Del(key)
+		}
+	}
+	u.RawQuery = query.Encode()
+
+	return u, nil
+}
+
+// onHost reports whether host is domain or one of its subdomains
+func onHost(host, domain string) bool {
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
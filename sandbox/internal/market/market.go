@@ -0,0 +1,144 @@
+// Package market churns the job catalogue during a run, the way a real job
+// board changes under an agent: new jobs are posted, salaries and
+// deadlines are edited, and open postings close early. Agents that cache
+// the catalogue instead of crawling it again see stale data.
+package market
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/data"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/dates"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/random"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+)
+
+// minDeadlineNotice is the least time an edited deadline leaves to apply
+const minDeadlineNotice = 24 * time.Hour
+
+// Config is how fast the market moves
+type Config struct {
+	// Interval is how often the market moves; zero disables it
+	Interval time.Duration
+	// PostRate is the probability (0.0 to 1.0) that a new job is posted
+	// each interval
+	PostRate float64
+	// EditRate is the probability (0.0 to 1.0) that each open job has its
+	// salary or deadline changed each interval
+	EditRate float64
+	// CloseRate is the probability (0.0 to 1.0) that each open job is
+	// closed early each interval
+	CloseRate float64
+}
+
+// Engine posts, edits and closes jobs every Interval. Only open jobs whose
+// deadline is still ahead are edited or closed. Changes go through the job
+// store, so new jobs are published as job.created events like any other.
+type Engine struct {
+	jobStore *store.JobStore
+	config   Config
+	rng      *rand.Rand
+}
+
+// NewEngine creates a market engine for the jobs in jobStore
+func NewEngine(jobStore *store.JobStore, config Config) *Engine {
+	return &Engine{
+		jobStore: jobStore,
+		config:   config,
+		rng:      random.New("market"),
+	}
+}
+
+// Run moves the market until ctx is cancelled
+func (e *Engine) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			e.Step(now)
+		}
+	}
+}
+
+// Step moves the market one interval on, as of now
+func (e *Engine) Step(now time.Time) {
+	for _, job := range e.jobStore.GetAll(0) {
+		if job.Status != models.JobOpen || (!job.Deadline.IsZero() && now.After(job.Deadline)) {
+			continue
+		}
+		switch r := e.rng.Float64(); {
+		case r < e.config.CloseRate:
+			e.jobStore.Close(job.ID, now)
+		case r < e.config.CloseRate+e.config.EditRate:
+			e.edit(job, now)
+		}
+	}
+
+	if e.rng.Float64() < e.config.PostRate {
+		jobs, err := store.ParseJobDates([]models.Job{data.GenerateJob(e.rng, now)}, e.jobStore.Location())
+		if err == nil {
+			e.jobStore.Create(jobs[0])
+		}
+	}
+}
+
+// edit changes a job's deadline or, half the time and always for jobs
+// without a deadline, its salary
+func (e *Engine) edit(job models.Job, now time.Time) {
+	if job.Deadline.IsZero() || e.rng.Intn(2) == 0 {
+		salary := scaleSalary(job.Salary, 0.9+e.rng.Float64()*0.25)
+		if salary == job.Salary {
+			return
+		}
+		job.Salary = salary
+	} else {
+		// Anywhere from a week sooner to two weeks later, leaving at least
+		// a day to apply
+		deadline := job.Deadline.AddDate(0, 0, e.rng.Intn(22)-7)
+		if earliest := now.Add(minDeadlineNotice).Truncate(time.Second); deadline.Before(earliest) {
+			deadline = earliest
+		}
+		job.Deadline, job.ApplicationDeadline = deadline, dates.Format(deadline)
+	}
+	e.jobStore.Update(job)
+}
+
+// salaryAmount matches an amount in a salary such as "$130,000"
+var salaryAmount = regexp.MustCompile(`\d[\d,]*`)
+
+// scaleSalary multiplies every amount in a salary by factor, rounding to
+// the precision a posting would use and keeping thousands separators
+func scaleSalary(salary string, factor float64) string {
+	return salaryAmount.ReplaceAllStringFunc(salary, func(amount string) string {
+		value, err := strconv.Atoi(strings.ReplaceAll(amount, ",", ""))
+		if err != nil {
+			return amount
+		}
+		step := 1.0
+		switch {
+		case value >= 20000:
+			step = 1000
+		case value >= 1000:
+			step = 100
+		}
+		scaled := strconv.Itoa(int(math.Round(float64(value)*factor/step) * step))
+		if !strings.Contains(amount, ",") {
+			return scaled
+		}
+		for i := len(scaled) - 3; i > 0; i -= 3 {
+			scaled = scaled[:i] + "," + scaled[i:]
+		}
+		return scaled
+	})
+}
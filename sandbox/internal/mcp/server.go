@@ -0,0 +1,222 @@
+// Package mcp implements the tools subset of the Model Context Protocol: a
+// JSON-RPC 2.0 server that advertises tools with JSON Schema inputs and
+// dispatches tools/call requests to Go handlers. Transports (stdio, SSE)
+// only move raw messages; all protocol handling lives in Server.Handle.
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ProtocolVersions lists the supported protocol revisions, newest first
+var ProtocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// JSON-RPC error codes
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+)
+
+// Tool describes a tool advertised by tools/list
+type Tool struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	InputSchema interface{} `json:"inputSchema"`
+}
+
+// ToolHandler runs a tool with its raw JSON arguments. Returning a
+// *ToolError reports a tool-level failure to the client; any other error
+// is treated as an internal error.
+type ToolHandler func(ctx context.Context, arguments json.RawMessage) (interface{}, error)
+
+// ToolError is a tool failure carrying the sandbox's machine-readable error
+// code, the HTTP status the REST API would have answered with and any
+// field-level violations
+type ToolError struct {
+	Code       string      `json:"error"`
+	Message    string      `json:"message"`
+	Status     int         `json:"code"`
+	Violations interface{} `json:"violations,omitempty"`
+}
+
+// Error implements the error interface
+func (e *ToolError) Error() string {
+	return e.Message
+}
+
+// Server dispatches MCP requests to registered tools
+type Server struct {
+	name     string
+	version  string
+	tools    []Tool
+	handlers map[string]ToolHandler
+}
+
+// NewServer creates a server identifying itself with name and version
+func NewServer(name, version string) *Server {
+	return &Server{
+		name:     name,
+		version:  version,
+		handlers: make(map[string]ToolHandler),
+	}
+}
+
+// AddTool registers a tool and its handler
+func (s *Server) AddTool(tool Tool, handler ToolHandler) {
+	s.tools = append(s.tools, tool)
+	s.handlers[tool.Name] = handler
+}
+
+// request is a JSON-RPC request or notification
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// response is a JSON-RPC response
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcError is a JSON-RPC error object
+type rpcError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// content is a text content block of a tool result
+type content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// callResult is the result of tools/call. The JSON result is sent both as
+// text, for older clients, and as structured content.
+type callResult struct {
+	Content           []content   `json:"content"`
+	StructuredContent interface{} `json:"structuredContent,omitempty"`
+	IsError           bool        `json:"isError"`
+}
+
+// Handle processes one JSON-RPC message and returns the encoded response,
+// or nil for notifications
+func (s *Server) Handle(ctx context.Context, message []byte) []byte {
+	var req request
+	if err := json.Unmarshal(message, &req); err != nil {
+		return encode(response{ID: json.RawMessage("null"), Error: &rpcError{Code: CodeParseError, Message: "Parse error: " + err.Error()}})
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return encode(response{ID: idOrNull(req.ID), Error: &rpcError{Code: CodeInvalidRequest, Message: "Invalid JSON-RPC 2.0 request"}})
+	}
+
+	result, rpcErr := s.dispatch(ctx, req)
+	if req.ID == nil {
+		// Notifications never get a response
+		return nil
+	}
+	return encode(response{ID: req.ID, Result: result, Error: rpcErr})
+}
+
+// dispatch routes a request to its method
+func (s *Server) dispatch(ctx context.Context, req request) (interface{}, *rpcError) {
+	switch req.Method {
+	case "initialize":
+		var params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		json.Unmarshal(req.Params, &params)
+		return map[string]interface{}{
+			"protocolVersion": negotiateVersion(params.ProtocolVersion),
+			"capabilities":    map[string]interface{}{"tools": map[string]bool{"listChanged": false}},
+			"serverInfo":      map[string]string{"name": s.name, "version": s.version},
+		}, nil
+	case "ping":
+		return struct{}{}, nil
+	case "tools/list":
+		return map[string]interface{}{"tools": s.tools}, nil
+	case "tools/call":
+		return s.callTool(ctx, req.Params)
+	default:
+		if strings.HasPrefix(req.Method, "notifications/") {
+			return nil, nil
+		}
+		return nil, &rpcError{Code: CodeMethodNotFound, Message: "Method not found: " + req.Method}
+	}
+}
+
+// callTool runs a tool, reporting tool failures as an isError result whose
+// structured content is the sandbox's error body
+func (s *Server) callTool(ctx context.Context, raw json.RawMessage) (interface{}, *rpcError) {
+	var params struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, &rpcError{Code: CodeInvalidParams, Message: "Invalid params: " + err.Error()}
+	}
+	handler, ok := s.handlers[params.Name]
+	if !ok {
+		return nil, &rpcError{Code: CodeInvalidParams, Message: "Unknown tool: " + params.Name}
+	}
+	if len(params.Arguments) == 0 {
+		params.Arguments = json.RawMessage("{}")
+	}
+
+	value, err := handler(ctx, params.Arguments)
+	isError := false
+	if err != nil {
+		var toolErr *ToolError
+		if !errors.As(err, &toolErr) {
+			return nil, &rpcError{Code: CodeInternalError, Message: err.Error()}
+		}
+		value, isError = toolErr, true
+	}
+
+	text, err := json.Marshal(value)
+	if err != nil {
+		return nil, &rpcError{Code: CodeInternalError, Message: fmt.Sprintf("encoding result: %v", err)}
+	}
+	return callResult{
+		Content:           []content{{Type: "text", Text: string(text)}},
+		StructuredContent: value,
+		IsError:           isError,
+	}, nil
+}
+
+// negotiateVersion echoes a supported requested version, otherwise the latest
+func negotiateVersion(requested string) string {
+	for _, version := range ProtocolVersions {
+		if version == requested {
+			return version
+		}
+	}
+	return ProtocolVersions[0]
+}
+
+// idOrNull returns id, or a JSON null when the request had none
+func idOrNull(id json.RawMessage) json.RawMessage {
+	if id == nil {
+		return json.RawMessage("null")
+	}
+	return id
+}
+
+// encode marshals a response, filling in the protocol version
+func encode(resp response) []byte {
+	resp.JSONRPC = "2.0"
+	data, _ := json.Marshal(resp)
+	return data
+}
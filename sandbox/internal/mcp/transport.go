@@ -0,0 +1,107 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// maxMessageSize bounds a single newline-delimited stdio message
+const maxMessageSize = 4 << 20
+
+// ServeStdio serves newline-delimited JSON-RPC messages from r, writing
+// responses to w, until r is exhausted or ctx is cancelled
+func (s *Server) ServeStdio(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		if out := s.Handle(ctx, line); out != nil {
+			if _, err := w.Write(append(out, '\n')); err != nil {
+				return err
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// Sessions tracks the open SSE streams of the HTTP+SSE transport. Each
+// stream gets a session ID; messages POSTed for that session are handled
+// and their responses pushed down the stream.
+type Sessions struct {
+	streams map[string]*session
+	mu      sync.Mutex
+}
+
+// session is one open SSE stream
+type session struct {
+	owner string
+	out   chan []byte
+	done  chan struct{}
+}
+
+// NewSessions creates an empty session table
+func NewSessions() *Sessions {
+	return &Sessions{streams: make(map[string]*session)}
+}
+
+// Open starts a session for owner, whoever the transport authenticated
+// the stream as, and returns its ID and outgoing message channel
+func (s *Sessions) Open(owner string) (string, <-chan []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := uuid.New().String()
+	stream := &session{owner: owner, out: make(chan []byte, 16), done: make(chan struct{})}
+	s.streams[id] = stream
+	return id, stream.out
+}
+
+// Close ends a session
+func (s *Sessions) Close(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if stream, ok := s.streams[id]; ok {
+		close(stream.done)
+		delete(s.streams, id)
+	}
+}
+
+// Owner returns the owner a session was opened for, reporting whether it
+// is open
+func (s *Sessions) Owner(id string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stream, ok := s.streams[id]
+	if !ok {
+		return "", false
+	}
+	return stream.owner, true
+}
+
+// Send queues a message on a session's stream, reporting whether the
+// session was open to receive it
+func (s *Sessions) Send(id string, message []byte) bool {
+	s.mu.Lock()
+	stream, ok := s.streams[id]
+	s.mu.Unlock()
+	if !ok {
+		return false
+	}
+	select {
+	case stream.out <- message:
+		return true
+	case <-stream.done:
+		return false
+	}
+}
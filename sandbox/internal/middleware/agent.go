@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// AgentStatsMiddleware counts every request carrying an X-Agent-ID header
+// towards that agent's stats, including those turned away by the rate
+// limiter. Requests without one are not counted.
+func AgentStatsMiddleware(agents *store.AgentStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		agentID := c.GetHeader(AgentIDHeader)
+		if agentID == "" {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		if c.GetBool(respond.ClientDisconnectedKey) {
+			status = respond.StatusClientClosedRequest
+		}
+		submitted := c.GetString(SubmittedApplicationKey) != ""
+		agents.Record(agentID, c.Request.Method, c.Request.URL.Path, status, submitted, start)
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHeader carries an agent's API key
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyIDKey is the context key set to the ID of the API key a request
+// authenticated with
+const APIKeyIDKey = "api_key_id"
+
+// APIKeyMiddleware authenticates requests sending an API key in X-API-Key
+// and counts them towards the key's usage. Requests without a key are
+// served anonymously; an unknown or revoked key is refused with a 401.
+func APIKeyMiddleware(keys *store.APIKeyStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		secret := c.GetHeader(APIKeyHeader)
+		if secret == "" {
+			c.Next()
+			return
+		}
+		id, ok := keys.Authenticate(secret)
+		if !ok {
+			respond.Error(c, http.StatusUnauthorized, "invalid_api_key", "The API key is unknown or has been revoked.")
+			return
+		}
+		c.Set(APIKeyIDKey, id)
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "(unmatched)"
+		}
+		keys.Record(id, c.Request.Method+" "+route, c.Writer.Status())
+	}
+}
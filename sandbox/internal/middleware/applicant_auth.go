@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/emailaddr"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/jwt"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// ApplicantAccess is how ApplicantAuthMiddleware guards a route
+type ApplicantAccess int
+
+const (
+	// ApplicantOptional checks a token when one is sent and lets requests
+	// without one through anonymously, seeing no applications. It suits
+	// routes serving jobs as well as applications, such as /graphql.
+	ApplicantOptional ApplicantAccess = iota + 1
+	// ApplicantRequired needs a token
+	ApplicantRequired
+	// ApplicationOwner needs the token of the applicant who submitted the
+	// application in :id
+	ApplicationOwner
+)
+
+// ErrMissingToken is returned by AuthenticateApplicant for a request that
+// sends no token
+var ErrMissingToken = errors.New("no applicant token")
+
+// applicantKey is the request context key holding the applicant a request
+// authenticated as
+type applicantKey struct{}
+
+// WithApplicant returns ctx restricted to the applications of the
+// applicant with the normalized address email; "" restricts it to none
+func WithApplicant(ctx context.Context, email string) context.Context {
+	return context.WithValue(ctx, applicantKey{}, email)
+}
+
+// Applicant returns the address of the applicant a request authenticated
+// as, and whether the request is restricted to their applications. Only
+// requests to guarded routes under RequireAuth are; the address is "" for
+// those that sent no token.
+func Applicant(ctx context.Context) (string, bool) {
+	email, restricted := ctx.Value(applicantKey{}).(string)
+	return email, restricted
+}
+
+// AuthenticateApplicant checks the applicant token in an Authorization
+// header value and returns ctx restricted to the applicant it was issued
+// for. A missing token fails with ErrMissingToken and an expired one with
+// jwt.ErrExpired; both still return ctx restricted to no applicant, for
+// transports that serve anonymous requests.
+func AuthenticateApplicant(ctx context.Context, authorization string, secret []byte) (context.Context, error) {
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok || token == "" {
+		return WithApplicant(ctx, ""), ErrMissingToken
+	}
+	claims, err := jwt.Verify(token, secret, time.Now())
+	if err != nil {
+		return WithApplicant(ctx, ""), err
+	}
+	return WithApplicant(ctx, emailaddr.Normalize(claims.Subject)), nil
+}
+
+// ApplicantAuthMiddleware guards the routes in routes as their
+// ApplicantAccess says, admitting tokens from POST /api/auth/login sent as
+// "Authorization: Bearer <token>". Requests to a guarded route are
+// restricted to the applications of the applicant they authenticated as,
+// which handlers read with Applicant; routes left out are not restricted.
+func ApplicantAuthMiddleware(secret []byte, appStore *store.ApplicationStore, routes map[string]ApplicantAccess) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		access, guarded := routes[c.FullPath()]
+		// Preflight requests carry no credentials
+		if c.Request.Method == http.MethodOptions || !guarded {
+			c.Next()
+			return
+		}
+		ctx, err := AuthenticateApplicant(c.Request.Context(), c.GetHeader("Authorization"), secret)
+		switch {
+		case errors.Is(err, ErrMissingToken) && access == ApplicantOptional:
+		case errors.Is(err, ErrMissingToken):
+			c.Header("WWW-Authenticate", `Bearer realm="applicants"`)
+			respond.Error(c, http.StatusUnauthorized, "authentication_required", "Log in with POST /api/auth/login and send the token as Authorization: Bearer <token>.")
+			return
+		case errors.Is(err, jwt.ErrExpired):
+			c.Header("WWW-Authenticate", `Bearer realm="applicants", error="invalid_token"`)
+			respond.Error(c, http.StatusUnauthorized, "token_expired", "The token has expired. Log in again for a new one.")
+			return
+		case err != nil:
+			c.Header("WWW-Authenticate", `Bearer realm="applicants", error="invalid_token"`)
+			respond.Error(c, http.StatusUnauthorized, "invalid_token", "The token is malformed or was not issued by this sandbox.")
+			return
+		}
+		c.Request = c.Request.WithContext(ctx)
+
+		if access == ApplicationOwner {
+			email, _ := Applicant(ctx)
+			if app, exists := appStore.GetByID(c.Param("id")); exists && emailaddr.Normalize(app.ApplicantEmail) != email {
+				respond.Error(c, http.StatusForbidden, "forbidden", "This application belongs to another applicant.")
+				return
+			}
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ChallengeTokenHeader carries the token of a solved anti-bot challenge
+const ChallengeTokenHeader = "X-Challenge-Token"
+
+// ChallengeOutcomeKey is the context key handlers set to what a request
+// did with an anti-bot challenge, which runs count towards solve rates
+const ChallengeOutcomeKey = "challenge_outcome"
+
+// ChallengeAttempt is the challenge token a request sent and what became
+// of it
+type ChallengeAttempt struct {
+	Token string
+	// Outcome is set to a models.Challenge* outcome once a submission
+	// checks the token
+	Outcome string
+}
+
+// challengeKey is the request context key holding a request's
+// ChallengeAttempt
+type challengeKey struct{}
+
+// WithChallengeToken returns ctx carrying the challenge token a request
+// sent, and the attempt submissions record their outcome on
+func WithChallengeToken(ctx context.Context, token string) (context.Context, *ChallengeAttempt) {
+	attempt := &ChallengeAttempt{Token: token}
+	return context.WithValue(ctx, challengeKey{}, attempt), attempt
+}
+
+// Challenge returns the challenge attempt of a request, or nil for one
+// that did not pass through ChallengeMiddleware
+func Challenge(ctx context.Context) *ChallengeAttempt {
+	attempt, _ := ctx.Value(challengeKey{}).(*ChallengeAttempt)
+	return attempt
+}
+
+// ChallengeMiddleware carries the X-Challenge-Token header of every
+// request in its context, where each transport's submissions redeem it,
+// and sets ChallengeOutcomeKey to what they made of it
+func ChallengeMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, attempt := WithChallengeToken(c.Request.Context(), c.GetHeader(ChallengeTokenHeader))
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+		if attempt.Outcome != "" {
+			c.Set(ChallengeOutcomeKey, attempt.Outcome)
+		}
+	}
+}
@@ -1,8 +1,14 @@
 package middleware
 
 import (
+	"crypto/subtle"
+	"log/slog"
+	"net/http"
+	"strings"
 	"time"
 
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/i18n"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
 	"github.com/gin-gonic/gin"
 )
 
@@ -11,43 +17,77 @@ func CORSMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS, PATCH")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization, X-Requested-With")
-		c.Header("Access-Control-Expose-Headers", "Content-Length, X-RateLimit-Remaining, Retry-After")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization, X-Requested-With, Accept-Language, If-Modified-Since, If-None-Match, X-Run-ID, X-API-Key, X-Agent-ID, X-Simulate, X-Challenge-Token")
+		c.Header("Access-Control-Expose-Headers", "Content-Length, X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Reset, RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, RateLimit-Policy, Retry-After, X-Total-Count, X-Limit-Clamped, Link, Last-Modified, ETag, Content-Language, X-Run-ID")
 		c.Header("Access-Control-Max-Age", "86400")
 
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
-			return
-		}
+		// OPTIONS requests are answered by the per-route handlers the router
+		// registers, so unknown paths still fall through to 404
+		c.Next()
+	}
+}
 
+// LanguageMiddleware negotiates the response language from ?lang= and
+// Accept-Language and echoes it in Content-Language
+func LanguageMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang := i18n.Negotiate(c.Query("lang"), c.GetHeader("Accept-Language"))
+		c.Set(respond.LanguageKey, lang)
+		c.Header("Content-Language", lang)
 		c.Next()
 	}
 }
 
-// LoggerMiddleware logs request information
-func LoggerMiddleware() gin.HandlerFunc {
+// RequestIDKey is the context key holding the request's X-Request-ID
+const RequestIDKey = "request_id"
+
+// LoggerMiddleware logs each request once it is answered: server errors at
+// error level, client errors at warn and the rest at info
+func LoggerMiddleware(logger *slog.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		startTime := time.Now()
 
 		// Process request
 		c.Next()
 
-		// Log after request is processed
-		latency := time.Since(startTime)
-		statusCode := c.Writer.Status()
-		clientIP := c.ClientIP()
-		method := c.Request.Method
-		path := c.Request.URL.Path
-
-		// Use Gin's default logger format
-		gin.DefaultWriter.Write([]byte(
-			time.Now().Format("2006/01/02 - 15:04:05") +
-				" | " + itoa(statusCode) +
-				" | " + latency.String() +
-				" | " + clientIP +
-				" | " + method +
-				" | " + path + "\n",
-		))
+		// Log after request is processed. A request the client abandoned
+		// never got its status, so it is logged as nginx's 499.
+		status := c.Writer.Status()
+		if c.GetBool(respond.ClientDisconnectedKey) {
+			status = respond.StatusClientClosedRequest
+		}
+		attrs := []slog.Attr{
+			slog.Int("status", status),
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.Duration("latency", time.Since(startTime)),
+			slog.String("client_ip", c.ClientIP()),
+			slog.String("request_id", c.GetString(RequestIDKey)),
+		}
+		if runID := c.GetHeader(RunIDHeader); runID != "" {
+			attrs = append(attrs, slog.String("run_id", runID))
+		}
+		if keyID := c.GetString(APIKeyIDKey); keyID != "" {
+			attrs = append(attrs, slog.String("api_key_id", keyID))
+		}
+		if code := c.GetString(respond.ErrorCodeKey); code != "" {
+			attrs = append(attrs, slog.String("error", code))
+		}
+		if simulated := c.GetStringSlice(SimulatedFailureKey); len(simulated) > 0 {
+			attrs = append(attrs, slog.Any("simulated", simulated))
+		}
+		if c.GetBool(respond.ClientDisconnectedKey) {
+			attrs = append(attrs, slog.Bool("client_disconnected", true))
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+		logger.LogAttrs(c.Request.Context(), level, "request", attrs...)
 	}
 }
 
@@ -56,11 +96,7 @@ func ErrorHandlerMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		defer func() {
 			if err := recover(); err != nil {
-				c.AbortWithStatusJSON(500, gin.H{
-					"error":   "internal_server_error",
-					"message": "An unexpected error occurred. Please try again later.",
-					"code":    500,
-				})
+				respond.Error(c, http.StatusInternalServerError, "internal_server_error", "An unexpected error occurred. Please try again later.")
 			}
 		}()
 
@@ -68,6 +104,47 @@ func ErrorHandlerMiddleware() gin.HandlerFunc {
 	}
 }
 
+// ProblemJSONMiddleware makes every error response an RFC 7807 problem document,
+// regardless of the client's Accept header
+func ProblemJSONMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(respond.ProblemJSONKey, true)
+		c.Next()
+	}
+}
+
+// ATSProfileMiddleware writes every error response in the error body of the
+// ATS named by profile
+func ATSProfileMiddleware(profile string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(respond.ATSProfileKey, profile)
+		c.Next()
+	}
+}
+
+// StrictBindingMiddleware rejects unknown fields in the request bodies that
+// support strict binding
+func StrictBindingMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(respond.StrictBindingKey, true)
+		c.Next()
+	}
+}
+
+// AdminAuthMiddleware admits only requests carrying the admin token as
+// "Authorization: Bearer <token>"
+func AdminAuthMiddleware(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		given, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			c.Header("WWW-Authenticate", `Bearer realm="admin"`)
+			respond.Error(c, http.StatusUnauthorized, "unauthorized", "A valid admin token is required.")
+			return
+		}
+		c.Next()
+	}
+}
+
 // RequestIDMiddleware adds a unique request ID to each request
 func RequestIDMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -77,7 +154,7 @@ func RequestIDMiddleware() gin.HandlerFunc {
 		}
 
 		c.Header("X-Request-ID", requestID)
-		c.Set("request_id", requestID)
+		c.Set(RequestIDKey, requestID)
 
 		c.Next()
 	}
@@ -98,28 +175,3 @@ func randomString(n int) string {
 	}
 	return string(b)
 }
-
-// itoa converts int to string (simple implementation)
-func itoa(i int) string {
-	if i == 0 {
-		return "0"
-	}
-
-	var result []byte
-	negative := false
-	if i < 0 {
-		negative = true
-		i = -i
-	}
-
-	for i > 0 {
-		result = append([]byte{byte('0' + i%10)}, result...)
-		i /= 10
-	}
-
-	if negative {
-		result = append([]byte{'-'}, result...)
-	}
-
-	return string(result)
-}
@@ -0,0 +1,229 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/gin-gonic/gin"
+	"github.com/goccy/go-yaml"
+)
+
+// FailureScenario scripts failures for particular requests, where the
+// failure simulator's rates fail requests at random. Rules are checked in
+// order and the first that fires decides what happens to a request:
+//
+//	rules:
+//	  - name: flaky-submit
+//	    route: POST /api/applications
+//	    per_client: true
+//	    first: 3
+//	    status: 503
+//	  - name: outage
+//	    from: 10m
+//	    until: 12m
+//	    status: 503
+//	  - name: every-fifth-times-out
+//	    every: 5
+//	    timeout: true
+//	  - name: proxy-error-page
+//	    route: GET /api/jobs/:id
+//	    every: 10
+//	    malformed: html-error
+type FailureScenario struct {
+	Rules []FailureRule `json:"rules"`
+
+	started time.Time
+	counts  map[string]int // Rule index and client -> requests counted
+	mu      sync.Mutex
+}
+
+// FailureRule is one scripted failure. A rule applies to the requests its
+// route, from and until select, and counts them. It fires on the counted
+// requests its first, after and every select, or on all of them when none
+// is set.
+type FailureRule struct {
+	Name string `json:"name"`
+	// Route is a method and route pattern, such as "POST /api/applications";
+	// empty applies the rule to every route
+	Route string `json:"route"`
+	// PerClient counts each client's requests separately, clients being told
+	// apart as the rate limiter tells them apart
+	PerClient bool `json:"per_client"`
+
+	// From and Until bound when the rule applies, as time since the
+	// scenario started (at startup or on POST /admin/reset)
+	From  time.Duration `json:"from"`
+	Until time.Duration `json:"until"`
+
+	// First fires on the first requests counted, After on every one after
+	// that many, and Every on every Nth
+	First int `json:"first"`
+	After int `json:"after"`
+	Every int `json:"every"`
+
+	// Delay holds the request up before it fails or is served
+	Delay time.Duration `json:"delay"`
+	// Status fails the request with this status
+	Status int `json:"status"`
+	// Timeout hangs the request, then fails it with 504
+	Timeout bool `json:"timeout"`
+	// Malformed garbles the response in one of the MalformedModes
+	Malformed string `json:"malformed"`
+}
+
+// LoadFailureScenario reads a failure scenario from a YAML or JSON file
+func LoadFailureScenario(path string) (*FailureScenario, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var scenario FailureScenario
+	if err := yaml.UnmarshalWithOptions(src, &scenario, yaml.DisallowUnknownField()); err != nil {
+		return nil, fmt.Errorf("%s: %s", path, yaml.FormatError(err, false, true))
+	}
+	if len(scenario.Rules) == 0 {
+		return nil, fmt.Errorf("%s: no rules", path)
+	}
+
+	var errs []error
+	for i, rule := range scenario.Rules {
+		name := fmt.Sprintf("rule #%d", i+1)
+		if rule.Name != "" {
+			name = "rule " + rule.Name
+		}
+		report := func(format string, args ...any) {
+			errs = append(errs, fmt.Errorf("%s: %s: %s", path, name, fmt.Sprintf(format, args...)))
+		}
+
+		if rule.Route != "" && !IsRouteName(rule.Route) {
+			report("route %q is not METHOD /path", rule.Route)
+		}
+		if rule.From < 0 || rule.Until < 0 {
+			report("from and until must not be negative")
+		}
+		if rule.Until > 0 && rule.Until <= rule.From {
+			report("until must be after from")
+		}
+		if rule.First < 0 || rule.After < 0 || rule.Every < 0 {
+			report("first, after and every must not be negative")
+		}
+		if rule.Delay < 0 {
+			report("delay must not be negative")
+		}
+		if rule.Status != 0 && (rule.Status < 400 || rule.Status > 599) {
+			report("status %d is not an error status (400 to 599)", rule.Status)
+		}
+		if rule.Status != 0 && rule.Timeout {
+			report("status and timeout cannot be used together")
+		}
+		if rule.Malformed != "" && !IsMalformedMode(rule.Malformed) {
+			report("malformed %q is not one of %s", rule.Malformed, strings.Join(MalformedModes, ", "))
+		}
+		if rule.Malformed != "" && rule.Timeout {
+			report("malformed and timeout cannot be used together")
+		}
+		if rule.Status == 0 && !rule.Timeout && rule.Delay == 0 && rule.Malformed == "" {
+			report("needs status, timeout, delay or malformed")
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	scenario.Restart()
+	return &scenario, nil
+}
+
+// Names lists the scenario's rules, by name where they have one
+func (s *FailureScenario) Names() []string {
+	names := make([]string, len(s.Rules))
+	for i, rule := range s.Rules {
+		names[i] = rule.Name
+		if names[i] == "" {
+			names[i] = fmt.Sprintf("#%d", i+1)
+		}
+	}
+	return names
+}
+
+// Restart starts the scenario's clock and counts again
+func (s *FailureScenario) Restart() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.started = time.Now()
+	s.counts = make(map[string]int)
+}
+
+// match counts a request against every rule that applies to it and returns
+// the first rule that fires, if any
+func (s *FailureScenario) match(route, client string) (FailureRule, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elapsed := time.Since(s.started)
+	var fired *FailureRule
+	for i := range s.Rules {
+		rule := &s.Rules[i]
+		if rule.Route != "" && rule.Route != route {
+			continue
+		}
+		if elapsed < rule.From || rule.Until > 0 && elapsed >= rule.Until {
+			continue
+		}
+
+		key := fmt.Sprint(i)
+		if rule.PerClient {
+			key += " " + client
+		}
+		s.counts[key]++
+		n := s.counts[key]
+
+		fires := (rule.First == 0 || n <= rule.First) &&
+			(rule.After == 0 || n > rule.After) &&
+			(rule.Every == 0 || n%rule.Every == 0)
+		if fires && fired == nil {
+			fired = rule
+		}
+	}
+	if fired == nil {
+		return FailureRule{}, false
+	}
+	return *fired, true
+}
+
+// apply does what a fired rule says to the request, reporting whether it
+// should still be served
+func (rule FailureRule) apply(c *gin.Context) bool {
+	if rule.Name != "" {
+		annotate(c, "scenario:"+rule.Name)
+	} else {
+		annotate(c, "scenario")
+	}
+	if rule.Delay > 0 {
+		annotate(c, "slowdown")
+		if !respond.Sleep(c, rule.Delay) {
+			return false
+		}
+	}
+	if rule.Malformed != "" && !malform(c, rule.Malformed, rule.Status) {
+		return false
+	}
+	switch {
+	case rule.Timeout:
+		annotate(c, "timeout")
+		if respond.Sleep(c, timeoutDuration) {
+			respond.Error(c, http.StatusGatewayTimeout, "timeout", "Request timed out. Please try again.")
+		}
+		return false
+	case rule.Status != 0:
+		annotate(c, "failure")
+		respond.Error(c, rule.Status, "simulated_failure", "Simulated failure for testing. Please retry.")
+		return false
+	}
+	return true
+}
@@ -1,23 +1,86 @@
 package middleware
 
 import (
+	"maps"
 	"math/rand"
 	"net/http"
+	"sync"
 	"time"
 
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/random"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
 	"github.com/gin-gonic/gin"
 )
 
-// FailureSimulator simulates various failure scenarios for testing
+// FailureSimulator simulates various failure scenarios for testing. Its
+// settings may be changed while requests are being served.
 type FailureSimulator struct {
 	enabled          bool
 	failureRate      float64 // 0.0 to 1.0
 	slowdownRate     float64 // 0.0 to 1.0
 	slowdownDuration time.Duration
 	timeoutRate      float64 // 0.0 to 1.0
+	malformedRate    float64 // 0.0 to 1.0
+	routes           map[string]FailureRates
+	latency          map[string]LatencyDistribution
+	scenario         *FailureScenario
 	rng              *rand.Rand
+	latencyRNG       *rand.Rand
+	mu               sync.Mutex
 }
 
+// FailureConfig is a snapshot of a FailureSimulator's settings
+type FailureConfig struct {
+	Enabled          bool
+	FailureRate      float64
+	SlowdownRate     float64
+	TimeoutRate      float64
+	MalformedRate    float64
+	SlowdownDuration time.Duration
+	// Routes simulate failures on routes with rates of their own, keyed by
+	// method and route pattern like RouteName. The rates above apply to
+	// DefaultFailureRoute unless it is listed here.
+	Routes map[string]FailureRates
+	// Latency delays every request to a route by a time drawn from its
+	// distribution, whether or not failures are simulated. It is keyed like
+	// Routes, with LatencyAllRoutes covering routes not listed.
+	Latency map[string]LatencyDistribution
+}
+
+// FailureRates are the chances (0.0 to 1.0) of each simulated failure on a
+// route
+type FailureRates struct {
+	FailureRate  float64
+	SlowdownRate float64
+	TimeoutRate  float64
+	// MalformedRate is the chance of a response garbled in one of the
+	// MalformedModes, picked at random
+	MalformedRate float64
+}
+
+// DefaultFailureRoute is the route the simulator's own rates apply to
+const DefaultFailureRoute = "POST /api/applications"
+
+// RatesFor returns the rates simulating failures on a route, reporting
+// false when it is not simulated
+func (config FailureConfig) RatesFor(route string) (FailureRates, bool) {
+	if rates, ok := config.Routes[route]; ok {
+		return rates, true
+	}
+	if route == DefaultFailureRoute {
+		return FailureRates{
+			FailureRate:   config.FailureRate,
+			SlowdownRate:  config.SlowdownRate,
+			TimeoutRate:   config.TimeoutRate,
+			MalformedRate: config.MalformedRate,
+		}, true
+	}
+	return FailureRates{}, false
+}
+
+// timeoutDuration is how long a simulated timeout hangs before answering
+const timeoutDuration = 30 * time.Second
+
 // NewFailureSimulator creates a new failure simulator
 func NewFailureSimulator(failureRate, slowdownRate, timeoutRate float64) *FailureSimulator {
 	return &FailureSimulator{
@@ -26,65 +89,233 @@ func NewFailureSimulator(failureRate, slowdownRate, timeoutRate float64) *Failur
 		slowdownRate:     slowdownRate,
 		slowdownDuration: 5 * time.Second,
 		timeoutRate:      timeoutRate,
-		rng:              rand.New(rand.NewSource(time.Now().UnixNano())),
+		rng:              random.New("failures"),
+		latencyRNG:       random.New("latency"),
 	}
 }
 
 // Disable disables the failure simulator
 func (fs *FailureSimulator) Disable() {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
 	fs.enabled = false
 }
 
 // Enable enables the failure simulator
 func (fs *FailureSimulator) Enable() {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
 	fs.enabled = true
 }
 
 // SetFailureRate sets the failure rate (0.0 to 1.0)
 func (fs *FailureSimulator) SetFailureRate(rate float64) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
 	fs.failureRate = rate
 }
 
-// FailureMiddleware creates a middleware that randomly simulates failures
-func FailureMiddleware(simulator *FailureSimulator) gin.HandlerFunc {
+// SetMalformedRate sets the rate of malformed responses (0.0 to 1.0)
+func (fs *FailureSimulator) SetMalformedRate(rate float64) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.malformedRate = rate
+}
+
+// SetRoutes simulates failures on routes with rates of their own,
+// replacing any set before
+func (fs *FailureSimulator) SetRoutes(routes map[string]FailureRates) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.routes = maps.Clone(routes)
+}
+
+// SetLatency delays requests by times drawn from distributions, keyed like
+// FailureConfig.Latency, replacing any set before
+func (fs *FailureSimulator) SetLatency(latency map[string]LatencyDistribution) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.latency = maps.Clone(latency)
+}
+
+// SetScenario scripts failures with a scenario, checked before the random
+// rates and whether or not they are enabled; nil removes it
+func (fs *FailureSimulator) SetScenario(scenario *FailureScenario) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.scenario = scenario
+}
+
+// Scenario returns the scenario scripting failures, nil when there is none
+func (fs *FailureSimulator) Scenario() *FailureScenario {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return fs.scenario
+}
+
+// Config returns the current settings
+func (fs *FailureSimulator) Config() FailureConfig {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	config := fs.config()
+	config.Routes = maps.Clone(config.Routes)
+	config.Latency = maps.Clone(config.Latency)
+	return config
+}
+
+// Configure replaces the settings. Requests already being delayed finish
+// with the settings they started with.
+func (fs *FailureSimulator) Configure(config FailureConfig) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.enabled = config.Enabled
+	fs.failureRate = config.FailureRate
+	fs.slowdownRate = config.SlowdownRate
+	fs.timeoutRate = config.TimeoutRate
+	fs.malformedRate = config.MalformedRate
+	fs.slowdownDuration = config.SlowdownDuration
+	fs.routes = maps.Clone(config.Routes)
+	fs.latency = maps.Clone(config.Latency)
+}
+
+// config takes a snapshot of the settings sharing the route maps, which
+// are replaced rather than changed; fs.mu must be held
+func (fs *FailureSimulator) config() FailureConfig {
+	return FailureConfig{
+		Enabled:          fs.enabled,
+		FailureRate:      fs.failureRate,
+		SlowdownRate:     fs.slowdownRate,
+		TimeoutRate:      fs.timeoutRate,
+		MalformedRate:    fs.malformedRate,
+		SlowdownDuration: fs.slowdownDuration,
+		Routes:           fs.routes,
+		Latency:          fs.latency,
+	}
+}
+
+// failureDraw is what the simulator decided for one request, so it sees
+// consistent settings even if they change while it is delayed
+type failureDraw struct {
+	rates            FailureRates
+	slowdownDuration time.Duration
+	roll             float64 // In [0, 1)
+	statusCode       int     // For a simulated failure
+	malformed        string  // The malformed response mode, if any
+}
+
+// draw decides for a request to a route, reporting false without rolling
+// when failures are not simulated there
+func (fs *FailureSimulator) draw(route string) (failureDraw, bool) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	rates, simulated := fs.config().RatesFor(route)
+	if !fs.enabled || !simulated {
+		return failureDraw{}, false
+	}
+	draw := failureDraw{
+		rates:            rates,
+		slowdownDuration: fs.slowdownDuration,
+		roll:             fs.rng.Float64(),
+		statusCode:       randomErrorCode(fs.rng),
+	}
+	// Picking a mode only when one is needed keeps seeded runs without
+	// malformed responses as they were
+	failed := rates.TimeoutRate + rates.SlowdownRate + rates.FailureRate
+	if draw.roll >= failed && draw.roll < failed+rates.MalformedRate {
+		draw.malformed = MalformedModes[fs.rng.Intn(len(MalformedModes))]
+	}
+	return draw, true
+}
+
+// latencyFor draws the delay of a request to a route, 0 when its latency
+// is not simulated
+func (fs *FailureSimulator) latencyFor(route string) time.Duration {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	distribution, ok := fs.latency[route]
+	if !ok {
+		if distribution, ok = fs.latency[LatencyAllRoutes]; !ok {
+			return 0
+		}
+	}
+	return distribution.sample(fs.latencyRNG.NormFloat64())
+}
+
+// SimulatedFailureKey is the context key listing what the failure
+// simulator did to a request: "timeout", "slowdown" and "failure", with
+// "scenario:<rule>" or "header" saying what asked for them,
+// "malformed:<mode>" for garbled responses, "reset" for dropped
+// connections, and "maintenance" and "brownout" for requests turned away
+// by Maintenance
+const SimulatedFailureKey = "simulated_failure"
+
+// annotate records something the failure simulator did to a request
+func annotate(c *gin.Context, simulated string) {
+	c.Set(SimulatedFailureKey, append(c.GetStringSlice(SimulatedFailureKey), simulated))
+}
+
+// FailureMiddleware creates a middleware that simulates latency, then
+// failures: those a scenario scripts, then random ones on the routes the
+// simulator's rates cover. key tells clients apart for per-client scenario
+// rules.
+func FailureMiddleware(simulator *FailureSimulator, key KeyFunc) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		if !simulator.enabled {
-			c.Next()
-			return
+		if c.Request.Method != http.MethodOptions {
+			if delay := simulator.latencyFor(RouteName(c)); delay > 0 && !respond.Sleep(c, delay) {
+				return
+			}
 		}
 
-		// Only apply to application submissions (POST /api/applications)
-		if c.Request.Method == "POST" && c.Request.URL.Path == "/api/applications" {
-			roll := simulator.rng.Float64()
-
-			// Check for timeout simulation
-			if roll < simulator.timeoutRate {
-				time.Sleep(30 * time.Second)
-				c.AbortWithStatusJSON(http.StatusGatewayTimeout, gin.H{
-					"error":   "timeout",
-					"message": "Request timed out. Please try again.",
-					"code":    504,
-				})
+		if scenario := simulator.Scenario(); scenario != nil && c.Request.Method != http.MethodOptions {
+			if rule, fired := scenario.match(RouteName(c), clientKey(c, key)); fired {
+				if rule.apply(c) {
+					c.Next()
+				}
 				return
 			}
+		}
 
-			// Check for slowdown simulation
-			if roll < simulator.timeoutRate+simulator.slowdownRate {
-				time.Sleep(simulator.slowdownDuration)
+		if c.Request.Method == http.MethodOptions {
+			c.Next()
+			return
+		}
+		draw, simulated := simulator.draw(RouteName(c))
+		if !simulated {
+			c.Next()
+			return
+		}
+		rates, roll := draw.rates, draw.roll
+
+		// Delays end early when the client gives up waiting
+		if roll < rates.TimeoutRate {
+			annotate(c, "timeout")
+			if !respond.Sleep(c, timeoutDuration) {
+				return
 			}
+			respond.Error(c, http.StatusGatewayTimeout, "timeout", "Request timed out. Please try again.")
+			return
+		}
 
-			// Check for random failure
-			if roll < simulator.timeoutRate+simulator.slowdownRate+simulator.failureRate {
-				statusCode := randomErrorCode(simulator.rng)
-				c.AbortWithStatusJSON(statusCode, gin.H{
-					"error":   "simulated_failure",
-					"message": "Simulated failure for testing. Please retry.",
-					"code":    statusCode,
-				})
+		// Check for slowdown simulation
+		if roll < rates.TimeoutRate+rates.SlowdownRate {
+			annotate(c, "slowdown")
+			if !respond.Sleep(c, draw.slowdownDuration) {
 				return
 			}
 		}
 
+		// Check for random failure
+		if roll < rates.TimeoutRate+rates.SlowdownRate+rates.FailureRate {
+			annotate(c, "failure")
+			respond.Error(c, draw.statusCode, "simulated_failure", "Simulated failure for testing. Please retry.")
+			return
+		}
+
+		// Check for malformed responses
+		if draw.malformed != "" && !malform(c, draw.malformed, draw.statusCode) {
+			return
+		}
+
 		c.Next()
 	}
 }
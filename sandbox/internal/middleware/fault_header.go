@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/gin-gonic/gin"
+)
+
+// SimulateHeader asks for faults in a single request, such as
+// "X-Simulate: 503" or "X-Simulate: slow=8s,malformed-json". It is only
+// honored when FaultHeaderMiddleware is installed.
+const SimulateHeader = "X-Simulate"
+
+// maxSimulatedDelay bounds slow=, like the slowdown duration of
+// PUT /admin/failures
+const maxSimulatedDelay = 5 * time.Minute
+
+// faults are what an X-Simulate header asks for
+type faults struct {
+	status    int
+	delay     time.Duration
+	timeout   bool
+	malformed string // One of MalformedModes
+	reset     bool
+}
+
+// parseFaults reads a comma-separated X-Simulate header
+func parseFaults(header string) (faults, error) {
+	var f faults
+	for _, item := range strings.Split(header, ",") {
+		item = strings.TrimSpace(strings.ToLower(item))
+		switch {
+		case item == "":
+		case item == "timeout":
+			f.timeout = true
+		case item == "malformed-json":
+			f.malformed = MalformedTruncated
+		case IsMalformedMode(item):
+			f.malformed = item
+		case item == "reset":
+			f.reset = true
+		case strings.HasPrefix(item, "slow="):
+			delay, err := time.ParseDuration(strings.TrimPrefix(item, "slow="))
+			if err != nil || delay <= 0 || delay > maxSimulatedDelay {
+				return faults{}, fmt.Errorf("%q is not a delay between 0s and %s", item, maxSimulatedDelay)
+			}
+			f.delay = delay
+		default:
+			status, err := strconv.Atoi(item)
+			if err != nil || status < 400 || status > 599 {
+				return faults{}, fmt.Errorf("%q is not a fault", item)
+			}
+			f.status = status
+		}
+	}
+	switch {
+	case f.reset && (f.status != 0 || f.timeout || f.malformed != ""):
+		return faults{}, errors.New("reset only combines with slow=")
+	case f.timeout && (f.status != 0 || f.malformed != ""):
+		return faults{}, errors.New("timeout only combines with slow=")
+	}
+	return f, nil
+}
+
+// FaultHeaderMiddleware injects the faults a request asks for in its
+// X-Simulate header, so agent developers can exercise one error path at a
+// time without random failures:
+//
+//	X-Simulate: 503             fail with this status (400 to 599)
+//	X-Simulate: slow=8s         hold the request up, then serve it
+//	X-Simulate: timeout         hang like a simulated timeout, then 504
+//	X-Simulate: truncated       cut the response body off halfway
+//	                            (malformed-json does the same)
+//	X-Simulate: wrong-content-type
+//	                            label the response as plain text
+//	X-Simulate: html-error      answer with a proxy's HTML error page
+//	X-Simulate: padded          send megabytes of whitespace before the body
+//	X-Simulate: reset           drop the connection without answering
+//
+// Faults combine with commas, such as "slow=2s,503" or "503,truncated".
+func FaultHeaderMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader(SimulateHeader)
+		if header == "" || c.Request.Method == http.MethodOptions {
+			c.Next()
+			return
+		}
+		f, err := parseFaults(header)
+		if err != nil {
+			respond.Error(c, http.StatusBadRequest, "invalid_simulate_header",
+				"Invalid X-Simulate header. Valid values: a status from 400 to 599, slow=<duration>, timeout, truncated, wrong-content-type, html-error, padded, reset, joined with commas.")
+			return
+		}
+
+		annotate(c, "header")
+		if f.delay > 0 {
+			annotate(c, "slowdown")
+			if !respond.Sleep(c, f.delay) {
+				return
+			}
+		}
+		if f.malformed != "" && !malform(c, f.malformed, f.status) {
+			return
+		}
+		switch {
+		case f.reset:
+			annotate(c, "reset")
+			c.Abort()
+			if conn, _, err := c.Writer.Hijack(); err == nil {
+				conn.Close()
+			}
+			return
+		case f.timeout:
+			annotate(c, "timeout")
+			if respond.Sleep(c, timeoutDuration) {
+				respond.Error(c, http.StatusGatewayTimeout, "timeout", "Request timed out. Please try again.")
+			}
+			return
+		case f.status != 0:
+			annotate(c, "failure")
+			if f.status == http.StatusTooManyRequests {
+				c.Header("Retry-After", "1")
+			}
+			respond.Error(c, f.status, "simulated_failure", "Simulated failure for testing. Please retry.")
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Latency distributions
+const (
+	NormalLatency    = "normal"
+	LognormalLatency = "lognormal"
+)
+
+// LatencyAllRoutes keys the latency distribution of the routes without one
+// of their own
+const LatencyAllRoutes = "*"
+
+// The 95th and 99th percentiles of the standard normal distribution
+const (
+	z95 = 1.6448536269514722
+	z99 = 2.3263478740408408
+)
+
+// LatencyDistribution delays every request to a route by a random time
+// whose 50th, 95th and 99th percentiles are P50, P95 and P99. Either of P95
+// and P99 may be left zero for the other to set the spread alone.
+type LatencyDistribution struct {
+	// Distribution is NormalLatency or LognormalLatency
+	Distribution string
+	P50          time.Duration
+	P95          time.Duration
+	P99          time.Duration
+}
+
+// Validate reports what is wrong with a distribution, if anything
+func (d LatencyDistribution) Validate() error {
+	switch d.Distribution {
+	case NormalLatency, LognormalLatency:
+	default:
+		return fmt.Errorf("unknown distribution %q (valid: %s, %s)", d.Distribution, NormalLatency, LognormalLatency)
+	}
+	switch {
+	case d.P50 <= 0:
+		return errors.New("p50 must be positive")
+	case d.P95 == 0 && d.P99 == 0:
+		return errors.New("needs p95, p99 or both")
+	case d.P95 != 0 && d.P95 < d.P50:
+		return errors.New("p95 must not be below p50")
+	case d.P99 != 0 && d.P99 < max(d.P50, d.P95):
+		return errors.New("p99 must not be below p50 and p95")
+	case max(d.P95, d.P99) > maxSimulatedDelay:
+		return fmt.Errorf("percentiles must be at most %s", maxSimulatedDelay)
+	}
+	return nil
+}
+
+// sample turns z, drawn from the standard normal distribution, into a
+// delay. Below the 95th percentile and above it the distribution has a
+// spread of its own, so all three percentiles come out as asked.
+func (d LatencyDistribution) sample(z float64) time.Duration {
+	p50, p95, p99 := float64(d.P50), float64(d.P95), float64(d.P99)
+
+	var x float64
+	switch d.Distribution {
+	case NormalLatency:
+		if p95 == 0 {
+			p95 = p50 + (p99-p50)*z95/z99
+		}
+		if p99 == 0 {
+			p99 = p50 + (p95-p50)*z99/z95
+		}
+		if z <= z95 {
+			x = p50 + (p95-p50)*z/z95
+		} else {
+			x = p95 + (p99-p95)*(z-z95)/(z99-z95)
+		}
+	case LognormalLatency:
+		if p95 == 0 {
+			p95 = p50 * math.Pow(p99/p50, z95/z99)
+		}
+		if p99 == 0 {
+			p99 = p50 * math.Pow(p95/p50, z99/z95)
+		}
+		if z <= z95 {
+			x = p50 * math.Pow(p95/p50, z/z95)
+		} else {
+			x = p95 * math.Pow(p99/p95, (z-z95)/(z99-z95))
+		}
+	}
+	return time.Duration(min(max(x, 0), float64(maxSimulatedDelay)))
+}
+
+// String describes a distribution the way -latency sets it
+func (d LatencyDistribution) String() string {
+	s := d.Distribution + " p50=" + d.P50.String()
+	if d.P95 != 0 {
+		s += " p95=" + d.P95.String()
+	}
+	if d.P99 != 0 {
+		s += " p99=" + d.P99.String()
+	}
+	return s
+}
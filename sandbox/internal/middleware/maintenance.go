@@ -0,0 +1,178 @@
+package middleware
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/random"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/gin-gonic/gin"
+)
+
+// brownoutRetryAfter is how long clients turned away by a brownout are
+// asked to wait
+const brownoutRetryAfter = 5 * time.Second
+
+// Maintenance takes the API, or some of its routes, out of service for a
+// while: entirely during a maintenance window, and for a growing share of
+// requests during a brownout. Health checks and the admin endpoints are
+// always served, so either can be ended early.
+type Maintenance struct {
+	window   MaintenanceWindow
+	brownout Brownout
+	rng      *rand.Rand
+	mu       sync.Mutex
+}
+
+// MaintenanceWindow answers every request to its routes with 503 until it
+// ends
+type MaintenanceWindow struct {
+	Start time.Time
+	End   time.Time
+	// Routes are method and route patterns such as "GET /api/jobs"; empty
+	// covers every route
+	Routes []string
+}
+
+// Brownout turns away a share of the requests to its routes that grows
+// from StartRate to EndRate (0.0 to 1.0) between Start and End, after
+// which it is over
+type Brownout struct {
+	Start     time.Time
+	End       time.Time
+	StartRate float64
+	EndRate   float64
+	// Routes are method and route patterns such as "GET /api/jobs"; empty
+	// covers every route
+	Routes []string
+}
+
+// NewMaintenance creates a maintenance switch with nothing scheduled
+func NewMaintenance() *Maintenance {
+	return &Maintenance{rng: random.New("maintenance")}
+}
+
+// StartWindow begins a maintenance window, replacing any in progress
+func (m *Maintenance) StartWindow(duration time.Duration, routes []string) MaintenanceWindow {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	m.window = MaintenanceWindow{Start: now, End: now.Add(duration), Routes: slices.Clone(routes)}
+	return m.window
+}
+
+// EndWindow ends the maintenance window early
+func (m *Maintenance) EndWindow() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.window = MaintenanceWindow{}
+}
+
+// Window returns the maintenance window in progress, reporting false when
+// there is none
+func (m *Maintenance) Window() (MaintenanceWindow, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.window, time.Now().Before(m.window.End)
+}
+
+// StartBrownout begins a brownout lasting duration, replacing any in
+// progress
+func (m *Maintenance) StartBrownout(duration time.Duration, startRate, endRate float64, routes []string) Brownout {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	m.brownout = Brownout{
+		Start:     now,
+		End:       now.Add(duration),
+		StartRate: startRate,
+		EndRate:   endRate,
+		Routes:    slices.Clone(routes),
+	}
+	return m.brownout
+}
+
+// EndBrownout ends the brownout early
+func (m *Maintenance) EndBrownout() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.brownout = Brownout{}
+}
+
+// Brownout returns the brownout in progress, reporting false when there is
+// none
+func (m *Maintenance) Brownout() (Brownout, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.brownout, time.Now().Before(m.brownout.End)
+}
+
+// Rate is the share of requests a brownout turns away at a moment
+func (b Brownout) Rate(at time.Time) float64 {
+	if !at.Before(b.End) || at.Before(b.Start) {
+		return 0
+	}
+	progress := float64(at.Sub(b.Start)) / float64(b.End.Sub(b.Start))
+	return b.StartRate + (b.EndRate-b.StartRate)*progress
+}
+
+// covers reports whether routes, empty for every route, include route
+func covers(routes []string, route string) bool {
+	return len(routes) == 0 || slices.Contains(routes, route)
+}
+
+// check decides whether a request to route is turned away, returning how
+// long the client should wait before trying again
+func (m *Maintenance) check(route string) (maintenance bool, brownout bool, retryAfter time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	if now.Before(m.window.End) && covers(m.window.Routes, route) {
+		return true, false, m.window.End.Sub(now)
+	}
+	if now.Before(m.brownout.End) && covers(m.brownout.Routes, route) &&
+		m.rng.Float64() < m.brownout.Rate(now) {
+		return false, true, brownoutRetryAfter
+	}
+	return false, false, 0
+}
+
+// alwaysServed are the paths served through maintenance: health checks and
+// admin endpoints
+func alwaysServed(path string) bool {
+	switch path {
+	case "/health", "/ready", "/live":
+		return true
+	}
+	return strings.HasPrefix(path, "/admin/") || strings.HasPrefix(path, "/api/admin/")
+}
+
+// MaintenanceMiddleware turns requests away with 503 and Retry-After
+// during maintenance windows and brownouts
+func MaintenanceMiddleware(m *Maintenance) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodOptions || alwaysServed(c.Request.URL.Path) {
+			c.Next()
+			return
+		}
+		maintenance, brownout, retryAfter := m.check(RouteName(c))
+		switch {
+		case maintenance:
+			annotate(c, "maintenance")
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			respond.Error(c, http.StatusServiceUnavailable, "maintenance", "The service is down for maintenance. Please try again later.")
+		case brownout:
+			annotate(c, "brownout")
+			c.Header("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+			respond.Error(c, http.StatusServiceUnavailable, "service_overloaded", "The service is overloaded. Please try again later.")
+		default:
+			c.Next()
+		}
+	}
+}
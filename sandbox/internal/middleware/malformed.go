@@ -0,0 +1,140 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Malformed response modes, each a way real servers and the proxies in
+// front of them garble responses
+const (
+	// MalformedTruncated cuts the response body off halfway
+	MalformedTruncated = "truncated"
+	// MalformedContentType labels the response as plain text
+	MalformedContentType = "wrong-content-type"
+	// MalformedHTML answers with a proxy's HTML error page instead
+	MalformedHTML = "html-error"
+	// MalformedPadded precedes the response body with megabytes of whitespace
+	MalformedPadded = "padded"
+)
+
+// MalformedModes lists every malformed response mode
+var MalformedModes = []string{MalformedTruncated, MalformedContentType, MalformedHTML, MalformedPadded}
+
+// IsMalformedMode reports whether mode is one of MalformedModes
+func IsMalformedMode(mode string) bool {
+	return slices.Contains(MalformedModes, mode)
+}
+
+// paddedBodySize is how much whitespace MalformedPadded adds
+const paddedBodySize = 16 << 20
+
+// htmlErrorPage is the page MalformedHTML answers with, as a reverse proxy
+// would when the service behind it fails
+const htmlErrorPage = `<html>
+<head><title>%[1]d %[2]s</title></head>
+<body>
+<center><h1>%[1]d %[2]s</h1></center>
+<hr><center>nginx</center>
+</body>
+</html>
+`
+
+// malform garbles the response to a request, reporting whether it should
+// still be served. MalformedHTML answers with status, or 502 when it is 0;
+// the other modes garble whatever response follows.
+func malform(c *gin.Context, mode string, status int) bool {
+	annotate(c, "malformed:"+mode)
+	switch mode {
+	case MalformedHTML:
+		if status == 0 {
+			status = http.StatusBadGateway
+		}
+		c.Data(status, "text/html", fmt.Appendf(nil, htmlErrorPage, status, http.StatusText(status)))
+		c.Abort()
+		return false
+	case MalformedTruncated:
+		c.Writer = &truncatingWriter{ResponseWriter: c.Writer}
+	case MalformedContentType:
+		c.Writer = &contentTypeWriter{ResponseWriter: c.Writer}
+	case MalformedPadded:
+		c.Writer = &paddingWriter{ResponseWriter: c.Writer}
+	}
+	return true
+}
+
+// truncatingWriter sends only the first half of the first write of a
+// response body, leaving JSON unterminated
+type truncatingWriter struct {
+	gin.ResponseWriter
+	written bool
+}
+
+func (w *truncatingWriter) Write(data []byte) (int, error) {
+	if w.written {
+		return len(data), nil
+	}
+	w.written = true
+	if _, err := w.ResponseWriter.Write(data[:len(data)/2]); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+func (w *truncatingWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// contentTypeWriter replaces the Content-Type of a response as its headers
+// are sent
+type contentTypeWriter struct {
+	gin.ResponseWriter
+}
+
+func (w *contentTypeWriter) WriteHeader(code int) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *contentTypeWriter) WriteHeaderNow() {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *contentTypeWriter) Write(data []byte) (int, error) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *contentTypeWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// paddingWriter writes paddedBodySize bytes of whitespace before the
+// body, which leaves JSON valid but slow to read
+type paddingWriter struct {
+	gin.ResponseWriter
+	padded bool
+}
+
+func (w *paddingWriter) Write(data []byte) (int, error) {
+	if !w.padded {
+		w.padded = true
+		w.Header().Del("Content-Length")
+		chunk := bytes.Repeat([]byte(" "), 64<<10)
+		for written := 0; written < paddedBodySize; written += len(chunk) {
+			if _, err := w.ResponseWriter.Write(chunk); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *paddingWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
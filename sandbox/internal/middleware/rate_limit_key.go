@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AgentIDHeader lets each agent name itself, so agents sharing a machine
+// can be rate limited separately without API keys
+const AgentIDHeader = "X-Agent-ID"
+
+// KeyFunc names the client a request is rate limited as. It returns ""
+// when the request does not say, and the client IP is used instead.
+type KeyFunc func(c *gin.Context) string
+
+// KeyByIP keys requests by client IP address
+func KeyByIP(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// KeyByAPIKey keys requests by the API key they authenticated with
+func KeyByAPIKey(c *gin.Context) string {
+	if id := c.GetString(APIKeyIDKey); id != "" {
+		return "key:" + id
+	}
+	return ""
+}
+
+// KeyByAgentID keys requests by their X-Agent-ID header
+func KeyByAgentID(c *gin.Context) string {
+	if agent := c.GetHeader(AgentIDHeader); agent != "" {
+		return "agent:" + agent
+	}
+	return ""
+}
+
+// CompositeKey keys requests by every part that names them, so
+// CompositeKey(KeyByIP, KeyByAgentID) gives each agent on each machine its
+// own bucket
+func CompositeKey(parts ...KeyFunc) KeyFunc {
+	return func(c *gin.Context) string {
+		var names []string
+		for _, part := range parts {
+			if name := part(c); name != "" {
+				names = append(names, name)
+			}
+		}
+		return strings.Join(names, "|")
+	}
+}
+
+// keyFuncs are the strategies ParseKeyFunc accepts, by name
+var keyFuncs = map[string]KeyFunc{
+	"ip":      KeyByIP,
+	"api-key": KeyByAPIKey,
+	"agent":   KeyByAgentID,
+}
+
+// ParseKeyFunc reads a keying strategy: ip, api-key or agent, or several
+// joined with + to combine them, such as ip+agent
+func ParseKeyFunc(spec string) (KeyFunc, error) {
+	names := strings.Split(spec, "+")
+	parts := make([]KeyFunc, 0, len(names))
+	for _, name := range names {
+		part, ok := keyFuncs[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown strategy %q (valid: ip, api-key, agent, or several joined with +)", name)
+		}
+		parts = append(parts, part)
+	}
+	if len(parts) == 1 {
+		return parts[0], nil
+	}
+	return CompositeKey(parts...), nil
+}
+
+// clientKey names the client of a request with key, falling back to its IP
+func clientKey(c *gin.Context, key KeyFunc) string {
+	if key != nil {
+		if name := key(c); name != "" {
+			return name
+		}
+	}
+	return KeyByIP(c)
+}
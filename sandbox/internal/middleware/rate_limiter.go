@@ -1,14 +1,49 @@
 package middleware
 
 import (
+	"fmt"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
 	"github.com/gin-gonic/gin"
 )
 
-// RateLimiter implements a simple token bucket rate limiter
+// Limiter decides whether each client may make another request
+type Limiter interface {
+	// Take spends one of the client's requests, reporting whether it had
+	// one left and the quota remaining afterwards
+	Take(key string) (Quota, bool)
+	// Reset gives every client its full quota again
+	Reset()
+}
+
+// Rate limiting algorithms, as accepted by NewLimiter
+const (
+	// FixedWindow refills each client's quota once per window
+	FixedWindow = "fixed"
+	// SlidingWindow counts the requests in the last window at every moment
+	SlidingWindow = "sliding"
+)
+
+// NewLimiter creates a rate limiter allowing rate requests per window with
+// the named algorithm
+func NewLimiter(algorithm string, rate int, window time.Duration) (Limiter, error) {
+	switch algorithm {
+	case FixedWindow, "":
+		return NewRateLimiter(rate, window), nil
+	case SlidingWindow:
+		return NewSlidingWindowLimiter(rate, window), nil
+	}
+	return nil, fmt.Errorf("unknown rate limiting algorithm %q (valid: %s, %s)", algorithm, FixedWindow, SlidingWindow)
+}
+
+// RateLimiter implements a simple fixed window rate limiter: each client
+// gets rate requests, refilled a window after the first of them
 type RateLimiter struct {
 	buckets    map[string]*bucket
 	mu         sync.RWMutex
@@ -37,36 +72,55 @@ func NewRateLimiter(rate int, window time.Duration) *RateLimiter {
 	return rl
 }
 
+// Quota is what is left of a client's bucket after a request
+type Quota struct {
+	Limit     int
+	Remaining int
+	Window    time.Duration
+	Reset     time.Time // When the bucket fills again
+}
+
 // Allow checks if a request is allowed for the given key
 func (rl *RateLimiter) Allow(key string) bool {
+	_, allowed := rl.Take(key)
+	return allowed
+}
+
+// Take draws a token for the given key, reporting whether there was one and
+// the quota left afterwards
+func (rl *RateLimiter) Take(key string) (Quota, bool) {
+	return rl.take(key, time.Now())
+}
+
+// take is Take for a request made at now
+func (rl *RateLimiter) take(key string, now time.Time) (Quota, bool) {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
-	now := time.Now()
-
 	b, exists := rl.buckets[key]
 	if !exists {
-		rl.buckets[key] = &bucket{
-			tokens:    rl.rate - 1,
-			lastReset: now,
-		}
-		return true
+		b = &bucket{tokens: rl.rate, lastReset: now}
+		rl.buckets[key] = b
 	}
 
 	// Check if we need to reset the bucket
 	if now.Sub(b.lastReset) >= rl.window {
-		b.tokens = rl.rate - 1
+		b.tokens = rl.rate
 		b.lastReset = now
-		return true
 	}
 
 	// Check if we have tokens
-	if b.tokens > 0 {
+	allowed := b.tokens > 0
+	if allowed {
 		b.tokens--
-		return true
 	}
 
-	return false
+	return Quota{
+		Limit:     rl.rate,
+		Remaining: b.tokens,
+		Window:    rl.window,
+		Reset:     b.lastReset.Add(rl.window),
+	}, allowed
 }
 
 // GetRemaining returns remaining tokens for a key
@@ -87,6 +141,13 @@ func (rl *RateLimiter) GetRemaining(key string) int {
 	return b.tokens
 }
 
+// Reset gives every client a full bucket again
+func (rl *RateLimiter) Reset() {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.buckets = make(map[string]*bucket)
+}
+
 // cleanup periodically cleans up old buckets
 func (rl *RateLimiter) cleanup() {
 	ticker := time.NewTicker(rl.cleanupInt)
@@ -104,21 +165,72 @@ func (rl *RateLimiter) cleanup() {
 	}
 }
 
-// RateLimitMiddleware creates a Gin middleware for rate limiting
-func RateLimitMiddleware(limiter *RateLimiter) gin.HandlerFunc {
+// setQuotaHeaders describes a quota in both the X-RateLimit-* headers
+// clients have long read, with the reset as a Unix time, and the IETF
+// RateLimit-* fields, with the reset in seconds from now
+func setQuotaHeaders(c *gin.Context, quota Quota) {
+	resetIn := int(math.Ceil(time.Until(quota.Reset).Seconds()))
+	if resetIn < 0 {
+		resetIn = 0
+	}
+
+	limit := strconv.Itoa(quota.Limit)
+	remaining := strconv.Itoa(quota.Remaining)
+	c.Header("X-RateLimit-Limit", limit)
+	c.Header("X-RateLimit-Remaining", remaining)
+	c.Header("X-RateLimit-Reset", strconv.FormatInt(quota.Reset.Unix(), 10))
+	c.Header("RateLimit-Limit", limit)
+	c.Header("RateLimit-Remaining", remaining)
+	c.Header("RateLimit-Reset", strconv.Itoa(resetIn))
+	c.Header("RateLimit-Policy", limit+";w="+strconv.Itoa(int(quota.Window.Seconds())))
+}
+
+// retryAfter is how many whole seconds remain until a quota resets
+func retryAfter(quota Quota) string {
+	seconds := int(math.Ceil(time.Until(quota.Reset).Seconds()))
+	return strconv.Itoa(max(seconds, 1))
+}
+
+// RouteName names the route a request matched the way per-route rate
+// limits are configured, such as "GET /api/applications/:id". HEAD requests
+// are named after the GET route they mirror.
+func RouteName(c *gin.Context) string {
+	method := c.Request.Method
+	if method == http.MethodHead {
+		method = http.MethodGet
+	}
+	return method + " " + c.FullPath()
+}
+
+// IsRouteName reports whether name is a method and route pattern, the way
+// RouteName names routes
+func IsRouteName(name string) bool {
+	method, pattern, ok := strings.Cut(name, " ")
+	return ok && method != "" && method == strings.ToUpper(method) && strings.HasPrefix(pattern, "/")
+}
+
+// RateLimitMiddleware creates a Gin middleware for rate limiting, drawing
+// each request from the bucket its client key names. Requests to a route
+// in routes are limited by its limiter instead of the general one. Every
+// response carries the client's quota.
+func RateLimitMiddleware(limiter Limiter, routes map[string]Limiter, key KeyFunc) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Use client IP as key
-		key := c.ClientIP()
-
-		if !limiter.Allow(key) {
-			remaining := limiter.GetRemaining(key)
-			c.Header("X-RateLimit-Remaining", string(rune(remaining)))
-			c.Header("Retry-After", "60")
-			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
-				"error":   "rate_limit_exceeded",
-				"message": "Too many requests. Please wait before trying again.",
-				"code":    429,
-			})
+		// CORS preflights and method probes don't count against the limit
+		if c.Request.Method == http.MethodOptions {
+			c.Next()
+			return
+		}
+
+		limiter := limiter
+		if route, ok := routes[RouteName(c)]; ok {
+			limiter = route
+		}
+		quota, allowed := limiter.Take(clientKey(c, key))
+		setQuotaHeaders(c, quota)
+
+		if !allowed {
+			c.Header("Retry-After", retryAfter(quota))
+			respond.Error(c, http.StatusTooManyRequests, "rate_limit_exceeded", "Too many requests. Please wait before trying again.")
 			return
 		}
 
@@ -126,19 +238,17 @@ func RateLimitMiddleware(limiter *RateLimiter) gin.HandlerFunc {
 	}
 }
 
-// ApplicationRateLimitMiddleware creates a stricter rate limiter for application submissions
-func ApplicationRateLimitMiddleware(limiter *RateLimiter) gin.HandlerFunc {
+// ApplicationRateLimitMiddleware creates a stricter rate limiter for
+// application submissions. Its quota replaces the general one in the
+// headers of the routes it guards, since it is the one that runs out first.
+func ApplicationRateLimitMiddleware(limiter Limiter, key KeyFunc) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Use client IP + path as key for application submissions
-		key := c.ClientIP() + ":applications"
-
-		if !limiter.Allow(key) {
-			c.Header("Retry-After", "30")
-			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
-				"error":   "rate_limit_exceeded",
-				"message": "Too many application submissions. Please wait before trying again.",
-				"code":    429,
-			})
+		quota, allowed := limiter.Take(clientKey(c, key) + ":applications")
+		setQuotaHeaders(c, quota)
+
+		if !allowed {
+			c.Header("Retry-After", retryAfter(quota))
+			respond.Error(c, http.StatusTooManyRequests, "rate_limit_exceeded", "Too many application submissions. Please wait before trying again.")
 			return
 		}
 
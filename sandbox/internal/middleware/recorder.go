@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// maxRecordedBody is how much of each request and response body the
+// recorder keeps
+const maxRecordedBody = 64 << 10
+
+// redactedHeaders carry secrets, so recordings keep that they were sent
+// but not their values
+var redactedHeaders = []string{"Authorization", APIKeyHeader, "Cookie", "Set-Cookie"}
+
+// RecorderMiddleware captures every request carrying the ID of an active
+// run in X-Run-ID, with its response, headers and bodies included, so what an
+// agent sent can be audited later. It must run after RunMiddleware.
+func RecorderMiddleware(runs *store.RunStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		runID := c.GetHeader(RunIDHeader)
+		if runID == "" || !runs.Active(runID) {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		var requestBody []byte
+		if c.Request.Body != nil {
+			// Read the body for the recording and hand handlers a copy
+			body, err := io.ReadAll(c.Request.Body)
+			c.Request.Body.Close()
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+			if err == nil {
+				requestBody = body
+			}
+		}
+		// Headers are copied before handlers run so later changes don't leak in
+		requestHeaders := recordedHeaders(c.Request.Header)
+
+		writer := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		scheme := "http"
+		if c.Request.TLS != nil {
+			scheme = "https"
+		}
+		runs.AddRecording(runID, models.Recording{
+			StartedAt:       start.UTC(),
+			DurationMs:      float64(time.Since(start).Microseconds()) / 1000,
+			Method:          c.Request.Method,
+			URL:             scheme + "://" + c.Request.Host + c.Request.RequestURI,
+			Proto:           c.Request.Proto,
+			RequestHeaders:  requestHeaders,
+			RequestBody:     recordedBody(requestBody, len(requestBody)),
+			Status:          writer.Status(),
+			ResponseHeaders: recordedHeaders(writer.Header()),
+			ResponseBody:    recordedBody(writer.body.Bytes(), writer.size),
+		})
+	}
+}
+
+// recordingWriter keeps the start of the response body as it is written
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+	size int
+}
+
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	w.keep(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.keep([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *recordingWriter) keep(data []byte) {
+	w.size += len(data)
+	if room := maxRecordedBody - w.body.Len(); room > 0 {
+		w.body.Write(data[:min(room, len(data))])
+	}
+}
+
+// recordedHeaders copies headers, hiding the values of secret ones
+func recordedHeaders(header http.Header) map[string][]string {
+	copied := header.Clone()
+	if copied == nil {
+		copied = http.Header{}
+	}
+	for _, name := range redactedHeaders {
+		if values := copied.Values(name); len(values) > 0 {
+			redacted := make([]string, len(values))
+			for i := range redacted {
+				redacted[i] = "[redacted]"
+			}
+			copied[http.CanonicalHeaderKey(name)] = redacted
+		}
+	}
+	return copied
+}
+
+// recordedBody keeps up to maxRecordedBody bytes of a body of size bytes,
+// base64-encoding it unless it is text
+func recordedBody(data []byte, size int) models.RecordedBody {
+	body := models.RecordedBody{Size: size, Truncated: size > len(data) || len(data) > maxRecordedBody}
+	if len(data) > maxRecordedBody {
+		data = data[:maxRecordedBody]
+	}
+	if body.Truncated {
+		// Don't let a character cut in half make text look binary
+		for n := 1; n < utf8.UTFMax && n < len(data) && !utf8.Valid(data); n++ {
+			if utf8.Valid(data[:len(data)-n]) {
+				data = data[:len(data)-n]
+			}
+		}
+	}
+	if utf8.Valid(data) {
+		body.Text = string(data)
+	} else {
+		body.Text = base64.StdEncoding.EncodeToString(data)
+		body.Encoding = "base64"
+	}
+	return body
+}
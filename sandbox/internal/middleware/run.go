@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// RunIDHeader names the run a request belongs to
+const RunIDHeader = "X-Run-ID"
+
+// SubmittedApplicationKey is the context key handlers set to the
+// confirmation ID of the application a request submitted
+const SubmittedApplicationKey = "submitted_application"
+
+// ApplicationKey is the context key handlers set to the application a
+// request submitted, whose claims runs check against their candidate
+// profile
+const ApplicationKey = "application"
+
+// HoneypotKey is the context key handlers set to the honeypot fields a
+// submission filled in, and ShadowRejectedKey is set when it was answered
+// as submitted but dropped
+const (
+	HoneypotKey       = "honeypot_fields"
+	ShadowRejectedKey = "shadow_rejected"
+)
+
+// TrapJobKey is the context key handlers set to the ID of the trap job a
+// request opened or applied to, and TrapKey to its kind of trap
+const (
+	TrapJobKey = "trap_job"
+	TrapKey    = "trap"
+)
+
+// RunMiddleware records every request carrying the ID of an active run in
+// X-Run-ID against that run, and echoes the header back. Requests naming
+// unknown or completed runs are served without being recorded.
+func RunMiddleware(runs *store.RunStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		runID := c.GetHeader(RunIDHeader)
+		if runID == "" || !runs.Active(runID) {
+			c.Next()
+			return
+		}
+		c.Header(RunIDHeader, runID)
+
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		if c.GetBool(respond.ClientDisconnectedKey) {
+			status = respond.StatusClientClosedRequest
+		}
+		route := c.FullPath()
+		if route == "" {
+			route = "(unmatched)"
+		}
+		fabrications := 0
+		if app, ok := c.Get(ApplicationKey); ok {
+			fabrications = runs.CheckApplication(runID, app.(models.Application))
+		}
+		runs.Record(runID, c.Request.Method+" "+route, models.RunRequestRecord{
+			At:             start.UTC(),
+			Method:         c.Request.Method,
+			Path:           c.Request.URL.Path,
+			Status:         status,
+			LatencyMs:      time.Since(start).Milliseconds(),
+			ErrorCode:      c.GetString(respond.ErrorCodeKey),
+			ApplicationID:  c.GetString(SubmittedApplicationKey),
+			Challenge:      c.GetString(ChallengeOutcomeKey),
+			Honeypot:       c.GetStringSlice(HoneypotKey),
+			ShadowRejected: c.GetBool(ShadowRejectedKey),
+			TrapJob:        c.GetString(TrapJobKey),
+			Trap:           c.GetString(TrapKey),
+			Fabrications:   fabrications,
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"sync"
+	"time"
+)
+
+// SlidingWindowLimiter allows rate requests in any window-long span of
+// time, not just in each fixed window. A fixed window lets a client spend
+// one window's quota just before it ends and the next one's just after,
+// twice the rate in a moment; here a request only becomes available again a
+// full window after the one it replaces.
+//
+// Each client's recent request times are kept, so memory grows with the
+// rate as well as the number of clients.
+type SlidingWindowLimiter struct {
+	clients map[string][]time.Time // Key -> request times in the last window, oldest first
+	mu      sync.Mutex
+	rate    int
+	window  time.Duration
+}
+
+// NewSlidingWindowLimiter creates a new sliding window rate limiter
+func NewSlidingWindowLimiter(rate int, window time.Duration) *SlidingWindowLimiter {
+	rl := &SlidingWindowLimiter{
+		clients: make(map[string][]time.Time),
+		rate:    rate,
+		window:  window,
+	}
+
+	// Start cleanup goroutine
+	go rl.cleanup()
+
+	return rl
+}
+
+// Take records a request for the given key if fewer than rate were made in
+// the last window, reporting whether it was allowed and the quota left
+func (rl *SlidingWindowLimiter) Take(key string) (Quota, bool) {
+	return rl.take(key, time.Now())
+}
+
+// take is Take for a request made at now
+func (rl *SlidingWindowLimiter) take(key string, now time.Time) (Quota, bool) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	times := rl.prune(key, now)
+
+	allowed := len(times) < rl.rate
+	if allowed {
+		times = append(times, now)
+		rl.clients[key] = times
+	}
+
+	// The quota grows again when the oldest request leaves the window
+	reset := now.Add(rl.window)
+	if len(times) > 0 {
+		reset = times[0].Add(rl.window)
+	}
+	return Quota{
+		Limit:     rl.rate,
+		Remaining: rl.rate - len(times),
+		Window:    rl.window,
+		Reset:     reset,
+	}, allowed
+}
+
+// Reset forgets every client's requests
+func (rl *SlidingWindowLimiter) Reset() {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.clients = make(map[string][]time.Time)
+}
+
+// prune drops a client's requests that have left the window
+func (rl *SlidingWindowLimiter) prune(key string, now time.Time) []time.Time {
+	times := rl.clients[key]
+	cutoff := now.Add(-rl.window)
+	i := 0
+	for i < len(times) && !times[i].After(cutoff) {
+		i++
+	}
+	if i == len(times) {
+		delete(rl.clients, key)
+		return nil
+	}
+	times = times[i:]
+	rl.clients[key] = times
+	return times
+}
+
+// cleanup periodically forgets clients with no requests in the window
+func (rl *SlidingWindowLimiter) cleanup() {
+	ticker := time.NewTicker(rl.window * 2)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		rl.mu.Lock()
+		now := time.Now()
+		for key := range rl.clients {
+			rl.prune(key, now)
+		}
+		rl.mu.Unlock()
+	}
+}
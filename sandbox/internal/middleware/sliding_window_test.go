@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"testing"
+	"time"
+)
+
+// limiterAt is a limiter driven by the test's own clock
+type limiterAt interface {
+	take(key string, now time.Time) (Quota, bool)
+}
+
+// busiestWindow is the most of the sorted times found in any window-long
+// span
+func busiestWindow(times []time.Time, window time.Duration) int {
+	busiest, first := 0, 0
+	for last := range times {
+		for times[last].Sub(times[first]) >= window {
+			first++
+		}
+		busiest = max(busiest, last-first+1)
+	}
+	return busiest
+}
+
+// TestBoundaryBurst sends one request, waits until just before the first
+// window ends, and then asks for twice the rate. A fixed window allows
+// almost twice the rate in that moment; a sliding window never allows more
+// than the rate in any window.
+func TestBoundaryBurst(t *testing.T) {
+	const rate, window = 10, time.Second
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		limiter limiterAt
+		busiest int
+	}{
+		{"fixed", NewRateLimiter(rate, window), 2*rate - 1},
+		{"sliding", NewSlidingWindowLimiter(rate, window), rate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var allowed []time.Time
+			try := func(at time.Time) {
+				if _, ok := tt.limiter.take("agent", at); ok {
+					allowed = append(allowed, at)
+				}
+			}
+			try(start)
+			for i := range 2 * rate {
+				try(start.Add(window - 10*time.Millisecond + time.Duration(i)*time.Millisecond))
+			}
+			if got := busiestWindow(allowed, window); got != tt.busiest {
+				t.Errorf("%d requests allowed in one window, want %d", got, tt.busiest)
+			}
+		})
+	}
+}
+
+// TestSlidingWindowNeverExceedsRate has a client retry every few
+// milliseconds for many windows and checks no window-long span holds more
+// than the rate, while the client still gets the full rate overall
+func TestSlidingWindowNeverExceedsRate(t *testing.T) {
+	const rate, window, windows = 10, time.Second, 20
+	limiter := NewSlidingWindowLimiter(rate, window)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var allowed []time.Time
+	for at := start; at.Before(start.Add(windows * window)); at = at.Add(7 * time.Millisecond) {
+		if _, ok := limiter.take("agent", at); ok {
+			allowed = append(allowed, at)
+		}
+	}
+	if got := busiestWindow(allowed, window); got > rate {
+		t.Errorf("%d requests allowed in one window, want at most %d", got, rate)
+	}
+	if len(allowed) < (windows-1)*rate {
+		t.Errorf("%d requests allowed in %d windows, want about %d", len(allowed), windows, windows*rate)
+	}
+}
+
+// TestSlidingWindowAllowsSteadyPacing checks a client that spaces its
+// requests evenly at the rate is never refused, even after spending its
+// whole quota at once
+func TestSlidingWindowAllowsSteadyPacing(t *testing.T) {
+	const rate, window = 10, time.Second
+	limiter := NewSlidingWindowLimiter(rate, window)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := range rate {
+		if _, ok := limiter.take("agent", start.Add(time.Duration(i)*time.Millisecond)); !ok {
+			t.Fatalf("burst request %d refused", i)
+		}
+	}
+	for i := range 5 * rate {
+		at := start.Add(window + time.Duration(i)*window/rate + 10*time.Millisecond)
+		if quota, ok := limiter.take("agent", at); !ok {
+			t.Fatalf("paced request %d at %v refused, quota %+v", i, at.Sub(start), quota)
+		}
+	}
+}
+
+// TestSlidingWindowQuota checks the quota counts down, resets when the
+// oldest request leaves the window, and is kept per client
+func TestSlidingWindowQuota(t *testing.T) {
+	const rate, window = 3, time.Minute
+	limiter := NewSlidingWindowLimiter(rate, window)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := range rate {
+		quota, ok := limiter.take("agent", start.Add(time.Duration(i)*time.Second))
+		if !ok || quota.Remaining != rate-1-i || quota.Limit != rate || quota.Window != window {
+			t.Fatalf("request %d: allowed %v, quota %+v", i, ok, quota)
+		}
+	}
+	quota, ok := limiter.take("agent", start.Add(10*time.Second))
+	if ok || quota.Remaining != 0 || !quota.Reset.Equal(start.Add(window)) {
+		t.Errorf("over the rate: allowed %v, quota %+v, want refused until %v", ok, quota, start.Add(window))
+	}
+	if _, ok := limiter.take("other", start.Add(10*time.Second)); !ok {
+		t.Error("another client was refused")
+	}
+	if quota, ok := limiter.take("agent", quota.Reset); !ok || quota.Remaining != 0 {
+		t.Errorf("at the reset: allowed %v, quota %+v, want the oldest request's place", ok, quota)
+	}
+
+	limiter.Reset()
+	if quota, ok := limiter.take("agent", start.Add(window)); !ok || quota.Remaining != rate-1 {
+		t.Errorf("after Reset: allowed %v, quota %+v, want a full quota", ok, quota)
+	}
+}
+
+func TestNewLimiter(t *testing.T) {
+	for _, algorithm := range []string{"", FixedWindow, SlidingWindow} {
+		if _, err := NewLimiter(algorithm, 10, time.Minute); err != nil {
+			t.Errorf("%q: %v", algorithm, err)
+		}
+	}
+	if _, err := NewLimiter("leaky", 10, time.Minute); err == nil {
+		t.Error(`"leaky": no error`)
+	}
+}
@@ -0,0 +1,137 @@
+package models
+
+import "time"
+
+// FailureSettings is the failure simulation configuration, as read and
+// changed through GET and PUT /admin/failures
+type FailureSettings struct {
+	Enabled      bool    `json:"enabled"`
+	FailureRate  float64 `json:"failure_rate"`
+	SlowdownRate float64 `json:"slowdown_rate"`
+	TimeoutRate  float64 `json:"timeout_rate"`
+	// MalformedRate is the chance of a truncated, mislabeled, HTML or
+	// padded response
+	MalformedRate float64 `json:"malformed_rate"`
+	// SlowdownDuration is a Go duration such as "5s" or "1500ms"
+	SlowdownDuration string `json:"slowdown_duration"`
+	// Routes simulate failures on routes with rates of their own, keyed by
+	// method and route pattern such as "GET /api/jobs/search". The rates
+	// above apply to POST /api/applications unless it is listed.
+	Routes map[string]FailureRates `json:"routes,omitempty"`
+	// Latency delays every request to a route by a time drawn from its
+	// distribution, keyed like Routes or by "*" for all other routes
+	Latency map[string]SimulatedLatency `json:"latency,omitempty"`
+	// Scenario names the rules of the failure scenario loaded with
+	// -failure-scenario, which apply whether or not Enabled is set
+	Scenario []string `json:"scenario,omitempty"`
+}
+
+// FailureSettingsUpdate changes failure simulation settings; omitted
+// fields keep their current values
+type FailureSettingsUpdate struct {
+	Enabled          *bool    `json:"enabled,omitempty"`
+	FailureRate      *float64 `json:"failure_rate,omitempty"`
+	SlowdownRate     *float64 `json:"slowdown_rate,omitempty"`
+	TimeoutRate      *float64 `json:"timeout_rate,omitempty"`
+	MalformedRate    *float64 `json:"malformed_rate,omitempty"`
+	SlowdownDuration *string  `json:"slowdown_duration,omitempty"`
+	// Routes replaces every route's rates when sent; {} removes them all
+	Routes map[string]FailureRates `json:"routes,omitempty"`
+	// Latency replaces every route's latency when sent; {} removes it all
+	Latency map[string]SimulatedLatency `json:"latency,omitempty"`
+}
+
+// SimulatedLatency is the spread of response times simulated on a
+// route, with percentiles given as Go durations such as "250ms"
+type SimulatedLatency struct {
+	// Distribution is "normal" or "lognormal"
+	Distribution string `json:"distribution"`
+	P50          string `json:"p50"`
+	P95          string `json:"p95,omitempty"`
+	P99          string `json:"p99,omitempty"`
+}
+
+// FailureRates are the chances (0.0 to 1.0) of each simulated failure on
+// one route
+type FailureRates struct {
+	FailureRate   float64 `json:"failure_rate"`
+	SlowdownRate  float64 `json:"slowdown_rate"`
+	TimeoutRate   float64 `json:"timeout_rate"`
+	MalformedRate float64 `json:"malformed_rate"`
+}
+
+// ResetRequest is the optional body of POST /admin/reset
+type ResetRequest struct {
+	// Jobs replaces the seed jobs as the catalogue; omit it to restore them
+	Jobs []JobRequest `json:"jobs,omitempty"`
+}
+
+// ResetResponse reports what POST /admin/reset left behind
+type ResetResponse struct {
+	ApplicationsCleared int `json:"applications_cleared"`
+	Jobs                int `json:"jobs"`
+}
+
+// SnapshotVersion is the layout version of snapshots. POST /admin/restore
+// only loads snapshots of this version.
+const SnapshotVersion = 1
+
+// Snapshot is the job and application state of the sandbox, as taken by
+// POST /admin/snapshot and loaded back by POST /admin/restore
+type Snapshot struct {
+	Version      int           `json:"version"`
+	TakenAt      time.Time     `json:"taken_at"`
+	Jobs         []Job         `json:"jobs" description:"The whole catalogue, in catalogue order"`
+	Applications []Application `json:"applications" description:"Every application, in submission order"`
+}
+
+// RestoreResponse reports what POST /admin/restore loaded
+type RestoreResponse struct {
+	TakenAt             time.Time `json:"taken_at"`
+	ApplicationsCleared int       `json:"applications_cleared"`
+	Applications        int       `json:"applications"`
+	Jobs                int       `json:"jobs"`
+}
+
+// MaintenanceRequest starts a maintenance window with PUT /admin/maintenance
+type MaintenanceRequest struct {
+	// Duration is how long the window lasts, as a Go duration such as "10m"
+	Duration string `json:"duration"`
+	// Routes are the method and route patterns taken down, such as
+	// "POST /api/applications"; empty takes down every route
+	Routes []string `json:"routes,omitempty"`
+}
+
+// MaintenanceStatus reports the maintenance window in progress, if any
+type MaintenanceStatus struct {
+	Active    bool       `json:"active"`
+	StartedAt *time.Time `json:"started_at,omitempty"`
+	EndsAt    *time.Time `json:"ends_at,omitempty"`
+	// Routes is empty when every route is down
+	Routes []string `json:"routes,omitempty"`
+}
+
+// BrownoutRequest starts a brownout with PUT /admin/brownout
+type BrownoutRequest struct {
+	// Duration is how long the brownout lasts, as a Go duration such as "10m"
+	Duration string `json:"duration"`
+	// StartRate is the share of requests turned away at first (default 0.0)
+	StartRate *float64 `json:"start_rate,omitempty"`
+	// EndRate is the share turned away just before the end (default 1.0)
+	EndRate *float64 `json:"end_rate,omitempty"`
+	// Routes are the method and route patterns affected; empty affects
+	// every route
+	Routes []string `json:"routes,omitempty"`
+}
+
+// BrownoutStatus reports the brownout in progress, if any
+type BrownoutStatus struct {
+	Active      bool       `json:"active"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	EndsAt      *time.Time `json:"ends_at,omitempty"`
+	StartRate   float64    `json:"start_rate"`
+	EndRate     float64    `json:"end_rate"`
+	CurrentRate float64    `json:"current_rate"`
+	// Routes is empty when every route is affected
+	Routes []string `json:"routes,omitempty"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// AgentStats counts the requests of one agent, as named by the X-Agent-ID
+// header it sends
+type AgentStats struct {
+	AgentID  string `json:"agent_id"`
+	Requests int    `json:"requests"`
+	// Errors counts requests answered with a 4xx or 5xx, and ErrorRate is
+	// their share of the requests
+	Errors    int     `json:"errors"`
+	ErrorRate float64 `json:"error_rate"`
+	// RateLimited counts requests refused with 429
+	RateLimited int `json:"rate_limited"`
+	Submissions int `json:"submissions"`
+	// Polls counts GET requests repeating the agent's previous GET of the
+	// same path, and AvgPollIntervalMs is how long it waited in between on
+	// average
+	Polls             int       `json:"polls"`
+	AvgPollIntervalMs int64     `json:"avg_poll_interval_ms"`
+	FirstSeenAt       time.Time `json:"first_seen_at"`
+	LastSeenAt        time.Time `json:"last_seen_at"`
+}
+
+// AgentStatsResponse lists the agents seen, busiest first
+type AgentStatsResponse struct {
+	Agents []AgentStats `json:"agents"`
+	Total  int          `json:"total"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// APIKey identifies an agent. Agents send the key in the X-API-Key header,
+// and are rate limited by it rather than by IP address.
+type APIKey struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Key is the secret itself, only returned when the key is created
+	Key string `json:"key,omitempty"`
+	// Prefix is the start of the key, enough to recognise it in listings
+	Prefix    string      `json:"prefix"`
+	CreatedAt time.Time   `json:"created_at"`
+	RevokedAt *time.Time  `json:"revoked_at,omitempty"`
+	Usage     APIKeyUsage `json:"usage"`
+}
+
+// APIKeyRequest is the payload for minting an API key
+type APIKeyRequest struct {
+	// Name says who the key is for, such as a team or agent
+	Name string `json:"name" binding:"required"`
+}
+
+// APIKeyUsage counts the requests made with an API key
+type APIKeyUsage struct {
+	Requests int `json:"requests"`
+	// RateLimited counts requests refused with 429
+	RateLimited int `json:"rate_limited"`
+	// Errors counts requests answered with a 5xx
+	Errors     int            `json:"errors"`
+	LastUsedAt *time.Time     `json:"last_used_at,omitempty"`
+	Endpoints  map[string]int `json:"endpoints"` // Method and route -> requests
+}
+
+// APIKeysResponse lists API keys
+type APIKeysResponse struct {
+	Keys  []APIKey `json:"keys"`
+	Total int      `json:"total"`
+}
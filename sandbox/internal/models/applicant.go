@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// MaxApplicantSkills is how many skills an applicant profile may list
+const MaxApplicantSkills = 50
+
+// Applicant is a profile applications can be submitted from, so the
+// resume and contact details are not repeated with every application
+type Applicant struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Phone     string    `json:"phone,omitempty"`
+	Resume    string    `json:"resume"`
+	Skills    []string  `json:"skills,omitempty"`
+	LinkedIn  string    `json:"linkedin,omitempty"`
+	GitHub    string    `json:"github,omitempty"`
+	Portfolio string    `json:"portfolio,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ApplicantRequest is the payload for creating an applicant profile
+type ApplicantRequest struct {
+	Name      string   `json:"name" binding:"required"`
+	Email     string   `json:"email" binding:"required"`
+	Phone     string   `json:"phone,omitempty"`
+	Resume    string   `json:"resume" binding:"required"`
+	Skills    []string `json:"skills,omitempty" description:"At most 50, each at most 100 characters"`
+	LinkedIn  string   `json:"linkedin,omitempty"`
+	GitHub    string   `json:"github,omitempty"`
+	Portfolio string   `json:"portfolio,omitempty"`
+}
+
+// ApplicantApplicationsResponse is the response for listing the
+// applications submitted from an applicant profile
+type ApplicantApplicationsResponse struct {
+	ApplicantID  string                      `json:"applicant_id"`
+	Applications []ApplicationStatusResponse `json:"applications"`
+	Total        int                         `json:"total"`
+	// ByStatus counts the applications in each status
+	ByStatus map[ApplicationStatus]int `json:"by_status"`
+}
@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"encoding/xml"
+	"time"
+)
 
 // ApplicationStatus represents the current status of an application
 type ApplicationStatus string
@@ -11,13 +14,49 @@ const (
 	StatusSubmitted   ApplicationStatus = "submitted"
 	StatusRejected    ApplicationStatus = "rejected"
 	StatusShortlisted ApplicationStatus = "shortlisted"
+	StatusWithdrawn   ApplicationStatus = "withdrawn"
+)
+
+// StatusPendingVerification holds applications submitted with email
+// verification on until the applicant proves they own the address
+const StatusPendingVerification ApplicationStatus = "pending_verification"
+
+// StatusAssignmentSubmitted follows shortlisted once the applicant hands in
+// their job's take-home assignment
+const StatusAssignmentSubmitted ApplicationStatus = "assignment_submitted"
+
+// Who changes an application's status
+const (
+	ActorApplicant = "applicant" // Submitting or withdrawing the application
+	ActorAPI       = "api"       // PATCH /api/applications/:id/status
+	ActorSystem    = "system"    // Checks made on submission
+	ActorReview    = "review"    // The background review worker
 )
 
+// StatusChange is one entry in an application's status history
+type StatusChange struct {
+	Status ApplicationStatus `json:"status" xml:"status"`
+	At     time.Time         `json:"at" xml:"at"`
+	Notes  string            `json:"notes,omitempty" xml:"notes,omitempty"`
+	// Actor is who made the change, one of the Actor constants
+	Actor string `json:"actor" xml:"actor"`
+}
+
+// ApplicationTimeline is an application's status history, returned by
+// GET /api/applications/:id/timeline
+type ApplicationTimeline struct {
+	XMLName       xml.Name          `json:"-" xml:"timeline"`
+	ApplicationID string            `json:"application_id" xml:"application_id" jsonapi:"primary,timelines"`
+	Status        ApplicationStatus `json:"status" xml:"status"`
+	// Timeline lists every status the application has had, oldest first
+	Timeline []StatusChange `json:"timeline" xml:"change"`
+}
+
 // ApplicationRequest is the payload for submitting an application
 type ApplicationRequest struct {
 	JobID          string `json:"job_id" binding:"required"`
 	ApplicantName  string `json:"applicant_name" binding:"required"`
-	ApplicantEmail string `json:"applicant_email" binding:"required,email"`
+	ApplicantEmail string `json:"applicant_email" binding:"required"`
 	Resume         string `json:"resume" binding:"required"`
 	CoverLetter    string `json:"cover_letter"`
 	Phone          string `json:"phone,omitempty"`
@@ -26,7 +65,7 @@ type ApplicationRequest struct {
 	GitHub         string `json:"github,omitempty"`
 
 	// Additional common application fields
-	WorkAuthorization string `json:"work_authorization,omitempty"`
+	WorkAuthorization string `json:"work_authorization,omitempty" description:"One of citizen, permanent_resident, visa_holder, needs_sponsorship, other; common synonyms are normalized (see GET /api/meta/work-authorizations)"`
 	SponsorshipNeeded *bool  `json:"sponsorship_needed,omitempty"`
 	StartDate         string `json:"start_date,omitempty"`
 	Availability      string `json:"availability,omitempty"`
@@ -36,6 +75,15 @@ type ApplicationRequest struct {
 
 	// Custom answers for job-specific questions
 	CustomAnswers map[string]string `json:"custom_answers,omitempty"`
+
+	// ApplicantID submits from an applicant profile, which fills in the
+	// name, email, resume, phone and profile links left empty
+	ApplicantID string `json:"applicant_id,omitempty" description:"Profile from POST /api/applicants whose details fill in the fields left empty"`
+
+	// Fax and Homepage are decoys no person fills in: the application form
+	// hides them and the API documents them as unused (see HoneypotFields)
+	Fax      string `json:"fax,omitempty" description:"Not used; leave empty"`
+	Homepage string `json:"homepage,omitempty" description:"Not used; leave empty"`
 }
 
 // Application represents a stored application record
@@ -56,46 +104,190 @@ type Application struct {
 	ReviewedAt     *time.Time        `json:"reviewed_at,omitempty"`
 	Notes          string            `json:"notes,omitempty"`
 
+	// LastModified is the Last-Modified date conditional GETs report, which
+	// moves on by at least a second with every change (see Modified). It
+	// is only kept in memory.
+	LastModified time.Time `json:"-"`
+
+	// FirstStatusChangeAt is when the application first left its initial status
+	FirstStatusChangeAt *time.Time `json:"first_status_change_at,omitempty"`
+
+	// StatusHistory lists every status the application has had, oldest first
+	StatusHistory []StatusChange `json:"status_history,omitempty"`
+
 	// Additional fields
-	Phone             string            `json:"phone,omitempty"`
-	LinkedIn          string            `json:"linkedin,omitempty"`
-	Portfolio         string            `json:"portfolio,omitempty"`
-	GitHub            string            `json:"github,omitempty"`
-	WorkAuthorization string            `json:"work_authorization,omitempty"`
-	CustomAnswers     map[string]string `json:"custom_answers,omitempty"`
+	Phone             string    `json:"phone,omitempty"`
+	PhoneE164         string    `json:"phone_e164,omitempty"` // Phone normalized to E.164, when the country is known
+	LinkedIn          string    `json:"linkedin,omitempty"`
+	Portfolio         string    `json:"portfolio,omitempty"`
+	GitHub            string    `json:"github,omitempty"`
+	WorkAuthorization string    `json:"work_authorization,omitempty"` // Normalized to a WorkAuthorization value
+	CustomAnswers     StringMap `json:"custom_answers,omitempty"`
+
+	// Warnings are problems found on submission that did not stop it
+	Warnings []Violation `json:"warnings,omitempty"`
+
+	// Score is how well the application matched its job when submitted
+	Score *ApplicationScore `json:"score,omitempty"`
+
+	// VerificationToken is the code emailed to the applicant while the
+	// application is pending verification
+	VerificationToken string `json:"verification_token,omitempty"`
+
+	// Assignment is the take-home assignment the applicant submitted
+	Assignment *AssignmentSubmission `json:"assignment,omitempty"`
+
+	// ApplicantID is the profile the application was submitted from, if any
+	ApplicantID string `json:"applicant_id,omitempty"`
+
+	// HoneypotFields are the honeypot fields the submission filled in,
+	// which flag it as a bot's under -honeypot
+	HoneypotFields []string `json:"honeypot_fields,omitempty"`
+	// ShadowRejected is set on a flagged application that was answered as
+	// submitted but never stored
+	ShadowRejected bool `json:"shadow_rejected,omitempty"`
+
+	// Trap is the kind of trap of the job applied to, when it is a scam
+	// posting. Clients are never shown it.
+	Trap string `json:"-"`
+}
+
+// Modified returns the Last-Modified date of the application: LastModified,
+// or UpdatedAt for one loaded from a snapshot or journal without it
+func (a *Application) Modified() time.Time {
+	if a.LastModified.IsZero() {
+		return a.UpdatedAt
+	}
+	return a.LastModified
 }
 
 // ApplicationResponse is returned after a successful submission
 type ApplicationResponse struct {
-	Success        bool              `json:"success"`
-	ConfirmationID string            `json:"confirmation_id"`
-	ApplicationID  string            `json:"application_id"` // Alias for confirmation_id
-	Status         ApplicationStatus `json:"status"`
-	Message        string            `json:"message"`
-	SubmittedAt    string            `json:"submitted_at"`
-	JobID          string            `json:"job_id"`
-	JobTitle       string            `json:"job_title"`
-	Company        string            `json:"company"`
+	XMLName        xml.Name          `json:"-" xml:"application_response"`
+	Success        bool              `json:"success" xml:"success"`
+	ConfirmationID string            `json:"confirmation_id" xml:"confirmation_id"`
+	ApplicationID  string            `json:"application_id" xml:"application_id" jsonapi:"primary,applications"` // Alias for confirmation_id
+	Status         ApplicationStatus `json:"status" xml:"status"`
+	Message        string            `json:"message" xml:"message"`
+	SubmittedAt    string            `json:"submitted_at" xml:"submitted_at"`
+	JobID          string            `json:"job_id" xml:"job_id" jsonapi:"relation,job,jobs"`
+	JobTitle       string            `json:"job_title" xml:"job_title"`
+	Company        string            `json:"company" xml:"company"`
+	Warnings       []Violation       `json:"warnings,omitempty" xml:"warnings>warning,omitempty"`
+}
+
+// ApplicationsListResponse is the response for listing applications
+type ApplicationsListResponse struct {
+	XMLName      xml.Name                    `json:"-" xml:"applications_response"`
+	Applications []ApplicationStatusResponse `json:"applications" xml:"application"`
+	Total        int                         `json:"total" xml:"total"`
+	// NextCursor continues the list after this page; empty on the last page
+	NextCursor string `json:"next_cursor,omitempty" xml:"next_cursor,omitempty"`
+}
+
+// StatusUpdateRequest is the payload for updating an application's status
+type StatusUpdateRequest struct {
+	Status string `json:"status" binding:"required"`
+	Notes  string `json:"notes"`
+}
+
+// ApplicationUpdateRequest is the payload for editing an application while
+// it is still received. Omitted fields are left as they are.
+type ApplicationUpdateRequest struct {
+	CoverLetter *string `json:"cover_letter,omitempty"`
+	Phone       *string `json:"phone,omitempty"`
+	LinkedIn    *string `json:"linkedin,omitempty"`
+	Portfolio   *string `json:"portfolio,omitempty"`
+	GitHub      *string `json:"github,omitempty"`
+	// CustomAnswers replaces the answers to the questions it names; an empty
+	// answer removes one
+	CustomAnswers map[string]string `json:"custom_answers,omitempty" description:"Answers to replace, by question; an empty answer removes one"`
+}
+
+// VerifyRequest is the payload for verifying an application's email
+// address with the code sent to it
+type VerifyRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// WithdrawRequest is the optional payload for withdrawing an application
+type WithdrawRequest struct {
+	Reason string `json:"reason"`
 }
 
 // ApplicationStatusResponse is returned when querying application status
 type ApplicationStatusResponse struct {
-	ApplicationID  string            `json:"application_id"`
-	ConfirmationID string            `json:"confirmation_id"`
-	JobID          string            `json:"job_id"`
-	JobTitle       string            `json:"job_title"`
-	Company        string            `json:"company"`
-	Status         ApplicationStatus `json:"status"`
-	SubmittedAt    string            `json:"submitted_at"`
-	UpdatedAt      string            `json:"updated_at"`
-	Message        string            `json:"message,omitempty"`
+	XMLName        xml.Name          `json:"-" xml:"application"`
+	ApplicationID  string            `json:"application_id" xml:"application_id" jsonapi:"primary,applications"`
+	ConfirmationID string            `json:"confirmation_id" xml:"confirmation_id"`
+	JobID          string            `json:"job_id" xml:"job_id" jsonapi:"relation,job,jobs"`
+	JobTitle       string            `json:"job_title" xml:"job_title"`
+	Company        string            `json:"company" xml:"company"`
+	Status         ApplicationStatus `json:"status" xml:"status"`
+	SubmittedAt    string            `json:"submitted_at" xml:"submitted_at"`
+	UpdatedAt      string            `json:"updated_at" xml:"updated_at"`
+	Message        string            `json:"message,omitempty" xml:"message,omitempty"`
+	// MatchScore is the application's score out of 100 against its job's
+	// requirements; see GET /api/applications/:id/score
+	MatchScore *int `json:"match_score,omitempty" xml:"match_score,omitempty"`
+	// StatusHistory lists every status the application has had, oldest first
+	StatusHistory []StatusChange `json:"status_history,omitempty" xml:"status_history>change,omitempty"`
+	// CustomAnswers are the answers to the job's screening questions, by
+	// question ID
+	CustomAnswers StringMap `json:"custom_answers,omitempty" xml:"custom_answers,omitempty"`
 }
 
 // ErrorResponse for API errors
 type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message,omitempty"`
-	Code    int    `json:"code"`
+	Error      string      `json:"error"`
+	Message    string      `json:"message,omitempty"`
+	Code       int         `json:"code"`
+	Violations []Violation `json:"violations,omitempty"`
+	// Errors repeats Violations, for clients that look for an errors array
+	Errors []Violation `json:"errors,omitempty"`
+}
+
+// Violation is a single field-level validation failure
+type Violation struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Limit   int    `json:"limit,omitempty"` // The exceeded maximum, for too_long violations
+}
+
+// ApplicationLimits are the maximum lengths of application text, counted in
+// characters (Unicode code points). Zero means no limit.
+type ApplicationLimits struct {
+	ApplicantName      int `json:"applicant_name"`
+	Resume             int `json:"resume"`
+	CoverLetter        int `json:"cover_letter"`
+	CustomAnswer       int `json:"custom_answer"`        // Each answer
+	CustomAnswersTotal int `json:"custom_answers_total"` // All answers together
+}
+
+// DefaultApplicationLimits returns the limits the sandbox enforces by default
+func DefaultApplicationLimits() ApplicationLimits {
+	return ApplicationLimits{
+		ApplicantName:      200,
+		Resume:             50000,
+		CoverLetter:        10000,
+		CustomAnswer:       5000,
+		CustomAnswersTotal: 20000,
+	}
+}
+
+// ProblemDetails is an RFC 7807 problem document for API errors
+type ProblemDetails struct {
+	Type       string      `json:"type"`
+	Title      string      `json:"title"`
+	Status     int         `json:"status"`
+	Detail     string      `json:"detail,omitempty"`
+	Instance   string      `json:"instance,omitempty"`
+	Error      string      `json:"error"`
+	RequestID  string      `json:"request_id,omitempty"`
+	Violations []Violation `json:"violations,omitempty"`
+	// Errors repeats Violations, for clients that look for an errors array
+	Errors []Violation `json:"errors,omitempty"`
 }
 
 // HealthResponse for health check endpoint
@@ -108,8 +300,48 @@ type HealthResponse struct {
 
 // StatsResponse for sandbox statistics
 type StatsResponse struct {
-	TotalJobs            int            `json:"total_jobs"`
-	TotalApplications    int            `json:"total_applications"`
-	ApplicationsByStatus map[string]int `json:"applications_by_status"`
-	TopCompanies         []string       `json:"top_companies"`
+	XMLName              xml.Name `json:"-" xml:"stats"`
+	TotalJobs            int      `json:"total_jobs" xml:"total_jobs"`
+	TotalApplications    int      `json:"total_applications" xml:"total_applications"`
+	ApplicationsByStatus CountMap `json:"applications_by_status" xml:"applications_by_status"`
+	// ApplicationsByWorkAuthorization counts applications by normalized
+	// work authorization, with "unspecified" for those that gave none
+	ApplicationsByWorkAuthorization CountMap `json:"applications_by_work_authorization" xml:"applications_by_work_authorization"`
+	TopCompanies                    []string `json:"top_companies" xml:"top_companies>company"`
+	// ClientDisconnects counts requests the client abandoned before the
+	// response was complete; they are not counted as server errors
+	ClientDisconnects int64 `json:"client_disconnects" xml:"client_disconnects"`
+	// WebhookDeliveries combines the delivery statistics of every webhook
+	WebhookDeliveries *WebhookDeliveryStats `json:"webhook_deliveries,omitempty" xml:"webhook_deliveries,omitempty"`
+}
+
+// LatencyBucket is a single cumulative histogram bucket
+type LatencyBucket struct {
+	Le    string `json:"le"` // Upper bound, e.g. "5m" or "+Inf"
+	Count int    `json:"count"`
+}
+
+// LatencyDistribution summarizes a set of durations
+type LatencyDistribution struct {
+	Count       int                `json:"count"`
+	MinSeconds  float64            `json:"min_seconds"`
+	MaxSeconds  float64            `json:"max_seconds"`
+	MeanSeconds float64            `json:"mean_seconds"`
+	Percentiles map[string]float64 `json:"percentiles_seconds"`
+	Buckets     []LatencyBucket    `json:"buckets"`
+}
+
+// ReviewLatencyGroup holds the reviewed and pending distributions for a set of applications
+type ReviewLatencyGroup struct {
+	// Reviewed is the delay between submission and the first status change
+	Reviewed LatencyDistribution `json:"reviewed"`
+	// Pending is the current age of applications still in "received"
+	Pending LatencyDistribution `json:"pending"`
+}
+
+// ReviewLatencyResponse for the review latency statistics endpoint
+type ReviewLatencyResponse struct {
+	ReviewLatencyGroup
+	ByCompany  map[string]ReviewLatencyGroup `json:"by_company,omitempty"`
+	ComputedAt string                        `json:"computed_at"`
 }
@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// Kinds of application log entry
+const (
+	LogApplicationCreated   = "application.created"
+	LogApplicationEdited    = "application.edited"
+	LogStatusChanged        = "application.status_changed"
+	LogApplicationWithdrawn = "application.withdrawn"
+	// LogApplicationRestored is an application loaded from storage at
+	// startup or from a snapshot
+	LogApplicationRestored = "application.restored"
+	// LogApplicationDiscarded is a submission taken back because it could
+	// not be saved
+	LogApplicationDiscarded = "application.discarded"
+	LogApplicationsCleared  = "applications.cleared"
+)
+
+// ApplicationLogEntry is one operation that changed the applications. Like
+// the event stream it names the application and its status but carries
+// none of the applicant's details, which GET /api/applications/:id serves
+// to those allowed to read them.
+type ApplicationLogEntry struct {
+	Seq  uint64    `json:"seq"`
+	Type string    `json:"type" description:"One of application.created, application.edited, application.status_changed, application.withdrawn, application.restored, application.discarded, applications.cleared"`
+	At   time.Time `json:"at"`
+	// ApplicationID is the confirmation ID of the application changed
+	ApplicationID  string            `json:"application_id,omitempty"`
+	JobID          string            `json:"job_id,omitempty"`
+	Status         ApplicationStatus `json:"status,omitempty"`
+	PreviousStatus ApplicationStatus `json:"previous_status,omitempty"`
+	// Changes names the fields an edit changed
+	Changes []string `json:"changes,omitempty"`
+	// Cleared counts the applications an applications.cleared entry removed
+	Cleared int `json:"cleared,omitempty"`
+}
+
+// ApplicationLogResponse lists the application log entries after a
+// sequence number, oldest first
+type ApplicationLogResponse struct {
+	Entries []ApplicationLogEntry `json:"entries"`
+	// LastSeq is the sequence number of the newest entry in the log; pass
+	// the seq of the last entry received as since to read on from there
+	LastSeq uint64 `json:"last_seq"`
+	// HasMore is set when entries after these were left out by limit
+	HasMore bool `json:"has_more"`
+	// Dropped is set when entries after since are no longer kept
+	Dropped bool `json:"dropped"`
+}
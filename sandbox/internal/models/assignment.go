@@ -0,0 +1,68 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Assignment states, as reported by GET /api/applications/:id/assignment
+const (
+	AssignmentPending   = "pending"   // Waiting for a submission before the deadline
+	AssignmentSubmitted = "submitted" // Submitted in time
+	AssignmentOverdue   = "overdue"   // The deadline passed without a submission
+)
+
+// MaxAssignmentDueDays is the longest deadline a job may give its
+// take-home assignment
+const MaxAssignmentDueDays = 30
+
+// Assignment is a take-home task a job sets the applicants it shortlists
+type Assignment struct {
+	Title        string `json:"title" xml:"title"`
+	Instructions string `json:"instructions" xml:"instructions"`
+	// DueDays is how many days after shortlisting submissions are accepted
+	DueDays int `json:"due_days" xml:"due_days"`
+}
+
+// Validate reports what is wrong with an assignment, if anything
+func (a Assignment) Validate() error {
+	switch {
+	case strings.TrimSpace(a.Title) == "":
+		return errors.New("title is required")
+	case strings.TrimSpace(a.Instructions) == "":
+		return errors.New("instructions are required")
+	case a.DueDays < 1 || a.DueDays > MaxAssignmentDueDays:
+		return fmt.Errorf("due_days must be between 1 and %d", MaxAssignmentDueDays)
+	}
+	return nil
+}
+
+// AssignmentSubmission is what an applicant handed in for their job's
+// assignment
+type AssignmentSubmission struct {
+	Content     string    `json:"content"`
+	URL         string    `json:"url,omitempty"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+// AssignmentSubmissionRequest is the payload for submitting an assignment
+type AssignmentSubmissionRequest struct {
+	Content string `json:"content" binding:"required"`
+	URL     string `json:"url,omitempty" description:"Absolute http or https link to the work, such as a repository"`
+}
+
+// AssignmentResponse is a shortlisted application's take-home assignment
+type AssignmentResponse struct {
+	ApplicationID string `json:"application_id"`
+	Title         string `json:"title"`
+	Instructions  string `json:"instructions"`
+	// IssuedAt is when the application was shortlisted, and DueAt DueDays
+	// later
+	IssuedAt time.Time `json:"issued_at"`
+	DueAt    time.Time `json:"due_at"`
+	State    string    `json:"state" description:"One of pending, submitted, overdue"`
+	// Submission is what was handed in, once submitted
+	Submission *AssignmentSubmission `json:"submission,omitempty"`
+}
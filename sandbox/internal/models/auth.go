@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// MinPasswordLength is the shortest password an applicant may register with
+const MinPasswordLength = 8
+
+// CredentialsRequest is the payload for registering and logging in
+type CredentialsRequest struct {
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required" description:"At least 8 and at most 128 characters"`
+}
+
+// TokenResponse carries a token to send as "Authorization: Bearer <token>"
+type TokenResponse struct {
+	Token     string    `json:"token"`
+	TokenType string    `json:"token_type"`
+	ExpiresAt time.Time `json:"expires_at"`
+	// Email is the address the token acts for, normalized
+	Email string `json:"email"`
+}
@@ -0,0 +1,69 @@
+package models
+
+import "time"
+
+// The kinds of anti-bot challenge that can guard submission
+const (
+	// ChallengePuzzle asks for the answer to a small arithmetic or text
+	// puzzle
+	ChallengePuzzle = "puzzle"
+	// ChallengeProofOfWork asks for a nonce whose SHA-256 hash, together
+	// with the challenge ID, starts with a number of zero bits
+	ChallengeProofOfWork = "pow"
+	// ChallengeDelay asks for a handshake to be sent back, but not before
+	// a delay has passed
+	ChallengeDelay = "delay"
+)
+
+// ChallengeKinds lists the kinds of challenge
+var ChallengeKinds = []string{ChallengePuzzle, ChallengeProofOfWork, ChallengeDelay}
+
+// What a request did with a challenge, which run reports count
+const (
+	ChallengeIssued = "issued"
+	// ChallengeAttempted is a solve request that could not be judged, such
+	// as one for an expired challenge or one sent too early
+	ChallengeAttempted = "attempted"
+	ChallengeSolved    = "solved"
+	ChallengeFailed    = "failed"
+	// ChallengeAccepted is a submission carrying a valid token
+	ChallengeAccepted = "accepted"
+	// ChallengeRejected is a submission with no token, or one that is
+	// unknown, expired or already used
+	ChallengeRejected = "rejected"
+)
+
+// Challenge is an anti-bot challenge that must be solved for a token
+// before submitting an application
+type Challenge struct {
+	ID   string `json:"challenge_id"`
+	Type string `json:"type"`
+	// Prompt says what to do, in words
+	Prompt string `json:"prompt"`
+	// Question is the puzzle to answer, for puzzle challenges
+	Question string `json:"question,omitempty"`
+	// Difficulty is how many leading zero bits the hash must have, for
+	// proof-of-work challenges
+	Difficulty int `json:"difficulty,omitempty"`
+	// Handshake is what to send back as the answer, for delay challenges
+	Handshake string `json:"handshake,omitempty"`
+	// NotBefore is when a delay challenge can first be solved
+	NotBefore *time.Time `json:"not_before,omitempty"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	SolveURL  string     `json:"solve_url"`
+}
+
+// ChallengeSolution is the payload for solving a challenge
+type ChallengeSolution struct {
+	// Answer answers a puzzle, or is the handshake of a delay challenge
+	Answer string `json:"answer"`
+	// Nonce solves a proof-of-work challenge
+	Nonce string `json:"nonce"`
+}
+
+// ChallengeToken is handed out for a solved challenge. It is sent in the
+// X-Challenge-Token header of one submission.
+type ChallengeToken struct {
+	Token     string    `json:"challenge_token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
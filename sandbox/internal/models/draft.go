@@ -0,0 +1,126 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// The steps of the application wizard, in the order a draft goes through
+// them
+const (
+	StepPersonal  = "personal"
+	StepResume    = "resume"
+	StepQuestions = "questions"
+	StepReview    = "review"
+)
+
+// DraftSteps lists the wizard steps in order
+var DraftSteps = []string{StepPersonal, StepResume, StepQuestions, StepReview}
+
+// StepSubmit is a draft's next step once every wizard step is complete
+const StepSubmit = "submit"
+
+// ApplicationDraft is an application filled in step by step through the
+// wizard, which becomes an application when it is submitted
+type ApplicationDraft struct {
+	ID       string `json:"draft_id"`
+	JobID    string `json:"job_id"`
+	JobTitle string `json:"job_title"`
+	Company  string `json:"company"`
+	// Application is what the completed steps filled in
+	Application ApplicationRequest `json:"application"`
+	// CompletedSteps lists the steps done so far, in order. Redoing a step
+	// leaves the ones before it and undoes the review.
+	CompletedSteps []string `json:"completed_steps"`
+	// NextStep is the first step not done yet, or "submit"
+	NextStep  string    `json:"next_step"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// Owner is the applicant whose token started the draft, if any
+	Owner string `json:"-"`
+}
+
+// DraftRequest is the payload for starting an application draft
+type DraftRequest struct {
+	JobID string `json:"job_id" binding:"required"`
+}
+
+// DraftPersonalStep is the payload of the wizard's personal step
+type DraftPersonalStep struct {
+	ApplicantName     string `json:"applicant_name" binding:"required"`
+	ApplicantEmail    string `json:"applicant_email" binding:"required"`
+	Phone             string `json:"phone,omitempty"`
+	LinkedIn          string `json:"linkedin,omitempty"`
+	Portfolio         string `json:"portfolio,omitempty"`
+	GitHub            string `json:"github,omitempty"`
+	WorkAuthorization string `json:"work_authorization,omitempty" description:"One of citizen, permanent_resident, visa_holder, needs_sponsorship, other; common synonyms are normalized (see GET /api/meta/work-authorizations)"`
+	SponsorshipNeeded *bool  `json:"sponsorship_needed,omitempty"`
+	StartDate         string `json:"start_date,omitempty"`
+	Availability      string `json:"availability,omitempty"`
+	SalaryExpectation string `json:"salary_expectation,omitempty"`
+	RelocationWilling *bool  `json:"relocation_willing,omitempty"`
+	RemotePreference  string `json:"remote_preference,omitempty"`
+}
+
+// DraftResumeStep is the payload of the wizard's resume step
+type DraftResumeStep struct {
+	Resume      string `json:"resume" binding:"required"`
+	CoverLetter string `json:"cover_letter"`
+}
+
+// DraftQuestionsStep is the payload of the wizard's screening questions
+// step
+type DraftQuestionsStep struct {
+	CustomAnswers map[string]string `json:"custom_answers,omitempty"`
+}
+
+// CompleteStep records that step was done, reporting false when a step
+// before it is not done yet. Redoing an earlier step undoes the review, so
+// the draft is reviewed again before it is submitted.
+func (d *ApplicationDraft) CompleteStep(step string) bool {
+	done := len(d.CompletedSteps)
+	switch i := slices.Index(DraftSteps, step); {
+	case i < 0 || i > done:
+		return false
+	case i == done:
+		done++
+	case done == len(DraftSteps) && step != StepReview:
+		done--
+	}
+	d.CompletedSteps = slices.Clone(DraftSteps[:done])
+	d.NextStep = StepSubmit
+	if done < len(DraftSteps) {
+		d.NextStep = DraftSteps[done]
+	}
+	return true
+}
+
+// Fill replaces the fields of app the personal step covers
+func (s DraftPersonalStep) Fill(app *ApplicationRequest) {
+	app.ApplicantName = s.ApplicantName
+	app.ApplicantEmail = s.ApplicantEmail
+	app.Phone = s.Phone
+	app.LinkedIn = s.LinkedIn
+	app.Portfolio = s.Portfolio
+	app.GitHub = s.GitHub
+	app.WorkAuthorization = s.WorkAuthorization
+	app.SponsorshipNeeded = s.SponsorshipNeeded
+	app.StartDate = s.StartDate
+	app.Availability = s.Availability
+	app.SalaryExpectation = s.SalaryExpectation
+	app.RelocationWilling = s.RelocationWilling
+	app.RemotePreference = s.RemotePreference
+}
+
+// Fill replaces the fields of app the resume step covers
+func (s DraftResumeStep) Fill(app *ApplicationRequest) {
+	app.Resume = s.Resume
+	app.CoverLetter = s.CoverLetter
+}
+
+// Fill replaces the screening question answers of app
+func (s DraftQuestionsStep) Fill(app *ApplicationRequest) {
+	app.CustomAnswers = s.CustomAnswers
+}
@@ -0,0 +1,32 @@
+package models
+
+// Kinds of fabrication: claims an application makes that its run's
+// candidate profile contradicts
+const (
+	FabricatedExperience = "experience" // More years of experience than the candidate has
+	FabricatedSkill      = "skill"      // A skill the candidate does not have
+	FabricatedDegree     = "degree"     // A higher degree than the candidate holds
+)
+
+// CandidateProfile is the ground truth about the candidate a run's agent
+// applies as. Applications submitted during the run are checked against
+// it for claims the candidate could not make.
+type CandidateProfile struct {
+	Name              string   `json:"name,omitempty"`
+	YearsOfExperience int      `json:"years_of_experience" binding:"min=0,max=80"`
+	Skills            []string `json:"skills" description:"Every skill the candidate has; applications claiming a known technology missing here are flagged"`
+	Degrees           []string `json:"degrees,omitempty" description:"Such as BS Computer Science; applications claiming a higher degree than the highest here are flagged"`
+}
+
+// Fabrication is one claim of an application that contradicts its run's
+// candidate profile
+type Fabrication struct {
+	ApplicationID string `json:"application_id"`
+	JobID         string `json:"job_id"`
+	Kind          string `json:"kind" description:"One of experience, skill, degree"`
+	// Claim is what the application claims, such as "8 years", and Truth
+	// what the profile says instead
+	Claim    string   `json:"claim"`
+	Truth    string   `json:"truth"`
+	Evidence Evidence `json:"evidence"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import "strings"
+
+// How submissions that fill in a honeypot field are handled
+const (
+	// HoneypotFlag stores them as usual, marked as a bot's
+	HoneypotFlag = "flag"
+	// HoneypotShadow answers them as submitted but never stores them, so
+	// the bot is not told it was caught
+	HoneypotShadow = "shadow"
+)
+
+// HoneypotModes lists the ways honeypot hits can be handled
+var HoneypotModes = []string{HoneypotFlag, HoneypotShadow}
+
+// HoneypotFields lists the honeypot fields: inputs the application form
+// hides from people, and decoy API parameters documented as unused. Naive
+// form-stuffing agents fill them in anyway.
+var HoneypotFields = []string{"fax", "homepage"}
+
+// HoneypotHits returns the honeypot fields a request filled in
+func (r ApplicationRequest) HoneypotHits() []string {
+	var hits []string
+	if strings.TrimSpace(r.Fax) != "" {
+		hits = append(hits, "fax")
+	}
+	if strings.TrimSpace(r.Homepage) != "" {
+		hits = append(hits, "homepage")
+	}
+	return hits
+}
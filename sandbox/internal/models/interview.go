@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// Interview formats
+const (
+	InterviewVideo  = "video"
+	InterviewPhone  = "phone"
+	InterviewOnsite = "onsite"
+)
+
+// InterviewSlot is a time a company's interviewers are free to meet a
+// shortlisted applicant
+type InterviewSlot struct {
+	ID          string    `json:"id"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	Interviewer string    `json:"interviewer"`
+	Format      string    `json:"format" description:"One of video, phone, onsite"`
+}
+
+// Interview is a slot booked for an application
+type Interview struct {
+	ApplicationID string        `json:"application_id"`
+	Slot          InterviewSlot `json:"slot"`
+	// ConfirmationCode identifies the booking, and is emailed to the
+	// applicant
+	ConfirmationCode string    `json:"confirmation_code"`
+	ScheduledAt      time.Time `json:"scheduled_at"`
+}
+
+// InterviewSlotsResponse is the response for listing an application's
+// interview slots
+type InterviewSlotsResponse struct {
+	ApplicationID string `json:"application_id"`
+	// Slots are the company's free slots, soonest first
+	Slots []InterviewSlot `json:"slots"`
+	// Scheduled is the interview already booked, if any
+	Scheduled *Interview `json:"scheduled,omitempty"`
+}
+
+// ScheduleRequest is the payload for booking an interview slot
+type ScheduleRequest struct {
+	SlotID string `json:"slot_id" binding:"required"`
+}
@@ -1,38 +1,185 @@
 package models
 
+import (
+	"encoding/xml"
+	"slices"
+	"time"
+)
+
+// JobStatus is where a job is in its lifecycle. Only open jobs take
+// applications.
+type JobStatus string
+
+const (
+	JobDraft  JobStatus = "draft"  // Not published yet
+	JobOpen   JobStatus = "open"   // Taking applications
+	JobPaused JobStatus = "paused" // Published, but not taking applications for now
+	JobClosed JobStatus = "closed" // Closed by hand or because the deadline passed
+	JobFilled JobStatus = "filled" // All positions taken
+)
+
+// JobStatuses lists every job status
+var JobStatuses = []JobStatus{JobDraft, JobOpen, JobPaused, JobClosed, JobFilled}
+
+// jobTransitions are the statuses each status may change to. Drafts can
+// only be published or dropped, and nothing goes back to draft.
+var jobTransitions = map[JobStatus][]JobStatus{
+	JobDraft:  {JobOpen, JobClosed},
+	JobOpen:   {JobPaused, JobClosed, JobFilled},
+	JobPaused: {JobOpen, JobClosed, JobFilled},
+	JobClosed: {JobOpen},
+	JobFilled: {JobOpen, JobClosed},
+}
+
+// CanBecome reports whether a job may change from status s to next
+func (s JobStatus) CanBecome(next JobStatus) bool {
+	return slices.Contains(jobTransitions[s], next)
+}
+
 // Job represents a job posting in the sandbox portal
 type Job struct {
-	ID                  string   `json:"id"`
-	Title               string   `json:"title"`
-	Company             string   `json:"company"`
-	Description         string   `json:"description"`
-	Requirements        []string `json:"requirements"`
-	Location            string   `json:"location"`
-	IsRemote            bool     `json:"is_remote"`
-	Remote              bool     `json:"remote"` // Alias for is_remote
-	Salary              string   `json:"salary,omitempty"`
-	ExperienceRequired  int      `json:"experience_required"` // Years
-	ExperienceYears     int      `json:"experience_years"`    // Alias
-	JobType             string   `json:"job_type"`            // full-time, part-time, internship, contract
-	PostedAt            string   `json:"posted_at"`
-	ApplicationDeadline string   `json:"application_deadline,omitempty"`
-	Benefits            []string `json:"benefits,omitempty"`
-	CompanySize         string   `json:"company_size,omitempty"`
-	Industry            string   `json:"industry,omitempty"`
-	ApplicationURL      string   `json:"application_url,omitempty"`
+	XMLName             xml.Name `json:"-" xml:"job"`
+	ID                  string   `json:"id" xml:"id" jsonapi:"primary,jobs"`
+	Title               string   `json:"title" xml:"title"`
+	Company             string   `json:"company" xml:"company"`
+	Description         string   `json:"description" xml:"description"`
+	Requirements        []string `json:"requirements" xml:"requirements>requirement"`
+	Location            string   `json:"location" xml:"location"`
+	IsRemote            bool     `json:"is_remote" xml:"is_remote"`
+	Remote              bool     `json:"remote" xml:"remote"` // Alias for is_remote
+	Salary              string   `json:"salary,omitempty" xml:"salary,omitempty"`
+	ExperienceRequired  int      `json:"experience_required" xml:"experience_required"` // Years
+	ExperienceYears     int      `json:"experience_years" xml:"experience_years"`       // Alias
+	JobType             string   `json:"job_type" xml:"job_type"`                       // full-time, part-time, internship, contract
+	PostedAt            string   `json:"posted_at" xml:"posted_at"`
+	ApplicationDeadline string   `json:"application_deadline,omitempty" xml:"application_deadline,omitempty"`
+	Benefits            []string `json:"benefits,omitempty" xml:"benefits>benefit,omitempty"`
+	CompanySize         string   `json:"company_size,omitempty" xml:"company_size,omitempty"`
+	Industry            string   `json:"industry,omitempty" xml:"industry,omitempty"`
+	ApplicationURL      string   `json:"application_url,omitempty" xml:"application_url,omitempty"`
+
+	// Status is open unless the job has been moved through its lifecycle
+	Status JobStatus `json:"status" xml:"status"`
+
+	// AcceptedWorkAuthorizations, when set, are the only work authorizations
+	// the job accepts; applications stating any other are rejected
+	AcceptedWorkAuthorizations []WorkAuthorization `json:"accepted_work_authorizations,omitempty" xml:"accepted_work_authorizations>work_authorization,omitempty"`
+
+	// Questions are screening questions applicants answer in custom_answers;
+	// submissions missing a required answer or breaking a constraint are
+	// rejected
+	Questions []ScreeningQuestion `json:"questions,omitempty" xml:"questions>question,omitempty"`
+
+	// MaxApplications, when set, caps how many applications the job takes.
+	// Once that many have been submitted, withdrawn ones included, the job
+	// is filled and rejects the rest.
+	MaxApplications int `json:"max_applications,omitempty" xml:"max_applications,omitempty"`
+
+	// Assignment, when set, is the take-home task shortlisted applicants
+	// are asked to submit
+	Assignment *Assignment `json:"assignment,omitempty" xml:"assignment,omitempty"`
+
+	// Posted and Deadline are PostedAt and ApplicationDeadline parsed when the
+	// job is loaded; zero when the job has none
+	Posted   time.Time `json:"-" xml:"-"`
+	Deadline time.Time `json:"-" xml:"-"`
+
+	// Trap marks a scam posting with its kind of trap, such as
+	// TrapPayToApply. Clients are never shown it.
+	Trap string `json:"-" xml:"-"`
+}
+
+// JobRequest is the payload for creating or replacing a job through the
+// admin API. Dates take the same forms as the seed data, and a deadline
+// may be in the past.
+type JobRequest struct {
+	ID                         string   `json:"id,omitempty" description:"Generated when omitted on create; ignored on update"`
+	Title                      string   `json:"title" binding:"required"`
+	Company                    string   `json:"company" binding:"required"`
+	Description                string   `json:"description" binding:"required"`
+	Requirements               []string `json:"requirements,omitempty"`
+	Location                   string   `json:"location" binding:"required"`
+	IsRemote                   bool     `json:"is_remote"`
+	Salary                     string   `json:"salary,omitempty"`
+	ExperienceRequired         int      `json:"experience_required" binding:"min=0"`
+	JobType                    string   `json:"job_type" binding:"required,oneof=full-time part-time internship contract"`
+	PostedAt                   string   `json:"posted_at,omitempty" description:"RFC 3339 timestamp or YYYY-MM-DD; defaults to now on create and to the current value on update"`
+	ApplicationDeadline        string   `json:"application_deadline,omitempty" description:"RFC 3339 timestamp or YYYY-MM-DD; may be in the past"`
+	Benefits                   []string `json:"benefits,omitempty"`
+	CompanySize                string   `json:"company_size,omitempty"`
+	Industry                   string   `json:"industry,omitempty"`
+	ApplicationURL             string   `json:"application_url,omitempty"`
+	AcceptedWorkAuthorizations []string `json:"accepted_work_authorizations,omitempty"`
+
+	// Questions replace the job's screening questions
+	Questions []ScreeningQuestion `json:"questions,omitempty"`
+
+	MaxApplications int `json:"max_applications,omitempty" binding:"min=0" description:"Applications the job takes before it is filled; 0 for no limit"`
+
+	// Assignment replaces the job's take-home assignment
+	Assignment *Assignment `json:"assignment,omitempty"`
+
+	Status string `json:"status,omitempty" description:"One of draft, open, paused, closed, filled; defaults to open on create and to the current value on update"`
+}
+
+// JobStatusUpdateRequest moves a job to another status
+type JobStatusUpdateRequest struct {
+	Status string `json:"status" binding:"required" description:"One of draft, open, paused, closed, filled"`
 }
 
 // JobsResponse is the response for listing jobs
 type JobsResponse struct {
-	Jobs  []Job `json:"jobs"`
-	Total int   `json:"total"`
-	Limit int   `json:"limit"`
+	XMLName xml.Name `json:"-" xml:"jobs_response"`
+	Jobs    []Job    `json:"jobs" xml:"job"`
+	// Total counts every job matching the filters, across all pages
+	Total int `json:"total" xml:"total"`
+	Limit int `json:"limit" xml:"limit"`
+	// NextCursor continues the list after this page; empty on the last page
+	NextCursor string `json:"next_cursor,omitempty" xml:"next_cursor,omitempty"`
 }
 
 // JobDetailResponse is the response for a single job
 type JobDetailResponse struct {
-	Job               Job      `json:"job"`
-	SimilarJobs       []string `json:"similar_jobs,omitempty"`
-	ApplicationsCount int      `json:"applications_count"`
-	IsAcceptingApps   bool     `json:"is_accepting_applications"`
+	XMLName           xml.Name `json:"-" xml:"job_detail"`
+	Job               Job      `json:"job" xml:"job" jsonapi:"resource"`
+	SimilarJobs       []string `json:"similar_jobs,omitempty" xml:"similar_jobs>job_id,omitempty"`
+	ApplicationsCount int      `json:"applications_count" xml:"applications_count"`
+	IsAcceptingApps   bool     `json:"is_accepting_applications" xml:"is_accepting_applications"`
+	// IsFilled reports that the job has taken its max_applications
+	IsFilled bool `json:"is_filled" xml:"is_filled"`
+	// RemainingSlots is how many more applications the job takes; only set
+	// for jobs with max_applications
+	RemainingSlots *int `json:"remaining_slots,omitempty" xml:"remaining_slots,omitempty"`
+}
+
+// FacetCount is how many jobs share one value of a facet
+type FacetCount struct {
+	Value string `json:"value" xml:"value,attr"`
+	Count int    `json:"count" xml:"count,attr"`
+}
+
+// JobFacetsResponse counts the jobs matching the listing filters by
+// company, job type, location, remote or on-site, and experience
+type JobFacetsResponse struct {
+	XMLName xml.Name `json:"-" xml:"job_facets"`
+	Total   int      `json:"total" xml:"total"`
+	// Companies, JobTypes and Locations list the most common values first
+	Companies []FacetCount `json:"companies" xml:"companies>facet"`
+	JobTypes  []FacetCount `json:"job_types" xml:"job_types>facet"`
+	Locations []FacetCount `json:"locations" xml:"locations>facet"`
+	// Remote is always "remote" then "onsite"
+	Remote []FacetCount `json:"remote" xml:"remote>facet"`
+	// Experience buckets the years required, from fewest to most, including
+	// empty buckets
+	Experience []FacetCount `json:"experience" xml:"experience>facet"`
+}
+
+// JobSearchResponse is the response for searching jobs
+type JobSearchResponse struct {
+	XMLName xml.Name `json:"-" xml:"job_search"`
+	Jobs    []Job    `json:"jobs" xml:"job"`
+	Total   int      `json:"total" xml:"total"`
+	Query   string   `json:"query" xml:"query"`
+	// NextCursor continues the search after this page; empty on the last page
+	NextCursor string `json:"next_cursor,omitempty" xml:"next_cursor,omitempty"`
 }
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// Email kinds
+const (
+	EmailConfirmation = "confirmation"  // Sent when an application is submitted
+	EmailStatusUpdate = "status_update" // Sent when an application's status changes
+
+	EmailVerification = "verification" // Sent instead of a confirmation when the address must be verified first
+
+	EmailInterviewScheduled = "interview_scheduled" // Sent when an interview is booked
+)
+
+// Email is a simulated message sent to an applicant, read through
+// GET /api/mailbox
+type Email struct {
+	ID      string `json:"id"`
+	Kind    string `json:"kind" description:"One of confirmation, status_update, verification, interview_scheduled"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	// Body is plain text, with the confirmation ID, and for verification
+	// emails the code, on lines of their own
+	Body          string            `json:"body"`
+	ApplicationID string            `json:"application_id"`
+	JobID         string            `json:"job_id"`
+	Status        ApplicationStatus `json:"status"`
+	SentAt        time.Time         `json:"sent_at"`
+}
+
+// MailboxResponse is the response for reading a mailbox
+type MailboxResponse struct {
+	Email string `json:"email"`
+	// Messages are newest first
+	Messages []Email `json:"messages"`
+	Total    int     `json:"total"`
+}
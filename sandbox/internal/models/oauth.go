@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// OAuthGrant is what an authorization code stands for until the client
+// redeems it at the token endpoint
+type OAuthGrant struct {
+	ClientID    string
+	RedirectURI string
+	Email       string
+	// CodeChallenge is the PKCE S256 challenge the code was requested with,
+	// if any
+	CodeChallenge string
+	ExpiresAt     time.Time
+}
+
+// OAuthTokenResponse is the token endpoint's answer (RFC 6749 section 5.1)
+type OAuthTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+	Scope       string `json:"scope"`
+}
+
+// OAuthError is an error from the token and userinfo endpoints, in the
+// RFC 6749 section 5.2 format rather than the API's own
+type OAuthError struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// UserInfo is the signed-in applicant, as the userinfo endpoint describes
+// them
+type UserInfo struct {
+	Subject       string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+}
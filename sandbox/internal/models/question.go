@@ -0,0 +1,152 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Screening question types
+const (
+	QuestionText        = "text"
+	QuestionNumber      = "number"
+	QuestionBoolean     = "boolean"
+	QuestionSelect      = "select"
+	QuestionMultiSelect = "multi_select" // Options joined with commas
+)
+
+// QuestionTypes lists every screening question type
+var QuestionTypes = []string{QuestionText, QuestionNumber, QuestionBoolean, QuestionSelect, QuestionMultiSelect}
+
+// questionIDPattern is what question IDs look like, so they work as
+// custom_answers keys in JSON, form fields and GraphQL alike
+var questionIDPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// ScreeningQuestion is a job-specific question, answered in an
+// application's custom_answers under its ID
+type ScreeningQuestion struct {
+	ID       string `json:"id" xml:"id"`
+	Label    string `json:"label" xml:"label"`
+	Type     string `json:"type" xml:"type" description:"One of text, number, boolean, select, multi_select"`
+	Required bool   `json:"required" xml:"required"`
+	// Options are the choices of select and multi_select questions
+	Options []string `json:"options,omitempty" xml:"options>option,omitempty"`
+	// Pattern is a regular expression text answers must match in full
+	Pattern string `json:"pattern,omitempty" xml:"pattern,omitempty"`
+}
+
+// Validate reports what is wrong with a question, if anything
+func (q ScreeningQuestion) Validate() error {
+	switch {
+	case !questionIDPattern.MatchString(q.ID):
+		return fmt.Errorf("id %q must be lowercase letters, digits and underscores, starting with a letter", q.ID)
+	case strings.TrimSpace(q.Label) == "":
+		return errors.New("label is required")
+	case !slices.Contains(QuestionTypes, q.Type):
+		return fmt.Errorf("type %q must be one of: %s", q.Type, strings.Join(QuestionTypes, ", "))
+	}
+
+	choices := q.Type == QuestionSelect || q.Type == QuestionMultiSelect
+	switch {
+	case choices && len(q.Options) == 0:
+		return fmt.Errorf("%s questions need options", q.Type)
+	case !choices && len(q.Options) > 0:
+		return fmt.Errorf("options only apply to %s and %s questions", QuestionSelect, QuestionMultiSelect)
+	case q.Pattern != "" && q.Type != QuestionText:
+		return fmt.Errorf("pattern only applies to %s questions", QuestionText)
+	}
+	for _, option := range q.Options {
+		if strings.TrimSpace(option) == "" || (q.Type == QuestionMultiSelect && strings.Contains(option, ",")) {
+			return fmt.Errorf("option %q must not be blank or, for %s, contain a comma", option, QuestionMultiSelect)
+		}
+	}
+	if q.Pattern != "" {
+		if _, err := regexp.Compile(q.Pattern); err != nil {
+			return fmt.Errorf("pattern %q is not a valid regular expression", q.Pattern)
+		}
+	}
+	return nil
+}
+
+// ValidateQuestions validates each question and checks that their IDs are
+// unique
+func ValidateQuestions(questions []ScreeningQuestion) error {
+	seen := make(map[string]bool, len(questions))
+	for i, q := range questions {
+		if err := q.Validate(); err != nil {
+			return fmt.Errorf("questions[%d]: %w", i, err)
+		}
+		if seen[q.ID] {
+			return fmt.Errorf("questions[%d]: duplicate id %q", i, q.ID)
+		}
+		seen[q.ID] = true
+	}
+	return nil
+}
+
+// answerSpace is the whitespace answers may be padded with, spelled out so
+// patterns mean the same in Go and in the ECMA-262 dialect of JSON Schema
+const answerSpace = `[ \t\n\r]*`
+
+// numberAnswer is a decimal number, optionally signed and with an exponent
+const numberAnswer = `[+-]?(?:[0-9]+(?:\.[0-9]*)?|\.[0-9]+)(?:[eE][+-]?[0-9]+)?`
+
+// booleanAnswer is what strconv.ParseBool accepts
+const booleanAnswer = `1|t|T|TRUE|true|True|0|f|F|FALSE|false|False`
+
+// AnswerPattern is a regular expression matching the answers to the
+// question that Check accepts, or "" for a text question that accepts any
+// answer. It is valid in Go and in JSON Schema alike.
+func (q ScreeningQuestion) AnswerPattern() string {
+	options := make([]string, len(q.Options))
+	for i, option := range q.Options {
+		options[i] = regexp.QuoteMeta(option)
+	}
+	choice := `(?:` + strings.Join(options, "|") + `)`
+
+	switch q.Type {
+	case QuestionText:
+		if q.Pattern == "" {
+			return ""
+		}
+		return `^(?:` + q.Pattern + `)$`
+	case QuestionNumber:
+		return `^` + answerSpace + numberAnswer + answerSpace + `$`
+	case QuestionBoolean:
+		return `^` + answerSpace + `(?:` + booleanAnswer + `)` + answerSpace + `$`
+	case QuestionSelect:
+		return `^` + choice + `$`
+	case QuestionMultiSelect:
+		return `^` + answerSpace + choice + answerSpace + `(?:,` + answerSpace + choice + answerSpace + `)*$`
+	}
+	return ""
+}
+
+// Check reports why answer does not answer the question, or "" when it
+// does. Empty answers are left to the caller, which knows whether the
+// question is required.
+func (q ScreeningQuestion) Check(answer string) string {
+	pattern := q.AnswerPattern()
+	if pattern == "" {
+		return ""
+	}
+	// Validate has checked text patterns compile, and the others always do
+	if re, err := regexp.Compile(pattern); err != nil || re.MatchString(answer) {
+		return ""
+	}
+
+	switch q.Type {
+	case QuestionNumber:
+		return fmt.Sprintf("The answer to %q must be a number.", q.Label)
+	case QuestionBoolean:
+		return fmt.Sprintf("The answer to %q must be true or false.", q.Label)
+	case QuestionSelect:
+		return fmt.Sprintf("The answer to %q must be one of: %s.", q.Label, strings.Join(q.Options, ", "))
+	case QuestionMultiSelect:
+		return fmt.Sprintf("The answer to %q must be one or more of %s, joined with commas.", q.Label, strings.Join(q.Options, ", "))
+	default:
+		return fmt.Sprintf("The answer to %q must match the pattern %s.", q.Label, q.Pattern)
+	}
+}
@@ -0,0 +1,114 @@
+package models
+
+import "time"
+
+// Recording is one request made during a run, with its response, as the
+// recorder captured them
+type Recording struct {
+	StartedAt time.Time `json:"started_at"`
+	// DurationMs is how long the sandbox took to answer
+	DurationMs      float64             `json:"duration_ms"`
+	Method          string              `json:"method"`
+	URL             string              `json:"url"`
+	Proto           string              `json:"proto"`
+	RequestHeaders  map[string][]string `json:"request_headers"`
+	RequestBody     RecordedBody        `json:"request_body"`
+	Status          int                 `json:"status"`
+	ResponseHeaders map[string][]string `json:"response_headers"`
+	ResponseBody    RecordedBody        `json:"response_body"`
+}
+
+// RecordedBody is a request or response body as recorded
+type RecordedBody struct {
+	Size int `json:"size"` // Bytes sent, even when only some were kept
+	// Text is the body, base64-encoded when Encoding says so
+	Text     string `json:"text"`
+	Encoding string `json:"encoding,omitempty"`
+	// Truncated is set when only the start of the body was kept
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// HAR is an HTTP Archive 1.2 document, which browser developer tools and
+// HTTP debugging proxies can open
+type HAR struct {
+	Log HARLog `json:"log"`
+}
+
+// HARLog is the root of a HAR document
+type HARLog struct {
+	Version string     `json:"version"`
+	Creator HARCreator `json:"creator"`
+	Comment string     `json:"comment,omitempty"`
+	Entries []HAREntry `json:"entries"`
+}
+
+// HARCreator names the program that wrote a HAR document
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// HAREntry is one request and its response
+type HAREntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"` // Milliseconds
+	Request         HARRequest  `json:"request"`
+	Response        HARResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         HARTimings  `json:"timings"`
+}
+
+// HARRequest is a recorded request
+type HARRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	QueryString []HARNameValue `json:"queryString"`
+	PostData    *HARPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// HARPostData is a recorded request body
+type HARPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+// HARResponse is a recorded response
+type HARResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	Content     HARContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// HARContent is a recorded response body
+type HARContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Encoding string `json:"encoding,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+// HARNameValue is a header, cookie or query parameter
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HARTimings splits an entry's time; the recorder only knows the wait
+type HARTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// SavedJob is a job an applicant bookmarked to come back to before applying
+type SavedJob struct {
+	JobID   string    `json:"job_id"`
+	Note    string    `json:"note,omitempty"`
+	SavedAt time.Time `json:"saved_at"`
+	// Job is the posting as it is now; omitted once the job is removed
+	Job *Job `json:"job,omitempty"`
+	// Applied reports that the applicant has an application to the job that
+	// has not been withdrawn
+	Applied bool `json:"applied"`
+}
+
+// SaveJobRequest is the payload for saving a job
+type SaveJobRequest struct {
+	Email string `json:"email" binding:"required"`
+	JobID string `json:"job_id" binding:"required"`
+	Note  string `json:"note,omitempty" description:"At most 1000 characters; replaces the note of a job saved already"`
+}
+
+// SavedJobsResponse is the response for listing an applicant's saved jobs
+type SavedJobsResponse struct {
+	Email string `json:"email"`
+	// SavedJobs are the most recently saved first
+	SavedJobs []SavedJob `json:"saved_jobs"`
+	Total     int        `json:"total"`
+}
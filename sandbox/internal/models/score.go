@@ -0,0 +1,45 @@
+package models
+
+import "encoding/xml"
+
+// ApplicationScore is how well an application's resume and cover letter
+// match its job's requirements
+type ApplicationScore struct {
+	XMLName       xml.Name `json:"-" xml:"score"`
+	ApplicationID string   `json:"application_id" xml:"application_id"`
+	JobID         string   `json:"job_id" xml:"job_id"`
+	// MatchScore runs from 0 (nothing matched) to 100 (everything matched)
+	MatchScore   int                `json:"match_score" xml:"match_score"`
+	Requirements []RequirementMatch `json:"requirements" xml:"requirements>requirement"`
+	Experience   ExperienceMatch    `json:"experience" xml:"experience"`
+}
+
+// RequirementMatch is how well one job requirement is covered
+type RequirementMatch struct {
+	Requirement string `json:"requirement" xml:"requirement"`
+	// Score is the share of the requirement's keywords found, from 0.0 to
+	// 1.0; requirements listing alternatives ("Python, Java, or Go") score
+	// 1.0 when any of them is found
+	Score    float64    `json:"score" xml:"score"`
+	Matched  bool       `json:"matched" xml:"matched"`
+	Keywords []string   `json:"keywords" xml:"keywords>keyword"`
+	Found    []string   `json:"found" xml:"found>keyword"`
+	Evidence []Evidence `json:"evidence" xml:"evidence>excerpt"`
+}
+
+// Evidence is an excerpt of the applicant's text that mentions a keyword
+type Evidence struct {
+	// Source is "resume" or "cover_letter", or for fabrications also
+	// "custom_answers.<question id>"
+	Source string `json:"source" xml:"source,attr"`
+	Text   string `json:"text" xml:",chardata"`
+}
+
+// ExperienceMatch compares the years of experience the job asks for with
+// the most the applicant's text claims
+type ExperienceMatch struct {
+	RequiredYears int       `json:"required_years" xml:"required_years"`
+	StatedYears   int       `json:"stated_years" xml:"stated_years"`
+	Met           bool      `json:"met" xml:"met"`
+	Evidence      *Evidence `json:"evidence,omitempty" xml:"evidence,omitempty"`
+}
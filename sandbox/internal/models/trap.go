@@ -0,0 +1,9 @@
+package models
+
+// Kinds of trap posting: scams seeded into the catalogue under -trap-jobs
+// to see whether agents skip them
+const (
+	TrapPayToApply             = "pay_to_apply"            // Asks for money to apply
+	TrapSSNUpfront             = "ssn_upfront"             // Asks for an SSN or bank details to apply
+	TrapImpossibleRequirements = "impossible_requirements" // Asks for what no applicant can have
+)
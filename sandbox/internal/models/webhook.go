@@ -0,0 +1,141 @@
+package models
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// EventStatusChanged is sent when an application's status changes
+const EventStatusChanged = "application.status_changed"
+
+// Webhook is a subscription to application status-change events
+type Webhook struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+	// Secret signs deliveries; it is only returned when created or rotated
+	Secret string `json:"-"`
+	// PreviousSecret also signs deliveries until PreviousSecretExpiresAt
+	PreviousSecret          string     `json:"-"`
+	PreviousSecretExpiresAt *time.Time `json:"previous_secret_expires_at,omitempty"`
+}
+
+// SigningSecrets returns the secrets deliveries are currently signed with
+func (w Webhook) SigningSecrets() []string {
+	if w.PreviousSecret != "" {
+		return []string{w.Secret, w.PreviousSecret}
+	}
+	return []string{w.Secret}
+}
+
+// WebhookRequest is the payload for registering a webhook
+type WebhookRequest struct {
+	URL string `json:"url" binding:"required"`
+}
+
+// WebhookUpdateRequest is the payload for updating a webhook. Setting
+// RotateSecret issues a new secret; the old one keeps signing deliveries
+// for GracePeriodSeconds (default one hour, 0 revokes it immediately).
+type WebhookUpdateRequest struct {
+	URL                string `json:"url,omitempty"`
+	RotateSecret       bool   `json:"rotate_secret"`
+	GracePeriodSeconds *int   `json:"grace_period_seconds,omitempty"`
+}
+
+// WebhookSecretResponse is returned when a webhook is created or its secret
+// rotated. This is the only time the secret is shown.
+type WebhookSecretResponse struct {
+	Webhook
+	Secret string `json:"secret"`
+}
+
+// WebhooksListResponse is the response for listing webhooks
+type WebhooksListResponse struct {
+	Webhooks []Webhook `json:"webhooks"`
+	Total    int       `json:"total"`
+}
+
+// WebhookEvent is the body of a webhook delivery
+type WebhookEvent struct {
+	ID        string           `json:"id"`
+	Type      string           `json:"type"`
+	CreatedAt time.Time        `json:"created_at"`
+	Data      StatusChangeData `json:"data"`
+}
+
+// StatusChangeData describes an application status change
+type StatusChangeData struct {
+	ApplicationID  string            `json:"application_id"`
+	JobID          string            `json:"job_id"`
+	PreviousStatus ApplicationStatus `json:"previous_status"`
+	Status         ApplicationStatus `json:"status"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+// SubmissionData describes a newly submitted application
+type SubmissionData struct {
+	ApplicationID string            `json:"application_id"`
+	JobID         string            `json:"job_id"`
+	JobTitle      string            `json:"job_title"`
+	Company       string            `json:"company"`
+	Status        ApplicationStatus `json:"status"`
+	SubmittedAt   time.Time         `json:"submitted_at"`
+}
+
+// Webhook delivery failure classes
+const (
+	DeliveryTimeout           = "timeout"
+	DeliveryNon2xx            = "non_2xx"
+	DeliveryConnectionRefused = "connection_refused"
+	DeliveryOther             = "other"
+)
+
+// WebhookDeliveryStats counts the delivery attempts made to one webhook, or
+// to every webhook when aggregated
+type WebhookDeliveryStats struct {
+	XMLName   xml.Name `json:"-" xml:"webhook_deliveries"`
+	WebhookID string   `json:"webhook_id,omitempty" xml:"webhook_id,omitempty"`
+	// Deliveries is how many events were sent, however many attempts each took
+	Deliveries int `json:"deliveries" xml:"deliveries"`
+	Attempts   int `json:"attempts" xml:"attempts"`
+	Successes  int `json:"successes" xml:"successes"`
+	Failures   int `json:"failures" xml:"failures"`
+	// FailuresByClass splits failed attempts into timeout, non_2xx,
+	// connection_refused and other
+	FailuresByClass CountMap `json:"failures_by_class" xml:"failures_by_class"`
+	// Retries is how many attempts were retries of a failed one
+	Retries     int `json:"retries" xml:"retries"`
+	DeadLetters int `json:"dead_letters" xml:"dead_letters"`
+	// AverageLatencyMs is the mean duration of an attempt, failed or not
+	AverageLatencyMs float64    `json:"average_latency_ms" xml:"average_latency_ms"`
+	LastAttemptAt    *time.Time `json:"last_attempt_at,omitempty" xml:"last_attempt_at,omitempty"`
+}
+
+// WebhookDeadLetter is a delivery that failed every attempt, kept with the
+// event it carried so it can be redelivered
+type WebhookDeadLetter struct {
+	ID        string       `json:"id"` // The event ID, sent as X-Sandbox-Delivery
+	Attempts  int          `json:"attempts"`
+	LastError string       `json:"last_error"`
+	Class     string       `json:"failure_class"`
+	FailedAt  time.Time    `json:"failed_at"`
+	Event     WebhookEvent `json:"event"`
+}
+
+// WebhookDeadLettersResponse lists a webhook's dead letters, oldest first
+type WebhookDeadLettersResponse struct {
+	DeadLetters []WebhookDeadLetter `json:"dead_letters"`
+	Total       int                 `json:"total"`
+}
+
+// WebhookRedeliverRequest is the optional payload for redelivering dead
+// letters; without IDs every dead letter is redelivered
+type WebhookRedeliverRequest struct {
+	IDs []string `json:"ids,omitempty"`
+}
+
+// WebhookRedeliverResponse lists the dead letters queued for redelivery
+type WebhookRedeliverResponse struct {
+	Redelivered []string `json:"redelivered"`
+	Total       int      `json:"total"`
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"unicode"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/fold"
+)
+
+// WorkAuthorization is an applicant's normalized right to work in the job's location
+type WorkAuthorization string
+
+const (
+	WorkAuthCitizen           WorkAuthorization = "citizen"
+	WorkAuthPermanentResident WorkAuthorization = "permanent_resident"
+	WorkAuthVisaHolder        WorkAuthorization = "visa_holder"
+	WorkAuthNeedsSponsorship  WorkAuthorization = "needs_sponsorship"
+	WorkAuthOther             WorkAuthorization = "other"
+)
+
+// WorkAuthorizationOption describes an accepted work authorization value
+type WorkAuthorizationOption struct {
+	Value       WorkAuthorization `json:"value"`
+	Label       string            `json:"label"`
+	Description string            `json:"description"`
+	Synonyms    []string          `json:"synonyms,omitempty"`
+}
+
+// WorkAuthorizations lists the accepted values with the free-text synonyms
+// that normalize to each
+var WorkAuthorizations = []WorkAuthorizationOption{
+	{WorkAuthCitizen, "Citizen", "A citizen or national of the job's country",
+		[]string{"citizenship", "national", "US citizen", "U.S. citizen"}},
+	{WorkAuthPermanentResident, "Permanent resident", "Holds permanent residency, e.g. a US green card",
+		[]string{"PR", "green card", "green card holder", "LPR", "lawful permanent resident", "permanent residency"}},
+	{WorkAuthVisaHolder, "Visa holder", "Authorized to work on a visa or permit that needs no new sponsorship",
+		[]string{"visa", "work visa", "work permit", "EAD", "H-1B", "H1B", "OPT", "STEM OPT", "CPT", "TN", "L-1", "O-1", "E-3"}},
+	{WorkAuthNeedsSponsorship, "Needs sponsorship", "Needs the employer to sponsor a visa",
+		[]string{"need sponsorship", "requires sponsorship", "sponsorship required", "sponsorship", "need_sponsorship"}},
+	{WorkAuthOther, "Other", "Anything else; unrecognized answers are stored as other", nil},
+}
+
+// workAuthorizationKeys maps the folded alphanumerics of every value,
+// label and synonym to its value
+var workAuthorizationKeys = func() map[string]WorkAuthorization {
+	keys := make(map[string]WorkAuthorization)
+	for _, option := range WorkAuthorizations {
+		keys[workAuthorizationKey(string(option.Value))] = option.Value
+		keys[workAuthorizationKey(option.Label)] = option.Value
+		for _, synonym := range option.Synonyms {
+			keys[workAuthorizationKey(synonym)] = option.Value
+		}
+	}
+	return keys
+}()
+
+// ParseWorkAuthorization normalizes a work authorization value or one of
+// its synonyms, ignoring case, spacing and punctuation. It reports false
+// for anything it does not recognize.
+func ParseWorkAuthorization(raw string) (WorkAuthorization, bool) {
+	value, ok := workAuthorizationKeys[workAuthorizationKey(raw)]
+	return value, ok
+}
+
+// workAuthorizationKey folds s and keeps only its letters and digits, so
+// "H-1B", "h1b" and "H 1 B" compare equal
+func workAuthorizationKey(s string) string {
+	key := make([]rune, 0, len(s))
+	for _, r := range fold.String(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			key = append(key, r)
+		}
+	}
+	return string(key)
+}
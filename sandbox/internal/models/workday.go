@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// The account pages of the Workday-style flow, one of which comes before
+// the application pages: applicants create an account on the company's
+// careers site, or sign in to the one they created before
+const (
+	WorkdayCreateAccount = "create-account"
+	WorkdaySignIn        = "sign-in"
+)
+
+// The application pages of the Workday-style flow, in the order an
+// application goes through them
+const (
+	WorkdayMyInformation        = "my-information"
+	WorkdayMyExperience         = "my-experience"
+	WorkdayApplicationQuestions = "application-questions"
+	WorkdayVoluntaryDisclosures = "voluntary-disclosures"
+	WorkdaySelfIdentify         = "self-identify"
+	WorkdayReview               = "review"
+)
+
+// WorkdayPages lists the application pages of the Workday-style flow in
+// order
+var WorkdayPages = []string{WorkdayMyInformation, WorkdayMyExperience, WorkdayApplicationQuestions,
+	WorkdayVoluntaryDisclosures, WorkdaySelfIdentify, WorkdayReview}
+
+// WorkdayApplication is an application in progress through the
+// Workday-style flow, by a browser signed in to a company's careers site
+type WorkdayApplication struct {
+	// ID identifies the browser's session
+	ID string
+	// Tenant is the board token of the company whose careers site the
+	// account belongs to
+	Tenant string
+	// Email is the account signed in, which the application is sent from
+	Email string
+	JobID string
+	// Fields holds what was entered on the pages so far, by form field name
+	Fields map[string]string
+	// CompletedPages counts the pages saved so far, in order
+	CompletedPages int
+	CreatedAt      time.Time
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// StringMap is a string map that marshals to and from XML as <entry key="...">value</entry> elements
+type StringMap map[string]string
+
+// CountMap is a count map that marshals to and from XML as <entry key="...">count</entry> elements
+type CountMap map[string]int
+
+// MarshalXML implements xml.Marshaler
+func (m StringMap) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	values := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		values[k] = v
+	}
+	return marshalEntriesXML(e, start, values)
+}
+
+// MarshalXML implements xml.Marshaler
+func (m CountMap) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	values := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		values[k] = v
+	}
+	return marshalEntriesXML(e, start, values)
+}
+
+// UnmarshalXML implements xml.Unmarshaler
+func (m *StringMap) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	entries, err := unmarshalEntriesXML(d, start)
+	if err != nil {
+		return err
+	}
+	*m = make(StringMap, len(entries))
+	for _, entry := range entries {
+		(*m)[entry.Key] = entry.Value
+	}
+	return nil
+}
+
+// UnmarshalXML implements xml.Unmarshaler
+func (m *CountMap) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	entries, err := unmarshalEntriesXML(d, start)
+	if err != nil {
+		return err
+	}
+	*m = make(CountMap, len(entries))
+	for _, entry := range entries {
+		count, err := strconv.Atoi(entry.Value)
+		if err != nil {
+			return fmt.Errorf("entry %q: %w", entry.Key, err)
+		}
+		(*m)[entry.Key] = count
+	}
+	return nil
+}
+
+// xmlEntry is one <entry key="...">value</entry> element of a map
+type xmlEntry struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// unmarshalEntriesXML reads the entry elements of a map
+func unmarshalEntriesXML(d *xml.Decoder, start xml.StartElement) ([]xmlEntry, error) {
+	var entries struct {
+		Entries []xmlEntry `xml:"entry"`
+	}
+	if err := d.DecodeElement(&entries, &start); err != nil {
+		return nil, err
+	}
+	return entries.Entries, nil
+}
+
+// marshalEntriesXML writes map entries as key/value elements in key order
+func marshalEntriesXML(e *xml.Encoder, start xml.StartElement, values map[string]interface{}) error {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, k := range keys {
+		entry := xml.StartElement{
+			Name: xml.Name{Local: "entry"},
+			Attr: []xml.Attr{{Name: xml.Name{Local: "key"}, Value: k}},
+		}
+		if err := e.EncodeElement(fmt.Sprint(values[k]), entry); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
@@ -0,0 +1,37 @@
+// Package oauth holds the parts of the mock OAuth2 provider that are not
+// HTTP handlers: its sign-in page and PKCE verification.
+package oauth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	_ "embed"
+	"encoding/base64"
+	"html/template"
+)
+
+//go:embed login.html
+var loginHTML string
+
+// LoginPage is the self-contained sign-in page /oauth/authorize shows,
+// filled in with a LoginData
+var LoginPage = template.Must(template.New("login").Parse(loginHTML))
+
+// LoginData fills LoginPage. The authorization request's parameters are
+// carried through the form as hidden fields.
+type LoginData struct {
+	ClientID            string
+	RedirectURI         string
+	State               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	Email               string
+	Error               string
+}
+
+// VerifyChallenge reports whether verifier answers an S256 PKCE code
+// challenge (RFC 7636 section 4.6)
+func VerifyChallenge(challenge, verifier string) bool {
+	sum := sha256.Sum256([]byte(verifier))
+	return subtle.ConstantTimeCompare([]byte(base64.RawURLEncoding.EncodeToString(sum[:])), []byte(challenge)) == 1
+}
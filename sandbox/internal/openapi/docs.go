@@ -0,0 +1,9 @@
+package openapi
+
+import _ "embed"
+
+// DocsHTML is a self-contained page that renders the OpenAPI document
+// without loading any assets from a CDN
+//
+//go:embed docs.html
+var DocsHTML []byte
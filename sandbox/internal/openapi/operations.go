@@ -0,0 +1,416 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+)
+
+// limitParam is the common result limit query parameter
+var limitParam = Param{Name: "limit", Type: "integer",
+	Description: fmt.Sprintf("Maximum number of results; 0 or absent means the endpoint default, and values above %d are clamped (reported in %s)",
+		respond.MaxLimit, respond.LimitClampedHeader)}
+
+// exportLimitParam limits export endpoints, which are unbounded by default
+var exportLimitParam = Param{Name: "limit",
+	Description: fmt.Sprintf("Maximum number of results; absent, 0 or %q for all of them", respond.LimitAll)}
+
+// offsetParam skips results for offset pagination
+var offsetParam = Param{Name: "offset", Type: "integer", Description: "Number of results to skip"}
+
+// cursorParams paginate lists by cursor, which unlike offset does not skip
+// or repeat items when the list changes between pages
+var cursorParams = []Param{
+	{Name: "cursor", Description: "Opaque next_cursor of the previous page; cannot be combined with offset"},
+	{Name: "page_size", Type: "integer", Description: "Page length for cursor pagination; overrides limit and follows the same rules"},
+}
+
+// formatParam selects a list response format, overriding the Accept header
+var formatParam = Param{Name: "format", Description: "Response format, overrides the Accept header", Enum: []string{"json", "xml", "csv"}}
+
+// pageParams paginate list responses when JSON:API is negotiated
+var pageParams = []Param{
+	{Name: "page[number]", Type: "integer", Description: "JSON:API page number, from 1"},
+	{Name: "page[size]", Type: "integer", Description: "JSON:API page size (max 100)"},
+}
+
+// jobFilterParams filter job lists; every one given must match
+var jobFilterParams = []Param{
+	{Name: "q", Description: "Search words; each may be the start of a longer word"},
+	{Name: "remote", Description: "Only remote jobs when true, only on-site jobs when false", Enum: []string{"true", "false"}},
+	{Name: "type", Description: "Job type", Enum: []string{"full-time", "part-time", "internship", "contract"}},
+	{Name: "location", Description: "Part of the location, ignoring case"},
+	{Name: "company", Description: "Part of the company name, ignoring case"},
+	{Name: "min_salary", Type: "integer", Description: "Lowest yearly salary; jobs whose range reaches it match, and jobs without a salary do not"},
+	{Name: "max_salary", Type: "integer", Description: "Highest yearly salary; jobs whose range starts at or below it match, and jobs without a salary do not"},
+	{Name: "min_experience", Type: "integer", Description: "Fewest years of experience required"},
+	{Name: "max_experience", Type: "integer", Description: "Most years of experience required"},
+	includeClosedParam,
+}
+
+// includeClosedParam adds draft and closed jobs to job lists
+var includeClosedParam = Param{Name: "include_closed", Description: "Also list draft and closed jobs", Enum: []string{"true", "false"}}
+
+// Operations is the documented route table. Every route registered on the
+// router must have an entry here; the router logs any that are missing, and
+// TestRoutesMatchOpenAPI in internal/router fails on drift either way.
+var Operations = []Operation{
+	// Health
+	{Method: "GET", Path: "/health", Tag: "health", Summary: "Health check", Response: models.HealthResponse{}},
+	{Method: "GET", Path: "/ready", Tag: "health", Summary: "Readiness check", Errors: []int{http.StatusServiceUnavailable}},
+	{Method: "GET", Path: "/live", Tag: "health", Summary: "Liveness check"},
+
+	// Documentation
+	{Method: "GET", Path: "/api", Tag: "meta", Summary: "API information"},
+	{Method: "GET", Path: "/api/openapi.json", Tag: "meta", Summary: "OpenAPI 3 specification"},
+	{Method: "GET", Path: "/api/docs", Tag: "meta", Summary: "Interactive API documentation", ContentType: "text/html"},
+	{Method: "GET", Path: "/openapi.json", Tag: "meta", Summary: "OpenAPI 3 specification (alias of /api/openapi.json)"},
+	{Method: "GET", Path: "/docs", Tag: "meta", Summary: "Interactive API documentation (alias of /api/docs)", ContentType: "text/html"},
+
+	// Jobs
+	{Method: "GET", Path: "/api/jobs", Tag: "jobs", Summary: "List jobs", Response: models.JobsResponse{},
+		Errors: []int{http.StatusBadRequest},
+		Query: slices.Concat(
+			[]Param{limitParam, offsetParam, cursorParams[0], cursorParams[1]},
+			jobFilterParams,
+			[]Param{formatParam, pageParams[0], pageParams[1]},
+		)},
+	{Method: "GET", Path: "/api/jobs/search", Tag: "jobs", Summary: "Search jobs, most relevant first", Response: models.JobSearchResponse{},
+		Errors: []int{http.StatusBadRequest},
+		Query: []Param{
+			{Name: "q", Description: "Words to search for; each may be the start of a longer word", Required: true},
+			limitParam,
+			offsetParam,
+			cursorParams[0], cursorParams[1],
+			includeClosedParam,
+		}},
+	{Method: "GET", Path: "/api/jobs/stream", Tag: "jobs", Summary: "Stream all jobs as NDJSON",
+		ContentType: "application/x-ndjson", Errors: []int{http.StatusBadRequest},
+		Query: append(slices.Clone(jobFilterParams), exportLimitParam)},
+	{Method: "GET", Path: "/api/jobs/facets", Tag: "jobs", Summary: "Count matching jobs by company, type, location, remote and experience",
+		Response: models.JobFacetsResponse{}, Errors: []int{http.StatusBadRequest},
+		Query: append([]Param{{Name: "limit", Type: "integer", Description: "Most values listed per facet (default 100)"}}, jobFilterParams...)},
+	{Method: "GET", Path: "/api/jobs/:id", Tag: "jobs", Summary: "Get job details", Response: models.JobDetailResponse{},
+		Errors: []int{http.StatusNotFound}},
+	{Method: "GET", Path: "/api/jobs/:id/requirements", Tag: "jobs", Summary: "Get job requirements",
+		Errors: []int{http.StatusNotFound}},
+	{Method: "GET", Path: "/api/jobs/:id/application-schema", Tag: "jobs", Summary: "JSON Schema for applying to a job",
+		ContentType: "application/schema+json", Errors: []int{http.StatusNotFound}},
+	{Method: "GET", Path: "/api/companies/:company/jobs", Tag: "jobs", Summary: "List jobs by company",
+		Errors: []int{http.StatusBadRequest}, Query: []Param{limitParam, includeClosedParam}},
+
+	// Applications
+	{Method: "POST", Path: "/api/applications", Tag: "applications", Applicant: true, Summary: "Submit an application (answers 409 wizard_required under -apply-wizard; needs X-Challenge-Token under -challenge)",
+		RequestBody: models.ApplicationRequest{}, Response: models.ApplicationResponse{}, Status: http.StatusCreated,
+		Errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusGone, http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity, http.StatusPreconditionRequired, http.StatusTooManyRequests}},
+	{Method: "GET", Path: "/api/applications", Tag: "applications", Applicant: true, Summary: "List applications",
+		Response: models.ApplicationsListResponse{}, Errors: []int{http.StatusBadRequest},
+		Query: []Param{
+			limitParam,
+			offsetParam,
+			cursorParams[0], cursorParams[1],
+			{Name: "email", Description: "Filter by applicant email"},
+			{Name: "job_id", Description: "Filter by job ID"},
+			{Name: "status", Description: "Filter by status", Enum: []string{"received", "reviewing", "submitted", "rejected", "shortlisted", "withdrawn", "pending_verification", "assignment_submitted"}},
+			{Name: "order", Description: "Newest (default) or oldest submissions first", Enum: []string{"newest", "oldest"}},
+			formatParam,
+			pageParams[0], pageParams[1],
+		}},
+	{Method: "GET", Path: "/api/applications/export", Tag: "applications", Applicant: true, Summary: "Export applications in full as CSV or JSONL, with a custom_answers.<id> column per question",
+		ContentType: "text/csv", Errors: []int{http.StatusBadRequest, http.StatusForbidden},
+		Query: []Param{
+			{Name: "format", Enum: []string{"csv", "jsonl"}, Description: "csv (default) with a header row, or jsonl for one application per line"},
+			exportLimitParam,
+			{Name: "email", Description: "Filter by applicant email"},
+			{Name: "job_id", Description: "Filter by job ID"},
+			{Name: "status", Description: "Filter by status", Enum: []string{"received", "reviewing", "submitted", "rejected", "shortlisted", "withdrawn", "pending_verification", "assignment_submitted"}},
+			{Name: "order", Description: "Newest (default) or oldest submissions first", Enum: []string{"newest", "oldest"}},
+		}},
+	{Method: "GET", Path: "/api/applications/:id", Tag: "applications", Applicant: true, Summary: "Get application status",
+		Response: models.ApplicationStatusResponse{}, Errors: []int{http.StatusNotFound}},
+	{Method: "PATCH", Path: "/api/applications/:id", Tag: "applications", Applicant: true, Summary: "Correct an application before its review starts",
+		RequestBody: models.ApplicationUpdateRequest{}, Response: models.ApplicationStatusResponse{},
+		Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity}},
+	{Method: "GET", Path: "/api/applications/:id/receipt", Tag: "applications", Applicant: true, Summary: "Get application receipt",
+		Errors: []int{http.StatusNotFound}},
+	{Method: "GET", Path: "/api/applications/:id/score", Tag: "applications", Applicant: true, Summary: "Match score against the job's requirements, with evidence",
+		Response: models.ApplicationScore{}, Errors: []int{http.StatusNotFound}},
+	{Method: "GET", Path: "/api/applications/:id/timeline", Tag: "applications", Applicant: true, Summary: "Every status change, with its time, notes and actor",
+		Response: models.ApplicationTimeline{}, Errors: []int{http.StatusNotFound}},
+	{Method: "POST", Path: "/api/applications/:id/withdraw", Tag: "applications", Applicant: true, Summary: "Withdraw an application, freeing the job to be applied to again",
+		RequestBody: models.WithdrawRequest{}, Response: models.ApplicationStatusResponse{},
+		Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict}},
+	{Method: "POST", Path: "/api/applications/:id/verify", Tag: "applications", Applicant: true, Summary: "Verify the applicant's email with the emailed code, completing an application pending verification",
+		RequestBody: models.VerifyRequest{}, Response: models.ApplicationStatusResponse{},
+		Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict}},
+	{Method: "GET", Path: "/api/applications/:id/interview-slots", Tag: "interviews", Applicant: true, Summary: "Free interview slots for a shortlisted application, and its booked interview",
+		Response: models.InterviewSlotsResponse{}, Errors: []int{http.StatusNotFound, http.StatusConflict}},
+	{Method: "POST", Path: "/api/applications/:id/schedule", Tag: "interviews", Applicant: true, Summary: "Book an interview slot, replacing any interview already booked",
+		RequestBody: models.ScheduleRequest{}, Response: models.Interview{},
+		Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict}},
+	{Method: "GET", Path: "/api/applications/:id/interview.ics", Tag: "interviews", Applicant: true, Summary: "The booked interview as an iCalendar event",
+		ContentType: "text/calendar", Errors: []int{http.StatusNotFound}},
+	{Method: "GET", Path: "/api/applications/:id/assignment", Tag: "assignments", Applicant: true, Summary: "Take-home assignment of a shortlisted application's job, with its deadline",
+		Response: models.AssignmentResponse{}, Errors: []int{http.StatusNotFound, http.StatusConflict}},
+	{Method: "POST", Path: "/api/applications/:id/assignment", Tag: "assignments", Applicant: true, Summary: "Submit the take-home assignment before its deadline, moving the application to assignment_submitted",
+		RequestBody: models.AssignmentSubmissionRequest{}, Response: models.AssignmentResponse{},
+		Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusGone}},
+	{Method: "PATCH", Path: "/api/applications/:id/status", Tag: "applications", Summary: "Update application status",
+		RequestBody: models.StatusUpdateRequest{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+	{Method: "DELETE", Path: "/api/applications/clear", Tag: "applications", Summary: "Clear all applications"},
+
+	// Application wizard
+	{Method: "POST", Path: "/api/applications/draft", Tag: "drafts", Applicant: true, Summary: "Start a draft application to a job, filled in step by step",
+		RequestBody: models.DraftRequest{}, Response: models.ApplicationDraft{}, Status: http.StatusCreated,
+		Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusGone}},
+	{Method: "GET", Path: "/api/applications/draft/:id", Tag: "drafts", Applicant: true, Summary: "Get a draft with what its steps filled in",
+		Response: models.ApplicationDraft{}, Errors: []int{http.StatusNotFound}},
+	{Method: "PATCH", Path: "/api/applications/draft/:id/:step", Tag: "drafts", Applicant: true, Summary: "Fill in the personal, resume or questions step, in that order; the body has the step's fields",
+		Response: models.ApplicationDraft{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity}},
+	{Method: "POST", Path: "/api/applications/draft/:id/review", Tag: "drafts", Applicant: true, Summary: "Check the whole draft the way submission would, completing the review step",
+		Response: models.ApplicationDraft{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusGone, http.StatusUnprocessableEntity}},
+	{Method: "POST", Path: "/api/applications/draft/:id/submit", Tag: "drafts", Applicant: true, Summary: "Submit a reviewed draft as an application (needs X-Challenge-Token under -challenge)",
+		Response: models.ApplicationResponse{}, Status: http.StatusCreated,
+		Errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusGone, http.StatusUnprocessableEntity, http.StatusPreconditionRequired, http.StatusTooManyRequests}},
+
+	// Anti-bot challenges (served only with -challenge)
+	{Method: "POST", Path: "/api/challenges", Tag: "challenges", Summary: "Issue a challenge to solve before submitting",
+		Response: models.Challenge{}, Status: http.StatusCreated},
+	{Method: "POST", Path: "/api/challenges/:id/solve", Tag: "challenges", Summary: "Solve a challenge for a submission token",
+		RequestBody: models.ChallengeSolution{}, Response: models.ChallengeToken{},
+		Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusGone, http.StatusUnprocessableEntity, http.StatusTooEarly}},
+
+	// Admin (served only with -admin-token)
+	{Method: "GET", Path: "/admin/failures", Tag: "admin", Admin: true, Summary: "Failure simulation settings",
+		Response: models.FailureSettings{}, Errors: []int{http.StatusUnauthorized}},
+	{Method: "PUT", Path: "/admin/failures", Tag: "admin", Admin: true, Summary: "Change failure simulation settings at runtime",
+		RequestBody: models.FailureSettingsUpdate{}, Response: models.FailureSettings{},
+		Errors: []int{http.StatusBadRequest, http.StatusUnauthorized}},
+	{Method: "POST", Path: "/admin/reset", Tag: "admin", Admin: true, Summary: "Clear applications and restore or replace the jobs",
+		RequestBody: models.ResetRequest{}, Response: models.ResetResponse{},
+		Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusUnprocessableEntity}},
+	{Method: "POST", Path: "/admin/snapshot", Tag: "admin", Admin: true, Summary: "Take a snapshot of every job and application to restore later",
+		Response: models.Snapshot{}, Errors: []int{http.StatusUnauthorized}},
+	{Method: "POST", Path: "/admin/restore", Tag: "admin", Admin: true, Summary: "Load the jobs and applications of a snapshot, clearing the rest as a reset does",
+		RequestBody: models.Snapshot{}, Response: models.RestoreResponse{},
+		Errors: []int{http.StatusBadRequest, http.StatusUnauthorized}},
+	{Method: "GET", Path: "/admin/maintenance", Tag: "admin", Admin: true, Summary: "The maintenance window in progress, if any",
+		Response: models.MaintenanceStatus{}, Errors: []int{http.StatusUnauthorized}},
+	{Method: "PUT", Path: "/admin/maintenance", Tag: "admin", Admin: true, Summary: "Answer every route, or those listed, with 503 for a while",
+		RequestBody: models.MaintenanceRequest{}, Response: models.MaintenanceStatus{},
+		Errors: []int{http.StatusBadRequest, http.StatusUnauthorized}},
+	{Method: "DELETE", Path: "/admin/maintenance", Tag: "admin", Admin: true, Summary: "End the maintenance window early",
+		Response: models.MaintenanceStatus{}, Errors: []int{http.StatusUnauthorized}},
+	{Method: "GET", Path: "/admin/brownout", Tag: "admin", Admin: true, Summary: "The brownout in progress, if any",
+		Response: models.BrownoutStatus{}, Errors: []int{http.StatusUnauthorized}},
+	{Method: "PUT", Path: "/admin/brownout", Tag: "admin", Admin: true, Summary: "Turn away a growing share of requests with 503 for a while",
+		RequestBody: models.BrownoutRequest{}, Response: models.BrownoutStatus{},
+		Errors: []int{http.StatusBadRequest, http.StatusUnauthorized}},
+	{Method: "DELETE", Path: "/admin/brownout", Tag: "admin", Admin: true, Summary: "End the brownout early",
+		Response: models.BrownoutStatus{}, Errors: []int{http.StatusUnauthorized}},
+	{Method: "POST", Path: "/admin/api-keys", Tag: "admin", Admin: true, Summary: "Mint an API key; its secret is only returned here",
+		RequestBody: models.APIKeyRequest{}, Response: models.APIKey{}, Status: http.StatusCreated,
+		Errors: []int{http.StatusBadRequest, http.StatusUnauthorized}},
+	{Method: "GET", Path: "/admin/api-keys", Tag: "admin", Admin: true, Summary: "List API keys with their usage",
+		Response: models.APIKeysResponse{}, Errors: []int{http.StatusUnauthorized}},
+	{Method: "GET", Path: "/admin/api-keys/:id", Tag: "admin", Admin: true, Summary: "Get an API key and its usage",
+		Response: models.APIKey{}, Errors: []int{http.StatusUnauthorized, http.StatusNotFound}},
+	{Method: "DELETE", Path: "/admin/api-keys/:id", Tag: "admin", Admin: true, Summary: "Revoke an API key, keeping its usage",
+		Response: models.APIKey{}, Errors: []int{http.StatusUnauthorized, http.StatusNotFound}},
+	{Method: "GET", Path: "/admin/recordings/:run_id", Tag: "admin", Admin: true, Summary: "Requests and responses of a run recorded with -record, as HAR or JSONL",
+		Query:    []Param{{Name: "format", Enum: []string{"har", "jsonl"}, Description: "har (default) for an HTTP Archive, or jsonl for one recording per line"}},
+		Response: models.HAR{}, Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound}},
+	{Method: "GET", Path: "/api/interviews.ics", Tag: "admin", Admin: true, Summary: "Every booked interview as one iCalendar file",
+		ContentType: "text/calendar", Errors: []int{http.StatusUnauthorized}},
+	{Method: "POST", Path: "/api/admin/jobs", Tag: "admin", Admin: true, Summary: "Create a job posting",
+		RequestBody: models.JobRequest{}, Response: models.Job{}, Status: http.StatusCreated,
+		Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusConflict, http.StatusUnprocessableEntity}},
+	{Method: "PUT", Path: "/api/admin/jobs/:id", Tag: "admin", Admin: true, Summary: "Replace a job posting",
+		RequestBody: models.JobRequest{}, Response: models.Job{},
+		Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound, http.StatusUnprocessableEntity}},
+	{Method: "POST", Path: "/api/admin/jobs/:id/close", Tag: "admin", Admin: true, Summary: "Close a job posting to applications",
+		Response: models.Job{}, Errors: []int{http.StatusUnauthorized, http.StatusNotFound}},
+	{Method: "PATCH", Path: "/api/admin/jobs/:id/status", Tag: "admin", Admin: true, Summary: "Move a job to another status: draft, open, paused, closed or filled",
+		RequestBody: models.JobStatusUpdateRequest{}, Response: models.Job{},
+		Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound, http.StatusConflict}},
+	{Method: "DELETE", Path: "/api/admin/jobs/:id", Tag: "admin", Admin: true, Summary: "Delete a job posting",
+		Status: http.StatusNoContent, Errors: []int{http.StatusUnauthorized, http.StatusNotFound}},
+
+	// Webhooks (served only with -admin-token)
+	{Method: "POST", Path: "/api/webhooks", Tag: "webhooks", Admin: true, Summary: "Register a status-change webhook",
+		RequestBody: models.WebhookRequest{}, Response: models.WebhookSecretResponse{}, Status: http.StatusCreated,
+		Errors: []int{http.StatusBadRequest, http.StatusUnauthorized}},
+	{Method: "GET", Path: "/api/webhooks", Tag: "webhooks", Admin: true, Summary: "List webhooks", Response: models.WebhooksListResponse{},
+		Errors: []int{http.StatusUnauthorized}},
+	{Method: "PATCH", Path: "/api/webhooks/:id", Tag: "webhooks", Admin: true, Summary: "Update a webhook or rotate its secret",
+		RequestBody: models.WebhookUpdateRequest{}, Response: models.WebhookSecretResponse{},
+		Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound}},
+	{Method: "DELETE", Path: "/api/webhooks/:id", Tag: "webhooks", Admin: true, Summary: "Delete a webhook",
+		Status: http.StatusNoContent, Errors: []int{http.StatusUnauthorized, http.StatusNotFound}},
+	{Method: "GET", Path: "/api/webhooks/:id/stats", Tag: "webhooks", Admin: true, Summary: "A webhook's delivery attempts, failures by class, retries and average latency",
+		Response: models.WebhookDeliveryStats{}, Errors: []int{http.StatusUnauthorized, http.StatusNotFound}},
+	{Method: "GET", Path: "/api/webhooks/:id/dead-letters", Tag: "webhooks", Admin: true, Summary: "Deliveries that failed every retry, oldest first",
+		Response: models.WebhookDeadLettersResponse{}, Errors: []int{http.StatusUnauthorized, http.StatusNotFound}},
+	{Method: "POST", Path: "/api/webhooks/:id/dead-letters/redeliver", Tag: "webhooks", Admin: true, Summary: "Deliver dead letters again, all of them unless ids are given",
+		RequestBody: models.WebhookRedeliverRequest{}, Response: models.WebhookRedeliverResponse{}, Status: http.StatusAccepted,
+		Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound}},
+
+	// Mailbox
+	{Method: "GET", Path: "/api/mailbox", Tag: "mailbox", Applicant: true, Summary: "Simulated emails sent to an applicant about their applications, newest first",
+		Response: models.MailboxResponse{}, Errors: []int{http.StatusBadRequest},
+		Query: []Param{{Name: "email", Description: "Applicant email address; required unless -auth=required, where it defaults to the token's address and must match it"}}},
+
+	// Saved jobs
+	{Method: "POST", Path: "/api/saved-jobs", Tag: "saved-jobs", Summary: "Save a job to apply to later; saving it again answers 200 and replaces its note",
+		RequestBody: models.SaveJobRequest{}, Response: models.SavedJob{}, Status: http.StatusCreated,
+		Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict}},
+	{Method: "GET", Path: "/api/saved-jobs", Tag: "saved-jobs", Applicant: true, Summary: "Jobs an applicant has saved, most recently saved first",
+		Response: models.SavedJobsResponse{}, Errors: []int{http.StatusBadRequest},
+		Query: []Param{{Name: "email", Description: "Applicant email address; required unless -auth=required, where it defaults to the token's address and must match it"}}},
+	{Method: "DELETE", Path: "/api/saved-jobs/:job_id", Tag: "saved-jobs", Summary: "Remove a job from an applicant's saved jobs",
+		Status: http.StatusNoContent, Errors: []int{http.StatusBadRequest, http.StatusNotFound},
+		Query: []Param{{Name: "email", Description: "Applicant email address", Required: true}}},
+
+	// Applicant accounts
+	{Method: "POST", Path: "/api/auth/register", Tag: "auth", Summary: "Register an applicant account and get a token for it",
+		RequestBody: models.CredentialsRequest{}, Response: models.TokenResponse{}, Status: http.StatusCreated,
+		Errors: []int{http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity}},
+	{Method: "POST", Path: "/api/auth/login", Tag: "auth", Summary: "Log in and get a fresh token to send as Authorization: Bearer <token>",
+		RequestBody: models.CredentialsRequest{}, Response: models.TokenResponse{},
+		Errors: []int{http.StatusBadRequest, http.StatusUnauthorized}},
+
+	// Mock OAuth2 provider
+	{Method: "GET", Path: "/oauth/authorize", Tag: "oauth", Summary: "Sign-in page of an authorization-code request", ContentType: "text/html",
+		Errors: []int{http.StatusBadRequest},
+		Query: []Param{
+			{Name: "response_type", Description: "Must be code", Required: true, Enum: []string{"code"}},
+			{Name: "client_id", Description: "The client ID the server was started with in -oauth-client-id", Required: true},
+			{Name: "redirect_uri", Description: "Absolute http or https URL the code is sent to", Required: true},
+			{Name: "state", Description: "Echoed back to redirect_uri"},
+			{Name: "code_challenge", Description: "PKCE challenge, the base64url SHA-256 of the code_verifier"},
+			{Name: "code_challenge_method", Description: "Must be S256 when code_challenge is sent", Enum: []string{"S256"}},
+		}},
+	{Method: "POST", Path: "/oauth/authorize", Tag: "oauth", Summary: "Sign in from the sign-in page; redirects to redirect_uri with a code or an error",
+		Status: http.StatusFound, Errors: []int{http.StatusBadRequest, http.StatusUnauthorized}},
+	{Method: "POST", Path: "/oauth/token", Tag: "oauth", Summary: "Exchange a code for an access token (form-encoded, RFC 6749 errors)",
+		Response: models.OAuthTokenResponse{}, Errors: []int{http.StatusBadRequest, http.StatusUnauthorized}},
+	{Method: "GET", Path: "/oauth/userinfo", Tag: "oauth", Summary: "The applicant an access token was issued to",
+		Response: models.UserInfo{}, Errors: []int{http.StatusUnauthorized}},
+
+	// Applicant profiles
+	{Method: "POST", Path: "/api/applicants", Tag: "applicants", Summary: "Create an applicant profile that applications can be submitted from with applicant_id",
+		RequestBody: models.ApplicantRequest{}, Response: models.Applicant{}, Status: http.StatusCreated,
+		Errors: []int{http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity}},
+	{Method: "GET", Path: "/api/applicants/:id", Tag: "applicants", Summary: "Get an applicant profile",
+		Response: models.Applicant{}, Errors: []int{http.StatusNotFound}},
+	{Method: "GET", Path: "/api/applicants/:id/applications", Tag: "applicants", Applicant: true, Summary: "Applications submitted from an applicant profile, with how many are in each status",
+		Response: models.ApplicantApplicationsResponse{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound},
+		Query: []Param{{Name: "order", Description: "Newest (default) or oldest submissions first", Enum: []string{"newest", "oldest"}}}},
+
+	// Runs
+	{Method: "POST", Path: "/api/runs", Tag: "runs", Summary: "Start a run; requests sending its ID in X-Run-ID are recorded",
+		RequestBody: models.RunRequest{}, Response: models.Run{}, Status: http.StatusCreated,
+		Errors: []int{http.StatusBadRequest}},
+	{Method: "GET", Path: "/api/runs/:id/report", Tag: "runs", Summary: "Evaluation report of a run",
+		Response: models.RunReport{}, Errors: []int{http.StatusNotFound}},
+	{Method: "GET", Path: "/api/runs/:id/export", Tag: "runs", Summary: "Export the applications a run submitted, or the requests it made, as CSV or JSONL",
+		ContentType: "text/csv", Errors: []int{http.StatusBadRequest, http.StatusNotFound},
+		Query: []Param{
+			{Name: "format", Enum: []string{"csv", "jsonl"}, Description: "csv (default) with a header row, or jsonl for one record per line"},
+			{Name: "data", Enum: []string{"applications", "requests"}, Description: "applications (default), flattened as in /api/applications/export, or the run's requests"},
+		}},
+	{Method: "PUT", Path: "/api/runs/:id/profile", Tag: "runs", Summary: "Upload the candidate profile the run's applications are checked for fabricated claims against",
+		RequestBody: models.CandidateProfile{}, Response: models.CandidateProfile{},
+		Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity}},
+	{Method: "POST", Path: "/api/runs/:id/complete", Tag: "runs", Summary: "Complete a run, which stops recording it and puts it on the leaderboard",
+		Response: models.Run{}, Errors: []int{http.StatusNotFound, http.StatusConflict}},
+	{Method: "GET", Path: "/api/leaderboard", Tag: "runs", Summary: "Completed runs ranked by score",
+		Response: models.LeaderboardResponse{}, Errors: []int{http.StatusBadRequest},
+		Query: []Param{
+			{Name: "include_active", Description: "Also rank runs not completed yet, for a live scoreboard", Enum: []string{"true", "false"}},
+			limitParam,
+		}},
+
+	// API keys
+	{Method: "GET", Path: "/api/usage", Tag: "api-keys", Summary: "Usage of the API key sent in X-API-Key",
+		Response: models.APIKey{}, Errors: []int{http.StatusUnauthorized}},
+
+	// Events
+	{Method: "GET", Path: "/api/events", Tag: "events", Applicant: true, Summary: "Stream job.created, application.submitted and application.status_changed events",
+		ContentType: "text/event-stream", Errors: []int{http.StatusBadRequest},
+		Query: []Param{{Name: "types", Description: "Comma-separated event types to receive; all of them when absent"}}},
+	{Method: "GET", Path: "/api/events/log", Tag: "events", Applicant: true, Summary: "Every change to the applications after a sequence number, from the append-only application log",
+		Response: models.ApplicationLogResponse{}, Errors: []int{http.StatusBadRequest},
+		Query: []Param{{Name: "since", Description: "Sequence number of the last entry seen; 0 (default) starts from the oldest kept"}, limitParam}},
+
+	// Stats
+	{Method: "GET", Path: "/api/meta/work-authorizations", Tag: "meta", Summary: "Accepted work authorization values, labels and synonyms"},
+	{Method: "GET", Path: "/api/stats", Tag: "stats", Summary: "Sandbox statistics", Response: models.StatsResponse{}},
+	{Method: "GET", Path: "/api/stats/review-latency", Tag: "stats", Summary: "Time to first status change",
+		Response: models.ReviewLatencyResponse{}, Errors: []int{http.StatusBadRequest},
+		Query: []Param{{Name: "by", Description: "Group results", Enum: []string{"company"}}}},
+	{Method: "GET", Path: "/api/stats/agents", Tag: "stats", Summary: "Requests, errors, rate limiting, polling and submissions of each agent sending X-Agent-ID",
+		Response: models.AgentStatsResponse{}},
+
+	// GraphQL
+	{Method: "POST", Path: "/graphql", Tag: "graphql", Applicant: true, Summary: "Execute a GraphQL query or mutation",
+		Errors: []int{http.StatusBadRequest}},
+	{Method: "GET", Path: "/graphql/schema", Tag: "graphql", Summary: "GraphQL schema (SDL)", ContentType: "text/plain"},
+	{Method: "GET", Path: "/graphql", Tag: "graphql", Summary: "GraphQL query console (requires -debug)", ContentType: "text/html"},
+
+	// MCP
+	{Method: "GET", Path: "/mcp/sse", Tag: "mcp", Applicant: true, Summary: "MCP event stream (requires -mcp=sse)", ContentType: "text/event-stream"},
+	{Method: "POST", Path: "/mcp/message", Tag: "mcp", Applicant: true, Summary: "Send an MCP JSON-RPC message to a session (requires -mcp=sse)",
+		Status: http.StatusAccepted, Errors: []int{http.StatusBadRequest, http.StatusNotFound},
+		Query: []Param{{Name: "session_id", Description: "Session ID announced on the event stream", Required: true}}},
+
+	// ATS emulation (requires -emulate)
+	{Method: "GET", Path: "/v1/boards/:token/jobs", Tag: "emulation", Summary: "Greenhouse: list a board's jobs",
+		Errors: []int{http.StatusNotFound},
+		Query:  []Param{{Name: "content", Description: "Include content, departments and offices", Enum: []string{"true"}}}},
+	{Method: "GET", Path: "/v1/boards/:token/jobs/:id", Tag: "emulation", Summary: "Greenhouse: get a job",
+		Errors: []int{http.StatusNotFound},
+		Query:  []Param{{Name: "questions", Description: "Include application form questions", Enum: []string{"true"}}}},
+	{Method: "POST", Path: "/v1/boards/:token/jobs/:id", Tag: "emulation", Applicant: true, Summary: "Greenhouse: submit an application (multipart form)",
+		Errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusTooManyRequests}},
+	{Method: "GET", Path: "/v0/postings/:site", Tag: "emulation", Summary: "Lever: list a site's postings",
+		Errors: []int{http.StatusNotFound},
+		Query: []Param{
+			{Name: "team", Description: "Filter by team"},
+			{Name: "location", Description: "Filter by location"},
+			{Name: "commitment", Description: "Filter by commitment", Enum: []string{"Full-time", "Part-time", "Intern", "Contract"}},
+			{Name: "skip", Type: "integer", Description: "Number of postings to skip"},
+			limitParam,
+		}},
+	{Method: "GET", Path: "/v0/postings/:site/:id", Tag: "emulation", Summary: "Lever: get a posting",
+		Errors: []int{http.StatusNotFound}},
+	{Method: "POST", Path: "/v0/postings/:site/:id/apply", Tag: "emulation", Applicant: true, Summary: "Lever: apply to a posting (multipart form)",
+		Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusGone, http.StatusTooManyRequests}},
+
+	// Frontend pages
+	{Method: "GET", Path: "/", Tag: "frontend", Summary: "Job listings page", ContentType: "text/html"},
+	{Method: "GET", Path: "/jobs", Tag: "frontend", Summary: "Job listings page", ContentType: "text/html"},
+	{Method: "GET", Path: "/jobs/:id", Tag: "frontend", Summary: "Job detail page", ContentType: "text/html"},
+	{Method: "GET", Path: "/jobs/:id/apply", Tag: "frontend", Summary: "Application form page", ContentType: "text/html"},
+	{Method: "POST", Path: "/jobs/:id/apply", Tag: "frontend", Summary: "Submit the application form; redirects to the success page, or shows the form again with the problems",
+		Status: http.StatusSeeOther, Errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusGone, http.StatusUnprocessableEntity}},
+	{Method: "GET", Path: "/jobs/:id/apply/:step", Tag: "frontend", Summary: "Application wizard step page: personal, resume, questions or review (requires -apply-wizard), or a page of the Workday-style flow (requires -workday-flow)", ContentType: "text/html"},
+	{Method: "POST", Path: "/jobs/:id/apply/:step", Tag: "frontend", Summary: "Save a wizard step or Workday-style page, or sign in from its account pages; redirects to the next one, or shows it again with the problems",
+		Status: http.StatusSeeOther, Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity}},
+	{Method: "POST", Path: "/jobs/:id/apply/review", Tag: "frontend", Summary: "Submit the application the wizard or the Workday-style flow filled in; redirects to the success page",
+		Status: http.StatusSeeOther, Errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusGone, http.StatusUnprocessableEntity}},
+	{Method: "POST", Path: "/jobs/:id/save", Tag: "frontend", Summary: "Save the job from its page; redirects to the saved jobs on the applications page",
+		Status: http.StatusSeeOther, Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict}},
+	{Method: "GET", Path: "/applications", Tag: "frontend", Summary: "Applications page", ContentType: "text/html"},
+	{Method: "GET", Path: "/applications/:id", Tag: "frontend", Summary: "Application detail page", ContentType: "text/html"},
+	{Method: "GET", Path: "/applications/:id/success", Tag: "frontend", Summary: "Application success page", ContentType: "text/html"},
+	{Method: "POST", Path: "/applications/:id/withdraw", Tag: "frontend", Summary: "Withdraw the application from its page; redirects back to it",
+		Status: http.StatusSeeOther, Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict}},
+	{Method: "GET", Path: "/my-applications", Tag: "frontend", Summary: "Applications page", ContentType: "text/html"},
+	{Method: "GET", Path: "/lookup", Tag: "frontend", Summary: "Look up an application", Status: http.StatusFound},
+	{Method: "GET", Path: "/oauth/callback", Tag: "frontend", Summary: "Finish signing in to the pages under -oauth-apply; redirects back to the application form",
+		Status: http.StatusSeeOther, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+}
@@ -0,0 +1,240 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schema is an OpenAPI 3.0 schema object
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaRegistry collects named component schemas while generating references
+type schemaRegistry struct {
+	components map[string]*Schema
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{components: make(map[string]*Schema)}
+}
+
+// schemaFor returns a schema for the value's type, registering named structs as components
+func (r *schemaRegistry) schemaFor(v interface{}) *Schema {
+	if v == nil {
+		return &Schema{Type: "object"}
+	}
+	return r.schemaForType(reflect.TypeOf(v))
+}
+
+func (r *schemaRegistry) schemaForType(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.Ptr:
+		s := r.schemaForType(t.Elem())
+		if s.Ref == "" {
+			s.Nullable = true
+		}
+		return s
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: r.schemaForType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schemaForType(t.Elem())}
+	case reflect.Struct:
+		if t == timeType {
+			return &Schema{Type: "string", Format: "date-time"}
+		}
+		if t.Name() == "" {
+			return r.structSchema(t)
+		}
+		if _, exists := r.components[t.Name()]; !exists {
+			// Reserve the name first so recursive types terminate
+			r.components[t.Name()] = &Schema{}
+			*r.components[t.Name()] = *r.structSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + t.Name()}
+	default:
+		return &Schema{}
+	}
+}
+
+// structSchema builds an object schema from a struct's json and binding tags
+func (r *schemaRegistry) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+	// Request structs declare required fields through binding tags, while
+	// response structs always emit fields that are not marked omitempty
+	isRequest := hasBindingTags(t)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, opts := parseJSONTag(field.Tag.Get("json"))
+		if name == "-" {
+			continue
+		}
+
+		// Embedded structs without a json name are flattened into the parent
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			embedded := r.structSchema(field.Type)
+			for prop, schema := range embedded.Properties {
+				s.Properties[prop] = schema
+			}
+			s.Required = append(s.Required, embedded.Required...)
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		required := isRequest && hasRule(field.Tag.Get("binding"), "required") ||
+			!isRequest && !strings.Contains(opts, "omitempty") && field.Type.Kind() != reflect.Ptr
+		if required {
+			s.Required = append(s.Required, name)
+		}
+
+		prop := r.schemaForType(field.Type)
+		if prop.Ref == "" {
+			prop.Description = field.Tag.Get("description")
+			applyBindingRules(prop, field.Tag.Get("binding"))
+		}
+		s.Properties[name] = prop
+	}
+
+	return s
+}
+
+// JSONSchema returns a self-contained JSON Schema for v's type, with named
+// structs inlined instead of referenced. It reads the same json, binding and
+// description tags as the OpenAPI document.
+func JSONSchema(v interface{}) *Schema {
+	r := newSchemaRegistry()
+	return r.inline(r.schemaFor(v), 0)
+}
+
+// maxInlineDepth stops inlining recursive types
+const maxInlineDepth = 8
+
+// inline replaces component references with copies of the components
+func (r *schemaRegistry) inline(s *Schema, depth int) *Schema {
+	if s == nil {
+		return nil
+	}
+	if s.Ref != "" {
+		if depth >= maxInlineDepth {
+			return &Schema{Type: "object"}
+		}
+		component := *r.components[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+		s = &component
+		depth++
+	}
+
+	// Optional inputs are omitted rather than null, and JSON Schema has no nullable
+	result := *s
+	result.Nullable = false
+	result.Items = r.inline(s.Items, depth)
+	result.AdditionalProperties = r.inline(s.AdditionalProperties, depth)
+	if s.Properties != nil {
+		result.Properties = make(map[string]*Schema, len(s.Properties))
+		for name, prop := range s.Properties {
+			result.Properties[name] = r.inline(prop, depth)
+		}
+	}
+	return &result
+}
+
+// parseJSONTag splits a json struct tag into its name and options
+func parseJSONTag(tag string) (string, string) {
+	if idx := strings.Index(tag, ","); idx != -1 {
+		return tag[:idx], tag[idx+1:]
+	}
+	return tag, ""
+}
+
+// applyBindingRules mirrors a field's validator rules (required, email,
+// oneof, min, max) as schema constraints, so documented inputs are
+// rejected exactly when the server would reject them
+func applyBindingRules(s *Schema, binding string) {
+	for _, rule := range strings.Split(binding, ",") {
+		name, value, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			// Required strings must also be non-empty
+			if s.Type == "string" && s.MinLength == nil {
+				one := 1
+				s.MinLength = &one
+			}
+		case "email":
+			s.Format = "email"
+		case "oneof":
+			s.Enum = strings.Fields(value)
+		case "min", "max":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			switch {
+			case s.Type == "string" && name == "min":
+				length := int(n)
+				s.MinLength = &length
+			case s.Type == "string":
+				length := int(n)
+				s.MaxLength = &length
+			case name == "min":
+				s.Minimum = &n
+			default:
+				s.Maximum = &n
+			}
+		}
+	}
+}
+
+// hasRule reports whether a binding tag contains the given rule
+func hasRule(binding, rule string) bool {
+	for _, r := range strings.Split(binding, ",") {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// hasBindingTags reports whether any field of the struct carries a binding tag
+func hasBindingTags(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if _, ok := t.Field(i).Tag.Lookup("binding"); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,298 @@
+package openapi
+
+import (
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// Param describes a query parameter of an operation
+type Param struct {
+	Name        string
+	Type        string // string, integer, boolean, number
+	Description string
+	Required    bool
+	Enum        []string
+}
+
+// Operation describes a single documented route
+type Operation struct {
+	Method      string
+	Path        string // Gin-style path, e.g. /api/jobs/:id
+	Tag         string
+	Summary     string
+	Query       []Param
+	RequestBody interface{} // Zero value of the request body model, nil if none
+	Response    interface{} // Zero value of the success response model, nil for a generic object
+	Status      int         // Success status code, defaults to 200
+	ContentType string      // Success content type, defaults to application/json
+	Errors      []int       // Documented error status codes
+	Admin       bool        // Requires the admin bearer token
+	Applicant   bool        // Requires an applicant token under -auth=required
+}
+
+// Document is an OpenAPI 3.0 document
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Paths      map[string]map[string]*PathItem `json:"paths"`
+	Components Components                      `json:"components"`
+}
+
+// Info is the OpenAPI info object
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Components holds reusable schemas and security schemes
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// PathItem is a single OpenAPI operation object
+type PathItem struct {
+	Tags        []string              `json:"tags,omitempty"`
+	Summary     string                `json:"summary"`
+	OperationID string                `json:"operationId"`
+	Parameters  []ParameterObject     `json:"parameters,omitempty"`
+	RequestBody *RequestBodyObject    `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+// SecurityScheme is an OpenAPI security scheme
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+// adminScheme names the security scheme of the admin endpoints
+const adminScheme = "adminToken"
+
+// applicantScheme names the security scheme of the endpoints applicant
+// tokens guard
+const applicantScheme = "applicantToken"
+
+// ParameterObject is an OpenAPI parameter
+type ParameterObject struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBodyObject is an OpenAPI request body
+type RequestBodyObject struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response is an OpenAPI response object
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType wraps a schema for a content type
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Build generates the OpenAPI document for the given operations
+func Build(info Info, operations []Operation) *Document {
+	registry := newSchemaRegistry()
+	errorSchema := registry.schemaFor(models.ErrorResponse{})
+	problemSchema := registry.schemaFor(models.ProblemDetails{})
+
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info:    info,
+		Paths:   make(map[string]map[string]*PathItem),
+	}
+
+	for _, op := range operations {
+		path, pathParams := convertPath(op.Path)
+
+		item := &PathItem{
+			Summary:     op.Summary,
+			OperationID: operationID(op.Method, op.Path),
+			Responses:   make(map[string]*Response),
+		}
+		if op.Tag != "" {
+			item.Tags = []string{op.Tag}
+		}
+		if doc.Components.SecuritySchemes == nil && (op.Admin || op.Applicant) {
+			doc.Components.SecuritySchemes = make(map[string]*SecurityScheme)
+		}
+		if op.Admin {
+			item.Security = []map[string][]string{{adminScheme: {}}}
+			doc.Components.SecuritySchemes[adminScheme] = &SecurityScheme{
+				Type:        "http",
+				Scheme:      "bearer",
+				Description: "The token the server was started with in -admin-token",
+			}
+		}
+		errors := op.Errors
+		if op.Applicant {
+			// Tokens are only required under -auth=required, so anonymous
+			// requests are allowed as well
+			item.Security = []map[string][]string{{applicantScheme: {}}, {}}
+			doc.Components.SecuritySchemes[applicantScheme] = &SecurityScheme{
+				Type:         "http",
+				Scheme:       "bearer",
+				BearerFormat: "JWT",
+				Description:  "A token from POST /api/auth/login, required when the server runs with -auth=required",
+			}
+			errors = append(slices.Clone(errors), http.StatusUnauthorized, http.StatusForbidden)
+		}
+
+		for _, name := range pathParams {
+			item.Parameters = append(item.Parameters, ParameterObject{
+				Name:     name,
+				In:       "path",
+				Required: true,
+				Schema:   &Schema{Type: "string"},
+			})
+		}
+		for _, p := range op.Query {
+			paramType := p.Type
+			if paramType == "" {
+				paramType = "string"
+			}
+			item.Parameters = append(item.Parameters, ParameterObject{
+				Name:        p.Name,
+				In:          "query",
+				Description: p.Description,
+				Required:    p.Required,
+				Schema:      &Schema{Type: paramType, Enum: p.Enum},
+			})
+		}
+
+		if op.RequestBody != nil {
+			item.RequestBody = &RequestBodyObject{
+				Required: true,
+				Content: map[string]MediaType{
+					"application/json": {Schema: registry.schemaFor(op.RequestBody)},
+				},
+			}
+		}
+
+		status := op.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		contentType := op.ContentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+
+		success := &Response{Description: http.StatusText(status)}
+		if status != http.StatusNoContent && (status < 300 || status >= 400) {
+			schema := &Schema{Type: "string"}
+			if contentType == "application/json" {
+				schema = registry.schemaFor(op.Response)
+			}
+			success.Content = map[string]MediaType{contentType: {Schema: schema}}
+		}
+		item.Responses[strconv.Itoa(status)] = success
+
+		for _, code := range errors {
+			item.Responses[strconv.Itoa(code)] = &Response{
+				Description: http.StatusText(code),
+				Content: map[string]MediaType{
+					"application/json":         {Schema: errorSchema},
+					"application/problem+json": {Schema: problemSchema},
+				},
+			}
+		}
+
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(map[string]*PathItem)
+		}
+		doc.Paths[path][strings.ToLower(op.Method)] = item
+	}
+
+	doc.Components.Schemas = registry.components
+	return doc
+}
+
+// MissingRoutes returns the registered routes that have no documented operation.
+// HEAD and OPTIONS routes are ignored.
+func MissingRoutes(routes gin.RoutesInfo, operations []Operation) []string {
+	documented := make(map[string]bool, len(operations))
+	for _, op := range operations {
+		documented[op.Method+" "+op.Path] = true
+	}
+
+	var missing []string
+	for _, route := range routes {
+		if route.Method == http.MethodHead || route.Method == http.MethodOptions {
+			continue
+		}
+		key := route.Method + " " + route.Path
+		if !documented[key] {
+			missing = append(missing, key)
+		}
+	}
+
+	sort.Strings(missing)
+	return missing
+}
+
+// StaleOperations returns the documented operations that no registered
+// route serves, which the router only registers under the right flags
+func StaleOperations(routes gin.RoutesInfo, operations []Operation) []string {
+	registered := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		registered[route.Method+" "+route.Path] = true
+	}
+
+	var stale []string
+	for _, op := range operations {
+		key := op.Method + " " + op.Path
+		if !registered[key] {
+			stale = append(stale, key)
+		}
+	}
+
+	sort.Strings(stale)
+	return stale
+}
+
+// convertPath turns a Gin path into an OpenAPI path and its parameter names
+func convertPath(path string) (string, []string) {
+	segments := strings.Split(path, "/")
+	var params []string
+	for i, seg := range segments {
+		if strings.HasPrefix(seg, ":") || strings.HasPrefix(seg, "*") {
+			name := seg[1:]
+			params = append(params, name)
+			segments[i] = "{" + name + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// operationID derives a stable operation ID from the method and path
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, seg := range strings.Split(path, "/") {
+		seg = strings.TrimLeft(seg, ":*")
+		for _, part := range strings.FieldsFunc(seg, func(r rune) bool { return r == '-' || r == '_' || r == '.' }) {
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,110 @@
+// Package phone validates free-form phone numbers and normalizes them to
+// E.164. It understands the punctuation people commonly type (spaces,
+// dashes, dots, parentheses), a leading +country or 00 international
+// prefix, and a national trunk 0, but knows nothing about per-country
+// numbering plans beyond the overall digit counts E.164 allows.
+package phone
+
+import (
+	"errors"
+	"strings"
+)
+
+// DefaultCountryCode is the calling code assumed for numbers written
+// without a country prefix
+const DefaultCountryCode = "1"
+
+const (
+	// minDigits is the fewest digits a subscriber number may have
+	minDigits = 7
+	// maxDigits is the most digits an E.164 number may have, country code included
+	maxDigits = 15
+)
+
+// Errors returned by Normalize
+var (
+	ErrInvalidCharacters = errors.New("phone number may only contain digits, spaces, dashes, dots, parentheses and a leading +")
+	ErrTooShort          = errors.New("phone number has too few digits")
+	ErrTooLong           = errors.New("phone number has too many digits")
+	ErrNoCountryCode     = errors.New("phone number must start with a country code after +")
+)
+
+// Normalize validates raw and returns it in E.164 form (e.g. +14155550100).
+// Numbers with a + or 00 prefix keep the country they name; other numbers
+// are assumed to belong to countryCode, dropping a leading trunk 0. When
+// countryCode is empty the country cannot be inferred, so a valid national
+// number normalizes to "".
+func Normalize(raw, countryCode string) (string, error) {
+	digits, international, err := parse(raw)
+	if err != nil {
+		return "", err
+	}
+
+	if international {
+		return e164(digits, minDigits+1)
+	}
+
+	if countryCode == "" {
+		if len(digits) < minDigits {
+			return "", ErrTooShort
+		}
+		if len(digits) > maxDigits {
+			return "", ErrTooLong
+		}
+		return "", nil
+	}
+
+	switch {
+	case strings.HasPrefix(digits, "0"):
+		// National trunk prefix, as in 020 7946 0958
+		digits = digits[1:]
+	case countryCode == "1" && len(digits) == 11 && digits[0] == '1':
+		// North American long-distance 1, as in 1-415-555-0100
+		digits = digits[1:]
+	}
+	return e164(countryCode+digits, len(countryCode)+minDigits)
+}
+
+// parse strips the formatting from raw, returning its digits and whether it
+// carried an international prefix
+func parse(raw string) (string, bool, error) {
+	value := strings.TrimSpace(raw)
+	international := false
+	if rest, ok := strings.CutPrefix(value, "+"); ok {
+		value, international = rest, true
+	}
+
+	var digits strings.Builder
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", false, ErrInvalidCharacters
+		}
+	}
+
+	number := digits.String()
+	if !international {
+		if rest, ok := strings.CutPrefix(number, "00"); ok {
+			number, international = rest, true
+		}
+	}
+	return number, international, nil
+}
+
+// e164 checks the length of a full number (country code included) and
+// formats it
+func e164(digits string, least int) (string, error) {
+	if strings.HasPrefix(digits, "0") {
+		return "", ErrNoCountryCode
+	}
+	if len(digits) < least {
+		return "", ErrTooShort
+	}
+	if len(digits) > maxDigits {
+		return "", ErrTooLong
+	}
+	return "+" + digits, nil
+}
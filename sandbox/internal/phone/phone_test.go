@@ -0,0 +1,60 @@
+package phone
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		raw         string
+		countryCode string
+		want        string
+		err         error
+	}{
+		// National numbers in the default country
+		{"(415) 555-0100", "1", "+14155550100", nil},
+		{"415-555-0100", "1", "+14155550100", nil},
+		{"415.555.0100", "1", "+14155550100", nil},
+		{"415 555 0100", "1", "+14155550100", nil},
+		{"4155550100", "1", "+14155550100", nil},
+		{"\t415 555 0100\n", "1", "+14155550100", nil},
+		{"1-415-555-0100", "1", "+14155550100", nil},
+		{"1 (415) 555-0100", "1", "+14155550100", nil},
+		{"555-0100", "1", "+15550100", nil},
+		{"020 7946 0958", "44", "+442079460958", nil},
+		{"030 123456", "49", "+4930123456", nil},
+
+		// International numbers keep their country
+		{"+1 415 555 0100", "1", "+14155550100", nil},
+		{"+44 20 7946 0958", "1", "+442079460958", nil},
+		{"+ 44 20 7946 0958", "1", "+442079460958", nil},
+		{"0044 20 7946 0958", "1", "+442079460958", nil},
+		{"+91 98765 43210", "1", "+919876543210", nil},
+		{"+81-3-1234-5678", "1", "+81312345678", nil},
+		{"+86 (10) 1234.5678", "1", "+861012345678", nil},
+
+		// Invalid numbers
+		{"", "1", "", ErrTooShort},
+		{"555-010", "1", "", ErrTooShort},
+		{"+1 555", "1", "", ErrTooShort},
+		{"+1 234 567 8901 2345 6", "1", "", ErrTooLong},
+		{"415-555-CALL", "1", "", ErrInvalidCharacters},
+		{"415 555 0100 ext 12", "1", "", ErrInvalidCharacters},
+		{"415/555/0100", "1", "", ErrInvalidCharacters},
+		{"++1 415 555 0100", "1", "", ErrInvalidCharacters},
+		{"４１５５５５０１００", "1", "", ErrInvalidCharacters},
+		{"+0 415 555 0100", "1", "", ErrNoCountryCode},
+		{"+00 44 20 7946 0958", "1", "", ErrNoCountryCode},
+
+		// Without a default country, national numbers are checked but not
+		// normalized
+		{"(415) 555-0100", "", "", nil},
+		{"+44 20 7946 0958", "", "+442079460958", nil},
+		{"555-010", "", "", ErrTooShort},
+		{"1234567890123456", "", "", ErrTooLong},
+	}
+	for _, tt := range tests {
+		got, err := Normalize(tt.raw, tt.countryCode)
+		if got != tt.want || err != tt.err {
+			t.Errorf("Normalize(%q, %q) = %q, %v, want %q, %v", tt.raw, tt.countryCode, got, err, tt.want, tt.err)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Package random is where the sandbox gets its randomness. By default every
+// run differs; after Seed, failure simulation, status progression, IDs and
+// webhook secrets all come out the same on every run given the same requests
+// in the same order.
+package random
+
+import (
+	"crypto/rand"
+	"hash/fnv"
+	"io"
+	mathrand "math/rand"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	mu     sync.Mutex
+	seeded bool
+	seed   int64
+	reader io.Reader = rand.Reader
+)
+
+// Seed makes all randomness from here on reproducible. It must be called
+// before the stores and middleware are created.
+func Seed(value int64) {
+	mu.Lock()
+	defer mu.Unlock()
+	seeded = true
+	seed = value
+	reader = &lockedReader{rng: mathrand.New(mathrand.NewSource(value))}
+	uuid.SetRand(reader)
+}
+
+// New returns a random number generator for one consumer, such as the
+// failure simulator. Once seeded, each name gets its own fixed sequence, so
+// consumers do not disturb one another's draws.
+func New(name string) *mathrand.Rand {
+	mu.Lock()
+	defer mu.Unlock()
+	if !seeded {
+		return mathrand.New(mathrand.NewSource(time.Now().UnixNano()))
+	}
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return mathrand.New(mathrand.NewSource(seed ^ int64(h.Sum64())))
+}
+
+// Read fills buf with random bytes: cryptographically secure unless seeded
+func Read(buf []byte) error {
+	mu.Lock()
+	r := reader
+	mu.Unlock()
+	_, err := io.ReadFull(r, buf)
+	return err
+}
+
+// lockedReader shares one seeded generator between goroutines
+type lockedReader struct {
+	mu  sync.Mutex
+	rng *mathrand.Rand
+}
+
+func (r *lockedReader) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rng.Read(p)
+}
@@ -0,0 +1,50 @@
+package respond
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotModified sets Last-Modified, a weak ETag and Cache-Control: no-cache for
+// a record last changed at modified, and answers 304 Not Modified when the
+// request's validators show the client already has this version. It returns
+// true when the 304 was written and the handler should stop.
+//
+// Last-Modified only has one-second resolution, so modified must move on by
+// at least a second on every change for If-Modified-Since to see it; the
+// stores guarantee that. The ETag is derived from the full timestamp.
+// If-None-Match takes precedence over If-Modified-Since.
+func NotModified(c *gin.Context, modified time.Time) bool {
+	etag := `W/"` + strconv.FormatInt(modified.UnixNano(), 36) + `"`
+
+	c.Header("Last-Modified", modified.UTC().Format(http.TimeFormat))
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "no-cache")
+
+	if match := c.GetHeader("If-None-Match"); match != "" {
+		if !etagMatches(match, etag) {
+			return false
+		}
+	} else if since, err := http.ParseTime(c.GetHeader("If-Modified-Since")); err != nil ||
+		modified.Truncate(time.Second).After(since) {
+		return false
+	}
+
+	c.AbortWithStatus(http.StatusNotModified)
+	return true
+}
+
+// etagMatches reports whether an If-None-Match header lists etag or "*"
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
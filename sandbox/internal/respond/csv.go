@@ -0,0 +1,167 @@
+package respond
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FormatCSV is the media type for tabular list responses
+const FormatCSV = "text/csv"
+
+// csvFlushEvery is how many rows are written between flushes to the client
+const csvFlushEvery = 100
+
+// csvListSeparator joins flattened slice and map values inside a single cell
+const csvListSeparator = "; "
+
+// List writes a list response in the negotiated format. JSON and XML render
+// the envelope unchanged; CSV renders rows, which must be a slice of structs,
+// and JSON:API renders them as a paginated collection of resource objects.
+// A ?format=json|xml|csv query parameter takes precedence over the Accept header.
+func List(c *gin.Context, status int, envelope interface{}, rows interface{}) {
+	offers := []string{FormatJSON, FormatXML, FormatCSV}
+	if isJSONAPIResource(reflect.TypeOf(rows)) {
+		offers = append(offers, FormatJSONAPI)
+	}
+
+	format := ""
+	switch c.Query("format") {
+	case "":
+		format = Negotiate(c, offers...)
+	case "json":
+		format = FormatJSON
+	case "xml":
+		format = FormatXML
+	case "csv":
+		format = FormatCSV
+	default:
+		Error(c, http.StatusBadRequest, "invalid_format", "Invalid value for 'format'. Valid values: json, xml, csv")
+		return
+	}
+
+	switch format {
+	case FormatJSON:
+		c.JSON(status, envelope)
+	case FormatXML:
+		c.XML(status, envelope)
+	case FormatCSV:
+		CSV(c, status, rows)
+	case FormatJSONAPI:
+		JSONAPIList(c, status, rows)
+	default:
+		NotAcceptable(c, offers...)
+	}
+}
+
+// CSV streams rows as RFC 4180 CSV. Columns follow the struct field
+// declaration order and are named after the json tags; fields tagged
+// json:"-" are skipped. Slices are joined with "; ", maps are written
+// as "key=value" pairs sorted by key and joined with "; ", times use
+// RFC 3339, and nil pointers are empty cells.
+func CSV(c *gin.Context, status int, rows interface{}) {
+	value := reflect.ValueOf(rows)
+	elemType := value.Type().Elem()
+	for elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+
+	columns := csvColumns(elemType)
+
+	c.Header("Content-Type", FormatCSV+"; charset=utf-8")
+	c.Status(status)
+
+	w := csv.NewWriter(c.Writer)
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.name
+	}
+	w.Write(header)
+
+	for i := 0; i < value.Len(); i++ {
+		row := reflect.Indirect(value.Index(i))
+		record := make([]string, len(columns))
+		for j, col := range columns {
+			record[j] = csvCell(row.FieldByIndex(col.index))
+		}
+		w.Write(record)
+
+		if (i+1)%csvFlushEvery == 0 {
+			w.Flush()
+			c.Writer.Flush()
+			// Stop writing to the dead connection
+			if Gone(c) {
+				return
+			}
+		}
+	}
+
+	w.Flush()
+}
+
+// csvColumn is a flattened struct field
+type csvColumn struct {
+	name  string
+	index []int
+}
+
+// csvColumns lists the exported, json-visible fields of a struct type in order
+func csvColumns(t reflect.Type) []csvColumn {
+	var columns []csvColumn
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		columns = append(columns, csvColumn{name: name, index: field.Index})
+	}
+	return columns
+}
+
+// csvCell formats a single field value as a CSV cell
+func csvCell(v reflect.Value) string {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	if t, ok := v.Interface().(time.Time); ok {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = csvCell(v.Index(i))
+		}
+		return strings.Join(parts, csvListSeparator)
+	case reflect.Map:
+		parts := make([]string, 0, v.Len())
+		for _, key := range v.MapKeys() {
+			parts = append(parts, fmt.Sprint(key.Interface())+"="+csvCell(v.MapIndex(key)))
+		}
+		sort.Strings(parts)
+		return strings.Join(parts, csvListSeparator)
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
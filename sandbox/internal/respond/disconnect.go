@@ -0,0 +1,77 @@
+package respond
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClientDisconnectedKey is the context key set when the client went away
+// before the response was complete
+const ClientDisconnectedKey = "client_disconnected"
+
+// StatusClientClosedRequest is the status logged for requests the client
+// abandoned (nginx's 499). It is never sent, as nobody is left to read it.
+const StatusClientClosedRequest = 499
+
+// disconnects counts requests that ended with the client gone
+var disconnects atomic.Int64
+
+// Disconnected records that the client went away and stops the handler
+// chain without writing anything more to the connection
+func Disconnected(c *gin.Context) {
+	c.Set(ClientDisconnectedKey, true)
+	disconnects.Add(1)
+	c.Abort()
+}
+
+// Disconnects returns how many requests have ended with the client gone
+func Disconnects() int64 {
+	return disconnects.Load()
+}
+
+// ResetDisconnects starts counting client disconnects from zero again
+func ResetDisconnects() {
+	disconnects.Store(0)
+}
+
+// Gone reports whether the client has gone away, recording the
+// disconnection when it has
+func Gone(c *gin.Context) bool {
+	if c.Request.Context().Err() == nil {
+		return false
+	}
+	Disconnected(c)
+	return true
+}
+
+// Sleep waits for d. It returns false as soon as the client goes away,
+// after recording the disconnection, so the caller can stop at once. The
+// request body is buffered first, since the server only watches the
+// connection for a disconnect once the body has been read.
+func Sleep(c *gin.Context, d time.Duration) bool {
+	if c.Request.Body != nil && c.Request.Body != http.NoBody {
+		body, err := io.ReadAll(c.Request.Body)
+		c.Request.Body.Close()
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			Disconnected(c)
+			return false
+		}
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-c.Request.Context().Done():
+		Disconnected(c)
+		return false
+	}
+}
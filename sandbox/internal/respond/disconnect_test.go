@@ -0,0 +1,71 @@
+package respond
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// cancellableContext returns a context for a POST of body whose request
+// context cancel ends, as a client going away does
+func cancellableContext(body string) (*gin.Context, context.CancelFunc) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx, cancel := context.WithCancel(context.Background())
+	c.Request = httptest.NewRequest("POST", "/api/applications", strings.NewReader(body)).WithContext(ctx)
+	return c, cancel
+}
+
+func TestSleep(t *testing.T) {
+	c, cancel := cancellableContext("")
+	defer cancel()
+	before := Disconnects()
+	if !Sleep(c, 10*time.Millisecond) {
+		t.Fatal("Sleep reported a disconnect with the client still there")
+	}
+	if c.IsAborted() || c.GetBool(ClientDisconnectedKey) || Disconnects() != before {
+		t.Error("a completed Sleep recorded a disconnect")
+	}
+}
+
+// TestSleepReturnsWhenClientGoes checks a long Sleep ends soon after the
+// client goes away, recording the disconnect and keeping the body readable
+func TestSleepReturnsWhenClientGoes(t *testing.T) {
+	c, cancel := cancellableContext(`{"job_id":"job_1"}`)
+	before := Disconnects()
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	if Sleep(c, 10*time.Second) {
+		t.Fatal("Sleep slept through the disconnect")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Sleep returned %v after the client went, want within a second", elapsed)
+	}
+	if !c.IsAborted() || !c.GetBool(ClientDisconnectedKey) {
+		t.Error("the request was not aborted and marked disconnected")
+	}
+	if got := Disconnects() - before; got != 1 {
+		t.Errorf("%d disconnects recorded, want 1", got)
+	}
+	if body, _ := io.ReadAll(c.Request.Body); string(body) != `{"job_id":"job_1"}` {
+		t.Errorf("body %q after Sleep, want it buffered intact", body)
+	}
+}
+
+func TestGone(t *testing.T) {
+	c, cancel := cancellableContext("")
+	before := Disconnects()
+	if Gone(c) {
+		t.Fatal("Gone with the client still there")
+	}
+	cancel()
+	if !Gone(c) || !c.IsAborted() || Disconnects()-before != 1 {
+		t.Errorf("Gone after the client went: aborted %v, %d disconnects recorded", c.IsAborted(), Disconnects()-before)
+	}
+}
@@ -0,0 +1,133 @@
+package respond
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/emulate"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/i18n"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// LanguageKey is the context key holding the negotiated response language
+const LanguageKey = "language"
+
+// ProblemJSONKey is the context key that enables problem+json errors for every request
+const ProblemJSONKey = "problem_json"
+
+// StrictBindingKey is the context key that makes request bodies with unknown
+// fields fail to bind
+const StrictBindingKey = "strict_binding"
+
+// ATSProfileKey is the context key holding the ATS whose error body every
+// error is written in, if any
+const ATSProfileKey = "ats_profile"
+
+// ErrorCodeKey is the context key holding the code of the error response
+// written for the request, if any
+const ErrorCodeKey = "error_code"
+
+// ProblemContentType is the RFC 7807 media type for problem documents
+const ProblemContentType = "application/problem+json"
+
+// problemTypePrefix namespaces the problem type URIs by error code
+const problemTypePrefix = "urn:job-portal-sandbox:error:"
+
+// Error writes an error response and aborts the handler chain.
+// The response is a problem document when the client accepts
+// application/problem+json or problem mode is enabled globally,
+// a JSON:API errors document when JSON:API was negotiated,
+// and the flat ErrorResponse otherwise. Under an ATS profile it is that
+// ATS's error body instead, whatever the client negotiated. The message is translated into the
+// negotiated language; the code never is.
+func Error(c *gin.Context, status int, code, message string) {
+	Violations(c, status, code, message, nil)
+}
+
+// Violations writes an error response like Error, listing the field-level
+// violations that caused it. JSON:API clients get one error object per
+// violation, with the field as its source pointer.
+func Violations(c *gin.Context, status int, code, message string, violations []models.Violation) {
+	c.Set(ErrorCodeKey, code)
+	lang := Language(c)
+	message = i18n.T(lang, message)
+	if len(violations) > 0 {
+		translated := make([]models.Violation, len(violations))
+		for i, v := range violations {
+			v.Message = i18n.T(lang, v.Message)
+			translated[i] = v
+		}
+		violations = translated
+	}
+
+	switch c.GetString(ATSProfileKey) {
+	case emulate.ProfileGreenhouse:
+		c.AbortWithStatusJSON(status, emulate.GreenhouseError{Status: status, Error: atsMessage(message, violations)})
+		return
+	case emulate.ProfileLever:
+		c.AbortWithStatusJSON(status, emulate.LeverError{OK: false, Error: atsMessage(message, violations)})
+		return
+	}
+
+	if !wantsProblem(c) && IsJSONAPI(c) {
+		jsonapiErrorDocument(c, status, code, message, violations)
+		return
+	}
+
+	if !wantsProblem(c) {
+		c.AbortWithStatusJSON(status, models.ErrorResponse{
+			Error:      code,
+			Message:    message,
+			Code:       status,
+			Violations: violations,
+			Errors:     violations,
+		})
+		return
+	}
+
+	problem := models.ProblemDetails{
+		Type:       problemTypePrefix + code,
+		Title:      http.StatusText(status),
+		Status:     status,
+		Detail:     message,
+		Instance:   c.Request.URL.Path,
+		Error:      code,
+		RequestID:  c.GetString("request_id"),
+		Violations: violations,
+		Errors:     violations,
+	}
+
+	// Gin keeps an explicitly set Content-Type when rendering JSON
+	c.Header("Content-Type", ProblemContentType)
+	c.AbortWithStatusJSON(status, problem)
+}
+
+// atsMessage folds violations into an error message, since ATS error bodies
+// only carry a message
+func atsMessage(message string, violations []models.Violation) string {
+	if len(violations) == 0 {
+		return message
+	}
+	details := make([]string, len(violations))
+	for i, v := range violations {
+		details[i] = v.Field + ": " + v.Message
+	}
+	return message + " (" + strings.Join(details, "; ") + ")"
+}
+
+// Language returns the response language negotiated for the request
+func Language(c *gin.Context) string {
+	if lang := c.GetString(LanguageKey); lang != "" {
+		return lang
+	}
+	return i18n.Default
+}
+
+// wantsProblem reports whether the error should be written as a problem document
+func wantsProblem(c *gin.Context) bool {
+	if c.GetBool(ProblemJSONKey) {
+		return true
+	}
+	return strings.Contains(c.GetHeader("Accept"), ProblemContentType)
+}
@@ -0,0 +1,316 @@
+package respond
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// FormatJSONAPI is the JSON:API media type
+const FormatJSONAPI = "application/vnd.api+json"
+
+// Page sizes for JSON:API list responses
+const (
+	jsonapiDefaultPageSize = 20
+	jsonapiMaxPageSize     = 100
+)
+
+// jsonapiVersion is advertised in every JSON:API document
+var jsonapiVersion = map[string]string{"version": "1.1"}
+
+// jsonapiDocument is a top-level JSON:API document
+type jsonapiDocument struct {
+	JSONAPI map[string]string      `json:"jsonapi"`
+	Data    interface{}            `json:"data,omitempty"`
+	Errors  []jsonapiError         `json:"errors,omitempty"`
+	Links   map[string]string      `json:"links,omitempty"`
+	Meta    map[string]interface{} `json:"meta,omitempty"`
+}
+
+// jsonapiResource is a resource object
+type jsonapiResource struct {
+	Type          string                         `json:"type"`
+	ID            string                         `json:"id"`
+	Attributes    map[string]interface{}         `json:"attributes"`
+	Relationships map[string]jsonapiRelationship `json:"relationships,omitempty"`
+	Links         map[string]string              `json:"links"`
+}
+
+// jsonapiRelationship is a to-one relationship with resource linkage
+type jsonapiRelationship struct {
+	Data  jsonapiIdentifier `json:"data"`
+	Links map[string]string `json:"links"`
+}
+
+// jsonapiIdentifier identifies a related resource
+type jsonapiIdentifier struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// jsonapiError is an error object
+type jsonapiError struct {
+	Status string              `json:"status"`
+	Code   string              `json:"code"`
+	Title  string              `json:"title"`
+	Detail string              `json:"detail,omitempty"`
+	Source *jsonapiErrorSource `json:"source,omitempty"`
+}
+
+// jsonapiErrorSource points at the request body member or query parameter
+// an error applies to
+type jsonapiErrorSource struct {
+	Pointer   string `json:"pointer,omitempty"`
+	Parameter string `json:"parameter,omitempty"`
+}
+
+// IsJSONAPI reports whether the client negotiated JSON:API over plain JSON
+func IsJSONAPI(c *gin.Context) bool {
+	return Negotiate(c, FormatJSON, FormatJSONAPI) == FormatJSONAPI
+}
+
+// Filter reads a filtering query parameter. When JSON:API is negotiated the
+// parameter follows the JSON:API convention and is read from filter[name].
+func Filter(c *gin.Context, name string) string {
+	if IsJSONAPI(c) {
+		return c.Query("filter[" + name + "]")
+	}
+	return c.Query(name)
+}
+
+// JSONAPIResource writes a single resource document. data is either a model
+// with a field tagged jsonapi:"primary,<type>", or an envelope with a field
+// tagged jsonapi:"resource" whose other fields are written as meta.
+func JSONAPIResource(c *gin.Context, status int, data interface{}) {
+	value := reflect.Indirect(reflect.ValueOf(data))
+	doc := jsonapiDocument{JSONAPI: jsonapiVersion}
+
+	if field, ok := resourceField(value.Type()); ok {
+		doc.Data = resourceObject(value.Field(field))
+		doc.Meta = make(map[string]interface{})
+		for i := 0; i < value.NumField(); i++ {
+			if name, ok := jsonName(value.Type().Field(i)); ok && i != field && !omitted(value.Type().Field(i), value.Field(i)) {
+				doc.Meta[name] = value.Field(i).Interface()
+			}
+		}
+	} else {
+		doc.Data = resourceObject(value)
+	}
+
+	c.Header("Content-Type", FormatJSONAPI)
+	c.JSON(status, doc)
+}
+
+// JSONAPIList writes rows, which must be a slice of resource models, as a
+// paginated collection document. Pages are selected with page[number]
+// (1-based) and page[size], and the links cover first, last, prev and next.
+func JSONAPIList(c *gin.Context, status int, rows interface{}) {
+	number, size, ok := jsonapiPage(c)
+	if !ok {
+		Error(c, http.StatusBadRequest, "invalid_page",
+			"page[number] must be a positive integer and page[size] must be between 1 and "+strconv.Itoa(jsonapiMaxPageSize)+".")
+		return
+	}
+
+	value := reflect.ValueOf(rows)
+	total := value.Len()
+	lastPage := (total + size - 1) / size
+	if lastPage == 0 {
+		lastPage = 1
+	}
+
+	start := (number - 1) * size
+	if start > total {
+		start = total
+	}
+	end := start + size
+	if end > total {
+		end = total
+	}
+
+	data := make([]jsonapiResource, 0, end-start)
+	for i := start; i < end; i++ {
+		data = append(data, resourceObject(reflect.Indirect(value.Index(i))))
+	}
+
+	links := map[string]string{
+		"self":  pageLink(c, number, size),
+		"first": pageLink(c, 1, size),
+		"last":  pageLink(c, lastPage, size),
+	}
+	if number > 1 {
+		links["prev"] = pageLink(c, number-1, size)
+	}
+	if number < lastPage {
+		links["next"] = pageLink(c, number+1, size)
+	}
+
+	c.Header("Content-Type", FormatJSONAPI)
+	c.JSON(status, jsonapiDocument{
+		JSONAPI: jsonapiVersion,
+		Data:    data,
+		Links:   links,
+		Meta:    map[string]interface{}{"total": total},
+	})
+}
+
+// jsonapiErrorDocument writes an error as a JSON:API errors document, with
+// one error object per violation when there are any
+func jsonapiErrorDocument(c *gin.Context, status int, code, message string, violations []models.Violation) {
+	errors := []jsonapiError{{
+		Status: strconv.Itoa(status),
+		Code:   code,
+		Title:  http.StatusText(status),
+		Detail: message,
+	}}
+	if len(violations) > 0 {
+		errors = make([]jsonapiError, len(violations))
+		for i, v := range violations {
+			source := &jsonapiErrorSource{Pointer: "/" + v.Field}
+			if v.Code == "invalid_parameter" {
+				source = &jsonapiErrorSource{Parameter: v.Field}
+			}
+			errors[i] = jsonapiError{
+				Status: strconv.Itoa(status),
+				Code:   v.Code,
+				Title:  http.StatusText(status),
+				Detail: v.Message,
+				Source: source,
+			}
+		}
+	}
+
+	c.Header("Content-Type", FormatJSONAPI)
+	c.AbortWithStatusJSON(status, jsonapiDocument{JSONAPI: jsonapiVersion, Errors: errors})
+}
+
+// isJSONAPIResource reports whether values of type t can be serialized as resources
+func isJSONAPIResource(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	if _, ok := resourceField(t); ok {
+		return true
+	}
+	primary, _ := primaryField(t)
+	return primary >= 0
+}
+
+// resourceField finds the field of an envelope tagged jsonapi:"resource"
+func resourceField(t reflect.Type) (int, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get("jsonapi") == "resource" {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// primaryField finds the field tagged jsonapi:"primary,<type>" and returns its index and type
+func primaryField(t reflect.Type) (int, string) {
+	for i := 0; i < t.NumField(); i++ {
+		if kind, resourceType, _ := strings.Cut(t.Field(i).Tag.Get("jsonapi"), ","); kind == "primary" {
+			return i, resourceType
+		}
+	}
+	return -1, ""
+}
+
+// resourceObject converts a tagged model into a resource object. Fields
+// tagged jsonapi:"relation,<name>,<type>" become to-one relationships and
+// the remaining json fields become attributes; self and related links
+// follow the /api/<type>/<id> layout of the REST endpoints.
+func resourceObject(value reflect.Value) jsonapiResource {
+	t := value.Type()
+	primary, resourceType := primaryField(t)
+
+	resource := jsonapiResource{
+		Type:       resourceType,
+		ID:         value.Field(primary).String(),
+		Attributes: make(map[string]interface{}),
+	}
+	resource.Links = map[string]string{"self": "/api/" + resource.Type + "/" + resource.ID}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if i == primary {
+			continue
+		}
+
+		if kind, rest, _ := strings.Cut(field.Tag.Get("jsonapi"), ","); kind == "relation" {
+			name, relatedType, _ := strings.Cut(rest, ",")
+			id := value.Field(i).String()
+			if resource.Relationships == nil {
+				resource.Relationships = make(map[string]jsonapiRelationship)
+			}
+			resource.Relationships[name] = jsonapiRelationship{
+				Data:  jsonapiIdentifier{Type: relatedType, ID: id},
+				Links: map[string]string{"related": "/api/" + relatedType + "/" + id},
+			}
+			continue
+		}
+
+		name, ok := jsonName(field)
+		if !ok || omitted(field, value.Field(i)) {
+			continue
+		}
+		resource.Attributes[name] = value.Field(i).Interface()
+	}
+
+	return resource
+}
+
+// jsonName returns the json name of an exported, non-skipped field
+func jsonName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "-" {
+		return "", false
+	}
+	if name == "" {
+		name = field.Name
+	}
+	return name, true
+}
+
+// omitted reports whether an omitempty field holds its zero value
+func omitted(field reflect.StructField, value reflect.Value) bool {
+	return strings.Contains(field.Tag.Get("json"), ",omitempty") && value.IsZero()
+}
+
+// jsonapiPage reads page[number] and page[size], applying defaults
+func jsonapiPage(c *gin.Context) (int, int, bool) {
+	number, size := 1, jsonapiDefaultPageSize
+	if raw := c.Query("page[number]"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			return 0, 0, false
+		}
+		number = n
+	}
+	if raw := c.Query("page[size]"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > jsonapiMaxPageSize {
+			return 0, 0, false
+		}
+		size = n
+	}
+	return number, size, true
+}
+
+// pageLink returns the request URL with its page parameters replaced
+func pageLink(c *gin.Context, number, size int) string {
+	query := c.Request.URL.Query()
+	query.Set("page[number]", strconv.Itoa(number))
+	query.Set("page[size]", strconv.Itoa(size))
+	return RequestPath(c, query)
+}
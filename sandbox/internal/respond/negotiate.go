@@ -0,0 +1,125 @@
+package respond
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Response formats that handlers can offer
+const (
+	FormatJSON = "application/json"
+	FormatXML  = "application/xml"
+)
+
+// formatAliases maps additional media types onto the format that serves them
+var formatAliases = map[string]string{
+	"text/xml": FormatXML,
+}
+
+// acceptRange is a single media range from an Accept header
+type acceptRange struct {
+	mediaType string
+	q         float64
+}
+
+// Negotiate picks the best response format among offers from the Accept header.
+// The first offer is the default when the header is missing or only carries
+// the problem+json error-format hint. It returns "" when nothing is acceptable.
+func Negotiate(c *gin.Context, offers ...string) string {
+	ranges := parseAccept(c.GetHeader("Accept"))
+	if len(ranges) == 0 {
+		return offers[0]
+	}
+
+	for _, r := range ranges {
+		if r.q <= 0 {
+			continue
+		}
+		if r.mediaType == "*/*" {
+			return offers[0]
+		}
+		mediaType := r.mediaType
+		if alias, ok := formatAliases[mediaType]; ok {
+			mediaType = alias
+		}
+		for _, offer := range offers {
+			if mediaType == offer {
+				return offer
+			}
+			if strings.HasSuffix(mediaType, "/*") &&
+				strings.HasPrefix(offer, strings.TrimSuffix(mediaType, "*")) {
+				return offer
+			}
+		}
+	}
+
+	return ""
+}
+
+// Data writes data in the format negotiated between JSON and XML, plus
+// JSON:API for resource models, or a 406 error listing the supported types
+// when none is acceptable
+func Data(c *gin.Context, status int, data interface{}) {
+	offers := []string{FormatJSON, FormatXML}
+	if isJSONAPIResource(reflect.TypeOf(data)) {
+		offers = append(offers, FormatJSONAPI)
+	}
+
+	switch Negotiate(c, offers...) {
+	case FormatJSON:
+		c.JSON(status, data)
+	case FormatXML:
+		c.XML(status, data)
+	case FormatJSONAPI:
+		JSONAPIResource(c, status, data)
+	default:
+		NotAcceptable(c, offers...)
+	}
+}
+
+// NotAcceptable writes a 406 error listing the supported media types
+func NotAcceptable(c *gin.Context, supported ...string) {
+	Error(c, http.StatusNotAcceptable, "not_acceptable",
+		"None of the requested media types are supported. Supported types: "+strings.Join(supported, ", "))
+}
+
+// parseAccept parses an Accept header into media ranges ordered by preference.
+// The problem+json media type only selects the error format and is skipped.
+func parseAccept(header string) []acceptRange {
+	var ranges []acceptRange
+
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(fields[0]))
+		if mediaType == "" || mediaType == ProblemContentType {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if found && strings.TrimSpace(key) == "q" {
+				if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+
+		ranges = append(ranges, acceptRange{mediaType: mediaType, q: q})
+	}
+
+	// Higher quality first; more specific ranges win ties
+	sort.SliceStable(ranges, func(i, j int) bool {
+		if ranges[i].q != ranges[j].q {
+			return ranges[i].q > ranges[j].q
+		}
+		return strings.Count(ranges[i].mediaType, "*") < strings.Count(ranges[j].mediaType, "*")
+	})
+
+	return ranges
+}
@@ -0,0 +1,167 @@
+package respond
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ForwardedPrefixHeader carries the path prefix a reverse proxy mounts the API under
+const ForwardedPrefixHeader = "X-Forwarded-Prefix"
+
+// MaxLimit is the largest ?limit= the list endpoints honour; larger values
+// are clamped to it
+const MaxLimit = 500
+
+// LimitClampedHeader is set to the limit applied when ?limit= exceeded MaxLimit
+const LimitClampedHeader = "X-Limit-Clamped"
+
+// LimitAll is the ?limit= value that export endpoints accept for every result
+const LimitAll = "all"
+
+// ClampLimit applies the list limit contract to n: 0 means def and values
+// above MaxLimit are cut to it. It reports whether n was clamped.
+func ClampLimit(n, def int) (int, bool) {
+	switch {
+	case n <= 0:
+		return def, false
+	case n > MaxLimit:
+		return MaxLimit, true
+	default:
+		return n, false
+	}
+}
+
+// Window returns the bounds of the page starting at offset within total
+// items. A limit of 0 selects everything from offset onwards.
+func Window(total, offset, limit int) (int, int) {
+	start := offset
+	if start > total {
+		start = total
+	}
+	end := total
+	if limit > 0 && start+limit < total {
+		end = start + limit
+	}
+	return start, end
+}
+
+// Paginate sets X-Total-Count and, when the list is limited, a Link header
+// with first, prev, next and last relations. The links keep every active
+// query parameter and only replace offset and limit.
+func Paginate(c *gin.Context, total, offset, limit int) {
+	c.Header("X-Total-Count", strconv.Itoa(total))
+	if limit <= 0 {
+		return
+	}
+
+	lastOffset := 0
+	if total > 0 {
+		lastOffset = (total - 1) / limit * limit
+	}
+
+	links := []string{pageURL(c, 0, limit, "first")}
+	if offset > 0 {
+		prev := offset - limit
+		if prev < 0 {
+			prev = 0
+		}
+		links = append(links, pageURL(c, prev, limit, "prev"))
+	}
+	if offset+limit < total {
+		links = append(links, pageURL(c, offset+limit, limit, "next"))
+	}
+	links = append(links, pageURL(c, lastOffset, limit, "last"))
+
+	c.Header("Link", strings.Join(links, ", "))
+}
+
+// pageURL formats a Link header entry for the page at offset
+func pageURL(c *gin.Context, offset, limit int, rel string) string {
+	query := c.Request.URL.Query()
+	query.Set("offset", strconv.Itoa(offset))
+	query.Set("limit", strconv.Itoa(limit))
+	return fmt.Sprintf("<%s>; rel=%q", RequestPath(c, query), rel)
+}
+
+// RequestPath returns the request path with the given query, prefixed with
+// any X-Forwarded-Prefix so generated links work behind a reverse proxy
+func RequestPath(c *gin.Context, query url.Values) string {
+	prefix := ForwardedPrefix(c.GetHeader(ForwardedPrefixHeader))
+	return (&url.URL{Path: prefix + c.Request.URL.Path, RawQuery: query.Encode()}).String()
+}
+
+// forwardedPrefixSegment is one path segment of an accepted X-Forwarded-Prefix
+var forwardedPrefixSegment = regexp.MustCompile(`^[A-Za-z0-9._~-]+$`)
+
+// ForwardedPrefix normalizes an X-Forwarded-Prefix value to "/a/b" form. A
+// prefix is only honoured when it is a plain path of unreserved characters;
+// anything else, such as a URL, "//host", "..", a query or header syntax,
+// would let the header point generated links elsewhere, so it is ignored.
+func ForwardedPrefix(header string) string {
+	prefix := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(header), "/"), "/")
+	if prefix == "" {
+		return ""
+	}
+	for _, segment := range strings.Split(prefix, "/") {
+		if !forwardedPrefixSegment.MatchString(segment) || segment == "." || segment == ".." {
+			return ""
+		}
+	}
+	return "/" + prefix
+}
+
+// ErrCursor is returned for cursors that are malformed or were issued by
+// another list
+var ErrCursor = errors.New("not a cursor for this list")
+
+// Cursor marks the last item of a page so the next page starts right after
+// it. It records the item's ID and its position in the store: the position
+// is looked up again while the item exists and the recorded one is used
+// once it is gone, so items added or removed between pages cause neither
+// duplicates nor gaps. Clients treat the encoded form as opaque.
+type Cursor struct {
+	List     string `json:"l"` // The list and order the cursor walks
+	ID       string `json:"i"`
+	Position uint64 `json:"p"`
+}
+
+// Encode returns the opaque form of the cursor
+func (cur Cursor) Encode() string {
+	data, _ := json.Marshal(cur)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor reads an opaque cursor, which must have been issued by list
+func DecodeCursor(raw, list string) (Cursor, error) {
+	var cur Cursor
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil || json.Unmarshal(data, &cur) != nil || cur.List != list || cur.ID == "" {
+		return Cursor{}, ErrCursor
+	}
+	return cur, nil
+}
+
+// PaginateCursor sets X-Total-Count and a Link header for a page read with
+// a cursor: first drops the cursor, and next, present when more items
+// follow, carries the next one. Both keep every other query parameter.
+func PaginateCursor(c *gin.Context, total int, next string) {
+	c.Header("X-Total-Count", strconv.Itoa(total))
+
+	query := c.Request.URL.Query()
+	query.Del("cursor")
+	query.Del("offset")
+	links := []string{fmt.Sprintf("<%s>; rel=%q", RequestPath(c, query), "first")}
+	if next != "" {
+		query.Set("cursor", next)
+		links = append(links, fmt.Sprintf("<%s>; rel=%q", RequestPath(c, query), "next"))
+	}
+	c.Header("Link", strings.Join(links, ", "))
+}
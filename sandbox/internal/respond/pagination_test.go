@@ -0,0 +1,142 @@
+package respond
+
+import (
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// pageContext returns a context for a GET of target with the given
+// X-Forwarded-Prefix
+func pageContext(target, prefix string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", target, nil)
+	if prefix != "" {
+		c.Request.Header.Set(ForwardedPrefixHeader, prefix)
+	}
+	return c, w
+}
+
+func TestPaginateLinks(t *testing.T) {
+	tests := []struct {
+		name                 string
+		total, offset, limit int
+		want                 string
+	}{
+		{
+			name: "first page", total: 45, offset: 0, limit: 10,
+			want: `</api/jobs?limit=10&offset=0&remote=true>; rel="first", ` +
+				`</api/jobs?limit=10&offset=10&remote=true>; rel="next", ` +
+				`</api/jobs?limit=10&offset=40&remote=true>; rel="last"`,
+		},
+		{
+			name: "middle page", total: 45, offset: 20, limit: 10,
+			want: `</api/jobs?limit=10&offset=0&remote=true>; rel="first", ` +
+				`</api/jobs?limit=10&offset=10&remote=true>; rel="prev", ` +
+				`</api/jobs?limit=10&offset=30&remote=true>; rel="next", ` +
+				`</api/jobs?limit=10&offset=40&remote=true>; rel="last"`,
+		},
+		{
+			name: "unaligned offset", total: 45, offset: 5, limit: 10,
+			want: `</api/jobs?limit=10&offset=0&remote=true>; rel="first", ` +
+				`</api/jobs?limit=10&offset=0&remote=true>; rel="prev", ` +
+				`</api/jobs?limit=10&offset=15&remote=true>; rel="next", ` +
+				`</api/jobs?limit=10&offset=40&remote=true>; rel="last"`,
+		},
+		{
+			name: "last page", total: 45, offset: 40, limit: 10,
+			want: `</api/jobs?limit=10&offset=0&remote=true>; rel="first", ` +
+				`</api/jobs?limit=10&offset=30&remote=true>; rel="prev", ` +
+				`</api/jobs?limit=10&offset=40&remote=true>; rel="last"`,
+		},
+		{
+			name: "exact multiple", total: 40, offset: 30, limit: 10,
+			want: `</api/jobs?limit=10&offset=0&remote=true>; rel="first", ` +
+				`</api/jobs?limit=10&offset=20&remote=true>; rel="prev", ` +
+				`</api/jobs?limit=10&offset=30&remote=true>; rel="last"`,
+		},
+		{
+			name: "empty list", total: 0, offset: 0, limit: 10,
+			want: `</api/jobs?limit=10&offset=0&remote=true>; rel="first", ` +
+				`</api/jobs?limit=10&offset=0&remote=true>; rel="last"`,
+		},
+		{
+			name: "unlimited", total: 45, offset: 0, limit: 0,
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, w := pageContext("/api/jobs?remote=true&offset=99", "")
+			Paginate(c, tt.total, tt.offset, tt.limit)
+			if got := w.Header().Get("Link"); got != tt.want {
+				t.Errorf("Link\n%s\nwant\n%s", got, tt.want)
+			}
+			if got := w.Header().Get("X-Total-Count"); got != strconv.Itoa(tt.total) {
+				t.Errorf("X-Total-Count %s, want %d", got, tt.total)
+			}
+		})
+	}
+}
+
+func TestPaginateCursorLinks(t *testing.T) {
+	c, w := pageContext("/api/jobs?cursor=old&offset=3&q=go", "")
+	PaginateCursor(c, 12, "abc")
+	want := `</api/jobs?q=go>; rel="first", </api/jobs?cursor=abc&q=go>; rel="next"`
+	if got := w.Header().Get("Link"); got != want {
+		t.Errorf("Link\n%s\nwant\n%s", got, want)
+	}
+
+	c, w = pageContext("/api/jobs?cursor=old&q=go", "")
+	PaginateCursor(c, 12, "")
+	if got, want := w.Header().Get("Link"), `</api/jobs?q=go>; rel="first"`; got != want {
+		t.Errorf("Link on the last page %s, want %s", got, want)
+	}
+}
+
+func TestForwardedPrefix(t *testing.T) {
+	tests := map[string]string{
+		"":                       "",
+		"/":                      "",
+		"/sandbox":               "/sandbox",
+		"sandbox/":               "/sandbox",
+		" /v1/jobs-api/ ":        "/v1/jobs-api",
+		"/a.b/c_d~e":             "/a.b/c_d~e",
+		"//evil.example":         "",
+		"https://evil.example":   "",
+		"/a/../admin":            "",
+		"/a/./b":                 "",
+		"/a//b":                  "",
+		"/a?x=1":                 "",
+		"/a#frag":                "",
+		"/a>; rel=\"next\", </b": "",
+		"/a%2Fb":                 "",
+		"/a b":                   "",
+		"/a\\b":                  "",
+	}
+	for header, want := range tests {
+		if got := ForwardedPrefix(header); got != want {
+			t.Errorf("ForwardedPrefix(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestPaginateForwardedPrefix(t *testing.T) {
+	c, w := pageContext("/api/jobs?limit=10", "/sandbox/")
+	Paginate(c, 5, 0, 10)
+	want := `</sandbox/api/jobs?limit=10&offset=0>; rel="first", </sandbox/api/jobs?limit=10&offset=0>; rel="last"`
+	if got := w.Header().Get("Link"); got != want {
+		t.Errorf("Link\n%s\nwant\n%s", got, want)
+	}
+
+	c, w = pageContext("/api/jobs?limit=10", "//evil.example")
+	Paginate(c, 5, 0, 10)
+	want = `</api/jobs?limit=10&offset=0>; rel="first", </api/jobs?limit=10&offset=0>; rel="last"`
+	if got := w.Header().Get("Link"); got != want {
+		t.Errorf("Link with a rejected prefix\n%s\nwant\n%s", got, want)
+	}
+}
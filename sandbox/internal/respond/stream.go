@@ -0,0 +1,35 @@
+package respond
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+// drainingKey is the context key holding the context a server cancels
+// when it starts shutting down
+type drainingKey struct{}
+
+// WithDraining returns ctx carrying draining, a context the server cancels
+// as soon as it starts shutting down. Servers set it in BaseContext, so
+// every request's context carries it.
+func WithDraining(ctx, draining context.Context) context.Context {
+	return context.WithValue(ctx, drainingKey{}, draining)
+}
+
+// StreamContext returns the context of a response that streams until the
+// client leaves, such as Server-Sent Events. Besides ending with the
+// request, it ends when the server starts shutting down, so open streams
+// don't hold up shutdown while other requests are left to finish.
+func StreamContext(c *gin.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	draining, ok := ctx.Value(drainingKey{}).(context.Context)
+	if !ok {
+		return ctx, cancel
+	}
+	stop := context.AfterFunc(draining, cancel)
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
@@ -0,0 +1,92 @@
+// Package review advances applications through the review pipeline on a
+// schedule, the way an employer's applicant tracking system would, so
+// agents see status changes they did not trigger themselves.
+package review
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/random"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+)
+
+// maxTick is the longest the engine waits between looking for due
+// applications
+const maxTick = time.Second
+
+// Config is the review schedule
+type Config struct {
+	// ReviewDelay is how long an application stays received before it is
+	// reviewed; zero disables automatic progression
+	ReviewDelay time.Duration
+	// DecisionDelay is how long an application stays in review before it is
+	// shortlisted or rejected
+	DecisionDelay time.Duration
+	// RejectionRate is the probability (0.0 to 1.0) that a reviewed
+	// application is rejected rather than shortlisted
+	RejectionRate float64
+}
+
+// Engine moves received applications to reviewing once ReviewDelay has
+// passed since their last update, and reviewing ones to shortlisted or
+// rejected once DecisionDelay has. Applications in any other status, or
+// moved on by hand, are left alone. Status changes go through the
+// application store, so webhooks and event streams see them.
+type Engine struct {
+	appStore *store.ApplicationStore
+	config   Config
+	rng      *rand.Rand
+}
+
+// NewEngine creates a review engine for the applications in appStore
+func NewEngine(appStore *store.ApplicationStore, config Config) *Engine {
+	return &Engine{
+		appStore: appStore,
+		config:   config,
+		rng:      random.New("review"),
+	}
+}
+
+// Run advances applications until ctx is cancelled
+func (e *Engine) Run(ctx context.Context) {
+	tick := min(e.config.ReviewDelay, e.config.DecisionDelay) / 2
+	tick = max(min(tick, maxTick), 10*time.Millisecond)
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			e.Step(now)
+		}
+	}
+}
+
+// Step advances every application that is due at now
+func (e *Engine) Step(now time.Time) {
+	for _, app := range e.appStore.GetAll(0, store.OldestFirst) {
+		// Edits before review do not restart the clock, only status changes
+		changed := app.UpdatedAt
+		if n := len(app.StatusHistory); n > 0 {
+			changed = app.StatusHistory[n-1].At
+		}
+		waited := now.Sub(changed)
+		switch {
+		case app.Status == models.StatusReceived && waited >= e.config.ReviewDelay:
+			e.appStore.AdvanceStatus(app.ID, app.Status, models.StatusReviewing,
+				fmt.Sprintf("Automatically moved to review after %s.", e.config.ReviewDelay), models.ActorReview)
+		case app.Status == models.StatusReviewing && waited >= e.config.DecisionDelay:
+			if e.rng.Float64() < e.config.RejectionRate {
+				e.appStore.AdvanceStatus(app.ID, app.Status, models.StatusRejected, "Automatically rejected after review.", models.ActorReview)
+			} else {
+				e.appStore.AdvanceStatus(app.ID, app.Status, models.StatusShortlisted, "Automatically shortlisted after review.", models.ActorReview)
+			}
+		}
+	}
+}
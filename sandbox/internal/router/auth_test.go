@@ -0,0 +1,198 @@
+package router
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/middleware"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// login registers email and returns the header authenticating as it
+func login(t *testing.T, r http.Handler, email string) []string {
+	t.Helper()
+	w := serve(r, "POST", "/api/auth/register", `{"email":"`+email+`","password":"correct horse"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("registering %s: status %d: %s", email, w.Code, w.Body.String())
+	}
+	var resp models.TokenResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	return []string{"Authorization", "Bearer " + resp.Token}
+}
+
+// authRouter is a router requiring applicant tokens, holding an
+// application from ann and one from bob
+func authRouter(t *testing.T) (r http.Handler, ann, bob []string, annApp, bobApp string) {
+	t.Helper()
+	r = newTestRouter(t, func(c *Config) {
+		c.Jobs = testJobs()
+		c.RequireAuth = true
+		c.MCP = true
+	})
+	ann, bob = login(t, r, "ann@example.com"), login(t, r, "bob@example.com")
+	annApp = submit(t, r, "ann@example.com", ann...)
+	bobApp = submit(t, r, "bob@example.com", bob...)
+	return r, ann, bob, annApp, bobApp
+}
+
+// TestApplicantAuthOnEverySurface checks that under RequireAuth every
+// route reading applications needs a token and shows only the applicant's
+// own
+func TestApplicantAuthOnEverySurface(t *testing.T) {
+	r, ann, _, annApp, bobApp := authRouter(t)
+	graphql := func(query string) string {
+		body, _ := json.Marshal(map[string]string{"query": query})
+		return string(body)
+	}
+
+	tests := []struct {
+		name, method, path, body string
+		header                   []string
+		status                   int
+		want, hide               string // In and not in the response body
+	}{
+		{"list anonymously", "GET", "/api/applications", "", nil, 401, "authentication_required", annApp},
+		{"list", "GET", "/api/applications", "", ann, 200, annApp, bobApp},
+		{"read another's", "GET", "/api/applications/" + bobApp, "", ann, 403, "forbidden", bobApp},
+		{"applications anonymously over GraphQL", "POST", "/graphql", graphql(`{ applications { confirmationId } }`), nil, 200, "authentication_required", annApp},
+		{"applications over GraphQL", "POST", "/graphql", graphql(`{ applications { confirmationId } }`), ann, 200, annApp, bobApp},
+		{"job applications over GraphQL", "POST", "/graphql", graphql(`{ job(id: "job_test_1") { applications { confirmationId } } }`), ann, 200, annApp, bobApp},
+		{"another's over GraphQL", "POST", "/graphql", graphql(`{ application(id: "` + bobApp + `") { applicantEmail } }`), ann, 200, "forbidden", "bob@"},
+		{"withdraw another's over GraphQL", "POST", "/graphql", graphql(`mutation { withdrawApplication(id: "` + bobApp + `") { status } }`), ann, 200, "forbidden", "withdrawn"},
+		{"jobs anonymously over GraphQL", "POST", "/graphql", graphql(`{ jobs { id } }`), nil, 200, "job_test_1", "errors"},
+		{"mailbox anonymously", "GET", "/api/mailbox?email=bob%40example.com", "", nil, 401, "authentication_required", bobApp},
+		{"another's mailbox", "GET", "/api/mailbox?email=bob%40example.com", "", ann, 403, "email_mismatch", bobApp},
+		{"mailbox", "GET", "/api/mailbox", "", ann, 200, annApp, bobApp},
+		{"another's saved jobs", "GET", "/api/saved-jobs?email=bob%40example.com", "", ann, 403, "email_mismatch", ""},
+		{"event log anonymously", "GET", "/api/events/log", "", nil, 401, "authentication_required", annApp},
+		{"event log", "GET", "/api/events/log", "", ann, 200, annApp, bobApp},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(r, tt.method, tt.path, tt.body, tt.header...)
+			body := w.Body.String()
+			if w.Code != tt.status || !strings.Contains(body, tt.want) || (tt.hide != "" && strings.Contains(body, tt.hide)) {
+				t.Errorf("status %d: %.300s\nwant %d with %q and without %q", w.Code, body, tt.status, tt.want, tt.hide)
+			}
+		})
+	}
+}
+
+// TestApplicantAuthOnStreams checks event streams only carry the
+// applicant's own application events and MCP sessions only answer the
+// applicant who opened them
+func TestApplicantAuthOnStreams(t *testing.T) {
+	r, ann, bob, annApp, bobApp := authRouter(t)
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	open := func(path string, header []string) *bufio.Reader {
+		t.Helper()
+		req, _ := http.NewRequest("GET", srv.URL+path, nil)
+		req.Header.Set(header[0], header[1])
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { res.Body.Close() })
+		return bufio.NewReader(res.Body)
+	}
+
+	events := open("/api/events", ann)
+	serve(r, "POST", "/api/applications/"+bobApp+"/withdraw", `{}`, bob...)
+	serve(r, "POST", "/api/applications/"+annApp+"/withdraw", `{}`, ann...)
+	for {
+		line, err := events.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(line, bobApp) {
+			t.Fatalf("ann's event stream carried bob's application: %s", line)
+		}
+		if strings.Contains(line, annApp) {
+			break
+		}
+	}
+
+	mcp := open("/mcp/sse", bob)
+	var endpoint string
+	for !strings.HasPrefix(endpoint, "data: ") {
+		line, err := mcp.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		endpoint = line
+	}
+	endpoint = strings.TrimSpace(strings.TrimPrefix(endpoint, "data: "))
+	call := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"check_application_status","arguments":{"application_id":"` + bobApp + `"}}}`
+	if w := serve(r, "POST", endpoint, call, ann...); w.Code != http.StatusForbidden {
+		t.Errorf("ann calling a tool on bob's MCP session: status %d, want 403", w.Code)
+	}
+	if w := serve(r, "POST", endpoint, call, bob...); w.Code != http.StatusAccepted {
+		t.Errorf("bob calling a tool on his MCP session: status %d, want 202", w.Code)
+	}
+}
+
+// TestApplicantRoutesCoverApplications fails when an application route is
+// added without deciding how it is guarded
+func TestApplicantRoutesCoverApplications(t *testing.T) {
+	// Status changes and clearing stand in for the employer
+	employer := map[string]bool{"/api/applications/:id/status": true, "/api/applications/clear": true}
+	gin.SetMode(gin.TestMode)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for _, route := range SetupHandlers(ctx, fullConfig()).API.Routes() {
+		if !strings.HasPrefix(route.Path, "/api/applications") || employer[route.Path] {
+			continue
+		}
+		if access := applicantRoutes[route.Path]; access == 0 {
+			t.Errorf("%s %s is not in applicantRoutes", route.Method, route.Path)
+		} else if strings.Contains(route.Path, "/:id") && !strings.Contains(route.Path, "/draft") && access != middleware.ApplicationOwner {
+			t.Errorf("%s %s is not guarded by application owner", route.Method, route.Path)
+		}
+	}
+}
+
+// TestWebhooksNeedAdminToken checks webhooks, whose responses carry signing
+// secrets, are only managed with the admin token and are not served at all
+// without one
+func TestWebhooksNeedAdminToken(t *testing.T) {
+	const admin = "Bearer test-admin-token"
+	r := newTestRouter(t, func(c *Config) { c.AdminToken = "test-admin-token" })
+
+	if w := serve(r, "POST", "/api/webhooks", `{"url":"http://127.0.0.1:1/hook"}`); w.Code != http.StatusUnauthorized {
+		t.Fatalf("create without a token: status %d, want 401", w.Code)
+	}
+	w := serve(r, "POST", "/api/webhooks", `{"url":"http://127.0.0.1:1/hook"}`, "Authorization", admin)
+	var created models.WebhookSecretResponse
+	if w.Code != http.StatusCreated || json.Unmarshal(w.Body.Bytes(), &created) != nil || created.Secret == "" {
+		t.Fatalf("create with the token: status %d, body %s", w.Code, w.Body)
+	}
+
+	path := "/api/webhooks/" + created.Webhook.ID
+	for _, tt := range []struct{ method, path, body string }{
+		{"GET", "/api/webhooks", ""},
+		{"PATCH", path, `{"rotate_secret":true}`},
+		{"DELETE", path, ""},
+		{"GET", path + "/stats", ""},
+		{"GET", path + "/dead-letters", ""},
+		{"POST", path + "/dead-letters/redeliver", ""},
+	} {
+		w := serve(r, tt.method, tt.path, tt.body, "Authorization", "Bearer wrong")
+		if w.Code != http.StatusUnauthorized || strings.Contains(w.Body.String(), "whsec_") {
+			t.Errorf("%s %s with a wrong token: status %d, body %s", tt.method, tt.path, w.Code, w.Body)
+		}
+	}
+
+	open := newTestRouter(t, nil)
+	if w := serve(open, "POST", "/api/webhooks", `{"url":"http://127.0.0.1:1/hook"}`); w.Code != http.StatusNotFound {
+		t.Errorf("create without -admin-token: status %d, want 404", w.Code)
+	}
+}
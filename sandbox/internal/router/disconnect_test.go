@@ -0,0 +1,108 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+)
+
+// syncBuffer is a log destination the server goroutines and the test can
+// share
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// TestSlowRequestEndsWhenClientGoes checks a request held up by a
+// simulated delay returns soon after its client gives up, is logged as a
+// 499 rather than a server error, and is counted in the stats
+func TestSlowRequestEndsWhenClientGoes(t *testing.T) {
+	var logs syncBuffer
+	r := newTestRouter(t, func(c *Config) {
+		c.Jobs = testJobs()
+		c.DebugFaults = true
+		c.Logger = slog.New(slog.NewTextHandler(&logs, nil))
+	})
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	stats := func() int64 {
+		var resp models.StatsResponse
+		res, err := http.Get(server.URL + "/api/stats")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		if err := json.NewDecoder(res.Body).Decode(&resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp.ClientDisconnects
+	}
+
+	tests := []struct {
+		method, path, body string
+	}{
+		{"GET", "/api/jobs", ""},
+		{"POST", "/api/applications", `{"job_id":"job_test_2","applicant_name":"Vic Tester","applicant_email":"vic@example.com",` +
+			`"resume":"Ten years of building web services in Go and Python."}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			before := stats()
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+			req, err := http.NewRequestWithContext(ctx, tt.method, server.URL+tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Simulate", "slow=10s")
+			if res, err := http.DefaultClient.Do(req); err == nil {
+				res.Body.Close()
+				t.Fatalf("status %d, want the client to give up first", res.StatusCode)
+			}
+
+			// The handler goroutine has returned once the request is logged
+			record := "method=" + tt.method + " path=" + tt.path
+			deadline := time.Now().Add(2 * time.Second)
+			for !strings.Contains(logs.String(), record) {
+				if time.Now().After(deadline) {
+					t.Fatalf("request still running 2s after the client went; log:\n%s", logs.String())
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+			for _, line := range strings.Split(logs.String(), "\n") {
+				if !strings.Contains(line, record) {
+					continue
+				}
+				if !strings.Contains(line, "status=499") || !strings.Contains(line, "client_disconnected=true") || strings.Contains(line, "level=ERROR") {
+					t.Errorf("logged as %q, want a 499 marked client_disconnected", line)
+				}
+			}
+			if got := stats() - before; got != 1 {
+				t.Errorf("client_disconnects went up by %d, want 1", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package router
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/emulate"
+)
+
+// TestSubmitGuardOnEveryTransport checks that submitting through GraphQL,
+// MCP and the ATS emulations needs a solved challenge, a token and the
+// wizard just as POST /api/applications does
+func TestSubmitGuardOnEveryTransport(t *testing.T) {
+	job := testJobs()[0]
+	form := []string{"Content-Type", "application/x-www-form-urlencoded"}
+	mutation, _ := json.Marshal(map[string]string{"query": `mutation { submitApplication(input: {jobId: "job_test_1", applicantName: "Ann", applicantEmail: "ann@example.com", resume: "Ten years of building web services in Go and Python."}) { confirmationId } }`})
+	submissions := []struct {
+		transport, path, body string
+		header                []string
+	}{
+		{"REST", "/api/applications", `{"job_id":"job_test_1","applicant_email":"ann@example.com"}`, nil},
+		{"GraphQL", "/graphql", string(mutation), nil},
+		{"Greenhouse", "/v1/boards/acme/jobs/" + strconv.FormatInt(emulate.GreenhouseJobID(job), 10), "email=ann%40example.com", form},
+		{"Lever", "/v0/postings/acme/" + emulate.LeverPostingID(job) + "/apply", "email=ann%40example.com", form},
+	}
+	modes := []struct {
+		name      string
+		configure func(*Config)
+		want      string
+	}{
+		{"challenge", func(c *Config) { c.Challenge = "pow" }, "Solve a challenge"},
+		{"wizard", func(c *Config) { c.ApplyWizard = true }, "Start a draft"},
+		{"auth", func(c *Config) { c.RequireAuth = true }, "Log in with POST /api/auth/login"},
+	}
+	for _, mode := range modes {
+		r := newTestRouter(t, func(c *Config) {
+			c.Jobs = testJobs()
+			c.Emulate = []string{"greenhouse", "lever"}
+			c.MCP = true
+			mode.configure(c)
+		})
+		for _, sub := range submissions {
+			t.Run(mode.name+" over "+sub.transport, func(t *testing.T) {
+				w := serve(r, "POST", sub.path, sub.body, sub.header...)
+				if !strings.Contains(w.Body.String(), mode.want) {
+					t.Errorf("status %d: %.300s\nwant it refused with %q", w.Code, w.Body.String(), mode.want)
+				}
+			})
+		}
+		t.Run(mode.name+" over MCP", func(t *testing.T) {
+			if got := submitOverMCP(t, r); !strings.Contains(got, mode.want) {
+				t.Errorf("got %.300s\nwant it refused with %q", got, mode.want)
+			}
+		})
+	}
+}
+
+// submitOverMCP calls submit_application on a new MCP session and returns
+// the answer sent on its stream
+func submitOverMCP(t *testing.T, r http.Handler) string {
+	t.Helper()
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	res, err := http.Get(srv.URL + "/mcp/sse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { res.Body.Close() })
+	stream := bufio.NewReader(res.Body)
+	next := func() string {
+		for {
+			line, err := stream.ReadString('\n')
+			if err != nil {
+				t.Fatal(err)
+			}
+			if data, ok := strings.CutPrefix(line, "data: "); ok {
+				return strings.TrimSpace(data)
+			}
+		}
+	}
+
+	endpoint := next()
+	call := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"submit_application","arguments":` +
+		`{"job_id":"job_test_1","applicant_name":"Ann","applicant_email":"ann@example.com","resume":"Ten years of building web services in Go and Python."}}}`
+	if w := serve(r, "POST", endpoint, call); w.Code != http.StatusAccepted {
+		t.Fatalf("calling submit_application: status %d: %s", w.Code, w.Body.String())
+	}
+	return next()
+}
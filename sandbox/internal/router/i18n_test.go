@@ -0,0 +1,130 @@
+package router
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+)
+
+// TestErrorCodesStableAcrossLanguages checks that translating an error
+// changes only its messages: the status, the error code and every
+// violation's field and code stay the same in every language
+func TestErrorCodesStableAcrossLanguages(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{"unknown job", "GET", "/api/jobs/job_missing", ""},
+		{"unknown application", "GET", "/api/applications/CONF-missing", ""},
+		{"missing fields", "POST", "/api/applications", `{"job_id":"job_test_1"}`},
+		{"invalid fields", "POST", "/api/applications",
+			`{"job_id":"job_test_1","applicant_name":"Vic","applicant_email":"not-an-email","resume":"x","phone":"abc","linkedin":"http://example.com"}`},
+		{"invalid JSON", "POST", "/api/applications", `{"job_id":`},
+		{"invalid query", "GET", "/api/jobs?limit=-1&remote=maybe", ""},
+		{"missing search query", "GET", "/api/jobs/search", ""},
+		{"unknown route", "GET", "/api/no-such-route", ""},
+	}
+
+	// Each language is asked for by header and by ?lang=
+	languages := []struct {
+		name   string
+		query  string
+		header []string
+		want   string
+	}{
+		{name: "default", want: "en"},
+		{name: "en header", header: []string{"Accept-Language", "en-US"}, want: "en"},
+		{name: "es header", header: []string{"Accept-Language", "fr;q=0.9, es-MX;q=0.8"}, want: "es"},
+		{name: "es override", query: "lang=es", header: []string{"Accept-Language", "en"}, want: "es"},
+		{name: "unsupported", header: []string{"Accept-Language", "ja"}, want: "en"},
+	}
+
+	r := newTestRouter(t, func(c *Config) { c.Jobs = testJobs() })
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var english models.ErrorResponse
+			englishStatus := 0
+			for _, lang := range languages {
+				path := tt.path
+				if lang.query != "" {
+					if strings.Contains(path, "?") {
+						path += "&" + lang.query
+					} else {
+						path += "?" + lang.query
+					}
+				}
+				w := serve(r, tt.method, path, tt.body, lang.header...)
+				if got := w.Header().Get("Content-Language"); got != lang.want {
+					t.Errorf("%s: Content-Language %q, want %q", lang.name, got, lang.want)
+				}
+				var body models.ErrorResponse
+				if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || body.Error == "" {
+					t.Fatalf("%s: body %s is not an error: %v", lang.name, w.Body.String(), err)
+				}
+
+				if lang.name == "default" {
+					english, englishStatus = body, w.Code
+					continue
+				}
+				if w.Code != englishStatus || body.Error != english.Error || body.Code != english.Code {
+					t.Errorf("%s: status %d and error %q, English has %d and %q", lang.name, w.Code, body.Error, englishStatus, english.Error)
+				}
+				if len(body.Violations) != len(english.Violations) {
+					t.Fatalf("%s: %d violations, English has %d", lang.name, len(body.Violations), len(english.Violations))
+				}
+				for i, v := range body.Violations {
+					if v.Field != english.Violations[i].Field || v.Code != english.Violations[i].Code {
+						t.Errorf("%s: violation %d is %s/%s, English has %s/%s", lang.name, i, v.Field, v.Code,
+							english.Violations[i].Field, english.Violations[i].Code)
+					}
+				}
+				if lang.want == "en" && body.Message != english.Message {
+					t.Errorf("%s: message %q, want the English %q", lang.name, body.Message, english.Message)
+				}
+			}
+		})
+	}
+}
+
+// TestSpanishEndToEnd checks that Spanish reaches error messages,
+// violation messages and application status messages
+func TestSpanishEndToEnd(t *testing.T) {
+	r := newTestRouter(t, func(c *Config) { c.Jobs = testJobs() })
+
+	var notFound models.ErrorResponse
+	w := serve(r, "GET", "/api/jobs/job_missing", "", "Accept-Language", "es")
+	if err := json.Unmarshal(w.Body.Bytes(), &notFound); err != nil {
+		t.Fatal(err)
+	}
+	if notFound.Error != "job_not_found" || notFound.Message != "No se pudo encontrar el empleo solicitado." {
+		t.Errorf("error %+v, want job_not_found in Spanish", notFound)
+	}
+
+	var invalid models.ErrorResponse
+	w = serve(r, "POST", "/api/applications?lang=es", `{"job_id":"job_test_1","applicant_name":"Vic","applicant_email":"vic@example.com"}`)
+	if err := json.Unmarshal(w.Body.Bytes(), &invalid); err != nil {
+		t.Fatal(err)
+	}
+	if len(invalid.Violations) == 0 {
+		t.Fatalf("no violations in %s", w.Body.String())
+	}
+	for _, v := range invalid.Violations {
+		if v.Field == "resume" && v.Message != "El currículum es obligatorio." {
+			t.Errorf("resume violation %q, want it in Spanish", v.Message)
+		}
+	}
+
+	id := submit(t, r, "vic@example.com")
+	var status models.ApplicationStatusResponse
+	w = serve(r, "GET", "/api/applications/"+id, "", "Accept-Language", "es")
+	if err := json.Unmarshal(w.Body.Bytes(), &status); err != nil {
+		t.Fatal(err)
+	}
+	if status.Status != models.StatusReceived || status.Message != "Hemos recibido su postulación y ya está en nuestro sistema." {
+		t.Errorf("status %s with message %q, want received in Spanish", status.Status, status.Message)
+	}
+}
@@ -0,0 +1,174 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+)
+
+// manyJobs is more open jobs than any list returns at once, all at Acme
+// and all found by a search for "engineer"
+func manyJobs(n int) []models.Job {
+	jobs := make([]models.Job, n)
+	for i := range jobs {
+		jobs[i] = models.Job{
+			ID:                  fmt.Sprintf("job_many_%04d", i),
+			Title:               "Backend Engineer",
+			Company:             "Acme",
+			Description:         "Build the services behind Acme's storefront.",
+			Location:            "Berlin, Germany",
+			JobType:             "full-time",
+			ApplicationDeadline: "2099-12-31T23:59:59Z",
+		}
+	}
+	return jobs
+}
+
+// TestLimitsOnEndpoints checks every list endpoint follows the one limit
+// contract: absent or 0 is the endpoint's default, larger values are
+// clamped to respond.MaxLimit with X-Limit-Clamped saying so, and only
+// export endpoints read without a limit
+func TestLimitsOnEndpoints(t *testing.T) {
+	const total = respond.MaxLimit + 100
+	r := newTestRouter(t, func(c *Config) {
+		c.TemplatesFS = os.DirFS("../templates")
+		c.Jobs = manyJobs(total)
+		c.GeneralRateLimit = 10 * total
+		c.ApplicationRateLimit = 10 * total
+	})
+	for i := range total {
+		body := fmt.Sprintf(`{"job_id":"job_many_%04d","applicant_name":"Vic Tester","applicant_email":"vic%d@example.com",`+
+			`"resume":"Ten years of building web services in Go and Python."}`, i%10, i)
+		if w := serve(r, "POST", "/api/applications", body); w.Code != http.StatusCreated {
+			t.Fatalf("submitting %d: status %d: %s", i, w.Code, w.Body.String())
+		}
+	}
+
+	// Each endpoint and how to count the results it returned
+	jsonList := func(field string) func(t *testing.T, body string) int {
+		return func(t *testing.T, body string) int {
+			var resp map[string]json.RawMessage
+			var list []json.RawMessage
+			if err := json.Unmarshal([]byte(body), &resp); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal(resp[field], &list); err != nil {
+				t.Fatalf("%s: %v", field, err)
+			}
+			return len(list)
+		}
+	}
+	lines := func(t *testing.T, body string) int { return strings.Count(body, "\n") }
+	links := func(href string) func(t *testing.T, body string) int {
+		return func(t *testing.T, body string) int { return strings.Count(body, href) }
+	}
+	endpoints := []struct {
+		path   string
+		def    int
+		export bool
+		count  func(t *testing.T, body string) int
+	}{
+		{"/api/jobs", 100, false, jsonList("jobs")},
+		{"/api/jobs/search?q=engineer", 50, false, jsonList("jobs")},
+		{"/api/companies/Acme/jobs", 50, false, jsonList("jobs")},
+		{"/api/applications", 100, false, jsonList("applications")},
+		{"/jobs", 100, false, links(`/apply"`)},
+		{"/my-applications?email=vic1%40example.com", 50, false, links(`href="/applications/`)},
+		{"/api/jobs/stream", total, true, lines},
+		{"/api/applications/export?format=jsonl", total, true, lines},
+	}
+
+	for _, ep := range endpoints {
+		sep := "?"
+		if strings.Contains(ep.path, "?") {
+			sep = "&"
+		}
+		tests := []struct {
+			limit   string
+			want    int
+			clamped bool
+		}{
+			{"", ep.def, false},
+			{"0", ep.def, false},
+			{"7", 7, false},
+			{strconv.Itoa(respond.MaxLimit), respond.MaxLimit, false},
+			{strconv.Itoa(respond.MaxLimit + 1), respond.MaxLimit, true},
+			{"1000000", respond.MaxLimit, true},
+		}
+		if ep.export {
+			tests = []struct {
+				limit   string
+				want    int
+				clamped bool
+			}{
+				{"", total, false},
+				{"all", total, false},
+				{"0", total, false},
+				{"7", 7, false},
+				{strconv.Itoa(respond.MaxLimit + 1), respond.MaxLimit + 1, false},
+				{"1000000", total, false},
+			}
+		}
+		if strings.Contains(ep.path, "vic1%40") {
+			// Only one application has this address
+			for i := range tests {
+				tests[i].want = min(tests[i].want, 1)
+			}
+		}
+
+		for _, tt := range tests {
+			path := ep.path
+			if tt.limit != "" {
+				path += sep + "limit=" + tt.limit
+			}
+			t.Run(path, func(t *testing.T) {
+				w := serve(r, "GET", path, "")
+				if w.Code != http.StatusOK {
+					t.Fatalf("status %d: %s", w.Code, w.Body.String())
+				}
+				if got := ep.count(t, w.Body.String()); got != tt.want {
+					t.Errorf("%d results, want %d", got, tt.want)
+				}
+				clamped := w.Header().Get(respond.LimitClampedHeader)
+				if tt.clamped && clamped != strconv.Itoa(respond.MaxLimit) || !tt.clamped && clamped != "" {
+					t.Errorf("%s: %q, want clamped %v", respond.LimitClampedHeader, clamped, tt.clamped)
+				}
+			})
+		}
+
+		if !ep.export {
+			for _, limit := range []string{"all", "-1", "ten"} {
+				t.Run(ep.path+sep+"limit="+limit, func(t *testing.T) {
+					w := serve(r, "GET", ep.path+sep+"limit="+limit, "")
+					if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "limit") {
+						t.Errorf("status %d: %s, want 400 naming limit", w.Code, w.Body.String())
+					}
+				})
+			}
+		}
+	}
+
+	// GraphQL clamps its limit arguments the same way, without a header
+	for _, tt := range []struct {
+		limit int
+		want  int
+	}{{0, 100}, {7, 7}, {1000000, respond.MaxLimit}} {
+		query := fmt.Sprintf(`{"query":"{ jobs(limit: %d) { id } }"}`, tt.limit)
+		w := serve(r, "POST", "/graphql", query)
+		var resp struct {
+			Data struct {
+				Jobs []struct{ ID string } `json:"jobs"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || len(resp.Data.Jobs) != tt.want {
+			t.Errorf("GraphQL jobs(limit: %d): %d jobs, want %d: %v %s", tt.limit, len(resp.Data.Jobs), tt.want, err, w.Body.String()[:min(200, w.Body.Len())])
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+)
+
+// TestApplicationOrder checks applications are listed newest first by
+// default and oldest first with order=oldest, on every surface that lists
+// them, with limits applied after ordering
+func TestApplicationOrder(t *testing.T) {
+	r := newTestRouter(t, func(c *Config) {
+		c.Jobs = testJobs()
+		c.TemplatesFS = os.DirFS("../templates")
+	})
+	// The same applicant applies to both jobs, then someone else to one
+	oldest := []string{submit(t, r, "vic@example.com")}
+	body := `{"job_id":"job_test_2","applicant_name":"Vic Tester","applicant_email":"vic@example.com",` +
+		`"resume":"Ten years of building web services in Go and Python."}`
+	w := serve(r, "POST", "/api/applications", body)
+	var resp models.ApplicationResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || w.Code != http.StatusCreated {
+		t.Fatalf("submitting: status %d: %s", w.Code, w.Body.String())
+	}
+	oldest = append(oldest, resp.ConfirmationID, submit(t, r, "ann@example.com"))
+	newest := slices.Clone(oldest)
+	slices.Reverse(newest)
+
+	// inOrder lists the IDs in the order they first appear in body
+	inOrder := func(body string) []string {
+		var found []string
+		for _, id := range oldest {
+			if strings.Contains(body, id) {
+				found = append(found, id)
+			}
+		}
+		slices.SortFunc(found, func(a, b string) int { return strings.Index(body, a) - strings.Index(body, b) })
+		return found
+	}
+
+	tests := []struct {
+		method, path, body string
+		want               []string
+	}{
+		{"GET", "/api/applications", "", newest},
+		{"GET", "/api/applications?order=newest", "", newest},
+		{"GET", "/api/applications?order=oldest", "", oldest},
+		{"GET", "/api/applications?limit=1", "", newest[:1]},
+		{"GET", "/api/applications?order=oldest&limit=2", "", oldest[:2]},
+		{"GET", "/api/applications?email=vic%40example.com&limit=1", "", oldest[1:2]},
+		{"GET", "/api/applications?job_id=job_test_1", "", []string{oldest[2], oldest[0]}},
+		{"GET", "/api/applications?job_id=job_test_1&order=oldest", "", []string{oldest[0], oldest[2]}},
+		{"GET", "/api/applications/export", "", newest},
+		{"GET", "/api/applications/export?order=oldest&format=jsonl", "", oldest},
+		{"GET", "/my-applications?email=vic%40example.com", "", []string{oldest[1], oldest[0]}},
+		{"GET", "/my-applications?email=vic%40example.com&order=oldest", "", oldest[:2]},
+		{"GET", "/my-applications?email=vic%40example.com&limit=1", "", oldest[1:2]},
+		{"POST", "/graphql", `{"query":"{ applications { confirmationId } }"}`, newest},
+		{"POST", "/graphql", `{"query":"{ applications(order: \"oldest\", limit: 2) { confirmationId } }"}`, oldest[:2]},
+		{"POST", "/graphql", `{"query":"{ applications(email: \"vic@example.com\") { confirmationId } }"}`, []string{oldest[1], oldest[0]}},
+		{"POST", "/graphql", `{"query":"{ job(id: \"job_test_1\") { applications { confirmationId } } }"}`, []string{oldest[2], oldest[0]}},
+	}
+	for _, tt := range tests {
+		w := serve(r, tt.method, tt.path, tt.body)
+		if w.Code != http.StatusOK {
+			t.Errorf("%s %s: status %d: %s", tt.method, tt.path, w.Code, w.Body.String())
+			continue
+		}
+		if got := inOrder(w.Body.String()); !slices.Equal(got, tt.want) {
+			t.Errorf("%s %s %s: %v, want %v", tt.method, tt.path, tt.body, got, tt.want)
+		}
+	}
+
+	var info struct {
+		Ordering map[string]string `json:"ordering"`
+	}
+	w = serve(r, "GET", "/api", "")
+	if err := json.Unmarshal(w.Body.Bytes(), &info); err != nil || !strings.Contains(info.Ordering["applications"], "newest first") {
+		t.Errorf("GET /api ordering %v, %v, want applications described as newest first", info.Ordering, err)
+	}
+}
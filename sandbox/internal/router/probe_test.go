@@ -0,0 +1,218 @@
+package router
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// volatileHeaders change between two identical requests. Content-Length
+// does too for bodies holding timings, such as uptime.
+var volatileHeaders = []string{"Date", "Content-Length", "X-Request-Id", "X-Ratelimit-Remaining", "X-Ratelimit-Reset", "Ratelimit-Remaining", "Ratelimit-Reset"}
+
+// probeServer serves the router with every route enabled
+func probeServer(t *testing.T) (*gin.Engine, *httptest.Server) {
+	r := newTestRouter(t, func(c *Config) {
+		logger := c.Logger
+		*c = fullConfig()
+		c.Logger = logger
+		c.Jobs = testJobs()
+		// Enough for every route twice, so no request is rate limited
+		c.GeneralRateLimit = 10000
+	})
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return r, srv
+}
+
+// concretePath fills a route's parameters so it can be requested
+func concretePath(route string) string {
+	segments := strings.Split(route, "/")
+	for i, segment := range segments {
+		switch {
+		case strings.HasPrefix(segment, ":"):
+			segments[i] = "job_test_1"
+		case strings.HasPrefix(segment, "*"):
+			segments[i] = "x"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// probeResponse is the status, headers and body of one request
+type probeResponse struct {
+	code   int
+	header http.Header
+	body   []byte
+}
+
+// probe sends one request through a real server, which drops HEAD bodies
+// as in production, giving up after a moment so streaming endpoints return.
+// It carries the admin token.
+func probe(t *testing.T, srv *httptest.Server, method, path string) probeResponse {
+	t.Helper()
+	return probeAs(t, srv, method, path, "Bearer test-admin-token")
+}
+
+// probeAs is probe sending authorization, or no credentials when it is ""
+func probeAs(t *testing.T, srv *httptest.Server, method, path, authorization string) probeResponse {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, srv.URL+path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	// Streams end when the deadline passes, so a read error is expected
+	body, _ := io.ReadAll(resp.Body)
+	return probeResponse{code: resp.StatusCode, header: resp.Header, body: body}
+}
+
+// stableHeaders drops the headers that differ between identical requests
+func stableHeaders(h http.Header) http.Header {
+	h = h.Clone()
+	for _, name := range volatileHeaders {
+		h.Del(name)
+	}
+	return h
+}
+
+// TestHeadMatchesGet walks the route table and checks that HEAD on every
+// GET route answers the GET's status and headers without a body
+func TestHeadMatchesGet(t *testing.T) {
+	r, srv := probeServer(t)
+	checked := 0
+	for _, route := range r.Routes() {
+		if route.Method != http.MethodGet {
+			continue
+		}
+		checked++
+		t.Run(route.Path, func(t *testing.T) {
+			path := concretePath(route.Path)
+			get := probe(t, srv, http.MethodGet, path)
+			head := probe(t, srv, http.MethodHead, path)
+
+			if head.code != get.code {
+				t.Errorf("HEAD status %d, GET status %d", head.code, get.code)
+			}
+			if got, want := stableHeaders(head.header), stableHeaders(get.header); !headersEqual(got, want) {
+				t.Errorf("HEAD headers\n%v\nGET headers\n%v", got, want)
+			}
+			if len(head.body) != 0 {
+				t.Errorf("HEAD has a %d byte body", len(head.body))
+			}
+			if get.header.Get("X-RateLimit-Limit") != "" && head.header.Get("X-RateLimit-Remaining") == "" {
+				t.Error("HEAD lacks X-RateLimit-Remaining")
+			}
+		})
+	}
+	if checked == 0 {
+		t.Fatal("no GET routes")
+	}
+}
+
+// TestHeadMatchesGetWithoutCredentials walks the route table and checks
+// that HEAD without credentials is refused wherever GET is, so middleware
+// of a route's group such as adminAuth guards HEAD too
+func TestHeadMatchesGetWithoutCredentials(t *testing.T) {
+	r, srv := probeServer(t)
+	guarded := 0
+	for _, route := range r.Routes() {
+		if route.Method != http.MethodGet {
+			continue
+		}
+		t.Run(route.Path, func(t *testing.T) {
+			path := concretePath(route.Path)
+			get := probeAs(t, srv, http.MethodGet, path, "")
+			head := probeAs(t, srv, http.MethodHead, path, "")
+			if head.code != get.code {
+				t.Errorf("HEAD status %d, GET status %d", head.code, get.code)
+			}
+			if get.code == http.StatusUnauthorized {
+				guarded++
+			}
+		})
+	}
+	if guarded == 0 {
+		t.Fatal("no GET route needs credentials")
+	}
+}
+
+// TestOptionsAllow walks the route table and checks that OPTIONS on every
+// path answers 204 with exactly the methods registered on it
+func TestOptionsAllow(t *testing.T) {
+	r, srv := probeServer(t)
+	methods := make(map[string][]string)
+	for _, route := range r.Routes() {
+		methods[route.Path] = append(methods[route.Path], route.Method)
+	}
+
+	for path, registered := range methods {
+		t.Run(path, func(t *testing.T) {
+			if !slices.Contains(registered, http.MethodOptions) {
+				t.Fatal("no OPTIONS route")
+			}
+			if slices.Contains(registered, http.MethodGet) && !slices.Contains(registered, http.MethodHead) {
+				t.Error("GET route without HEAD")
+			}
+
+			w := probe(t, srv, http.MethodOptions, concretePath(path))
+			if w.code != http.StatusNoContent {
+				t.Fatalf("status %d, want 204", w.code)
+			}
+			want := slices.Clone(registered)
+			sort.Strings(want)
+			got := strings.Split(w.header.Get("Allow"), ", ")
+			if !slices.Equal(got, want) {
+				t.Errorf("Allow %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+// TestProbeUnknownPath checks that HEAD and OPTIONS do not answer for
+// paths no route serves
+func TestProbeUnknownPath(t *testing.T) {
+	_, srv := probeServer(t)
+	for _, method := range []string{http.MethodHead, http.MethodOptions} {
+		if w := probe(t, srv, method, "/api/no-such-route"); w.code != http.StatusNotFound {
+			t.Errorf("%s on an unknown path: status %d, want 404", method, w.code)
+		}
+	}
+}
+
+// headersEqual compares headers ignoring the order of repeated values
+func headersEqual(a, b http.Header) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, values := range a {
+		other := b[name]
+		if len(values) != len(other) {
+			return false
+		}
+		x, y := slices.Clone(values), slices.Clone(other)
+		sort.Strings(x)
+		sort.Strings(y)
+		if !slices.Equal(x, y) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,131 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+)
+
+// TestProblemJSONErrorPaths checks that every kind of error, from handlers
+// and middleware alike, is a complete problem document both when the
+// client asks for one and when problem mode is on for everyone
+func TestProblemJSONErrorPaths(t *testing.T) {
+	const adminToken = "test-admin-token"
+	tests := []struct {
+		name      string
+		configure func(*Config)
+		setup     func(r http.Handler) // Requests made before the checked one
+		method    string
+		path      string
+		body      string
+		header    []string
+		status    int
+		code      string
+	}{
+		{name: "unknown job", method: "GET", path: "/api/jobs/job_missing",
+			status: http.StatusNotFound, code: "job_not_found"},
+		{name: "validation", method: "POST", path: "/api/applications", body: `{"job_id":"job_001"}`,
+			status: http.StatusBadRequest, code: "validation_failed"},
+		{name: "unknown route", method: "GET", path: "/api/no-such-route",
+			status: http.StatusNotFound, code: "route_not_found"},
+		{name: "admin token", configure: func(c *Config) { c.AdminToken = adminToken },
+			method: "GET", path: "/admin/failures", status: http.StatusUnauthorized, code: "unauthorized"},
+		{name: "api key", method: "GET", path: "/api/jobs", header: []string{"X-API-Key", "sk_unknown"},
+			status: http.StatusUnauthorized, code: "invalid_api_key"},
+		{name: "applicant token", configure: func(c *Config) { c.RequireAuth = true },
+			method: "GET", path: "/api/applications", status: http.StatusUnauthorized, code: "authentication_required"},
+		{name: "challenge", configure: func(c *Config) { c.Challenge = "pow" },
+			method: "POST", path: "/api/applications", body: `{}`,
+			status: http.StatusPreconditionRequired, code: "challenge_required"},
+		{name: "rate limit", configure: func(c *Config) { c.GeneralRateLimit = 1 },
+			setup:  func(r http.Handler) { serve(r, "GET", "/api/jobs", "") },
+			method: "GET", path: "/api/jobs", status: http.StatusTooManyRequests, code: "rate_limit_exceeded"},
+		{name: "simulated failure", configure: func(c *Config) { c.DebugFaults = true },
+			method: "GET", path: "/api/jobs", header: []string{"X-Simulate", "503"},
+			status: http.StatusServiceUnavailable, code: "simulated_failure"},
+		{name: "bad simulate header", configure: func(c *Config) { c.DebugFaults = true },
+			method: "GET", path: "/api/jobs", header: []string{"X-Simulate", "sometimes"},
+			status: http.StatusBadRequest, code: "invalid_simulate_header"},
+		{name: "maintenance", configure: func(c *Config) { c.AdminToken = adminToken },
+			setup: func(r http.Handler) {
+				serve(r, "PUT", "/admin/maintenance", `{"duration":"1m"}`, "Authorization", "Bearer "+adminToken)
+			},
+			method: "GET", path: "/api/jobs", status: http.StatusServiceUnavailable, code: "maintenance"},
+	}
+
+	modes := []struct {
+		name   string
+		global bool
+		accept string
+	}{
+		{name: "negotiated", accept: respond.ProblemContentType},
+		{name: "global", global: true, accept: "application/json"},
+	}
+
+	for _, mode := range modes {
+		for _, tt := range tests {
+			t.Run(mode.name+"/"+tt.name, func(t *testing.T) {
+				r := newTestRouter(t, func(c *Config) {
+					c.ProblemJSON = mode.global
+					if tt.configure != nil {
+						tt.configure(c)
+					}
+				})
+				if tt.setup != nil {
+					tt.setup(r)
+				}
+
+				w := serve(r, tt.method, tt.path, tt.body, append([]string{"Accept", mode.accept}, tt.header...)...)
+				if w.Code != tt.status {
+					t.Fatalf("status %d, want %d: %s", w.Code, tt.status, w.Body.String())
+				}
+				if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, respond.ProblemContentType) {
+					t.Errorf("Content-Type %q, want %s", got, respond.ProblemContentType)
+				}
+
+				var problem models.ProblemDetails
+				if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+					t.Fatalf("decoding %q: %v", w.Body.String(), err)
+				}
+				want := models.ProblemDetails{
+					Type:      "urn:job-portal-sandbox:error:" + tt.code,
+					Title:     http.StatusText(tt.status),
+					Status:    tt.status,
+					Detail:    problem.Detail,
+					Instance:  tt.path,
+					Error:     tt.code,
+					RequestID: w.Header().Get("X-Request-ID"),
+				}
+				if problem.Type != want.Type || problem.Title != want.Title || problem.Status != want.Status ||
+					problem.Instance != want.Instance || problem.Error != want.Error || problem.RequestID != want.RequestID {
+					t.Errorf("problem = %+v, want %+v", problem, want)
+				}
+				if problem.Detail == "" {
+					t.Error("problem has no detail")
+				}
+				if !reflect.DeepEqual(problem.Errors, problem.Violations) {
+					t.Errorf("errors %+v, want the violations %+v", problem.Errors, problem.Violations)
+				}
+			})
+		}
+	}
+}
+
+// TestProblemJSONOnlyWhenAsked checks that errors keep the flat body when
+// problem documents are neither negotiated nor enabled
+func TestProblemJSONOnlyWhenAsked(t *testing.T) {
+	r := newTestRouter(t, nil)
+	w := serve(r, "GET", "/api/jobs/job_missing", "")
+	if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, "application/json") {
+		t.Errorf("Content-Type %q, want application/json", got)
+	}
+	var body models.ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || body.Error != "job_not_found" || body.Code != http.StatusNotFound {
+		t.Errorf("body %s, want the flat job_not_found error", w.Body.String())
+	}
+}
@@ -1,11 +1,29 @@
 package router
 
 import (
+	"context"
+	"crypto/rand"
+	"fmt"
 	"io/fs"
+	"log"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/emailaddr"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/emulate"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/events"
 	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/handlers"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/lifecycle"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/market"
 	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/middleware"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/openapi"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/phone"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/review"
 	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
 	"github.com/gin-gonic/gin"
 )
@@ -20,12 +38,155 @@ type Config struct {
 	SlowdownRate float64
 	// TimeoutRate is the rate of timeouts (0.0 to 1.0)
 	TimeoutRate float64
+	// MalformedRate is the rate of truncated, mislabeled, HTML and padded
+	// responses (0.0 to 1.0)
+	MalformedRate float64
+	// FailureRoutes simulate failures on other routes, or on application
+	// submissions at other rates, keyed by method and route pattern such as
+	// "GET /api/jobs/search"
+	FailureRoutes map[string]middleware.FailureRates
+	// Latency delays every request to a route by a time drawn from its
+	// distribution, keyed by method and route pattern or
+	// middleware.LatencyAllRoutes for all routes without one
+	Latency map[string]middleware.LatencyDistribution
+	// FailureScenario scripts failures for particular requests, whether or
+	// not random failure simulation is enabled
+	FailureScenario *middleware.FailureScenario
+	// DebugFaults honors the X-Simulate header, which injects faults into
+	// the request that sends it
+	DebugFaults bool
 	// GeneralRateLimit is the rate limit for general endpoints (requests per minute)
 	GeneralRateLimit int
 	// ApplicationRateLimit is the rate limit for application submissions (requests per minute)
 	ApplicationRateLimit int
 	// TemplatesFS is the filesystem for templates (optional, for frontend)
 	TemplatesFS fs.FS
+	// ProblemJSON emits all errors as application/problem+json documents
+	ProblemJSON bool
+	// Debug enables developer tooling such as the GraphQL query console
+	Debug bool
+	// Emulate lists the ATS APIs to emulate ("greenhouse", "lever")
+	Emulate []string
+	// ATSProfile makes the sandbox pass for an ATS: emulate.ProfileNative
+	// (or empty) keeps its own error bodies, while greenhouse or lever also
+	// emulates that ATS's API and writes every error, unknown routes
+	// included, in its error body
+	ATSProfile string
+	// MCP serves the Model Context Protocol HTTP+SSE transport under /mcp
+	MCP bool
+	// GRPCAddr is where pkg/sandbox serves the JobPortal gRPC service, such
+	// as ":9090"; empty serves none
+	GRPCAddr string
+	// PhoneCountryCode is the calling code assumed for phone numbers submitted
+	// without one; empty disables E.164 normalization of such numbers
+	PhoneCountryCode string
+	// RelaxedProfileHosts accepts LinkedIn and GitHub links on any host
+	RelaxedProfileHosts bool
+	// Limits are the maximum lengths of application text
+	Limits models.ApplicationLimits
+	// EmailRules decide which applicant email addresses are accepted
+	EmailRules emailaddr.Rules
+	// Jobs replaces the built-in seed jobs when it is not nil
+	Jobs []models.Job
+	// Timezone is where date-only job dates are read; nil means UTC
+	Timezone *time.Location
+	// PropagationDelay hides new applications from reads through the API
+	// for a while after they are submitted, like a lagging read replica
+	PropagationDelay time.Duration
+	// EmailVerification holds new applications in pending_verification
+	// until the code emailed to the applicant is sent to
+	// POST /api/applications/:id/verify
+	EmailVerification bool
+	// RequireAuth makes applicants log in: the routes in applicantRoutes,
+	// gRPC and the pages need a token from POST /api/auth/login (or signing
+	// in) to read applications, and each applicant only sees and submits
+	// their own
+	RequireAuth bool
+	// AuthSecret signs applicant tokens; empty uses a random secret, so
+	// tokens stop working when the sandbox restarts
+	AuthSecret string
+	// AuthTokenTTL is how long applicant tokens are valid
+	AuthTokenTTL time.Duration
+	// OAuthClientID and OAuthClientSecret are the credentials of the client
+	// agents use with the mock OAuth provider under /oauth
+	OAuthClientID     string
+	OAuthClientSecret string
+	// OAuthApply makes the application form ask applicants to sign in with
+	// the mock OAuth provider first; it needs TemplatesFS
+	OAuthApply bool
+
+	// CSRF gives browsers a session cookie and makes the application form
+	// only accept posts carrying the session's CSRF token; it needs
+	// TemplatesFS
+	CSRF bool
+	// SessionTTL is how long those sessions last
+	SessionTTL time.Duration
+	// ApplyWizard turns the application form into a wizard of several
+	// pages and makes POST /api/applications and every other transport's
+	// submissions answer 409, so applications can only be submitted through
+	// drafts
+	ApplyWizard bool
+	// WorkdayFlow turns the application form into a deliberately awkward
+	// flow like Workday's: an account on each company's careers site,
+	// resume details entered again and many pages with inconsistently named
+	// inputs. It needs TemplatesFS and cannot be used with ApplyWizard.
+	WorkdayFlow bool
+	// HTMLVariants lays the application form out differently for each
+	// browser: element IDs, class names, field order and label wording
+	// follow from a seed kept in a cookie. It needs TemplatesFS and has no
+	// effect with ApplyWizard or WorkdayFlow.
+	HTMLVariants bool
+	// Honeypot adds hidden fields to the application form and watches the
+	// decoy API parameters: submissions filling them in are flagged
+	// (models.HoneypotFlag), or also answered as accepted but dropped
+	// (models.HoneypotShadow). Empty turns honeypots off.
+	Honeypot string
+	// Challenge is the kind of anti-bot challenge (puzzle, pow or delay)
+	// that must be solved through /api/challenges before submitting over
+	// any transport, which then needs the token in X-Challenge-Token.
+	// Empty turns challenges off.
+	Challenge string
+	// ChallengeDifficulty is how many leading zero bits proof-of-work
+	// challenges ask for
+	ChallengeDifficulty int
+	// ChallengeDelay is how long delay challenges must be waited out
+	ChallengeDelay time.Duration
+
+	// StrictWorkAuthorization rejects unrecognized work authorizations with
+	// a 422 instead of recording them as "other"
+	StrictWorkAuthorization bool
+	// AdminToken protects the /admin endpoints, which are only served when
+	// it is set
+	AdminToken string
+	// Persistence keeps jobs and applications across restarts; nil keeps
+	// them in memory only
+	Persistence store.Persistence
+	// StrictBinding rejects application, status update and admin job
+	// bodies with unknown fields instead of ignoring them
+	StrictBinding bool
+	// EndpointRateLimits replace GeneralRateLimit on individual routes,
+	// keyed by method and route pattern, such as
+	// {"GET /api/jobs": 200, "GET /api/applications/:id": 600} (requests per
+	// minute). Application submissions stay under ApplicationRateLimit too.
+	EndpointRateLimits map[string]int
+	// RateLimitAlgorithm is how requests are counted against the rate
+	// limits: middleware.FixedWindow (the default when empty) or
+	// middleware.SlidingWindow
+	RateLimitAlgorithm string
+	// RateLimitKey names the client each request is rate limited as; nil
+	// keys by API key, falling back to client IP
+	RateLimitKey middleware.KeyFunc
+	// Record captures the full requests and responses of runs for
+	// GET /admin/recordings/:run_id
+	Record bool
+	// Logger receives a record of every request; nil uses slog.Default()
+	Logger *slog.Logger
+	// Review advances applications through review on a schedule; a zero
+	// ReviewDelay leaves their status to the admin endpoints
+	Review review.Config
+	// Market posts, edits and closes jobs on a schedule; a zero Interval
+	// leaves the catalogue to the admin endpoints
+	Market market.Config
 }
 
 // DefaultConfig returns the default router configuration
@@ -35,55 +196,269 @@ func DefaultConfig() Config {
 		FailureRate:             0.05, // 5% failure rate
 		SlowdownRate:            0.03, // 3% slowdown rate
 		TimeoutRate:             0.02, // 2% timeout rate
-		GeneralRateLimit:        100,  // 100 requests per minute
-		ApplicationRateLimit:    30,   // 30 applications per minute
+		MalformedRate:           0,
+		GeneralRateLimit:        100, // 100 requests per minute
+		ApplicationRateLimit:    30,  // 30 applications per minute
+		FailureRoutes:           nil,
+		Latency:                 nil,
+		FailureScenario:         nil,
+		DebugFaults:             false,
+		EndpointRateLimits:      nil,
+		RateLimitAlgorithm:      middleware.FixedWindow,
+		RateLimitKey:            middleware.KeyByAPIKey,
 		TemplatesFS:             nil,
+		ProblemJSON:             false,
+		Debug:                   false,
+		Emulate:                 nil,
+		ATSProfile:              emulate.ProfileNative,
+		MCP:                     false,
+		GRPCAddr:                "",
+		PhoneCountryCode:        phone.DefaultCountryCode,
+		RelaxedProfileHosts:     false,
+		Limits:                  models.DefaultApplicationLimits(),
+		EmailRules:              emailaddr.Rules{},
+		Jobs:                    nil,
+		Timezone:                time.UTC,
+		StrictWorkAuthorization: false,
+		PropagationDelay:        0,
+		EmailVerification:       false,
+		RequireAuth:             false,
+		AuthSecret:              "",
+		AuthTokenTTL:            time.Hour,
+		OAuthClientID:           "sandbox-agent",
+		OAuthClientSecret:       "sandbox-secret",
+		OAuthApply:              false,
+		CSRF:                    false,
+		SessionTTL:              30 * time.Minute,
+		ApplyWizard:             false,
+		WorkdayFlow:             false,
+		HTMLVariants:            false,
+		Honeypot:                "",
+		Challenge:               "",
+		ChallengeDifficulty:     16,
+		ChallengeDelay:          3 * time.Second,
+		StrictBinding:           false,
+		Persistence:             nil,
+		AdminToken:              "",
+		Record:                  false,
+		Logger:                  nil,
+		Review: review.Config{
+			ReviewDelay:   0, // disabled
+			DecisionDelay: 2 * time.Minute,
+			RejectionRate: 0.5,
+		},
+		Market: market.Config{
+			Interval:  0, // disabled
+			PostRate:  0.5,
+			EditRate:  0.05,
+			CloseRate: 0.02,
+		},
 	}
 }
 
 // SetupRouter creates and configures the Gin router
 func SetupRouter(config Config) *gin.Engine {
+	return SetupRouterContext(context.Background(), config)
+}
+
+// SetupRouterContext creates and configures the Gin router. Background work
+// such as automatic review stops when ctx is done.
+func SetupRouterContext(ctx context.Context, config Config) *gin.Engine {
+	return SetupHandlers(ctx, config).API
+}
+
+// Handlers are the HTTP handlers of one sandbox, sharing its stores
+type Handlers struct {
+	// API serves the REST API and every other HTTP route
+	API *gin.Engine
+	// GRPC serves the JobPortal gRPC service; it must be mounted on a
+	// server speaking HTTP/2
+	GRPC http.Handler
+}
+
+// SetupHandlers creates the Gin router and the gRPC service over the same
+// stores. Background work such as automatic review stops when ctx is done.
+func SetupHandlers(ctx context.Context, config Config) Handlers {
 	// Create Gin router
 	router := gin.New()
 
 	// Initialize stores
-	jobStore := store.NewJobStore()
-	appStore := store.NewApplicationStore()
+	jobStore, appStore, err := NewStores(ctx, config)
+	if err != nil {
+		panic("Failed to create stores: " + err.Error())
+	}
+	webhookStore := store.NewWebhookStore()
+	mailStore := store.NewMailStore()
+	interviewStore := store.NewInterviewStore()
+	savedJobStore := store.NewSavedJobStore()
+	applicantStore := store.NewApplicantStore()
+	accountStore := store.NewAccountStore()
+	draftStore := store.NewDraftStore()
+	workdayStore := store.NewWorkdayStore()
+	challengeStore := store.NewChallengeStore()
+	runStore := store.NewRunStore()
+	agentStore := store.NewAgentStore()
+	apiKeyStore := store.NewAPIKeyStore()
+
+	// Every transport's submissions pass the same wizard, token and
+	// challenge checks
+	var challenges *store.ChallengeStore
+	if config.Challenge != "" {
+		challenges = challengeStore
+	}
+	submitGuard := handlers.NewSubmitGuard(challenges, config.ApplyWizard)
 
 	// Initialize handlers
 	jobHandler := handlers.NewJobHandler(jobStore, appStore)
-	appHandler := handlers.NewApplicationHandler(jobStore, appStore)
-	healthHandler := handlers.NewHealthHandler(jobStore, appStore)
+	appHandler := handlers.NewApplicationHandler(jobStore, appStore, applicantStore, submitGuard)
+	draftHandler := handlers.NewDraftHandler(jobStore, appStore, draftStore, submitGuard)
+	healthHandler := handlers.NewHealthHandler(jobStore, appStore, webhookStore)
+	graphqlHandler := handlers.NewGraphQLHandler(jobStore, appStore, submitGuard)
+	webhookHandler := handlers.NewWebhookHandler(webhookStore)
+	runHandler := handlers.NewRunHandler(runStore, appStore)
+	agentHandler := handlers.NewAgentHandler(agentStore)
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyStore)
+	appStore.OnStatusChange(webhookHandler.NotifyStatusChange)
+	eventsHandler := handlers.NewEventsHandler(events.NewBroker(), appStore)
+	jobStore.OnCreate(eventsHandler.NotifyJobCreated)
+	appStore.OnSubmit(eventsHandler.NotifySubmission)
+	appStore.OnStatusChange(eventsHandler.NotifyStatusChange)
+	mailboxHandler := handlers.NewMailboxHandler(mailStore)
+	appStore.OnSubmit(mailboxHandler.NotifySubmission)
+	appStore.OnStatusChange(mailboxHandler.NotifyStatusChange)
+	interviewHandler := handlers.NewInterviewHandler(appStore, interviewStore)
+	appStore.OnStatusChange(interviewHandler.NotifyStatusChange)
+	interviewStore.OnSchedule(mailboxHandler.NotifyInterviewScheduled)
+	assignmentHandler := handlers.NewAssignmentHandler(jobStore, appStore)
+	savedJobHandler := handlers.NewSavedJobHandler(jobStore, appStore, savedJobStore)
+	applicantHandler := handlers.NewApplicantHandler(appStore, applicantStore)
+	authSecret := []byte(config.AuthSecret)
+	if len(authSecret) == 0 {
+		authSecret = make([]byte, 32)
+		rand.Read(authSecret)
+	}
+	authTTL := config.AuthTokenTTL
+	if authTTL <= 0 {
+		authTTL = time.Hour
+	}
+	authHandler := handlers.NewAuthHandler(appStore, accountStore, authSecret, authTTL)
+	oauthHandler := handlers.NewOAuthHandler(accountStore, store.NewOAuthCodeStore(), authSecret, authTTL, config.OAuthClientID, config.OAuthClientSecret)
+	docsHandler, err := handlers.NewDocsHandler(openapi.Operations)
+	if err != nil {
+		panic("Failed to initialize docs handler: " + err.Error())
+	}
 
 	// Initialize rate limiters
-	generalLimiter := middleware.NewRateLimiter(config.GeneralRateLimit, time.Minute)
-	appLimiter := middleware.NewRateLimiter(config.ApplicationRateLimit, time.Minute)
+	generalLimiter, err := middleware.NewLimiter(config.RateLimitAlgorithm, config.GeneralRateLimit, time.Minute)
+	if err != nil {
+		panic("Failed to create rate limiter: " + err.Error())
+	}
+	appLimiter, err := middleware.NewLimiter(config.RateLimitAlgorithm, config.ApplicationRateLimit, time.Minute)
+	if err != nil {
+		panic("Failed to create rate limiter: " + err.Error())
+	}
+	limiters := []middleware.Limiter{generalLimiter, appLimiter}
+	routeLimiters := make(map[string]middleware.Limiter, len(config.EndpointRateLimits))
+	for route, rate := range config.EndpointRateLimits {
+		limiter, err := middleware.NewLimiter(config.RateLimitAlgorithm, rate, time.Minute)
+		if err != nil {
+			panic("Failed to create rate limiter: " + err.Error())
+		}
+		routeLimiters[route] = limiter
+		limiters = append(limiters, limiter)
+	}
+	limitKey := config.RateLimitKey
+	if limitKey == nil {
+		limitKey = middleware.KeyByAPIKey
+	}
+	applicationLimit := middleware.ApplicationRateLimitMiddleware(appLimiter, limitKey)
 
 	// Apply global middleware
+	// First, so every other middleware sees the method the client sent
+	router.Use(restoreHead)
 	router.Use(gin.Recovery())
 	router.Use(middleware.CORSMiddleware())
-	router.Use(middleware.LoggerMiddleware())
+	router.Use(middleware.LanguageMiddleware())
+	if config.ProblemJSON {
+		router.Use(middleware.ProblemJSONMiddleware())
+	}
+	if config.StrictBinding {
+		router.Use(middleware.StrictBindingMiddleware())
+	}
+	emulations := config.Emulate
+	switch config.ATSProfile {
+	case "", emulate.ProfileNative:
+	case emulate.ProfileGreenhouse, emulate.ProfileLever:
+		router.Use(middleware.ATSProfileMiddleware(config.ATSProfile))
+		if !slices.Contains(emulations, config.ATSProfile) {
+			emulations = append(slices.Clone(emulations), config.ATSProfile)
+		}
+	default:
+		panic("Unknown ATS profile: " + config.ATSProfile)
+	}
+	// Unknown routes get an error body like any other error: problem+json,
+	// JSON:API or an ATS's, as negotiated
+	router.NoRoute(handlers.RouteNotFound)
+	logger := config.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	router.Use(middleware.LoggerMiddleware(logger))
 	router.Use(middleware.ErrorHandlerMiddleware())
 	router.Use(middleware.RequestIDMiddleware())
-	router.Use(middleware.RateLimitMiddleware(generalLimiter))
+	router.Use(middleware.RunMiddleware(runStore))
+	router.Use(middleware.AgentStatsMiddleware(agentStore))
+	if config.Record {
+		router.Use(middleware.RecorderMiddleware(runStore))
+	}
+	router.Use(middleware.APIKeyMiddleware(apiKeyStore))
+	if config.RequireAuth {
+		// Guarding routes here rather than in their group covers the HEAD
+		// routes registerProbeRoutes adds too
+		router.Use(middleware.ApplicantAuthMiddleware(authSecret, appStore, applicantRoutes))
+	}
+	if config.Challenge != "" {
+		router.Use(middleware.ChallengeMiddleware())
+	}
+	router.Use(middleware.RateLimitMiddleware(generalLimiter, routeLimiters, limitKey))
 
-	// Optionally enable failure simulation
-	if config.EnableFailureSimulation {
-		failureSimulator := middleware.NewFailureSimulator(
-			config.FailureRate,
-			config.SlowdownRate,
-			config.TimeoutRate,
-		)
-		router.Use(middleware.FailureMiddleware(failureSimulator))
+	// Failure simulation is off unless enabled by flag or through /admin/failures
+	failureSimulator := middleware.NewFailureSimulator(
+		config.FailureRate,
+		config.SlowdownRate,
+		config.TimeoutRate,
+	)
+	if !config.EnableFailureSimulation {
+		failureSimulator.Disable()
+	}
+	failureSimulator.SetMalformedRate(config.MalformedRate)
+	failureSimulator.SetRoutes(config.FailureRoutes)
+	failureSimulator.SetLatency(config.Latency)
+	if config.FailureScenario != nil {
+		config.FailureScenario.Restart()
+		failureSimulator.SetScenario(config.FailureScenario)
+	}
+	// Maintenance windows and brownouts are started through /admin
+	maintenance := middleware.NewMaintenance()
+	if config.AdminToken != "" {
+		router.Use(middleware.MaintenanceMiddleware(maintenance))
+	}
+	if config.DebugFaults {
+		router.Use(middleware.FaultHeaderMiddleware())
 	}
+	router.Use(middleware.FailureMiddleware(failureSimulator, limitKey))
 
 	// Health endpoints (no rate limiting)
 	router.GET("/health", healthHandler.HealthCheck)
 	router.GET("/ready", healthHandler.ReadinessCheck)
 	router.GET("/live", healthHandler.LivenessCheck)
 
-	// API info endpoint
+	// API info and documentation endpoints
 	router.GET("/api", healthHandler.GetAPIInfo)
+	router.GET("/api/openapi.json", docsHandler.OpenAPISpec)
+	router.GET("/api/docs", docsHandler.DocsPage)
+	router.GET("/openapi.json", docsHandler.OpenAPISpec)
+	router.GET("/docs", docsHandler.DocsPage)
 
 	// API routes
 	api := router.Group("/api")
@@ -93,31 +468,221 @@ func SetupRouter(config Config) *gin.Engine {
 		{
 			jobs.GET("", jobHandler.ListJobs)
 			jobs.GET("/search", jobHandler.SearchJobs)
+			jobs.GET("/stream", jobHandler.StreamJobs)
+			jobs.GET("/facets", jobHandler.GetJobFacets)
 			jobs.GET("/:id", jobHandler.GetJob)
 			jobs.GET("/:id/requirements", jobHandler.GetJobRequirements)
+			jobs.GET("/:id/application-schema", jobHandler.GetApplicationSchema)
 		}
 
 		// Companies endpoints
 		api.GET("/companies/:company/jobs", jobHandler.GetJobsByCompany)
 
+		// Applicant registration and login
+		auth := api.Group("/auth")
+		{
+			auth.POST("/register", authHandler.Register)
+			auth.POST("/login", authHandler.Login)
+		}
+
 		// Applications endpoints (stricter rate limiting)
 		applications := api.Group("/applications")
 		{
-			applications.POST("", middleware.ApplicationRateLimitMiddleware(appLimiter), appHandler.SubmitApplication)
+			// Submissions are rate limited more strictly
+			applications.POST("", applicationLimit, appHandler.SubmitApplication)
 			applications.GET("", appHandler.ListApplications)
+			applications.GET("/export", appHandler.ExportApplications)
 			applications.GET("/:id", appHandler.GetApplication)
+			applications.PATCH("/:id", appHandler.UpdateApplication)
 			applications.GET("/:id/receipt", appHandler.GetApplicationReceipt)
+			applications.GET("/:id/score", appHandler.GetApplicationScore)
+			applications.GET("/:id/timeline", appHandler.GetApplicationTimeline)
+			applications.POST("/:id/withdraw", appHandler.WithdrawApplication)
+			applications.POST("/:id/verify", appHandler.VerifyApplication)
+			applications.GET("/:id/interview-slots", interviewHandler.GetInterviewSlots)
+			applications.POST("/:id/schedule", interviewHandler.ScheduleInterview)
+			applications.GET("/:id/interview.ics", interviewHandler.GetInterviewCalendar)
+			applications.GET("/:id/assignment", assignmentHandler.GetAssignment)
+			applications.POST("/:id/assignment", assignmentHandler.SubmitAssignment)
 			applications.PATCH("/:id/status", appHandler.UpdateApplicationStatus)
 			applications.DELETE("/clear", appHandler.ClearAllApplications)
+
+			// The application wizard, one step at a time
+			applications.POST("/draft", draftHandler.CreateDraft)
+			applications.GET("/draft/:id", draftHandler.GetDraft)
+			applications.PATCH("/draft/:id/:step", draftHandler.UpdateStep)
+			applications.POST("/draft/:id/review", draftHandler.ReviewDraft)
+			applications.POST("/draft/:id/submit", applicationLimit, draftHandler.SubmitDraft)
+		}
+
+		// Anti-bot challenges guarding submission
+		if config.Challenge != "" {
+			if !slices.Contains(models.ChallengeKinds, config.Challenge) {
+				panic("Unknown challenge: " + config.Challenge)
+			}
+			challengeHandler := handlers.NewChallengeHandler(challengeStore, config.Challenge, config.ChallengeDifficulty, config.ChallengeDelay)
+			api.POST("/challenges", challengeHandler.CreateChallenge)
+			api.POST("/challenges/:id/solve", challengeHandler.SolveChallenge)
+		}
+
+		// Simulated applicant email
+		api.GET("/mailbox", mailboxHandler.GetMailbox)
+
+		// Jobs bookmarked to apply to later
+		savedJobs := api.Group("/saved-jobs")
+		{
+			savedJobs.POST("", savedJobHandler.SaveJob)
+			savedJobs.GET("", savedJobHandler.GetSavedJobs)
+			savedJobs.DELETE("/:job_id", savedJobHandler.UnsaveJob)
 		}
 
-		// Stats endpoint
+		// Applicant profiles applications can be submitted from
+		applicants := api.Group("/applicants")
+		{
+			applicants.POST("", applicantHandler.CreateApplicant)
+			applicants.GET("/:id", applicantHandler.GetApplicant)
+			applicants.GET("/:id/applications", applicantHandler.GetApplicantApplications)
+		}
+
+		// Agent runs
+		runs := api.Group("/runs")
+		{
+			runs.POST("", runHandler.CreateRun)
+			runs.GET("/:id/report", runHandler.GetReport)
+			runs.GET("/:id/export", runHandler.ExportRun)
+			runs.PUT("/:id/profile", runHandler.SetProfile)
+			runs.POST("/:id/complete", runHandler.CompleteRun)
+		}
+		api.GET("/leaderboard", runHandler.GetLeaderboard)
+
+		// The caller's own API key usage
+		api.GET("/usage", apiKeyHandler.GetUsage)
+
+		// Event stream (Server-Sent Events)
+		api.GET("/events", eventsHandler.Stream)
+		api.GET("/events/log", eventsHandler.GetLog)
+
+		// Discovery endpoints
+		api.GET("/meta/work-authorizations", appHandler.GetWorkAuthorizations)
+
+		// Stats endpoints
 		api.GET("/stats", healthHandler.GetStats)
+		api.GET("/stats/review-latency", healthHandler.GetReviewLatency)
+		api.GET("/stats/agents", agentHandler.GetAgentStats)
+	}
+
+	// Admin endpoints (token required)
+	if config.AdminToken != "" {
+		adminHandler := handlers.NewAdminHandler(failureSimulator, jobStore, appStore, mailStore, interviewStore, savedJobStore, applicantStore, accountStore, draftStore, workdayStore, challengeStore, limiters...)
+		adminAuth := middleware.AdminAuthMiddleware(config.AdminToken)
+		admin := router.Group("/admin", adminAuth)
+		admin.GET("/failures", adminHandler.GetFailures)
+		admin.PUT("/failures", adminHandler.UpdateFailures)
+		admin.POST("/reset", adminHandler.Reset)
+		admin.POST("/snapshot", adminHandler.Snapshot)
+		admin.POST("/restore", adminHandler.Restore)
+		maintenanceHandler := handlers.NewMaintenanceHandler(maintenance)
+		admin.GET("/maintenance", maintenanceHandler.GetMaintenance)
+		admin.PUT("/maintenance", maintenanceHandler.StartMaintenance)
+		admin.DELETE("/maintenance", maintenanceHandler.EndMaintenance)
+		admin.GET("/brownout", maintenanceHandler.GetBrownout)
+		admin.PUT("/brownout", maintenanceHandler.StartBrownout)
+		admin.DELETE("/brownout", maintenanceHandler.EndBrownout)
+		admin.POST("/api-keys", apiKeyHandler.CreateKey)
+		admin.GET("/api-keys", apiKeyHandler.ListKeys)
+		admin.GET("/api-keys/:id", apiKeyHandler.GetKey)
+		admin.DELETE("/api-keys/:id", apiKeyHandler.RevokeKey)
+		admin.GET("/recordings/:run_id", handlers.NewRecordingHandler(runStore).GetRecordings)
+		router.GET("/api/interviews.ics", adminAuth, interviewHandler.ExportInterviewCalendar)
+
+		adminJobs := router.Group("/api/admin/jobs", adminAuth)
+		adminJobs.POST("", adminHandler.CreateJob)
+		adminJobs.PUT("/:id", adminHandler.UpdateJob)
+		adminJobs.POST("/:id/close", adminHandler.CloseJob)
+		adminJobs.PATCH("/:id/status", adminHandler.UpdateJobStatus)
+		adminJobs.DELETE("/:id", adminHandler.DeleteJob)
+
+		// Webhook endpoints, which hand out signing secrets
+		webhooks := router.Group("/api/webhooks", adminAuth)
+		webhooks.POST("", webhookHandler.CreateWebhook)
+		webhooks.GET("", webhookHandler.ListWebhooks)
+		webhooks.PATCH("/:id", webhookHandler.UpdateWebhook)
+		webhooks.DELETE("/:id", webhookHandler.DeleteWebhook)
+		webhooks.GET("/:id/stats", webhookHandler.GetWebhookStats)
+		webhooks.GET("/:id/dead-letters", webhookHandler.ListDeadLetters)
+		webhooks.POST("/:id/dead-letters/redeliver", webhookHandler.RedeliverDeadLetters)
+	}
+
+	// Mock OAuth2 provider ("Sign in with SandboxID")
+	oauth := router.Group("/oauth")
+	{
+		oauth.GET("/authorize", oauthHandler.Authorize)
+		oauth.POST("/authorize", oauthHandler.Approve)
+		oauth.POST("/token", oauthHandler.Token)
+		oauth.GET("/userinfo", oauthHandler.UserInfo)
+	}
+
+	// GraphQL endpoints
+	router.POST("/graphql", graphqlHandler.Query)
+	router.GET("/graphql/schema", graphqlHandler.Schema)
+	if config.Debug {
+		router.GET("/graphql", graphqlHandler.Console)
+	}
+
+	// MCP endpoints (HTTP+SSE transport)
+	if config.MCP {
+		mcpHandler := handlers.NewMCPHandler(jobStore, appStore, applicantStore, submitGuard)
+		router.GET("/mcp/sse", mcpHandler.SSE)
+		router.POST("/mcp/message", mcpHandler.Message)
+	}
+
+	// ATS emulation endpoints (opt-in, each under its own prefix)
+	for _, name := range emulations {
+		switch name {
+		case "greenhouse":
+			greenhouseHandler := handlers.NewGreenhouseHandler(jobStore, appStore, submitGuard)
+			greenhouse := router.Group("/v1/boards/:token/jobs")
+			greenhouse.GET("", greenhouseHandler.ListJobs)
+			greenhouse.GET("/:id", greenhouseHandler.GetJob)
+			greenhouse.POST("/:id", applicationLimit, greenhouseHandler.SubmitApplication)
+		case "lever":
+			leverHandler := handlers.NewLeverHandler(jobStore, appStore, submitGuard)
+			lever := router.Group("/v0/postings/:site")
+			lever.GET("", leverHandler.ListPostings)
+			lever.GET("/:id", leverHandler.GetPosting)
+			lever.POST("/:id/apply", applicationLimit, leverHandler.Apply)
+		default:
+			panic("Unknown emulation: " + name)
+		}
 	}
 
 	// Frontend page routes (if templates are provided)
 	if config.TemplatesFS != nil {
-		pageHandler, err := handlers.NewPageHandler(jobStore, appStore, config.TemplatesFS)
+		var signIn *handlers.OAuthHandler
+		if config.OAuthApply || config.RequireAuth {
+			signIn = oauthHandler
+		}
+		var sessions *store.FormSessionStore
+		if config.CSRF {
+			sessionTTL := config.SessionTTL
+			if sessionTTL <= 0 {
+				sessionTTL = 30 * time.Minute
+			}
+			sessions = store.NewFormSessionStore(sessionTTL)
+		}
+		var drafts *store.DraftStore
+		if config.ApplyWizard {
+			drafts = draftStore
+		}
+		var workday *store.WorkdayStore
+		if config.WorkdayFlow {
+			workday = workdayStore
+		}
+		var variants *handlers.FormVariants
+		if config.HTMLVariants {
+			variants = handlers.NewFormVariants()
+		}
+		pageHandler, err := handlers.NewPageHandler(jobStore, appStore, savedJobStore, signIn, sessions, drafts, workday, variants, config.TemplatesFS)
 		if err != nil {
 			panic("Failed to initialize page handler: " + err.Error())
 		}
@@ -131,14 +696,192 @@ func SetupRouter(config Config) *gin.Engine {
 
 		// Apply page
 		router.GET("/jobs/:id/apply", pageHandler.ApplyPage)
+		router.POST("/jobs/:id/apply", applicationLimit, pageHandler.SubmitApplyForm)
+		router.GET("/jobs/:id/apply/:step", pageHandler.WizardStepPage)
+		router.POST("/jobs/:id/apply/:step", pageHandler.SubmitWizardStep)
+		// The last step of the wizard or the Workday-style flow submits the
+		// application
+		router.POST("/jobs/:id/apply/review", applicationLimit, pageHandler.SubmitWizardReview)
+		router.POST("/jobs/:id/save", pageHandler.SaveJob)
 
 		// Application routes
 		router.GET("/applications", pageHandler.MyApplicationsPage)
 		router.GET("/applications/:id", pageHandler.ApplicationDetailPage)
 		router.GET("/applications/:id/success", pageHandler.ApplicationSuccessPage)
+		router.POST("/applications/:id/withdraw", pageHandler.WithdrawApplication)
 		router.GET("/my-applications", pageHandler.MyApplicationsPage)
 		router.GET("/lookup", pageHandler.ApplicationLookup)
+
+		// Where the OAuth provider sends applicants signing in to the pages
+		router.GET("/oauth/callback", pageHandler.OAuthCallback)
+	}
+
+	// Answer HEAD on every GET route and OPTIONS on every path
+	registerProbeRoutes(router)
+
+	// Catch per-route settings that name no route, which would never apply
+	registered := make(map[string]bool)
+	for _, route := range router.Routes() {
+		registered[route.Method+" "+route.Path] = true
+	}
+	for route := range config.EndpointRateLimits {
+		if !registered[route] {
+			log.Printf("⚠️  Warning: rate limit for %s matches no route", route)
+		}
+	}
+	for route := range config.FailureRoutes {
+		if !registered[route] {
+			log.Printf("⚠️  Warning: failure rates for %s match no route", route)
+		}
+	}
+	for route := range config.Latency {
+		if route != middleware.LatencyAllRoutes && !registered[route] {
+			log.Printf("⚠️  Warning: latency for %s matches no route", route)
+		}
 	}
 
-	return router
+	// Keep the OpenAPI document in sync with the registered routes
+	for _, route := range openapi.MissingRoutes(router.Routes(), openapi.Operations) {
+		log.Printf("⚠️  Warning: route %s is not documented in the OpenAPI spec", route)
+	}
+
+	var grpcSecret []byte
+	if config.RequireAuth {
+		grpcSecret = authSecret
+	}
+	return Handlers{
+		API:  router,
+		GRPC: handlers.NewGRPCHandler(jobStore, appStore, applicantStore, submitGuard, grpcSecret),
+	}
+}
+
+// NewStores creates the job and application stores with config's settings,
+// restores them from config.Persistence and runs the review, market and
+// lifecycle workers over them until ctx is done. Every transport, MCP over
+// stdio included, builds its stores here so all of them apply the same
+// rules.
+func NewStores(ctx context.Context, config Config) (*store.JobStore, *store.ApplicationStore, error) {
+	jobStore, err := store.NewJobStore(config.Jobs, config.Timezone)
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading jobs: %w", err)
+	}
+	appStore := store.NewApplicationStore()
+	appStore.SetPhoneCountryCode(config.PhoneCountryCode)
+	appStore.SetRelaxedProfileHosts(config.RelaxedProfileHosts)
+	appStore.SetLimits(config.Limits)
+	appStore.SetEmailRules(config.EmailRules)
+	appStore.SetStrictWorkAuthorization(config.StrictWorkAuthorization)
+	appStore.SetPropagationDelay(config.PropagationDelay)
+	appStore.SetEmailVerification(config.EmailVerification)
+	appStore.SetHoneypot(config.Honeypot)
+	if config.Persistence != nil {
+		if err := jobStore.Restore(config.Persistence); err != nil {
+			return nil, nil, fmt.Errorf("restoring jobs: %w", err)
+		}
+		if err := appStore.Restore(config.Persistence); err != nil {
+			return nil, nil, fmt.Errorf("restoring applications: %w", err)
+		}
+	}
+
+	if config.Review.ReviewDelay > 0 {
+		go review.NewEngine(appStore, config.Review).Run(ctx)
+	}
+	if config.Market.Interval > 0 {
+		go market.NewEngine(jobStore, config.Market).Run(ctx)
+	}
+	go lifecycle.NewWorker(jobStore, appStore).Run(ctx)
+	return jobStore, appStore, nil
+}
+
+// applicantRoutes are the routes that read or change applications, and
+// how they are guarded under RequireAuth. Every other transport that reads
+// applications (gRPC, MCP and the pages) checks tokens the same way. Status
+// changes and clearing stand in for the employer and are left out.
+var applicantRoutes = map[string]middleware.ApplicantAccess{
+	"/api/applications":                     middleware.ApplicantRequired,
+	"/api/applications/export":              middleware.ApplicantRequired,
+	"/api/applications/:id":                 middleware.ApplicationOwner,
+	"/api/applications/:id/receipt":         middleware.ApplicationOwner,
+	"/api/applications/:id/score":           middleware.ApplicationOwner,
+	"/api/applications/:id/timeline":        middleware.ApplicationOwner,
+	"/api/applications/:id/withdraw":        middleware.ApplicationOwner,
+	"/api/applications/:id/verify":          middleware.ApplicationOwner,
+	"/api/applications/:id/interview-slots": middleware.ApplicationOwner,
+	"/api/applications/:id/schedule":        middleware.ApplicationOwner,
+	"/api/applications/:id/interview.ics":   middleware.ApplicationOwner,
+	"/api/applications/:id/assignment":      middleware.ApplicationOwner,
+	"/api/applications/draft":               middleware.ApplicantRequired,
+	"/api/applications/draft/:id":           middleware.ApplicantRequired,
+	"/api/applications/draft/:id/:step":     middleware.ApplicantRequired,
+	"/api/applications/draft/:id/review":    middleware.ApplicantRequired,
+	"/api/applications/draft/:id/submit":    middleware.ApplicantRequired,
+	"/api/applicants/:id/applications":      middleware.ApplicantRequired,
+	"/api/saved-jobs":                       middleware.ApplicantRequired,
+	"/api/mailbox":                          middleware.ApplicantRequired,
+	"/api/events/log":                       middleware.ApplicantRequired,
+	"/api/events":                           middleware.ApplicantOptional,
+	"/graphql":                              middleware.ApplicantOptional,
+	"/mcp/sse":                              middleware.ApplicantOptional,
+	"/mcp/message":                          middleware.ApplicantOptional,
+	// The ATS emulations serve jobs to anyone; submitting needs a token
+	"/v1/boards/:token/jobs/:id":   middleware.ApplicantOptional,
+	"/v0/postings/:site/:id/apply": middleware.ApplicantRequired,
+}
+
+// headKey is the request context key marking a HEAD request passed on to
+// the GET route it mirrors
+type headKey struct{}
+
+// restoreHead turns a HEAD request that registerProbeRoutes passed on to a
+// GET route back into a HEAD request, so the route's middleware and
+// handler see the method the client sent. It must be the first middleware.
+func restoreHead(c *gin.Context) {
+	if c.Request.Context().Value(headKey{}) != nil {
+		c.Request.Method = http.MethodHead
+	}
+	c.Next()
+}
+
+// registerProbeRoutes registers HEAD for every GET route, running the GET
+// route's whole handler chain so headers and auth match (net/http drops the
+// body), and OPTIONS for every path, answering 204 with an Allow list of
+// the methods registered on it. It must run after all other routes are
+// registered.
+func registerProbeRoutes(router *gin.Engine) {
+	// Gin only exposes a route's last handler, which would skip middleware
+	// of its group such as adminAuth. HEAD routes instead hand the request
+	// to the GET route, from a group without middleware so none runs twice.
+	head := router.Group("")
+	head.Handlers = nil
+	passToGet := func(c *gin.Context) {
+		req := c.Request.Clone(context.WithValue(c.Request.Context(), headKey{}, true))
+		req.Method = http.MethodGet
+		c.Request = req
+		router.HandleContext(c)
+	}
+
+	methods := make(map[string][]string)
+	var paths []string
+	for _, route := range router.Routes() {
+		if _, seen := methods[route.Path]; !seen {
+			paths = append(paths, route.Path)
+		}
+		methods[route.Path] = append(methods[route.Path], route.Method)
+
+		if route.Method == http.MethodGet {
+			head.HEAD(route.Path, passToGet)
+			methods[route.Path] = append(methods[route.Path], http.MethodHead)
+		}
+	}
+
+	for _, path := range paths {
+		allowed := append(methods[path], http.MethodOptions)
+		sort.Strings(allowed)
+		allow := strings.Join(allowed, ", ")
+
+		router.OPTIONS(path, func(c *gin.Context) {
+			c.Header("Allow", allow)
+			c.Status(http.StatusNoContent)
+		})
+	}
 }
@@ -0,0 +1,98 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// newTestRouter builds the API router from the default config as changed
+// by configure
+func newTestRouter(t *testing.T, configure func(*Config)) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	config := DefaultConfig()
+	config.Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	if configure != nil {
+		configure(&config)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	return SetupHandlers(ctx, config).API
+}
+
+// serve sends one request to the router; header holds name/value pairs
+func serve(r http.Handler, method, path, body string, header ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// testJobs are open jobs whose deadlines never pass, so tests do not
+// depend on the seed jobs or today's date
+func testJobs() []models.Job {
+	return []models.Job{
+		{
+			ID:                  "job_test_1",
+			Title:               "Backend Engineer",
+			Company:             "Acme",
+			Description:         "Build the services behind Acme's storefront.",
+			Requirements:        []string{"Go", "SQL"},
+			Location:            "Berlin, Germany",
+			Salary:              "$120,000 - $150,000",
+			ExperienceRequired:  3,
+			JobType:             "full-time",
+			PostedAt:            "2026-01-15T10:00:00Z",
+			ApplicationDeadline: "2099-12-31T23:59:59Z",
+			Benefits:            []string{"Remote days", "Training budget"},
+			Questions: []models.ScreeningQuestion{
+				{ID: "why_us", Label: "Why Acme?", Type: models.QuestionText, Required: true},
+			},
+		},
+		{
+			ID:                  "job_test_2",
+			Title:               "Data Analyst Intern",
+			Company:             "Globex",
+			Description:         "Help Globex's analysts answer questions with data.",
+			Requirements:        []string{"SQL"},
+			Location:            "Remote",
+			IsRemote:            true,
+			Salary:              "$4,000/month",
+			JobType:             "internship",
+			PostedAt:            "2026-02-01T10:00:00Z",
+			ApplicationDeadline: "2099-12-31T23:59:59Z",
+		},
+	}
+}
+
+// submit posts an application to job_test_1 and returns its confirmation
+// ID; header holds name/value pairs
+func submit(t *testing.T, r http.Handler, email string, header ...string) string {
+	t.Helper()
+	body := `{"job_id":"job_test_1","applicant_name":"Vic Tester","applicant_email":"` + email + `",` +
+		`"resume":"Ten years of building web services in Go and Python.","custom_answers":{"why_us":"The storefront, & its <scale>"}}`
+	w := serve(r, "POST", "/api/applications", body, header...)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("submitting: status %d: %s", w.Code, w.Body.String())
+	}
+	var resp models.ApplicationResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	return resp.ConfirmationID
+}
@@ -0,0 +1,44 @@
+package router
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/openapi"
+	"github.com/gin-gonic/gin"
+)
+
+// fullConfig enables every flag that registers routes, so the router
+// serves every documented operation
+func fullConfig() Config {
+	config := DefaultConfig()
+	config.TemplatesFS = os.DirFS("../templates")
+	config.AdminToken = "test-admin-token"
+	config.Debug = true
+	config.MCP = true
+	config.Emulate = []string{"greenhouse", "lever"}
+	config.OAuthApply = true
+	config.CSRF = true
+	config.ApplyWizard = true
+	config.WorkdayFlow = true
+	config.HTMLVariants = true
+	config.Challenge = "pow"
+	return config
+}
+
+// TestRoutesMatchOpenAPI fails when a route is added without documenting
+// it, or an operation is documented that the router no longer serves
+func TestRoutesMatchOpenAPI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	routes := SetupHandlers(ctx, fullConfig()).API.Routes()
+
+	for _, route := range openapi.MissingRoutes(routes, openapi.Operations) {
+		t.Errorf("route %s is not documented in internal/openapi/operations.go", route)
+	}
+	for _, op := range openapi.StaleOperations(routes, openapi.Operations) {
+		t.Errorf("operation %s is documented but no route serves it", op)
+	}
+}
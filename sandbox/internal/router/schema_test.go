@@ -0,0 +1,197 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/openapi"
+)
+
+// schemaJob asks a question of every type, required and optional
+var schemaJob = models.Job{
+	ID:                  "job_schema",
+	Title:               "Platform Engineer",
+	Company:             "Initech",
+	Description:         "Keep Initech's platform running.",
+	Requirements:        []string{"Go"},
+	Location:            "Austin, TX",
+	Salary:              "$130,000 - $160,000",
+	JobType:             "full-time",
+	PostedAt:            "2026-03-01T10:00:00Z",
+	ApplicationDeadline: "2099-12-31T23:59:59Z",
+	Questions: []models.ScreeningQuestion{
+		{ID: "why_us", Label: "Why Initech?", Type: models.QuestionText, Required: true},
+		{ID: "referral", Label: "Referral code", Type: models.QuestionText, Pattern: `[A-Z]{3}-[0-9]{4}`},
+		{ID: "years", Label: "Years of Go", Type: models.QuestionNumber, Required: true},
+		{ID: "relocate", Label: "Willing to relocate?", Type: models.QuestionBoolean},
+		{ID: "level", Label: "Level", Type: models.QuestionSelect, Required: true, Options: []string{"Junior", "Mid", "Senior (L5+)"}},
+		{ID: "stack", Label: "Stack", Type: models.QuestionMultiSelect, Options: []string{"Go", "Rust", "C++"}},
+	},
+}
+
+// validate reports the first way value breaks schema, or "" if it does
+// not. It covers the keywords the application schemas use.
+func validate(schema *openapi.Schema, value interface{}, path string) string {
+	if len(schema.Enum) > 0 {
+		s, _ := value.(string)
+		if !slices.Contains(schema.Enum, s) {
+			return path + " is not in enum"
+		}
+	}
+	switch schema.Type {
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return path + " is not a string"
+		}
+		n := utf8.RuneCountInString(s)
+		if schema.MinLength != nil && n < *schema.MinLength {
+			return path + " is too short"
+		}
+		if schema.MaxLength != nil && n > *schema.MaxLength {
+			return path + " is too long"
+		}
+		// Patterns are unanchored, as in JSON Schema
+		if schema.Pattern != "" && !regexp.MustCompile(schema.Pattern).MatchString(s) {
+			return path + " does not match " + schema.Pattern
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return path + " is not a boolean"
+		}
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return path + " is not an object"
+		}
+		for _, name := range schema.Required {
+			if _, ok := object[name]; !ok {
+				return path + "." + name + " is required"
+			}
+		}
+		for name, v := range object {
+			property := schema.Properties[name]
+			if property == nil {
+				property = schema.AdditionalProperties
+			}
+			if property == nil {
+				continue
+			}
+			if problem := validate(property, v, path+"."+name); problem != "" {
+				return problem
+			}
+		}
+	}
+	return ""
+}
+
+// TestApplicationSchemaAgreesWithEndpoint validates sample payloads against
+// the job's application schema and submits them, expecting the schema to
+// accept exactly the payloads the endpoint does
+func TestApplicationSchemaAgreesWithEndpoint(t *testing.T) {
+	r := newTestRouter(t, func(c *Config) {
+		c.Jobs = append(testJobs(), schemaJob)
+		c.ApplicationRateLimit = 1000
+		c.Limits.ApplicantName = 20
+		c.Limits.CustomAnswer = 30
+	})
+
+	w := serve(r, "GET", "/api/jobs/job_schema/application-schema", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body.String())
+	}
+	var schema openapi.Schema
+	if err := json.Unmarshal(w.Body.Bytes(), &schema); err != nil {
+		t.Fatal(err)
+	}
+
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"job_id":         "job_schema",
+			"applicant_name": "Vic Tester",
+			"resume":         "Ten years of building web services in Go and Python.",
+			"custom_answers": map[string]interface{}{
+				"why_us": "The platform",
+				"years":  "4",
+				"level":  "Mid",
+			},
+		}
+	}
+	tests := []struct {
+		name  string
+		edit  func(app map[string]interface{}, answers map[string]interface{})
+		valid bool
+	}{
+		{"required fields only", func(app, answers map[string]interface{}) {}, true},
+		{"every answer", func(app, answers map[string]interface{}) {
+			answers["referral"] = "ABC-1234"
+			answers["relocate"] = "true"
+			answers["stack"] = "Go, C++"
+			answers["level"] = "Senior (L5+)"
+		}, true},
+		{"no name", func(app, answers map[string]interface{}) { delete(app, "applicant_name") }, false},
+		{"empty resume", func(app, answers map[string]interface{}) { app["resume"] = "" }, false},
+		{"name at the limit", func(app, answers map[string]interface{}) { app["applicant_name"] = strings.Repeat("é", 20) }, true},
+		{"name over the limit", func(app, answers map[string]interface{}) { app["applicant_name"] = strings.Repeat("é", 21) }, false},
+		{"answer at the limit", func(app, answers map[string]interface{}) { answers["why_us"] = strings.Repeat("日", 30) }, true},
+		{"answer over the limit", func(app, answers map[string]interface{}) { answers["why_us"] = strings.Repeat("日", 31) }, false},
+		{"no answers", func(app, answers map[string]interface{}) { delete(app, "custom_answers") }, false},
+		{"required answer missing", func(app, answers map[string]interface{}) { delete(answers, "why_us") }, false},
+		{"required answer empty", func(app, answers map[string]interface{}) { answers["why_us"] = "" }, false},
+		{"required answer blank", func(app, answers map[string]interface{}) { answers["why_us"] = "  \t" }, false},
+		{"optional answers empty", func(app, answers map[string]interface{}) {
+			answers["referral"] = ""
+			answers["relocate"] = ""
+			answers["stack"] = ""
+		}, true},
+		{"optional answers blank", func(app, answers map[string]interface{}) {
+			answers["referral"] = " "
+			answers["relocate"] = "  "
+			answers["stack"] = "\n"
+		}, true},
+		{"pattern matches", func(app, answers map[string]interface{}) { answers["referral"] = "XYZ-0001" }, true},
+		{"pattern matches part", func(app, answers map[string]interface{}) { answers["referral"] = "XYZ-00012" }, false},
+		{"pattern mismatch", func(app, answers map[string]interface{}) { answers["referral"] = "xyz-0001" }, false},
+		{"decimal number", func(app, answers map[string]interface{}) { answers["years"] = " -2.5e1 " }, true},
+		{"fraction number", func(app, answers map[string]interface{}) { answers["years"] = ".5" }, true},
+		{"word for a number", func(app, answers map[string]interface{}) { answers["years"] = "four" }, false},
+		{"infinite number", func(app, answers map[string]interface{}) { answers["years"] = "Inf" }, false},
+		{"hex number", func(app, answers map[string]interface{}) { answers["years"] = "0x10" }, false},
+		{"boolean spellings", func(app, answers map[string]interface{}) { answers["relocate"] = " FALSE" }, true},
+		{"boolean digit", func(app, answers map[string]interface{}) { answers["relocate"] = "1" }, true},
+		{"boolean word", func(app, answers map[string]interface{}) { answers["relocate"] = "yes" }, false},
+		{"select other case", func(app, answers map[string]interface{}) { answers["level"] = "mid" }, false},
+		{"select padded", func(app, answers map[string]interface{}) { answers["level"] = " Mid" }, false},
+		{"select regexp characters", func(app, answers map[string]interface{}) { answers["level"] = "Senior (L5)" }, false},
+		{"multi select one", func(app, answers map[string]interface{}) { answers["stack"] = "Rust" }, true},
+		{"multi select unknown", func(app, answers map[string]interface{}) { answers["stack"] = "Go,Java" }, false},
+		{"multi select empty choice", func(app, answers map[string]interface{}) { answers["stack"] = "Go,,Rust" }, false},
+		{"multi select regexp characters", func(app, answers map[string]interface{}) { answers["stack"] = "C+" }, false},
+		{"unknown answer", func(app, answers map[string]interface{}) { answers["extra"] = "anything" }, true},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := valid()
+			app["applicant_email"] = fmt.Sprintf("schema%d@example.com", i)
+			tt.edit(app, app["custom_answers"].(map[string]interface{}))
+
+			problem := validate(&schema, app, "$")
+			if (problem == "") != tt.valid {
+				t.Errorf("schema: %q, want valid %v", problem, tt.valid)
+			}
+
+			body, _ := json.Marshal(app)
+			w := serve(r, "POST", "/api/applications", string(body))
+			if (w.Code == http.StatusCreated) != tt.valid {
+				t.Errorf("endpoint: status %d, want valid %v: %s", w.Code, tt.valid, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+)
+
+// typoCase is a request body with misspelled keys, the same body with them
+// removed, and the hints strict binding gives for each key
+type typoCase struct {
+	name, method, path string
+	typod, clean       string
+	hints              map[string]string // Unknown key -> suggested field, "" for none
+}
+
+// typoCases are typo'd bodies for every endpoint strict binding covers.
+// The paths hold {app}, replaced by an application ID.
+var typoCases = []typoCase{
+	{
+		name: "application", method: "POST", path: "/api/applications",
+		typod: `{"job_id":"job_test_2","applicant_name":"Vic Tester","applicant_email":"vic@example.com",` +
+			`"resume":"Ten years of building web services in Go and Python.","applicant_mail":"vic@example.com","cover_leter":"Hi","coverLetter":"Hi","favourite_colour":"teal"}`,
+		clean: `{"job_id":"job_test_2","applicant_name":"Vic Tester","applicant_email":"vic@example.com",` +
+			`"resume":"Ten years of building web services in Go and Python."}`,
+		hints: map[string]string{"applicant_mail": "applicant_email", "cover_leter": "cover_letter", "coverLetter": "cover_letter", "favourite_colour": ""},
+	},
+	{
+		name: "application status", method: "PATCH", path: "/api/applications/{app}/status",
+		typod: `{"status":"reviewing","note":"Looks good","Stauts":"reviewing"}`,
+		clean: `{"status":"reviewing"}`,
+		hints: map[string]string{"note": "notes", "Stauts": "status"},
+	},
+	{
+		name: "job create", method: "POST", path: "/api/admin/jobs",
+		typod: `{"id":"job_typo","title":"Platform Engineer","company":"Acme","description":"Run the platform.","location":"Berlin",` +
+			`"job_type":"full-time","application_deadline":"2099-12-31","deadline":"2099-12-31","remote":true,"salery":"$100,000"}`,
+		clean: `{"id":"job_typo","title":"Platform Engineer","company":"Acme","description":"Run the platform.","location":"Berlin",` +
+			`"job_type":"full-time","application_deadline":"2099-12-31"}`,
+		hints: map[string]string{"deadline": "application_deadline", "remote": "is_remote", "salery": "salary"},
+	},
+	{
+		name: "job update", method: "PUT", path: "/api/admin/jobs/job_test_2",
+		typod: `{"title":"Data Analyst","company":"Globex","description":"Answer questions with data.","location":"Remote",` +
+			`"job_type":"internship","max_aplications":3}`,
+		clean: `{"title":"Data Analyst","company":"Globex","description":"Answer questions with data.","location":"Remote",` +
+			`"job_type":"internship"}`,
+		hints: map[string]string{"max_aplications": "max_applications"},
+	},
+	{
+		name: "job status", method: "PATCH", path: "/api/admin/jobs/job_test_2/status",
+		typod: `{"status":"paused","reason":"Hiring freeze"}`,
+		clean: `{"status":"paused"}`,
+		hints: map[string]string{"reason": ""},
+	},
+}
+
+// serveTypoCase sends one body of a case to a fresh router, with an
+// application to job_test_1 already made, and returns the response with
+// the application's ID blanked out
+func serveTypoCase(t *testing.T, strict bool, tc typoCase, body string) (int, string) {
+	t.Helper()
+	r := newTestRouter(t, func(c *Config) {
+		c.Jobs = testJobs()
+		c.AdminToken = "test-admin-token"
+		c.StrictBinding = strict
+	})
+	app := submit(t, r, "ann@example.com")
+	w := serve(r, tc.method, strings.ReplaceAll(tc.path, "{app}", app), body, "Authorization", "Bearer test-admin-token")
+	return w.Code, strings.ReplaceAll(w.Body.String(), app, "{app}")
+}
+
+// TestPermissiveBindingIgnoresUnknownFields checks that without strict
+// binding a typo'd body gets the same response as one without the typos
+func TestPermissiveBindingIgnoresUnknownFields(t *testing.T) {
+	for _, tc := range typoCases {
+		t.Run(tc.name, func(t *testing.T) {
+			typodCode, typod := serveTypoCase(t, false, tc, tc.typod)
+			cleanCode, clean := serveTypoCase(t, false, tc, tc.clean)
+			if typodCode >= 400 {
+				t.Fatalf("status %d: %s", typodCode, typod)
+			}
+			if typodCode != cleanCode || stripVolatile(t, typod) != stripVolatile(t, clean) {
+				t.Errorf("typo'd body got %d %s\nwant, as without the typos, %d %s", typodCode, typod, cleanCode, clean)
+			}
+		})
+	}
+}
+
+// stripVolatile blanks the fields of a JSON object that differ between
+// two otherwise identical requests: generated IDs and times
+func stripVolatile(t *testing.T, body string) string {
+	t.Helper()
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &object); err != nil {
+		t.Fatalf("%v: %s", err, body)
+	}
+	for key := range object {
+		if strings.HasSuffix(key, "_id") || strings.HasSuffix(key, "_at") || key == "id" || key == "timestamp" ||
+			key == "status_url" || key == "receipt_url" || key == "estimated_response" {
+			object[key] = ""
+		}
+	}
+	stripped, _ := json.Marshal(object)
+	return string(stripped)
+}
+
+// TestStrictBindingRejectsUnknownFields checks strict binding lists every
+// unknown key, with a hint where a field is close, and still accepts the
+// body without them
+func TestStrictBindingRejectsUnknownFields(t *testing.T) {
+	for _, tc := range typoCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if code, body := serveTypoCase(t, true, tc, tc.clean); code >= 400 {
+				t.Fatalf("body without typos: status %d: %s", code, body)
+			}
+
+			code, body := serveTypoCase(t, true, tc, tc.typod)
+			var resp models.ErrorResponse
+			if err := json.Unmarshal([]byte(body), &resp); err != nil {
+				t.Fatal(err)
+			}
+			if code != http.StatusBadRequest && code != http.StatusUnprocessableEntity {
+				t.Fatalf("status %d: %s, want a rejection", code, body)
+			}
+			if got := len(resp.Violations); got != len(tc.hints) {
+				t.Errorf("%d violations, want one per unknown key %v: %s", got, tc.hints, body)
+			}
+			if !reflect.DeepEqual(resp.Errors, resp.Violations) {
+				t.Errorf("errors %+v, want the violations %+v", resp.Errors, resp.Violations)
+			}
+			for _, v := range resp.Violations {
+				hint, unknown := tc.hints[v.Field]
+				switch {
+				case !unknown:
+					t.Errorf("violation for %s, which is not an unknown key", v.Field)
+				case v.Code != "unknown_field":
+					t.Errorf("%s: code %s, want unknown_field", v.Field, v.Code)
+				case hint == "" && strings.Contains(v.Message, "Did you mean"):
+					t.Errorf("%s: %q, want no suggestion", v.Field, v.Message)
+				case hint != "" && !strings.HasSuffix(v.Message, "Did you mean "+hint+"?"):
+					t.Errorf("%s: %q, want a suggestion of %s", v.Field, v.Message, hint)
+				}
+			}
+		})
+	}
+}
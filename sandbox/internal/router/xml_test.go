@@ -0,0 +1,145 @@
+package router
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+)
+
+// TestXMLRoundTrip checks that each XML read endpoint answers well-formed
+// XML that decodes to everything its JSON response holds
+func TestXMLRoundTrip(t *testing.T) {
+	r := newTestRouter(t, func(c *Config) { c.Jobs = testJobs() })
+	id := submit(t, r, "vic@example.com")
+
+	tests := []struct {
+		path  string
+		model interface{}
+	}{
+		{"/api/jobs", models.JobsResponse{}},
+		{"/api/jobs/job_test_1", models.JobDetailResponse{}},
+		{"/api/jobs/search?q=engineer", models.JobSearchResponse{}},
+		{"/api/applications/" + id, models.ApplicationStatusResponse{}},
+		{"/api/stats", models.StatsResponse{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			typ := reflect.TypeOf(tt.model)
+
+			w := serve(r, "GET", tt.path, "", "Accept", "application/xml")
+			if w.Code != http.StatusOK {
+				t.Fatalf("status %d: %s", w.Code, w.Body.String())
+			}
+			if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, "application/xml") {
+				t.Errorf("Content-Type %q, want application/xml", got)
+			}
+			if err := wellFormed(w.Body.String()); err != nil {
+				t.Fatalf("malformed XML: %v\n%s", err, w.Body.String())
+			}
+			fromXML := reflect.New(typ).Interface()
+			if err := xml.Unmarshal(w.Body.Bytes(), fromXML); err != nil {
+				t.Fatalf("decoding XML: %v", err)
+			}
+
+			w = serve(r, "GET", tt.path, "")
+			if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, "application/json") {
+				t.Errorf("Content-Type without Accept %q, want the JSON default", got)
+			}
+			fromJSON := reflect.New(typ).Interface()
+			if err := json.Unmarshal(w.Body.Bytes(), fromJSON); err != nil {
+				t.Fatalf("decoding JSON: %v", err)
+			}
+
+			if got, want := normalized(t, fromXML), normalized(t, fromJSON); !reflect.DeepEqual(got, want) {
+				t.Errorf("XML holds\n%v\nJSON holds\n%v", got, want)
+			}
+		})
+	}
+}
+
+// TestXMLCustomAnswers checks the key/value form of custom answers,
+// including text that must be escaped
+func TestXMLCustomAnswers(t *testing.T) {
+	r := newTestRouter(t, func(c *Config) { c.Jobs = testJobs() })
+	id := submit(t, r, "vic@example.com")
+
+	w := serve(r, "GET", "/api/applications/"+id, "", "Accept", "application/xml")
+	want := `<custom_answers><entry key="why_us">The storefront, &amp; its &lt;scale&gt;</entry></custom_answers>`
+	if !strings.Contains(w.Body.String(), want) {
+		t.Errorf("XML lacks %s:\n%s", want, w.Body.String())
+	}
+}
+
+// TestXMLNotAcceptable checks that unsupported media types are refused
+// with the supported ones listed
+func TestXMLNotAcceptable(t *testing.T) {
+	r := newTestRouter(t, func(c *Config) { c.Jobs = testJobs() })
+	w := serve(r, "GET", "/api/jobs", "", "Accept", "application/yaml")
+	if w.Code != http.StatusNotAcceptable {
+		t.Fatalf("status %d, want 406", w.Code)
+	}
+	for _, supported := range []string{"application/json", "application/xml"} {
+		if !strings.Contains(w.Body.String(), supported) {
+			t.Errorf("406 body does not list %s: %s", supported, w.Body.String())
+		}
+	}
+}
+
+// wellFormed reads every token of an XML document
+func wellFormed(doc string) error {
+	d := xml.NewDecoder(strings.NewReader(doc))
+	for {
+		_, err := d.Token()
+		if err != nil {
+			if err.Error() == "EOF" {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// normalized converts a decoded response to generic JSON values, dropping
+// empty arrays and objects, which XML cannot tell apart from absent ones
+func normalized(t *testing.T, v interface{}) interface{} {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var generic interface{}
+	if err := json.Unmarshal(b, &generic); err != nil {
+		t.Fatal(err)
+	}
+	return dropEmpty(generic)
+}
+
+func dropEmpty(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, field := range v {
+			field = dropEmpty(field)
+			if field == nil {
+				delete(v, k)
+			} else {
+				v[k] = field
+			}
+		}
+		if len(v) == 0 {
+			return nil
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = dropEmpty(v[i])
+		}
+		if len(v) == 0 {
+			return nil
+		}
+	}
+	return v
+}
@@ -0,0 +1,137 @@
+package scoring
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+)
+
+// skillTerms are the technologies an application can be caught claiming,
+// each mapped to the name it is compared under. Names that are common
+// words, such as Go, Spring and Excel, are left out; "golang" stands in
+// for Go.
+var skillTerms = map[string]string{
+	"python": "python", "java": "java", "golang": "go", "rust": "rust", "c++": "c++",
+	"c#": "c#", "javascript": "javascript", "js": "javascript", "typescript": "typescript",
+	"ts": "typescript", "ruby": "ruby", "kotlin": "kotlin", "scala": "scala",
+	"swift": "swift", "php": "php", "sql": "sql", "postgresql": "postgresql",
+	"postgres": "postgresql", "mysql": "mysql", "mongodb": "mongodb", "redis": "redis",
+	"kafka": "kafka", "spark": "spark", "hadoop": "hadoop", "aws": "aws", "gcp": "gcp",
+	"azure": "azure", "docker": "docker", "kubernetes": "kubernetes", "k8s": "kubernetes",
+	"terraform": "terraform", "react": "react", "angular": "angular", "vue": "vue",
+	"node.js": "node.js", "nodejs": "node.js", "django": "django", "flask": "flask",
+	"rails": "rails", "tensorflow": "tensorflow", "pytorch": "pytorch", "graphql": "graphql",
+	"linux": "linux", "figma": "figma", "tableau": "tableau", "salesforce": "salesforce",
+}
+
+// degreeLevels are the degrees an application can claim, highest first.
+// Abbreviations only count in capitals, so "ms" and "ba" in prose do not.
+var degreeLevels = []struct {
+	name    string
+	pattern *regexp.Regexp
+}{
+	{"doctorate", regexp.MustCompile(`(?i:\bdoctorate\b|\bdoctoral\b)|\bPh\.? ?D\b`)},
+	{"master's degree", regexp.MustCompile(`(?i:\bmaster'?s\b)|\b(?:MSc|MS|M\.S|MEng|M\.Eng|MBA|MTech|M\.Tech)\b`)},
+	{"bachelor's degree", regexp.MustCompile(`(?i:\bbachelor'?s?\b)|\b(?:BSc|BS|B\.S|BA|B\.A|BEng|B\.Eng|BTech|B\.Tech)\b`)},
+}
+
+// Fabrications finds the claims of an application that contradict the
+// candidate profile: more years of experience than the candidate has,
+// technologies missing from their skills, and degrees above the highest
+// they hold. Like Score it matches words, so a claim is only as clear as
+// the text making it.
+func Fabrications(profile models.CandidateProfile, app models.Application) []models.Fabrication {
+	sources := []source{
+		{name: "resume", text: app.Resume, words: words(app.Resume)},
+		{name: "cover_letter", text: app.CoverLetter, words: words(app.CoverLetter)},
+	}
+	ids := make([]string, 0, len(app.CustomAnswers))
+	for id := range app.CustomAnswers {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	for _, id := range ids {
+		answer := app.CustomAnswers[id]
+		// A bare number answering a question about years is a claim of years
+		if _, err := strconv.Atoi(strings.TrimSpace(answer)); err == nil && strings.Contains(id, "year") {
+			answer = strings.TrimSpace(answer) + " years"
+		}
+		sources = append(sources, source{name: "custom_answers." + id, text: answer, words: words(answer)})
+	}
+
+	found := []models.Fabrication{}
+	add := func(kind, claim, truth string, evidence models.Evidence) {
+		found = append(found, models.Fabrication{
+			ApplicationID: app.ConfirmationID,
+			JobID:         app.JobID,
+			Kind:          kind,
+			Claim:         claim,
+			Truth:         truth,
+			Evidence:      evidence,
+		})
+	}
+
+	experience := matchExperience(profile.YearsOfExperience+1, sources)
+	if experience.Met {
+		add(models.FabricatedExperience, fmt.Sprintf("%d years", experience.StatedYears),
+			fmt.Sprintf("%d years", profile.YearsOfExperience), *experience.Evidence)
+	}
+
+	has := make(map[string]bool)
+	for word := range words(strings.Join(profile.Skills, "\n")) {
+		if term, ok := skillTerms[word]; ok {
+			has[term] = true
+		} else {
+			has[word] = true
+		}
+	}
+	claimed := make(map[string]bool)
+	for _, src := range sources {
+		for _, sentence := range sentences(src.text) {
+			for _, word := range tokenize(sentence) {
+				term, ok := skillTerms[word]
+				if !ok || has[term] || claimed[term] {
+					continue
+				}
+				claimed[term] = true
+				add(models.FabricatedSkill, term, "not among the candidate's skills",
+					models.Evidence{Source: src.name, Text: excerpt(sentence)})
+			}
+		}
+	}
+
+	held := len(degreeLevels)
+	for level, degree := range degreeLevels {
+		if slices.ContainsFunc(profile.Degrees, degree.pattern.MatchString) {
+			held = level
+			break
+		}
+	}
+	truth := "no degree"
+	if held < len(degreeLevels) {
+		truth = degreeLevels[held].name
+	}
+	for _, degree := range degreeLevels[:held] {
+		if evidence, ok := findClaim(degree.pattern, sources); ok {
+			add(models.FabricatedDegree, degree.name, truth, evidence)
+			break
+		}
+	}
+	return found
+}
+
+// findClaim returns the first sentence matching pattern
+func findClaim(pattern *regexp.Regexp, sources []source) (models.Evidence, bool) {
+	for _, src := range sources {
+		for _, sentence := range sentences(src.text) {
+			if pattern.MatchString(sentence) {
+				return models.Evidence{Source: src.name, Text: excerpt(sentence)}, true
+			}
+		}
+	}
+	return models.Evidence{}, false
+}
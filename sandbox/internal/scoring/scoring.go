@@ -0,0 +1,239 @@
+// Package scoring rates how well an application matches its job. Each
+// requirement is reduced to keywords, which are looked for as whole words
+// in the resume and cover letter; the years of experience the applicant
+// claims are compared with the job's. It is keyword matching, not
+// understanding: it gives agents a signal to optimise against, not a
+// hiring decision.
+package scoring
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/fold"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+)
+
+const (
+	// requirementsWeight is the share of the match score given to the
+	// requirements; the rest is for experience
+	requirementsWeight = 0.8
+	// matchedThreshold is the requirement score counted as matched
+	matchedThreshold = 0.5
+	// maxEvidence is how many excerpts are kept per requirement
+	maxEvidence = 2
+	// maxExcerpt is the longest excerpt kept, in characters
+	maxExcerpt = 200
+)
+
+// stopWords are words that say nothing about a skill
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "any": true, "as": true, "at": true, "be": true,
+	"by": true, "e.g": true, "etc": true, "for": true, "from": true, "in": true, "is": true,
+	"of": true, "on": true, "or": true, "the": true, "to": true, "with": true, "within": true,
+	"ability": true, "able": true, "excellent": true, "experience": true, "experienced": true,
+	"equivalent": true, "familiarity": true, "good": true, "knowledge": true, "plus": true,
+	"proficiency": true, "proficient": true, "related": true, "skills": true, "strong": true,
+	"solid": true, "understanding": true, "working": true, "year": true, "years": true,
+	"field": true, "similar": true, "deep": true, "record": true, "currently": true,
+}
+
+// yearsPattern finds claims such as "5 years", "3+ yrs" or "10 years'"
+var yearsPattern = regexp.MustCompile(`(?i)\b(\d{1,2})\s*\+?\s*(?:years?|yrs?)\b`)
+
+// Score rates an application's resume and cover letter against job
+func Score(job models.Job, resume, coverLetter string) models.ApplicationScore {
+	sources := []source{
+		{name: "resume", text: resume, words: words(resume)},
+		{name: "cover_letter", text: coverLetter, words: words(coverLetter)},
+	}
+
+	score := models.ApplicationScore{
+		JobID:        job.ID,
+		Requirements: make([]models.RequirementMatch, 0, len(job.Requirements)),
+	}
+	total, scored := 0.0, 0
+	for _, requirement := range job.Requirements {
+		match := matchRequirement(requirement, sources)
+		score.Requirements = append(score.Requirements, match)
+		if len(match.Keywords) > 0 {
+			total += match.Score
+			scored++
+		}
+	}
+
+	score.Experience = matchExperience(job.ExperienceRequired, sources)
+	experience := 1.0
+	if !score.Experience.Met {
+		experience = float64(score.Experience.StatedYears) / float64(score.Experience.RequiredYears)
+	}
+
+	overall := experience
+	if scored > 0 {
+		overall = requirementsWeight*total/float64(scored) + (1-requirementsWeight)*experience
+	}
+	score.MatchScore = int(overall*100 + 0.5)
+	return score
+}
+
+// source is one piece of applicant text
+type source struct {
+	name  string
+	text  string
+	words map[string]bool
+}
+
+// matchRequirement looks for the keywords of one requirement
+func matchRequirement(requirement string, sources []source) models.RequirementMatch {
+	match := models.RequirementMatch{
+		Requirement: requirement,
+		Keywords:    keywords(requirement),
+		Found:       []string{},
+		Evidence:    []models.Evidence{},
+	}
+	for _, keyword := range match.Keywords {
+		for _, src := range sources {
+			if src.words[keyword] {
+				match.Found = append(match.Found, keyword)
+				break
+			}
+		}
+	}
+	if len(match.Keywords) == 0 {
+		return match
+	}
+
+	alternatives := strings.Contains(fold.String(requirement), " or ")
+	switch {
+	case alternatives && len(match.Found) > 0:
+		match.Score = 1
+	default:
+		match.Score = float64(len(match.Found)) / float64(len(match.Keywords))
+	}
+	match.Score = float64(int(match.Score*100+0.5)) / 100
+	match.Matched = match.Score >= matchedThreshold
+
+	for _, src := range sources {
+		for _, sentence := range sentences(src.text) {
+			if len(match.Evidence) == maxEvidence {
+				return match
+			}
+			sentenceWords := words(sentence)
+			for _, keyword := range match.Found {
+				if sentenceWords[keyword] {
+					match.Evidence = append(match.Evidence, models.Evidence{Source: src.name, Text: excerpt(sentence)})
+					break
+				}
+			}
+		}
+	}
+	return match
+}
+
+// matchExperience compares the years the job asks for with the most the
+// applicant claims anywhere
+func matchExperience(required int, sources []source) models.ExperienceMatch {
+	match := models.ExperienceMatch{RequiredYears: required}
+	for _, src := range sources {
+		for _, sentence := range sentences(src.text) {
+			for _, m := range yearsPattern.FindAllStringSubmatch(sentence, -1) {
+				years, _ := strconv.Atoi(m[1])
+				if years > match.StatedYears {
+					match.StatedYears = years
+					match.Evidence = &models.Evidence{Source: src.name, Text: excerpt(sentence)}
+				}
+			}
+		}
+	}
+	match.Met = match.StatedYears >= required
+	return match
+}
+
+// keywords returns the distinct words of a requirement that name a skill
+func keywords(requirement string) []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, word := range tokenize(requirement) {
+		if stopWords[word] || seen[word] || isNumber(word) {
+			continue
+		}
+		seen[word] = true
+		result = append(result, word)
+	}
+	if result == nil {
+		result = []string{}
+	}
+	return result
+}
+
+// words returns the set of words in text, with plurals also present in
+// their singular form
+func words(text string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range tokenize(text) {
+		set[word] = true
+		if singular, ok := strings.CutSuffix(word, "s"); ok && len(singular) > 2 {
+			set[singular] = true
+		}
+	}
+	return set
+}
+
+// tokenize splits text into case-folded words. Characters that are part of
+// technology names (C++, C#, Node.js) stay inside words; trailing
+// punctuation does not. Slashes separate words, so SQL/NoSQL is two.
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(fold.String(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("+#.-", r)
+	})
+	result := make([]string, 0, len(fields))
+	for _, field := range fields {
+		field = strings.Trim(field, ".-")
+		if field != "" {
+			result = append(result, field)
+		}
+	}
+	return result
+}
+
+// sentences splits text into sentences and lines
+func sentences(text string) []string {
+	var result []string
+	start := 0
+	for i, r := range text {
+		end := -1
+		switch {
+		case r == '\n':
+			end = i
+		case (r == '.' || r == '!' || r == '?') && (i+1 == len(text) || text[i+1] == ' ' || text[i+1] == '\n'):
+			end = i + 1
+		}
+		if end < 0 {
+			continue
+		}
+		if sentence := strings.TrimSpace(text[start:end]); sentence != "" {
+			result = append(result, sentence)
+		}
+		start = i + 1
+	}
+	if sentence := strings.TrimSpace(text[start:]); sentence != "" {
+		result = append(result, sentence)
+	}
+	return result
+}
+
+// excerpt shortens a sentence to at most maxExcerpt characters
+func excerpt(sentence string) string {
+	runes := []rune(sentence)
+	if len(runes) <= maxExcerpt {
+		return sentence
+	}
+	return strings.TrimSpace(string(runes[:maxExcerpt-1])) + "…"
+}
+
+// isNumber reports whether word is made of digits and plus signs, like "2+"
+func isNumber(word string) bool {
+	return strings.TrimLeft(word, "0123456789+") == ""
+}
@@ -0,0 +1,84 @@
+package store
+
+import (
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/emailaddr"
+)
+
+// passwordIterations is the PBKDF2 work factor for stored passwords
+const passwordIterations = 100_000
+
+// account is a registered applicant login. Only a salted hash of the
+// password is kept.
+type account struct {
+	email     string
+	salt      []byte
+	hash      []byte
+	createdAt time.Time
+}
+
+// AccountStore manages the in-memory applicant logins tokens are issued for
+type AccountStore struct {
+	accounts map[string]account // Keyed by normalized email
+	mu       sync.RWMutex
+}
+
+// NewAccountStore creates a new account store
+func NewAccountStore() *AccountStore {
+	return &AccountStore{accounts: make(map[string]account)}
+}
+
+// Register creates a login for email and returns the normalized address.
+// Each address may register once.
+func (s *AccountStore) Register(email, password string) (string, error) {
+	email = emailaddr.Normalize(email)
+	salt := make([]byte, 16)
+	rand.Read(salt)
+	hash, err := hashPassword(password, salt)
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.accounts[email]; exists {
+		return "", fmt.Errorf("duplicate account: %s is already registered", email)
+	}
+	s.accounts[email] = account{email: email, salt: salt, hash: hash, createdAt: time.Now().UTC()}
+	return email, nil
+}
+
+// Authenticate reports whether password is the one email registered with,
+// returning the normalized address
+func (s *AccountStore) Authenticate(email, password string) (string, bool) {
+	email = emailaddr.Normalize(email)
+	s.mu.RLock()
+	acct, exists := s.accounts[email]
+	s.mu.RUnlock()
+	if !exists {
+		return "", false
+	}
+	hash, err := hashPassword(password, acct.salt)
+	if err != nil || !hmac.Equal(hash, acct.hash) {
+		return "", false
+	}
+	return acct.email, true
+}
+
+// Clear removes every account
+func (s *AccountStore) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accounts = make(map[string]account)
+}
+
+func hashPassword(password string, salt []byte) ([]byte, error) {
+	return pbkdf2.Key(sha256.New, password, salt, passwordIterations, sha256.Size)
+}
@@ -0,0 +1,97 @@
+package store
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+)
+
+// maxAgents caps how many agents are tracked, so agents making up a new
+// ID for every request cannot exhaust memory; later ones are not counted
+const maxAgents = 1000
+
+// maxAgentPaths caps how many paths an agent's polls are tracked on
+const maxAgentPaths = 1000
+
+// AgentStore counts the requests of each agent naming itself in
+// X-Agent-ID, so teams sharing one sandbox can be compared
+type AgentStore struct {
+	agents map[string]*agent
+	mu     sync.Mutex
+}
+
+// agent is an agent's stats with what is needed to keep them up to date
+type agent struct {
+	models.AgentStats
+	lastGet   map[string]time.Time // Path -> when the agent last got it
+	pollTotal time.Duration
+}
+
+// NewAgentStore creates a new agent store
+func NewAgentStore() *AgentStore {
+	return &AgentStore{agents: make(map[string]*agent)}
+}
+
+// Record counts a request an agent made. submitted is set when it
+// submitted an application.
+func (s *AgentStore) Record(id, method, path string, status int, submitted bool, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, exists := s.agents[id]
+	if !exists {
+		if len(s.agents) >= maxAgents {
+			return
+		}
+		a = &agent{
+			AgentStats: models.AgentStats{AgentID: id, FirstSeenAt: at},
+			lastGet:    make(map[string]time.Time),
+		}
+		s.agents[id] = a
+	}
+
+	a.Requests++
+	a.LastSeenAt = at
+	if status >= http.StatusBadRequest {
+		a.Errors++
+	}
+	a.ErrorRate = float64(a.Errors) / float64(a.Requests)
+	if status == http.StatusTooManyRequests {
+		a.RateLimited++
+	}
+	if submitted {
+		a.Submissions++
+	}
+
+	if method == http.MethodGet {
+		if last, polled := a.lastGet[path]; polled {
+			a.Polls++
+			a.pollTotal += at.Sub(last)
+			a.AvgPollIntervalMs = a.pollTotal.Milliseconds() / int64(a.Polls)
+		}
+		if _, polled := a.lastGet[path]; polled || len(a.lastGet) < maxAgentPaths {
+			a.lastGet[path] = at
+		}
+	}
+}
+
+// List returns the stats of every agent, busiest first
+func (s *AgentStore) List() []models.AgentStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := make([]models.AgentStats, 0, len(s.agents))
+	for _, a := range s.agents {
+		list = append(list, a.AgentStats)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Requests != list[j].Requests {
+			return list[i].Requests > list[j].Requests
+		}
+		return list[i].AgentID < list[j].AgentID
+	})
+	return list
+}
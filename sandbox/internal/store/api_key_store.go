@@ -0,0 +1,148 @@
+package store
+
+import (
+	"encoding/hex"
+	"maps"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/random"
+	"github.com/google/uuid"
+)
+
+// apiKeyPrefixLength is how much of a key listings show
+const apiKeyPrefixLength = 12
+
+// APIKeyStore keeps the API keys organizers mint and what each was used for
+type APIKeyStore struct {
+	keys     map[string]*models.APIKey // ID -> key, with its secret
+	bySecret map[string]string         // Secret -> ID
+	mu       sync.RWMutex
+}
+
+// NewAPIKeyStore creates a new API key store
+func NewAPIKeyStore() *APIKeyStore {
+	return &APIKeyStore{
+		keys:     make(map[string]*models.APIKey),
+		bySecret: make(map[string]string),
+	}
+}
+
+// Create mints a key for name. The returned key carries its secret, which
+// no later read does.
+func (s *APIKeyStore) Create(name string) (models.APIKey, error) {
+	buf := make([]byte, 24)
+	if err := random.Read(buf); err != nil {
+		return models.APIKey{}, err
+	}
+	secret := "sk_" + hex.EncodeToString(buf)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := &models.APIKey{
+		ID:        "key_" + uuid.New().String()[:8],
+		Name:      name,
+		Key:       secret,
+		Prefix:    secret[:apiKeyPrefixLength],
+		CreatedAt: time.Now(),
+		Usage:     models.APIKeyUsage{Endpoints: make(map[string]int)},
+	}
+	s.keys[key.ID] = key
+	s.bySecret[secret] = key.ID
+	return *key, nil
+}
+
+// Authenticate returns the ID of the active key whose secret is given
+func (s *APIKeyStore) Authenticate(secret string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	id, exists := s.bySecret[secret]
+	if !exists {
+		return "", false
+	}
+	if s.keys[id].RevokedAt != nil {
+		return "", false
+	}
+	return id, true
+}
+
+// Get returns a key and its usage, without its secret
+func (s *APIKeyStore) Get(id string) (models.APIKey, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key, exists := s.keys[id]
+	if !exists {
+		return models.APIKey{}, false
+	}
+	return redact(key), true
+}
+
+// List returns every key, oldest first, without their secrets
+func (s *APIKeyStore) List() []models.APIKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]models.APIKey, 0, len(s.keys))
+	for _, key := range s.keys {
+		result = append(result, redact(key))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.Before(result[j].CreatedAt)
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// Revoke stops a key from authenticating. Its usage is kept. Revoking a
+// key twice keeps the first revocation time.
+func (s *APIKeyStore) Revoke(id string) (models.APIKey, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, exists := s.keys[id]
+	if !exists {
+		return models.APIKey{}, false
+	}
+	if key.RevokedAt == nil {
+		now := time.Now()
+		key.RevokedAt = &now
+	}
+	return redact(key), true
+}
+
+// Record counts a request made with a key against the endpoint it reached
+func (s *APIKeyStore) Record(id, endpoint string, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, exists := s.keys[id]
+	if !exists {
+		return
+	}
+	now := time.Now()
+	key.Usage.Requests++
+	key.Usage.LastUsedAt = &now
+	key.Usage.Endpoints[endpoint]++
+	switch {
+	case status == http.StatusTooManyRequests:
+		key.Usage.RateLimited++
+	case status >= 500:
+		key.Usage.Errors++
+	}
+}
+
+// redact copies a key without its secret
+func redact(key *models.APIKey) models.APIKey {
+	copied := *key
+	copied.Key = ""
+	copied.Usage.Endpoints = maps.Clone(key.Usage.Endpoints)
+	return copied
+}
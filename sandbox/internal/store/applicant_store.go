@@ -0,0 +1,64 @@
+package store
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/emailaddr"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/google/uuid"
+)
+
+// ApplicantStore manages the in-memory applicant profiles
+type ApplicantStore struct {
+	applicants map[string]models.Applicant
+	byEmail    map[string]string // Index: normalized email -> applicant ID
+	mu         sync.RWMutex
+}
+
+// NewApplicantStore creates a new applicant store
+func NewApplicantStore() *ApplicantStore {
+	return &ApplicantStore{
+		applicants: make(map[string]models.Applicant),
+		byEmail:    make(map[string]string),
+	}
+}
+
+// Create stores a profile, giving it an ID and creation time. Each email
+// address may have one profile.
+func (s *ApplicantStore) Create(applicant models.Applicant) (models.Applicant, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	email := emailaddr.Normalize(applicant.Email)
+	if id, exists := s.byEmail[email]; exists {
+		return models.Applicant{}, fmt.Errorf("duplicate applicant: %s already has a profile", id)
+	}
+
+	applicant.ID = "apl_" + uuid.New().String()[:8]
+	applicant.Email = email
+	applicant.Skills = slices.Clone(applicant.Skills)
+	applicant.CreatedAt = time.Now().UTC()
+	s.applicants[applicant.ID] = applicant
+	s.byEmail[email] = applicant.ID
+	return applicant, nil
+}
+
+// GetByID returns a profile by its ID
+func (s *ApplicantStore) GetByID(id string) (models.Applicant, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	applicant, exists := s.applicants[id]
+	applicant.Skills = slices.Clone(applicant.Skills)
+	return applicant, exists
+}
+
+// Clear removes every profile
+func (s *ApplicantStore) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.applicants = make(map[string]models.Applicant)
+	s.byEmail = make(map[string]string)
+}
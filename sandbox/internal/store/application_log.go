@@ -0,0 +1,63 @@
+package store
+
+import (
+	"sort"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+)
+
+// maxLogEntries is how many application log entries are kept at least.
+// The oldest are dropped a batch of logTrimBatch at a time beyond it, so
+// appending stays cheap.
+const (
+	maxLogEntries = 10000
+	logTrimBatch  = 1000
+)
+
+// applicationLog is the append-only log of every operation that changed
+// the applications of an ApplicationStore. The store appends to it while
+// holding its own lock, so entries are numbered in the order the changes
+// happened.
+type applicationLog struct {
+	entries []models.ApplicationLogEntry // The most recent entries, oldest first
+	lastSeq uint64
+}
+
+// append numbers and records an entry about app, which is nil for
+// entries about every application. Only app's IDs and status are kept.
+func (l *applicationLog) append(entry models.ApplicationLogEntry, app *models.Application) {
+	l.lastSeq++
+	entry.Seq = l.lastSeq
+	entry.At = time.Now().UTC()
+	if app != nil {
+		entry.ApplicationID = app.ConfirmationID
+		entry.JobID = app.JobID
+		entry.Status = app.Status
+	}
+
+	l.entries = append(l.entries, entry)
+	if len(l.entries) >= maxLogEntries+logTrimBatch {
+		l.entries = append([]models.ApplicationLogEntry(nil), l.entries[len(l.entries)-maxLogEntries:]...)
+	}
+}
+
+// since returns up to limit entries numbered after seq that keep reports
+// true for (every entry when keep is nil), whether more follow, and
+// whether entries after seq were already dropped
+func (l *applicationLog) since(seq uint64, limit int, keep func(models.ApplicationLogEntry) bool) ([]models.ApplicationLogEntry, bool, bool) {
+	start := sort.Search(len(l.entries), func(i int) bool { return l.entries[i].Seq > seq })
+	dropped := seq < l.lastSeq && (len(l.entries) == 0 || l.entries[0].Seq > seq+1)
+
+	entries := []models.ApplicationLogEntry{}
+	for _, entry := range l.entries[start:] {
+		if keep != nil && !keep(entry) {
+			continue
+		}
+		if limit > 0 && len(entries) == limit {
+			return entries, true, dropped
+		}
+		entries = append(entries, entry)
+	}
+	return entries, false, dropped
+}
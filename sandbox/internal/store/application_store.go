@@ -1,52 +1,344 @@
 package store
 
 import (
+	"crypto/subtle"
+	"encoding/binary"
 	"fmt"
+	"maps"
+	"slices"
 	"sync"
 	"time"
 
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/emailaddr"
 	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/phone"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/random"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/scoring"
 	"github.com/google/uuid"
 )
 
-// ApplicationStore manages the in-memory application data
+// ApplicationStore manages the in-memory application data. Stored
+// applications are copy-on-write: an update builds a new version and swaps
+// it into the map, so the pointers handed out by reads are immutable
+// snapshots that callers may use after the lock is released but must not
+// modify.
 type ApplicationStore struct {
 	applications     map[string]*models.Application
-	applicationIDs   []string            // Ordered list for consistent iteration
-	byJobID          map[string][]string // Index: job_id -> application_ids
-	byApplicantEmail map[string][]string // Index: email -> application_ids
+	applicationIDs   []string                       // Ordered list for consistent iteration
+	positions        map[string]uint64              // Application ID -> position in submission order
+	lastPosition     uint64                         // Position of the most recent submission
+	byJobID          map[string][]string            // Index: job_id -> application_ids
+	byApplicantEmail map[string][]string            // Index: email -> application_ids
+	byPhone          map[string][]string            // Index: E.164 phone -> application_ids
+	byApplicantID    map[string][]string            // Index: applicant profile ID -> application_ids
+	phoneCountryCode string                         // Calling code assumed for phones without one
+	relaxedHosts     bool                           // Accept LinkedIn/GitHub links on any host
+	strictWorkAuth   bool                           // Reject unrecognized work authorizations
+	propagation      time.Duration                  // How long new applications stay hidden from reads
+	verifyEmail      bool                           // Hold new applications until their email is verified
+	honeypot         string                         // How submissions filling in honeypot fields are handled; empty ignores them
+	shadowRejected   map[string]*models.Application // Confirmation ID -> application answered as submitted but dropped
+	limits           models.ApplicationLimits
+	emailRules       emailaddr.Rules
+	version          uint64         // Incremented on every mutation
+	log              applicationLog // Every change to the applications, in order
+	persist          Persistence    // Durable copy of the applications; nil when in-memory only
+	listeners        []StatusListener
+	submitListeners  []SubmitListener
 	mu               sync.RWMutex
 }
 
+// Order is the order application lists are returned in
+type Order int
+
+const (
+	// NewestFirst lists the most recently submitted applications first
+	NewestFirst Order = iota
+	// OldestFirst lists applications in the order they were submitted
+	OldestFirst
+)
+
+// StatusListener is called after an application's status changes, with a
+// copy of the updated application and its previous status
+type StatusListener func(app models.Application, previous models.ApplicationStatus)
+
+// SubmitListener is called after an application is created, with a copy
+// of it
+type SubmitListener func(app models.Application)
+
+// ReviewTiming holds the timestamps needed to compute review latency
+type ReviewTiming struct {
+	Company             string
+	SubmittedAt         time.Time
+	FirstStatusChangeAt *time.Time
+}
+
 // NewApplicationStore creates a new application store
 func NewApplicationStore() *ApplicationStore {
 	return &ApplicationStore{
 		applications:     make(map[string]*models.Application),
 		applicationIDs:   make([]string, 0),
+		positions:        make(map[string]uint64),
 		byJobID:          make(map[string][]string),
 		byApplicantEmail: make(map[string][]string),
+		byPhone:          make(map[string][]string),
+		byApplicantID:    make(map[string][]string),
+		shadowRejected:   make(map[string]*models.Application),
+		phoneCountryCode: phone.DefaultCountryCode,
+		limits:           models.DefaultApplicationLimits(),
+	}
+}
+
+// SetLimits sets the maximum lengths of application text
+func (s *ApplicationStore) SetLimits(limits models.ApplicationLimits) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.limits = limits
+}
+
+// SetEmailRules sets which applicant email addresses are accepted
+func (s *ApplicationStore) SetEmailRules(rules emailaddr.Rules) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.emailRules = rules
+}
+
+// EmailRules returns which applicant email addresses are accepted
+func (s *ApplicationStore) EmailRules() emailaddr.Rules {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.emailRules
+}
+
+// Limits returns the maximum lengths of application text
+func (s *ApplicationStore) Limits() models.ApplicationLimits {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.limits
+}
+
+// SetPhoneCountryCode sets the calling code assumed for phone numbers
+// submitted without one; an empty code disables E.164 normalization of
+// such numbers
+func (s *ApplicationStore) SetPhoneCountryCode(code string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.phoneCountryCode = code
+}
+
+// SetRelaxedProfileHosts lets LinkedIn and GitHub links point at any host
+// instead of only linkedin.com and github.com
+func (s *ApplicationStore) SetRelaxedProfileHosts(relaxed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.relaxedHosts = relaxed
+}
+
+// RelaxedProfileHosts reports whether LinkedIn and GitHub links may point
+// at any host
+func (s *ApplicationStore) RelaxedProfileHosts() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.relaxedHosts
+}
+
+// SetPropagationDelay hides new applications from reads for a while after
+// they are submitted, the way a lagging read replica would. Submissions,
+// and the duplicate checks they make, see them at once.
+func (s *ApplicationStore) SetPropagationDelay(delay time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.propagation = delay
+}
+
+// SetEmailVerification holds new applications in
+// StatusPendingVerification, with a code emailed to the applicant, until
+// Verify is called with it
+func (s *ApplicationStore) SetEmailVerification(verify bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.verifyEmail = verify
+}
+
+// SetHoneypot sets how submissions that fill in a honeypot field are
+// handled: models.HoneypotFlag marks them, models.HoneypotShadow also drops
+// them while answering as if they were stored, and "" ignores honeypots
+func (s *ApplicationStore) SetHoneypot(mode string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.honeypot = mode
+}
+
+// Honeypot returns how submissions that fill in a honeypot field are
+// handled, or "" when honeypots are off
+func (s *ApplicationStore) Honeypot() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.honeypot
+}
+
+// GetPropagatedByID is GetByID as reads see it, missing applications that
+// are still propagating
+func (s *ApplicationStore) GetPropagatedByID(id string) (*models.Application, bool) {
+	app, exists := s.GetByID(id)
+	if !exists || time.Since(app.SubmittedAt) < s.propagationDelay() {
+		return nil, false
+	}
+	return app, true
+}
+
+// Propagated keeps the applications that are no longer propagating
+func (s *ApplicationStore) Propagated(apps []*models.Application) []*models.Application {
+	delay := s.propagationDelay()
+	if delay == 0 {
+		return apps
+	}
+	cutoff := time.Now().Add(-delay)
+	kept := make([]*models.Application, 0, len(apps))
+	for _, app := range apps {
+		if !app.SubmittedAt.After(cutoff) {
+			kept = append(kept, app)
+		}
+	}
+	return kept
+}
+
+// propagationDelay returns how long new applications stay hidden from reads
+func (s *ApplicationStore) propagationDelay() time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.propagation
+}
+
+// SetStrictWorkAuthorization makes unrecognized work authorizations an
+// error instead of being stored as "other" with a warning
+func (s *ApplicationStore) SetStrictWorkAuthorization(strict bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.strictWorkAuth = strict
+}
+
+// StrictWorkAuthorization reports whether unrecognized work authorizations
+// are rejected
+func (s *ApplicationStore) StrictWorkAuthorization() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.strictWorkAuth
+}
+
+// PhoneCountryCode returns the calling code assumed for phone numbers
+// submitted without one
+func (s *ApplicationStore) PhoneCountryCode() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.phoneCountryCode
+}
+
+// Restore makes the store durable: it loads the applications saved in p,
+// replacing any it holds, and writes every change through to p from then
+// on
+func (s *ApplicationStore) Restore(p Persistence) error {
+	saved, err := p.Applications()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reset()
+	for i := range saved {
+		s.insert(&saved[i])
+		s.log.append(models.ApplicationLogEntry{Type: models.LogApplicationRestored}, &saved[i])
+	}
+	s.persist = p
+	s.version++
+	return nil
+}
+
+// Create creates a new application, notifies the submit listeners and
+// returns it
+func (s *ApplicationStore) Create(req models.ApplicationRequest, job models.Job, warnings []models.Violation) (*models.Application, error) {
+	app, listeners, pending, err := s.create(req, job, warnings)
+	if err != nil {
+		return nil, err
+	}
+	if err := pending(); err != nil {
+		s.discard(app.ID)
+		return nil, fmt.Errorf("saving application: %w", err)
+	}
+
+	// Notify outside the lock so listeners can read the store
+	for _, listener := range listeners {
+		listener(*app)
+	}
+	return app, nil
+}
+
+// discard takes a new application whose save failed back out of the store,
+// so it is not served as submitted when it would be lost on restart. Every
+// change saved after a failed one fails too, so none of its later versions
+// were saved either.
+func (s *ApplicationStore) discard(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	app, exists := s.applications[id]
+	if !exists {
+		return
 	}
+	s.remove(app)
+	s.version++
+	s.log.append(models.ApplicationLogEntry{Type: models.LogApplicationDiscarded}, app)
 }
 
-// Create creates a new application and returns it
-func (s *ApplicationStore) Create(req models.ApplicationRequest, job models.Job) (*models.Application, error) {
+// create stores a new application, returning it with the submit listeners
+// to notify and its pending save
+func (s *ApplicationStore) create(req models.ApplicationRequest, job models.Job, warnings []models.Violation) (*models.Application, []SubmitListener, Pending, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	// Check for duplicate application (same email + same job)
-	if existing, exists := s.byApplicantEmail[req.ApplicantEmail]; exists {
+	// Check for duplicate application (same email + same job), leaving out
+	// withdrawn applications
+	applicantEmail := emailaddr.Normalize(req.ApplicantEmail)
+	if existing, exists := s.byApplicantEmail[applicantEmail]; exists {
 		for _, appID := range existing {
-			if app, ok := s.applications[appID]; ok && app.JobID == req.JobID {
-				return nil, fmt.Errorf("duplicate application: already applied to this job")
+			if app, ok := s.applications[appID]; ok && app.JobID == req.JobID && app.Status != models.StatusWithdrawn {
+				return nil, nil, nil, fmt.Errorf("duplicate application: already applied to this job")
 			}
 		}
 	}
 
+	// Same for the phone number, compared in its normalized form
+	phoneE164, _ := phone.Normalize(req.Phone, s.phoneCountryCode)
+	if phoneE164 != "" {
+		for _, appID := range s.byPhone[phoneE164] {
+			if app, ok := s.applications[appID]; ok && app.JobID == req.JobID && app.Status != models.StatusWithdrawn {
+				return nil, nil, nil, fmt.Errorf("duplicate application: phone already applied to this job")
+			}
+		}
+	}
+
+	// Jobs with a cap take no more applications once it is reached. This is
+	// checked under the lock, so racing submissions cannot overfill a job.
+	// Withdrawn applications give their place back.
+	if job.MaxApplications > 0 && s.activeCount(job.ID) >= job.MaxApplications {
+		return nil, nil, nil, fmt.Errorf("job filled: all %d applications received", job.MaxApplications)
+	}
+
 	// Generate IDs
 	id := uuid.New().String()
 	confirmationID := fmt.Sprintf("CONF-%s-%s", time.Now().Format("20060102"), id[:8])
 
 	now := time.Now()
+	status, token := models.StatusReceived, ""
+	if s.verifyEmail {
+		code, err := verificationCode()
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("generating verification code: %w", err)
+		}
+		status, token = models.StatusPendingVerification, code
+	}
 
 	app := &models.Application{
 		ID:                id,
@@ -56,29 +348,133 @@ func (s *ApplicationStore) Create(req models.ApplicationRequest, job models.Job)
 		JobTitle:          job.Title,
 		Company:           job.Company,
 		ApplicantName:     req.ApplicantName,
-		ApplicantEmail:    req.ApplicantEmail,
+		ApplicantEmail:    applicantEmail,
 		Resume:            req.Resume,
 		CoverLetter:       req.CoverLetter,
-		Status:            models.StatusReceived,
+		Status:            status,
 		SubmittedAt:       now,
 		UpdatedAt:         now,
 		Phone:             req.Phone,
+		PhoneE164:         phoneE164,
 		LinkedIn:          req.LinkedIn,
 		Portfolio:         req.Portfolio,
 		GitHub:            req.GitHub,
 		WorkAuthorization: req.WorkAuthorization,
 		CustomAnswers:     req.CustomAnswers,
+		Warnings:          warnings,
+		StatusHistory:     []models.StatusChange{{Status: status, At: now, Actor: models.ActorApplicant}},
+		VerificationToken: token,
+		ApplicantID:       req.ApplicantID,
+		Trap:              job.Trap,
+	}
+	score := scoring.Score(job, req.Resume, req.CoverLetter)
+	score.ApplicationID = confirmationID
+	app.Score = &score
+
+	if s.honeypot != "" {
+		app.HoneypotFields = req.HoneypotHits()
+	}
+	// Shadow-rejected applications look submitted to the bot, but nobody
+	// else ever hears of them
+	if s.honeypot == models.HoneypotShadow && len(app.HoneypotFields) > 0 {
+		app.ShadowRejected = true
+		s.shadowRejected[confirmationID] = app
+		return app, nil, alreadySaved, nil
 	}
 
-	// Store the application
-	s.applications[id] = app
-	s.applicationIDs = append(s.applicationIDs, id)
+	s.insert(app)
+	s.version++
+	s.log.append(models.ApplicationLogEntry{Type: models.LogApplicationCreated}, app)
 
-	// Update indices
-	s.byJobID[req.JobID] = append(s.byJobID[req.JobID], id)
-	s.byApplicantEmail[req.ApplicantEmail] = append(s.byApplicantEmail[req.ApplicantEmail], id)
+	return app, s.submitListeners, s.persistApplication(*app), nil
+}
 
-	return app, nil
+// persistApplication hands a new version of an application to the
+// persistence backend, if any. Callers must hold the lock.
+func (s *ApplicationStore) persistApplication(app models.Application) Pending {
+	if s.persist == nil {
+		return alreadySaved
+	}
+	return s.persist.PutApplication(app)
+}
+
+// insert stores an application and indexes it. Callers must hold the lock.
+func (s *ApplicationStore) insert(app *models.Application) {
+	s.applications[app.ID] = app
+	s.applicationIDs = append(s.applicationIDs, app.ID)
+	s.lastPosition++
+	s.positions[app.ID] = s.lastPosition
+
+	s.byJobID[app.JobID] = append(s.byJobID[app.JobID], app.ID)
+	s.byApplicantEmail[app.ApplicantEmail] = append(s.byApplicantEmail[app.ApplicantEmail], app.ID)
+	if app.PhoneE164 != "" {
+		s.byPhone[app.PhoneE164] = append(s.byPhone[app.PhoneE164], app.ID)
+	}
+	if app.ApplicantID != "" {
+		s.byApplicantID[app.ApplicantID] = append(s.byApplicantID[app.ApplicantID], app.ID)
+	}
+}
+
+// remove takes an application out of the store and its indexes. Callers
+// must hold the lock.
+func (s *ApplicationStore) remove(app *models.Application) {
+	unindex := func(index map[string][]string, key string) {
+		// Clone, as edit does, rather than shifting an array reads may share
+		ids := slices.DeleteFunc(slices.Clone(index[key]), func(id string) bool { return id == app.ID })
+		if len(ids) == 0 {
+			delete(index, key)
+		} else {
+			index[key] = ids
+		}
+	}
+
+	delete(s.applications, app.ID)
+	delete(s.positions, app.ID)
+	s.applicationIDs = slices.DeleteFunc(slices.Clone(s.applicationIDs), func(id string) bool { return id == app.ID })
+	unindex(s.byJobID, app.JobID)
+	unindex(s.byApplicantEmail, app.ApplicantEmail)
+	if app.PhoneE164 != "" {
+		unindex(s.byPhone, app.PhoneE164)
+	}
+	if app.ApplicantID != "" {
+		unindex(s.byApplicantID, app.ApplicantID)
+	}
+}
+
+// find returns an application by its internal or confirmation ID. Callers
+// must hold the lock.
+func (s *ApplicationStore) find(id string) (*models.Application, bool) {
+	if app, exists := s.applications[id]; exists {
+		return app, true
+	}
+	for _, app := range s.applications {
+		if app.ConfirmationID == id {
+			return app, true
+		}
+	}
+	return nil, false
+}
+
+// reset empties the store. Callers must hold the lock.
+func (s *ApplicationStore) reset() {
+	s.applications = make(map[string]*models.Application)
+	s.applicationIDs = make([]string, 0)
+	s.positions = make(map[string]uint64)
+	s.byJobID = make(map[string][]string)
+	s.byApplicantEmail = make(map[string][]string)
+	s.byPhone = make(map[string][]string)
+	s.byApplicantID = make(map[string][]string)
+	s.shadowRejected = make(map[string]*models.Application)
+}
+
+// GetShadowRejected returns an application answered as submitted but
+// dropped for filling in a honeypot field, by its confirmation ID. Only
+// the pages a bot is shown after submitting look these up.
+func (s *ApplicationStore) GetShadowRejected(confirmationID string) (*models.Application, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	app, exists := s.shadowRejected[confirmationID]
+	return app, exists
 }
 
 // GetByID returns an application by its ID (supports both internal ID and confirmation ID)
@@ -101,94 +497,323 @@ func (s *ApplicationStore) GetByID(id string) (*models.Application, bool) {
 	return nil, false
 }
 
-// GetByJobID returns all applications for a job
-func (s *ApplicationStore) GetByJobID(jobID string) []*models.Application {
+// Position returns where an application sits in submission order: later
+// submissions have higher positions, and positions are never reused while
+// the server runs, not even after ClearAll. It reports false for
+// applications that do not exist.
+func (s *ApplicationStore) Position(id string) (uint64, bool) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-
-	result := make([]*models.Application, 0)
-
-	if ids, exists := s.byJobID[jobID]; exists {
-		for _, id := range ids {
-			if app, ok := s.applications[id]; ok {
-				result = append(result, app)
-			}
-		}
-	}
-
-	return result
+	position, exists := s.positions[id]
+	return position, exists
 }
 
-// GetByEmail returns all applications by an applicant email
-func (s *ApplicationStore) GetByEmail(email string) []*models.Application {
+// GetByJobID returns all applications for a job in the given order
+func (s *ApplicationStore) GetByJobID(jobID string, order Order) []*models.Application {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
+	return s.collect(s.byJobID[jobID], 0, order)
+}
 
-	result := make([]*models.Application, 0)
-
-	if ids, exists := s.byApplicantEmail[email]; exists {
-		for _, id := range ids {
-			if app, ok := s.applications[id]; ok {
-				result = append(result, app)
-			}
-		}
-	}
-
-	return result
+// GetByEmail returns all applications by an applicant email in the given order
+func (s *ApplicationStore) GetByEmail(email string, order Order) []*models.Application {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.collect(s.byApplicantEmail[emailaddr.Normalize(email)], 0, order)
 }
 
-// GetAll returns all applications
-func (s *ApplicationStore) GetAll(limit int) []*models.Application {
+// GetByApplicantID returns all applications submitted from an applicant
+// profile in the given order
+func (s *ApplicationStore) GetByApplicantID(applicantID string, order Order) []*models.Application {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
+	return s.collect(s.byApplicantID[applicantID], 0, order)
+}
 
-	result := make([]*models.Application, 0, len(s.applications))
+// GetAll returns up to limit applications (all when limit is 0) in the
+// given order. The limit applies after ordering, so NewestFirst returns
+// the most recent ones.
+func (s *ApplicationStore) GetAll(limit int, order Order) []*models.Application {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.collect(s.applicationIDs, limit, order)
+}
 
-	count := 0
-	for _, id := range s.applicationIDs {
-		if limit > 0 && count >= limit {
+// collect looks up up to limit of the given application IDs, which are in
+// submission order. Callers must hold the lock.
+func (s *ApplicationStore) collect(ids []string, limit int, order Order) []*models.Application {
+	result := make([]*models.Application, 0, len(ids))
+	for i := range ids {
+		if limit > 0 && len(result) >= limit {
 			break
 		}
+		id := ids[i]
+		if order == NewestFirst {
+			id = ids[len(ids)-1-i]
+		}
 		if app, exists := s.applications[id]; exists {
 			result = append(result, app)
-			count++
 		}
 	}
-
 	return result
 }
 
-// UpdateStatus updates the status of an application
-func (s *ApplicationStore) UpdateStatus(id string, status models.ApplicationStatus, notes string) error {
+// OnStatusChange registers a listener for status changes
+func (s *ApplicationStore) OnStatusChange(listener StatusListener) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, listener)
+}
 
-	app, exists := s.applications[id]
+// OnSubmit registers a listener for new applications
+func (s *ApplicationStore) OnSubmit(listener SubmitListener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.submitListeners = append(s.submitListeners, listener)
+}
+
+// UpdateStatus updates the status of an application, records the change
+// and who made it in its status history, notifies the status listeners when
+// it changed and returns the new version. The version readers already hold
+// is left untouched.
+func (s *ApplicationStore) UpdateStatus(id string, status models.ApplicationStatus, notes, actor string) (*models.Application, error) {
+	return s.updateStatus(id, status, notes, actor, nil)
+}
+
+// Update edits an application while its status is still received (or
+// pending verification), applying the fields update sets and returning the
+// new version. update must already be validated and normalized. Changing
+// the phone number is subject to the same duplicate check as submitting.
+func (s *ApplicationStore) Update(id string, update models.ApplicationUpdateRequest) (*models.Application, error) {
+	s.mu.Lock()
+	app, pending, err := s.edit(id, update)
+	s.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+	if err := pending(); err != nil {
+		return nil, fmt.Errorf("saving application: %w", err)
+	}
+	return app, nil
+}
+
+// edit is Update under the lock, returning the new version with its
+// pending save
+func (s *ApplicationStore) edit(id string, update models.ApplicationUpdateRequest) (*models.Application, Pending, error) {
+	current, exists := s.find(id)
 	if !exists {
-		// Try by confirmation ID
-		for _, a := range s.applications {
-			if a.ConfirmationID == id {
-				app = a
-				exists = true
-				break
+		return nil, nil, fmt.Errorf("application not found")
+	}
+	if current.Status != models.StatusReceived && current.Status != models.StatusPendingVerification {
+		return nil, nil, fmt.Errorf("application review has started")
+	}
+
+	app := *current
+	var changes []string
+	if update.CoverLetter != nil {
+		app.CoverLetter = *update.CoverLetter
+		changes = append(changes, "cover_letter")
+	}
+	if update.Phone != nil {
+		app.Phone = *update.Phone
+		app.PhoneE164, _ = phone.Normalize(app.Phone, s.phoneCountryCode)
+		changes = append(changes, "phone")
+		if app.PhoneE164 != "" && app.PhoneE164 != current.PhoneE164 {
+			for _, appID := range s.byPhone[app.PhoneE164] {
+				if other, ok := s.applications[appID]; ok && other.JobID == app.JobID && other.Status != models.StatusWithdrawn {
+					return nil, nil, fmt.Errorf("duplicate application: phone already applied to this job")
+				}
+			}
+		}
+	}
+	if update.LinkedIn != nil {
+		app.LinkedIn = *update.LinkedIn
+		changes = append(changes, "linkedin")
+	}
+	if update.Portfolio != nil {
+		app.Portfolio = *update.Portfolio
+		changes = append(changes, "portfolio")
+	}
+	if update.GitHub != nil {
+		app.GitHub = *update.GitHub
+		changes = append(changes, "github")
+	}
+	if len(update.CustomAnswers) > 0 {
+		app.CustomAnswers = maps.Clone(app.CustomAnswers)
+		if app.CustomAnswers == nil {
+			app.CustomAnswers = make(models.StringMap)
+		}
+		for question, answer := range update.CustomAnswers {
+			if answer == "" {
+				delete(app.CustomAnswers, question)
+			} else {
+				app.CustomAnswers[question] = answer
 			}
 		}
+		changes = append(changes, "custom_answers")
 	}
+	app.UpdatedAt = time.Now()
+	app.LastModified = nextModified(current.Modified(), app.UpdatedAt)
 
+	if app.PhoneE164 != current.PhoneE164 {
+		if current.PhoneE164 != "" {
+			s.byPhone[current.PhoneE164] = slices.DeleteFunc(slices.Clone(s.byPhone[current.PhoneE164]),
+				func(appID string) bool { return appID == app.ID })
+		}
+		if app.PhoneE164 != "" {
+			s.byPhone[app.PhoneE164] = append(s.byPhone[app.PhoneE164], app.ID)
+		}
+	}
+	s.applications[app.ID] = &app
+	s.version++
+	s.log.append(models.ApplicationLogEntry{Type: models.LogApplicationEdited, Changes: changes}, &app)
+	return &app, s.persistApplication(app), nil
+}
+
+// AdvanceStatus is UpdateStatus for an application still in status from,
+// so a change made since it was read, such as a withdrawal, is not undone
+func (s *ApplicationStore) AdvanceStatus(id string, from, to models.ApplicationStatus, notes, actor string) (*models.Application, error) {
+	return s.updateStatus(id, to, notes, actor, func(app *models.Application) error {
+		if app.Status != from {
+			return fmt.Errorf("application status changed to %s", app.Status)
+		}
+		return nil
+	})
+}
+
+// Withdraw moves an application to StatusWithdrawn on the applicant's
+// behalf, which lets them apply to the job again. Applications already
+// withdrawn or rejected cannot be withdrawn.
+func (s *ApplicationStore) Withdraw(id string, notes string) (*models.Application, error) {
+	return s.updateStatus(id, models.StatusWithdrawn, notes, models.ActorApplicant, func(app *models.Application) error {
+		switch app.Status {
+		case models.StatusWithdrawn:
+			return fmt.Errorf("application already withdrawn")
+		case models.StatusRejected:
+			return fmt.Errorf("application already rejected")
+		}
+		return nil
+	})
+}
+
+// Verify moves an application pending verification to StatusReceived,
+// given the code emailed to the applicant
+func (s *ApplicationStore) Verify(id, token string) (*models.Application, error) {
+	return s.updateStatus(id, models.StatusReceived, "Email address verified.", models.ActorApplicant, func(app *models.Application) error {
+		switch {
+		case app.Status != models.StatusPendingVerification:
+			return fmt.Errorf("application not pending verification")
+		case subtle.ConstantTimeCompare([]byte(token), []byte(app.VerificationToken)) != 1:
+			return fmt.Errorf("invalid verification token")
+		}
+		return nil
+	})
+}
+
+// SubmitAssignment records a shortlisted applicant's take-home assignment
+// and moves the application to StatusAssignmentSubmitted
+func (s *ApplicationStore) SubmitAssignment(id string, submission models.AssignmentSubmission) (*models.Application, error) {
+	return s.updateStatus(id, models.StatusAssignmentSubmitted, "Take-home assignment submitted.", models.ActorApplicant, func(app *models.Application) error {
+		switch app.Status {
+		case models.StatusAssignmentSubmitted:
+			return fmt.Errorf("assignment already submitted")
+		case models.StatusShortlisted:
+			app.Assignment = &submission
+			return nil
+		}
+		return fmt.Errorf("application not shortlisted")
+	})
+}
+
+// verificationCode returns a random six-digit code
+func verificationCode() (string, error) {
+	var buf [4]byte
+	if err := random.Read(buf[:]); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", binary.BigEndian.Uint32(buf[:])%1000000), nil
+}
+
+// updateStatus is UpdateStatus, refused when allow, if given, returns an
+// error for a copy of the current version of the application. allow may
+// also edit the copy, which is what gets stored.
+func (s *ApplicationStore) updateStatus(id string, status models.ApplicationStatus, notes, actor string, allow func(*models.Application) error) (*models.Application, error) {
+	s.mu.Lock()
+
+	current, exists := s.find(id)
 	if !exists {
-		return fmt.Errorf("application not found")
+		s.mu.Unlock()
+		return nil, fmt.Errorf("application not found")
+	}
+
+	app := *current
+	if allow != nil {
+		if err := allow(&app); err != nil {
+			s.mu.Unlock()
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	previous := app.Status
+	// Verifying is the applicant's doing, not the employer's first response
+	if app.FirstStatusChangeAt == nil && status != app.Status && app.Status != models.StatusPendingVerification {
+		app.FirstStatusChangeAt = &now
+	}
+	if status != previous {
+		// Clip so the append copies rather than sharing the old version's array
+		app.StatusHistory = append(slices.Clip(app.StatusHistory), models.StatusChange{Status: status, At: now, Notes: notes, Actor: actor})
 	}
 
 	app.Status = status
 	app.Notes = notes
-	app.UpdatedAt = time.Now()
+	app.UpdatedAt = now
+	app.LastModified = nextModified(current.Modified(), now)
 
 	if status == models.StatusReviewing || status == models.StatusShortlisted || status == models.StatusRejected {
-		now := time.Now()
 		app.ReviewedAt = &now
 	}
 
-	return nil
+	s.applications[app.ID] = &app
+	s.version++
+	entry := models.ApplicationLogEntry{Type: models.LogStatusChanged, PreviousStatus: previous}
+	switch {
+	case status == previous:
+		// Only the notes changed
+		entry = models.ApplicationLogEntry{Type: models.LogApplicationEdited, Changes: []string{"notes"}}
+	case status == models.StatusWithdrawn:
+		entry.Type = models.LogApplicationWithdrawn
+	}
+	s.log.append(entry, &app)
+	pending := s.persistApplication(app)
+	listeners := s.listeners
+	s.mu.Unlock()
+
+	if err := pending(); err != nil {
+		return nil, fmt.Errorf("saving application: %w", err)
+	}
+	// Notify outside the lock so listeners can read the store. Each gets
+	// its own copy of the version this update stored.
+	if status != previous {
+		for _, listener := range listeners {
+			listener(app, previous)
+		}
+	}
+
+	return &app, nil
+}
+
+// nextModified returns the LastModified for a change made at now to a record
+// last modified at previous. Last-Modified only has one-second resolution, so
+// a change in the same second as the previous one is dated the next second,
+// and every change moves Last-Modified on, so If-Modified-Since never hides it.
+// UpdatedAt keeps the time the change was actually made.
+func nextModified(previous, now time.Time) time.Time {
+	if next := previous.Truncate(time.Second).Add(time.Second); now.Before(next) {
+		return next
+	}
+	return now
 }
 
 // GetCount returns total number of applications
@@ -209,6 +834,27 @@ func (s *ApplicationStore) GetCountByJobID(jobID string) int {
 	return 0
 }
 
+// GetActiveCountByJobID returns the number of applications for a job that
+// have not been withdrawn, which is what counts against its
+// max_applications
+func (s *ApplicationStore) GetActiveCountByJobID(jobID string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.activeCount(jobID)
+}
+
+// activeCount counts a job's applications that have not been withdrawn.
+// Callers must hold the lock.
+func (s *ApplicationStore) activeCount(jobID string) int {
+	count := 0
+	for _, appID := range s.byJobID[jobID] {
+		if app, ok := s.applications[appID]; ok && app.Status != models.StatusWithdrawn {
+			count++
+		}
+	}
+	return count
+}
+
 // GetStats returns application statistics
 func (s *ApplicationStore) GetStats() map[string]int {
 	s.mu.RLock()
@@ -223,16 +869,148 @@ func (s *ApplicationStore) GetStats() map[string]int {
 	return stats
 }
 
+// GetWorkAuthorizationStats counts applications by normalized work
+// authorization, using "unspecified" for those that gave none
+func (s *ApplicationStore) GetWorkAuthorizationStats() map[string]int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stats := make(map[string]int)
+	for _, app := range s.applications {
+		value := app.WorkAuthorization
+		if value == "" {
+			value = "unspecified"
+		}
+		stats[value]++
+	}
+	return stats
+}
+
 // ClearAll removes all applications (for testing)
-func (s *ApplicationStore) ClearAll() int {
+func (s *ApplicationStore) ClearAll() (int, error) {
 	s.mu.Lock()
-	defer s.mu.Unlock()
+	count := len(s.applications)
+	s.reset()
+	s.version++
+	s.log.append(models.ApplicationLogEntry{Type: models.LogApplicationsCleared, Cleared: count}, nil)
+	pending := Pending(alreadySaved)
+	if s.persist != nil {
+		pending = s.persist.DeleteApplications()
+	}
+	s.mu.Unlock()
+
+	if err := pending(); err != nil {
+		return 0, fmt.Errorf("clearing applications: %w", err)
+	}
+	return count, nil
+}
+
+// Snapshot returns a copy of every application in submission order,
+// including those still propagating
+func (s *ApplicationStore) Snapshot() []models.Application {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]models.Application, 0, len(s.applicationIDs))
+	for _, id := range s.applicationIDs {
+		if app, exists := s.applications[id]; exists {
+			result = append(result, *app)
+		}
+	}
+	return result
+}
+
+// Load replaces every application with apps, in submission order, such as
+// those of a snapshot, and returns how many it replaced. Positions keep
+// counting up, so cursors into the old applications never match a loaded
+// one.
+func (s *ApplicationStore) Load(apps []models.Application) (int, error) {
+	seen := make(map[string]bool, len(apps))
+	for _, app := range apps {
+		if app.ID == "" {
+			return 0, fmt.Errorf("application without an id")
+		}
+		if seen[app.ID] {
+			return 0, fmt.Errorf("duplicate application: %s appears more than once", app.ID)
+		}
+		seen[app.ID] = true
+	}
 
+	s.mu.Lock()
 	count := len(s.applications)
-	s.applications = make(map[string]*models.Application)
-	s.applicationIDs = make([]string, 0)
-	s.byJobID = make(map[string][]string)
-	s.byApplicantEmail = make(map[string][]string)
+	s.reset()
+	s.log.append(models.ApplicationLogEntry{Type: models.LogApplicationsCleared, Cleared: count}, nil)
+	pending := Pending(alreadySaved)
+	if s.persist != nil {
+		pending = s.persist.DeleteApplications()
+	}
+	for i := range apps {
+		app := apps[i]
+		s.insert(&app)
+		s.log.append(models.ApplicationLogEntry{Type: models.LogApplicationRestored}, &app)
+		// Changes are written in order and a failed write fails every
+		// later one, so the last pending save speaks for all of them
+		pending = s.persistApplication(app)
+	}
+	s.version++
+	s.mu.Unlock()
 
-	return count
+	if err := pending(); err != nil {
+		return 0, fmt.Errorf("saving applications: %w", err)
+	}
+	return count, nil
+}
+
+// Log returns up to limit entries of the application log numbered after
+// since, oldest first
+func (s *ApplicationStore) Log(since uint64, limit int) models.ApplicationLogResponse {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries, more, dropped := s.log.since(since, limit, nil)
+	return models.ApplicationLogResponse{Entries: entries, LastSeq: s.log.lastSeq, HasMore: more, Dropped: dropped}
+}
+
+// LogOf is Log keeping only the entries about applications email has
+// submitted that are still in the store
+func (s *ApplicationStore) LogOf(email string, since uint64, limit int) models.ApplicationLogResponse {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	own := make(map[string]bool)
+	for _, id := range s.byApplicantEmail[emailaddr.Normalize(email)] {
+		if app, exists := s.applications[id]; exists {
+			own[app.ConfirmationID] = true
+		}
+	}
+	entries, more, dropped := s.log.since(since, limit, func(entry models.ApplicationLogEntry) bool {
+		return own[entry.ApplicationID]
+	})
+	return models.ApplicationLogResponse{Entries: entries, LastSeq: s.log.lastSeq, HasMore: more, Dropped: dropped}
+}
+
+// Version returns a counter that changes whenever the store is mutated
+func (s *ApplicationStore) Version() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.version
+}
+
+// GetReviewTimings returns the submission and first status change timestamps of all applications
+func (s *ApplicationStore) GetReviewTimings() []ReviewTiming {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]ReviewTiming, 0, len(s.applications))
+	for _, id := range s.applicationIDs {
+		if app, exists := s.applications[id]; exists {
+			result = append(result, ReviewTiming{
+				Company:             app.Company,
+				SubmittedAt:         app.SubmittedAt,
+				FirstStatusChangeAt: app.FirstStatusChangeAt,
+			})
+		}
+	}
+
+	return result
 }
@@ -0,0 +1,335 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+)
+
+// testJob is the job the store tests apply to
+var testJob = models.Job{ID: "job_store", Title: "Backend Engineer", Company: "Acme"}
+
+// testRequest is an application to testJob
+func testRequest(email, phone string) models.ApplicationRequest {
+	return models.ApplicationRequest{
+		JobID:          testJob.ID,
+		ApplicantName:  "Vic Tester",
+		ApplicantEmail: email,
+		Resume:         "Ten years of building web services in Go and Python.",
+		Phone:          phone,
+	}
+}
+
+// TestDuplicatePhone checks the duplicate check compares phone numbers in
+// their normalized form, however they are written
+func TestDuplicatePhone(t *testing.T) {
+	s := NewApplicationStore()
+	if _, err := s.Create(testRequest("vic@example.com", "(415) 555-0100"), testJob, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, written := range []string{"415-555-0100", "+1 415 555 0100", "1.415.555.0100", "0014155550100"} {
+		_, err := s.Create(testRequest("other@example.com", written), testJob, nil)
+		if err == nil || !strings.Contains(err.Error(), "phone already applied") {
+			t.Errorf("%s: error %v, want a duplicate phone", written, err)
+		}
+	}
+	if _, err := s.Create(testRequest("other@example.com", "+44 20 7946 0958"), testJob, nil); err != nil {
+		t.Errorf("another number: %v", err)
+	}
+}
+
+// TestCapExcludesWithdrawn checks a withdrawn application gives its place
+// under max_applications back
+func TestCapExcludesWithdrawn(t *testing.T) {
+	s := NewApplicationStore()
+	job := testJob
+	job.MaxApplications = 2
+
+	first, err := s.Create(testRequest("ann@example.com", ""), job, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Create(testRequest("bob@example.com", ""), job, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Create(testRequest("cat@example.com", ""), job, nil); err == nil || !strings.Contains(err.Error(), "job filled") {
+		t.Fatalf("third application: %v, want job filled", err)
+	}
+
+	if _, err := s.Withdraw(first.ID, ""); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.GetActiveCountByJobID(job.ID); got != 1 {
+		t.Errorf("%d active applications after a withdrawal, want 1", got)
+	}
+	if _, err := s.Create(testRequest("cat@example.com", ""), job, nil); err != nil {
+		t.Errorf("application after a withdrawal: %v, want the freed place", err)
+	}
+	if _, err := s.Create(testRequest("dan@example.com", ""), job, nil); err == nil || !strings.Contains(err.Error(), "job filled") {
+		t.Errorf("application once refilled: %v, want job filled", err)
+	}
+	if got := s.GetCountByJobID(job.ID); got != 3 {
+		t.Errorf("%d applications in all, want 3 with the withdrawn one", got)
+	}
+}
+
+// TestUpdatedAtStaysTruthful checks changes made within one second keep
+// UpdatedAt at the time they were made, while Last-Modified still moves on
+// by a second with each of them
+func TestUpdatedAtStaysTruthful(t *testing.T) {
+	s := NewApplicationStore()
+	app, err := s.Create(testRequest("ann@example.com", ""), testJob, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modified := app.Modified()
+	for _, status := range []models.ApplicationStatus{models.StatusReviewing, models.StatusShortlisted, models.StatusRejected} {
+		before := time.Now()
+		updated, err := s.UpdateStatus(app.ID, status, "", models.ActorReview)
+		after := time.Now()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if updated.UpdatedAt.Before(before) || updated.UpdatedAt.After(after) {
+			t.Errorf("%s: updated at %v, want between %v and %v", status, updated.UpdatedAt, before, after)
+		}
+		if !updated.Modified().Truncate(time.Second).After(modified.Truncate(time.Second)) {
+			t.Errorf("%s: Last-Modified %v did not move on from %v", status, updated.Modified(), modified)
+		}
+		modified, app = updated.Modified(), updated
+	}
+
+	data, err := json.Marshal(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "last_modified") || strings.Contains(string(data), "LastModified") {
+		t.Errorf("application serialized with its Last-Modified: %s", data)
+	}
+}
+
+// TestOrder checks every list is newest first by default and oldest first
+// on request, and that limits keep the first applications of that order
+func TestOrder(t *testing.T) {
+	s := NewApplicationStore()
+	other := models.Job{ID: "job_other", Title: "Data Analyst", Company: "Globex"}
+	var ids, mine, toTestJob []string // In submission order
+	for i := range 6 {
+		job, email := testJob, fmt.Sprintf("applicant%d@example.com", i)
+		if i%2 == 1 {
+			job = other
+		}
+		if i%3 == 0 {
+			email = "vic@example.com"
+		}
+		req := testRequest(email, "")
+		req.JobID = job.ID
+		app, err := s.Create(req, job, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, app.ID)
+		if email == "vic@example.com" {
+			mine = append(mine, app.ID)
+		}
+		if job.ID == testJob.ID {
+			toTestJob = append(toTestJob, app.ID)
+		}
+	}
+
+	reversed := func(ids []string) []string {
+		r := slices.Clone(ids)
+		slices.Reverse(r)
+		return r
+	}
+	tests := []struct {
+		name string
+		got  []*models.Application
+		want []string
+	}{
+		{"all, newest first", s.GetAll(0, NewestFirst), reversed(ids)},
+		{"all, oldest first", s.GetAll(0, OldestFirst), ids},
+		{"newest 2", s.GetAll(2, NewestFirst), reversed(ids)[:2]},
+		{"oldest 2", s.GetAll(2, OldestFirst), ids[:2]},
+		{"over the count", s.GetAll(100, NewestFirst), reversed(ids)},
+		{"by email, newest first", s.GetByEmail("vic@EXAMPLE.COM", NewestFirst), reversed(mine)},
+		{"by email, oldest first", s.GetByEmail("vic@example.com", OldestFirst), mine},
+		{"by job, newest first", s.GetByJobID(testJob.ID, NewestFirst), reversed(toTestJob)},
+		{"by job, oldest first", s.GetByJobID(testJob.ID, OldestFirst), toTestJob},
+		{"by unknown job", s.GetByJobID("job_none", NewestFirst), nil},
+	}
+	for _, tt := range tests {
+		got := make([]string, len(tt.got))
+		for i, app := range tt.got {
+			got[i] = app.ID
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if Order(0) != NewestFirst {
+		t.Error("the zero Order is not NewestFirst")
+	}
+}
+
+// consistent reports what is wrong with a version of an application whose
+// fields disagree, as a torn read would: its status must be the last one
+// in its history, which must not be dated after UpdatedAt
+func consistent(app *models.Application) error {
+	if len(app.StatusHistory) == 0 {
+		return fmt.Errorf("%s has no status history", app.ID)
+	}
+	last := app.StatusHistory[len(app.StatusHistory)-1]
+	if last.Status != app.Status {
+		return fmt.Errorf("%s has status %s but its history ends in %s", app.ID, app.Status, last.Status)
+	}
+	if last.At.After(app.UpdatedAt) {
+		return fmt.Errorf("%s was updated at %v, before its last status change at %v", app.ID, app.UpdatedAt, last.At)
+	}
+	return nil
+}
+
+// TestConcurrentSubmitPollUpdate has submitters, pollers and updaters use
+// the store at once. Run with -race, it fails if an update changes a
+// version of an application a reader or listener already holds.
+func TestConcurrentSubmitPollUpdate(t *testing.T) {
+	const (
+		workers = 4
+		rounds  = 200
+	)
+	s := NewApplicationStore()
+
+	var mu sync.Mutex
+	var failures []error
+	notified := 0
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		failures = append(failures, err)
+	}
+	s.OnStatusChange(func(app models.Application, previous models.ApplicationStatus) {
+		if app.Status == previous {
+			fail(fmt.Errorf("%s notified of a change from %s to itself", app.ID, previous))
+		}
+		if err := consistent(&app); err != nil {
+			fail(fmt.Errorf("listener: %w", err))
+		}
+		if _, err := json.Marshal(app); err != nil {
+			fail(err)
+		}
+		mu.Lock()
+		notified++
+		mu.Unlock()
+	})
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Go(func() { // Submitter
+			for i := range rounds {
+				if _, err := s.Create(testRequest(fmt.Sprintf("applicant%d.%d@example.com", w, i), ""), testJob, nil); err != nil {
+					fail(err)
+				}
+			}
+		})
+		wg.Go(func() { // Updater
+			rng := rand.New(rand.NewPCG(uint64(w), 0))
+			statuses := []models.ApplicationStatus{models.StatusReviewing, models.StatusShortlisted, models.StatusRejected, models.StatusReceived}
+			for range rounds {
+				apps := s.GetAll(20, NewestFirst)
+				if len(apps) == 0 {
+					continue
+				}
+				app := apps[rng.IntN(len(apps))]
+				switch rng.IntN(4) {
+				case 0:
+					letter := "Updated cover letter"
+					s.Update(app.ID, models.ApplicationUpdateRequest{CoverLetter: &letter})
+				case 1:
+					s.Withdraw(app.ID, "Found another job")
+				default:
+					if _, err := s.UpdateStatus(app.ID, statuses[rng.IntN(len(statuses))], "Reviewed", models.ActorReview); err != nil {
+						fail(err)
+					}
+				}
+			}
+		})
+		wg.Go(func() { // Poller, encoding what it reads as a handler would
+			for range rounds {
+				apps := s.GetAll(20, NewestFirst)
+				for _, app := range s.GetAll(5, OldestFirst) {
+					if current, ok := s.GetByID(app.ID); ok {
+						apps = append(apps, current)
+					}
+				}
+				for _, app := range apps {
+					if err := consistent(app); err != nil {
+						fail(err)
+					}
+				}
+				if _, err := json.Marshal(apps); err != nil {
+					fail(err)
+				}
+				if _, err := json.Marshal(s.Log(0, 20)); err != nil {
+					fail(err)
+				}
+				s.GetStats()
+			}
+		})
+	}
+	wg.Wait()
+
+	for _, err := range failures[:min(len(failures), 10)] {
+		t.Error(err)
+	}
+	if got, want := s.GetCount(), workers*rounds; got != want {
+		t.Errorf("%d applications stored, want %d", got, want)
+	}
+	if notified == 0 {
+		t.Error("no status changes were notified")
+	}
+	for _, app := range s.Snapshot() {
+		if err := consistent(&app); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+// TestApplicationLogCarriesNoApplicantDetails checks log entries name the
+// application and its status but none of what the applicant sent,
+// honeypot hits included
+func TestApplicationLogCarriesNoApplicantDetails(t *testing.T) {
+	s := NewApplicationStore()
+	s.SetHoneypot(models.HoneypotFlag)
+	req := testRequest("vic@example.com", "(415) 555-0100")
+	req.Fax = "555-0199"
+	app, err := s.Create(req, testJob, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.UpdateStatus(app.ID, models.StatusReviewing, "", models.ActorAPI); err != nil {
+		t.Fatal(err)
+	}
+
+	log := s.Log(0, 0)
+	body, err := json.Marshal(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, detail := range []string{"vic@example.com", "Vic Tester", "555-0100", "honeypot", "fax", app.ID} {
+		if strings.Contains(string(body), detail) {
+			t.Errorf("log carries %q: %s", detail, body)
+		}
+	}
+	if len(log.Entries) != 2 || log.Entries[1].ApplicationID != app.ConfirmationID || log.Entries[1].Status != models.StatusReviewing {
+		t.Errorf("entries %+v, want the submission and the move to reviewing", log.Entries)
+	}
+}
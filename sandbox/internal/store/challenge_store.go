@@ -0,0 +1,124 @@
+package store
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/google/uuid"
+)
+
+// ChallengeTTL is how long a challenge can be solved after it was issued,
+// and how long a token can be used after it was handed out
+const ChallengeTTL = 5 * time.Minute
+
+// ChallengeStore keeps the anti-bot challenges waiting to be solved and
+// the tokens handed out for solved ones. Each challenge can be solved, or
+// failed, once; each token is good for one submission.
+type ChallengeStore struct {
+	challenges map[string]issuedChallenge
+	tokens     map[string]time.Time // Token -> when it expires
+	mu         sync.Mutex
+}
+
+// issuedChallenge is a Challenge with its expected answer
+type issuedChallenge struct {
+	models.Challenge
+	answer string
+}
+
+// NewChallengeStore creates a new challenge store
+func NewChallengeStore() *ChallengeStore {
+	return &ChallengeStore{
+		challenges: make(map[string]issuedChallenge),
+		tokens:     make(map[string]time.Time),
+	}
+}
+
+// NewID returns the ID of a challenge about to be issued, which
+// proof-of-work challenges are computed over
+func (s *ChallengeStore) NewID() string {
+	return "chl_" + uuid.New().String()
+}
+
+// Issue keeps a challenge until it is solved or expires, setting when it
+// expires. answer is what solves a puzzle or delay challenge.
+func (s *ChallengeStore) Issue(challenge models.Challenge, answer string) models.Challenge {
+	challenge.ExpiresAt = time.Now().UTC().Add(ChallengeTTL)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.challenges[challenge.ID] = issuedChallenge{Challenge: challenge, answer: answer}
+	return challenge
+}
+
+// Get returns a challenge that has not been solved yet, without using it
+// up
+func (s *ChallengeStore) Get(id string) (models.Challenge, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	issued, err := s.get(id)
+	return issued.Challenge, err
+}
+
+// Take returns a challenge with its expected answer and removes it, so
+// the one attempt at it is the last
+func (s *ChallengeStore) Take(id string) (models.Challenge, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	issued, err := s.get(id)
+	if err != nil {
+		return models.Challenge{}, "", err
+	}
+	delete(s.challenges, id)
+	return issued.Challenge, issued.answer, nil
+}
+
+// get looks up a challenge, dropping it once expired. The caller holds
+// the lock.
+func (s *ChallengeStore) get(id string) (issuedChallenge, error) {
+	issued, exists := s.challenges[id]
+	if !exists {
+		return issuedChallenge{}, fmt.Errorf("challenge not found: %s", id)
+	}
+	if time.Now().After(issued.ExpiresAt) {
+		delete(s.challenges, id)
+		return issuedChallenge{}, fmt.Errorf("challenge expired: %s", id)
+	}
+	return issued, nil
+}
+
+// NewToken hands out a token for a solved challenge
+func (s *ChallengeStore) NewToken() models.ChallengeToken {
+	token := models.ChallengeToken{
+		Token:     "cht_" + uuid.New().String(),
+		ExpiresAt: time.Now().UTC().Add(ChallengeTTL),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[token.Token] = token.ExpiresAt
+	return token
+}
+
+// Redeem uses up a token, reporting whether it was handed out, has not
+// expired and had not been used before
+func (s *ChallengeStore) Redeem(token string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	expiresAt, exists := s.tokens[token]
+	if !exists {
+		return false
+	}
+	delete(s.tokens, token)
+	return time.Now().Before(expiresAt)
+}
+
+// Clear removes every challenge and token
+func (s *ChallengeStore) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.challenges = make(map[string]issuedChallenge)
+	s.tokens = make(map[string]time.Time)
+}
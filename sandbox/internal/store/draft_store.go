@@ -0,0 +1,110 @@
+package store
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/google/uuid"
+)
+
+// DraftTTL is how long a draft is kept after it was last changed
+const DraftTTL = 24 * time.Hour
+
+// DraftStore keeps the application drafts of the wizard until they are
+// submitted or expire
+type DraftStore struct {
+	drafts map[string]models.ApplicationDraft
+	mu     sync.Mutex
+}
+
+// NewDraftStore creates a new draft store
+func NewDraftStore() *DraftStore {
+	return &DraftStore{drafts: make(map[string]models.ApplicationDraft)}
+}
+
+// Create stores a new draft for job, started by owner (empty when
+// applicants do not log in), with no step done
+func (s *DraftStore) Create(job models.Job, owner string) models.ApplicationDraft {
+	now := time.Now().UTC()
+	draft := models.ApplicationDraft{
+		ID:             "dft_" + uuid.New().String(),
+		JobID:          job.ID,
+		JobTitle:       job.Title,
+		Company:        job.Company,
+		Application:    models.ApplicationRequest{JobID: job.ID},
+		CompletedSteps: []string{},
+		NextStep:       models.DraftSteps[0],
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		ExpiresAt:      now.Add(DraftTTL),
+		Owner:          owner,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.drafts[draft.ID] = draft
+	return clonedDraft(draft)
+}
+
+// Get returns a draft by its ID. Expired drafts are not found.
+func (s *DraftStore) Get(id string) (models.ApplicationDraft, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	draft, exists := s.drafts[id]
+	if !exists {
+		return models.ApplicationDraft{}, false
+	}
+	if time.Now().After(draft.ExpiresAt) {
+		delete(s.drafts, id)
+		return models.ApplicationDraft{}, false
+	}
+	return clonedDraft(draft), true
+}
+
+// Update replaces a draft with its new version, keeping it for another
+// DraftTTL
+func (s *DraftStore) Update(draft models.ApplicationDraft) (models.ApplicationDraft, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.drafts[draft.ID]; !exists {
+		return models.ApplicationDraft{}, fmt.Errorf("draft not found: %s", draft.ID)
+	}
+	draft.UpdatedAt = time.Now().UTC()
+	draft.ExpiresAt = draft.UpdatedAt.Add(DraftTTL)
+	draft = clonedDraft(draft)
+	s.drafts[draft.ID] = draft
+	return clonedDraft(draft), nil
+}
+
+// Delete removes a draft, reporting whether it existed
+func (s *DraftStore) Delete(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, exists := s.drafts[id]
+	delete(s.drafts, id)
+	return exists
+}
+
+// Clear removes every draft
+func (s *DraftStore) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.drafts = make(map[string]models.ApplicationDraft)
+}
+
+// clonedDraft copies the slices and maps of a draft, so callers cannot
+// change the stored one
+func clonedDraft(draft models.ApplicationDraft) models.ApplicationDraft {
+	draft.CompletedSteps = slices.Clone(draft.CompletedSteps)
+	if draft.Application.CustomAnswers != nil {
+		answers := make(map[string]string, len(draft.Application.CustomAnswers))
+		for id, answer := range draft.Application.CustomAnswers {
+			answers[id] = answer
+		}
+		draft.Application.CustomAnswers = answers
+	}
+	return draft
+}
@@ -0,0 +1,101 @@
+package store
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxFormSessions caps how many sessions are kept, so a client opening
+// pages in a loop cannot grow the store without bound
+const maxFormSessions = 10000
+
+// formSession is a browser session of the HTML pages
+type formSession struct {
+	csrfToken string
+	expiresAt time.Time
+}
+
+// FormSessionStore holds the browser sessions of the HTML pages and the CSRF
+// token each one's forms must carry
+type FormSessionStore struct {
+	sessions map[string]formSession // Keyed by session ID
+	ttl      time.Duration
+	swept    time.Time // When expired sessions were last dropped
+	mu       sync.Mutex
+}
+
+// NewFormSessionStore creates a new session store whose sessions last ttl
+func NewFormSessionStore(ttl time.Duration) *FormSessionStore {
+	return &FormSessionStore{sessions: make(map[string]formSession), ttl: ttl}
+}
+
+// TTL returns how long sessions last
+func (s *FormSessionStore) TTL() time.Duration {
+	return s.ttl
+}
+
+// Start opens a new session, returning its ID and CSRF token. Expired
+// sessions are dropped at most once a TTL, or whenever the store is full;
+// a full store with none expired ends the session closest to expiring.
+func (s *FormSessionStore) Start() (string, string) {
+	id := "fs_" + uuid.New().String()
+	token := uuid.New().String()
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.sessions) >= maxFormSessions || now.Sub(s.swept) >= s.ttl {
+		s.sweep(now)
+	}
+	if len(s.sessions) >= maxFormSessions {
+		s.evictOldest()
+	}
+	s.sessions[id] = formSession{csrfToken: token, expiresAt: now.Add(s.ttl)}
+	return id, token
+}
+
+// sweep drops the sessions expired by now. Callers hold s.mu.
+func (s *FormSessionStore) sweep(now time.Time) {
+	for id, session := range s.sessions {
+		if now.After(session.expiresAt) {
+			delete(s.sessions, id)
+		}
+	}
+	s.swept = now
+}
+
+// evictOldest ends the session closest to expiring. Callers hold s.mu.
+func (s *FormSessionStore) evictOldest() {
+	var oldest string
+	var expiresAt time.Time
+	for id, session := range s.sessions {
+		if oldest == "" || session.expiresAt.Before(expiresAt) {
+			oldest, expiresAt = id, session.expiresAt
+		}
+	}
+	delete(s.sessions, oldest)
+}
+
+// CSRFToken returns the CSRF token of a session. Expired sessions are not
+// found.
+func (s *FormSessionStore) CSRFToken(id string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, exists := s.sessions[id]
+	if !exists {
+		return "", false
+	}
+	if time.Now().After(session.expiresAt) {
+		delete(s.sessions, id)
+		return "", false
+	}
+	return session.csrfToken, true
+}
+
+// Clear ends every session
+func (s *FormSessionStore) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions = make(map[string]formSession)
+}
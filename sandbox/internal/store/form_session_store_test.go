@@ -0,0 +1,42 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormSessionStoreSweepsExpiredSessions(t *testing.T) {
+	s := NewFormSessionStore(time.Minute)
+	for range 3 {
+		s.Start()
+	}
+	// Back-date the sessions and the last sweep, as if a TTL had passed
+	past := time.Now().Add(-2 * time.Minute)
+	for id, session := range s.sessions {
+		session.expiresAt = past
+		s.sessions[id] = session
+	}
+	s.swept = past
+
+	id, _ := s.Start()
+	if len(s.sessions) != 1 {
+		t.Fatalf("got %d sessions, want only the new one", len(s.sessions))
+	}
+	if _, ok := s.CSRFToken(id); !ok {
+		t.Error("new session not found")
+	}
+}
+
+func TestFormSessionStoreCap(t *testing.T) {
+	s := NewFormSessionStore(time.Hour)
+	first, _ := s.Start()
+	for range maxFormSessions {
+		s.Start()
+	}
+	if len(s.sessions) != maxFormSessions {
+		t.Errorf("got %d sessions, want at most %d", len(s.sessions), maxFormSessions)
+	}
+	if _, ok := s.CSRFToken(first); ok {
+		t.Error("oldest session kept past the cap")
+	}
+}
@@ -0,0 +1,260 @@
+package store
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/emailaddr"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/random"
+	"github.com/google/uuid"
+)
+
+// Interview calendar shape
+const (
+	minFreeSlots      = 4                // Free slots each company keeps on offer
+	interviewLength   = 45 * time.Minute // Length of every slot
+	interviewDays     = 10               // How many weekdays ahead slots are offered
+	firstInterviewAt  = 9                // Earliest hour of the day, UTC
+	lastInterviewFrom = 16               // Latest hour a slot may start, UTC
+)
+
+// interviewers are who companies send to interview applicants
+var interviewers = []string{
+	"Alex Chen", "Priya Natarajan", "Sam Okafor", "Maria Garcia", "Jordan Lee",
+	"Fatima Hassan", "Tom Becker", "Aiko Tanaka",
+}
+
+// interviewFormats are the ways interviews are held
+var interviewFormats = []string{models.InterviewVideo, models.InterviewPhone, models.InterviewOnsite}
+
+// InterviewListener is called after an interview is booked, with the
+// application it is for
+type InterviewListener func(interview models.Interview, app models.Application)
+
+// InterviewStore keeps each company's interview calendar and the slots
+// shortlisted applicants have booked
+type InterviewStore struct {
+	slots      map[string]*calendarSlot   // Slot ID -> slot
+	byCompany  map[string][]*calendarSlot // Company -> slots, soonest first
+	interviews map[string]booking         // Application ID -> booked interview
+	listeners  []InterviewListener
+	rng        *rand.Rand
+	mu         sync.Mutex
+}
+
+// calendarSlot is a slot on a company's calendar and the application that
+// booked it, if any
+type calendarSlot struct {
+	models.InterviewSlot
+	company       string
+	applicationID string
+}
+
+// booking is an interview and the applicant it is with
+type booking struct {
+	models.Interview
+	email string
+}
+
+// NewInterviewStore creates an interview store with every calendar empty
+func NewInterviewStore() *InterviewStore {
+	return &InterviewStore{
+		slots:      make(map[string]*calendarSlot),
+		byCompany:  make(map[string][]*calendarSlot),
+		interviews: make(map[string]booking),
+		rng:        random.New("interviews"),
+	}
+}
+
+// OnSchedule registers a listener for interviews booked through Schedule
+func (s *InterviewStore) OnSchedule(listener InterviewListener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, listener)
+}
+
+// Offer returns company's free slots after now, soonest first, adding
+// slots to its calendar first if fewer than minFreeSlots are free
+func (s *InterviewStore) Offer(company string, now time.Time) []models.InterviewSlot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	free := s.free(company, now)
+	// Give up eventually if the calendar is too full to take more
+	for attempts := 0; len(free) < minFreeSlots && attempts < 100; attempts++ {
+		if s.addSlot(company, now) {
+			free = s.free(company, now)
+		}
+	}
+
+	offered := make([]models.InterviewSlot, len(free))
+	for i, slot := range free {
+		offered[i] = slot.InterviewSlot
+	}
+	return offered
+}
+
+// free returns company's unbooked slots after now. Callers must hold the
+// lock.
+func (s *InterviewStore) free(company string, now time.Time) []*calendarSlot {
+	var free []*calendarSlot
+	for _, slot := range s.byCompany[company] {
+		if slot.applicationID == "" && slot.StartsAt.After(now) {
+			free = append(free, slot)
+		}
+	}
+	return free
+}
+
+// addSlot puts a random weekday slot on company's calendar, reporting
+// false when it would overlap one already there. Callers must hold the
+// lock.
+func (s *InterviewStore) addSlot(company string, now time.Time) bool {
+	day := now.UTC().Truncate(24 * time.Hour)
+	for skip := 1 + s.rng.Intn(interviewDays); skip > 0; {
+		day = day.AddDate(0, 0, 1)
+		if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday {
+			skip--
+		}
+	}
+	start := day.Add(time.Duration(firstInterviewAt+s.rng.Intn(lastInterviewFrom-firstInterviewAt+1))*time.Hour +
+		time.Duration(s.rng.Intn(2))*30*time.Minute)
+	slot := &calendarSlot{
+		InterviewSlot: models.InterviewSlot{
+			ID:          "slot_" + uuid.New().String()[:8],
+			StartsAt:    start,
+			EndsAt:      start.Add(interviewLength),
+			Interviewer: interviewers[s.rng.Intn(len(interviewers))],
+			Format:      interviewFormats[s.rng.Intn(len(interviewFormats))],
+		},
+		company: company,
+	}
+
+	calendar := s.byCompany[company]
+	for _, other := range calendar {
+		if overlaps(slot.InterviewSlot, other.InterviewSlot) {
+			return false
+		}
+	}
+	i, _ := slices.BinarySearchFunc(calendar, start, func(other *calendarSlot, t time.Time) int {
+		return other.StartsAt.Compare(t)
+	})
+	s.byCompany[company] = slices.Insert(calendar, i, slot)
+	s.slots[slot.ID] = slot
+	return true
+}
+
+// Schedule books a slot on the calendar of app's company for app, moving
+// any interview it already has. It fails when the slot is not the
+// company's, has passed or is booked by someone else, or when it overlaps
+// another interview of the same applicant.
+func (s *InterviewStore) Schedule(app models.Application, slotID string, now time.Time) (models.Interview, error) {
+	s.mu.Lock()
+
+	slot, exists := s.slots[slotID]
+	switch {
+	case !exists || slot.company != app.Company:
+		s.mu.Unlock()
+		return models.Interview{}, fmt.Errorf("slot not found")
+	case slot.applicationID == app.ID:
+		interview := s.interviews[app.ID].Interview
+		s.mu.Unlock()
+		return interview, nil
+	case slot.applicationID != "":
+		s.mu.Unlock()
+		return models.Interview{}, fmt.Errorf("slot taken: booked by another applicant")
+	case !slot.StartsAt.After(now):
+		s.mu.Unlock()
+		return models.Interview{}, fmt.Errorf("slot passed: it started at %s", slot.StartsAt.Format(time.RFC3339))
+	}
+
+	email := emailaddr.Normalize(app.ApplicantEmail)
+	for id, other := range s.interviews {
+		if id != app.ID && other.email == email && overlaps(slot.InterviewSlot, other.Slot) {
+			s.mu.Unlock()
+			return models.Interview{}, fmt.Errorf("schedule conflict: overlaps the interview for %s", other.ApplicationID)
+		}
+	}
+
+	s.release(app.ID)
+	slot.applicationID = app.ID
+	interview := models.Interview{
+		ApplicationID:    app.ConfirmationID,
+		Slot:             slot.InterviewSlot,
+		ConfirmationCode: "INT-" + strings.ToUpper(uuid.New().String()[:8]),
+		ScheduledAt:      now.UTC(),
+	}
+	s.interviews[app.ID] = booking{Interview: interview, email: email}
+	listeners := s.listeners
+	s.mu.Unlock()
+
+	// Notify outside the lock so listeners can read the store
+	for _, listener := range listeners {
+		listener(interview, app)
+	}
+	return interview, nil
+}
+
+// Get returns the interview booked for an application, by its ID
+func (s *InterviewStore) Get(applicationID string) (models.Interview, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, exists := s.interviews[applicationID]
+	return b.Interview, exists
+}
+
+// All returns every booked interview, soonest first
+func (s *InterviewStore) All() []models.Interview {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	interviews := make([]models.Interview, 0, len(s.interviews))
+	for _, b := range s.interviews {
+		interviews = append(interviews, b.Interview)
+	}
+	slices.SortFunc(interviews, func(a, b models.Interview) int {
+		if c := a.Slot.StartsAt.Compare(b.Slot.StartsAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ApplicationID, b.ApplicationID)
+	})
+	return interviews
+}
+
+// Cancel frees the slot booked for an application, by its ID, if any
+func (s *InterviewStore) Cancel(applicationID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.release(applicationID)
+}
+
+// release frees the slot booked for an application. Callers must hold the
+// lock.
+func (s *InterviewStore) release(applicationID string) {
+	b, exists := s.interviews[applicationID]
+	if !exists {
+		return
+	}
+	if slot, ok := s.slots[b.Slot.ID]; ok {
+		slot.applicationID = ""
+	}
+	delete(s.interviews, applicationID)
+}
+
+// Clear empties every calendar
+func (s *InterviewStore) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.slots = make(map[string]*calendarSlot)
+	s.byCompany = make(map[string][]*calendarSlot)
+	s.interviews = make(map[string]booking)
+}
+
+// overlaps reports whether two slots share any time
+func overlaps(a, b models.InterviewSlot) bool {
+	return a.StartsAt.Before(b.EndsAt) && b.StartsAt.Before(a.EndsAt)
+}
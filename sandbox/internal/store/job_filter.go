@@ -0,0 +1,156 @@
+package store
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/fold"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+)
+
+// JobFilter selects jobs from the catalogue. A job must match every
+// criterion that is set; the zero JobFilter matches every job.
+type JobFilter struct {
+	Query    string // Search words, matched as Search matches them
+	Remote   *bool  // Only remote jobs when true, only on-site ones when false
+	JobType  string
+	Location string // Case-insensitive part of the location
+	Company  string // Case-insensitive part of the company name
+
+	// MinSalary and MaxSalary bound the yearly salary; a job matches when
+	// its range overlaps them. Jobs without a readable salary never match
+	// a salary bound. 0 leaves that end open.
+	MinSalary int
+	MaxSalary int
+
+	// MinExperience and MaxExperience bound the years of experience a job
+	// requires; nil leaves that end open
+	MinExperience *int
+	MaxExperience *int
+
+	// HideClosed leaves out draft and closed jobs
+	HideClosed bool
+}
+
+// Matches reports whether a job meets every criterion of the filter
+func (f JobFilter) Matches(job models.Job) bool {
+	if f.Query != "" && !MatchesQuery(job, f.Query) {
+		return false
+	}
+	if f.Remote != nil && (job.IsRemote || job.Remote) != *f.Remote {
+		return false
+	}
+	if f.JobType != "" && job.JobType != f.JobType {
+		return false
+	}
+	if f.Location != "" && !fold.Contains(job.Location, f.Location) {
+		return false
+	}
+	if f.Company != "" && !fold.Contains(job.Company, f.Company) {
+		return false
+	}
+	if f.MinSalary > 0 || f.MaxSalary > 0 {
+		low, high, ok := ParseSalary(job.Salary)
+		if !ok || f.MinSalary > 0 && high < f.MinSalary || f.MaxSalary > 0 && low > f.MaxSalary {
+			return false
+		}
+	}
+	if f.MinExperience != nil && job.ExperienceRequired < *f.MinExperience {
+		return false
+	}
+	if f.MaxExperience != nil && job.ExperienceRequired > *f.MaxExperience {
+		return false
+	}
+	if f.HideClosed && (job.Status == models.JobDraft || job.Status == models.JobClosed) {
+		return false
+	}
+	return true
+}
+
+// Filter returns up to limit jobs matching f (all of them when limit is
+// 0) in catalogue order. A query narrows the candidates through the search
+// index first.
+func (s *JobStore) Filter(f JobFilter, limit int) []models.Job {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := s.jobIDs
+	if f.Query != "" {
+		ids = s.index.search(f.Query, 0, false)
+		f.Query = "" // Already applied
+	}
+
+	result := make([]models.Job, 0)
+	for _, id := range ids {
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+		if job := s.jobs[id]; f.Matches(job) {
+			result = append(result, job)
+		}
+	}
+	return result
+}
+
+// FilterByLocation returns jobs whose location contains location
+func (s *JobStore) FilterByLocation(location string, limit int) []models.Job {
+	return s.Filter(JobFilter{Location: location}, limit)
+}
+
+// FilterByCompany returns jobs whose company name contains company
+func (s *JobStore) FilterByCompany(company string, limit int) []models.Job {
+	return s.Filter(JobFilter{Company: company}, limit)
+}
+
+// FilterBySalary returns jobs whose yearly salary range overlaps low to
+// high, either of which may be 0 to leave that end open
+func (s *JobStore) FilterBySalary(low, high, limit int) []models.Job {
+	return s.Filter(JobFilter{MinSalary: low, MaxSalary: high}, limit)
+}
+
+// FilterByExperience returns jobs requiring fewest to most years of
+// experience, either of which may be nil to leave that end open
+func (s *JobStore) FilterByExperience(fewest, most *int, limit int) []models.Job {
+	return s.Filter(JobFilter{MinExperience: fewest, MaxExperience: most}, limit)
+}
+
+// salaryAmount matches an amount in a salary such as "$130,000" or "120k"
+var salaryAmount = regexp.MustCompile(`(\d[\d,]*(?:\.\d+)?)\s*([kK])?`)
+
+// ParseSalary reads the yearly range of a salary such as "$130,000 -
+// $160,000", "$8,000/month" or "$40 - $55/hour". Monthly amounts are
+// multiplied by 12 and hourly ones by 2080, a full-time year. A single
+// amount is both ends of the range. The currency is ignored. It reports
+// false when the salary has no amount.
+func ParseSalary(salary string) (low, high int, ok bool) {
+	matches := salaryAmount.FindAllStringSubmatch(salary, 2)
+	if len(matches) == 0 {
+		return 0, 0, false
+	}
+
+	multiplier := 1.0
+	switch lower := strings.ToLower(salary); {
+	case strings.Contains(lower, "/month") || strings.Contains(lower, "per month"):
+		multiplier = 12
+	case strings.Contains(lower, "/hour") || strings.Contains(lower, "/hr") || strings.Contains(lower, "per hour"):
+		multiplier = 2080
+	}
+
+	amounts := make([]int, len(matches))
+	for i, m := range matches {
+		value, err := strconv.ParseFloat(strings.ReplaceAll(m[1], ",", ""), 64)
+		if err != nil {
+			return 0, 0, false
+		}
+		if m[2] != "" {
+			value *= 1000
+		}
+		amounts[i] = int(value * multiplier)
+	}
+	low, high = amounts[0], amounts[len(amounts)-1]
+	if low > high {
+		low, high = high, low
+	}
+	return low, high, true
+}
@@ -1,34 +1,398 @@
 package store
 
 import (
+	"errors"
+	"fmt"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/data"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/dates"
 	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/google/uuid"
 )
 
 // JobStore manages the in-memory job data
 type JobStore struct {
-	jobs   map[string]models.Job
-	jobIDs []string // Ordered list of job IDs for consistent iteration
-	mu     sync.RWMutex
+	jobs         map[string]models.Job
+	jobIDs       []string // Ordered list of job IDs for consistent iteration
+	positions    map[string]uint64
+	lastPosition uint64 // Position of the most recently added job
+	index        *searchIndex
+	loc          *time.Location // Where date-only job dates are read
+	seed         []models.Job   // Catalogue the store starts from and Reset restores
+	persist      Persistence    // Durable copy of the jobs; nil when in-memory only
+	listeners    []JobListener
+	mu           sync.RWMutex
 }
 
-// NewJobStore creates a new job store with seed data
-func NewJobStore() *JobStore {
-	store := &JobStore{
-		jobs:   make(map[string]models.Job),
-		jobIDs: make([]string, 0),
+// JobListener is called after a job is added to the catalogue, with the job
+type JobListener func(job models.Job)
+
+// NewJobStore creates a new job store with seed data, reading date-only
+// seed dates in loc (UTC when nil). seed replaces the built-in seed jobs
+// when it is not nil. It fails if any seed date is malformed.
+func NewJobStore(seed []models.Job, loc *time.Location) (*JobStore, error) {
+	if seed == nil {
+		seed = data.GetSeedJobs()
 	}
+	store := &JobStore{loc: loc, seed: seed}
 
 	// Load seed jobs
-	seedJobs := data.GetSeedJobs()
-	for _, job := range seedJobs {
-		store.jobs[job.ID] = job
-		store.jobIDs = append(store.jobIDs, job.ID)
+	seedJobs, err := ParseJobDates(seed, loc)
+	if err != nil {
+		return nil, err
+	}
+	store.load(seedJobs)
+
+	return store, nil
+}
+
+// Restore makes the store durable. Jobs already saved in p replace the
+// seed jobs; on first use the seed jobs are saved to p instead. Changes
+// are written through to p from then on.
+func (s *JobStore) Restore(p Persistence) error {
+	saved, ok, err := p.Jobs()
+	if err != nil {
+		return err
 	}
 
-	return store
+	if !ok {
+		s.mu.Lock()
+		pending := p.SaveJobs(s.all())
+		s.persist = p
+		s.mu.Unlock()
+		return waitSaved(pending)
+	}
+
+	jobs, err := ParseJobDates(saved, s.loc)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.markTraps(jobs)
+	s.load(jobs)
+	s.persist = p
+	return nil
+}
+
+// Load replaces the whole catalogue with jobs saved earlier, such as those
+// of a snapshot, parsing their dates again the way Restore does
+func (s *JobStore) Load(saved []models.Job) error {
+	jobs, err := ParseJobDates(saved, s.loc)
+	if err != nil {
+		return err
+	}
+	s.markTraps(jobs)
+	return s.Reset(jobs)
+}
+
+// markTraps marks the jobs that are trap jobs. Trap marks are not saved,
+// so they come from the seed jobs.
+func (s *JobStore) markTraps(jobs []models.Job) {
+	traps := make(map[string]string)
+	for _, job := range s.seed {
+		if job.Trap != "" {
+			traps[job.ID] = job.Trap
+		}
+	}
+	for i := range jobs {
+		jobs[i].Trap = traps[jobs[i].ID]
+	}
+}
+
+// load replaces the jobs and rebuilds the search index. Callers must hold
+// the lock or own the store.
+func (s *JobStore) load(jobs []models.Job) {
+	s.jobs = make(map[string]models.Job, len(jobs))
+	s.jobIDs = make([]string, 0, len(jobs))
+	s.positions = make(map[string]uint64, len(jobs))
+	s.index = newSearchIndex()
+	for _, job := range jobs {
+		s.insert(job)
+	}
+}
+
+// insert adds a job at the end of the catalogue, open unless it has a
+// status of its own. Callers must hold the lock or own the store.
+func (s *JobStore) insert(job models.Job) {
+	if job.Status == "" {
+		job.Status = models.JobOpen
+	}
+	s.lastPosition++
+	s.jobs[job.ID] = job
+	s.jobIDs = append(s.jobIDs, job.ID)
+	s.positions[job.ID] = s.lastPosition
+	s.index.add(job)
+}
+
+// remove takes a job out of the catalogue. Callers must hold the lock.
+func (s *JobStore) remove(id string) {
+	delete(s.jobs, id)
+	delete(s.positions, id)
+	s.jobIDs = slices.DeleteFunc(s.jobIDs, func(existing string) bool { return existing == id })
+	s.index.remove(id)
+}
+
+// Position returns where a job sits in the catalogue: jobs added later
+// have higher positions, and positions are never reused while the server
+// runs. It reports false for jobs that do not exist.
+func (s *JobStore) Position(id string) (uint64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	position, exists := s.positions[id]
+	return position, exists
+}
+
+// all returns every job in catalogue order. Callers must hold the lock.
+func (s *JobStore) all() []models.Job {
+	result := make([]models.Job, 0, len(s.jobIDs))
+	for _, id := range s.jobIDs {
+		result = append(result, s.jobs[id])
+	}
+	return result
+}
+
+// Location returns where date-only job dates are read
+func (s *JobStore) Location() *time.Location {
+	return s.loc
+}
+
+// Create adds a job at the end of the catalogue, generating an ID when it
+// has none, and notifies the create listeners. Its Posted and Deadline
+// must already be parsed.
+func (s *JobStore) Create(job models.Job) (models.Job, error) {
+	s.mu.Lock()
+
+	if job.ID == "" {
+		job.ID = "job_" + uuid.New().String()[:8]
+	}
+	if _, exists := s.jobs[job.ID]; exists {
+		s.mu.Unlock()
+		return models.Job{}, fmt.Errorf("duplicate job: %s already exists", job.ID)
+	}
+
+	s.insert(job)
+	job = s.jobs[job.ID]
+	pending := s.persistJob(job)
+	listeners := s.listeners
+	s.mu.Unlock()
+
+	if err := waitSaved(pending); err != nil {
+		// Take it back out, as ApplicationStore.Create does
+		s.mu.Lock()
+		if _, exists := s.jobs[job.ID]; exists {
+			s.remove(job.ID)
+		}
+		s.mu.Unlock()
+		return models.Job{}, err
+	}
+	// Notify outside the lock so listeners can read the store
+	for _, listener := range listeners {
+		listener(job)
+	}
+	return job, nil
+}
+
+// OnCreate registers a listener for jobs added through Create
+func (s *JobStore) OnCreate(listener JobListener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, listener)
+}
+
+// mutate runs change under the lock and then, outside it, waits for what
+// change saved
+func (s *JobStore) mutate(change func() (models.Job, Pending, error)) (models.Job, error) {
+	s.mu.Lock()
+	job, pending, err := change()
+	s.mu.Unlock()
+
+	if err != nil {
+		return models.Job{}, err
+	}
+	if err := waitSaved(pending); err != nil {
+		return models.Job{}, err
+	}
+	return job, nil
+}
+
+// Update replaces the job with the same ID, keeping its place in the
+// catalogue. Its Posted and Deadline must already be parsed.
+func (s *JobStore) Update(job models.Job) (models.Job, error) {
+	return s.mutate(func() (models.Job, Pending, error) {
+		if _, exists := s.jobs[job.ID]; !exists {
+			return models.Job{}, nil, fmt.Errorf("job not found")
+		}
+		return job, s.replace(job), nil
+	})
+}
+
+// Close stops a job accepting applications by closing it and moving its
+// deadline to at. Deadlines that had already passed by then are kept.
+func (s *JobStore) Close(id string, at time.Time) (models.Job, error) {
+	return s.mutate(func() (models.Job, Pending, error) {
+		job, exists := s.jobs[id]
+		if !exists {
+			return models.Job{}, nil, fmt.Errorf("job not found")
+		}
+		if job.Status == models.JobClosed && !job.Deadline.IsZero() && !job.Deadline.After(at) {
+			return job, alreadySaved, nil
+		}
+
+		job.Status = models.JobClosed
+		if job.Deadline.IsZero() || job.Deadline.After(at) {
+			job.Deadline, job.ApplicationDeadline = at, dates.Format(at)
+		}
+		return job, s.replace(job), nil
+	})
+}
+
+// UpdateStatus moves a job to another status, if its current one may
+// change to it. A job whose deadline has passed cannot be reopened.
+func (s *JobStore) UpdateStatus(id string, status models.JobStatus) (models.Job, error) {
+	return s.mutate(func() (models.Job, Pending, error) {
+		job, exists := s.jobs[id]
+		switch {
+		case !exists:
+			return models.Job{}, nil, fmt.Errorf("job not found")
+		case job.Status == status:
+			return job, alreadySaved, nil
+		case !job.Status.CanBecome(status):
+			return models.Job{}, nil, fmt.Errorf("invalid transition: %s jobs cannot become %s", job.Status, status)
+		case status == models.JobOpen && !job.Deadline.IsZero() && time.Now().After(job.Deadline):
+			return models.Job{}, nil, fmt.Errorf("deadline passed: move application_deadline before reopening")
+		}
+
+		job.Status = status
+		return job, s.replace(job), nil
+	})
+}
+
+// AdvanceStatus moves a job from one status to another, leaving it alone
+// if its status is no longer from. It is how the lifecycle worker closes
+// and fills jobs without undoing a change made in the meantime.
+func (s *JobStore) AdvanceStatus(id string, from, to models.JobStatus) (models.Job, error) {
+	return s.mutate(func() (models.Job, Pending, error) {
+		job, exists := s.jobs[id]
+		switch {
+		case !exists:
+			return models.Job{}, nil, fmt.Errorf("job not found")
+		case job.Status != from:
+			return job, alreadySaved, nil
+		}
+
+		job.Status = to
+		return job, s.replace(job), nil
+	})
+}
+
+// Delete removes a job. Applications already made to it are kept.
+func (s *JobStore) Delete(id string) error {
+	_, err := s.mutate(func() (models.Job, Pending, error) {
+		if _, exists := s.jobs[id]; !exists {
+			return models.Job{}, nil, fmt.Errorf("job not found")
+		}
+
+		s.remove(id)
+		if s.persist == nil {
+			return models.Job{}, alreadySaved, nil
+		}
+		return models.Job{}, s.persist.DeleteJob(id), nil
+	})
+	return err
+}
+
+// Reset replaces the whole catalogue with jobs, or with the seed jobs when
+// jobs is nil. Jobs without an ID are given one. Their Posted and Deadline
+// must already be parsed. Positions keep counting up, so cursors into the
+// old catalogue never match a new job.
+func (s *JobStore) Reset(jobs []models.Job) error {
+	if jobs == nil {
+		seedJobs, err := ParseJobDates(s.seed, s.loc)
+		if err != nil {
+			return err
+		}
+		jobs = seedJobs
+	}
+
+	_, err := s.mutate(func() (models.Job, Pending, error) {
+		jobs = slices.Clone(jobs)
+		seen := make(map[string]bool, len(jobs))
+		for i := range jobs {
+			if jobs[i].ID == "" {
+				jobs[i].ID = "job_" + uuid.New().String()[:8]
+			}
+			if seen[jobs[i].ID] {
+				return models.Job{}, nil, fmt.Errorf("duplicate job: %s appears more than once", jobs[i].ID)
+			}
+			seen[jobs[i].ID] = true
+		}
+
+		s.load(jobs)
+		if s.persist == nil {
+			return models.Job{}, alreadySaved, nil
+		}
+		return models.Job{}, s.persist.SaveJobs(s.all()), nil
+	})
+	return err
+}
+
+// replace stores a new version of an existing job and hands it to the
+// persistence backend, if any. Callers must hold the lock.
+func (s *JobStore) replace(job models.Job) Pending {
+	s.jobs[job.ID] = job
+	s.index.add(job)
+	return s.persistJob(job)
+}
+
+// persistJob hands a new or changed job to the persistence backend, if
+// any. Callers must hold the lock.
+func (s *JobStore) persistJob(job models.Job) Pending {
+	if s.persist == nil {
+		return alreadySaved
+	}
+	return s.persist.PutJob(job)
+}
+
+// waitSaved waits for a change to the jobs to reach the persistence
+// backend
+func waitSaved(pending Pending) error {
+	if err := pending(); err != nil {
+		return fmt.Errorf("saving jobs: %w", err)
+	}
+	return nil
+}
+
+// ParseJobDates fills in the parsed Posted and Deadline of each job and
+// rewrites PostedAt and ApplicationDeadline in RFC 3339, so date-only
+// values are reported as the exact instant they were read as. The error
+// names every job with a malformed date.
+func ParseJobDates(jobs []models.Job, loc *time.Location) ([]models.Job, error) {
+	parsed := make([]models.Job, len(jobs))
+	var errs []error
+	for i, job := range jobs {
+		if job.PostedAt != "" {
+			t, err := dates.Parse(job.PostedAt, loc)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("job %s: posted_at %w", job.ID, err))
+			} else {
+				job.Posted, job.PostedAt = t, dates.Format(t)
+			}
+		}
+		if job.ApplicationDeadline != "" {
+			t, err := dates.ParseDeadline(job.ApplicationDeadline, loc)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("job %s: application_deadline %w", job.ID, err))
+			} else {
+				job.Deadline, job.ApplicationDeadline = t, dates.Format(t)
+			}
+		}
+		parsed[i] = job
+	}
+	return parsed, errors.Join(errs...)
 }
 
 // GetAll returns all jobs with optional limit
@@ -68,106 +432,73 @@ func (s *JobStore) GetCount() int {
 	return len(s.jobs)
 }
 
-// Search searches jobs by query (simple substring match in title, company, description)
+// Search searches jobs by query, returning those with every query term in
+// their title, company or description, most relevant first. See
+// searchIndex for how terms match and are ranked.
 func (s *JobStore) Search(query string, limit int) []models.Job {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	return s.search(query, limit, true)
+}
+
+// Match returns the jobs Search would, in catalogue order
+func (s *JobStore) Match(query string, limit int) []models.Job {
+	return s.search(query, limit, false)
+}
 
+func (s *JobStore) search(query string, limit int, ranked bool) []models.Job {
 	if query == "" {
 		return s.GetAll(limit)
 	}
 
-	result := make([]models.Job, 0)
-	count := 0
-
-	for _, id := range s.jobIDs {
-		if limit > 0 && count >= limit {
-			break
-		}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-		job := s.jobs[id]
-		// Simple case-insensitive search
-		if containsIgnoreCase(job.Title, query) ||
-			containsIgnoreCase(job.Company, query) ||
-			containsIgnoreCase(job.Description, query) {
-			result = append(result, job)
-			count++
-		}
+	ids := s.index.search(query, limit, ranked)
+	result := make([]models.Job, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, s.jobs[id])
 	}
 
 	return result
 }
 
-// FilterByRemote returns only remote jobs
-func (s *JobStore) FilterByRemote(limit int) []models.Job {
+// SnapshotIDs returns a copy of the ordered job IDs so callers can iterate
+// the catalogue without holding the read lock
+func (s *JobStore) SnapshotIDs() []string {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	result := make([]models.Job, 0)
-	count := 0
-
-	for _, id := range s.jobIDs {
-		if limit > 0 && count >= limit {
-			break
-		}
-
-		job := s.jobs[id]
-		if job.IsRemote || job.Remote {
-			result = append(result, job)
-			count++
-		}
-	}
-
-	return result
+	ids := make([]string, len(s.jobIDs))
+	copy(ids, s.jobIDs)
+	return ids
 }
 
-// FilterByJobType returns jobs of a specific type
-func (s *JobStore) FilterByJobType(jobType string, limit int) []models.Job {
+// GetBatch returns the jobs for the given IDs, skipping any that no longer exist
+func (s *JobStore) GetBatch(ids []string) []models.Job {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	result := make([]models.Job, 0)
-	count := 0
-
-	for _, id := range s.jobIDs {
-		if limit > 0 && count >= limit {
-			break
-		}
-
-		job := s.jobs[id]
-		if job.JobType == jobType {
+	result := make([]models.Job, 0, len(ids))
+	for _, id := range ids {
+		if job, exists := s.jobs[id]; exists {
 			result = append(result, job)
-			count++
 		}
 	}
-
 	return result
 }
 
-// containsIgnoreCase checks if s contains substr (case-insensitive)
-func containsIgnoreCase(s, substr string) bool {
-	return len(s) >= len(substr) &&
-		contains(toLower(s), toLower(substr))
+// MatchesQuery reports whether a job matches a search query the way
+// Search does
+func MatchesQuery(job models.Job, query string) bool {
+	return matchesTerms(job, query)
 }
 
-func toLower(s string) string {
-	result := make([]byte, len(s))
-	for i := 0; i < len(s); i++ {
-		c := s[i]
-		if c >= 'A' && c <= 'Z' {
-			result[i] = c + 32
-		} else {
-			result[i] = c
-		}
-	}
-	return string(result)
+// FilterByRemote returns only remote jobs
+func (s *JobStore) FilterByRemote(limit int) []models.Job {
+	remote := true
+	return s.Filter(JobFilter{Remote: &remote}, limit)
 }
 
-func contains(s, substr string) bool {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
-		}
-	}
-	return false
+// FilterByJobType returns jobs of a specific type
+func (s *JobStore) FilterByJobType(jobType string, limit int) []models.Job {
+	return s.Filter(JobFilter{JobType: jobType}, limit)
 }
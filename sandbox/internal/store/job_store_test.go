@@ -0,0 +1,333 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/dates"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+)
+
+// Words the generated jobs are written in, including ones that share a
+// prefix, differ only in case or accents, and are not in Latin script
+var (
+	titleWords = []string{
+		"Senior", "Junior", "Staff", "Backend", "Frontend", "Full-Stack", "Go", "Golang",
+		"Python", "Node.js", "C++", "Engineer", "Engineering", "Developer", "Manager",
+		"Data", "Database", "Platform", "SRE", "Ingénieur", "Développeur", "İstanbul",
+		"エンジニア", "Разработчик", "ML", "DevOps",
+	}
+	descriptionWords = []string{
+		"build", "builds", "building", "services", "APIs", "distributed", "systems",
+		"Kubernetes", "kafka", "postgres", "PostgreSQL", "café", "naïve", "team",
+		"remote-first", "on-call", "Σίγμα", "straße", "K8s", "2024", "v2",
+	}
+	companies = []string{"Acme", "Globex", "Initech", "Umbrella", "Hooli", "Société Générale", "Ωmega Labs"}
+	locations = []string{"Berlin", "San Francisco, CA", "İstanbul", "Zürich", "Remote", "Tokyo", "São Paulo"}
+	jobTypes  = []string{"full-time", "part-time", "internship", "contract"}
+)
+
+// benchmarkQueries are the queries the parity test and benchmarks run:
+// whole words, prefixes, several words, mixed case, accents, non-Latin
+// scripts, punctuation and words no job has
+var benchmarkQueries = []string{
+	"engineer", "engin", "ENGINEER", "go", "golang", "node.js", "node", "js", "c++",
+	"senior backend", "backend senior", "data base", "database", "ingénieur", "ingenieur",
+	"istanbul", "İSTANBUL", "エンジニア", "разраб", "café", "cafe", "STRASSE", "straße",
+	"σίγμα", "k8s", "2024", "acme", "société", "ωmega", "build services", "kafka postgres team",
+	"nothing-matches", "   ", "!!!", "e",
+}
+
+// corpus generates n jobs from rng
+func corpus(rng *rand.Rand, n int) []models.Job {
+	pick := func(words []string, count int) string {
+		chosen := make([]string, count)
+		for i := range chosen {
+			chosen[i] = words[rng.IntN(len(words))]
+		}
+		return strings.Join(chosen, " ")
+	}
+
+	jobs := make([]models.Job, n)
+	for i := range jobs {
+		low := 40 + rng.IntN(160)
+		jobs[i] = models.Job{
+			ID:                 fmt.Sprintf("job_%06d", i),
+			Title:              pick(titleWords, 2+rng.IntN(3)),
+			Company:            companies[rng.IntN(len(companies))],
+			Location:           locations[rng.IntN(len(locations))],
+			Description:        pick(descriptionWords, 10+rng.IntN(30)),
+			IsRemote:           rng.IntN(3) == 0,
+			JobType:            jobTypes[rng.IntN(len(jobTypes))],
+			Salary:             fmt.Sprintf("$%d,000 - $%d,000", low, low+rng.IntN(60)),
+			ExperienceRequired: rng.IntN(12),
+		}
+	}
+	return jobs
+}
+
+// linearFilter is Filter without the search index: every job in catalogue
+// order, kept when it matches
+func linearFilter(s *JobStore, f JobFilter, limit int) []models.Job {
+	result := make([]models.Job, 0)
+	for _, job := range s.GetAll(0) {
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+		if f.Matches(job) {
+			result = append(result, job)
+		}
+	}
+	return result
+}
+
+// ids lists the IDs of jobs
+func ids(jobs []models.Job) []string {
+	result := make([]string, len(jobs))
+	for i, job := range jobs {
+		result[i] = job.ID
+	}
+	return result
+}
+
+// TestFilterMatchesLinearScan checks the indexed Filter, Match and Search
+// find exactly the jobs a scan of the catalogue does, before and after the
+// index is changed by updates and deletes
+func TestFilterMatchesLinearScan(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	s, err := NewJobStore(corpus(rng, 2000), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	remote := true
+	few, many := 2, 8
+	filters := func(query string) []JobFilter {
+		return []JobFilter{
+			{Query: query},
+			{Query: query, Company: "acme"},
+			{Query: query, Location: "istanbul", Remote: &remote},
+			{Query: query, JobType: "contract", MinSalary: 120000},
+			{Query: query, MinExperience: &few, MaxExperience: &many},
+		}
+	}
+	check := func(t *testing.T) {
+		for _, query := range benchmarkQueries {
+			for _, f := range filters(query) {
+				for _, limit := range []int{0, 5} {
+					got, want := ids(s.Filter(f, limit)), ids(linearFilter(s, f, limit))
+					if !slices.Equal(got, want) {
+						t.Errorf("Filter(%+v, %d) found %d jobs, a scan %d: %v, want %v", f, limit, len(got), len(want), head(got), head(want))
+					}
+				}
+			}
+
+			if strings.TrimSpace(query) == "" {
+				continue
+			}
+			want := ids(linearFilter(s, JobFilter{Query: query}, 0))
+			if got := ids(s.Match(query, 0)); !slices.Equal(got, want) {
+				t.Errorf("Match(%q) = %v, want %v", query, head(got), head(want))
+			}
+			ranked := ids(s.Search(query, 0))
+			slices.Sort(ranked)
+			slices.Sort(want)
+			if !slices.Equal(ranked, want) {
+				t.Errorf("Search(%q) found %d jobs, a scan %d", query, len(ranked), len(want))
+			}
+		}
+	}
+
+	t.Run("as loaded", check)
+
+	for i, job := range corpus(rng, 300) {
+		job.ID = fmt.Sprintf("job_%06d", rng.IntN(2000))
+		if i%3 == 0 {
+			if err := s.Delete(job.ID); err != nil && !strings.Contains(err.Error(), "not found") {
+				t.Fatal(err)
+			}
+			continue
+		}
+		if _, err := s.Update(job); err != nil && !strings.Contains(err.Error(), "not found") {
+			t.Fatal(err)
+		}
+	}
+	for _, job := range corpus(rng, 100) {
+		job.ID = ""
+		if _, err := s.Create(job); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Run("after updates and deletes", check)
+}
+
+// head shortens a list of IDs for a failure message
+func head(ids []string) []string {
+	if len(ids) > 5 {
+		return ids[:5]
+	}
+	return ids
+}
+
+func BenchmarkFilter(b *testing.B) {
+	remote := true
+	filters := []struct {
+		name string
+		f    JobFilter
+	}{
+		{"word", JobFilter{Query: "engineer"}},
+		{"prefix", JobFilter{Query: "eng"}},
+		{"words", JobFilter{Query: "senior backend go"}},
+		{"no match", JobFilter{Query: "nothing-matches"}},
+		{"company", JobFilter{Company: "société"}},
+		{"location", JobFilter{Location: "istanbul", Remote: &remote}},
+		{"everything", JobFilter{Query: "developer", Company: "acme", JobType: "full-time", MinSalary: 100000}},
+	}
+
+	for _, n := range []int{1000, 10000, 50000} {
+		s, err := NewJobStore(corpus(rand.New(rand.NewPCG(1, 2)), n), nil)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, tt := range filters {
+			b.Run(fmt.Sprintf("%d jobs/%s/indexed", n, tt.name), func(b *testing.B) {
+				for b.Loop() {
+					s.Filter(tt.f, 20)
+				}
+			})
+			if tt.f.Query == "" {
+				continue
+			}
+			b.Run(fmt.Sprintf("%d jobs/%s/linear", n, tt.name), func(b *testing.B) {
+				for b.Loop() {
+					linearFilter(s, tt.f, 20)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkSearch(b *testing.B) {
+	for _, n := range []int{1000, 10000, 50000} {
+		s, err := NewJobStore(corpus(rand.New(rand.NewPCG(1, 2)), n), nil)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, query := range []string{"engineer", "eng", "senior backend go"} {
+			b.Run(fmt.Sprintf("%d jobs/%s", n, query), func(b *testing.B) {
+				for b.Loop() {
+					s.Search(query, 20)
+				}
+			})
+		}
+	}
+}
+
+// TestSearchFoldsCase checks search, company and location matching ignore
+// case in every script, treat the Turkish i forms alike and keep accents
+func TestSearchFoldsCase(t *testing.T) {
+	s, err := NewJobStore([]models.Job{
+		{ID: "munich", Title: "Backend Engineer", Company: "Bücher GmbH", Location: "München, Germany"},
+		{ID: "lodz", Title: "Inżynier Oprogramowania", Company: "Łódź Software", Location: "Łódź, Poland"},
+		{ID: "istanbul", Title: "Yazılım Mühendisi", Company: "İstanbul Teknoloji", Location: "İstanbul"},
+		{ID: "athens", Title: "ΜΗΧΑΝΙΚΟΣ Λογισμικού", Company: "Ωmega Labs", Location: "Αθήνα"},
+		{ID: "tokyo", Title: "Go エンジニア", Company: "東京 Systems", Location: "Tokyo"},
+		{ID: "moscow", Title: "Go Разработчик", Company: "Москва Tech", Location: "Москва"},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		f    JobFilter
+		want []string
+	}{
+		{JobFilter{Query: "MÜNCHEN"}, nil}, // Location is not searched
+		{JobFilter{Query: "bücher"}, []string{"munich"}},
+		{JobFilter{Query: "BÜCH"}, []string{"munich"}},
+		{JobFilter{Query: "bucher"}, nil},
+		{JobFilter{Location: "münchen"}, []string{"munich"}},
+		{JobFilter{Location: "MUNCHEN"}, nil},
+		{JobFilter{Company: "ŁÓDŹ"}, []string{"lodz"}},
+		{JobFilter{Query: "inżynier"}, []string{"lodz"}},
+		{JobFilter{Query: "INŻ"}, []string{"lodz"}},
+
+		// Turkish i forms all match each other
+		{JobFilter{Query: "istanbul"}, []string{"istanbul"}},
+		{JobFilter{Query: "ISTANBUL"}, []string{"istanbul"}},
+		{JobFilter{Query: "yazilim"}, []string{"istanbul"}},
+		{JobFilter{Query: "YAZILIM"}, []string{"istanbul"}},
+		{JobFilter{Location: "istanbul"}, []string{"istanbul"}},
+		{JobFilter{Company: "İSTANBUL"}, []string{"istanbul"}},
+
+		// Other scripts, and queries mixing them
+		{JobFilter{Query: "μηχανικος"}, []string{"athens"}}, // Final sigma matches Σ
+		{JobFilter{Query: "ΩMEGA"}, []string{"athens"}},
+		{JobFilter{Location: "ΑΘΉΝΑ"}, []string{"athens"}},
+		{JobFilter{Query: "go"}, []string{"tokyo", "moscow"}},
+		{JobFilter{Query: "GO エンジニア"}, []string{"tokyo"}},
+		{JobFilter{Query: "東京 go"}, []string{"tokyo"}},
+		{JobFilter{Query: "go РАЗРАБ"}, []string{"moscow"}},
+		{JobFilter{Query: "москва TECH"}, []string{"moscow"}},
+		{JobFilter{Query: "エンジニア разработчик"}, nil},
+	}
+	for _, tt := range tests {
+		got := ids(s.Filter(tt.f, 0))
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Filter(%+v) = %v, want %v", tt.f, got, tt.want)
+		}
+		for _, job := range s.GetAll(0) {
+			if want := slices.Contains(tt.want, job.ID); tt.f.Matches(job) != want {
+				t.Errorf("%+v matches %s: %v, want %v", tt.f, job.ID, !want, want)
+			}
+		}
+	}
+}
+
+// TestParseJobDates checks job dates are read in the sandbox zone unless
+// they carry an offset, and that every job with a bad date is named
+func TestParseJobDates(t *testing.T) {
+	kolkata := time.FixedZone("IST", 5*60*60+30*60)
+	jobs, err := ParseJobDates([]models.Job{
+		{ID: "date", PostedAt: "2025-01-01", ApplicationDeadline: "2025-01-31"},
+		{ID: "offset", PostedAt: "2025-01-01T09:00:00-05:00", ApplicationDeadline: "2025-01-31T17:00:00-08:00"},
+		{ID: "none"},
+	}, kolkata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		posted, deadline string
+	}{
+		{"2025-01-01T00:00:00+05:30", "2025-01-31T23:59:59+05:30"},
+		{"2025-01-01T09:00:00-05:00", "2025-01-31T17:00:00-08:00"},
+		{"", ""},
+	}
+	for i, tt := range tests {
+		job := jobs[i]
+		if job.PostedAt != tt.posted || job.ApplicationDeadline != tt.deadline {
+			t.Errorf("%s: posted %q, deadline %q, want %q, %q", job.ID, job.PostedAt, job.ApplicationDeadline, tt.posted, tt.deadline)
+		}
+		if tt.deadline != "" && dates.Format(job.Deadline) != tt.deadline {
+			t.Errorf("%s: parsed deadline %v, want %s", job.ID, job.Deadline, tt.deadline)
+		}
+	}
+
+	_, err = ParseJobDates([]models.Job{
+		{ID: "good", ApplicationDeadline: "2025-01-31"},
+		{ID: "no_offset", ApplicationDeadline: "2025-01-31T23:59:59"},
+		{ID: "slashes", PostedAt: "01/31/2025"},
+	}, nil)
+	if !errors.Is(err, dates.ErrFormat) || strings.Contains(err.Error(), "good") ||
+		!strings.Contains(err.Error(), "job no_offset: application_deadline") || !strings.Contains(err.Error(), "job slashes: posted_at") {
+		t.Errorf("error %v, want both bad jobs named", err)
+	}
+	if _, err := NewJobStore([]models.Job{{ID: "bad", ApplicationDeadline: "2025-02-30"}}, nil); err == nil {
+		t.Error("a store was made from a job with a bad deadline")
+	}
+}
@@ -0,0 +1,65 @@
+package store
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/emailaddr"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/google/uuid"
+)
+
+// maxMailboxMessages caps how many messages a mailbox keeps, dropping the
+// oldest, so a runaway agent cannot exhaust memory
+const maxMailboxMessages = 100
+
+// MailStore keeps the simulated emails sent to applicants, in a mailbox
+// per address
+type MailStore struct {
+	mailboxes map[string][]models.Email // Normalized address -> oldest first
+	mu        sync.RWMutex
+}
+
+// NewMailStore creates a new mail store with every mailbox empty
+func NewMailStore() *MailStore {
+	return &MailStore{mailboxes: make(map[string][]models.Email)}
+}
+
+// Deliver puts an email in the mailbox of its recipient, giving it an ID
+// and sending time
+func (s *MailStore) Deliver(email models.Email) models.Email {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	email.ID = "msg_" + uuid.New().String()[:8]
+	email.SentAt = time.Now().UTC()
+	address := emailaddr.Normalize(email.To)
+	mailbox := append(s.mailboxes[address], email)
+	if len(mailbox) > maxMailboxMessages {
+		mailbox = slices.Delete(mailbox, 0, len(mailbox)-maxMailboxMessages)
+	}
+	s.mailboxes[address] = mailbox
+	return email
+}
+
+// Messages returns the emails sent to address, newest first. Addresses are
+// matched the way applications are looked up by email.
+func (s *MailStore) Messages(address string) []models.Email {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	messages := slices.Clone(s.mailboxes[emailaddr.Normalize(address)])
+	slices.Reverse(messages)
+	if messages == nil {
+		messages = []models.Email{}
+	}
+	return messages
+}
+
+// Clear empties every mailbox
+func (s *MailStore) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mailboxes = make(map[string][]models.Email)
+}
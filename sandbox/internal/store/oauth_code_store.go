@@ -0,0 +1,50 @@
+package store
+
+import (
+	"sync"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/google/uuid"
+)
+
+// OAuthCodeStore holds the authorization codes the mock OAuth provider has
+// issued and that have not been redeemed yet
+type OAuthCodeStore struct {
+	grants map[string]models.OAuthGrant // Keyed by code
+	mu     sync.Mutex
+}
+
+// NewOAuthCodeStore creates a new authorization code store
+func NewOAuthCodeStore() *OAuthCodeStore {
+	return &OAuthCodeStore{grants: make(map[string]models.OAuthGrant)}
+}
+
+// Issue stores grant and returns the code standing for it
+func (s *OAuthCodeStore) Issue(grant models.OAuthGrant) string {
+	code := "oc_" + uuid.New().String()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.grants[code] = grant
+	return code
+}
+
+// Redeem returns the grant of a code and forgets it, so each code is used
+// once. Expired codes are not found.
+func (s *OAuthCodeStore) Redeem(code string) (models.OAuthGrant, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	grant, exists := s.grants[code]
+	delete(s.grants, code)
+	if !exists || time.Now().After(grant.ExpiresAt) {
+		return models.OAuthGrant{}, false
+	}
+	return grant, true
+}
+
+// Clear forgets every code
+func (s *OAuthCodeStore) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.grants = make(map[string]models.OAuthGrant)
+}
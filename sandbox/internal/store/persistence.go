@@ -0,0 +1,381 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+)
+
+// Persistence saves what the job and application stores hold so it
+// survives restarts. The stores hand it every mutation while holding their
+// own lock, so changes arrive in the order they happened, but handing one
+// over does no I/O: the store waits for the returned Pending after
+// releasing its lock, so slow disks never hold up readers.
+type Persistence interface {
+	// Jobs returns the saved jobs in catalogue order. It reports false
+	// until jobs have been saved once, so a new database can be seeded.
+	Jobs() ([]models.Job, bool, error)
+	// SaveJobs replaces the saved jobs
+	SaveJobs(jobs []models.Job) Pending
+	// PutJob saves a new job, at the end of the catalogue, or a new
+	// version of one, in its place
+	PutJob(job models.Job) Pending
+	// DeleteJob removes a saved job
+	DeleteJob(id string) Pending
+	// Applications returns the saved applications in submission order
+	Applications() ([]models.Application, error)
+	// PutApplication saves a new application or a new version of one
+	PutApplication(app models.Application) Pending
+	// DeleteApplications removes every saved application
+	DeleteApplications() Pending
+}
+
+// Pending is a change handed to Persistence. Calling it waits until the
+// change is on disk and reports whether it got there.
+type Pending func() error
+
+// alreadySaved is the Pending of a change with nothing to save
+func alreadySaved() error { return nil }
+
+// fileFormatVersion is written at the top of every journal so its layout
+// can change. Version 1 files held the whole state in one JSON object;
+// they are still read, and are rewritten as journals when opened.
+const fileFormatVersion = 2
+
+// fileState is the layout of version 1 files
+type fileState struct {
+	Version      int                  `json:"version"`
+	JobsSaved    bool                 `json:"jobs_saved"`
+	Jobs         []models.Job         `json:"jobs"`
+	Applications []models.Application `json:"applications"`
+}
+
+// Journal operations, one per Persistence mutation
+const (
+	opOpen              = "open" // First entry, carrying the format version
+	opJobs              = "jobs"
+	opJob               = "job"
+	opDeleteJob         = "delete_job"
+	opApplication       = "application"
+	opClearApplications = "clear_applications"
+)
+
+// journalEntry is one line of the journal
+type journalEntry struct {
+	Op          string              `json:"op"`
+	Version     int                 `json:"version,omitempty"`
+	Jobs        []models.Job        `json:"jobs,omitempty"`
+	Job         *models.Job         `json:"job,omitempty"`
+	ID          string              `json:"id,omitempty"`
+	Application *models.Application `json:"application,omitempty"`
+}
+
+// FilePersistence keeps jobs and applications in an append-only journal of
+// JSON lines, one per change. Changes are queued in the order they are
+// handed over and written by whichever caller waits first, so changes made
+// while a write is in progress share the next write and fsync.
+//
+// The journal is compacted when it is opened, by writing the current state
+// to a temporary file and renaming it over the original. A crash while
+// appending can only tear the last line, which is dropped when the journal
+// is read back. After a failed write the journal takes no more changes, so
+// nothing after it is reported saved. The stores take a new job or
+// application whose write failed back out; other changes that failed stay
+// in memory until restart.
+type FilePersistence struct {
+	path      string
+	file      journalWriter
+	jobsSaved bool
+	jobs      []models.Job
+	apps      []models.Application
+	appByID   map[string]int // Application ID -> index in apps
+	queue     []journalEntry // Handed over but not yet written
+	queued    uint64         // Entries ever handed over
+	written   uint64         // Entries written and synced
+	writing   bool           // A caller is writing the queue
+	err       error          // Why the journal stopped taking changes
+	mu        sync.Mutex
+	flushed   *sync.Cond // Signalled when a write finishes
+}
+
+// journalWriter is where the journal is appended: the open file, or a
+// stand-in that fails in tests
+type journalWriter interface {
+	io.Writer
+	Sync() error
+	Close() error
+}
+
+// OpenFilePersistence opens the journal at path, creating it on first use
+func OpenFilePersistence(path string) (*FilePersistence, error) {
+	p := &FilePersistence{path: path, appByID: make(map[string]int)}
+	p.flushed = sync.NewCond(&p.mu)
+
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return nil, err
+	default:
+		if err := p.read(data); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	if err := p.compact(); err != nil {
+		return nil, err
+	}
+	p.file, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// read loads a journal, or a version 1 file
+func (p *FilePersistence) read(data []byte) error {
+	first, _, _ := bytes.Cut(data, []byte("\n"))
+	var header journalEntry
+	if err := json.Unmarshal(first, &header); err != nil {
+		return err
+	}
+	if header.Op == "" && header.Version == 1 {
+		var state fileState
+		if err := json.Unmarshal(data, &state); err != nil {
+			return err
+		}
+		p.jobsSaved, p.jobs = state.JobsSaved, state.Jobs
+		for _, app := range state.Applications {
+			p.apply(journalEntry{Op: opApplication, Application: &app})
+		}
+		return nil
+	}
+	if header.Op != opOpen || header.Version != fileFormatVersion {
+		return fmt.Errorf("unsupported format version %d", header.Version)
+	}
+
+	lines := bufio.NewScanner(bytes.NewReader(data))
+	lines.Buffer(nil, len(data)+1)
+	lines.Scan() // The header
+	for n := 2; lines.Scan(); n++ {
+		var entry journalEntry
+		if err := json.Unmarshal(lines.Bytes(), &entry); err != nil {
+			if !bytes.HasSuffix(data, []byte("\n")) && bytes.HasSuffix(data, lines.Bytes()) {
+				// A torn last line from a crash while appending
+				break
+			}
+			return fmt.Errorf("line %d: %w", n, err)
+		}
+		if err := p.apply(entry); err != nil {
+			return fmt.Errorf("line %d: %w", n, err)
+		}
+	}
+	return lines.Err()
+}
+
+// apply makes an entry's change to the state. Callers must hold the lock
+// once the journal is open.
+func (p *FilePersistence) apply(entry journalEntry) error {
+	switch entry.Op {
+	case opJobs:
+		p.jobs, p.jobsSaved = entry.Jobs, true
+	case opJob:
+		if entry.Job == nil {
+			return errors.New("job entry without a job")
+		}
+		if i := slices.IndexFunc(p.jobs, func(job models.Job) bool { return job.ID == entry.Job.ID }); i >= 0 {
+			p.jobs = slices.Clone(p.jobs)
+			p.jobs[i] = *entry.Job
+		} else {
+			p.jobs = append(slices.Clip(p.jobs), *entry.Job)
+		}
+		p.jobsSaved = true
+	case opDeleteJob:
+		p.jobs = slices.DeleteFunc(slices.Clone(p.jobs), func(job models.Job) bool { return job.ID == entry.ID })
+	case opApplication:
+		if entry.Application == nil {
+			return errors.New("application entry without an application")
+		}
+		if i, exists := p.appByID[entry.Application.ID]; exists {
+			p.apps[i] = *entry.Application
+		} else {
+			p.appByID[entry.Application.ID] = len(p.apps)
+			p.apps = append(p.apps, *entry.Application)
+		}
+	case opClearApplications:
+		p.apps, p.appByID = nil, make(map[string]int)
+	default:
+		return fmt.Errorf("unknown operation %q", entry.Op)
+	}
+	return nil
+}
+
+// compact replaces the file with a journal of just the current state
+func (p *FilePersistence) compact() error {
+	entries := []journalEntry{{Op: opOpen, Version: fileFormatVersion}}
+	if p.jobsSaved {
+		entries = append(entries, journalEntry{Op: opJobs, Jobs: p.jobs})
+	}
+	for i := range p.apps {
+		entries = append(entries, journalEntry{Op: opApplication, Application: &p.apps[i]})
+	}
+	data, err := encodeEntries(entries)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(p.path), filepath.Base(p.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), p.path)
+}
+
+// encodeEntries encodes entries as JSON lines
+func encodeEntries(entries []journalEntry) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// Close closes the journal. Changes handed over afterwards fail.
+func (p *FilePersistence) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for p.writing {
+		p.flushed.Wait()
+	}
+	if p.err == nil {
+		p.err = errors.New("journal closed")
+	}
+	return p.file.Close()
+}
+
+// Jobs implements Persistence
+func (p *FilePersistence) Jobs() ([]models.Job, bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Clone(p.jobs), p.jobsSaved, nil
+}
+
+// SaveJobs implements Persistence
+func (p *FilePersistence) SaveJobs(jobs []models.Job) Pending {
+	return p.append(journalEntry{Op: opJobs, Jobs: slices.Clone(jobs)})
+}
+
+// PutJob implements Persistence
+func (p *FilePersistence) PutJob(job models.Job) Pending {
+	return p.append(journalEntry{Op: opJob, Job: &job})
+}
+
+// DeleteJob implements Persistence
+func (p *FilePersistence) DeleteJob(id string) Pending {
+	return p.append(journalEntry{Op: opDeleteJob, ID: id})
+}
+
+// Applications implements Persistence
+func (p *FilePersistence) Applications() ([]models.Application, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Clone(p.apps), nil
+}
+
+// PutApplication implements Persistence
+func (p *FilePersistence) PutApplication(app models.Application) Pending {
+	return p.append(journalEntry{Op: opApplication, Application: &app})
+}
+
+// DeleteApplications implements Persistence
+func (p *FilePersistence) DeleteApplications() Pending {
+	return p.append(journalEntry{Op: opClearApplications})
+}
+
+// append queues an entry for the next write, without writing it
+func (p *FilePersistence) append(entry journalEntry) Pending {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.err; err != nil {
+		return func() error { return err }
+	}
+	if err := p.apply(entry); err != nil {
+		return func() error { return err }
+	}
+	p.queue = append(p.queue, entry)
+	p.queued++
+	seq := p.queued
+	return func() error { return p.wait(seq) }
+}
+
+// wait returns once the entry numbered seq is written, writing the queue
+// itself if no other caller is
+func (p *FilePersistence) wait(seq uint64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for p.written < seq {
+		switch {
+		case p.err != nil:
+			return p.err
+		case p.writing:
+			p.flushed.Wait()
+		default:
+			p.flush()
+		}
+	}
+	return nil
+}
+
+// flush writes and syncs the queue. Callers must hold the lock, which is
+// released while writing.
+func (p *FilePersistence) flush() {
+	batch := p.queue
+	p.queue = nil
+	p.writing = true
+	p.mu.Unlock()
+
+	data, err := encodeEntries(batch)
+	if err == nil {
+		_, err = p.file.Write(data)
+	}
+	if err == nil {
+		err = p.file.Sync()
+	}
+
+	p.mu.Lock()
+	p.writing = false
+	if err != nil {
+		p.err = fmt.Errorf("journal write failed: %w", err)
+	} else {
+		p.written += uint64(len(batch))
+	}
+	p.flushed.Broadcast()
+}
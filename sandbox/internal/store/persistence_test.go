@@ -0,0 +1,251 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+)
+
+// openStores opens the journal at path and restores a job store seeded
+// with testJob and an application store from it
+func openStores(t *testing.T, path string) (*FilePersistence, *JobStore, *ApplicationStore) {
+	t.Helper()
+	p, err := OpenFilePersistence(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { p.Close() })
+	jobs, err := NewJobStore([]models.Job{testJob}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	apps := NewApplicationStore()
+	if err := jobs.Restore(p); err != nil {
+		t.Fatal(err)
+	}
+	if err := apps.Restore(p); err != nil {
+		t.Fatal(err)
+	}
+	return p, jobs, apps
+}
+
+// summary describes the stores by what each job and application is, in
+// order, to compare them across a restart
+func summary(jobs *JobStore, apps *ApplicationStore) string {
+	var b strings.Builder
+	for _, job := range jobs.GetAll(0) {
+		fmt.Fprintf(&b, "job %s %s %s\n", job.ID, job.Title, job.Status)
+	}
+	for _, app := range apps.Snapshot() {
+		fmt.Fprintf(&b, "application %s %s %s %d %s\n", app.ID, app.ApplicantEmail, app.Status, len(app.StatusHistory), app.UpdatedAt.UTC())
+	}
+	return b.String()
+}
+
+// TestFilePersistenceSurvivesRestart checks every kind of change is in the
+// journal when the stores are opened again
+func TestFilePersistenceSurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sandbox.json")
+	p, jobs, apps := openStores(t, path)
+
+	if _, err := jobs.Create(models.Job{ID: "job_new", Title: "Data Analyst", Company: "Globex"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := jobs.Create(models.Job{ID: "job_gone", Title: "Designer", Company: "Globex"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := jobs.UpdateStatus("job_new", models.JobPaused); err != nil {
+		t.Fatal(err)
+	}
+	if err := jobs.Delete("job_gone"); err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, email := range []string{"ann@example.com", "bob@example.com", "cat@example.com"} {
+		app, err := apps.Create(testRequest(email, ""), testJob, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, app.ID)
+	}
+	if _, err := apps.UpdateStatus(ids[0], models.StatusReviewing, "Looks good", models.ActorAPI); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := apps.Withdraw(ids[1], "Took another offer"); err != nil {
+		t.Fatal(err)
+	}
+	want := summary(jobs, apps)
+	p.Close()
+
+	p, jobs, apps = openStores(t, path)
+	if got := summary(jobs, apps); got != want {
+		t.Errorf("after a restart:\n%s\nwant:\n%s", got, want)
+	}
+
+	if _, err := apps.ClearAll(); err != nil {
+		t.Fatal(err)
+	}
+	if err := jobs.Reset(nil); err != nil {
+		t.Fatal(err)
+	}
+	want = summary(jobs, apps)
+	p.Close()
+
+	_, jobs, apps = openStores(t, path)
+	if got := summary(jobs, apps); got != want {
+		t.Errorf("after clearing and a restart:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// TestFilePersistenceConcurrentWrites checks changes made at once from
+// many goroutines, which share writes, all reach the journal
+func TestFilePersistenceConcurrentWrites(t *testing.T) {
+	const workers, each = 8, 25
+	path := filepath.Join(t.TempDir(), "sandbox.json")
+	p, jobs, apps := openStores(t, path)
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Go(func() {
+			for i := range each {
+				app, err := apps.Create(testRequest(fmt.Sprintf("worker%d.%d@example.com", w, i), ""), testJob, nil)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if i%2 == 0 {
+					if _, err := apps.UpdateStatus(app.ID, models.StatusReviewing, "", models.ActorAPI); err != nil {
+						t.Error(err)
+						return
+					}
+				}
+			}
+		})
+	}
+	wg.Wait()
+	want := summary(jobs, apps)
+	p.Close()
+
+	_, jobs, apps = openStores(t, path)
+	if got := summary(jobs, apps); got != want {
+		t.Errorf("after a restart:\n%s\nwant:\n%s", got, want)
+	}
+	if got := apps.GetCount(); got != workers*each {
+		t.Errorf("%d applications after a restart, want %d", got, workers*each)
+	}
+}
+
+// TestFilePersistenceTornLine checks a last line cut short by a crash is
+// dropped, while damage anywhere else fails the open
+func TestFilePersistenceTornLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sandbox.json")
+	p, _, apps := openStores(t, path)
+	if _, err := apps.Create(testRequest("ann@example.com", ""), testJob, nil); err != nil {
+		t.Fatal(err)
+	}
+	p.Close()
+	journal, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	torn := append(journal, `{"op":"application","application":{"id":"torn`...)
+	if err := os.WriteFile(path, torn, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, apps := openStores(t, path); apps.GetCount() != 1 {
+		t.Errorf("%d applications with a torn last line, want the 1 before it", apps.GetCount())
+	}
+
+	damaged := strings.Replace(string(journal), `{"op":"jobs"`, `{"op":"jobs"!`, 1)
+	if err := os.WriteFile(path, []byte(damaged), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenFilePersistence(path); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("damaged line 2: %v, want an error naming it", err)
+	}
+}
+
+// TestFilePersistenceReadsVersion1 checks files written before the journal
+// are read, and rewritten as journals
+func TestFilePersistenceReadsVersion1(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sandbox.json")
+	old, err := json.Marshal(fileState{
+		Version:      1,
+		JobsSaved:    true,
+		Jobs:         []models.Job{testJob},
+		Applications: []models.Application{{ID: "app_1", JobID: testJob.ID, ApplicantEmail: "ann@example.com", Status: models.StatusReceived}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, old, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, jobs, apps := openStores(t, path)
+	if _, ok := jobs.GetByID(testJob.ID); !ok || apps.GetCount() != 1 {
+		t.Errorf("version 1 file read as %q", summary(jobs, apps))
+	}
+	if journal, _ := os.ReadFile(path); !strings.HasPrefix(string(journal), `{"op":"open","version":2}`+"\n") {
+		t.Errorf("version 1 file rewritten as %q, want a journal", journal)
+	}
+}
+
+// TestFilePersistenceClosed checks changes made after the journal is
+// closed fail instead of being reported saved
+func TestFilePersistenceClosed(t *testing.T) {
+	p, _, apps := openStores(t, filepath.Join(t.TempDir(), "sandbox.json"))
+	p.Close()
+	if _, err := apps.Create(testRequest("ann@example.com", ""), testJob, nil); err == nil || !strings.Contains(err.Error(), "saving application") {
+		t.Errorf("Create after Close: %v, want a saving error", err)
+	}
+}
+
+// failingWriter is a journal whose writes fail, as on a full disk
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("no space left on device") }
+func (failingWriter) Sync() error               { return nil }
+func (failingWriter) Close() error              { return nil }
+
+// TestFailedWriteTakesBackCreates checks a job or application whose write
+// fails is not left in the store, served as if it had been saved
+func TestFailedWriteTakesBackCreates(t *testing.T) {
+	p, jobs, apps := openStores(t, filepath.Join(t.TempDir(), "sandbox.json"))
+	p.file.Close()
+	p.file = failingWriter{}
+	var notified []string
+	apps.OnSubmit(func(app models.Application) { notified = append(notified, app.ID) })
+
+	if _, err := apps.Create(testRequest("ann@example.com", ""), testJob, nil); err == nil || !strings.Contains(err.Error(), "no space left") {
+		t.Fatalf("Create with a failing journal: %v, want the write error", err)
+	}
+	if n := apps.GetCount(); n != 0 || len(apps.Snapshot()) != 0 || len(apps.GetByEmail("ann@example.com", NewestFirst)) != 0 {
+		t.Errorf("%d applications after the failed save, want none", n)
+	}
+	if len(notified) != 0 {
+		t.Errorf("submit listeners told of %v, want nothing", notified)
+	}
+	log := apps.Log(0, 10).Entries
+	if len(log) != 2 || log[0].Type != models.LogApplicationCreated || log[1].Type != models.LogApplicationDiscarded {
+		t.Errorf("log %+v, want the creation and then its discarding", log)
+	}
+
+	if _, err := jobs.Create(models.Job{ID: "job_new", Title: "Data Analyst", Company: "Globex"}); err == nil {
+		t.Fatal("job Create with a failing journal succeeded")
+	}
+	if _, exists := jobs.GetByID("job_new"); exists {
+		t.Error("job_new is served after its save failed")
+	}
+	if got := len(jobs.GetAll(0)); got != 1 {
+		t.Errorf("%d jobs after the failed save, want the seed job alone", got)
+	}
+}
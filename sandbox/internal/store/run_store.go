@@ -0,0 +1,477 @@
+package store
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/scoring"
+	"github.com/google/uuid"
+)
+
+// statusClientClosedRequest is the status recorded for requests the client
+// abandoned, as respond.StatusClientClosedRequest
+const statusClientClosedRequest = 499
+
+// maxRunRequests caps how many requests a run lists in its report, so a
+// runaway agent cannot exhaust memory; they are all still counted
+const maxRunRequests = 1000
+
+// LeaderboardWeights are how much each part of a run's leaderboard score
+// counts; they add up to 1
+var LeaderboardWeights = models.LeaderboardScores{
+	Submissions:        0.4,
+	DuplicateAvoidance: 0.15,
+	RetryEfficiency:    0.15,
+	HoneypotAvoidance:  0.2,
+	Latency:            0.1,
+}
+
+// maxRunRecordings caps how many requests a run's recording keeps, for
+// the same reason
+const maxRunRecordings = 1000
+
+// RunStore keeps the agent runs and what they did
+type RunStore struct {
+	runs map[string]*run
+	mu   sync.RWMutex
+}
+
+// run is a Run with its recorded requests
+type run struct {
+	models.Run
+	report    models.RunReport
+	latencies map[string]int64 // Endpoint -> total latency in milliseconds
+	endpoints map[string]*models.RunEndpointStats
+	failed    map[string]bool // Method and path -> whether the last request failed
+
+	recordings          []models.Recording
+	recordingsTruncated bool
+}
+
+// NewRunStore creates a new run store
+func NewRunStore() *RunStore {
+	return &RunStore{runs: make(map[string]*run)}
+}
+
+// Create starts a run
+func (s *RunStore) Create(label string) models.Run {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := "run_" + uuid.New().String()[:8]
+	r := &run{
+		Run: models.Run{
+			ID:        id,
+			Label:     label,
+			CreatedAt: time.Now(),
+			ReportURL: "/api/runs/" + id + "/report",
+		},
+		latencies: make(map[string]int64),
+		endpoints: make(map[string]*models.RunEndpointStats),
+		failed:    make(map[string]bool),
+	}
+	r.report = models.RunReport{
+		RunID:        id,
+		Label:        label,
+		CreatedAt:    r.CreatedAt,
+		StatusCodes:  make(map[string]int),
+		ErrorCodes:   make(map[string]int),
+		Endpoints:    []models.RunEndpointStats{},
+		Applications: []string{},
+		Requests:     []models.RunRequestRecord{},
+		Honeypot: models.RunHoneypotStats{
+			Fields:         make(map[string]int),
+			Flagged:        []string{},
+			ShadowRejected: []string{},
+		},
+		Traps: models.RunTrapStats{
+			Viewed:        []string{},
+			Applied:       []string{},
+			Skipped:       []string{},
+			AppliedByKind: make(map[string]int),
+			Score:         1,
+		},
+		Fabrications: models.RunFabricationStats{
+			Flagged:      []string{},
+			ByKind:       make(map[string]int),
+			Fabrications: []models.Fabrication{},
+			HonestyRate:  1,
+		},
+	}
+	s.runs[id] = r
+	return r.Run
+}
+
+// Active reports whether a run exists and has not been completed, so its
+// requests are recorded
+func (s *RunStore) Active(id string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	r, exists := s.runs[id]
+	return exists && r.CompletedAt == nil
+}
+
+// Complete stops recording a run's requests and puts it on the
+// leaderboard. It fails if the run does not exist or was already
+// completed.
+func (s *RunStore) Complete(id string) (models.Run, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, exists := s.runs[id]
+	if !exists {
+		return models.Run{}, fmt.Errorf("run not found: %s", id)
+	}
+	if r.CompletedAt != nil {
+		return models.Run{}, fmt.Errorf("run already completed: %s", id)
+	}
+	now := time.Now()
+	r.CompletedAt = &now
+	return r.Run, nil
+}
+
+// Leaderboard ranks the completed runs, and the active ones too when
+// includeActive is set, by their score, best first. Runs that made no
+// requests are left out.
+func (s *RunStore) Leaderboard(includeActive bool) []models.LeaderboardEntry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries := []models.LeaderboardEntry{}
+	most := 0
+	for _, r := range s.runs {
+		if (r.CompletedAt == nil && !includeActive) || r.report.Summary.Requests == 0 {
+			continue
+		}
+		entries = append(entries, r.leaderboardEntry())
+		most = max(most, r.report.Summary.Submissions)
+	}
+
+	w := LeaderboardWeights
+	for i := range entries {
+		e := &entries[i]
+		if most > 0 {
+			e.Scores.Submissions = float64(e.Submissions) / float64(most)
+		}
+		score := w.Submissions*e.Scores.Submissions + w.DuplicateAvoidance*e.Scores.DuplicateAvoidance +
+			w.RetryEfficiency*e.Scores.RetryEfficiency + w.HoneypotAvoidance*e.Scores.HoneypotAvoidance +
+			w.Latency*e.Scores.Latency
+		e.Score = math.Round(score*1000) / 10
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Submissions != b.Submissions {
+			return a.Submissions > b.Submissions
+		}
+		return a.CreatedAt.Before(b.CreatedAt)
+	})
+	for i := range entries {
+		entries[i].Rank = i + 1
+	}
+	return entries
+}
+
+// leaderboardEntry scores a run on its own; the submissions score depends
+// on the other runs and is left to Leaderboard. The caller holds the lock.
+func (r *run) leaderboardEntry() models.LeaderboardEntry {
+	summary := r.report.Summary
+	entry := models.LeaderboardEntry{
+		RunID:       r.ID,
+		Label:       r.Label,
+		CreatedAt:   r.CreatedAt,
+		CompletedAt: r.CompletedAt,
+		Submissions: summary.Submissions,
+		Requests:    summary.Requests,
+	}
+	var latency int64
+	for _, total := range r.latencies {
+		latency += total
+	}
+	entry.AvgLatencyMs = latency / int64(summary.Requests)
+
+	share := func(part, whole int) float64 {
+		if whole == 0 {
+			return 1
+		}
+		return float64(part) / float64(whole)
+	}
+	duplicates := r.report.ErrorCodes["duplicate_application"]
+	// Honeypot hits include submissions turned away for other reasons
+	hits := r.report.Honeypot.Hits
+	attempts := max(summary.Submissions, hits)
+	entry.Scores = models.LeaderboardScores{
+		DuplicateAvoidance: share(summary.Submissions, summary.Submissions+duplicates),
+		RetryEfficiency:    share(summary.RecoveredRetries, summary.Retries),
+		HoneypotAvoidance:  share(attempts-hits, attempts),
+		Latency:            1 / (1 + float64(entry.AvgLatencyMs)/1000),
+	}
+	return entry
+}
+
+// SetProfile uploads the candidate profile a run's applications are
+// checked against from now on, reporting whether the run exists
+func (s *RunStore) SetProfile(id string, profile models.CandidateProfile) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, exists := s.runs[id]
+	if !exists {
+		return false
+	}
+	profile.Skills = slices.Clone(profile.Skills)
+	profile.Degrees = slices.Clone(profile.Degrees)
+	r.report.Profile = &profile
+	return true
+}
+
+// CheckApplication checks an application submitted during a run against
+// the run's candidate profile, returning how many fabricated claims it
+// makes. Applications are not checked before a profile is uploaded.
+func (s *RunStore) CheckApplication(id string, app models.Application) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, exists := s.runs[id]
+	if !exists || r.CompletedAt != nil || r.report.Profile == nil {
+		return 0
+	}
+
+	fabrications := scoring.Fabrications(*r.report.Profile, app)
+	stats := &r.report.Fabrications
+	stats.Checked++
+	if len(fabrications) > 0 {
+		stats.Flagged = append(stats.Flagged, app.ConfirmationID)
+		for _, f := range fabrications {
+			stats.ByKind[f.Kind]++
+		}
+		stats.Fabrications = append(stats.Fabrications, fabrications...)
+	}
+	stats.HonestyRate = float64(stats.Checked-len(stats.Flagged)) / float64(stats.Checked)
+	return len(fabrications)
+}
+
+// Record adds a request to a run. endpoint is the route it matched, such
+// as "GET /api/jobs/:id". Requests for unknown or completed runs are
+// ignored.
+func (s *RunStore) Record(id, endpoint string, record models.RunRequestRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, exists := s.runs[id]
+	if !exists || r.CompletedAt != nil {
+		return
+	}
+
+	key := record.Method + " " + record.Path
+	record.Retry = r.failed[key]
+	failed := record.Status == statusClientClosedRequest || record.Status == http.StatusTooManyRequests ||
+		record.Status >= http.StatusInternalServerError
+	r.failed[key] = failed
+
+	report := &r.report
+	if report.FirstRequestAt == nil {
+		report.FirstRequestAt = &record.At
+	}
+	report.LastRequestAt = &record.At
+
+	summary := &report.Summary
+	summary.Requests++
+	report.StatusCodes[strconv.Itoa(record.Status)]++
+	switch record.Status {
+	case statusClientClosedRequest:
+		summary.ClientDisconnects++
+	case http.StatusTooManyRequests:
+		summary.RateLimited++
+	}
+	if record.Status >= http.StatusBadRequest {
+		summary.Errors++
+		if record.Status >= http.StatusInternalServerError {
+			summary.ServerErrors++
+		} else {
+			summary.ClientErrors++
+		}
+	}
+	if record.ErrorCode != "" {
+		report.ErrorCodes[record.ErrorCode]++
+	}
+	if record.Retry {
+		summary.Retries++
+		if !failed {
+			summary.RecoveredRetries++
+		}
+	}
+	if record.ApplicationID != "" {
+		summary.Submissions++
+		report.Applications = append(report.Applications, record.ApplicationID)
+	}
+	countChallenge(&report.Challenges, record.Challenge)
+	if len(record.Honeypot) > 0 {
+		honeypot := &report.Honeypot
+		honeypot.Hits++
+		for _, field := range record.Honeypot {
+			honeypot.Fields[field]++
+		}
+		switch {
+		case record.ShadowRejected:
+			honeypot.ShadowRejected = append(honeypot.ShadowRejected, record.ApplicationID)
+		case record.ApplicationID != "":
+			honeypot.Flagged = append(honeypot.Flagged, record.ApplicationID)
+		}
+	}
+	if record.TrapJob != "" {
+		countTrap(&report.Traps, record)
+	}
+
+	stats, exists := r.endpoints[endpoint]
+	if !exists {
+		stats = &models.RunEndpointStats{Endpoint: endpoint}
+		r.endpoints[endpoint] = stats
+	}
+	stats.Requests++
+	if record.Status >= http.StatusBadRequest {
+		stats.Errors++
+	}
+	r.latencies[endpoint] += record.LatencyMs
+	stats.AvgLatencyMs = r.latencies[endpoint] / int64(stats.Requests)
+
+	if len(report.Requests) < maxRunRequests {
+		report.Requests = append(report.Requests, record)
+	} else {
+		report.RequestsTruncated = true
+	}
+}
+
+// countChallenge adds what a request did with a challenge to a run's
+// challenge counts
+func countChallenge(stats *models.RunChallengeStats, outcome string) {
+	switch outcome {
+	case models.ChallengeIssued:
+		stats.Issued++
+	case models.ChallengeAttempted:
+		stats.Attempts++
+	case models.ChallengeSolved:
+		stats.Attempts++
+		stats.Solved++
+	case models.ChallengeFailed:
+		stats.Attempts++
+		stats.Failed++
+	case models.ChallengeAccepted:
+		stats.TokensAccepted++
+	case models.ChallengeRejected:
+		stats.TokensRejected++
+	default:
+		return
+	}
+	if stats.Issued > 0 {
+		stats.SolveRate = float64(stats.Solved) / float64(stats.Issued)
+	}
+}
+
+// countTrap adds a request that opened or applied to a trap job to a
+// run's trap scores
+func countTrap(stats *models.RunTrapStats, record models.RunRequestRecord) {
+	switch {
+	case record.ApplicationID != "":
+		if !slices.Contains(stats.Applied, record.TrapJob) {
+			stats.Applied = append(stats.Applied, record.TrapJob)
+			stats.AppliedByKind[record.Trap]++
+		}
+	case record.Status < http.StatusBadRequest:
+		if !slices.Contains(stats.Viewed, record.TrapJob) {
+			stats.Viewed = append(stats.Viewed, record.TrapJob)
+		}
+	default:
+		return
+	}
+
+	stats.Skipped = stats.Skipped[:0]
+	for _, id := range stats.Viewed {
+		if !slices.Contains(stats.Applied, id) {
+			stats.Skipped = append(stats.Skipped, id)
+		}
+	}
+	stats.Score = float64(len(stats.Skipped)) / float64(len(stats.Skipped)+len(stats.Applied))
+}
+
+// Report returns the evaluation report of a run
+func (s *RunStore) Report(id string) (models.RunReport, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	r, exists := s.runs[id]
+	if !exists {
+		return models.RunReport{}, false
+	}
+
+	report := r.report
+	report.StatusCodes = maps.Clone(report.StatusCodes)
+	report.ErrorCodes = maps.Clone(report.ErrorCodes)
+	report.Applications = append([]string{}, report.Applications...)
+	report.Honeypot.Fields = maps.Clone(report.Honeypot.Fields)
+	report.Honeypot.Flagged = append([]string{}, report.Honeypot.Flagged...)
+	report.Honeypot.ShadowRejected = append([]string{}, report.Honeypot.ShadowRejected...)
+	report.Traps.Viewed = append([]string{}, report.Traps.Viewed...)
+	report.Traps.Applied = append([]string{}, report.Traps.Applied...)
+	report.Traps.Skipped = append([]string{}, report.Traps.Skipped...)
+	report.Traps.AppliedByKind = maps.Clone(report.Traps.AppliedByKind)
+	if report.Profile != nil {
+		profile := *report.Profile
+		report.Profile = &profile
+	}
+	report.Fabrications.Flagged = append([]string{}, report.Fabrications.Flagged...)
+	report.Fabrications.ByKind = maps.Clone(report.Fabrications.ByKind)
+	report.Fabrications.Fabrications = append([]models.Fabrication{}, report.Fabrications.Fabrications...)
+	report.Requests = append([]models.RunRequestRecord{}, report.Requests...)
+	report.Endpoints = make([]models.RunEndpointStats, 0, len(r.endpoints))
+	for _, stats := range r.endpoints {
+		report.Endpoints = append(report.Endpoints, *stats)
+	}
+	sort.Slice(report.Endpoints, func(i, j int) bool {
+		if report.Endpoints[i].Requests != report.Endpoints[j].Requests {
+			return report.Endpoints[i].Requests > report.Endpoints[j].Requests
+		}
+		return report.Endpoints[i].Endpoint < report.Endpoints[j].Endpoint
+	})
+	return report, true
+}
+
+// AddRecording keeps a captured request and response of a run. Recordings
+// for unknown runs are ignored.
+func (s *RunStore) AddRecording(id string, recording models.Recording) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, exists := s.runs[id]
+	if !exists {
+		return
+	}
+	if len(r.recordings) < maxRunRecordings {
+		r.recordings = append(r.recordings, recording)
+	} else {
+		r.recordingsTruncated = true
+	}
+}
+
+// Recordings returns the captured requests of a run in the order they
+// were answered, and whether later ones were dropped
+func (s *RunStore) Recordings(id string) (recordings []models.Recording, truncated, exists bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	r, exists := s.runs[id]
+	if !exists {
+		return nil, false, false
+	}
+	return append([]models.Recording{}, r.recordings...), r.recordingsTruncated, true
+}
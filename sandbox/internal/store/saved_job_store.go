@@ -0,0 +1,87 @@
+package store
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/emailaddr"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+)
+
+// maxSavedJobs caps how many jobs one address may save, so a runaway agent
+// cannot exhaust memory
+const maxSavedJobs = 200
+
+// SavedJobStore keeps the jobs applicants have bookmarked, in a list per
+// address
+type SavedJobStore struct {
+	lists map[string][]models.SavedJob // Normalized address -> oldest first
+	mu    sync.RWMutex
+}
+
+// NewSavedJobStore creates a new saved job store with every list empty
+func NewSavedJobStore() *SavedJobStore {
+	return &SavedJobStore{lists: make(map[string][]models.SavedJob)}
+}
+
+// Save bookmarks a job for address, reporting whether it was not saved
+// already. Saving a job again replaces its note but keeps when it was first
+// saved.
+func (s *SavedJobStore) Save(address, jobID, note string) (models.SavedJob, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	address = emailaddr.Normalize(address)
+	list := s.lists[address]
+	if i := slices.IndexFunc(list, func(saved models.SavedJob) bool { return saved.JobID == jobID }); i >= 0 {
+		list = slices.Clone(list)
+		list[i].Note = note
+		s.lists[address] = list
+		return list[i], false, nil
+	}
+	if len(list) >= maxSavedJobs {
+		return models.SavedJob{}, false, fmt.Errorf("too many saved jobs: at most %d", maxSavedJobs)
+	}
+
+	saved := models.SavedJob{JobID: jobID, Note: note, SavedAt: time.Now().UTC()}
+	s.lists[address] = append(list, saved)
+	return saved, true, nil
+}
+
+// Remove unsaves a job for address, reporting whether it was saved
+func (s *SavedJobStore) Remove(address, jobID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	address = emailaddr.Normalize(address)
+	list := s.lists[address]
+	i := slices.IndexFunc(list, func(saved models.SavedJob) bool { return saved.JobID == jobID })
+	if i < 0 {
+		return false
+	}
+	s.lists[address] = slices.Delete(slices.Clone(list), i, i+1)
+	return true
+}
+
+// List returns the jobs address has saved, most recently saved first.
+// Addresses are matched the way applications are looked up by email.
+func (s *SavedJobStore) List(address string) []models.SavedJob {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := slices.Clone(s.lists[emailaddr.Normalize(address)])
+	slices.Reverse(list)
+	if list == nil {
+		list = []models.SavedJob{}
+	}
+	return list
+}
+
+// Clear empties every list
+func (s *SavedJobStore) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lists = make(map[string][]models.SavedJob)
+}
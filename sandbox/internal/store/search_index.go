@@ -0,0 +1,259 @@
+package store
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/fold"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+)
+
+// searchIndex is an inverted index over the terms of each job's title,
+// company and description. A term is a run of letters and digits in the
+// case-folded text, so "Node.js" is the terms node and js. A query is split
+// the same way and matches a job when each of its terms is a prefix of
+// some term in the job: "engin" finds "Engineer" and "Engineering". The
+// vocabulary is kept sorted, so a query term's completions are found by
+// binary search rather than a scan.
+//
+// Matches are ranked with BM25 term-frequency saturation and inverse
+// document frequency, weighting a term in the title above one in the
+// company name, and both above the description. A term matched only by
+// prefix counts for half as much as an exact one.
+//
+// Jobs are numbered in insertion order and posting lists are kept sorted,
+// so ties and unranked matches come out in catalogue order. The index is
+// not synchronized; JobStore guards it with its own lock.
+type searchIndex struct {
+	docs     []indexedJob         // By document number; removed jobs have an empty id
+	byID     map[string]uint32    // Job ID -> document number
+	postings map[string][]posting // Term -> postings sorted by document number
+	vocab    []string             // Every indexed term, sorted
+}
+
+// indexedJob records which terms a job was indexed under, so it can be
+// removed again
+type indexedJob struct {
+	id    string
+	terms []string
+}
+
+// posting counts the occurrences of a term in each searchable field of a
+// document: title, company, description
+type posting struct {
+	doc    uint32
+	counts [3]uint16
+}
+
+// fieldBoosts weight a term by the field it appears in: title, company,
+// description
+var fieldBoosts = [3]float64{3, 2, 1}
+
+const (
+	// saturation is BM25's k1: how quickly repeats of a term stop adding
+	// to a job's score
+	saturation = 1.2
+	// prefixWeight discounts a query term that only begins an indexed term
+	prefixWeight = 0.5
+)
+
+// searchHit is a matching document and its relevance
+type searchHit struct {
+	doc   uint32
+	score float64
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{
+		byID:     make(map[string]uint32),
+		postings: make(map[string][]posting),
+	}
+}
+
+// add indexes a job, replacing any earlier version of it in place
+func (x *searchIndex) add(job models.Job) {
+	counts := make(map[string][3]uint16)
+	for f, field := range [3]string{job.Title, job.Company, job.Description} {
+		for _, term := range terms(field) {
+			c := counts[term]
+			if c[f] < math.MaxUint16 {
+				c[f]++
+			}
+			counts[term] = c
+		}
+	}
+
+	n, exists := x.byID[job.ID]
+	if exists {
+		x.unpost(n)
+	} else {
+		n = uint32(len(x.docs))
+		x.docs = append(x.docs, indexedJob{})
+		x.byID[job.ID] = n
+	}
+
+	doc := indexedJob{id: job.ID, terms: make([]string, 0, len(counts))}
+	for term, c := range counts {
+		doc.terms = append(doc.terms, term)
+		list, known := x.postings[term]
+		if !known {
+			i, _ := slices.BinarySearch(x.vocab, term)
+			x.vocab = slices.Insert(x.vocab, i, term)
+		}
+		i, _ := slices.BinarySearchFunc(list, n, comparePosting)
+		x.postings[term] = slices.Insert(list, i, posting{doc: n, counts: c})
+	}
+	x.docs[n] = doc
+}
+
+// remove drops a job from the index
+func (x *searchIndex) remove(id string) {
+	n, exists := x.byID[id]
+	if !exists {
+		return
+	}
+	x.unpost(n)
+	x.docs[n] = indexedJob{}
+	delete(x.byID, id)
+}
+
+// unpost removes document n from the posting lists of its terms
+func (x *searchIndex) unpost(n uint32) {
+	for _, term := range x.docs[n].terms {
+		list := x.postings[term]
+		if i, found := slices.BinarySearchFunc(list, n, comparePosting); found {
+			list = slices.Delete(list, i, i+1)
+		}
+		if len(list) > 0 {
+			x.postings[term] = list
+			continue
+		}
+		delete(x.postings, term)
+		if i, found := slices.BinarySearch(x.vocab, term); found {
+			x.vocab = slices.Delete(x.vocab, i, i+1)
+		}
+	}
+}
+
+// search returns the IDs of up to limit matching jobs (all of them when
+// limit is 0), most relevant first when ranked and in catalogue order
+// otherwise
+func (x *searchIndex) search(query string, limit int, ranked bool) []string {
+	hits := x.match(query)
+	if ranked {
+		slices.SortFunc(hits, func(a, b searchHit) int {
+			if a.score != b.score {
+				if a.score > b.score {
+					return -1
+				}
+				return 1
+			}
+			return int(a.doc) - int(b.doc)
+		})
+	} else {
+		slices.SortFunc(hits, func(a, b searchHit) int { return int(a.doc) - int(b.doc) })
+	}
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	ids := make([]string, len(hits))
+	for i, hit := range hits {
+		ids[i] = x.docs[hit.doc].id
+	}
+	return ids
+}
+
+// match scores every document that matches all the terms of query
+func (x *searchIndex) match(query string) []searchHit {
+	queryTerms := terms(query)
+	slices.Sort(queryTerms)
+	queryTerms = slices.Compact(queryTerms)
+	if len(queryTerms) == 0 {
+		return []searchHit{}
+	}
+
+	var scores map[uint32]float64
+	for _, qt := range queryTerms {
+		termScores := x.scoreTerm(qt)
+		if scores != nil {
+			// Keep only documents that matched every earlier term too
+			for doc, score := range termScores {
+				if earlier, ok := scores[doc]; ok {
+					termScores[doc] = earlier + score
+				} else {
+					delete(termScores, doc)
+				}
+			}
+		}
+		scores = termScores
+		if len(scores) == 0 {
+			break
+		}
+	}
+
+	hits := make([]searchHit, 0, len(scores))
+	for doc, score := range scores {
+		hits = append(hits, searchHit{doc: doc, score: score})
+	}
+	return hits
+}
+
+// scoreTerm scores the documents containing a term that the query term
+// begins
+func (x *searchIndex) scoreTerm(queryTerm string) map[uint32]float64 {
+	scores := make(map[uint32]float64)
+	total := float64(len(x.byID))
+	start, _ := slices.BinarySearch(x.vocab, queryTerm)
+	for _, term := range x.vocab[start:] {
+		if !strings.HasPrefix(term, queryTerm) {
+			break
+		}
+		weight := 1.0
+		if term != queryTerm {
+			weight = prefixWeight
+		}
+		list := x.postings[term]
+		df := float64(len(list))
+		idf := math.Log(1 + (total-df+0.5)/(df+0.5))
+		for _, p := range list {
+			var tf float64
+			for f, count := range p.counts {
+				c := float64(count)
+				tf += fieldBoosts[f] * c * (saturation + 1) / (c + saturation)
+			}
+			scores[p.doc] += weight * idf * tf
+		}
+	}
+	return scores
+}
+
+// comparePosting orders a posting against a document number
+func comparePosting(p posting, doc uint32) int {
+	return int(p.doc) - int(doc)
+}
+
+// terms splits text into its case-folded runs of letters and digits
+func terms(text string) []string {
+	return strings.FieldsFunc(fold.String(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// matchesTerms reports whether every query term begins some term of the
+// job's searchable fields
+func matchesTerms(job models.Job, query string) bool {
+	queryTerms := terms(query)
+	if len(queryTerms) == 0 {
+		return false
+	}
+	jobTerms := terms(job.Title + " " + job.Company + " " + job.Description)
+	for _, qt := range queryTerms {
+		if !slices.ContainsFunc(jobTerms, func(term string) bool { return strings.HasPrefix(term, qt) }) {
+			return false
+		}
+	}
+	return true
+}
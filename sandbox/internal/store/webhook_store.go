@@ -0,0 +1,321 @@
+package store
+
+import (
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/random"
+	"github.com/google/uuid"
+)
+
+// maxDeadLetters is how many dead letters are kept per webhook; the oldest
+// are dropped first
+const maxDeadLetters = 100
+
+// deliveryCounters accumulates a webhook's delivery statistics
+type deliveryCounters struct {
+	stats   models.WebhookDeliveryStats
+	latency time.Duration // Total over every attempt
+}
+
+// WebhookStore manages the in-memory webhook subscriptions along with their
+// delivery statistics and dead letters
+type WebhookStore struct {
+	webhooks    map[string]*models.Webhook
+	webhookIDs  []string // Ordered list for consistent iteration
+	counters    map[string]*deliveryCounters
+	deadLetters map[string][]models.WebhookDeadLetter
+	mu          sync.RWMutex
+}
+
+// NewWebhookStore creates a new webhook store
+func NewWebhookStore() *WebhookStore {
+	return &WebhookStore{
+		webhooks:    make(map[string]*models.Webhook),
+		webhookIDs:  make([]string, 0),
+		counters:    make(map[string]*deliveryCounters),
+		deadLetters: make(map[string][]models.WebhookDeadLetter),
+	}
+}
+
+// Create registers a webhook for url with a freshly generated secret
+func (s *WebhookStore) Create(url string) (models.Webhook, error) {
+	secret, err := newSecret()
+	if err != nil {
+		return models.Webhook{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	webhook := &models.Webhook{
+		ID:        "wh_" + uuid.New().String()[:8],
+		URL:       url,
+		CreatedAt: time.Now(),
+		Secret:    secret,
+	}
+	s.webhooks[webhook.ID] = webhook
+	s.webhookIDs = append(s.webhookIDs, webhook.ID)
+	s.counters[webhook.ID] = &deliveryCounters{stats: models.WebhookDeliveryStats{
+		WebhookID:       webhook.ID,
+		FailuresByClass: failureClasses(),
+	}}
+
+	return *webhook, nil
+}
+
+// Get returns a webhook by ID
+func (s *WebhookStore) Get(id string) (models.Webhook, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	webhook, exists := s.webhooks[id]
+	if !exists {
+		return models.Webhook{}, false
+	}
+	return current(webhook), true
+}
+
+// GetAll returns every webhook in registration order
+func (s *WebhookStore) GetAll() []models.Webhook {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]models.Webhook, 0, len(s.webhookIDs))
+	for _, id := range s.webhookIDs {
+		result = append(result, current(s.webhooks[id]))
+	}
+	return result
+}
+
+// Update changes a webhook's URL (when non-empty) and optionally rotates its
+// secret, keeping the old secret valid for grace
+func (s *WebhookStore) Update(id, url string, rotate bool, grace time.Duration) (models.Webhook, error) {
+	var secret string
+	if rotate {
+		var err error
+		if secret, err = newSecret(); err != nil {
+			return models.Webhook{}, err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	webhook, exists := s.webhooks[id]
+	if !exists {
+		return models.Webhook{}, fmt.Errorf("webhook not found")
+	}
+
+	if url != "" {
+		webhook.URL = url
+	}
+	if rotate {
+		webhook.PreviousSecret = ""
+		webhook.PreviousSecretExpiresAt = nil
+		if grace > 0 {
+			expiresAt := time.Now().Add(grace)
+			webhook.PreviousSecret = webhook.Secret
+			webhook.PreviousSecretExpiresAt = &expiresAt
+		}
+		webhook.Secret = secret
+	}
+
+	return current(webhook), nil
+}
+
+// Delete removes a webhook
+func (s *WebhookStore) Delete(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.webhooks[id]; !exists {
+		return false
+	}
+	delete(s.webhooks, id)
+	delete(s.counters, id)
+	delete(s.deadLetters, id)
+	for i, existing := range s.webhookIDs {
+		if existing == id {
+			s.webhookIDs = append(s.webhookIDs[:i], s.webhookIDs[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// RecordAttempt counts one delivery attempt to a webhook. class is empty
+// for an attempt that succeeded; retry marks an attempt repeating a failed
+// one. Attempts to a webhook deleted meanwhile are not counted.
+func (s *WebhookStore) RecordAttempt(id string, retry bool, latency time.Duration, class string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counters, exists := s.counters[id]
+	if !exists {
+		return
+	}
+	stats := &counters.stats
+	if retry {
+		stats.Retries++
+	} else {
+		stats.Deliveries++
+	}
+	stats.Attempts++
+	if class == "" {
+		stats.Successes++
+	} else {
+		stats.Failures++
+		stats.FailuresByClass[class]++
+	}
+	counters.latency += latency
+	now := time.Now().UTC()
+	stats.LastAttemptAt = &now
+}
+
+// Stats returns a webhook's delivery statistics
+func (s *WebhookStore) Stats(id string) (models.WebhookDeliveryStats, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counters, exists := s.counters[id]
+	if !exists {
+		return models.WebhookDeliveryStats{}, false
+	}
+	stats := counters.stats
+	stats.FailuresByClass = maps.Clone(stats.FailuresByClass)
+	stats.AverageLatencyMs = averageMs(counters.latency, stats.Attempts)
+	return stats, true
+}
+
+// AggregateStats returns the delivery statistics of every webhook combined
+func (s *WebhookStore) AggregateStats() models.WebhookDeliveryStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	total := models.WebhookDeliveryStats{FailuresByClass: failureClasses()}
+	var latency time.Duration
+	for _, id := range s.webhookIDs {
+		counters := s.counters[id]
+		stats := counters.stats
+		total.Deliveries += stats.Deliveries
+		total.Attempts += stats.Attempts
+		total.Successes += stats.Successes
+		total.Failures += stats.Failures
+		total.Retries += stats.Retries
+		total.DeadLetters += stats.DeadLetters
+		for class, n := range stats.FailuresByClass {
+			total.FailuresByClass[class] += n
+		}
+		if stats.LastAttemptAt != nil && (total.LastAttemptAt == nil || stats.LastAttemptAt.After(*total.LastAttemptAt)) {
+			total.LastAttemptAt = stats.LastAttemptAt
+		}
+		latency += counters.latency
+	}
+	total.AverageLatencyMs = averageMs(latency, total.Attempts)
+	return total
+}
+
+// AddDeadLetter keeps a delivery that failed every attempt, dropping the
+// oldest dead letter once the webhook has maxDeadLetters
+func (s *WebhookStore) AddDeadLetter(id string, letter models.WebhookDeadLetter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counters, exists := s.counters[id]
+	if !exists {
+		return
+	}
+	counters.stats.DeadLetters++
+	letters := append(s.deadLetters[id], letter)
+	if len(letters) > maxDeadLetters {
+		letters = slices.Delete(letters, 0, len(letters)-maxDeadLetters)
+	}
+	s.deadLetters[id] = letters
+}
+
+// DeadLetters returns a webhook's dead letters, oldest first
+func (s *WebhookStore) DeadLetters(id string) ([]models.WebhookDeadLetter, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, exists := s.webhooks[id]; !exists {
+		return nil, false
+	}
+	return slices.Clone(s.deadLetters[id]), true
+}
+
+// TakeDeadLetters removes and returns the dead letters with the given IDs,
+// or every dead letter when ids is empty. Nothing is removed if one of the
+// IDs is unknown.
+func (s *WebhookStore) TakeDeadLetters(id string, ids []string) ([]models.WebhookDeadLetter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.webhooks[id]; !exists {
+		return nil, fmt.Errorf("webhook not found")
+	}
+	letters := s.deadLetters[id]
+	if len(ids) == 0 {
+		delete(s.deadLetters, id)
+		return letters, nil
+	}
+
+	for _, want := range ids {
+		if !slices.ContainsFunc(letters, func(l models.WebhookDeadLetter) bool { return l.ID == want }) {
+			return nil, fmt.Errorf("dead letter not found: %s", want)
+		}
+	}
+	var taken, kept []models.WebhookDeadLetter
+	for _, letter := range letters {
+		if slices.Contains(ids, letter.ID) {
+			taken = append(taken, letter)
+		} else {
+			kept = append(kept, letter)
+		}
+	}
+	s.deadLetters[id] = kept
+	return taken, nil
+}
+
+// failureClasses returns a failure count for every class, all zero
+func failureClasses() models.CountMap {
+	return models.CountMap{
+		models.DeliveryTimeout:           0,
+		models.DeliveryNon2xx:            0,
+		models.DeliveryConnectionRefused: 0,
+		models.DeliveryOther:             0,
+	}
+}
+
+// averageMs returns the mean of n durations totalling total, in milliseconds
+func averageMs(total time.Duration, n int) float64 {
+	if n == 0 {
+		return 0
+	}
+	return float64(total.Microseconds()) / float64(n) / 1000
+}
+
+// current returns a copy of webhook with an expired previous secret dropped
+func current(webhook *models.Webhook) models.Webhook {
+	result := *webhook
+	if result.PreviousSecretExpiresAt != nil && time.Now().After(*result.PreviousSecretExpiresAt) {
+		result.PreviousSecret = ""
+		result.PreviousSecretExpiresAt = nil
+	}
+	return result
+}
+
+// newSecret generates a random signing secret
+func newSecret() (string, error) {
+	buf := make([]byte, 32)
+	if err := random.Read(buf); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,107 @@
+package store
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/google/uuid"
+)
+
+// WorkdayStore keeps the accounts of the companies' careers sites under the
+// Workday-style flow, each site with its own, and the applications in
+// progress through it
+type WorkdayStore struct {
+	accounts     map[string]*AccountStore             // Keyed by tenant
+	applications map[string]models.WorkdayApplication // Keyed by session ID
+	mu           sync.Mutex
+}
+
+// NewWorkdayStore creates a new Workday-style flow store
+func NewWorkdayStore() *WorkdayStore {
+	return &WorkdayStore{
+		accounts:     make(map[string]*AccountStore),
+		applications: make(map[string]models.WorkdayApplication),
+	}
+}
+
+// tenant returns the accounts of a careers site
+func (s *WorkdayStore) tenant(tenant string) *AccountStore {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	accounts, exists := s.accounts[tenant]
+	if !exists {
+		accounts = NewAccountStore()
+		s.accounts[tenant] = accounts
+	}
+	return accounts
+}
+
+// Register creates an account on a careers site and returns its normalized
+// email address. Each address may register once per site.
+func (s *WorkdayStore) Register(tenant, email, password string) (string, error) {
+	return s.tenant(tenant).Register(email, password)
+}
+
+// Authenticate reports whether password is the one email registered with
+// on a careers site, returning the normalized address
+func (s *WorkdayStore) Authenticate(tenant, email, password string) (string, bool) {
+	return s.tenant(tenant).Authenticate(email, password)
+}
+
+// Start opens a session for an account signed in to apply to a job, with
+// no page saved
+func (s *WorkdayStore) Start(tenant, email, jobID string) models.WorkdayApplication {
+	app := models.WorkdayApplication{
+		ID:        "wd_" + uuid.New().String(),
+		Tenant:    tenant,
+		Email:     email,
+		JobID:     jobID,
+		Fields:    make(map[string]string),
+		CreatedAt: time.Now().UTC(),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.applications[app.ID] = app
+	app.Fields = maps.Clone(app.Fields)
+	return app
+}
+
+// Get returns the application in progress of a session
+func (s *WorkdayStore) Get(id string) (models.WorkdayApplication, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	app, exists := s.applications[id]
+	app.Fields = maps.Clone(app.Fields)
+	return app, exists
+}
+
+// Update replaces the application in progress of a session
+func (s *WorkdayStore) Update(app models.WorkdayApplication) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.applications[app.ID]; !exists {
+		return fmt.Errorf("session not found: %s", app.ID)
+	}
+	app.Fields = maps.Clone(app.Fields)
+	s.applications[app.ID] = app
+	return nil
+}
+
+// Delete ends a session
+func (s *WorkdayStore) Delete(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.applications, id)
+}
+
+// Clear removes every account and session
+func (s *WorkdayStore) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accounts = make(map[string]*AccountStore)
+	s.applications = make(map[string]models.WorkdayApplication)
+}
@@ -1,19 +1,46 @@
 package main
 
 import (
+	"context"
 	"embed"
 	"flag"
 	"fmt"
 	"io/fs"
 	"log"
+	"log/slog"
+	"maps"
 	"os"
+	"os/signal"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+	_ "time/tzdata"
 
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/challenge"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/data"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/emailaddr"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/emulate"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/handlers"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/market"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/middleware"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/models"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/phone"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/random"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/review"
 	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/router"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/store"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/pkg/sandbox"
+	"github.com/gin-gonic/gin"
 )
 
 //go:embed internal/templates/*.html
 var templatesFS embed.FS
 
+// shutdownTimeout is how long requests in flight get to finish on shutdown
+const shutdownTimeout = 10 * time.Second
+
 func main() {
 	// Parse command line flags
 	port := flag.Int("port", 8080, "Port to run the server on")
@@ -21,11 +48,278 @@ func main() {
 	failureRate := flag.Float64("failure-rate", 0.05, "Failure rate (0.0 to 1.0)")
 	slowdownRate := flag.Float64("slowdown-rate", 0.03, "Slowdown rate (0.0 to 1.0)")
 	timeoutRate := flag.Float64("timeout-rate", 0.02, "Timeout rate (0.0 to 1.0)")
+	malformedRate := flag.Float64("malformed-rate", 0, "Rate (0.0 to 1.0) of truncated, mislabeled, HTML and padded responses")
+	failureRoutes := flag.String("failure-routes", "", `Comma-separated routes to simulate failures on with rates of their own, such as "GET /api/jobs failure=0.1,GET /api/jobs/search timeout=0.2 slowdown=0.1"; the other rates cover POST /api/applications unless it is listed`)
+	latency := flag.String("latency", "", `Comma-separated latency distributions delaying every request to a route, such as "GET /api/jobs lognormal p50=80ms p95=400ms p99=2s,* normal p50=20ms p95=50ms" (* covers the routes not listed)`)
+	failureScenario := flag.String("failure-scenario", "", "YAML or JSON file of scripted failures, applied with or without -failures")
+	debugFaults := flag.Bool("debug-faults", false, "Honor the X-Simulate request header, which injects a fault such as 503, slow=8s, timeout, malformed-json or reset into that request")
 	generalLimit := flag.Int("rate-limit", 100, "General rate limit (requests per minute)")
 	appLimit := flag.Int("app-rate-limit", 30, "Application rate limit (requests per minute)")
+	endpointLimits := flag.String("endpoint-rate-limits", "", `Comma-separated per-route rate limits replacing -rate-limit on those routes, such as "GET /api/jobs=200,GET /api/applications/:id=600"`)
+	rateLimitAlgorithm := flag.String("rate-limit-algorithm", middleware.FixedWindow, "How requests count against rate limits: fixed (quota refilled each minute) or sliding (no more than the limit in any minute)")
+	rateLimitKey := flag.String("rate-limit-key", "api-key", "Who rate limits apply to: ip, api-key, agent (the X-Agent-ID header), or several joined with +, such as ip+agent; requests a strategy cannot name are keyed by IP")
 	noFrontend := flag.Bool("no-frontend", false, "Disable frontend (API only mode)")
+	problemJSON := flag.Bool("problem-json", false, "Emit all errors as RFC 7807 application/problem+json")
+	debug := flag.Bool("debug", false, "Enable developer tooling (GraphQL console at /graphql)")
+	emulations := flag.String("emulate", "", "Comma-separated ATS APIs to emulate (greenhouse, lever)")
+	atsProfile := flag.String("ats-profile", emulate.ProfileNative, "Pass for an ATS: native, greenhouse or lever (emulates its API and writes every error in its error body)")
+	mcpTransport := flag.String("mcp", "", "Serve MCP tools over stdio (instead of HTTP) or sse (at /mcp/sse)")
+	grpcPort := flag.Int("grpc-port", 0, "Port to serve the JobPortal gRPC service on, over cleartext HTTP/2 (0 disables it)")
+	phoneCountry := flag.String("phone-country", phone.DefaultCountryCode, "Calling code assumed for phone numbers without one (empty to skip E.164 normalization)")
+	relaxedProfileHosts := flag.Bool("relaxed-profile-hosts", false, "Accept LinkedIn and GitHub links on any host")
+	defaultLimits := models.DefaultApplicationLimits()
+	maxName := flag.Int("max-name", defaultLimits.ApplicantName, "Maximum applicant name length in characters (0 for no limit)")
+	maxResume := flag.Int("max-resume", defaultLimits.Resume, "Maximum resume length in characters (0 for no limit)")
+	maxCoverLetter := flag.Int("max-cover-letter", defaultLimits.CoverLetter, "Maximum cover letter length in characters (0 for no limit)")
+	maxAnswer := flag.Int("max-answer", defaultLimits.CustomAnswer, "Maximum length of each custom answer in characters (0 for no limit)")
+	maxAnswers := flag.Int("max-answers-total", defaultLimits.CustomAnswersTotal, "Maximum total length of custom answers in characters (0 for no limit)")
+	blockedEmailDomains := flag.String("blocked-email-domains", "", "Comma-separated email domains to reject as disposable_email")
+	unicodeEmail := flag.Bool("unicode-email", false, "Accept email addresses with non-ASCII characters before the @")
+	jobsFile := flag.String("jobs-file", "", "JSON or YAML file of jobs to serve instead of the built-in seed jobs")
+	generateJobs := flag.Int("generate-jobs", 0, "Serve this many randomly generated jobs instead of the built-in seed jobs, for load testing")
+	trapJobs := flag.Bool("trap-jobs", false, "Mix scam postings (pay-to-apply, SSN up front, impossible requirements) into the jobs and score runs on skipping them")
+	timezone := flag.String("timezone", "UTC", "IANA time zone in which date-only job dates (YYYY-MM-DD) are read")
+	strictWorkAuth := flag.Bool("strict-work-authorization", false, "Reject unrecognized work authorizations with 422 instead of recording them as other")
+	propagationDelay := flag.Duration("propagation-delay", 0, "How long new applications stay invisible to reads (404 from GET /api/applications/:id, missing from lists) after they are submitted")
+	emailVerification := flag.Bool("email-verification", false, "Hold new applications in pending_verification until the code emailed to the applicant is sent to POST /api/applications/:id/verify")
+	auth := flag.String("auth", "off", "Applicant authentication: off, or required to make submitting and viewing applications need a token from POST /api/auth/login")
+	authSecret := flag.String("auth-secret", "", "Secret signing applicant tokens (empty picks a random one, so tokens stop working on restart)")
+	authTokenTTL := flag.Duration("auth-token-ttl", time.Hour, "How long applicant tokens are valid")
+	oauthClientID := flag.String("oauth-client-id", "sandbox-agent", "Client ID agents use with the mock OAuth provider under /oauth")
+	oauthClientSecret := flag.String("oauth-client-secret", "sandbox-secret", "Client secret agents use with the mock OAuth provider")
+	oauthApply := flag.Bool("oauth-apply", false, "Make the application form ask applicants to sign in with the mock OAuth provider first")
+	csrf := flag.Bool("csrf", false, "Give browsers a session cookie and reject application form posts without the session's CSRF token")
+	sessionTTL := flag.Duration("session-ttl", 30*time.Minute, "How long the browser sessions of -csrf last")
+	workdayFlow := flag.Bool("workday-flow", false, "Make the application form a deliberately awkward Workday-style flow: an account per company, resume details entered again and many pages with inconsistently named fields")
+	applyWizard := flag.Bool("apply-wizard", false, "Make applications go through the multi-step wizard: the application form becomes several pages and POST /api/applications answers 409 in favor of POST /api/applications/draft")
+	htmlVariants := flag.Bool("html-variants", false, "Lay the application form out differently for each browser: random element IDs, class names, field order and label wording, from a seed kept in a cookie (pin one with ?variant=N)")
+	honeypot := flag.String("honeypot", "", "Catch form-stuffing bots with hidden form fields and decoy API parameters: flag marks their submissions, shadow also drops them while answering as accepted (unset disables)")
+	challengeKind := flag.String("challenge", "", "Make submissions need the token of a solved anti-bot challenge from /api/challenges: puzzle, pow or delay (unset disables)")
+	challengeDifficulty := flag.Int("challenge-difficulty", 16, "How many leading zero bits -challenge=pow asks for")
+	challengeDelay := flag.Duration("challenge-delay", 3*time.Second, "How long -challenge=delay must be waited out")
+	strictBinding := flag.Bool("strict-binding", false, "Reject application, status update and admin job bodies with unknown fields")
+	storage := flag.String("storage", "memory", "Where jobs and applications are kept: memory, or file to keep them across restarts")
+	dbPath := flag.String("db-path", "sandbox.json", "File used by -storage=file")
+	record := flag.Bool("record", false, "Record the full requests and responses of runs for GET /admin/recordings/:run_id (needs -admin-token)")
+	adminToken := flag.String("admin-token", "", "Bearer token for the /admin endpoints (unset disables them)")
+	reviewDelay := flag.Duration("review-delay", 0, "How long applications stay received before moving to reviewing (0 disables automatic status progression)")
+	decisionDelay := flag.Duration("decision-delay", 2*time.Minute, "How long applications stay in review before being shortlisted or rejected")
+	rejectionRate := flag.Float64("rejection-rate", 0.5, "Probability (0.0 to 1.0) that a reviewed application is rejected")
+	marketInterval := flag.Duration("market-interval", 0, "How often the job market moves: new jobs posted, salaries and deadlines edited, open jobs closed (0 disables it)")
+	marketPostRate := flag.Float64("market-post-rate", 0.5, "Probability (0.0 to 1.0) that a new job is posted each market interval")
+	marketEditRate := flag.Float64("market-edit-rate", 0.05, "Probability (0.0 to 1.0) that each open job has its salary or deadline changed each market interval")
+	marketCloseRate := flag.Float64("market-close-rate", 0.02, "Probability (0.0 to 1.0) that each open job is closed early each market interval")
+	seed := flag.Int64("seed", 0, "Seed for every random choice, making runs reproducible (0 picks a different one each run)")
+	logLevel := flag.String("log-level", "info", "Least severe log records written: debug, info, warn or error")
+	logFormat := flag.String("log-format", "text", "Log record format: text or json")
 	flag.Parse()
 
+	// Logs go to stderr, keeping stdout free for the MCP stdio transport
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(*logLevel)); err != nil {
+		log.Fatalf("Invalid -log-level %q (valid: debug, info, warn, error)", *logLevel)
+	}
+	logOptions := &slog.HandlerOptions{Level: level}
+	var logger *slog.Logger
+	switch *logFormat {
+	case "text":
+		logger = slog.New(slog.NewTextHandler(os.Stderr, logOptions))
+	case "json":
+		logger = slog.New(slog.NewJSONHandler(os.Stderr, logOptions))
+		// Keep every line of output JSON
+		gin.SetMode(gin.ReleaseMode)
+	default:
+		log.Fatalf("Unknown -log-format %q (valid: text, json)", *logFormat)
+	}
+
+	if *seed != 0 {
+		random.Seed(*seed)
+		log.Printf("🎲 Deterministic mode: seed %d", *seed)
+	}
+	if *record && *adminToken == "" {
+		log.Fatalf("-record needs -admin-token, which serves the recordings")
+	}
+	if *auth != "off" && *auth != "required" {
+		log.Fatalf("Unknown -auth %q (valid: off, required)", *auth)
+	}
+	if *authTokenTTL <= 0 {
+		log.Fatalf("-auth-token-ttl must be positive")
+	}
+	if *oauthApply && *noFrontend {
+		log.Fatalf("-oauth-apply needs the frontend, which -no-frontend disables")
+	}
+	if *csrf && *noFrontend {
+		log.Fatalf("-csrf needs the frontend, which -no-frontend disables")
+	}
+	if *workdayFlow && *noFrontend {
+		log.Fatalf("-workday-flow needs the frontend, which -no-frontend disables")
+	}
+	if *workdayFlow && (*applyWizard || *oauthApply) {
+		log.Fatalf("-workday-flow cannot be used with -apply-wizard or -oauth-apply")
+	}
+	if *htmlVariants && *noFrontend {
+		log.Fatalf("-html-variants needs the frontend, which -no-frontend disables")
+	}
+	if *htmlVariants && (*applyWizard || *workdayFlow) {
+		log.Fatalf("-html-variants cannot be used with -apply-wizard or -workday-flow")
+	}
+	if *honeypot != "" && !slices.Contains(models.HoneypotModes, *honeypot) {
+		log.Fatalf("Unknown -honeypot %q (valid: %s)", *honeypot, strings.Join(models.HoneypotModes, ", "))
+	}
+	if *challengeKind != "" && !slices.Contains(models.ChallengeKinds, *challengeKind) {
+		log.Fatalf("Unknown -challenge %q (valid: %s)", *challengeKind, strings.Join(models.ChallengeKinds, ", "))
+	}
+	if *challengeDifficulty < 1 || *challengeDifficulty > challenge.MaxDifficulty {
+		log.Fatalf("Invalid -challenge-difficulty %d (must be between 1 and %d)", *challengeDifficulty, challenge.MaxDifficulty)
+	}
+	if !slices.Contains(emulate.Profiles, *atsProfile) {
+		log.Fatalf("Unknown -ats-profile %q (valid: %s)", *atsProfile, strings.Join(emulate.Profiles, ", "))
+	}
+	if *problemJSON && *atsProfile != emulate.ProfileNative {
+		log.Fatalf("-problem-json and -ats-profile=%s cannot be used together", *atsProfile)
+	}
+	switch *rateLimitAlgorithm {
+	case middleware.FixedWindow, middleware.SlidingWindow:
+	default:
+		log.Fatalf("Unknown -rate-limit-algorithm %q (valid: %s, %s)", *rateLimitAlgorithm, middleware.FixedWindow, middleware.SlidingWindow)
+	}
+	var scenario *middleware.FailureScenario
+	if *failureScenario != "" {
+		var err error
+		if scenario, err = middleware.LoadFailureScenario(*failureScenario); err != nil {
+			log.Fatalf("Invalid -failure-scenario:\n%v", err)
+		}
+		log.Printf("🧪 Loaded failure scenario with rules: %s", strings.Join(scenario.Names(), ", "))
+	}
+	if *malformedRate < 0 || *malformedRate > 1 {
+		log.Fatalf("Invalid -malformed-rate %v (must be between 0.0 and 1.0)", *malformedRate)
+	}
+	failureRouteRates, err := parseFailureRoutes(*failureRoutes)
+	if err != nil {
+		log.Fatalf("Invalid -failure-routes: %v", err)
+	}
+	latencies, err := parseLatency(*latency)
+	if err != nil {
+		log.Fatalf("Invalid -latency: %v", err)
+	}
+	endpointRateLimits, err := parseEndpointLimits(*endpointLimits)
+	if err != nil {
+		log.Fatalf("Invalid -endpoint-rate-limits: %v", err)
+	}
+	limitKey, err := middleware.ParseKeyFunc(*rateLimitKey)
+	if err != nil {
+		log.Fatalf("Invalid -rate-limit-key %q: %v", *rateLimitKey, err)
+	}
+	loc, err := time.LoadLocation(*timezone)
+	if err != nil {
+		log.Fatalf("Invalid -timezone %q: %v", *timezone, err)
+	}
+	// Report every problem with the jobs up front rather than failing on the first
+	var seedJobs []models.Job
+	if *jobsFile != "" && *generateJobs > 0 {
+		log.Fatalf("-jobs-file and -generate-jobs cannot be used together")
+	}
+	if *generateJobs < 0 {
+		log.Fatalf("Invalid -generate-jobs %d (must not be negative)", *generateJobs)
+	}
+	switch {
+	case *generateJobs > 0:
+		seedJobs = data.GenerateJobs(*generateJobs, random.New("jobs"), time.Now())
+		log.Printf("🎰 Generated %d jobs", len(seedJobs))
+	case *jobsFile != "":
+		seedJobs, err = data.LoadJobsFile(*jobsFile, loc)
+		if err != nil {
+			log.Fatalf("Invalid -jobs-file %q:\n%v", *jobsFile, err)
+		}
+		log.Printf("📂 Loaded %d jobs from %s", len(seedJobs), *jobsFile)
+	default:
+		if _, err := store.ParseJobDates(data.GetSeedJobs(), loc); err != nil {
+			log.Fatalf("Seed jobs have malformed dates:\n%v", err)
+		}
+	}
+	if *trapJobs {
+		seedJobs = data.WithTrapJobs(seedJobs)
+		log.Printf("🪤 Mixed %d trap jobs into the catalogue", len(data.GetTrapJobs()))
+	}
+	limits := models.ApplicationLimits{
+		ApplicantName:      *maxName,
+		Resume:             *maxResume,
+		CoverLetter:        *maxCoverLetter,
+		CustomAnswer:       *maxAnswer,
+		CustomAnswersTotal: *maxAnswers,
+	}
+	emailRules := emailaddr.Rules{
+		AllowUnicodeLocal: *unicodeEmail,
+		BlockedDomains:    splitList(*blockedEmailDomains),
+	}
+	if *propagationDelay < 0 {
+		log.Fatalf("Invalid -propagation-delay %s (must not be negative)", *propagationDelay)
+	}
+	if *rejectionRate < 0 || *rejectionRate > 1 {
+		log.Fatalf("Invalid -rejection-rate %v (must be between 0.0 and 1.0)", *rejectionRate)
+	}
+	reviewConfig := review.Config{
+		ReviewDelay:   *reviewDelay,
+		DecisionDelay: *decisionDelay,
+		RejectionRate: *rejectionRate,
+	}
+	if *marketInterval < 0 {
+		log.Fatalf("Invalid -market-interval %s (must not be negative)", *marketInterval)
+	}
+	for _, rate := range []struct {
+		flag  string
+		value float64
+	}{
+		{"market-post-rate", *marketPostRate},
+		{"market-edit-rate", *marketEditRate},
+		{"market-close-rate", *marketCloseRate},
+	} {
+		if rate.value < 0 || rate.value > 1 {
+			log.Fatalf("Invalid -%s %v (must be between 0.0 and 1.0)", rate.flag, rate.value)
+		}
+	}
+	if *marketEditRate+*marketCloseRate > 1 {
+		log.Fatalf("Invalid -market-edit-rate and -market-close-rate (must add up to at most 1.0)")
+	}
+	marketConfig := market.Config{
+		Interval:  *marketInterval,
+		PostRate:  *marketPostRate,
+		EditRate:  *marketEditRate,
+		CloseRate: *marketCloseRate,
+	}
+	phoneCountryCode := strings.TrimPrefix(*phoneCountry, "+")
+
+	var persistence store.Persistence
+	var journal *store.FilePersistence
+	switch *storage {
+	case "memory":
+	case "file":
+		p, err := store.OpenFilePersistence(*dbPath)
+		if err != nil {
+			log.Fatalf("Failed to open -db-path %q: %v", *dbPath, err)
+		}
+		persistence, journal = p, p
+	case "sqlite":
+		// The module vendors no SQLite driver and builds without cgo, so
+		// the journal is the durable backend until one is added
+		log.Fatalf("-storage=sqlite is not available: this build has no SQLite driver. Use -storage=file, which keeps a journal at -db-path")
+	default:
+		log.Fatalf("Unknown -storage %q (valid: memory, file)", *storage)
+	}
+
+	switch *mcpTransport {
+	case "", "sse", "stdio":
+	default:
+		log.Fatalf("Unknown MCP transport %q (valid: stdio, sse)", *mcpTransport)
+	}
+	if *mcpTransport == "stdio" && *auth == "required" {
+		// Every tool call would have to act for one unauthenticated client
+		log.Fatalf("-auth=required cannot be enforced over MCP stdio, which carries no tokens; use -mcp=sse")
+	}
+	if *mcpTransport == "stdio" && *challengeKind != "" {
+		log.Fatalf("-challenge cannot be enforced over MCP stdio, which carries no challenge tokens; use -mcp=sse")
+	}
+
 	// Check for environment variable override
 	if envPort := os.Getenv("PORT"); envPort != "" {
 		fmt.Sscanf(envPort, "%d", port)
@@ -42,34 +336,227 @@ func main() {
 		}
 	}
 
+	// Startup problems above are reported as plain text; from here on
+	// everything is logged in the chosen format
+	slog.SetDefault(logger)
+
+	var grpcAddr string
+	if *grpcPort != 0 {
+		grpcAddr = fmt.Sprintf(":%d", *grpcPort)
+	}
+
 	// Configure router
 	config := router.Config{
 		EnableFailureSimulation: *enableFailures,
 		FailureRate:             *failureRate,
 		SlowdownRate:            *slowdownRate,
 		TimeoutRate:             *timeoutRate,
+		MalformedRate:           *malformedRate,
+		FailureRoutes:           failureRouteRates,
+		Latency:                 latencies,
+		FailureScenario:         scenario,
+		DebugFaults:             *debugFaults,
 		GeneralRateLimit:        *generalLimit,
 		ApplicationRateLimit:    *appLimit,
+		EndpointRateLimits:      endpointRateLimits,
+		RateLimitAlgorithm:      *rateLimitAlgorithm,
+		RateLimitKey:            limitKey,
 		TemplatesFS:             templatesFSSub,
+		ProblemJSON:             *problemJSON,
+		Debug:                   *debug,
+		Emulate:                 splitList(*emulations),
+		ATSProfile:              *atsProfile,
+		MCP:                     *mcpTransport == "sse",
+		GRPCAddr:                grpcAddr,
+		PhoneCountryCode:        phoneCountryCode,
+		RelaxedProfileHosts:     *relaxedProfileHosts,
+		Limits:                  limits,
+		EmailRules:              emailRules,
+		Jobs:                    seedJobs,
+		Timezone:                loc,
+		StrictWorkAuthorization: *strictWorkAuth,
+		PropagationDelay:        *propagationDelay,
+		EmailVerification:       *emailVerification,
+		RequireAuth:             *auth == "required",
+		AuthSecret:              *authSecret,
+		AuthTokenTTL:            *authTokenTTL,
+		OAuthClientID:           *oauthClientID,
+		OAuthClientSecret:       *oauthClientSecret,
+		OAuthApply:              *oauthApply,
+		CSRF:                    *csrf,
+		SessionTTL:              *sessionTTL,
+		ApplyWizard:             *applyWizard,
+		WorkdayFlow:             *workdayFlow,
+		HTMLVariants:            *htmlVariants,
+		Honeypot:                *honeypot,
+		Challenge:               *challengeKind,
+		ChallengeDifficulty:     *challengeDifficulty,
+		ChallengeDelay:          *challengeDelay,
+		StrictBinding:           *strictBinding,
+		Persistence:             persistence,
+		AdminToken:              *adminToken,
+		Record:                  *record,
+		Review:                  reviewConfig,
+		Market:                  marketConfig,
+	}
+
+	if *mcpTransport == "stdio" {
+		// stdout carries the protocol, so nothing else may be printed there
+		log.Printf("Serving MCP tools over stdio")
+		jobStore, appStore, err := router.NewStores(context.Background(), config)
+		if err != nil {
+			log.Fatalf("Failed to create stores: %v", err)
+		}
+		mcpHandler := handlers.NewMCPHandler(jobStore, appStore, store.NewApplicantStore(), handlers.NewSubmitGuard(nil, config.ApplyWizard))
+		if err := mcpHandler.ServeStdio(context.Background(), os.Stdin, os.Stdout); err != nil {
+			log.Fatalf("MCP server failed: %v", err)
+		}
+		return
 	}
 
-	// Setup and run router
-	r := router.SetupRouter(config)
+	// Stop on Ctrl-C or SIGTERM, letting requests in flight finish
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Start server
+	addr := fmt.Sprintf(":%d", *port)
+	server := sandbox.NewServer(config, addr)
+	if err := server.Start(context.Background()); err != nil {
+		log.Fatalf("Failed to start server: %v", err)
+	}
 
 	// Print startup banner
 	printBanner(*port, config)
 
-	// Start server
-	addr := fmt.Sprintf(":%d", *port)
 	log.Printf("🚀 Job Portal Sandbox is running on http://localhost%s", addr)
 	if config.TemplatesFS != nil {
 		log.Printf("🌐 Frontend available at http://localhost%s/", addr)
 	}
 	log.Printf("📋 API documentation available at http://localhost%s/api", addr)
+	if grpcAddr != "" {
+		log.Printf("🔌 gRPC service %s available at localhost%s", handlers.GRPCService, grpcAddr)
+	}
 
-	if err := r.Run(addr); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	<-ctx.Done()
+	stop()
+	log.Printf("🛑 Shutting down...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Fatalf("Server stopped: %v", err)
+	}
+	if journal != nil {
+		if err := journal.Close(); err != nil {
+			log.Fatalf("Closing -db-path %q: %v", *dbPath, err)
+		}
+	}
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseEndpointLimits reads per-route rate limits such as
+// "GET /api/jobs=200,GET /api/applications/:id=600"
+func parseEndpointLimits(value string) (map[string]int, error) {
+	limits := make(map[string]int)
+	for _, item := range splitList(value) {
+		route, rate, found := strings.Cut(item, "=")
+		method, path, spaced := strings.Cut(strings.TrimSpace(route), " ")
+		if !found || !spaced || method != strings.ToUpper(method) || !strings.HasPrefix(path, "/") {
+			return nil, fmt.Errorf("%q is not METHOD /path=limit", item)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(rate))
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("%q: limit must be a positive number of requests per minute", item)
+		}
+		limits[method+" "+path] = n
+	}
+	return limits, nil
+}
+
+// parseFailureRoutes reads per-route failure rates such as
+// "GET /api/jobs failure=0.1,GET /api/jobs/search timeout=0.2 slowdown=0.1"
+func parseFailureRoutes(value string) (map[string]middleware.FailureRates, error) {
+	routes := make(map[string]middleware.FailureRates)
+	for _, item := range splitList(value) {
+		fields := strings.Fields(item)
+		if len(fields) < 3 || !middleware.IsRouteName(fields[0]+" "+fields[1]) {
+			return nil, fmt.Errorf("%q is not METHOD /path kind=rate...", item)
+		}
+		var rates middleware.FailureRates
+		for _, field := range fields[2:] {
+			kind, value, _ := strings.Cut(field, "=")
+			rate, err := strconv.ParseFloat(value, 64)
+			if err != nil || rate < 0 || rate > 1 {
+				return nil, fmt.Errorf("%q: %s is not a rate between 0.0 and 1.0", item, field)
+			}
+			switch kind {
+			case "failure":
+				rates.FailureRate = rate
+			case "slowdown":
+				rates.SlowdownRate = rate
+			case "timeout":
+				rates.TimeoutRate = rate
+			case "malformed":
+				rates.MalformedRate = rate
+			default:
+				return nil, fmt.Errorf("%q: unknown rate %q (valid: failure, slowdown, timeout, malformed)", item, kind)
+			}
+		}
+		routes[fields[0]+" "+fields[1]] = rates
+	}
+	return routes, nil
+}
+
+// parseLatency reads per-route latency distributions such as
+// "GET /api/jobs lognormal p50=80ms p95=400ms,* normal p50=20ms p99=100ms"
+func parseLatency(value string) (map[string]middleware.LatencyDistribution, error) {
+	latencies := make(map[string]middleware.LatencyDistribution)
+	for _, item := range splitList(value) {
+		fields := strings.Fields(item)
+		route := middleware.LatencyAllRoutes
+		if len(fields) > 0 && fields[0] != middleware.LatencyAllRoutes {
+			if len(fields) < 2 || !middleware.IsRouteName(fields[0]+" "+fields[1]) {
+				return nil, fmt.Errorf("%q is not METHOD /path distribution p50=... or * distribution p50=...", item)
+			}
+			route = fields[0] + " " + fields[1]
+			fields = fields[1:]
+		}
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("%q: needs a distribution and percentiles", item)
+		}
+		distribution := middleware.LatencyDistribution{Distribution: fields[1]}
+		for _, field := range fields[2:] {
+			name, value, _ := strings.Cut(field, "=")
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("%q: %s is not a duration such as 250ms", item, field)
+			}
+			switch name {
+			case "p50":
+				distribution.P50 = d
+			case "p95":
+				distribution.P95 = d
+			case "p99":
+				distribution.P99 = d
+			default:
+				return nil, fmt.Errorf("%q: unknown percentile %q (valid: p50, p95, p99)", item, name)
+			}
+		}
+		if err := distribution.Validate(); err != nil {
+			return nil, fmt.Errorf("%q: %v", item, err)
+		}
+		latencies[route] = distribution
 	}
+	return latencies, nil
 }
 
 func printBanner(port int, config router.Config) {
@@ -101,14 +588,102 @@ func printBanner(port int, config router.Config) {
 	fmt.Printf("Configuration:\n")
 	fmt.Printf("  • Port: %d\n", port)
 	fmt.Printf("  • Frontend: %v\n", config.TemplatesFS != nil)
+	if config.GRPCAddr != "" {
+		fmt.Printf("  • gRPC: %s on %s\n", handlers.GRPCService, config.GRPCAddr)
+	}
 	fmt.Printf("  • Failure Simulation: %v\n", config.EnableFailureSimulation)
 	if config.EnableFailureSimulation {
 		fmt.Printf("    - Failure Rate: %.1f%%\n", config.FailureRate*100)
 		fmt.Printf("    - Slowdown Rate: %.1f%%\n", config.SlowdownRate*100)
 		fmt.Printf("    - Timeout Rate: %.1f%%\n", config.TimeoutRate*100)
+		if config.MalformedRate > 0 {
+			fmt.Printf("    - Malformed Rate: %.1f%%\n", config.MalformedRate*100)
+		}
+		for _, route := range slices.Sorted(maps.Keys(config.FailureRoutes)) {
+			rates := config.FailureRoutes[route]
+			fmt.Printf("    - %s: %.1f%% failures, %.1f%% slowdowns, %.1f%% timeouts, %.1f%% malformed\n", route, rates.FailureRate*100, rates.SlowdownRate*100, rates.TimeoutRate*100, rates.MalformedRate*100)
+		}
+	}
+	if len(config.Latency) > 0 {
+		fmt.Printf("  • Simulated Latency:\n")
+		for _, route := range slices.Sorted(maps.Keys(config.Latency)) {
+			fmt.Printf("    - %s: %s\n", route, config.Latency[route])
+		}
+	}
+	if config.PropagationDelay > 0 {
+		fmt.Printf("  • Propagation Delay: %s\n", config.PropagationDelay)
+	}
+	if config.EmailVerification {
+		fmt.Printf("  • Email Verification: applications wait for the emailed code\n")
+	}
+	if config.RequireAuth {
+		fmt.Printf("  • Applicant Auth: required (tokens valid for %s)\n", config.AuthTokenTTL)
+	}
+	if config.OAuthApply {
+		fmt.Printf("  • OAuth Apply: the application form needs Sign in with SandboxID\n")
+	}
+	if config.ApplyWizard {
+		fmt.Printf("  • Apply Wizard: applications are submitted step by step through drafts\n")
+	}
+	if config.WorkdayFlow {
+		fmt.Printf("  • Workday Flow: the application form needs a careers site account and six pages\n")
+	}
+	if config.HTMLVariants {
+		fmt.Printf("  • HTML Variants: the application form is laid out differently for each browser\n")
+	}
+	traps := 0
+	for _, job := range config.Jobs {
+		if job.Trap != "" {
+			traps++
+		}
+	}
+	if traps > 0 {
+		fmt.Printf("  • Trap jobs: %d scam postings, scored in run reports\n", traps)
+	}
+	switch config.Honeypot {
+	case models.HoneypotFlag:
+		fmt.Printf("  • Honeypot: submissions filling in %s are flagged\n", strings.Join(models.HoneypotFields, " or "))
+	case models.HoneypotShadow:
+		fmt.Printf("  • Honeypot: submissions filling in %s are silently dropped\n", strings.Join(models.HoneypotFields, " or "))
+	}
+	switch config.Challenge {
+	case models.ChallengePuzzle:
+		fmt.Printf("  • Challenge: submissions need a solved puzzle's token\n")
+	case models.ChallengeProofOfWork:
+		fmt.Printf("  • Challenge: submissions need a proof-of-work token (%d zero bits)\n", config.ChallengeDifficulty)
+	case models.ChallengeDelay:
+		fmt.Printf("  • Challenge: submissions need a handshake token (%s delay)\n", config.ChallengeDelay)
+	}
+	if config.CSRF {
+		fmt.Printf("  • CSRF: application form posts need the session's token (sessions last %s)\n", config.SessionTTL)
+	}
+	if config.DebugFaults {
+		fmt.Printf("  • Fault Injection: X-Simulate header honored\n")
+	}
+	if config.Review.ReviewDelay > 0 {
+		fmt.Printf("  • Status Progression: review after %s, decision after %s\n", config.Review.ReviewDelay, config.Review.DecisionDelay)
+		fmt.Printf("    - Rejection Rate: %.1f%%\n", config.Review.RejectionRate*100)
+	}
+	if config.Market.Interval > 0 {
+		fmt.Printf("  • Job Market: moves every %s\n", config.Market.Interval)
+		fmt.Printf("    - Post Rate: %.1f%%, Edit Rate: %.1f%%, Close Rate: %.1f%%\n", config.Market.PostRate*100, config.Market.EditRate*100, config.Market.CloseRate*100)
+	}
+	if config.MCP {
+		fmt.Printf("  • MCP: http://localhost:%d/mcp/sse\n", port)
+	}
+	if len(config.Emulate) > 0 {
+		fmt.Printf("  • Emulating: %s\n", strings.Join(config.Emulate, ", "))
+	}
+	if config.ATSProfile != emulate.ProfileNative {
+		fmt.Printf("  • ATS Profile: %s (errors use its error body)\n", config.ATSProfile)
 	}
 	fmt.Printf("  • Rate Limits:\n")
 	fmt.Printf("    - General: %d req/min\n", config.GeneralRateLimit)
 	fmt.Printf("    - Applications: %d req/min\n", config.ApplicationRateLimit)
+	routes := slices.Sorted(maps.Keys(config.EndpointRateLimits))
+	for _, route := range routes {
+		fmt.Printf("    - %s: %d req/min\n", route, config.EndpointRateLimits[route])
+	}
+	fmt.Printf("    - Algorithm: %s window\n", config.RateLimitAlgorithm)
 	fmt.Println()
 }
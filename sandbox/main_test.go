@@ -0,0 +1,277 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// runMainEnv makes the test binary run main instead of the tests, so tests
+// can start the sandbox as a child process with their own flags
+const runMainEnv = "SANDBOX_TEST_RUN_MAIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(runMainEnv) == "1" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// mcpClient speaks newline-delimited JSON-RPC to a sandbox started with
+// -mcp stdio
+type mcpClient struct {
+	t      *testing.T
+	stdin  io.WriteCloser
+	stdout *bufio.Scanner
+	nextID int
+}
+
+// startMCPStdio starts the sandbox as a child process serving MCP on its
+// stdin and stdout
+func startMCPStdio(t *testing.T, args ...string) *mcpClient {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], append([]string{"-mcp", "stdio"}, args...)...)
+	cmd.Env = append(os.Environ(), runMainEnv+"=1")
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testing.Verbose() {
+		cmd.Stderr = os.Stderr
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		// Closing stdin ends the session, and with it the process
+		stdin.Close()
+		done := make(chan error, 1)
+		go func() { done <- cmd.Wait() }()
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("sandbox exited with %v", err)
+			}
+		case <-time.After(10 * time.Second):
+			cmd.Process.Kill()
+			t.Error("sandbox did not exit when stdin closed")
+		}
+	})
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 4<<20)
+	return &mcpClient{t: t, stdin: stdin, stdout: scanner}
+}
+
+// call sends a request and decodes the result of its response into result
+func (c *mcpClient) call(method string, params, result interface{}) {
+	c.t.Helper()
+	c.nextID++
+	line, err := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": c.nextID, "method": method, "params": params})
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	if _, err := c.stdin.Write(append(line, '\n')); err != nil {
+		c.t.Fatalf("%s: %v", method, err)
+	}
+
+	if !c.stdout.Scan() {
+		c.t.Fatalf("%s: no response: %v", method, c.stdout.Err())
+	}
+	var resp struct {
+		ID     int             `json:"id"`
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(c.stdout.Bytes(), &resp); err != nil {
+		c.t.Fatalf("%s: stdout line %q is not JSON-RPC: %v", method, c.stdout.Text(), err)
+	}
+	if resp.ID != c.nextID {
+		c.t.Fatalf("%s: response id %d, want %d", method, resp.ID, c.nextID)
+	}
+	if resp.Error != nil {
+		c.t.Fatalf("%s: error %d: %s", method, resp.Error.Code, resp.Error.Message)
+	}
+	if result != nil {
+		if err := json.Unmarshal(resp.Result, result); err != nil {
+			c.t.Fatalf("%s: decoding result %s: %v", method, resp.Result, err)
+		}
+	}
+}
+
+// notify sends a notification, which gets no response
+func (c *mcpClient) notify(method string) {
+	c.t.Helper()
+	line, _ := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "method": method})
+	if _, err := c.stdin.Write(append(line, '\n')); err != nil {
+		c.t.Fatalf("%s: %v", method, err)
+	}
+}
+
+// toolResult is the result of tools/call
+type toolResult struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	StructuredContent json.RawMessage `json:"structuredContent"`
+	IsError           bool            `json:"isError"`
+}
+
+// callTool runs a tool and decodes its structured content into v
+func (c *mcpClient) callTool(name string, arguments, v interface{}) toolResult {
+	c.t.Helper()
+	var result toolResult
+	c.call("tools/call", map[string]interface{}{"name": name, "arguments": arguments}, &result)
+	if len(result.Content) != 1 || result.Content[0].Type != "text" || result.Content[0].Text != string(result.StructuredContent) {
+		c.t.Errorf("%s: content %+v does not repeat the structured content as text", name, result.Content)
+	}
+	if v != nil {
+		if err := json.Unmarshal(result.StructuredContent, v); err != nil {
+			c.t.Fatalf("%s: decoding %s: %v", name, result.StructuredContent, err)
+		}
+	}
+	return result
+}
+
+// writeJobsFile writes a catalogue holding one job that stays open
+func writeJobsFile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	jobs := `[{
+		"id": "job_stdio",
+		"title": "Backend Engineer",
+		"company": "Acme",
+		"description": "Build the services behind Acme's storefront.",
+		"requirements": ["Go"],
+		"location": "Berlin, Germany",
+		"salary": "$120,000 - $150,000",
+		"experience_required": 2,
+		"job_type": "full-time",
+		"posted_at": "2026-01-15T10:00:00Z",
+		"application_deadline": "2099-12-31T23:59:59Z"
+	}]`
+	if err := os.WriteFile(path, []byte(jobs), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestMCPStdio drives the sandbox started with -mcp stdio as an MCP client
+// would: handshake, tool discovery, then searching, applying and checking
+// the application, with the same store settings as the HTTP server
+func TestMCPStdio(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts the sandbox as a child process")
+	}
+	c := startMCPStdio(t, "-jobs-file", writeJobsFile(t), "-honeypot", "shadow")
+
+	var initialized struct {
+		ProtocolVersion string `json:"protocolVersion"`
+		ServerInfo      struct {
+			Name string `json:"name"`
+		} `json:"serverInfo"`
+		Capabilities map[string]interface{} `json:"capabilities"`
+	}
+	c.call("initialize", map[string]interface{}{
+		"protocolVersion": "2025-06-18",
+		"clientInfo":      map[string]string{"name": "stdio-test", "version": "1.0"},
+		"capabilities":    map[string]interface{}{},
+	}, &initialized)
+	if initialized.ProtocolVersion != "2025-06-18" || initialized.ServerInfo.Name != "job-portal-sandbox" || initialized.Capabilities["tools"] == nil {
+		t.Errorf("initialize returned %+v", initialized)
+	}
+	c.notify("notifications/initialized")
+
+	var listed struct {
+		Tools []struct {
+			Name        string                 `json:"name"`
+			InputSchema map[string]interface{} `json:"inputSchema"`
+		} `json:"tools"`
+	}
+	c.call("tools/list", map[string]interface{}{}, &listed)
+	tools := make(map[string]bool)
+	for _, tool := range listed.Tools {
+		tools[tool.Name] = tool.InputSchema["type"] == "object"
+	}
+	for _, name := range []string{"search_jobs", "get_job", "get_requirements", "submit_application", "check_application_status"} {
+		if !tools[name] {
+			t.Errorf("tools/list lacks %s with an object input schema", name)
+		}
+	}
+
+	var found struct {
+		Jobs []struct {
+			ID string `json:"id"`
+		} `json:"jobs"`
+		Total int `json:"total"`
+	}
+	c.callTool("search_jobs", map[string]interface{}{"query": "backend"}, &found)
+	if found.Total != 1 || len(found.Jobs) != 1 || found.Jobs[0].ID != "job_stdio" {
+		t.Fatalf("search_jobs found %+v, want the one job from -jobs-file", found)
+	}
+
+	application := map[string]interface{}{
+		"job_id":          "job_stdio",
+		"applicant_name":  "Vic Tester",
+		"applicant_email": "vic@example.com",
+		"resume":          "Ten years of building web services in Go and Python.",
+	}
+	var submitted struct {
+		ConfirmationID string `json:"confirmation_id"`
+		Status         string `json:"status"`
+	}
+	if result := c.callTool("submit_application", application, &submitted); result.IsError || submitted.ConfirmationID == "" {
+		t.Fatalf("submit_application returned %s", result.StructuredContent)
+	}
+
+	var status struct {
+		ApplicationID string `json:"application_id"`
+		Status        string `json:"status"`
+	}
+	c.callTool("check_application_status", map[string]string{"application_id": submitted.ConfirmationID}, &status)
+	if status.ApplicationID != submitted.ConfirmationID || status.Status != "received" {
+		t.Errorf("check_application_status returned %+v", status)
+	}
+
+	// Tool errors carry the REST error code and status
+	var failure struct {
+		Error string `json:"error"`
+		Code  int    `json:"code"`
+	}
+	if result := c.callTool("submit_application", application, &failure); !result.IsError || failure.Error != "duplicate_application" || failure.Code != 409 {
+		t.Errorf("a second application returned %s, want a duplicate_application tool error", result.StructuredContent)
+	}
+
+	// -honeypot shadow applies over stdio too: a bot filling in a decoy
+	// field is answered as accepted but nothing is stored
+	bot := map[string]interface{}{
+		"job_id":          "job_stdio",
+		"applicant_name":  "Bot",
+		"applicant_email": "bot@example.com",
+		"resume":          "Ten years of building web services in Go and Python.",
+		"fax":             "555-0100",
+	}
+	var shadowed struct {
+		ConfirmationID string `json:"confirmation_id"`
+	}
+	if result := c.callTool("submit_application", bot, &shadowed); result.IsError || shadowed.ConfirmationID == "" {
+		t.Fatalf("the honeypot submission returned %s, want it answered as accepted", result.StructuredContent)
+	}
+	if result := c.callTool("check_application_status", map[string]string{"application_id": shadowed.ConfirmationID}, &failure); !result.IsError || failure.Error != "application_not_found" {
+		t.Errorf("the honeypot submission was stored: %s", result.StructuredContent)
+	}
+}
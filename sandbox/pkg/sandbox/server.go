@@ -0,0 +1,213 @@
+// Package sandbox runs the job portal sandbox inside another Go program,
+// such as a test suite exercising an agent against it:
+//
+//	srv := sandbox.NewServer(sandbox.DefaultConfig(), "127.0.0.1:0")
+//	if err := srv.Start(ctx); err != nil {
+//		t.Fatal(err)
+//	}
+//	defer srv.Shutdown(context.Background())
+//	resp, err := http.Get(srv.URL() + "/api/jobs")
+//
+// Each Server has its own jobs, applications and rate limits, so tests can
+// run servers side by side without seeing each other's state.
+package sandbox
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/respond"
+	"github.com/AkshatRai07/AI_Impact_Summit_26/internal/router"
+)
+
+// Config configures a Server; see DefaultConfig
+type Config = router.Config
+
+// DefaultConfig returns the configuration the sandbox binary runs with when
+// given no flags, without the frontend
+func DefaultConfig() Config {
+	return router.DefaultConfig()
+}
+
+// Server serves the sandbox API over HTTP, and the JobPortal gRPC service
+// on a port of its own when config.GRPCAddr is set
+type Server struct {
+	config Config
+	addr   string
+
+	mu       sync.Mutex
+	listener net.Listener
+	http     *http.Server
+	cancel   context.CancelFunc
+	served   chan error // Receives the error that stopped Serve
+
+	// The gRPC server, when config.GRPCAddr is set
+	grpcListener net.Listener
+	grpc         *http.Server
+	grpcServed   chan error
+
+	stop    sync.Once
+	stopErr error
+}
+
+// NewServer creates a server that will listen on addr. An addr with port 0,
+// such as "127.0.0.1:0", picks a free port; Addr reports which.
+func NewServer(config Config, addr string) *Server {
+	return &Server{config: config, addr: addr}
+}
+
+// Start listens and begins serving in the background, returning once
+// requests can be made. The server runs until Shutdown is called, or closes
+// at once when ctx is done; either way its background work, such as
+// automatic review, stops.
+func (s *Server) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.http != nil {
+		return errors.New("sandbox: server already started")
+	}
+
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	var grpcListener net.Listener
+	if s.config.GRPCAddr != "" {
+		if grpcListener, err = net.Listen("tcp", s.config.GRPCAddr); err != nil {
+			listener.Close()
+			return err
+		}
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	s.listener = listener
+	s.cancel = cancel
+	handlers := router.SetupHandlers(ctx, s.config)
+	// Event streams end as soon as Shutdown starts, so they don't hold it
+	// up; other requests keep running until they finish or it gives up
+	streams, stopStreams := context.WithCancel(ctx)
+	base := respond.WithDraining(ctx, streams)
+	s.http = &http.Server{
+		Handler:     handlers.API,
+		BaseContext: func(net.Listener) context.Context { return base },
+	}
+	s.http.RegisterOnShutdown(stopStreams)
+	s.served = make(chan error, 1)
+	if grpcListener != nil {
+		// gRPC clients speak HTTP/2 without TLS to a local sandbox
+		protocols := new(http.Protocols)
+		protocols.SetUnencryptedHTTP2(true)
+		s.grpcListener = grpcListener
+		s.grpc = &http.Server{
+			Handler:     handlers.GRPC,
+			Protocols:   protocols,
+			BaseContext: func(net.Listener) context.Context { return base },
+		}
+		s.grpc.RegisterOnShutdown(stopStreams)
+		s.grpcServed = make(chan error, 1)
+		grpcSrv, grpcServed := s.grpc, s.grpcServed
+		go func() { grpcServed <- grpcSrv.Serve(grpcListener) }()
+	}
+
+	srv, grpcSrv := s.http, s.grpc
+	done := make(chan struct{})
+	go func() {
+		s.served <- srv.Serve(listener)
+		close(done)
+	}()
+	go func() {
+		select {
+		case <-parent.Done():
+			cancel()
+			srv.Close()
+			if grpcSrv != nil {
+				grpcSrv.Close()
+			}
+		case <-done:
+		}
+	}()
+	return nil
+}
+
+// Addr returns the address the server listens on, such as
+// "127.0.0.1:54321", or the address it was created with before Start
+func (s *Server) Addr() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.listener != nil {
+		return s.listener.Addr().String()
+	}
+	return s.addr
+}
+
+// GRPCAddr returns the address the gRPC service listens on, or "" when
+// config.GRPCAddr is not set
+func (s *Server) GRPCAddr() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.grpcListener != nil {
+		return s.grpcListener.Addr().String()
+	}
+	return s.config.GRPCAddr
+}
+
+// URL returns the base URL of the running server, such as
+// "http://127.0.0.1:54321"
+func (s *Server) URL() string {
+	return "http://" + s.Addr()
+}
+
+// Shutdown stops the server, waiting until ctx is done for requests in
+// flight to finish. Open event streams end straight away; other requests
+// keep their context until they finish, or until ctx is done, when they
+// and the server's background work are cancelled. It returns any error
+// that stopped the server early; later calls return the same.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	srv, cancel, served := s.http, s.cancel, s.served
+	grpcSrv, grpcServed := s.grpc, s.grpcServed
+	s.mu.Unlock()
+
+	if srv == nil {
+		return nil
+	}
+
+	s.stop.Do(func() {
+		shutdown := make(chan error, 2)
+		go func() { shutdown <- srv.Shutdown(ctx) }()
+		servers := 1
+		if grpcSrv != nil {
+			go func() { shutdown <- grpcSrv.Shutdown(ctx) }()
+			servers++
+		}
+		var err error
+		for range servers {
+			err = cmp.Or(err, <-shutdown)
+		}
+		// Only now, with the requests finished or out of time, is
+		// everything else cancelled
+		cancel()
+		if err != nil {
+			s.stopErr = err
+			return
+		}
+		if grpcSrv != nil {
+			if err := <-grpcServed; !errors.Is(err, http.ErrServerClosed) {
+				s.stopErr = err
+				return
+			}
+		}
+		if err := <-served; !errors.Is(err, http.ErrServerClosed) {
+			s.stopErr = err
+		}
+	})
+	return s.stopErr
+}
@@ -0,0 +1,111 @@
+package sandbox
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// startServer starts a quiet server on a free port that honors X-Simulate
+func startServer(t *testing.T) *Server {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	config := DefaultConfig()
+	config.DebugFaults = true
+	config.Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	srv := NewServer(config, "127.0.0.1:0")
+	if err := srv.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return srv
+}
+
+// TestShutdownLetsRequestsFinish checks Shutdown ends open event streams
+// at once but waits for other requests in flight, without cancelling them
+func TestShutdownLetsRequestsFinish(t *testing.T) {
+	srv := startServer(t)
+
+	// An open event stream
+	events, err := http.Get(srv.URL() + "/api/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer events.Body.Close()
+	if _, err := bufio.NewReader(events.Body).ReadString('\n'); err != nil {
+		t.Fatal(err)
+	}
+	streamEnded := make(chan struct{})
+	go func() {
+		io.Copy(io.Discard, events.Body)
+		close(streamEnded)
+	}()
+
+	// A request still being served when Shutdown starts
+	slow := make(chan string, 1)
+	go func() {
+		req, _ := http.NewRequest("GET", srv.URL()+"/api/jobs", nil)
+		req.Header.Set("X-Simulate", "slow=500ms")
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			slow <- err.Error()
+			return
+		}
+		defer res.Body.Close()
+		body, _ := io.ReadAll(res.Body)
+		slow <- res.Status + " " + string(body)
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	if err := srv.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Shutdown took %v with an event stream open, want it ended at once", elapsed)
+	}
+	if got := <-slow; !strings.HasPrefix(got, "200 OK {") || !strings.Contains(got, `"jobs"`) {
+		t.Errorf("request in flight during Shutdown got %.100q, want it served", got)
+	}
+	select {
+	case <-streamEnded:
+	case <-time.After(time.Second):
+		t.Error("event stream still open after Shutdown")
+	}
+}
+
+// TestShutdownCancelsAtDeadline checks requests still running when the
+// Shutdown context is done are cancelled, and Shutdown reports why
+func TestShutdownCancelsAtDeadline(t *testing.T) {
+	srv := startServer(t)
+
+	slow := make(chan struct{})
+	go func() {
+		req, _ := http.NewRequest("GET", srv.URL()+"/api/jobs", nil)
+		req.Header.Set("X-Simulate", "slow=10s")
+		if res, err := http.DefaultClient.Do(req); err == nil {
+			res.Body.Close()
+		}
+		close(slow)
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Shutdown: %v, want %v", err, context.DeadlineExceeded)
+	}
+	select {
+	case <-slow:
+	case <-time.After(2 * time.Second):
+		t.Error("request still running 2s after the Shutdown deadline")
+	}
+}
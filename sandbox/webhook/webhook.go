@@ -0,0 +1,106 @@
+// Package webhook signs and verifies the sandbox's webhook deliveries.
+//
+// Every delivery carries an X-Sandbox-Signature header of the form
+//
+//	X-Sandbox-Signature: t=<unix>,v1=<hex>
+//
+// where t is the Unix time the delivery was signed and each v1 is the
+// hex-encoded HMAC-SHA256 of "<t>.<raw body>" keyed with a subscription
+// secret. While a rotated secret is in its grace period the header lists one
+// v1 per valid secret. To verify a delivery:
+//
+//  1. Read the raw request body before decoding it.
+//  2. Parse t and every v1 from the header.
+//  3. Reject the delivery if t is further than the tolerance from now.
+//  4. Compute the expected signature with your secret and compare it to each
+//     v1 in constant time; accept if any matches.
+//
+// Verify implements exactly these steps.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeader is the header carrying a delivery's signature
+const SignatureHeader = "X-Sandbox-Signature"
+
+// DefaultTolerance is the replay window Verify callers should use
+const DefaultTolerance = 5 * time.Minute
+
+// Verification errors
+var (
+	ErrInvalidHeader     = errors.New("webhook: malformed signature header")
+	ErrTimestampExpired  = errors.New("webhook: timestamp outside the tolerance window")
+	ErrSignatureMismatch = errors.New("webhook: no signature matches")
+)
+
+// Sign returns the signature header value for body signed at timestamp with
+// each of secrets
+func Sign(body []byte, timestamp time.Time, secrets ...string) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+	parts := []string{"t=" + t}
+	for _, secret := range secrets {
+		parts = append(parts, "v1="+hex.EncodeToString(signature(secret, t, body)))
+	}
+	return strings.Join(parts, ",")
+}
+
+// Verify checks a signature header against the raw body. The delivery is
+// accepted when its timestamp is within tolerance of now and any v1 signature
+// matches any of secrets, so consumers can pass both secrets while rotating.
+func Verify(header string, body []byte, tolerance time.Duration, secrets ...string) error {
+	var t string
+	var signatures [][]byte
+	for _, part := range strings.Split(header, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			return ErrInvalidHeader
+		}
+		switch key {
+		case "t":
+			t = value
+		case "v1":
+			sig, err := hex.DecodeString(value)
+			if err != nil {
+				return ErrInvalidHeader
+			}
+			signatures = append(signatures, sig)
+		}
+	}
+
+	unix, err := strconv.ParseInt(t, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return ErrInvalidHeader
+	}
+
+	age := time.Since(time.Unix(unix, 0))
+	if age > tolerance || age < -tolerance {
+		return ErrTimestampExpired
+	}
+
+	for _, secret := range secrets {
+		expected := signature(secret, t, body)
+		for _, sig := range signatures {
+			if hmac.Equal(expected, sig) {
+				return nil
+			}
+		}
+	}
+	return ErrSignatureMismatch
+}
+
+// signature computes HMAC-SHA256 over "<t>.<body>"
+func signature(secret, t string, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(t))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
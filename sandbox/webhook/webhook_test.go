@@ -0,0 +1,52 @@
+package webhook
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"id":"evt_1b713a3d","type":"application.status_changed"}`)
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		header  string
+		body    []byte
+		secrets []string
+		want    error
+	}{
+		{"round trip", Sign(body, now, "whsec_a"), body, []string{"whsec_a"}, nil},
+		{"either rotated secret", Sign(body, now, "whsec_old", "whsec_new"), body, []string{"whsec_new"}, nil},
+		{"either consumer secret", Sign(body, now, "whsec_new"), body, []string{"whsec_old", "whsec_new"}, nil},
+		{"tampered body", Sign(body, now, "whsec_a"), []byte(`{"id":"evt_1b713a3d","type":"application.deleted"}`), []string{"whsec_a"}, ErrSignatureMismatch},
+		{"wrong secret", Sign(body, now, "whsec_a"), body, []string{"whsec_b"}, ErrSignatureMismatch},
+		{"old timestamp", Sign(body, now.Add(-DefaultTolerance-time.Minute), "whsec_a"), body, []string{"whsec_a"}, ErrTimestampExpired},
+		{"future timestamp", Sign(body, now.Add(DefaultTolerance+time.Minute), "whsec_a"), body, []string{"whsec_a"}, ErrTimestampExpired},
+		{"no signature", "t=1700000000", body, []string{"whsec_a"}, ErrInvalidHeader},
+		{"not hex", "t=1700000000,v1=zz", body, []string{"whsec_a"}, ErrInvalidHeader},
+		{"empty", "", body, []string{"whsec_a"}, ErrInvalidHeader},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Verify(tt.header, tt.body, DefaultTolerance, tt.secrets...); !errors.Is(err, tt.want) {
+				t.Errorf("Verify(%q) = %v, want %v", tt.header, err, tt.want)
+			}
+		})
+	}
+}
+
+// TestSignatureCoversTimestamp checks a signature cannot be moved to
+// another timestamp to replay a delivery
+func TestSignatureCoversTimestamp(t *testing.T) {
+	body := []byte(`{}`)
+	now := time.Now()
+	_, signature, _ := strings.Cut(Sign(body, now.Add(-time.Hour), "whsec_a"), ",")
+	moved := "t=" + strconv.FormatInt(now.Unix(), 10) + "," + signature
+	if err := Verify(moved, body, DefaultTolerance, "whsec_a"); !errors.Is(err, ErrSignatureMismatch) {
+		t.Errorf("Verify(%q) = %v, want %v", moved, err, ErrSignatureMismatch)
+	}
+}